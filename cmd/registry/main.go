@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"flag"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
@@ -11,12 +12,17 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/modelcontextprotocol/registry/internal/analytics"
 	"github.com/modelcontextprotocol/registry/internal/api"
 	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/build"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/events"
 	"github.com/modelcontextprotocol/registry/internal/model"
 	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/vcs"
+	"github.com/redis/go-redis/v9"
 )
 
 func main() {
@@ -26,13 +32,17 @@ func main() {
 
 	// Show version information if requested
 	if *showVersion {
-		log.Printf("MCP Registry v%s\n", Version)
-		log.Printf("Git commit: %s\n", GitCommit)
-		log.Printf("Build time: %s\n", BuildTime)
+		log.Printf("MCP Registry v%s\n", build.Version)
+		log.Printf("Git commit: %s\n", build.GitCommit)
+		log.Printf("Build time: %s\n", build.BuildTime)
 		return
 	}
 
-	log.Printf("Starting MCP Registry Application v%s (commit: %s)", Version, GitCommit)
+	// Tag every log line with the running version so startup logs identify the deployed build
+	buildInfo := build.Get()
+	log.SetPrefix(fmt.Sprintf("[registry v%s] ", buildInfo.Version))
+	log.Printf("Starting MCP Registry Application v%s (commit: %s, built: %s, %s)",
+		buildInfo.Version, buildInfo.GitCommit, buildInfo.BuildTime, buildInfo.GoVersion)
 
 	var (
 		registryService service.RegistryService
@@ -51,11 +61,23 @@ func main() {
 		return
 	}
 
+	serviceOptions := []service.Option{
+		service.WithVacuumRetention(cfg.VacuumRetention),
+		service.WithAllowedRegistryNames(cfg.AllowedRegistryNames),
+		service.WithMaxPackages(cfg.MaxPackagesPerServer),
+	}
+	if cfg.RequireSemVer {
+		serviceOptions = append(serviceOptions, service.WithRequiredSemVer())
+	}
+	if cfg.VerifyPackageChecksums {
+		serviceOptions = append(serviceOptions, service.WithChecksumVerification())
+	}
+
 	// Initialize services based on environment
 	switch cfg.DatabaseType {
 	case config.DatabaseTypeMemory:
 		db = database.NewMemoryDB(map[string]*model.Server{})
-		registryService = service.NewRegistryServiceWithDB(db)
+		registryService = service.NewRegistryServiceWithDB(db, serviceOptions...)
 	case config.DatabaseTypeMongoDB:
 		// Use MongoDB for real registry service in production/other environments
 		// Create a context with timeout for MongoDB connection
@@ -63,14 +85,20 @@ func main() {
 		defer cancel()
 
 		// Connect to MongoDB
-		db, err = database.NewMongoDB(ctx, cfg.DatabaseURL, cfg.DatabaseName, cfg.CollectionName)
+		var mongoDB *database.MongoDB
+		mongoDB, err = database.NewMongoDB(ctx, cfg.DatabaseURL, cfg.DatabaseName, cfg.CollectionName)
 		if err != nil {
 			log.Printf("Failed to connect to MongoDB: %v", err)
 			return
 		}
+		// Explaining slow queries costs another round trip, so only do it in development
+		if cfg.Environment == "development" && cfg.SlowQueryThreshold > 0 {
+			mongoDB.LogSlowQueries(cfg.SlowQueryThreshold)
+		}
+		db = mongoDB
 
 		// Create registry service with MongoDB
-		registryService = service.NewRegistryServiceWithDB(db)
+		registryService = service.NewRegistryServiceWithDB(db, serviceOptions...)
 		log.Printf("MongoDB database name: %s", cfg.DatabaseName)
 		log.Printf("MongoDB collection name: %s", cfg.CollectionName)
 
@@ -87,6 +115,28 @@ func main() {
 		return
 	}
 
+	// Wrap the registry service with an in-process cache if enabled
+	if cfg.CacheTTL > 0 {
+		registryService = service.NewCachedRegistryService(registryService, cfg.CacheTTL)
+		log.Printf("Registry cache enabled with TTL %s", cfg.CacheTTL)
+	}
+
+	// Broadcast registry changes to event stream subscribers
+	hub := events.NewHub()
+	defer hub.Close()
+	registryService = service.NewEventingRegistryService(registryService, hub)
+
+	// Deliver registry changes to the webhooks of server subscriptions
+	service.NewWebhookDispatcher(db, service.NewWebhookClient()).Start(hub)
+
+	// Keep the GitHub star counts behind sort=stars current
+	if cfg.RepoStatsSyncEnabled {
+		syncCtx, stopSync := context.WithCancel(context.Background())
+		defer stopSync()
+		service.NewRepoStatsSyncer(db, vcs.FetchGitHubStars).Start(syncCtx, cfg.RepoStatsSyncInterval)
+		log.Printf("Repository stats sync enabled every %s", cfg.RepoStatsSyncInterval)
+	}
+
 	// Import seed data if requested (works for both memory and MongoDB)
 	if cfg.SeedImport {
 		log.Println("Importing data...")
@@ -101,10 +151,30 @@ func main() {
 	}
 
 	// Initialize authentication services
-	authService := auth.NewAuthService(cfg)
+	authService := auth.NewAuthService(cfg, auth.WithAPIKeyStore(db))
+
+	// Count server views in Redis for the trending endpoint if configured
+	var trending *analytics.Trending
+	if cfg.RedisURL != "" {
+		redisOptions, err := redis.ParseURL(cfg.RedisURL)
+		if err != nil {
+			log.Printf("Invalid Redis URL: %v", err)
+			return
+		}
+		redisClient := redis.NewClient(redisOptions)
+		defer redisClient.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := redisClient.Ping(ctx).Err(); err != nil {
+			log.Printf("Redis is not reachable, trending servers fall back to recent servers until it is: %v", err)
+		}
+
+		trending = analytics.NewTrending(redisClient, cfg.TrendingSampleRate)
+	}
 
 	// Initialize HTTP server
-	server := api.NewServer(cfg, registryService, authService)
+	server := api.NewServer(cfg, registryService, authService, hub, trending)
 
 	// Start server in a goroutine so it doesn't block signal handling
 	go func() {
@@ -1,13 +0,0 @@
-package main
-
-// Version info for the MCP Registry application
-var (
-	// Version is the current version of the MCP Registry application
-	Version = "0.1.0"
-
-	// BuildTime is the time at which the binary was built
-	BuildTime = "undefined"
-
-	// GitCommit is the git commit that was compiled
-	GitCommit = "undefined"
-)
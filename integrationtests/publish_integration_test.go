@@ -9,9 +9,11 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/model"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	"github.com/stretchr/testify/assert"
@@ -63,6 +65,16 @@ func (m *MockAuthService) GenerateEphemeralTokenForGitHubUser(_ context.Context,
 	return "mock_ephemeral_token_" + githubToken[:10], nil
 }
 
+func (m *MockAuthService) GenerateEphemeralTokenForPAT(ctx context.Context, pat string) (string, error) {
+	// For testing, personal access tokens are treated like any other GitHub token
+	return m.GenerateEphemeralTokenForGitHubUser(ctx, pat)
+}
+
+func (m *MockAuthService) GitHubUserExists(_ context.Context, _ string) (bool, error) {
+	// For testing, every GitHub user exists
+	return true, nil
+}
+
 func (m *MockAuthService) ValidateEphemeralOrOwnerToken(_ context.Context, token string) (bool, *auth.EphemeralTokenClaims, error) {
 	// For testing, accept any token starting with "mock_ephemeral_token_" as valid ephemeral token
 	// and any other non-empty token as valid owner token
@@ -79,6 +91,30 @@ func (m *MockAuthService) ValidateEphemeralOrOwnerToken(_ context.Context, token
 }
 
 // TestPublishIntegration tests the complete flow of publishing a server using the fake service
+func (m *MockAuthService) RefreshEphemeralToken(_ context.Context, token string) (string, time.Time, error) {
+	// For testing, ephemeral tokens are always returned unchanged
+	if strings.HasPrefix(token, "mock_ephemeral_token_") {
+		return token, time.Now().Add(time.Hour), nil
+	}
+	return "", time.Time{}, fmt.Errorf("invalid token")
+}
+
+func (m *MockAuthService) RevokeAllTokensForUser(_ context.Context, _ string, _ time.Time) error {
+	return nil
+}
+
+func (m *MockAuthService) CreateAPIKey(_ context.Context, _, _ string, _ time.Time) (*model.APIKey, string, error) {
+	return nil, "", auth.ErrAPIKeysUnavailable
+}
+
+func (m *MockAuthService) ListAPIKeys(_ context.Context) ([]*model.APIKey, error) {
+	return nil, auth.ErrAPIKeysUnavailable
+}
+
+func (m *MockAuthService) RevokeAPIKey(_ context.Context, _ string) error {
+	return auth.ErrAPIKeysUnavailable
+}
+
 func TestPublishIntegration(t *testing.T) {
 	// Setup fake service and auth service
 	registryService := service.NewFakeRegistryService()
@@ -694,7 +730,7 @@ func TestPublishIntegrationEndToEnd(t *testing.T) {
 
 	t.Run("end-to-end publish and retrieve flow", func(t *testing.T) {
 		// Step 1: Get initial count of servers
-		initialServers, _, err := registryService.List("", 100)
+		initialServers, _, err := registryService.List("", "", database.CursorNext, 100)
 		require.NoError(t, err)
 		initialCount := len(initialServers)
 
@@ -732,7 +768,7 @@ func TestPublishIntegrationEndToEnd(t *testing.T) {
 		require.Equal(t, http.StatusCreated, recorder.Code)
 
 		// Step 3: Verify the count increased
-		updatedServers, _, err := registryService.List("", 100)
+		updatedServers, _, err := registryService.List("", "", database.CursorNext, 100)
 		require.NoError(t, err)
 		assert.Equal(t, initialCount+1, len(updatedServers))
 
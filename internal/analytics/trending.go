@@ -0,0 +1,102 @@
+// Package analytics records server usage, such as detail views, to rank trending servers
+package analytics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/redis/go-redis/v9"
+)
+
+// Trending periods accepted by GetTrending
+const (
+	PeriodDay  = "day"
+	PeriodWeek = "week"
+)
+
+// ErrInvalidPeriod is returned when GetTrending is called with an unknown period
+var ErrInvalidPeriod = errors.New("invalid trending period")
+
+// dailyKeyTTL is how long the view counts of a day are kept; a week plus a day so weekly rankings are complete
+const dailyKeyTTL = 8 * 24 * time.Hour
+
+// Trending counts server views in a Redis sorted set per day and ranks servers by their views
+type Trending struct {
+	client     *redis.Client
+	sampleRate float64
+}
+
+// NewTrending creates a view counter storing counts in Redis.
+// Only a sampleRate fraction of views (between 0 and 1) is recorded to reduce writes.
+func NewTrending(client *redis.Client, sampleRate float64) *Trending {
+	return &Trending{
+		client:     client,
+		sampleRate: sampleRate,
+	}
+}
+
+// dailyKey returns the key of the sorted set holding the view counts of the day of t
+func dailyKey(t time.Time) string {
+	return "trending:daily:" + t.UTC().Format(time.DateOnly)
+}
+
+// RecordView counts a view of a server, subject to the sample rate
+func (t *Trending) RecordView(ctx context.Context, serverID string) error {
+	if t.sampleRate < 1 && rand.Float64() >= t.sampleRate {
+		return nil
+	}
+
+	key := dailyKey(time.Now())
+	pipe := t.client.TxPipeline()
+	pipe.ZIncrBy(ctx, key, 1, serverID)
+	pipe.Expire(ctx, key, dailyKeyTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("error recording view: %w", err)
+	}
+	return nil
+}
+
+// GetTrending returns the limit most viewed servers of the period, most viewed first.
+// The day period covers the current UTC day; the week period covers it and the six days before.
+func (t *Trending) GetTrending(ctx context.Context, period string, limit int) ([]model.TrendingEntry, error) {
+	var days int
+	switch period {
+	case PeriodDay:
+		days = 1
+	case PeriodWeek:
+		days = 7
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidPeriod, period)
+	}
+
+	// Sum the daily counts of the period
+	now := time.Now()
+	views := make(map[string]int64)
+	for i := range days {
+		counts, err := t.client.ZRangeWithScores(ctx, dailyKey(now.AddDate(0, 0, -i)), 0, -1).Result()
+		if err != nil {
+			return nil, fmt.Errorf("error reading trending servers: %w", err)
+		}
+		for _, count := range counts {
+			views[count.Member.(string)] += int64(count.Score)
+		}
+	}
+
+	entries := make([]model.TrendingEntry, 0, len(views))
+	for serverID, count := range views {
+		entries = append(entries, model.TrendingEntry{ServerID: serverID, Views: count})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Views != entries[j].Views {
+			return entries[i].Views > entries[j].Views
+		}
+		return entries[i].ServerID < entries[j].ServerID
+	})
+
+	return entries[:min(limit, len(entries))], nil
+}
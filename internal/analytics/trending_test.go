@@ -0,0 +1,107 @@
+package analytics_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/modelcontextprotocol/registry/internal/analytics"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTrending returns a view counter backed by an in-process Redis server
+func newTrending(t *testing.T, sampleRate float64) (*analytics.Trending, *miniredis.Miniredis) {
+	t.Helper()
+	redisServer := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: redisServer.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return analytics.NewTrending(client, sampleRate), redisServer
+}
+
+// dailyKey returns the sorted set key holding the view counts of the day the given number of days ago
+func dailyKey(daysAgo int) string {
+	return "trending:daily:" + time.Now().UTC().AddDate(0, 0, -daysAgo).Format(time.DateOnly)
+}
+
+func TestRecordView(t *testing.T) {
+	trending, redisServer := newTrending(t, 1)
+	ctx := context.Background()
+
+	for range 3 {
+		require.NoError(t, trending.RecordView(ctx, "server-a"))
+	}
+	require.NoError(t, trending.RecordView(ctx, "server-b"))
+
+	score, err := redisServer.ZScore(dailyKey(0), "server-a")
+	require.NoError(t, err)
+	assert.InDelta(t, 3, score, 0)
+
+	score, err = redisServer.ZScore(dailyKey(0), "server-b")
+	require.NoError(t, err)
+	assert.InDelta(t, 1, score, 0)
+
+	// Daily counts expire once they no longer contribute to the weekly ranking
+	assert.Equal(t, 8*24*time.Hour, redisServer.TTL(dailyKey(0)))
+}
+
+func TestGetTrending(t *testing.T) {
+	trending, redisServer := newTrending(t, 1)
+	ctx := context.Background()
+
+	// server-a is popular today, server-b was popular earlier in the week, server-c only before the week
+	_, _ = redisServer.ZAdd(dailyKey(0), 5, "server-a")
+	_, _ = redisServer.ZAdd(dailyKey(0), 2, "server-b")
+	_, _ = redisServer.ZAdd(dailyKey(3), 8, "server-b")
+	_, _ = redisServer.ZAdd(dailyKey(6), 1, "server-c")
+	_, _ = redisServer.ZAdd(dailyKey(7), 100, "server-d")
+
+	t.Run("day", func(t *testing.T) {
+		entries, err := trending.GetTrending(ctx, analytics.PeriodDay, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []model.TrendingEntry{
+			{ServerID: "server-a", Views: 5},
+			{ServerID: "server-b", Views: 2},
+		}, entries)
+	})
+
+	t.Run("week", func(t *testing.T) {
+		entries, err := trending.GetTrending(ctx, analytics.PeriodWeek, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []model.TrendingEntry{
+			{ServerID: "server-b", Views: 10},
+			{ServerID: "server-a", Views: 5},
+			{ServerID: "server-c", Views: 1},
+		}, entries)
+	})
+
+	t.Run("top N", func(t *testing.T) {
+		for i := range 15 {
+			_, _ = redisServer.ZAdd(dailyKey(1), float64(20+i), fmt.Sprintf("bulk-%02d", i))
+		}
+
+		entries, err := trending.GetTrending(ctx, analytics.PeriodWeek, 10)
+		require.NoError(t, err)
+		require.Len(t, entries, 10)
+		assert.Equal(t, "bulk-14", entries[0].ServerID)
+		assert.Equal(t, "bulk-05", entries[9].ServerID)
+	})
+
+	t.Run("invalid period", func(t *testing.T) {
+		_, err := trending.GetTrending(ctx, "month", 10)
+		assert.ErrorIs(t, err, analytics.ErrInvalidPeriod)
+	})
+
+	t.Run("Redis unavailable", func(t *testing.T) {
+		redisServer.SetError("connection refused")
+		defer redisServer.SetError("")
+
+		_, err := trending.GetTrending(ctx, analytics.PeriodDay, 10)
+		assert.Error(t, err)
+		assert.Error(t, trending.RecordView(ctx, "server-a"))
+	})
+}
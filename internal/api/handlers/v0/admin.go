@@ -0,0 +1,220 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// ReindexResponse represents the response returned when a reindex job is started
+type ReindexResponse struct {
+	JobID string `json:"job_id"`
+}
+
+// StartReindexHandler handles requests to rebuild the text search index.
+// The rebuild runs in the background; the returned job ID can be used to poll its status.
+func StartReindexHandler(registry service.RegistryService, authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !authorizeRegistryOwner(w, r, authService) {
+			return
+		}
+
+		job, err := registry.StartReindex(r.Context())
+		if err != nil {
+			middleware.Logf(r.Context(), "Error starting reindex job: %v", err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to start reindex job"}, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		if err := json.NewEncoder(w).Encode(ReindexResponse{JobID: job.ID}); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to encode response"}, http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// ReindexStatusHandler returns a handler for getting the status of a reindex job
+func ReindexStatusHandler(registry service.RegistryService, authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !authorizeRegistryOwner(w, r, authService) {
+			return
+		}
+
+		jobID := r.PathValue("job_id")
+		if _, err := uuid.Parse(jobID); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid job ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		job, err := registry.GetReindexJob(r.Context(), jobID)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Reindex job not found"}, http.StatusNotFound)
+				return
+			}
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Error retrieving reindex job"}, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(job); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to encode response"}, http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// VacuumResponse represents the response returned when a vacuum job is started
+type VacuumResponse struct {
+	JobID string `json:"job_id"`
+}
+
+// StartVacuumHandler handles requests to remove stale records and compact the database.
+// The vacuum runs in the background; the returned job ID can be used to poll its status.
+func StartVacuumHandler(registry service.RegistryService, authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !authorizeRegistryOwner(w, r, authService) {
+			return
+		}
+
+		job, err := registry.StartVacuum(r.Context())
+		if err != nil {
+			middleware.Logf(r.Context(), "Error starting vacuum job: %v", err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to start vacuum job"}, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		if err := json.NewEncoder(w).Encode(VacuumResponse{JobID: job.ID}); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to encode response"}, http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// VacuumStatusHandler returns a handler for getting the status of a vacuum job
+func VacuumStatusHandler(registry service.RegistryService, authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !authorizeRegistryOwner(w, r, authService) {
+			return
+		}
+
+		jobID := r.PathValue("job_id")
+		if _, err := uuid.Parse(jobID); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid job ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		job, err := registry.GetVacuumJob(r.Context(), jobID)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Vacuum job not found"}, http.StatusNotFound)
+				return
+			}
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Error retrieving vacuum job"}, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(job); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to encode response"}, http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// AdminServersHandler returns a handler for listing registry items including fields that public
+// endpoints hide, such as contact emails
+func AdminServersHandler(registry service.RegistryService, authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		generatedAt := time.Now().UTC()
+
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !authorizeRegistryOwner(w, r, authService) {
+			return
+		}
+
+		cursor, direction, ok := parseCursor(w, r)
+		if !ok {
+			return
+		}
+
+		limit := 30
+		if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+			parsedLimit, err := strconv.Atoi(limitStr)
+			if err != nil || parsedLimit <= 0 {
+				WriteError(w, APIError{Code: ErrCodeInvalidLimit, Message: "Invalid limit parameter"}, http.StatusBadRequest)
+				return
+			}
+			limit = min(parsedLimit, maxLimit)
+		}
+
+		servers, cursors, err := registry.List("", cursor, direction, limit)
+		if err != nil {
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: err.Error()}, http.StatusInternalServerError)
+			return
+		}
+
+		response := NewResponseEnvelope(servers, generatedAt)
+		response.Metadata = paginationMetadata(cursors, len(servers))
+		writeJSON(w, response)
+	}
+}
+
+// authorizeRegistryOwner verifies the request is authenticated with the registry owner token.
+// It writes an error response and returns false if it is not.
+func authorizeRegistryOwner(w http.ResponseWriter, r *http.Request, authService auth.Service) bool {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		WriteError(w, APIError{Code: ErrCodeAuthRequired, Message: "Authorization header is required"}, http.StatusUnauthorized)
+		return false
+	}
+
+	token := auth.ParseAuthorizationHeader(authHeader)
+	isOwner, err := authService.ValidateRegistryOwnerAuth(r.Context(), token)
+	if err != nil {
+		WriteError(w, APIError{Code: ErrCodeAuthFailed, Message: "Authentication failed: " + err.Error()}, http.StatusUnauthorized)
+		return false
+	}
+	if !isOwner {
+		WriteError(w, APIError{Code: ErrCodeForbidden, Message: "Only the registry owner can perform this operation"}, http.StatusForbidden)
+		return false
+	}
+
+	return true
+}
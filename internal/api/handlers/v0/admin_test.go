@@ -0,0 +1,153 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReindexHandlers(t *testing.T) {
+	db := database.NewMemoryDB(map[string]*model.Server{
+		"1": {ID: "1", Name: "example/one"},
+		"2": {ID: "2", Name: "example/two"},
+	})
+	registry := service.NewRegistryServiceWithDB(db)
+
+	mockAuthService := new(MockAuthService)
+	mockAuthService.Mock.On("ValidateRegistryOwnerAuth", mock.Anything, "owner-token").Return(true, nil)
+	mockAuthService.Mock.On("ValidateRegistryOwnerAuth", mock.Anything, "user-token").Return(false, nil)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0/admin/reindex", v0.StartReindexHandler(registry, mockAuthService))
+	mux.HandleFunc("/v0/admin/reindex/{job_id}", v0.ReindexStatusHandler(registry, mockAuthService))
+
+	serve := func(method, target, token string) *httptest.ResponseRecorder {
+		req, err := http.NewRequestWithContext(context.Background(), method, target, nil)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		serveHTTP(t, mux, rr, req)
+		return rr
+	}
+
+	t.Run("requires authorization header", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/v0/admin/reindex", "")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("rejects non-owner", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/v0/admin/reindex", "user-token")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Only the registry owner")
+	})
+
+	t.Run("starts job and completes", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/v0/admin/reindex", "owner-token")
+		require.Equal(t, http.StatusAccepted, rr.Code)
+
+		var resp v0.ReindexResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		require.NotEmpty(t, resp.JobID)
+
+		// Status is protected as well
+		rr = serve(http.MethodGet, "/v0/admin/reindex/"+resp.JobID, "user-token")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		var job model.ReindexJob
+		require.Eventually(t, func() bool {
+			rr := serve(http.MethodGet, "/v0/admin/reindex/"+resp.JobID, "owner-token")
+			if rr.Code != http.StatusOK {
+				return false
+			}
+			if err := json.NewDecoder(rr.Body).Decode(&job); err != nil {
+				return false
+			}
+			assert.Contains(t, []model.ReindexJobStatus{model.ReindexJobRunning, model.ReindexJobCompleted}, job.Status)
+			return job.Status == model.ReindexJobCompleted
+		}, 2*time.Second, 10*time.Millisecond)
+
+		assert.Equal(t, resp.JobID, job.ID)
+		assert.Equal(t, int64(2), job.DocumentsProcessed)
+		assert.Empty(t, job.Error)
+		assert.NotNil(t, job.CompletedAt)
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/v0/admin/reindex/550e8400-e29b-41d4-a716-446655440000", "owner-token")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestVacuumHandlers(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+
+	mockAuthService := new(MockAuthService)
+	mockAuthService.Mock.On("ValidateRegistryOwnerAuth", mock.Anything, "owner-token").Return(true, nil)
+	mockAuthService.Mock.On("ValidateRegistryOwnerAuth", mock.Anything, "user-token").Return(false, nil)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0/admin/vacuum", v0.StartVacuumHandler(registry, mockAuthService))
+	mux.HandleFunc("/v0/admin/vacuum/{job_id}", v0.VacuumStatusHandler(registry, mockAuthService))
+
+	serve := func(method, target, token string) *httptest.ResponseRecorder {
+		req, err := http.NewRequestWithContext(context.Background(), method, target, nil)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		serveHTTP(t, mux, rr, req)
+		return rr
+	}
+
+	t.Run("rejects non-owner", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/v0/admin/vacuum", "user-token")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("starts job and completes", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/v0/admin/vacuum", "owner-token")
+		require.Equal(t, http.StatusAccepted, rr.Code)
+
+		var resp v0.VacuumResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		require.NotEmpty(t, resp.JobID)
+
+		rr = serve(http.MethodGet, "/v0/admin/vacuum/"+resp.JobID, "user-token")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		var job model.VacuumJob
+		require.Eventually(t, func() bool {
+			rr := serve(http.MethodGet, "/v0/admin/vacuum/"+resp.JobID, "owner-token")
+			if rr.Code != http.StatusOK {
+				return false
+			}
+			job = model.VacuumJob{}
+			if err := json.NewDecoder(rr.Body).Decode(&job); err != nil {
+				return false
+			}
+			return job.Status == model.VacuumJobCompleted
+		}, 2*time.Second, 10*time.Millisecond)
+
+		assert.Equal(t, resp.JobID, job.ID)
+		assert.Len(t, job.Phases, 3)
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/v0/admin/vacuum/550e8400-e29b-41d4-a716-446655440000", "owner-token")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -0,0 +1,146 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/model"
+)
+
+// CreateAPIKeyRequest represents the request body for issuing an API key
+type CreateAPIKeyRequest struct {
+	OwnerGitHubUsername string    `json:"owner_github_username"`
+	Description         string    `json:"description"`
+	ExpiresAt           time.Time `json:"expires_at"`
+}
+
+// CreateAPIKeyResponse is returned when an API key is issued. Key is the bearer token to authenticate with;
+// it is only returned once.
+type CreateAPIKeyResponse struct {
+	APIKey *model.APIKey `json:"api_key"`
+	Key    string        `json:"key"`
+}
+
+// CreateAPIKeyHandler handles requests of the registry owner to issue an API key acting as a GitHub user,
+// for environments such as CI that cannot complete the GitHub OAuth flow
+func CreateAPIKeyHandler(authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		generatedAt := time.Now().UTC()
+
+		if r.Method != http.MethodPost {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !authorizeRegistryOwner(w, r, authService) {
+			return
+		}
+
+		// Parse request body
+		var req CreateAPIKeyRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidPayload, Message: "Invalid request payload: " + err.Error()}, http.StatusBadRequest)
+			return
+		}
+		defer r.Body.Close()
+
+		if req.OwnerGitHubUsername == "" {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Owner GitHub username is required"}, http.StatusBadRequest)
+			return
+		}
+		if !githubUsernameRegex.MatchString(req.OwnerGitHubUsername) {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid GitHub username"}, http.StatusBadRequest)
+			return
+		}
+		if !req.ExpiresAt.IsZero() && !req.ExpiresAt.After(generatedAt) {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Expiry time must be in the future"}, http.StatusBadRequest)
+			return
+		}
+
+		exists, err := authService.GitHubUserExists(r.Context(), req.OwnerGitHubUsername)
+		if err != nil {
+			middleware.Logf(r.Context(), "api-keys: Failed to look up GitHub user %s: %v", req.OwnerGitHubUsername, err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to look up GitHub user"}, http.StatusInternalServerError)
+			return
+		}
+		if !exists {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "GitHub user not found"}, http.StatusBadRequest)
+			return
+		}
+
+		apiKey, key, err := authService.CreateAPIKey(r.Context(), req.OwnerGitHubUsername, req.Description, req.ExpiresAt)
+		if err != nil {
+			middleware.Logf(r.Context(), "api-keys: Failed to create API key for %s: %v", req.OwnerGitHubUsername, err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to create API key"}, http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, NewResponseEnvelope(CreateAPIKeyResponse{APIKey: apiKey, Key: key}, generatedAt))
+	}
+}
+
+// ListAPIKeysHandler handles requests of the registry owner to list the issued API keys, oldest first.
+// The keys themselves are never returned.
+func ListAPIKeysHandler(authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		generatedAt := time.Now().UTC()
+
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !authorizeRegistryOwner(w, r, authService) {
+			return
+		}
+
+		apiKeys, err := authService.ListAPIKeys(r.Context())
+		if err != nil {
+			middleware.Logf(r.Context(), "api-keys: Failed to list API keys: %v", err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to list API keys"}, http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, NewResponseEnvelope(apiKeys, generatedAt))
+	}
+}
+
+// RevokeAPIKeyHandler handles requests of the registry owner to revoke an API key
+func RevokeAPIKeyHandler(authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Extract the API key ID from the URL path
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid API key ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		if !authorizeRegistryOwner(w, r, authService) {
+			return
+		}
+
+		if err := authService.RevokeAPIKey(r.Context(), id); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "API key not found"}, http.StatusNotFound)
+				return
+			}
+			middleware.Logf(r.Context(), "api-keys: Failed to revoke API key %s: %v", id, err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to revoke API key"}, http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
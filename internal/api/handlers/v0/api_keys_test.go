@@ -0,0 +1,182 @@
+//go:build test
+
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeys(t *testing.T) {
+	githubAuth := auth.NewMockGitHubAuth(map[string]string{"owner-token": "registry-owner", "ci-token": "ci-user"})
+	store := database.NewMemoryDB(map[string]*model.Server{})
+	authService := auth.NewAuthServiceWithGitHubAuth(&config.Config{
+		EphemeralTokenSecret:        testEphemeralTokenSecret,
+		RegistryOwnerGithubUsername: "registry-owner",
+	}, githubAuth, auth.WithAPIKeyStore(store))
+
+	request := func(t *testing.T, handler http.HandlerFunc, method, path, token string, body any) *httptest.ResponseRecorder {
+		t.Helper()
+		var payload []byte
+		if body != nil {
+			var err error
+			payload, err = json.Marshal(body)
+			require.NoError(t, err)
+		}
+		req, err := http.NewRequestWithContext(context.Background(), method, path, bytes.NewReader(payload))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		if id, ok := strings.CutPrefix(path, "/v0/auth/api-keys/"); ok {
+			req.SetPathValue("id", id)
+		}
+		rr := httptest.NewRecorder()
+		serveHTTP(t, handler, rr, req)
+		return rr
+	}
+	create := func(t *testing.T, token string, body v0.CreateAPIKeyRequest) *httptest.ResponseRecorder {
+		t.Helper()
+		return request(t, v0.CreateAPIKeyHandler(authService), http.MethodPost, "/v0/auth/api-keys", token, body)
+	}
+
+	var created v0.CreateAPIKeyResponse
+	t.Run("registry owner creates a key", func(t *testing.T) {
+		rr := create(t, "owner-token", v0.CreateAPIKeyRequest{OwnerGitHubUsername: "ci-user", Description: "release workflow"})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		assert.NotContains(t, rr.Body.String(), "hashed_secret")
+
+		var resp v0.ResponseEnvelope[v0.CreateAPIKeyResponse]
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		created = resp.Data
+		require.NotNil(t, created.APIKey)
+		assert.Equal(t, "ci-user", created.APIKey.OwnerGitHubUsername)
+		assert.Equal(t, "release workflow", created.APIKey.Description)
+		assert.True(t, strings.HasPrefix(created.Key, auth.APIKeyPrefix+created.APIKey.ID+"_"))
+	})
+
+	t.Run("key authenticates as its owner", func(t *testing.T) {
+		valid, claims, err := authService.ValidateEphemeralOrOwnerToken(context.Background(), created.Key)
+		require.NoError(t, err)
+		assert.True(t, valid)
+		require.NotNil(t, claims)
+		assert.Equal(t, "ci-user", claims.GitHubUsername)
+		assert.Empty(t, claims.GitHubUserID)
+	})
+
+	t.Run("wrong secret is rejected", func(t *testing.T) {
+		valid, _, err := authService.ValidateEphemeralOrOwnerToken(context.Background(), created.Key[:len(created.Key)-1]+"x")
+		require.Error(t, err)
+		assert.False(t, valid)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		testCases := []struct {
+			name           string
+			token          string
+			body           v0.CreateAPIKeyRequest
+			expectedStatus int
+		}{
+			{
+				name:           "not the registry owner",
+				token:          "ci-token",
+				body:           v0.CreateAPIKeyRequest{OwnerGitHubUsername: "ci-user"},
+				expectedStatus: http.StatusUnauthorized,
+			},
+			{
+				name:           "API key",
+				token:          created.Key,
+				body:           v0.CreateAPIKeyRequest{OwnerGitHubUsername: "ci-user"},
+				expectedStatus: http.StatusUnauthorized,
+			},
+			{name: "missing owner", token: "owner-token", expectedStatus: http.StatusBadRequest},
+			{
+				name:           "unknown owner",
+				token:          "owner-token",
+				body:           v0.CreateAPIKeyRequest{OwnerGitHubUsername: "nobody"},
+				expectedStatus: http.StatusBadRequest,
+			},
+			{
+				name:           "expiry in the past",
+				token:          "owner-token",
+				body:           v0.CreateAPIKeyRequest{OwnerGitHubUsername: "ci-user", ExpiresAt: time.Now().Add(-time.Minute)},
+				expectedStatus: http.StatusBadRequest,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				assert.Equal(t, tc.expectedStatus, create(t, tc.token, tc.body).Code)
+			})
+		}
+	})
+
+	t.Run("expired key is rejected", func(t *testing.T) {
+		rr := create(t, "owner-token", v0.CreateAPIKeyRequest{OwnerGitHubUsername: "ci-user", ExpiresAt: time.Now().Add(time.Hour)})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var resp v0.ResponseEnvelope[v0.CreateAPIKeyResponse]
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+		// Move the expiry of the stored key into the past
+		key, err := store.GetAPIKey(context.Background(), resp.Data.APIKey.ID)
+		require.NoError(t, err)
+		key.ExpiresAt = time.Now().Add(-time.Minute)
+		require.NoError(t, store.DeleteAPIKey(context.Background(), key.ID))
+		require.NoError(t, store.SaveAPIKey(context.Background(), key))
+
+		valid, _, err := authService.ValidateEphemeralOrOwnerToken(context.Background(), resp.Data.Key)
+		require.ErrorContains(t, err, "expired")
+		assert.False(t, valid)
+	})
+
+	t.Run("registry owner lists keys without secrets", func(t *testing.T) {
+		rr := request(t, v0.ListAPIKeysHandler(authService), http.MethodGet, "/v0/auth/api-keys", "owner-token", nil)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.NotContains(t, rr.Body.String(), "hashed_secret")
+
+		var resp v0.ResponseEnvelope[[]model.APIKey]
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		require.Len(t, resp.Data, 2)
+		assert.Equal(t, created.APIKey.ID, resp.Data[0].ID)
+
+		rr = request(t, v0.ListAPIKeysHandler(authService), http.MethodGet, "/v0/auth/api-keys", "ci-token", nil)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("registry owner revokes a key", func(t *testing.T) {
+		revoke := v0.RevokeAPIKeyHandler(authService)
+		path := "/v0/auth/api-keys/" + created.APIKey.ID
+
+		assert.Equal(t, http.StatusUnauthorized, request(t, revoke, http.MethodDelete, path, "ci-token", nil).Code)
+		require.Equal(t, http.StatusNoContent, request(t, revoke, http.MethodDelete, path, "owner-token", nil).Code)
+
+		valid, _, err := authService.ValidateEphemeralOrOwnerToken(context.Background(), created.Key)
+		require.Error(t, err)
+		assert.False(t, valid)
+
+		assert.Equal(t, http.StatusNotFound, request(t, revoke, http.MethodDelete, path, "owner-token", nil).Code)
+		assert.Equal(t, http.StatusBadRequest, request(t, revoke, http.MethodDelete, "/v0/auth/api-keys/abc", "owner-token", nil).Code)
+	})
+}
+
+func TestAPIKeysWithoutStore(t *testing.T) {
+	authService := auth.NewAuthService(&config.Config{EphemeralTokenSecret: testEphemeralTokenSecret})
+
+	valid, _, err := authService.ValidateEphemeralOrOwnerToken(context.Background(), auth.APIKeyPrefix+"id_secret")
+	require.ErrorContains(t, err, auth.ErrAPIKeysUnavailable.Error())
+	assert.False(t, valid)
+
+	_, _, err = authService.CreateAPIKey(context.Background(), "ci-user", "", time.Time{})
+	require.ErrorIs(t, err, auth.ErrAPIKeysUnavailable)
+}
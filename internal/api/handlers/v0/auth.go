@@ -15,14 +15,14 @@ func StartAuthHandler(authService auth.Service) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Only allow POST method
 		if r.Method != http.MethodPost {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
 			return
 		}
 
 		// Read the request body
 		body, err := io.ReadAll(r.Body)
 		if err != nil {
-			http.Error(w, "Error reading request body", http.StatusBadRequest)
+			WriteError(w, APIError{Code: ErrCodeInvalidPayload, Message: "Error reading request body"}, http.StatusBadRequest)
 			return
 		}
 		defer r.Body.Close()
@@ -34,13 +34,13 @@ func StartAuthHandler(authService auth.Service) http.HandlerFunc {
 		}
 		err = json.Unmarshal(body, &authReq)
 		if err != nil {
-			http.Error(w, "Invalid request payload: "+err.Error(), http.StatusBadRequest)
+			WriteError(w, APIError{Code: ErrCodeInvalidPayload, Message: "Invalid request payload: " + err.Error()}, http.StatusBadRequest)
 			return
 		}
 
 		// Validate required fields
 		if authReq.Method == "" {
-			http.Error(w, "Auth method is required", http.StatusBadRequest)
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Auth method is required"}, http.StatusBadRequest)
 			return
 		}
 
@@ -50,14 +50,17 @@ func StartAuthHandler(authService auth.Service) http.HandlerFunc {
 		case "github":
 			method = model.AuthMethodGitHub
 		default:
-			http.Error(w, "Unsupported authentication method", http.StatusBadRequest)
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Unsupported authentication method"}, http.StatusBadRequest)
 			return
 		}
 
 		// Start auth flow
 		flowInfo, statusToken, err := authService.StartAuthFlow(r.Context(), method, authReq.RepoRef)
 		if err != nil {
-			http.Error(w, "Failed to start auth flow: "+err.Error(), http.StatusInternalServerError)
+			WriteError(w, APIError{
+				Code:    ErrCodeInternal,
+				Message: "Failed to start auth flow: " + err.Error(),
+			}, http.StatusInternalServerError)
 			return
 		}
 
@@ -69,7 +72,7 @@ func StartAuthHandler(authService auth.Service) http.HandlerFunc {
 			"status_token": statusToken,
 			"expires_in":   300, // 5 minutes
 		}); err != nil {
-			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to encode response"}, http.StatusInternalServerError)
 			return
 		}
 	}
@@ -80,14 +83,14 @@ func CheckAuthStatusHandler(authService auth.Service) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Only allow GET method
 		if r.Method != http.MethodGet {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
 			return
 		}
 
 		// Get status token from query parameter
 		statusToken := r.URL.Query().Get("token")
 		if statusToken == "" {
-			http.Error(w, "Status token is required", http.StatusBadRequest)
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Status token is required"}, http.StatusBadRequest)
 			return
 		}
 
@@ -101,14 +104,17 @@ func CheckAuthStatusHandler(authService auth.Service) http.HandlerFunc {
 				if err := json.NewEncoder(w).Encode(map[string]interface{}{
 					"status": "pending",
 				}); err != nil {
-					http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+					WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to encode response"}, http.StatusInternalServerError)
 					return
 				}
 				return
 			}
 
 			// Other error
-			http.Error(w, "Failed to check auth status: "+err.Error(), http.StatusInternalServerError)
+			WriteError(w, APIError{
+				Code:    ErrCodeInternal,
+				Message: "Failed to check auth status: " + err.Error(),
+			}, http.StatusInternalServerError)
 			return
 		}
 
@@ -119,7 +125,7 @@ func CheckAuthStatusHandler(authService auth.Service) http.HandlerFunc {
 			"status": "complete",
 			"token":  token,
 		}); err != nil {
-			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to encode response"}, http.StatusInternalServerError)
 			return
 		}
 	}
@@ -2,7 +2,9 @@ package v0
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/modelcontextprotocol/registry/internal/auth"
 )
@@ -23,27 +25,27 @@ func AuthorizeHandler(authService auth.Service) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Only allow POST method
 		if r.Method != http.MethodPost {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
 			return
 		}
 
 		// Parse request body
 		var req AuthorizeRequest
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+			WriteError(w, APIError{Code: ErrCodeInvalidPayload, Message: "Invalid request body: " + err.Error()}, http.StatusBadRequest)
 			return
 		}
 
 		// Validate GitHub token is provided
 		if req.GitHubToken == "" {
-			http.Error(w, "GitHub token is required", http.StatusBadRequest)
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "GitHub token is required"}, http.StatusBadRequest)
 			return
 		}
 
 		// Generate ephemeral token
 		ephemeralToken, err := authService.GenerateEphemeralTokenForGitHubUser(r.Context(), req.GitHubToken)
 		if err != nil {
-			http.Error(w, "Failed to authorize: "+err.Error(), http.StatusUnauthorized)
+			WriteError(w, APIError{Code: ErrCodeAuthFailed, Message: "Failed to authorize: " + err.Error()}, http.StatusUnauthorized)
 			return
 		}
 
@@ -55,8 +57,165 @@ func AuthorizeHandler(authService auth.Service) http.HandlerFunc {
 
 		w.Header().Set("Content-Type", "application/json")
 		if err := json.NewEncoder(w).Encode(resp); err != nil {
-			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to encode response"}, http.StatusInternalServerError)
 			return
 		}
 	}
 }
+
+// PATRequest represents the request body for the personal access token endpoint
+type PATRequest struct {
+	GitHubPAT string `json:"github_pat"`
+}
+
+// InsufficientScopeResponse is returned when a personal access token lacks required scopes
+type InsufficientScopeResponse struct {
+	Error    string   `json:"error"`
+	Required []string `json:"required"`
+	Provided []string `json:"provided"`
+}
+
+// PATHandler handles requests to exchange a GitHub personal access token for an ephemeral token.
+// It allows publishing from environments where the browser-based device flow is not available.
+func PATHandler(authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Only allow POST method
+		if r.Method != http.MethodPost {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Parse request body
+		var req PATRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidPayload, Message: "Invalid request body: " + err.Error()}, http.StatusBadRequest)
+			return
+		}
+
+		// Validate personal access token is provided
+		if req.GitHubPAT == "" {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "GitHub personal access token is required"}, http.StatusBadRequest)
+			return
+		}
+
+		// Generate ephemeral token
+		ephemeralToken, err := authService.GenerateEphemeralTokenForPAT(r.Context(), req.GitHubPAT)
+		if err != nil {
+			var scopeErr *auth.InsufficientScopeError
+			if errors.As(err, &scopeErr) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusForbidden)
+				_ = json.NewEncoder(w).Encode(InsufficientScopeResponse{
+					Error:    "insufficient_scope",
+					Required: scopeErr.Required,
+					Provided: scopeErr.Provided,
+				})
+				return
+			}
+			WriteError(w, APIError{Code: ErrCodeAuthFailed, Message: "Failed to authorize: " + err.Error()}, http.StatusUnauthorized)
+			return
+		}
+
+		// Return response
+		resp := AuthorizeResponse{
+			EphemeralToken: ephemeralToken,
+			ExpiresIn:      3600, // 1 hour in seconds
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to encode response"}, http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// RefreshRequest represents the request body for the token refresh endpoint
+type RefreshRequest struct {
+	Token string `json:"token"`
+}
+
+// RefreshHandler handles requests to extend an ephemeral token that is close to expiry
+func RefreshHandler(authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Only allow POST method
+		if r.Method != http.MethodPost {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Parse request body
+		var req RefreshRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidPayload, Message: "Invalid request body: " + err.Error()}, http.StatusBadRequest)
+			return
+		}
+
+		// Validate token is provided
+		if req.Token == "" {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Token is required"}, http.StatusBadRequest)
+			return
+		}
+
+		// Refresh ephemeral token
+		ephemeralToken, expiresAt, err := authService.RefreshEphemeralToken(r.Context(), req.Token)
+		if err != nil {
+			WriteError(w, APIError{Code: ErrCodeAuthFailed, Message: "Failed to refresh token: " + err.Error()}, http.StatusUnauthorized)
+			return
+		}
+
+		// Return response
+		resp := AuthorizeResponse{
+			EphemeralToken: ephemeralToken,
+			ExpiresIn:      int(time.Until(expiresAt).Seconds()),
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to encode response"}, http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// LogoutHandler handles requests to revoke every ephemeral token of the user the given token belongs to,
+// for example after they revoked the registry's GitHub access. Tokens issued after logging out remain valid.
+func LogoutHandler(authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Only allow POST method
+		if r.Method != http.MethodPost {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		authHeader := r.Header.Get("Authorization")
+		if authHeader == "" {
+			WriteError(w, APIError{Code: ErrCodeAuthRequired, Message: "Authorization header is required"}, http.StatusUnauthorized)
+			return
+		}
+
+		token := auth.ParseAuthorizationHeader(authHeader)
+		valid, claims, err := authService.ValidateEphemeralOrOwnerToken(r.Context(), token)
+		if err != nil {
+			WriteError(w, APIError{Code: ErrCodeAuthFailed, Message: "Authentication failed: " + err.Error()}, http.StatusUnauthorized)
+			return
+		}
+		if !valid {
+			WriteError(w, APIError{Code: ErrCodeForbidden, Message: "Invalid authentication token"}, http.StatusForbidden)
+			return
+		}
+		if claims == nil || claims.GitHubUserID == "" {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Logging out requires an ephemeral token"}, http.StatusBadRequest)
+			return
+		}
+
+		// Every token issued so far expires within one token lifetime
+		until := time.Now().Add(auth.EphemeralTokenLifetime)
+		if err := authService.RevokeAllTokensForUser(r.Context(), claims.GitHubUserID, until); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to revoke tokens: " + err.Error()}, http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,171 @@
+package v0
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// maxBatchQueryIDs caps the number of IDs in the ids query parameter, keeping request URLs within common length limits
+const maxBatchQueryIDs = 20
+
+// maxBatchBodyIDs caps the number of IDs in the body of a batch request
+const maxBatchBodyIDs = 50
+
+// BatchRequest is the body of a batch fetch of servers by ID
+type BatchRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// BatchResult holds the servers found by a batch fetch and the requested IDs that do not exist
+type BatchResult struct {
+	Servers  []model.ServerDetail `json:"servers"`
+	NotFound []string             `json:"not_found"`
+}
+
+// BatchResponse is the API response of a batch fetch of servers
+type BatchResponse = ResponseEnvelope[BatchResult]
+
+// BatchMapResult holds the servers found by a batch request keyed by their ID, and the requested IDs that do not exist
+type BatchMapResult struct {
+	Servers  map[string]model.ServerDetail `json:"servers"`
+	NotFound []string                      `json:"not_found"`
+}
+
+// BatchMapResponse is the API response of a batch request for servers
+type BatchMapResponse = ResponseEnvelope[BatchMapResult]
+
+// parseBatchIDs splits a comma-separated list of server IDs, rejecting invalid UUIDs and lists
+// longer than maxIDs. Repeated IDs are kept once.
+func parseBatchIDs(value string, maxIDs int) ([]string, error) {
+	return validateBatchIDs(strings.Split(value, ","), maxIDs)
+}
+
+// validateBatchIDs rejects invalid UUIDs and empty lists or lists longer than maxIDs. Surrounding spaces are trimmed
+// and repeated IDs are kept once.
+func validateBatchIDs(requested []string, maxIDs int) ([]string, error) {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, id := range requested {
+		id = strings.TrimSpace(id)
+		if _, err := uuid.Parse(id); err != nil {
+			return nil, fmt.Errorf("invalid server ID %q", id)
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("at least one ID must be requested")
+	}
+	if len(ids) > maxIDs {
+		return nil, fmt.Errorf("at most %d IDs may be requested at once", maxIDs)
+	}
+	return ids, nil
+}
+
+// fetchBatch looks up the servers with the given IDs in a single query. It returns the servers found, in the order
+// they were requested and with their contact emails redacted, and the IDs that do not exist.
+func fetchBatch(
+	ctx context.Context, registry service.RegistryService, ids []string,
+) ([]model.ServerDetail, []string, error) {
+	servers, err := registry.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	found := make(map[string]bool, len(servers))
+	for i := range servers {
+		servers[i].RedactContactEmail()
+		found[servers[i].ID] = true
+	}
+
+	notFound := []string{}
+	for _, id := range ids {
+		if !found[id] {
+			notFound = append(notFound, id)
+		}
+	}
+	return servers, notFound, nil
+}
+
+// batchMetadata returns the metadata of a batch response. Batches are never paginated, so the total is the number of
+// servers found and only reported on request.
+func batchMetadata(found int, includeTotal bool) *Metadata {
+	if !includeTotal {
+		return nil
+	}
+	return withTotalCount(nil, found, int64(found))
+}
+
+// serveBatch writes the servers with the given IDs, in the order they were requested
+func serveBatch(
+	w http.ResponseWriter, r *http.Request, registry service.RegistryService, ids []string, includeTotal bool,
+	generatedAt time.Time,
+) {
+	servers, notFound, err := fetchBatch(r.Context(), registry, ids)
+	if err != nil {
+		WriteError(w, APIError{Code: ErrCodeInternal, Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+
+	response := NewResponseEnvelope(BatchResult{Servers: servers, NotFound: notFound}, generatedAt)
+	response.Metadata = batchMetadata(len(servers), includeTotal)
+	writeJSON(w, response)
+}
+
+// BatchHandler returns a handler fetching up to maxBatchBodyIDs servers by ID in one request. Unlike the ids
+// parameter of the server list, the IDs are sent in the body and the servers found are keyed by ID.
+func BatchHandler(registry service.RegistryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		generatedAt := time.Now().UTC()
+
+		if r.Method != http.MethodPost {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		includeTotal, err := parseIncludeTotal(r)
+		if err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid include_total parameter"}, http.StatusBadRequest)
+			return
+		}
+
+		var req BatchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidPayload, Message: "Invalid request payload: " + err.Error()}, http.StatusBadRequest)
+			return
+		}
+		defer r.Body.Close()
+
+		ids, err := validateBatchIDs(req.IDs, maxBatchBodyIDs)
+		if err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid ids: " + err.Error()}, http.StatusBadRequest)
+			return
+		}
+
+		servers, notFound, err := fetchBatch(r.Context(), registry, ids)
+		if err != nil {
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: err.Error()}, http.StatusInternalServerError)
+			return
+		}
+
+		byID := make(map[string]model.ServerDetail, len(servers))
+		for _, server := range servers {
+			byID[server.ID] = server
+		}
+		response := NewResponseEnvelope(BatchMapResult{Servers: byID, NotFound: notFound}, generatedAt)
+		response.Metadata = batchMetadata(len(servers), includeTotal)
+		writeJSON(w, response)
+	}
+}
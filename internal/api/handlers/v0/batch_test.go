@@ -0,0 +1,196 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServersHandlerBatchFetch(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	var ids []string
+	for _, name := range []string{"alpha", "bravo", "charlie"} {
+		serverDetail := &model.ServerDetail{
+			Server: model.Server{
+				Name:          "io.github.example/" + name,
+				Repository:    model.Repository{URL: "https://github.com/example/" + name, Source: "github"},
+				VersionDetail: model.VersionDetail{Version: "1.0.0"},
+				ContactEmail:  "security@example.com",
+			},
+		}
+		require.NoError(t, registry.Publish(serverDetail))
+		ids = append(ids, serverDetail.ID)
+	}
+
+	fetch := func(t *testing.T, ids string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers?ids="+ids, nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.ServersHandler(registry), rr, req)
+		return rr
+	}
+
+	decode := func(t *testing.T, rr *httptest.ResponseRecorder) v0.BatchResult {
+		t.Helper()
+		require.Equal(t, http.StatusOK, rr.Code)
+		var resp v0.BatchResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		return resp.Data
+	}
+
+	t.Run("valid IDs return all matching servers in request order", func(t *testing.T) {
+		result := decode(t, fetch(t, ids[2]+","+ids[0]+","+ids[1]))
+		require.Len(t, result.Servers, 3)
+		assert.Equal(t, "io.github.example/charlie", result.Servers[0].Name)
+		assert.Equal(t, "io.github.example/alpha", result.Servers[1].Name)
+		assert.Equal(t, "io.github.example/bravo", result.Servers[2].Name)
+		assert.Empty(t, result.NotFound)
+
+		// Contact emails are masked as in the other public endpoints
+		assert.Empty(t, result.Servers[0].ContactEmail)
+		assert.Equal(t, "se***@example.com", result.Servers[0].MaskedContactEmail)
+	})
+
+	t.Run("repeated IDs are returned once", func(t *testing.T) {
+		result := decode(t, fetch(t, ids[0]+","+ids[0]))
+		assert.Len(t, result.Servers, 1)
+	})
+
+	t.Run("non-existent IDs", func(t *testing.T) {
+		missing := uuid.New().String()
+		result := decode(t, fetch(t, ids[0]+","+missing))
+		require.Len(t, result.Servers, 1)
+		assert.Equal(t, ids[0], result.Servers[0].ID)
+		assert.Equal(t, []string{missing}, result.NotFound)
+
+		rr := fetch(t, missing)
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"servers":[]`)
+	})
+
+	t.Run("invalid UUID format", func(t *testing.T) {
+		for _, query := range []string{ids[0] + ",not-a-uuid", "", ids[0] + ","} {
+			rr := fetch(t, query)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+		}
+	})
+
+	t.Run("more than 20 IDs", func(t *testing.T) {
+		requested := make([]string, 21)
+		for i := range requested {
+			requested[i] = uuid.New().String()
+		}
+		rr := fetch(t, strings.Join(requested, ","))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr = fetch(t, strings.Join(requested[:20], ","))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestBatchHandler(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	var ids []string
+	for _, name := range []string{"alpha", "bravo"} {
+		serverDetail := &model.ServerDetail{
+			Server: model.Server{
+				Name:          "io.github.example/" + name,
+				Repository:    model.Repository{URL: "https://github.com/example/" + name, Source: "github"},
+				VersionDetail: model.VersionDetail{Version: "1.0.0"},
+				ContactEmail:  "security@example.com",
+			},
+		}
+		require.NoError(t, registry.Publish(serverDetail))
+		ids = append(ids, serverDetail.ID)
+	}
+
+	post := func(t *testing.T, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/servers/batch", strings.NewReader(body))
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.BatchHandler(registry), rr, req)
+		return rr
+	}
+
+	idsBody := func(ids ...string) string {
+		body, err := json.Marshal(v0.BatchRequest{IDs: ids})
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	t.Run("reports the total on request", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(
+			context.Background(), http.MethodPost, "/v0/servers/batch?include_total=true",
+			strings.NewReader(idsBody(ids[0], uuid.New().String())),
+		)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.BatchHandler(registry), rr, req)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var resp v0.BatchMapResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		require.NotNil(t, resp.Metadata)
+		require.NotNil(t, resp.Metadata.TotalCount)
+		assert.Equal(t, 1, *resp.Metadata.TotalCount)
+
+		rr = post(t, idsBody(ids[0]))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.NotContains(t, rr.Body.String(), "total_count")
+	})
+
+	t.Run("found servers are keyed by ID", func(t *testing.T) {
+		missing := uuid.New().String()
+		rr := post(t, idsBody(ids[1], missing, ids[0], ids[0]))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var resp v0.BatchMapResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		require.Len(t, resp.Data.Servers, 2)
+		assert.Equal(t, "io.github.example/alpha", resp.Data.Servers[ids[0]].Name)
+		assert.Equal(t, "io.github.example/bravo", resp.Data.Servers[ids[1]].Name)
+		assert.Empty(t, resp.Data.Servers[ids[0]].ContactEmail)
+		assert.Equal(t, []string{missing}, resp.Data.NotFound)
+	})
+
+	t.Run("no servers found", func(t *testing.T) {
+		rr := post(t, idsBody(uuid.New().String()))
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"servers":{}`)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		tooMany := make([]string, 51)
+		for i := range tooMany {
+			tooMany[i] = uuid.New().String()
+		}
+		for _, body := range []string{"{", idsBody(), idsBody(ids[0], "not-a-uuid"), idsBody(tooMany...)} {
+			rr := post(t, body)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+		}
+
+		rr := post(t, idsBody(tooMany[:50]...))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("only POST is allowed", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers/batch", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.BatchHandler(registry), rr, req)
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
@@ -0,0 +1,280 @@
+package v0
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+const (
+	// maxBulkPublishRepositories caps the number of repositories in a bulk publish request
+	maxBulkPublishRepositories = 20
+	// bulkPublishTimeout bounds how long a background bulk publish job may run
+	bulkPublishTimeout = 10 * time.Minute
+	// bulkPublishJobRetention is how long finished bulk publish jobs can be looked up
+	bulkPublishJobRetention = 24 * time.Hour
+)
+
+// BulkPublishStatus is the outcome of publishing one repository of a bulk publish request
+type BulkPublishStatus string
+
+const (
+	// BulkPublishCreated indicates the server was published
+	BulkPublishCreated BulkPublishStatus = "created"
+	// BulkPublishConflict indicates a server with the repository's name was already published
+	BulkPublishConflict BulkPublishStatus = "conflict"
+	// BulkPublishError indicates the repository could not be published
+	BulkPublishError BulkPublishStatus = "error"
+)
+
+// BulkPublishJobStatus represents the state of a bulk publish job
+type BulkPublishJobStatus string
+
+const (
+	// BulkPublishJobRunning indicates repositories are still being published
+	BulkPublishJobRunning BulkPublishJobStatus = "running"
+	// BulkPublishJobCompleted indicates all repositories were processed; see the results for their outcomes
+	BulkPublishJobCompleted BulkPublishJobStatus = "completed"
+)
+
+// BulkPublishOSSRequest represents a request to publish several open source repositories at once
+type BulkPublishOSSRequest struct {
+	Repositories []model.PublishOSSRequest `json:"repositories"`
+	// Async publishes the repositories in a background job instead of during the request
+	Async bool `json:"async"`
+}
+
+// BulkPublishResult is the outcome of publishing one repository of a bulk publish request
+type BulkPublishResult struct {
+	URL    string            `json:"url"`
+	Status BulkPublishStatus `json:"status"`
+	ID     string            `json:"id,omitempty"`
+	Error  string            `json:"error,omitempty"`
+}
+
+// BulkPublishResponse represents the response of a synchronous bulk publish request
+type BulkPublishResponse struct {
+	Results []BulkPublishResult `json:"results"`
+}
+
+// BulkPublishJobResponse represents the response returned when a bulk publish job is started
+type BulkPublishJobResponse struct {
+	JobID string `json:"job_id"`
+}
+
+// BulkPublishJob tracks a bulk publish running in the background
+type BulkPublishJob struct {
+	ID          string               `json:"id"`
+	Status      BulkPublishJobStatus `json:"status"`
+	Total       int                  `json:"total"`
+	Results     []BulkPublishResult  `json:"results"`
+	StartedAt   time.Time            `json:"started_at"`
+	CompletedAt *time.Time           `json:"completed_at,omitempty"`
+	// startedBy is the GitHub user ID of the requester, empty for the registry owner
+	startedBy string
+}
+
+// BulkPublishJobs keeps the bulk publish jobs started on this instance. Jobs are kept in memory and
+// removed a day after they complete.
+type BulkPublishJobs struct {
+	mu   sync.Mutex
+	jobs map[string]*BulkPublishJob
+}
+
+// NewBulkPublishJobs creates an empty set of bulk publish jobs
+func NewBulkPublishJobs() *BulkPublishJobs {
+	return &BulkPublishJobs{jobs: make(map[string]*BulkPublishJob)}
+}
+
+// start records a new running job for the given number of repositories, removing expired jobs
+func (j *BulkPublishJobs) start(total int, startedBy string) *BulkPublishJob {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := time.Now().UTC()
+	for id, job := range j.jobs {
+		if job.CompletedAt != nil && now.Sub(*job.CompletedAt) > bulkPublishJobRetention {
+			delete(j.jobs, id)
+		}
+	}
+
+	job := &BulkPublishJob{
+		ID:        uuid.New().String(),
+		Status:    BulkPublishJobRunning,
+		Total:     total,
+		Results:   []BulkPublishResult{},
+		StartedAt: now,
+		startedBy: startedBy,
+	}
+	j.jobs[job.ID] = job
+	return job
+}
+
+// record adds the result of a repository to a job
+func (j *BulkPublishJobs) record(job *BulkPublishJob, result BulkPublishResult) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	job.Results = append(job.Results, result)
+}
+
+// complete marks a job as completed
+func (j *BulkPublishJobs) complete(job *BulkPublishJob) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	completedAt := time.Now().UTC()
+	job.Status = BulkPublishJobCompleted
+	job.CompletedAt = &completedAt
+}
+
+// get returns a snapshot of the job with the given ID
+func (j *BulkPublishJobs) get(id string) (BulkPublishJob, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	job, ok := j.jobs[id]
+	if !ok {
+		return BulkPublishJob{}, false
+	}
+	snapshot := *job
+	snapshot.Results = append([]BulkPublishResult(nil), job.Results...)
+	return snapshot, true
+}
+
+// BulkPublishOSSHandler handles requests to publish up to 20 open source repositories at once.
+// Repositories are published one after another exactly as by publish-oss, each in its own transaction,
+// and the failure of one does not stop the others. Async requests are published in a background job whose status is served by
+// BulkPublishJobHandler.
+func BulkPublishOSSHandler(registry service.RegistryService, authService auth.Service, jobs *BulkPublishJobs) http.HandlerFunc {
+	authenticated := middleware.RequireAuth(authService)(bulkPublishOSS(registry, authService, jobs))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Only allow POST method
+		if r.Method != http.MethodPost {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		authenticated.ServeHTTP(w, r)
+	}
+}
+
+// bulkPublishOSS handles an authenticated bulk publish request
+func bulkPublishOSS(registry service.RegistryService, authService auth.Service, jobs *BulkPublishJobs) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req BulkPublishOSSRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidPayload, Message: "Invalid request payload: " + err.Error()}, http.StatusBadRequest)
+			return
+		}
+		defer r.Body.Close()
+
+		if len(req.Repositories) == 0 {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "At least one repository is required"}, http.StatusBadRequest)
+			return
+		}
+		if len(req.Repositories) > maxBulkPublishRepositories {
+			WriteError(w, APIError{
+				Code:    ErrCodeInvalidRequest,
+				Message: fmt.Sprintf("At most %d repositories may be published at once", maxBulkPublishRepositories),
+			}, http.StatusBadRequest)
+			return
+		}
+
+		githubAuth, githubToken, ok := ossGitHubAuth(w, r, authService)
+		if !ok {
+			return
+		}
+		remoteIP := middleware.GetRealIP(r)
+		var startedBy, publishedBy string
+		if claims := middleware.GetAuthClaims(r.Context()); claims != nil {
+			startedBy, publishedBy = claims.GitHubUserID, claims.GitHubUsername
+		}
+
+		if !req.Async {
+			results := make([]BulkPublishResult, 0, len(req.Repositories))
+			for i := range req.Repositories {
+				results = append(results, publishBulkRepository(
+					r.Context(), registry, githubAuth, githubToken, &req.Repositories[i], publishedBy, remoteIP))
+			}
+			writeJSON(w, BulkPublishResponse{Results: results})
+			return
+		}
+
+		job := jobs.start(len(req.Repositories), startedBy)
+		go func() {
+			// The job outlives the request that started it, but keeps its request ID for logs
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), bulkPublishTimeout)
+			defer cancel()
+
+			for i := range req.Repositories {
+				jobs.record(job,
+					publishBulkRepository(ctx, registry, githubAuth, githubToken, &req.Repositories[i], publishedBy, remoteIP))
+			}
+			jobs.complete(job)
+			middleware.Logf(ctx, "bulk-publish-oss: Job %s published %d repositories from %s", job.ID, len(req.Repositories), remoteIP)
+		}()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		if err := json.NewEncoder(w).Encode(BulkPublishJobResponse{JobID: job.ID}); err != nil {
+			middleware.Logf(r.Context(), "bulk-publish-oss: Failed to encode response for job %s: %v", job.ID, err)
+		}
+	}
+}
+
+// publishBulkRepository publishes one repository of a bulk publish request and reports the outcome
+func publishBulkRepository(
+	ctx context.Context, registry service.RegistryService, githubAuth auth.GitHubAuth, githubToken string,
+	ossReq *model.PublishOSSRequest, publishedBy, remoteIP string,
+) BulkPublishResult {
+	result := BulkPublishResult{URL: ossReq.RepositoryURL}
+
+	serverDetail, err := publishOSSRepository(ctx, registry, githubAuth, githubToken, ossReq, publishedBy, remoteIP)
+	var publishErr *ossPublishError
+	switch {
+	case err == nil:
+		result.Status = BulkPublishCreated
+		result.ID = serverDetail.ID
+	case errors.As(err, &publishErr) && publishErr.status == http.StatusConflict:
+		result.Status = BulkPublishConflict
+		result.Error = err.Error()
+	default:
+		result.Status = BulkPublishError
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// BulkPublishJobHandler returns a handler for getting the status of a bulk publish job.
+// Jobs are available to the registry owner and to the user who started them.
+func BulkPublishJobHandler(authService auth.Service, jobs *BulkPublishJobs) http.HandlerFunc {
+	authenticated := middleware.RequireAuth(authService)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims := middleware.GetAuthClaims(r.Context())
+		job, ok := jobs.get(r.PathValue("id"))
+		// Jobs of other users are reported as missing rather than revealing they exist
+		if !ok || (claims != nil && claims.GitHubUserID != job.startedBy) {
+			WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Bulk publish job not found"}, http.StatusNotFound)
+			return
+		}
+
+		writeJSON(w, job)
+	}))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		authenticated.ServeHTTP(w, r)
+	}
+}
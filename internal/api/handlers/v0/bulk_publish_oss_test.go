@@ -0,0 +1,178 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubGitHubRepoAPI replaces the default HTTP transport with a fake GitHub API for the duration of the test.
+// Every repository of the example owner exists, is public and written in TypeScript except "missing";
+// none has releases.
+func stubGitHubRepoAPI(t *testing.T) {
+	t.Helper()
+	original := http.DefaultTransport
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		status, body := http.StatusNotFound, `{"message": "Not Found"}`
+		repo, ok := strings.CutPrefix(req.URL.Path, "/repos/example/")
+		if ok && repo != "missing" && !strings.Contains(repo, "/") {
+			status = http.StatusOK
+			topics := `[]`
+			// GitHub only includes repository topics when they are requested with the preview media type
+			if req.Header.Get("Accept") == "application/vnd.github.mercy-preview+json" {
+				topics = `["MCP", "Database", "mcp"]`
+			}
+			body = fmt.Sprintf(`{"id": 1, "name": %q, "html_url": "https://github.com/example/%s", "private": false, "language": "TypeScript",
+				"default_branch": "trunk", "topics": %s}`, repo, repo, topics)
+		}
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}, nil
+	})
+	t.Cleanup(func() {
+		http.DefaultTransport = original
+	})
+}
+
+// ossRepository returns a publish request for the given repository of the example owner
+func ossRepository(repo string) model.PublishOSSRequest {
+	return model.PublishOSSRequest{
+		RepositoryURL: "https://github.com/example/" + repo,
+		Packages:      []model.Package{{RegistryName: "npm", Name: "@example/" + repo, Version: "1.0.0"}},
+	}
+}
+
+func TestBulkPublishOSSHandler(t *testing.T) {
+	stubGitHubRepoAPI(t)
+	authService := auth.NewAuthService(&config.Config{EphemeralTokenSecret: testEphemeralTokenSecret})
+	token := signEphemeralToken(t, time.Hour)
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	jobs := v0.NewBulkPublishJobs()
+
+	require.NoError(t, registry.Publish(&model.ServerDetail{
+		Server: model.Server{
+			Name:          "io.github.example/taken",
+			Repository:    model.Repository{URL: "https://github.com/example/taken", Source: "github"},
+			VersionDetail: model.VersionDetail{Version: "1.0.0"},
+		},
+	}))
+
+	bulkPublish := func(t *testing.T, req v0.BulkPublishOSSRequest) *httptest.ResponseRecorder {
+		t.Helper()
+		body, err := json.Marshal(req)
+		require.NoError(t, err)
+
+		httpReq, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/bulk-publish-oss", bytes.NewReader(body))
+		require.NoError(t, err)
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.BulkPublishOSSHandler(registry, authService, jobs), rr, httpReq)
+		return rr
+	}
+
+	getJob := func(t *testing.T, id string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/admin/bulk-publish-jobs/"+id, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.SetPathValue("id", id)
+
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.BulkPublishJobHandler(authService, jobs), rr, req)
+		return rr
+	}
+
+	t.Run("sync publish reports the outcome of each repository", func(t *testing.T) {
+		withoutPackages := ossRepository("beta")
+		withoutPackages.Packages = nil
+
+		rr := bulkPublish(t, v0.BulkPublishOSSRequest{Repositories: []model.PublishOSSRequest{
+			ossRepository("alpha"), ossRepository("taken"), ossRepository("missing"), withoutPackages,
+		}})
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var resp v0.BulkPublishResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		require.Len(t, resp.Results, 4)
+
+		created := resp.Results[0]
+		assert.Equal(t, "https://github.com/example/alpha", created.URL)
+		assert.Equal(t, v0.BulkPublishCreated, created.Status)
+		assert.Empty(t, created.Error)
+		serverDetail, err := registry.GetByID(created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "io.github.example/alpha", serverDetail.Name)
+
+		assert.Equal(t, v0.BulkPublishConflict, resp.Results[1].Status)
+		assert.Empty(t, resp.Results[1].ID)
+
+		assert.Equal(t, v0.BulkPublishError, resp.Results[2].Status)
+		assert.Contains(t, resp.Results[2].Error, "repository not found")
+
+		assert.Equal(t, v0.BulkPublishError, resp.Results[3].Status)
+		assert.Equal(t, "At least one package is required", resp.Results[3].Error)
+	})
+
+	t.Run("at most 20 repositories", func(t *testing.T) {
+		repositories := make([]model.PublishOSSRequest, 21)
+		for i := range repositories {
+			repositories[i] = ossRepository(fmt.Sprintf("capped-%d", i))
+		}
+
+		rr := bulkPublish(t, v0.BulkPublishOSSRequest{Repositories: repositories})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "At most 20 repositories")
+	})
+
+	t.Run("async publish runs as a job", func(t *testing.T) {
+		rr := bulkPublish(t, v0.BulkPublishOSSRequest{
+			Repositories: []model.PublishOSSRequest{ossRepository("gamma"), ossRepository("alpha")},
+			Async:        true,
+		})
+		require.Equal(t, http.StatusAccepted, rr.Code, rr.Body.String())
+
+		var started v0.BulkPublishJobResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&started))
+		require.NotEmpty(t, started.JobID)
+
+		var job v0.BulkPublishJob
+		require.Eventually(t, func() bool {
+			rr := getJob(t, started.JobID)
+			if rr.Code != http.StatusOK {
+				return false
+			}
+			job = v0.BulkPublishJob{}
+			return json.NewDecoder(rr.Body).Decode(&job) == nil && job.Status == v0.BulkPublishJobCompleted
+		}, 5*time.Second, 10*time.Millisecond)
+
+		assert.Equal(t, 2, job.Total)
+		require.Len(t, job.Results, 2)
+		assert.Equal(t, v0.BulkPublishCreated, job.Results[0].Status)
+		assert.Equal(t, v0.BulkPublishConflict, job.Results[1].Status)
+		assert.NotNil(t, job.CompletedAt)
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		rr := getJob(t, "00000000-0000-0000-0000-000000000000")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -0,0 +1,55 @@
+package v0
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/analytics"
+	"github.com/modelcontextprotocol/registry/internal/api/versioning"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// ServerByNameHandler returns a handler that looks up the latest version of a server by its name, such as
+// io.github.owner/repo, and responds like ServersDetailHandler. The slash of the name may be URL-encoded.
+func ServerByNameHandler(registry service.RegistryService, trending *analytics.Trending) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		generatedAt := time.Now().UTC()
+
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		if _, err := versioning.ParseVersionHeader(r); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: err.Error()}, http.StatusBadRequest)
+			return
+		}
+
+		// Path values are already URL-decoded, so encoded and plain slashes yield the same name
+		name := r.PathValue("name")
+		if name == "" {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Server name is required"}, http.StatusBadRequest)
+			return
+		}
+
+		followSuccessor, err := parseFollowSuccessor(r)
+		if err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid follow_successor parameter"}, http.StatusBadRequest)
+			return
+		}
+
+		serverDetail, err := registry.GetByName(r.Context(), name)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Server not found"}, http.StatusNotFound)
+				return
+			}
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Error retrieving server details"}, http.StatusInternalServerError)
+			return
+		}
+
+		writeServerDetail(w, r, registry, trending, serverDetail, followSuccessor, generatedAt)
+	}
+}
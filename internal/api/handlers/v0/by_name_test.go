@@ -0,0 +1,79 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/api/router"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/events"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerByNameHandler(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		require.NoError(t, registry.Publish(&model.ServerDetail{
+			Server: model.Server{
+				Name:          "io.github.example/weather",
+				Repository:    model.Repository{URL: "https://github.com/example/weather", Source: "github"},
+				VersionDetail: model.VersionDetail{Version: version},
+			},
+		}))
+	}
+
+	server := httptest.NewServer(router.NewHandler(&config.Config{}, registry, nil, events.NewHub(), nil))
+	defer server.Close()
+
+	// get requests the path from the full router without following redirects
+	get := func(t *testing.T, path string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+path, nil)
+		require.NoError(t, err)
+		client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		assert.NotEmpty(t, resp.Header.Get(middleware.RequestIDHeader))
+		return resp
+	}
+
+	for _, path := range []string{"/v0/servers/by-name/io.github.example/weather", "/v0/servers/by-name/io.github.example%2Fweather"} {
+		t.Run("returns the latest version for "+path, func(t *testing.T) {
+			resp := get(t, path)
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.NotEmpty(t, resp.Header.Get("ETag"))
+
+			var detail v0.ResponseEnvelope[model.ServerDetail]
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&detail))
+			assert.Equal(t, "io.github.example/weather", detail.Data.Name)
+			assert.Equal(t, "1.1.0", detail.Data.VersionDetail.Version)
+			assert.NotNil(t, detail.Data.InstallScore)
+		})
+	}
+
+	t.Run("unknown name", func(t *testing.T) {
+		resp := get(t, "/v0/servers/by-name/io.github.example/unknown")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("name in the ID slot redirects permanently", func(t *testing.T) {
+		resp := get(t, "/v0/servers/io.github.example%2Fweather?follow_successor=true")
+		assert.Equal(t, http.StatusMovedPermanently, resp.StatusCode)
+		assert.Equal(t, "/v0/servers/by-name/io.github.example/weather?follow_successor=true", resp.Header.Get("Location"))
+	})
+
+	t.Run("other invalid IDs are rejected", func(t *testing.T) {
+		resp := get(t, "/v0/servers/not-a-uuid")
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
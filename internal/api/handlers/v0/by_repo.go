@@ -0,0 +1,54 @@
+package v0
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// githubRepoRegex matches valid GitHub repository names: alphanumerics, hyphens, underscores and dots
+var githubRepoRegex = regexp.MustCompile(`^[A-Za-z0-9._-]{1,100}$`)
+
+// ServerByRepoHandler returns a handler that looks up the server published from a GitHub repository.
+// The server is found by its io.github.{owner}/{repo} name and the client is redirected to the
+// details of its latest version.
+func ServerByRepoHandler(registry service.RegistryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Path values are already URL-decoded; an encoded slash would otherwise change the server name
+		owner := r.PathValue("owner")
+		repo := r.PathValue("repo")
+		if !githubUsernameRegex.MatchString(owner) {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid repository owner"}, http.StatusBadRequest)
+			return
+		}
+		if !githubRepoRegex.MatchString(repo) || repo == "." || repo == ".." {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid repository name"}, http.StatusBadRequest)
+			return
+		}
+
+		name := fmt.Sprintf("io.github.%s/%s", owner, repo)
+		serverDetail, err := registry.GetByName(r.Context(), name)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: fmt.Sprintf(
+					"Server %s not found. To publish it, use POST /v0/publish-oss with repository_url https://github.com/%s/%s",
+					name, owner, repo,
+				)}, http.StatusNotFound)
+				return
+			}
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Error retrieving server details"}, http.StatusInternalServerError)
+			return
+		}
+
+		http.Redirect(w, r, "/v0/servers/"+serverDetail.ID, http.StatusTemporaryRedirect)
+	}
+}
@@ -0,0 +1,117 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/api/router"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/events"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerByRepoHandler(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	for _, name := range []string{"io.github.example/weather", "io.github.example/my-server.js"} {
+		for _, version := range []string{"1.0.0", "1.1.0"} {
+			require.NoError(t, registry.Publish(&model.ServerDetail{
+				Server: model.Server{
+					Name:          name,
+					Repository:    model.Repository{URL: "https://github.com/" + name[len("io.github."):], Source: "github"},
+					VersionDetail: model.VersionDetail{Version: version},
+				},
+			}))
+		}
+	}
+
+	server := httptest.NewServer(router.NewHandler(&config.Config{}, registry, nil, events.NewHub(), nil))
+	defer server.Close()
+
+	// get requests the path from the full router without following redirects
+	get := func(t *testing.T, path string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+path, nil)
+		require.NoError(t, err)
+		client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		assert.NotEmpty(t, resp.Header.Get(middleware.RequestIDHeader))
+		return resp
+	}
+
+	t.Run("existing server redirects to its latest version", func(t *testing.T) {
+		latest, err := registry.GetByName(context.Background(), "io.github.example/weather")
+		require.NoError(t, err)
+		assert.Equal(t, "1.1.0", latest.VersionDetail.Version)
+
+		resp := get(t, "/v0/servers/by-repo/example/weather")
+		assert.Equal(t, http.StatusTemporaryRedirect, resp.StatusCode)
+		assert.Equal(t, "/v0/servers/"+latest.ID, resp.Header.Get("Location"))
+	})
+
+	t.Run("redirect chain ends at the server details", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(
+			context.Background(), http.MethodGet, server.URL+"/v0/servers/by-repo/example/weather", nil,
+		)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var detail v0.ResponseEnvelope[model.ServerDetail]
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&detail))
+		assert.Equal(t, "io.github.example/weather", detail.Data.Name)
+		assert.True(t, detail.Data.VersionDetail.IsLatest)
+	})
+
+	t.Run("URL-encoded repository names", func(t *testing.T) {
+		latest, err := registry.GetByName(context.Background(), "io.github.example/my-server.js")
+		require.NoError(t, err)
+
+		for _, path := range []string{"/v0/servers/by-repo/example/my-server.js", "/v0/servers/by-repo/example/my%2Dserver%2Ejs"} {
+			resp := get(t, path)
+			assert.Equal(t, http.StatusTemporaryRedirect, resp.StatusCode, path)
+			assert.Equal(t, "/v0/servers/"+latest.ID, resp.Header.Get("Location"), path)
+		}
+	})
+
+	t.Run("non-existent server suggests publishing it", func(t *testing.T) {
+		resp := get(t, "/v0/servers/by-repo/example/unpublished")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), "io.github.example/unpublished")
+		assert.Contains(t, string(body), "POST /v0/publish-oss")
+	})
+
+	t.Run("invalid owner or repository", func(t *testing.T) {
+		for _, path := range []string{
+			"/v0/servers/by-repo/-example/weather",
+			"/v0/servers/by-repo/example/a%2Fb",
+			"/v0/servers/by-repo/example/%2E%2E",
+		} {
+			resp := get(t, path)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode, path)
+		}
+	})
+
+	t.Run("overlapping server routes still resolve", func(t *testing.T) {
+		latest, err := registry.GetByName(context.Background(), "io.github.example/weather")
+		require.NoError(t, err)
+
+		resp := get(t, "/v0/servers/"+latest.ID+"/versions")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
@@ -0,0 +1,30 @@
+package v0
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// CategoriesHandler returns a handler listing all server categories with the number of servers in each
+func CategoriesHandler(registry service.RegistryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		generatedAt := time.Now().UTC()
+
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		categories, err := registry.ListCategories(r.Context())
+		if err != nil {
+			middleware.Logf(r.Context(), "Error listing categories: %v", err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to list categories"}, http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, NewResponseEnvelope(categories, generatedAt))
+	}
+}
@@ -0,0 +1,60 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCategoriesHandler(t *testing.T) {
+	t.Run("lists categories with counts", func(t *testing.T) {
+		counts := []model.CategoryCount{
+			{Category: model.CategoryDatabase, Count: 2},
+			{Category: model.CategoryOther, Count: 0},
+		}
+		mockRegistry := new(MockRegistryService)
+		mockRegistry.Mock.On("ListCategories", mock.Anything).Return(counts, nil)
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/categories", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.CategoriesHandler(mockRegistry), rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var resp v0.ResponseEnvelope[[]model.CategoryCount]
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		assert.Equal(t, v0.APIVersion, resp.APIVersion)
+		assert.Equal(t, counts, resp.Data)
+		mockRegistry.Mock.AssertExpectations(t)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		mockRegistry := new(MockRegistryService)
+		mockRegistry.Mock.On("ListCategories", mock.Anything).Return([]model.CategoryCount(nil), errors.New("database error"))
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/categories", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.CategoriesHandler(mockRegistry), rr, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/categories", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.CategoriesHandler(new(MockRegistryService)), rr, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
@@ -0,0 +1,145 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/vcs"
+)
+
+const (
+	// changelogTTL is how long the changelog of a server is cached
+	changelogTTL = 30 * time.Minute
+	// defaultChangelogLimit is the number of releases returned unless the limit parameter says otherwise
+	defaultChangelogLimit = 10
+	// changelogBreakerThreshold is the number of consecutive failed GitHub calls after which changelogs are not
+	// fetched for changelogBreakerCooldown
+	changelogBreakerThreshold = 5
+	changelogBreakerCooldown  = time.Minute
+)
+
+// cachedChangelog holds the releases of a server together with their expiry time
+type cachedChangelog struct {
+	entries   []model.ChangelogEntry
+	expiresAt time.Time
+}
+
+// changelogCache fetches the releases of servers from GitHub, caching them per server.
+// Calls to GitHub go through a circuit breaker, so failures and rate limits pause fetching.
+type changelogCache struct {
+	breaker *vcs.CircuitBreaker
+	mu      sync.Mutex
+	entries map[string]cachedChangelog
+}
+
+// get returns the releases of the repository of a server, newest first, fetching them if they are not cached
+func (c *changelogCache) get(ctx context.Context, serverID, owner, repo string) ([]model.ChangelogEntry, error) {
+	c.mu.Lock()
+	cached, ok := c.entries[serverID]
+	c.mu.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.entries, nil
+	}
+
+	var releases []vcs.GitHubRelease
+	err := c.breaker.Do(func() (err error) {
+		releases, err = vcs.FetchGitHubReleases(ctx, owner, repo, vcs.MaxGitHubReleases)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]model.ChangelogEntry, len(releases))
+	for i, release := range releases {
+		entries[i] = model.ChangelogEntry{
+			Version:     strings.TrimPrefix(release.TagName, "v"),
+			ReleaseDate: release.PublishedAt,
+			Title:       release.Name,
+			Body:        release.Body,
+			HTMLURL:     release.HTMLURL,
+		}
+	}
+
+	c.mu.Lock()
+	c.entries[serverID] = cachedChangelog{entries: entries, expiresAt: time.Now().Add(changelogTTL)}
+	c.mu.Unlock()
+
+	return entries, nil
+}
+
+// ChangelogHandler returns a handler for the release history of a server, built from the releases of its
+// GitHub repository, newest first. Changelogs are cached for 30 minutes. Servers whose repository is not on
+// GitHub have an empty changelog.
+func ChangelogHandler(registry service.RegistryService) http.HandlerFunc {
+	changelogs := &changelogCache{
+		breaker: vcs.NewCircuitBreaker(changelogBreakerThreshold, changelogBreakerCooldown),
+		entries: make(map[string]cachedChangelog),
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		limit := defaultChangelogLimit
+		if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+			parsedLimit, err := strconv.Atoi(limitStr)
+			if err != nil || parsedLimit <= 0 {
+				WriteError(w, APIError{Code: ErrCodeInvalidLimit, Message: "Invalid limit parameter"}, http.StatusBadRequest)
+				return
+			}
+			limit = min(parsedLimit, vcs.MaxGitHubReleases)
+		}
+
+		serverMeta, err := registry.GetMetadata(r.Context(), id)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Server not found"}, http.StatusNotFound)
+				return
+			}
+			middleware.Logf(r.Context(), "changelog: Failed to look up server %s: %v", id, err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Error retrieving server"}, http.StatusInternalServerError)
+			return
+		}
+
+		owner, repo, err := extractGitHubRepo(serverMeta.Repository.URL)
+		if err != nil {
+			writeJSON(w, []model.ChangelogEntry{})
+			return
+		}
+
+		entries, err := changelogs.get(r.Context(), id, owner, repo)
+		if err != nil {
+			if errors.Is(err, vcs.ErrCircuitOpen) {
+				WriteError(w, APIError{
+					Code:    ErrCodeUnavailable,
+					Message: "GitHub is temporarily unavailable, try again later",
+				}, http.StatusServiceUnavailable)
+				return
+			}
+			middleware.Logf(r.Context(), "changelog: Failed to fetch releases of %s/%s: %v", owner, repo, err)
+			WriteError(w, APIError{Code: ErrCodeUpstream, Message: "Failed to fetch releases from GitHub"}, http.StatusBadGateway)
+			return
+		}
+
+		writeJSON(w, entries[:min(limit, len(entries))])
+	}
+}
@@ -0,0 +1,145 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubGitHubReleasesAPI replaces the default HTTP transport with a fake GitHub releases API for the duration of
+// the test. The example/released repository has two releases; every other repository has none.
+// It returns the number of requests made to the API.
+func stubGitHubReleasesAPI(t *testing.T) *atomic.Int32 {
+	t.Helper()
+	var calls atomic.Int32
+	original := http.DefaultTransport
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		body := `[]`
+		if req.URL.Path == "/repos/example/released/releases" {
+			body = `[
+				{"tag_name": "v2.0.0", "name": "Second release", "body": "Breaking changes",
+				 "html_url": "https://github.com/example/released/releases/tag/v2.0.0", "published_at": "2025-06-01T12:00:00Z"},
+				{"tag_name": "1.0.0", "name": "First release", "body": "Initial release",
+				 "html_url": "https://github.com/example/released/releases/tag/1.0.0", "published_at": "2025-05-01T12:00:00Z"}
+			]`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}, nil
+	})
+	t.Cleanup(func() {
+		http.DefaultTransport = original
+	})
+	return &calls
+}
+
+func TestChangelogHandler(t *testing.T) {
+	calls := stubGitHubReleasesAPI(t)
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	handler := v0.ChangelogHandler(registry)
+
+	publishRepository := func(t *testing.T, name, repositoryURL string) string {
+		t.Helper()
+		serverDetail := &model.ServerDetail{
+			Server: model.Server{
+				Name:          "io.github.example/" + name,
+				Repository:    model.Repository{URL: repositoryURL, Source: "github"},
+				VersionDetail: model.VersionDetail{Version: "1.0.0"},
+			},
+		}
+		require.NoError(t, registry.Publish(serverDetail))
+		return serverDetail.ID
+	}
+
+	getChangelog := func(t *testing.T, id, query string) []model.ChangelogEntry {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers/"+id+"/changelog"+query, nil)
+		require.NoError(t, err)
+		req.SetPathValue("id", id)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, handler, rr, req)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var entries []model.ChangelogEntry
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&entries))
+		require.NotNil(t, entries)
+		return entries
+	}
+
+	released := publishRepository(t, "released", "https://github.com/example/released")
+
+	t.Run("maps releases to changelog entries", func(t *testing.T) {
+		entries := getChangelog(t, released, "")
+		assert.Equal(t, []model.ChangelogEntry{
+			{
+				Version:     "2.0.0",
+				ReleaseDate: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+				Title:       "Second release",
+				Body:        "Breaking changes",
+				HTMLURL:     "https://github.com/example/released/releases/tag/v2.0.0",
+			},
+			{
+				Version:     "1.0.0",
+				ReleaseDate: time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC),
+				Title:       "First release",
+				Body:        "Initial release",
+				HTMLURL:     "https://github.com/example/released/releases/tag/1.0.0",
+			},
+		}, entries)
+	})
+
+	t.Run("changelogs are cached per server", func(t *testing.T) {
+		before := calls.Load()
+		entries := getChangelog(t, released, "?limit=1")
+		require.Len(t, entries, 1)
+		assert.Equal(t, "2.0.0", entries[0].Version)
+		assert.Equal(t, before, calls.Load())
+	})
+
+	t.Run("repository without releases", func(t *testing.T) {
+		id := publishRepository(t, "unreleased", "https://github.com/example/unreleased")
+		assert.Empty(t, getChangelog(t, id, ""))
+	})
+
+	t.Run("repository not on GitHub", func(t *testing.T) {
+		before := calls.Load()
+		id := publishRepository(t, "elsewhere", "https://gitlab.com/example/elsewhere")
+		assert.Empty(t, getChangelog(t, id, ""))
+		assert.Equal(t, before, calls.Load())
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers/"+released+"/changelog?limit=0", nil)
+		require.NoError(t, err)
+		req.SetPathValue("id", released)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, handler, rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("unknown server", func(t *testing.T) {
+		id := "00000000-0000-0000-0000-000000000000"
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers/"+id+"/changelog", nil)
+		require.NoError(t, err)
+		req.SetPathValue("id", id)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, handler, rr, req)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
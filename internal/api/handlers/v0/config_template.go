@@ -0,0 +1,79 @@
+package v0
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// ConfigTemplateHandler returns a handler for a configuration snippet users can paste into the configuration
+// file of their MCP client to start a server from one of its packages. The package is chosen by the registry
+// query parameter and defaults to the first package; the client defaults to Claude Desktop.
+func ConfigTemplateHandler(registry service.RegistryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		client := r.URL.Query().Get("client")
+		if client == "" {
+			client = model.ConfigClientClaudeDesktop
+		}
+
+		serverDetail, err := registry.GetByID(id)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Server not found"}, http.StatusNotFound)
+				return
+			}
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Error retrieving server details"}, http.StatusInternalServerError)
+			return
+		}
+
+		pkg := packageByRegistry(serverDetail, r.URL.Query().Get("registry"))
+		if pkg == nil {
+			WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Package not found"}, http.StatusNotFound)
+			return
+		}
+
+		config, err := model.GetConfigTemplate(*pkg, client)
+		if err != nil {
+			switch {
+			case errors.Is(err, model.ErrUnsupportedConfigClient):
+				WriteError(w, APIError{
+					Code:    ErrCodeInvalidRequest,
+					Message: "Invalid client parameter: must be claude-desktop or vscode",
+				}, http.StatusBadRequest)
+			case errors.Is(err, model.ErrUnsupportedConfigRegistry):
+				WriteError(w, APIError{
+					Code:    ErrCodeInvalidRequest,
+					Message: "Config templates are not supported for packages of this registry",
+				}, http.StatusBadRequest)
+			default:
+				middleware.Logf(r.Context(), "config-template: Failed to generate config for %s: %v", id, err)
+				WriteError(w, APIError{
+					Code:    ErrCodeInternal,
+					Message: "Failed to generate config template",
+				}, http.StatusInternalServerError)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write(config); err != nil {
+			middleware.Logf(r.Context(), "Error writing config template for server %s: %v", id, err)
+		}
+	}
+}
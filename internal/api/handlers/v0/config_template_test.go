@@ -0,0 +1,79 @@
+package v0_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigTemplateHandler(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	serverDetail := &model.ServerDetail{
+		Server: model.Server{
+			Name:          "io.github.example/config-server",
+			Repository:    model.Repository{URL: "https://github.com/example/config-server", Source: "github"},
+			VersionDetail: model.VersionDetail{Version: "1.0.0"},
+		},
+		Packages: []model.Package{
+			{RegistryName: "npm", Name: "@example/config-server", Version: "1.0.0"},
+			{RegistryName: "docker", Name: "example/config-server", Version: "1.0.0"},
+			{RegistryName: "nuget", Name: "Example.ConfigServer", Version: "1.0.0"},
+		},
+	}
+	require.NoError(t, registry.Publish(serverDetail))
+	handler := v0.ConfigTemplateHandler(registry)
+
+	getConfig := func(t *testing.T, id, query string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers/"+id+"/config-template"+query, nil)
+		require.NoError(t, err)
+		req.SetPathValue("id", id)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, handler, rr, req)
+		return rr
+	}
+
+	t.Run("first package for Claude Desktop by default", func(t *testing.T) {
+		rr := getConfig(t, serverDetail.ID, "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"mcpServers": {"config-server": {"command": "npx", "args": ["-y", "@example/config-server@1.0.0"]}}}`,
+			rr.Body.String())
+	})
+
+	t.Run("VS Code", func(t *testing.T) {
+		rr := getConfig(t, serverDetail.ID, "?registry=docker&client=vscode")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.JSONEq(t, `{"servers": {"config-server": {
+			"type": "stdio", "command": "docker", "args": ["run", "-i", "--rm", "example/config-server:1.0.0"]
+		}}}`, rr.Body.String())
+	})
+
+	tests := []struct {
+		name           string
+		id             string
+		query          string
+		expectedStatus int
+	}{
+		{"unknown client", serverDetail.ID, "?client=emacs", http.StatusBadRequest},
+		{"missing package", serverDetail.ID, "?registry=pypi", http.StatusNotFound},
+		{"unsupported registry", serverDetail.ID, "?registry=nuget", http.StatusBadRequest},
+		{"unknown server", uuid.New().String(), "", http.StatusNotFound},
+		{"invalid ID", "not-a-uuid", "", http.StatusBadRequest},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := getConfig(t, tc.id, tc.query)
+			assert.Equal(t, tc.expectedStatus, rr.Code, rr.Body.String())
+		})
+	}
+}
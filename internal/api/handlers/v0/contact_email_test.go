@@ -0,0 +1,121 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaskEmail(t *testing.T) {
+	testCases := map[string]string{
+		"security@example.com": "se***@example.com",
+		"ab@example.com":       "ab***@example.com",
+		"a@example.com":        "a***@example.com",
+	}
+	for email, expected := range testCases {
+		assert.Equal(t, expected, model.MaskEmail(email), email)
+	}
+}
+
+func TestContactEmail(t *testing.T) {
+	const email = "security@example.com"
+
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	serverDetail := &model.ServerDetail{
+		Server: model.Server{
+			Name:          "io.github.example/contact-server",
+			Repository:    model.Repository{URL: "https://github.com/example/contact-server", Source: "github"},
+			VersionDetail: model.VersionDetail{Version: "1.0.0"},
+			ContactEmail:  email,
+		},
+	}
+	require.NoError(t, registry.Publish(serverDetail))
+
+	mockAuthService := new(MockAuthService)
+	mockAuthService.Mock.On("ValidateRegistryOwnerAuth", mock.Anything, "owner-token").Return(true, nil)
+	mockAuthService.Mock.On("ValidateAuth", mock.Anything, mock.Anything).Return(true, nil)
+
+	serve := func(t *testing.T, handler http.Handler, method, target string, body []byte) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), method, target, bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer owner-token")
+		req.SetPathValue("id", serverDetail.ID)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, handler, rr, req)
+		return rr
+	}
+
+	t.Run("valid email is stored", func(t *testing.T) {
+		stored, err := registry.GetByID(serverDetail.ID)
+		require.NoError(t, err)
+		assert.Equal(t, email, stored.ContactEmail)
+	})
+
+	t.Run("invalid email is rejected on publish", func(t *testing.T) {
+		for _, invalid := range []string{"not-an-email", "Security Team <security@example.com>"} {
+			body, err := json.Marshal(model.ServerDetail{
+				Server: model.Server{
+					Name:          "io.github.example/invalid-contact",
+					Repository:    model.Repository{URL: "https://github.com/example/invalid-contact"},
+					VersionDetail: model.VersionDetail{Version: "1.0.0"},
+					ContactEmail:  invalid,
+				},
+			})
+			require.NoError(t, err)
+
+			rr := serve(t, v0.PublishHandler(registry, mockAuthService), http.MethodPost, "/v0/publish", body)
+			assert.Equal(t, http.StatusUnprocessableEntity, rr.Code, invalid)
+
+			var resp v0.ValidationErrorResponse
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+			require.Len(t, resp.Errors, 1)
+			assert.Equal(t, "contact_email", resp.Errors[0].Field)
+		}
+	})
+
+	t.Run("full email appears in admin endpoint", func(t *testing.T) {
+		rr := serve(t, v0.AdminServersHandler(registry, mockAuthService), http.MethodGet, "/v0/admin/servers", nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp v0.PaginatedResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		require.Len(t, resp.Data, 1)
+		assert.Equal(t, email, resp.Data[0].ContactEmail)
+	})
+
+	t.Run("masked email appears in public endpoints", func(t *testing.T) {
+		for name, handler := range map[string]http.Handler{
+			"list":     v0.ServersHandler(registry),
+			"detail":   v0.ServersDetailHandler(registry, nil),
+			"metadata": v0.ServerMetadataHandler(registry),
+		} {
+			rr := serve(t, handler, http.MethodGet, "/v0/servers", nil)
+			require.Equal(t, http.StatusOK, rr.Code, name)
+			assert.NotContains(t, rr.Body.String(), email, name)
+			assert.Contains(t, rr.Body.String(), `"masked_contact_email":"se***@example.com"`, name)
+		}
+	})
+
+	t.Run("admin endpoint requires the registry owner", func(t *testing.T) {
+		mockAuthService.Mock.On("ValidateRegistryOwnerAuth", mock.Anything, "user-token").Return(false, nil)
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/admin/servers", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer user-token")
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.AdminServersHandler(registry, mockAuthService), rr, req)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
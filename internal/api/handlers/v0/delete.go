@@ -0,0 +1,94 @@
+package v0
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// DeleteHandler handles requests to unpublish a server version. Only the current publisher of the server,
+// one of its maintainers or the registry owner may delete it. If the version was the latest, the highest
+// remaining version that is not yanked becomes the latest.
+func DeleteHandler(registry service.RegistryService, authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Only allow DELETE method
+		if r.Method != http.MethodDelete {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Extract the server ID from the URL path
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		serverDetail, deletedBy, ok := authorizePublisher(w, r, registry, authService, id)
+		if !ok {
+			return
+		}
+
+		if !checkIfMatch(w, r, serverDetail) {
+			return
+		}
+
+		if err := registry.Delete(id); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Server not found"}, http.StatusNotFound)
+				return
+			}
+			middleware.Logf(r.Context(), "delete: Failed to delete server %s: %v", id, err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to delete server: " + err.Error()}, http.StatusInternalServerError)
+			return
+		}
+
+		middleware.Logf(r.Context(), "delete: Deleted server %s (ID: %s, version %s) by %s from %s",
+			serverDetail.Name, id, serverDetail.VersionDetail.Version, deletedBy, middleware.GetRealIP(r))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// authorizePublisher authenticates the request and checks that the caller is the current publisher of the
+// server, one of its maintainers or the registry owner. It returns the server and the name of the caller for
+// logs; if the caller may not change the server it writes an error response and returns false.
+func authorizePublisher(
+	w http.ResponseWriter, r *http.Request, registry service.RegistryService, authService auth.Service, id string,
+) (*model.ServerDetail, string, bool) {
+	serverDetail, claims, ok := authorizePublisherClaims(w, r, registry, authService, id)
+	if !ok {
+		return nil, "", false
+	}
+	if claims == nil {
+		return serverDetail, "registry-owner", true
+	}
+	return serverDetail, claims.GitHubUsername, true
+}
+
+// authorizePublisherClaims performs the checks of authorizePublisher and returns the claims of the caller,
+// which are nil for the registry owner
+func authorizePublisherClaims(
+	w http.ResponseWriter, r *http.Request, registry service.RegistryService, authService auth.Service, id string,
+) (*model.ServerDetail, *auth.EphemeralTokenClaims, bool) {
+	serverDetail, claims, ok := authenticateServerRequest(w, r, registry, authService, id)
+	if !ok {
+		return nil, nil, false
+	}
+
+	// The registry owner may change any server
+	if claims != nil && !serverDetail.IsMaintainer(claims.GitHubUsername) {
+		WriteError(w, APIError{
+			Code:    ErrCodeForbidden,
+			Message: "Only the publisher, maintainers or registry owner can modify this server",
+		}, http.StatusForbidden)
+		return nil, nil, false
+	}
+
+	return serverDetail, claims, true
+}
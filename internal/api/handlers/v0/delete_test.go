@@ -0,0 +1,159 @@
+package v0_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/events"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// publishVersionBy publishes a version of a server as the given GitHub user
+func publishVersionBy(t *testing.T, registry service.RegistryService, version, publishedBy string) string {
+	t.Helper()
+	serverDetail := &model.ServerDetail{
+		Server: model.Server{
+			Name:          "io.github.example/owned-server",
+			Repository:    model.Repository{URL: "https://github.com/example/owned-server", Source: "github"},
+			VersionDetail: model.VersionDetail{Version: version},
+			PublishedBy:   publishedBy,
+		},
+	}
+	require.NoError(t, registry.Publish(serverDetail))
+	return serverDetail.ID
+}
+
+// deleteServer calls the delete handler as the user described by claims, or the registry owner if claims is nil
+func deleteServer(
+	t *testing.T, registry service.RegistryService, claims *auth.EphemeralTokenClaims, method, id string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+	mockAuthService := new(MockAuthService)
+	mockAuthService.Mock.On("ValidateEphemeralOrOwnerToken", mock.Anything, "test-token").Return(true, claims, nil)
+
+	req, err := http.NewRequestWithContext(context.Background(), method, "/v0/servers/"+id, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer test-token")
+	req.SetPathValue("id", id)
+
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.DeleteHandler(registry, mockAuthService), rr, req)
+	return rr
+}
+
+func TestDeleteHandler(t *testing.T) {
+	publisherClaims := &auth.EphemeralTokenClaims{GitHubUsername: "example"}
+
+	t.Run("publisher deletes a version", func(t *testing.T) {
+		hub := events.NewHub()
+		defer hub.Close()
+		eventsCh, unsubscribe := hub.Subscribe()
+		defer unsubscribe()
+
+		registry := service.NewEventingRegistryService(
+			service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{})), hub,
+		)
+		oldID := publishVersionBy(t, registry, "1.0.0", "example")
+		latestID := publishVersionBy(t, registry, "1.1.0", "example")
+
+		rr := deleteServer(t, registry, &auth.EphemeralTokenClaims{GitHubUsername: "Example"}, http.MethodDelete, latestID)
+		require.Equal(t, http.StatusNoContent, rr.Code, rr.Body.String())
+
+		_, err := registry.GetByID(latestID)
+		require.ErrorIs(t, err, database.ErrNotFound)
+		assert.True(t, getServer(t, registry, oldID).VersionDetail.IsLatest)
+
+		// Skip the published events to reach the deleted event
+		var event events.Event
+		for event.Type != events.EventDeleted {
+			event = <-eventsCh
+		}
+		assert.Equal(t, latestID, event.ServerID)
+		assert.Equal(t, "io.github.example/owned-server", event.Name)
+	})
+
+	t.Run("registry owner deletes any version", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersionBy(t, registry, "1.0.0", "")
+
+		rr := deleteServer(t, registry, nil, http.MethodDelete, id)
+		require.Equal(t, http.StatusNoContent, rr.Code)
+
+		_, err := registry.GetByID(id)
+		require.ErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("other users are forbidden", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersionBy(t, registry, "1.0.0", "example")
+
+		rr := deleteServer(t, registry, &auth.EphemeralTokenClaims{GitHubUsername: "someone-else"}, http.MethodDelete, id)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		_, err := registry.GetByID(id)
+		require.NoError(t, err)
+	})
+
+	t.Run("maintainer deletes a version", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersionBy(t, registry, "1.0.0", "example")
+
+		rr := addMaintainer(t, registry, publisherClaims, id, "co-maintainer", true)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		rr = deleteServer(t, registry, &auth.EphemeralTokenClaims{GitHubUsername: "co-maintainer"}, http.MethodDelete, id)
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	})
+
+	t.Run("versions without a recorded publisher", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersionBy(t, registry, "1.0.0", "")
+
+		// The owner of the namespace publishes servers without a recorded publisher
+		rr := deleteServer(t, registry, publisherClaims, http.MethodDelete, id)
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	})
+
+	t.Run("new owner deletes after a transfer", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		oldID := publishVersionBy(t, registry, "1.0.0", "example")
+		latestID := publishVersionBy(t, registry, "1.1.0", "example")
+
+		rr := transferServer(t, registry, publisherClaims, latestID, "new-owner", true, nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		// The previous owner lost access even though they published the versions
+		rr = deleteServer(t, registry, publisherClaims, http.MethodDelete, latestID)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		rr = deleteServer(t, registry, &auth.EphemeralTokenClaims{GitHubUsername: "new-owner"}, http.MethodDelete, latestID)
+		require.Equal(t, http.StatusNoContent, rr.Code)
+		assert.True(t, getServer(t, registry, oldID).VersionDetail.IsLatest)
+	})
+
+	t.Run("unknown server", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		rr := deleteServer(t, registry, nil, http.MethodDelete, "00000000-0000-0000-0000-000000000000")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("only DELETE is allowed", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersionBy(t, registry, "1.0.0", "example")
+
+		rr := deleteServer(t, registry, publisherClaims, http.MethodPost, id)
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+
+		_, err := registry.GetByID(id)
+		require.NoError(t, err)
+	})
+}
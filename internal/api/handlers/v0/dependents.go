@@ -0,0 +1,47 @@
+package v0
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// DependentsCountResponse represents the number of server versions depending on a server
+type DependentsCountResponse struct {
+	ServerID       string `json:"server_id"`
+	DependentCount int64  `json:"dependent_count"`
+}
+
+// DependentsCountHandler returns a handler for the number of server versions depending on a server.
+// Counts are cached briefly, so a newly published dependent may not be counted right away.
+func DependentsCountHandler(registry service.RegistryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		count, err := registry.CountDependents(r.Context(), id)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Server not found"}, http.StatusNotFound)
+				return
+			}
+			middleware.Logf(r.Context(), "dependents: Failed to count dependents of %s: %v", id, err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Error counting dependents"}, http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, DependentsCountResponse{ServerID: id, DependentCount: count})
+	}
+}
@@ -0,0 +1,70 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependentsCountHandler(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	target := publishWithSchema(t, registry, "target", nil)
+	standalone := publishWithSchema(t, registry, "standalone", nil)
+	for _, name := range []string{"first", "second", "third"} {
+		require.NoError(t, registry.Publish(&model.ServerDetail{
+			Server: model.Server{
+				Name:          "io.github.example/" + name,
+				Repository:    model.Repository{URL: "https://github.com/example/" + name, Source: "github"},
+				VersionDetail: model.VersionDetail{Version: "1.0.0"},
+			},
+			Dependencies: []string{target},
+		}))
+	}
+
+	countDependents := func(t *testing.T, id string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers/"+id+"/dependents/count", nil)
+		require.NoError(t, err)
+		req.SetPathValue("id", id)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.DependentsCountHandler(registry), rr, req)
+		return rr
+	}
+
+	tests := []struct {
+		name       string
+		id         string
+		wantStatus int
+		wantCount  int64
+	}{
+		{name: "server with dependents", id: target, wantStatus: http.StatusOK, wantCount: 3},
+		{name: "server without dependents", id: standalone, wantStatus: http.StatusOK},
+		{name: "unknown server", id: uuid.New().String(), wantStatus: http.StatusNotFound},
+		{name: "invalid ID", id: "not-a-uuid", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := countDependents(t, tt.id)
+			require.Equal(t, tt.wantStatus, rr.Code, rr.Body.String())
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var resp v0.DependentsCountResponse
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+			assert.Equal(t, tt.id, resp.ServerID)
+			assert.Equal(t, tt.wantCount, resp.DependentCount)
+		})
+	}
+}
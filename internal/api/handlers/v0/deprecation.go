@@ -0,0 +1,30 @@
+package v0
+
+import (
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// maxSuccessorHops bounds how many successors are looked up when checking a deprecated server for a cycle
+const maxSuccessorHops = 10
+
+// hasSuccessorCycle reports whether following the successors of a deprecated server leads back to a server
+// already visited, so redirecting to the successor would loop forever. Chains longer than maxSuccessorHops
+// are treated as cycles. A successor that cannot be looked up ends the chain.
+func hasSuccessorCycle(registry service.RegistryService, serverDetail *model.ServerDetail) bool {
+	visited := map[string]bool{serverDetail.ID: true}
+	next := serverDetail.SuccessorID
+	for range maxSuccessorHops {
+		if visited[next] {
+			return true
+		}
+		visited[next] = true
+
+		successor, err := registry.GetByID(next)
+		if err != nil || !successor.Deprecated || successor.SuccessorID == "" {
+			return false
+		}
+		next = successor.SuccessorID
+	}
+	return true
+}
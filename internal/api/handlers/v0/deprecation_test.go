@@ -0,0 +1,94 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServersDetailHandlerFollowSuccessor(t *testing.T) {
+	ids := make(map[string]string)
+	for _, name := range []string{"current", "deprecated", "orphaned", "cycle-a", "cycle-b"} {
+		ids[name] = uuid.New().String()
+	}
+	server := func(name, successor string, deprecated bool) *model.Server {
+		return &model.Server{
+			ID:            ids[name],
+			Name:          "io.github.example/" + name,
+			VersionDetail: model.VersionDetail{Version: "1.0.0", IsLatest: true},
+			Deprecated:    deprecated,
+			SuccessorID:   ids[successor],
+		}
+	}
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{
+		ids["current"]:    server("current", "", false),
+		ids["deprecated"]: server("deprecated", "current", true),
+		ids["orphaned"]:   server("orphaned", "", true),
+		ids["cycle-a"]:    server("cycle-a", "cycle-b", true),
+		ids["cycle-b"]:    server("cycle-b", "cycle-a", true),
+	}))
+
+	get := func(t *testing.T, id, query string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers/"+id+query, nil)
+		require.NoError(t, err)
+		req.SetPathValue("id", id)
+
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.ServersDetailHandler(registry, nil), rr, req)
+		return rr
+	}
+	decode := func(t *testing.T, rr *httptest.ResponseRecorder) model.ServerDetail {
+		t.Helper()
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var resp v0.ResponseEnvelope[model.ServerDetail]
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		return resp.Data
+	}
+
+	t.Run("deprecated with successor redirects", func(t *testing.T) {
+		rr := get(t, ids["deprecated"], "?follow_successor=true")
+		assert.Equal(t, http.StatusMovedPermanently, rr.Code)
+		assert.Equal(t, "/v0/servers/"+ids["current"]+"?follow_successor=true", rr.Header().Get("Location"))
+	})
+
+	t.Run("without the parameter the deprecation is reported", func(t *testing.T) {
+		serverDetail := decode(t, get(t, ids["deprecated"], ""))
+		assert.True(t, serverDetail.Deprecated)
+		assert.Equal(t, ids["current"], serverDetail.SuccessorID)
+		assert.False(t, serverDetail.DeprecationCycle)
+	})
+
+	t.Run("deprecated without successor", func(t *testing.T) {
+		serverDetail := decode(t, get(t, ids["orphaned"], "?follow_successor=true"))
+		assert.True(t, serverDetail.Deprecated)
+		assert.Empty(t, serverDetail.SuccessorID)
+	})
+
+	t.Run("servers that are not deprecated are returned", func(t *testing.T) {
+		serverDetail := decode(t, get(t, ids["current"], "?follow_successor=true"))
+		assert.False(t, serverDetail.Deprecated)
+	})
+
+	t.Run("circular successors are reported instead of redirecting", func(t *testing.T) {
+		serverDetail := decode(t, get(t, ids["cycle-a"], "?follow_successor=true"))
+		assert.True(t, serverDetail.Deprecated)
+		assert.Equal(t, ids["cycle-b"], serverDetail.SuccessorID)
+		assert.True(t, serverDetail.DeprecationCycle)
+	})
+
+	t.Run("invalid parameter", func(t *testing.T) {
+		rr := get(t, ids["deprecated"], "?follow_successor=maybe")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -0,0 +1,89 @@
+package v0
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// APIVersion is the API version reported in all v0 response envelopes
+const APIVersion = "v0"
+
+// ResponseEnvelope wraps v0 API responses with the API version and the time the response was generated
+type ResponseEnvelope[T any] struct {
+	APIVersion  string    `json:"api_version"`
+	GeneratedAt time.Time `json:"generated_at"`
+	Data        T         `json:"data"`
+	Metadata    *Metadata `json:"metadata,omitempty"`
+}
+
+// NewResponseEnvelope wraps data in a response envelope.
+// generatedAt should be captured by the handler when it starts processing the request.
+func NewResponseEnvelope[T any](data T, generatedAt time.Time) ResponseEnvelope[T] {
+	return ResponseEnvelope[T]{
+		APIVersion:  APIVersion,
+		GeneratedAt: generatedAt,
+		Data:        data,
+	}
+}
+
+// paginationMetadata returns the pagination metadata for a page of results, or nil if there are no other pages
+func paginationMetadata(cursors database.PageCursors, count int) *Metadata {
+	if cursors.Next == "" && cursors.Prev == "" {
+		return nil
+	}
+
+	return &Metadata{
+		NextCursor: cursors.Next,
+		PrevCursor: cursors.Prev,
+		Count:      count,
+	}
+}
+
+// withTotalCount returns the metadata of a page of count results with the total number of results set. Responses
+// without other pages have no pagination metadata, so it is created for them.
+func withTotalCount(metadata *Metadata, count int, total int64) *Metadata {
+	if metadata == nil {
+		metadata = &Metadata{Count: count}
+	}
+	totalCount := int(total)
+	metadata.TotalCount = &totalCount
+	return metadata
+}
+
+// parseIncludeTotal parses the optional include_total query parameter of paginated requests. Counting all results
+// takes an extra query, so the total is only included on request.
+func parseIncludeTotal(r *http.Request) (bool, error) {
+	value := r.URL.Query().Get("include_total")
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// parseCursor validates the cursor and cursor_direction query parameters of a paginated request.
+// The direction defaults to next; paging backwards needs a cursor. It writes an error response and
+// returns false if the parameters are invalid.
+func parseCursor(w http.ResponseWriter, r *http.Request) (string, database.CursorDirection, bool) {
+	cursor := r.URL.Query().Get("cursor")
+	if cursor != "" {
+		if _, err := database.DecodeCursor(cursor); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidCursor, Message: "Invalid cursor parameter"}, http.StatusBadRequest)
+			return "", "", false
+		}
+	}
+
+	direction, err := database.ParseCursorDirection(r.URL.Query().Get("cursor_direction"))
+	if err != nil {
+		WriteError(w, APIError{Code: ErrCodeInvalidCursor, Message: "Invalid cursor_direction parameter"}, http.StatusBadRequest)
+		return "", "", false
+	}
+	if direction == database.CursorPrev && cursor == "" {
+		WriteError(w, APIError{Code: ErrCodeInvalidCursor, Message: "cursor_direction=prev requires a cursor"}, http.StatusBadRequest)
+		return "", "", false
+	}
+
+	return cursor, direction, true
+}
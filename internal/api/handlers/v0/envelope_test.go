@@ -0,0 +1,78 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseEnvelope(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	serverDetail := &model.ServerDetail{
+		Server: model.Server{
+			Name:        "io.github.example/envelope-server",
+			Description: "Envelope test server",
+			Repository: model.Repository{
+				URL:    "https://github.com/example/envelope-server",
+				Source: "github",
+				ID:     "example/envelope-server",
+			},
+			VersionDetail: model.VersionDetail{
+				Version: "1.0.0",
+			},
+		},
+		Packages: []model.Package{
+			{RegistryName: "npm", Name: "@example/envelope-server", Version: "1.0.0"},
+		},
+	}
+	require.NoError(t, registry.Publish(serverDetail))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0/servers", v0.ServersHandler(registry))
+	mux.HandleFunc("/v0/servers/{id}", v0.ServersDetailHandler(registry, nil))
+	mux.HandleFunc("/v0/servers/{id}/metadata", v0.ServerMetadataHandler(registry))
+	mux.HandleFunc("/v0/search", v0.SearchHandler(registry, &config.Config{}))
+
+	paths := []string{
+		"/v0/servers",
+		"/v0/servers?format=minimal",
+		"/v0/servers/" + serverDetail.ID,
+		"/v0/servers/" + serverDetail.ID + "/metadata",
+		"/v0/search?q=envelope",
+		"/v0/search?q=envelope&format=minimal",
+	}
+
+	for _, path := range paths {
+		t.Run(path, func(t *testing.T) {
+			start := time.Now()
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, path, nil)
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			serveHTTP(t, mux, rr, req)
+			require.Equal(t, http.StatusOK, rr.Code)
+
+			var raw map[string]json.RawMessage
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &raw))
+			assert.Contains(t, raw, "api_version")
+			assert.Contains(t, raw, "generated_at")
+			assert.Contains(t, raw, "data")
+
+			var envelope v0.ResponseEnvelope[json.RawMessage]
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &envelope))
+			assert.Equal(t, v0.APIVersion, envelope.APIVersion)
+			assert.WithinDuration(t, start, envelope.GeneratedAt, time.Second)
+		})
+	}
+}
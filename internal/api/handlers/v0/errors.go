@@ -0,0 +1,67 @@
+package v0
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// Machine-readable codes of API errors. Codes are stable; messages may change.
+const (
+	// ErrCodeMethodNotAllowed is returned for HTTP methods an endpoint does not support
+	ErrCodeMethodNotAllowed = "ERR_METHOD_NOT_ALLOWED"
+	// ErrCodeInvalidRequest is returned for invalid query parameters and request fields
+	ErrCodeInvalidRequest = "ERR_INVALID_REQUEST"
+	// ErrCodeInvalidPayload is returned for request bodies that cannot be read or parsed
+	ErrCodeInvalidPayload = "ERR_INVALID_PAYLOAD"
+	// ErrCodeInvalidID is returned for malformed IDs in the request path
+	ErrCodeInvalidID = "ERR_INVALID_ID"
+	// ErrCodeInvalidCursor is returned for invalid pagination cursors and cursor directions
+	ErrCodeInvalidCursor = "ERR_INVALID_CURSOR"
+	// ErrCodeInvalidLimit is returned for invalid page sizes
+	ErrCodeInvalidLimit = "ERR_INVALID_LIMIT"
+	// ErrCodeInvalidVersion is returned for server versions that are missing or conflict with other versions
+	ErrCodeInvalidVersion = "ERR_INVALID_VERSION"
+	// ErrCodeAuthRequired is returned for requests missing the credentials the endpoint requires
+	ErrCodeAuthRequired = "ERR_AUTH_REQUIRED"
+	// ErrCodeAuthFailed is returned for requests whose credentials could not be verified
+	ErrCodeAuthFailed = "ERR_AUTH_FAILED"
+	// ErrCodeForbidden is returned when the caller may not perform the request
+	ErrCodeForbidden = "ERR_FORBIDDEN"
+	// ErrCodeNotFound is returned when the requested resource does not exist
+	ErrCodeNotFound = "ERR_NOT_FOUND"
+	// ErrCodeConflict is returned when the request conflicts with an existing resource
+	ErrCodeConflict = "ERR_CONFLICT"
+	// ErrCodePayloadTooLarge is returned for request bodies over the size limit of the endpoint
+	ErrCodePayloadTooLarge = "ERR_PAYLOAD_TOO_LARGE"
+	// ErrCodeUnprocessable is returned for well-formed requests the registry cannot act on
+	ErrCodeUnprocessable = "ERR_UNPROCESSABLE"
+	// ErrCodeRateLimited is returned when the caller exceeded a rate or quota limit
+	ErrCodeRateLimited = "ERR_RATE_LIMITED"
+	// ErrCodeInternal is returned for unexpected failures of the registry
+	ErrCodeInternal = "ERR_INTERNAL"
+	// ErrCodeUpstream is returned when a service the registry depends on failed
+	ErrCodeUpstream = "ERR_UPSTREAM"
+	// ErrCodeUnavailable is returned when a service the registry depends on is temporarily unavailable
+	ErrCodeUnavailable = "ERR_UNAVAILABLE"
+)
+
+// APIError is the JSON body of error responses
+type APIError struct {
+	// Code is one of the ErrCode constants, for clients to act on
+	Code string `json:"code"`
+	// Message describes the error for humans
+	Message string `json:"message"`
+	// Details optionally lists more specific problems
+	Details []string `json:"details,omitempty"`
+}
+
+// WriteError responds with the given status and the error as JSON
+func WriteError(w http.ResponseWriter, apiErr APIError, httpStatus int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(httpStatus)
+	if err := json.NewEncoder(w).Encode(apiErr); err != nil {
+		log.Printf("Failed to encode error response: %v", err)
+	}
+}
@@ -0,0 +1,111 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteError(t *testing.T) {
+	rr := httptest.NewRecorder()
+	v0.WriteError(rr, v0.APIError{
+		Code:    v0.ErrCodeInvalidRequest,
+		Message: "Invalid request",
+		Details: []string{"name is required"},
+	}, http.StatusBadRequest)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"code": "ERR_INVALID_REQUEST", "message": "Invalid request", "details": ["name is required"]}`,
+		rr.Body.String())
+}
+
+func TestHandlerErrorCodes(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+
+	for _, tc := range []struct {
+		name           string
+		method         string
+		target         string
+		id             string
+		handler        http.Handler
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "invalid cursor",
+			method:         http.MethodGet,
+			target:         "/v0/servers?cursor=!!!",
+			handler:        v0.ServersHandler(registry),
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   v0.ErrCodeInvalidCursor,
+		},
+		{
+			name:           "invalid limit",
+			method:         http.MethodGet,
+			target:         "/v0/servers?limit=0",
+			handler:        v0.ServersHandler(registry),
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   v0.ErrCodeInvalidLimit,
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodPost,
+			target:         "/v0/servers",
+			handler:        v0.ServersHandler(registry),
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedCode:   v0.ErrCodeMethodNotAllowed,
+		},
+		{
+			name:           "invalid ID",
+			method:         http.MethodGet,
+			target:         "/v0/servers/not-a-uuid/versions",
+			id:             "not-a-uuid",
+			handler:        v0.VersionsHandler(registry),
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   v0.ErrCodeInvalidID,
+		},
+		{
+			name:           "not found",
+			method:         http.MethodGet,
+			target:         "/v0/servers/00000000-0000-0000-0000-000000000000/versions",
+			id:             "00000000-0000-0000-0000-000000000000",
+			handler:        v0.VersionsHandler(registry),
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   v0.ErrCodeNotFound,
+		},
+		{
+			name:           "auth required",
+			method:         http.MethodPost,
+			target:         "/v0/servers/00000000-0000-0000-0000-000000000000/versions",
+			id:             "00000000-0000-0000-0000-000000000000",
+			handler:        v0.PublishVersionHandler(registry, new(MockAuthService)),
+			expectedStatus: http.StatusUnauthorized,
+			expectedCode:   v0.ErrCodeAuthRequired,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequestWithContext(context.Background(), tc.method, tc.target, nil)
+			require.NoError(t, err)
+			req.SetPathValue("id", tc.id)
+			rr := httptest.NewRecorder()
+			serveHTTP(t, tc.handler, rr, req)
+
+			require.Equal(t, tc.expectedStatus, rr.Code)
+			assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+			var apiErr v0.APIError
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &apiErr))
+			assert.Equal(t, tc.expectedCode, apiErr.Code)
+			assert.NotEmpty(t, apiErr.Message)
+		})
+	}
+}
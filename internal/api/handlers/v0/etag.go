@@ -0,0 +1,82 @@
+package v0
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/model"
+)
+
+// PreconditionFailedResponse is returned when an If-Match precondition does not match the current server state
+type PreconditionFailedResponse struct {
+	Error       string `json:"error"`
+	CurrentETag string `json:"current_etag"`
+}
+
+// serverETag computes a strong entity tag identifying the current state of a server: a SHA-256 hash of its JSON
+// encoding and of the stored GitHub metadata, which is left out of the JSON, so that it changes with any field
+func serverETag(serverDetail *model.ServerDetail) (string, error) {
+	data, err := json.Marshal(serverDetail)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	hash.Write(data)
+	hash.Write(serverDetail.GitHubMetadata)
+	sum := hash.Sum(nil)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`, nil
+}
+
+// notModified reports whether the If-None-Match header of a request lists the current ETag of a server, or is "*".
+// Entity tags are compared weakly, as If-None-Match requires, so a W/ prefix is ignored.
+func notModified(r *http.Request, currentETag string) bool {
+	ifNoneMatch := r.Header.Get("If-None-Match")
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	for _, etag := range strings.Split(ifNoneMatch, ",") {
+		etag = strings.TrimPrefix(strings.TrimSpace(etag), "W/")
+		if etag == "*" || etag == currentETag {
+			return true
+		}
+	}
+	return false
+}
+
+// checkIfMatch enforces the If-Match precondition of a request against the current server state.
+// Requests without If-Match always pass. It writes a 412 response and returns false if the precondition fails.
+func checkIfMatch(w http.ResponseWriter, r *http.Request, serverDetail *model.ServerDetail) bool {
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" {
+		return true
+	}
+
+	currentETag, err := serverETag(serverDetail)
+	if err != nil {
+		WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to compute ETag"}, http.StatusInternalServerError)
+		return false
+	}
+
+	for _, etag := range strings.Split(ifMatch, ",") {
+		etag = strings.TrimSpace(etag)
+		if etag == "*" || etag == currentETag {
+			return true
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", currentETag)
+	w.WriteHeader(http.StatusPreconditionFailed)
+	if err := json.NewEncoder(w).Encode(PreconditionFailedResponse{
+		Error:       "conflict",
+		CurrentETag: currentETag,
+	}); err != nil {
+		WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to encode response"}, http.StatusInternalServerError)
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package v0_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/events"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventsHandler(t *testing.T) {
+	hub := events.NewHub()
+	defer hub.Close()
+
+	registry := service.NewEventingRegistryService(
+		service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{})), hub,
+	)
+
+	server := httptest.NewServer(v0.EventsHandler(hub))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+
+	// Read events from the stream in the background
+	received := make(chan events.Event, 1)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+			if !ok {
+				continue
+			}
+			var event events.Event
+			if err := json.Unmarshal([]byte(data), &event); err == nil {
+				received <- event
+			}
+		}
+	}()
+
+	serverDetail := &model.ServerDetail{
+		Server: model.Server{
+			Name:        "io.github.example/events-server",
+			Description: "Events test server",
+			Repository: model.Repository{
+				URL:    "https://github.com/example/events-server",
+				Source: "github",
+				ID:     "example/events-server",
+			},
+			VersionDetail: model.VersionDetail{
+				Version: "1.0.0",
+			},
+		},
+		Packages: []model.Package{
+			{RegistryName: "npm", Name: "@example/events-server", Version: "1.0.0"},
+		},
+	}
+
+	// The subscription is registered once the response headers are sent
+	require.NoError(t, registry.Publish(serverDetail))
+
+	select {
+	case event := <-received:
+		assert.Equal(t, events.EventPublished, event.Type)
+		assert.Equal(t, serverDetail.ID, event.ServerID)
+		assert.Equal(t, serverDetail.Name, event.Name)
+		assert.False(t, event.Timestamp.IsZero())
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for published event")
+	}
+
+	_, err = registry.AppendPackages(context.Background(), serverDetail.ID, []model.Package{
+		{RegistryName: "docker", Name: "example/events-server", Version: "1.0.0"},
+	})
+	require.NoError(t, err)
+
+	select {
+	case event := <-received:
+		assert.Equal(t, events.EventUpdated, event.Type)
+		assert.Equal(t, serverDetail.ID, event.ServerID)
+		assert.Equal(t, serverDetail.Name, event.Name)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for updated event")
+	}
+}
+
+func TestEventsHandlerMethodNotAllowed(t *testing.T) {
+	hub := events.NewHub()
+	defer hub.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/events", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.EventsHandler(hub), rr, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+}
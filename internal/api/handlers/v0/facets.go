@@ -0,0 +1,70 @@
+package v0
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// FacetCount is the number of servers with a value of a search filter
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// SearchFacets lists the values of the search filters with the number of matching servers having each, keyed like
+// the search parameters they are passed as. Values are sorted by count, most common first, ties by value.
+type SearchFacets struct {
+	RegistryName []FacetCount `json:"registry_name"`
+	Tags         []FacetCount `json:"tags"`
+	License      []FacetCount `json:"license"`
+	Status       []FacetCount `json:"status"`
+}
+
+// SearchFacetsResponse is the response of the search facets endpoint
+type SearchFacetsResponse = ResponseEnvelope[SearchFacets]
+
+// SearchFacetsHandler returns a handler for the values of the search filters with the number of servers matching
+// the optional q parameter that have each, so clients can offer only filters that narrow the search down
+func SearchFacetsHandler(registry service.RegistryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		generatedAt := time.Now().UTC()
+
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		facets, err := registry.SearchFacets(r.Context(), r.URL.Query().Get("q"))
+		if err != nil {
+			middleware.Logf(r.Context(), "Error counting search facets: %v", err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to count search facets"}, http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, NewResponseEnvelope(SearchFacets{
+			RegistryName: facetCounts(facets.RegistryNames),
+			Tags:         facetCounts(facets.Tags),
+			License:      facetCounts(facets.Licenses),
+			Status:       facetCounts(facets.Statuses),
+		}, generatedAt))
+	}
+}
+
+// facetCounts returns the counts of the values of a facet, most common first
+func facetCounts(counts map[string]int) []FacetCount {
+	result := make([]FacetCount, 0, len(counts))
+	for value, count := range counts {
+		result = append(result, FacetCount{Value: value, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Value < result[j].Value
+	})
+	return result
+}
@@ -0,0 +1,96 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchFacetsHandler(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	servers := []struct {
+		name       string
+		license    string
+		tags       []string
+		registries []string
+	}{
+		{name: "memory", license: "MIT", tags: []string{"memory", "graph"}, registries: []string{"npm", "docker"}},
+		{name: "memory-lite", license: "MIT", tags: []string{"memory"}, registries: []string{"npm", "npm"}},
+		{name: "files", license: "Apache-2.0", tags: []string{"files"}, registries: []string{"pypi"}},
+		{name: "unlicensed"},
+	}
+	var ids []string
+	for _, server := range servers {
+		serverDetail := &model.ServerDetail{
+			Server: model.Server{
+				Name:          "io.github.example/" + server.name,
+				Repository:    model.Repository{URL: "https://github.com/example/" + server.name, Source: "github"},
+				VersionDetail: model.VersionDetail{Version: "1.0.0"},
+				License:       server.license,
+				Tags:          server.tags,
+			},
+		}
+		for i, registryName := range server.registries {
+			serverDetail.Packages = append(serverDetail.Packages, model.Package{
+				RegistryName: registryName, Name: server.name + string(rune('a'+i)), Version: "1.0.0",
+			})
+		}
+		require.NoError(t, registry.Publish(serverDetail))
+		ids = append(ids, serverDetail.ID)
+	}
+	require.NoError(t, registry.UpdateStatus(context.Background(), ids[1], model.StatusDeprecated, false))
+
+	facets := func(t *testing.T, query string) v0.SearchFacets {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/search/facets"+query, nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.SearchFacetsHandler(registry), rr, req)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var resp v0.SearchFacetsResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		return resp.Data
+	}
+
+	t.Run("counts all servers without a query", func(t *testing.T) {
+		assert.Equal(t, v0.SearchFacets{
+			RegistryName: []v0.FacetCount{{Value: "npm", Count: 2}, {Value: "docker", Count: 1}, {Value: "pypi", Count: 1}},
+			Tags: []v0.FacetCount{
+				{Value: "memory", Count: 2}, {Value: "files", Count: 1}, {Value: "graph", Count: 1},
+			},
+			License: []v0.FacetCount{{Value: "MIT", Count: 2}, {Value: "Apache-2.0", Count: 1}},
+			Status:  []v0.FacetCount{{Value: "active", Count: 3}, {Value: "deprecated", Count: 1}},
+		}, facets(t, ""))
+	})
+
+	t.Run("counts servers matching the query", func(t *testing.T) {
+		result := facets(t, "?q=memory")
+		assert.Equal(t, []v0.FacetCount{{Value: "npm", Count: 2}, {Value: "docker", Count: 1}}, result.RegistryName)
+		assert.Equal(t, []v0.FacetCount{{Value: "MIT", Count: 2}}, result.License)
+		assert.Equal(t, []v0.FacetCount{{Value: "active", Count: 1}, {Value: "deprecated", Count: 1}}, result.Status)
+	})
+
+	t.Run("lists no values without matches", func(t *testing.T) {
+		result := facets(t, "?q=nothing")
+		assert.Empty(t, result.RegistryName)
+		assert.Empty(t, result.Status)
+	})
+
+	t.Run("only GET is allowed", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/search/facets", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.SearchFacetsHandler(registry), rr, req)
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
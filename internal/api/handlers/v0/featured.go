@@ -0,0 +1,82 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// ReorderFeaturedRequest represents the request body for reordering the featured servers
+type ReorderFeaturedRequest struct {
+	// Order lists the IDs of all featured servers in their new display order
+	Order []string `json:"order"`
+}
+
+// FeaturedServersHandler returns a handler for the featured servers in their display order
+func FeaturedServersHandler(registry service.RegistryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		generatedAt := time.Now().UTC()
+
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		servers, err := registry.ListFeatured(r.Context())
+		if err != nil {
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: err.Error()}, http.StatusInternalServerError)
+			return
+		}
+
+		redactContactEmails(servers)
+		writeJSON(w, NewResponseEnvelope(servers, generatedAt))
+	}
+}
+
+// ReorderFeaturedHandler handles requests from the registry owner to change the display order of the featured
+// servers. The order must list every featured server exactly once; the updated featured servers are returned.
+func ReorderFeaturedHandler(registry service.RegistryService, authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		generatedAt := time.Now().UTC()
+
+		if r.Method != http.MethodPost {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !authorizeRegistryOwner(w, r, authService) {
+			return
+		}
+
+		var req ReorderFeaturedRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidPayload, Message: "Invalid request payload: " + err.Error()}, http.StatusBadRequest)
+			return
+		}
+		defer r.Body.Close()
+
+		if err := registry.ReorderFeatured(r.Context(), req.Order); err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: err.Error()}, http.StatusBadRequest)
+				return
+			}
+			middleware.Logf(r.Context(), "reorder-featured: Failed to reorder featured servers: %v", err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to reorder featured servers"}, http.StatusInternalServerError)
+			return
+		}
+
+		servers, err := registry.ListFeatured(r.Context())
+		if err != nil {
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: err.Error()}, http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, NewResponseEnvelope(servers, generatedAt))
+	}
+}
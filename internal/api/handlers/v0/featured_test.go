@@ -0,0 +1,127 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReorderFeaturedHandler(t *testing.T) {
+	const (
+		alpha   = "11111111-1111-1111-1111-111111111111"
+		beta    = "22222222-2222-2222-2222-222222222222"
+		gamma   = "33333333-3333-3333-3333-333333333333"
+		regular = "44444444-4444-4444-4444-444444444444"
+	)
+	position := func(order int) *int { return &order }
+	db := database.NewMemoryDB(map[string]*model.Server{
+		alpha:   {ID: alpha, Name: "example/alpha", FeaturedOrder: position(0)},
+		beta:    {ID: beta, Name: "example/beta", FeaturedOrder: position(1)},
+		gamma:   {ID: gamma, Name: "example/gamma", FeaturedOrder: position(2)},
+		regular: {ID: regular, Name: "example/regular"},
+	})
+	registry := service.NewCachedRegistryService(service.NewRegistryServiceWithDB(db), time.Minute)
+
+	mockAuthService := new(MockAuthService)
+	mockAuthService.Mock.On("ValidateRegistryOwnerAuth", mock.Anything, "owner-token").Return(true, nil)
+	mockAuthService.Mock.On("ValidateRegistryOwnerAuth", mock.Anything, "user-token").Return(false, nil)
+
+	reorder := func(t *testing.T, token string, order ...string) *httptest.ResponseRecorder {
+		t.Helper()
+		body, err := json.Marshal(v0.ReorderFeaturedRequest{Order: order})
+		require.NoError(t, err)
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/admin/reorder-featured", bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.ReorderFeaturedHandler(registry, mockAuthService), rr, req)
+		return rr
+	}
+
+	listFeatured := func(t *testing.T) []model.Server {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers/featured", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.FeaturedServersHandler(registry), rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp v0.ResponseEnvelope[[]model.Server]
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		return resp.Data
+	}
+
+	ids := func(servers []model.Server) []string {
+		result := make([]string, len(servers))
+		for i, server := range servers {
+			result[i] = server.ID
+		}
+		return result
+	}
+
+	// Cache the featured list, so the reorder must invalidate it
+	require.Equal(t, []string{alpha, beta, gamma}, ids(listFeatured(t)))
+
+	t.Run("requires the registry owner", func(t *testing.T) {
+		rr := reorder(t, "user-token", gamma, alpha, beta)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("missing featured server", func(t *testing.T) {
+		rr := reorder(t, "owner-token", gamma, alpha)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), beta)
+	})
+
+	t.Run("server that is not featured", func(t *testing.T) {
+		rr := reorder(t, "owner-token", gamma, alpha, beta, regular)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), regular)
+	})
+
+	t.Run("unknown server", func(t *testing.T) {
+		rr := reorder(t, "owner-token", gamma, alpha, beta, "55555555-5555-5555-5555-555555555555")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("duplicate server", func(t *testing.T) {
+		rr := reorder(t, "owner-token", gamma, alpha, beta, alpha)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("rejected orders leave the featured servers unchanged", func(t *testing.T) {
+		assert.Equal(t, []string{alpha, beta, gamma}, ids(listFeatured(t)))
+	})
+
+	t.Run("valid order is persisted", func(t *testing.T) {
+		rr := reorder(t, "owner-token", gamma, alpha, beta)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var resp v0.ResponseEnvelope[[]model.Server]
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		assert.Equal(t, []string{gamma, alpha, beta}, ids(resp.Data))
+		assert.Equal(t, []string{gamma, alpha, beta}, ids(listFeatured(t)))
+
+		for id, expected := range map[string]int{gamma: 0, alpha: 1, beta: 2} {
+			serverDetail, err := db.GetByID(context.Background(), id)
+			require.NoError(t, err)
+			require.NotNil(t, serverDetail.FeaturedOrder)
+			assert.Equal(t, expected, *serverDetail.FeaturedOrder, id)
+		}
+		serverDetail, err := db.GetByID(context.Background(), regular)
+		require.NoError(t, err)
+		assert.Nil(t, serverDetail.FeaturedOrder)
+	})
+}
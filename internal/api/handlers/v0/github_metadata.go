@@ -0,0 +1,55 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// GitHubMetadataHandler returns a handler for the repository information GitHub returned when a server was
+// published through publish-oss. The information is returned exactly as GitHub sent it, for debugging
+// publish issues, and is only available to the registry owner.
+func GitHubMetadataHandler(registry service.RegistryService, authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		if !authorizeRegistryOwner(w, r, authService) {
+			return
+		}
+
+		metadata, err := registry.GetGitHubMetadata(r.Context(), id)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				// Servers published before the metadata was stored, or not through publish-oss, have none
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusNotFound)
+				if err := json.NewEncoder(w).Encode(map[string]string{"error": "GitHub metadata not found"}); err != nil {
+					middleware.Logf(r.Context(), "github-metadata: Failed to encode response for %s: %v", id, err)
+				}
+				return
+			}
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Error retrieving GitHub metadata"}, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write(metadata); err != nil {
+			middleware.Logf(r.Context(), "github-metadata: Failed to write metadata for %s: %v", id, err)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package v0_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubMetadataHandler(t *testing.T) {
+	const metadata = `{"id": 42, "full_name": "example/oss-server", "private": false, "topics": ["mcp"]}`
+
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	ossServer := &model.ServerDetail{
+		Server: model.Server{
+			Name:          "io.github.example/oss-server",
+			Repository:    model.Repository{URL: "https://github.com/example/oss-server", Source: "github", ID: "42"},
+			VersionDetail: model.VersionDetail{Version: "1.0.0"},
+		},
+		GitHubMetadata: []byte(metadata),
+	}
+	require.NoError(t, registry.Publish(ossServer))
+	legacyID := publishVersion(t, registry, "1.0.0")
+
+	mockAuthService := new(MockAuthService)
+	mockAuthService.Mock.On("ValidateRegistryOwnerAuth", mock.Anything, "owner-token").Return(true, nil)
+	mockAuthService.Mock.On("ValidateRegistryOwnerAuth", mock.Anything, "user-token").Return(false, nil)
+
+	get := func(t *testing.T, id, token string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers/"+id+"/raw-github-metadata", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.SetPathValue("id", id)
+
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.GitHubMetadataHandler(registry, mockAuthService), rr, req)
+		return rr
+	}
+
+	t.Run("returns the stored metadata verbatim", func(t *testing.T) {
+		rr := get(t, ossServer.ID, "owner-token")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+		assert.Equal(t, metadata, rr.Body.String())
+	})
+
+	t.Run("metadata is not part of the public server details", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers/"+ossServer.ID, nil)
+		require.NoError(t, err)
+		req.SetPathValue("id", ossServer.ID)
+		serveHTTP(t, v0.ServersDetailHandler(registry, nil), rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.NotContains(t, rr.Body.String(), "topics")
+	})
+
+	t.Run("non-owner access is rejected", func(t *testing.T) {
+		rr := get(t, ossServer.ID, "user-token")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("servers without metadata", func(t *testing.T) {
+		for _, id := range []string{legacyID, "00000000-0000-0000-0000-000000000000"} {
+			rr := get(t, id, "owner-token")
+			assert.Equal(t, http.StatusNotFound, rr.Code, id)
+			assert.JSONEq(t, `{"error": "GitHub metadata not found"}`, rr.Body.String(), id)
+		}
+	})
+}
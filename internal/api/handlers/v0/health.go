@@ -11,6 +11,7 @@ import (
 type HealthResponse struct {
 	Status         string `json:"status"`
 	GitHubClientID string `json:"github_client_id"`
+	AuthMethod     string `json:"auth_method"`
 }
 
 // HealthHandler returns a handler for health check endpoint
@@ -20,8 +21,9 @@ func HealthHandler(cfg *config.Config) http.HandlerFunc {
 		if err := json.NewEncoder(w).Encode(HealthResponse{
 			Status:         "ok",
 			GitHubClientID: cfg.GithubClientID,
+			AuthMethod:     cfg.AuthMethod(),
 		}); err != nil {
-			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to encode response"}, http.StatusInternalServerError)
 		}
 	}
 }
@@ -29,6 +29,7 @@ func TestHealthHandler(t *testing.T) {
 			expectedBody: v0.HealthResponse{
 				Status:         "ok",
 				GitHubClientID: "test-github-client-id",
+				AuthMethod:     "oauth",
 			},
 		},
 		{
@@ -40,6 +41,21 @@ func TestHealthHandler(t *testing.T) {
 			expectedBody: v0.HealthResponse{
 				Status:         "ok",
 				GitHubClientID: "",
+				AuthMethod:     "oauth",
+			},
+		},
+		{
+			name: "reports github app auth when the app is configured",
+			config: &config.Config{
+				GithubClientID:          "test-github-client-id",
+				GithubAppID:             12345,
+				GithubAppPrivateKeyPath: "/etc/registry/app.pem",
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: v0.HealthResponse{
+				Status:         "ok",
+				GitHubClientID: "test-github-client-id",
+				AuthMethod:     "github_app",
 			},
 		},
 	}
@@ -59,7 +75,7 @@ func TestHealthHandler(t *testing.T) {
 			rr := httptest.NewRecorder()
 
 			// Call the handler
-			handler.ServeHTTP(rr, req)
+			serveHTTP(t, handler, rr, req)
 
 			// Check status code
 			assert.Equal(t, tc.expectedStatus, rr.Code)
@@ -117,6 +133,7 @@ func TestHealthHandlerIntegration(t *testing.T) {
 	expectedResp := v0.HealthResponse{
 		Status:         "ok",
 		GitHubClientID: "integration-test-client-id",
+		AuthMethod:     "oauth",
 	}
 	assert.Equal(t, expectedResp, healthResp)
 }
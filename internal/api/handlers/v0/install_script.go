@@ -0,0 +1,101 @@
+package v0
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/installscript"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// InstallScriptHandler returns a handler that generates a script installing and starting a server from one of
+// its packages: Bash for Linux and macOS, PowerShell for Windows. The package is chosen by the registry query
+// parameter and defaults to the first package of the server.
+func InstallScriptHandler(registry service.RegistryService, cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !cfg.ScriptGenerationEnabled {
+			WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Install scripts are disabled"}, http.StatusNotFound)
+			return
+		}
+
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		os := installscript.OSLinux
+		if osParam := r.URL.Query().Get("os"); osParam != "" {
+			os = installscript.OS(osParam)
+			if !os.IsValid() {
+				WriteError(w, APIError{
+					Code:    ErrCodeInvalidRequest,
+					Message: "Invalid os parameter: must be linux, macos or windows",
+				}, http.StatusBadRequest)
+				return
+			}
+		}
+
+		serverDetail, err := registry.GetByID(id)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Server not found"}, http.StatusNotFound)
+				return
+			}
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Error retrieving server details"}, http.StatusInternalServerError)
+			return
+		}
+
+		pkg := packageByRegistry(serverDetail, r.URL.Query().Get("registry"))
+		if pkg == nil {
+			WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Package not found"}, http.StatusNotFound)
+			return
+		}
+
+		script, err := installscript.Generate(serverDetail, pkg, os)
+		if err != nil {
+			switch {
+			case errors.Is(err, installscript.ErrUnsupportedRegistry):
+				WriteError(w, APIError{
+					Code:    ErrCodeInvalidRequest,
+					Message: "Install scripts are not supported for packages of this registry",
+				}, http.StatusBadRequest)
+			case errors.Is(err, installscript.ErrInvalidPackage):
+				WriteError(w, APIError{
+					Code:    ErrCodeUnprocessable,
+					Message: "Package name or version cannot be used in an install script",
+				}, http.StatusUnprocessableEntity)
+			default:
+				WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to generate install script"}, http.StatusInternalServerError)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", os.ContentType())
+		if _, err := w.Write([]byte(script)); err != nil {
+			middleware.Logf(r.Context(), "Error writing install script for server %s: %v", id, err)
+		}
+	}
+}
+
+// packageByRegistry returns the first package of the server in the registry, or its first package if registryName
+// is empty. It returns nil if there is no such package.
+func packageByRegistry(serverDetail *model.ServerDetail, registryName string) *model.Package {
+	for i := range serverDetail.Packages {
+		if registryName == "" || strings.EqualFold(serverDetail.Packages[i].RegistryName, registryName) {
+			return &serverDetail.Packages[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package v0_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstallScriptHandler(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	serverDetail := &model.ServerDetail{
+		Server: model.Server{
+			Name:          "io.github.example/install-server",
+			Repository:    model.Repository{URL: "https://github.com/example/install-server", Source: "github"},
+			VersionDetail: model.VersionDetail{Version: "1.0.0"},
+		},
+		Packages: []model.Package{
+			{RegistryName: "npm", Name: "@example/install-server$(reboot)", Version: "1.0.0"},
+			{RegistryName: "docker", Name: "example/install-server", Version: "1.0.0"},
+			{RegistryName: "nuget", Name: "Example.InstallServer", Version: "1.0.0"},
+		},
+	}
+	require.NoError(t, registry.Publish(serverDetail))
+
+	getScript := func(t *testing.T, cfg *config.Config, id, query string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers/"+id+"/install-script"+query, nil)
+		require.NoError(t, err)
+		req.SetPathValue("id", id)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.InstallScriptHandler(registry, cfg), rr, req)
+		return rr
+	}
+	enabled := &config.Config{ScriptGenerationEnabled: true}
+
+	t.Run("first package for linux by default", func(t *testing.T) {
+		rr := getScript(t, enabled, serverDetail.ID, "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Equal(t, "text/x-shellscript", rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Body.String(), "#!/usr/bin/env bash\n")
+		assert.Contains(t, rr.Body.String(), "'npm' 'install' '--global' '@example/install-serverreboot@1.0.0'\n")
+		assert.NotContains(t, rr.Body.String(), "$(")
+	})
+
+	t.Run("PowerShell for windows", func(t *testing.T) {
+		rr := getScript(t, enabled, serverDetail.ID, "?registry=docker&os=windows")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Equal(t, "text/x-powershell", rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Body.String(), "& 'docker' 'pull' 'example/install-server:1.0.0'\n")
+	})
+
+	tests := []struct {
+		name           string
+		cfg            *config.Config
+		id             string
+		query          string
+		expectedStatus int
+	}{
+		{"disabled", &config.Config{}, serverDetail.ID, "", http.StatusNotFound},
+		{"invalid os", enabled, serverDetail.ID, "?os=plan9", http.StatusBadRequest},
+		{"missing package", enabled, serverDetail.ID, "?registry=pypi", http.StatusNotFound},
+		{"unsupported registry", enabled, serverDetail.ID, "?registry=nuget", http.StatusBadRequest},
+		{"unknown server", enabled, uuid.New().String(), "", http.StatusNotFound},
+		{"invalid ID", enabled, "not-a-uuid", "", http.StatusBadRequest},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := getScript(t, tc.cfg, tc.id, tc.query)
+			assert.Equal(t, tc.expectedStatus, rr.Code, rr.Body.String())
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package v0
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// LanguagesHandler returns a handler listing the programming languages servers are written in with the number
+// of servers in each, most common first
+func LanguagesHandler(registry service.RegistryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		generatedAt := time.Now().UTC()
+
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		languages, err := registry.ListLanguages(r.Context())
+		if err != nil {
+			middleware.Logf(r.Context(), "Error listing languages: %v", err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to list languages"}, http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, NewResponseEnvelope(languages, generatedAt))
+	}
+}
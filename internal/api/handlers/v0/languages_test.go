@@ -0,0 +1,80 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLanguageFromGitHub(t *testing.T) {
+	stubGitHubRepoAPI(t)
+	authService := auth.NewAuthService(&config.Config{EphemeralTokenSecret: testEphemeralTokenSecret})
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+
+	body, err := json.Marshal(ossRepository("typed-server"))
+	require.NoError(t, err)
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/publish-oss", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+signEphemeralToken(t, time.Hour))
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.PublishOSSHandler(registry, authService), rr, req)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+	var published struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &published))
+	serverDetail, err := registry.GetByID(published.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "typescript", serverDetail.Language)
+
+	// A server without a detected language
+	publishWithSchema(t, registry, "untyped-server", nil)
+
+	t.Run("search by language", func(t *testing.T) {
+		for query, expectedNames := range map[string][]string{
+			"?language=TypeScript":                {"io.github.example/typed-server"},
+			"?language=typescript&format=minimal": {"io.github.example/typed-server"},
+			"?language=python":                    {},
+		} {
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/search"+query, nil)
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			serveHTTP(t, v0.SearchHandler(registry, &config.Config{}), rr, req)
+			require.Equal(t, http.StatusOK, rr.Code, query)
+
+			var resp v0.ResponseEnvelope[[]model.ServerMinimal]
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			names := make([]string, len(resp.Data))
+			for i, server := range resp.Data {
+				names[i] = server.Name
+			}
+			assert.ElementsMatch(t, expectedNames, names, query)
+		}
+	})
+
+	t.Run("list languages", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/languages", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.LanguagesHandler(registry), rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp v0.ResponseEnvelope[[]model.LanguageCount]
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		assert.Equal(t, []model.LanguageCount{{Language: "typescript", Count: 1}}, resp.Data)
+	})
+}
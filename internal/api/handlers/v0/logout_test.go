@@ -0,0 +1,66 @@
+package v0_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogoutHandler(t *testing.T) {
+	authService := auth.NewAuthService(&config.Config{EphemeralTokenSecret: testEphemeralTokenSecret})
+	handler := v0.LogoutHandler(authService)
+
+	logout := func(t *testing.T, authHeader string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/auth/logout", nil)
+		require.NoError(t, err)
+		if authHeader != "" {
+			req.Header.Set("Authorization", authHeader)
+		}
+		rr := httptest.NewRecorder()
+		serveHTTP(t, handler, rr, req)
+		return rr
+	}
+
+	t.Run("missing authorization header", func(t *testing.T) {
+		rr := logout(t, "")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("expired token is rejected", func(t *testing.T) {
+		rr := logout(t, "Bearer "+signEphemeralToken(t, -time.Minute))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("revokes all tokens of the user", func(t *testing.T) {
+		token := signEphemeralToken(t, 30*time.Minute)
+		otherToken := signEphemeralToken(t, 50*time.Minute)
+
+		rr := logout(t, "Bearer "+token)
+		require.Equal(t, http.StatusNoContent, rr.Code, rr.Body.String())
+
+		for _, revoked := range []string{token, otherToken} {
+			valid, _, err := authService.ValidateEphemeralOrOwnerToken(context.Background(), revoked)
+			require.ErrorContains(t, err, "revoked")
+			assert.False(t, valid)
+		}
+
+		// Logging out again with a revoked token fails
+		rr = logout(t, "Bearer "+otherToken)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+		// Tokens issued after logging out are valid
+		valid, claims, err := authService.ValidateEphemeralOrOwnerToken(context.Background(), signEphemeralToken(t, time.Hour+time.Second))
+		require.NoError(t, err)
+		assert.True(t, valid)
+		assert.Equal(t, "123456", claims.GitHubUserID)
+	})
+}
@@ -0,0 +1,156 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// MaintainerRequest represents the request body for adding a maintainer to a server
+type MaintainerRequest struct {
+	Username string `json:"username"`
+}
+
+// AddMaintainerHandler handles requests to add a GitHub user to the maintainers of a server.
+// Maintainers may modify the server like its publisher; the list covers all versions of the server.
+// Only the publisher or the registry owner may add maintainers.
+func AddMaintainerHandler(registry service.RegistryService, authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Only allow POST method
+		if r.Method != http.MethodPost {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Extract the server ID from the URL path
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		// Parse request body
+		var req MaintainerRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidPayload, Message: "Invalid request payload: " + err.Error()}, http.StatusBadRequest)
+			return
+		}
+		defer r.Body.Close()
+
+		if req.Username == "" {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Username is required"}, http.StatusBadRequest)
+			return
+		}
+		if !githubUsernameRegex.MatchString(req.Username) {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid GitHub username"}, http.StatusBadRequest)
+			return
+		}
+
+		serverDetail, ok := authorizeServerOwner(w, r, registry, authService, id)
+		if !ok || !checkIfMatch(w, r, serverDetail) {
+			return
+		}
+
+		exists, err := authService.GitHubUserExists(r.Context(), req.Username)
+		if err != nil {
+			middleware.Logf(r.Context(), "maintainers: Failed to look up GitHub user %s: %v", req.Username, err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to look up GitHub user"}, http.StatusInternalServerError)
+			return
+		}
+		if !exists {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "GitHub user not found"}, http.StatusBadRequest)
+			return
+		}
+
+		maintainers, err := registry.AddMaintainer(r.Context(), id, req.Username)
+		if err != nil {
+			switch {
+			case errors.Is(err, database.ErrAlreadyExists):
+				WriteError(w, APIError{Code: ErrCodeConflict, Message: "User is already a maintainer"}, http.StatusConflict)
+			case errors.Is(err, database.ErrNotFound):
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Server not found"}, http.StatusNotFound)
+			default:
+				middleware.Logf(r.Context(), "maintainers: Failed to add %s to server %s: %v", req.Username, id, err)
+				WriteError(w, APIError{
+					Code:    ErrCodeInternal,
+					Message: "Failed to add maintainer: " + err.Error(),
+				}, http.StatusInternalServerError)
+			}
+			return
+		}
+
+		writeMaintainers(w, "Maintainer added", serverDetail.Name, maintainers)
+	}
+}
+
+// RemoveMaintainerHandler handles requests to remove a GitHub user from the maintainers of a server.
+// Only the publisher or the registry owner may remove maintainers. The publisher cannot be removed;
+// ownership is handed over with the transfer endpoint instead.
+func RemoveMaintainerHandler(registry service.RegistryService, authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Only allow DELETE method
+		if r.Method != http.MethodDelete {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Extract the server ID and username from the URL path
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+		username := r.PathValue("username")
+		if !githubUsernameRegex.MatchString(username) {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid GitHub username"}, http.StatusBadRequest)
+			return
+		}
+
+		serverDetail, ok := authorizeServerOwner(w, r, registry, authService, id)
+		if !ok || !checkIfMatch(w, r, serverDetail) {
+			return
+		}
+
+		maintainers, err := registry.RemoveMaintainer(r.Context(), id, username)
+		if err != nil {
+			switch {
+			case errors.Is(err, database.ErrInvalidInput):
+				WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: err.Error()}, http.StatusBadRequest)
+			case errors.Is(err, database.ErrNotFound):
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Maintainer not found"}, http.StatusNotFound)
+			default:
+				middleware.Logf(r.Context(), "maintainers: Failed to remove %s from server %s: %v", username, id, err)
+				WriteError(w, APIError{
+					Code:    ErrCodeInternal,
+					Message: "Failed to remove maintainer: " + err.Error(),
+				}, http.StatusInternalServerError)
+			}
+			return
+		}
+
+		writeMaintainers(w, "Maintainer removed", serverDetail.Name, maintainers)
+	}
+}
+
+// writeMaintainers writes the maintainers of a server after a change
+func writeMaintainers(w http.ResponseWriter, message, name string, maintainers []string) {
+	if maintainers == nil {
+		maintainers = []string{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]any{
+		"message":       message,
+		"name":          name,
+		"maintained_by": maintainers,
+	}); err != nil {
+		WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to encode response"}, http.StatusInternalServerError)
+		return
+	}
+}
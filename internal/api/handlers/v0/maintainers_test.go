@@ -0,0 +1,253 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// addMaintainer calls the add maintainer handler as the user described by claims.
+// The GitHub user lookup answers with exists.
+func addMaintainer(
+	t *testing.T, registry service.RegistryService, claims *auth.EphemeralTokenClaims, id, username string, exists bool,
+) *httptest.ResponseRecorder {
+	t.Helper()
+	mockAuthService := new(MockAuthService)
+	mockAuthService.Mock.On("ValidateEphemeralOrOwnerToken", mock.Anything, "test-token").Return(true, claims, nil)
+	mockAuthService.Mock.On("GitHubUserExists", mock.Anything, username).Return(exists, nil)
+
+	body, err := json.Marshal(v0.MaintainerRequest{Username: username})
+	require.NoError(t, err)
+
+	req, err := http.NewRequestWithContext(
+		context.Background(), http.MethodPost, "/v0/servers/"+id+"/maintainers", bytes.NewReader(body),
+	)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer test-token")
+	req.SetPathValue("id", id)
+
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.AddMaintainerHandler(registry, mockAuthService), rr, req)
+	return rr
+}
+
+// removeMaintainer calls the remove maintainer handler as the user described by claims
+func removeMaintainer(
+	t *testing.T, registry service.RegistryService, claims *auth.EphemeralTokenClaims, id, username string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+	mockAuthService := new(MockAuthService)
+	mockAuthService.Mock.On("ValidateEphemeralOrOwnerToken", mock.Anything, "test-token").Return(true, claims, nil)
+
+	req, err := http.NewRequestWithContext(
+		context.Background(), http.MethodDelete, "/v0/servers/"+id+"/maintainers/"+username, nil,
+	)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer test-token")
+	req.SetPathValue("id", id)
+	req.SetPathValue("username", username)
+
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.RemoveMaintainerHandler(registry, mockAuthService), rr, req)
+	return rr
+}
+
+func TestMaintainerHandlers(t *testing.T) {
+	publisherClaims := &auth.EphemeralTokenClaims{GitHubUsername: "example"}
+	maintainerClaims := &auth.EphemeralTokenClaims{GitHubUsername: "co-maintainer"}
+
+	t.Run("publisher is a maintainer after publish", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersion(t, registry, "1.0.0")
+
+		assert.Equal(t, []string{"example"}, getServer(t, registry, id).MaintainedBy)
+	})
+
+	t.Run("added maintainer can modify all versions", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		oldID := publishVersion(t, registry, "1.0.0")
+		latestID := publishVersion(t, registry, "1.1.0")
+
+		rr := addMaintainer(t, registry, publisherClaims, latestID, "co-maintainer", true)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp map[string]any
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Equal(t, []any{"example", "co-maintainer"}, resp["maintained_by"])
+		assert.Equal(t, []string{"example", "co-maintainer"}, getServer(t, registry, oldID).MaintainedBy)
+
+		rr = pinVersion(t, registry, maintainerClaims, latestID, "1.0.0")
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		// New versions keep the maintainers
+		newID := publishVersion(t, registry, "1.2.0")
+		assert.Equal(t, []string{"example", "co-maintainer"}, getServer(t, registry, newID).MaintainedBy)
+	})
+
+	t.Run("removed maintainer loses access", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersion(t, registry, "1.0.0")
+
+		rr := addMaintainer(t, registry, publisherClaims, id, "co-maintainer", true)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		rr = removeMaintainer(t, registry, publisherClaims, id, "Co-Maintainer")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, []string{"example"}, getServer(t, registry, id).MaintainedBy)
+
+		rr = pinVersion(t, registry, maintainerClaims, id, "1.0.0")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("maintainers cannot manage maintainers", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersion(t, registry, "1.0.0")
+
+		rr := addMaintainer(t, registry, publisherClaims, id, "co-maintainer", true)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		rr = addMaintainer(t, registry, maintainerClaims, id, "someone-else", true)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		rr = removeMaintainer(t, registry, maintainerClaims, id, "co-maintainer")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Equal(t, []string{"example", "co-maintainer"}, getServer(t, registry, id).MaintainedBy)
+	})
+
+	t.Run("non-maintainer access is rejected", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersion(t, registry, "1.0.0")
+		outsiderClaims := &auth.EphemeralTokenClaims{GitHubUsername: "someone-else"}
+
+		rr := addMaintainer(t, registry, outsiderClaims, id, "someone-else", true)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		rr = removeMaintainer(t, registry, outsiderClaims, id, "example")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		rr = pinVersion(t, registry, outsiderClaims, id, "1.0.0")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Equal(t, []string{"example"}, getServer(t, registry, id).MaintainedBy)
+	})
+
+	t.Run("unknown GitHub user", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersion(t, registry, "1.0.0")
+
+		rr := addMaintainer(t, registry, publisherClaims, id, "ghost-user", false)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "GitHub user not found")
+	})
+
+	t.Run("existing maintainer", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersion(t, registry, "1.0.0")
+
+		rr := addMaintainer(t, registry, publisherClaims, id, "Example", true)
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("publisher cannot be removed", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersion(t, registry, "1.0.0")
+
+		rr := removeMaintainer(t, registry, publisherClaims, id, "example")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("user who is not a maintainer", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersion(t, registry, "1.0.0")
+
+		rr := removeMaintainer(t, registry, publisherClaims, id, "someone-else")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestMaintainerInfoFromPublishOSS(t *testing.T) {
+	stubGitHubRepoAPI(t)
+	authService := auth.NewAuthService(&config.Config{EphemeralTokenSecret: testEphemeralTokenSecret})
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+
+	publish := func(t *testing.T, ossReq model.PublishOSSRequest) *httptest.ResponseRecorder {
+		t.Helper()
+		body, err := json.Marshal(ossReq)
+		require.NoError(t, err)
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/publish-oss", bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+signEphemeralToken(t, time.Hour))
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.PublishOSSHandler(registry, authService), rr, req)
+		return rr
+	}
+
+	t.Run("publisher is added", func(t *testing.T) {
+		ossReq := ossRepository("maintained-server")
+		ossReq.Maintainers = []model.Maintainer{{Name: "Jane Doe", Email: "jane@example.com"}}
+		rr := publish(t, ossReq)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var published struct {
+			ID string `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &published))
+		serverDetail, err := registry.GetByID(published.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []model.Maintainer{
+			{Name: "Jane Doe", Email: "jane@example.com"},
+			{GitHubUsername: "testuser"},
+		}, serverDetail.Maintainers)
+	})
+
+	t.Run("publisher is not listed twice", func(t *testing.T) {
+		ossReq := ossRepository("listed-server")
+		ossReq.Maintainers = []model.Maintainer{{Name: "Test User", GitHubUsername: "TestUser"}}
+		rr := publish(t, ossReq)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	})
+
+	t.Run("invalid maintainers are rejected", func(t *testing.T) {
+		ossReq := ossRepository("invalid-server")
+		ossReq.Maintainers = []model.Maintainer{{Email: "not an email", GitHubUsername: "jane_doe"}}
+		rr := publish(t, ossReq)
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), "maintainers[0].email")
+		assert.Contains(t, rr.Body.String(), "maintainers[0].github_username")
+	})
+
+	t.Run("search by maintainer", func(t *testing.T) {
+		for query, expectedNames := range map[string][]string{
+			"?maintainer=testuser": {"io.github.example/maintained-server"},
+			"?maintainer=TestUser": {"io.github.example/listed-server"},
+			"?maintainer=jane":     {},
+		} {
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/search"+query, nil)
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			serveHTTP(t, v0.SearchHandler(registry, &config.Config{}), rr, req)
+			require.Equal(t, http.StatusOK, rr.Code, query)
+
+			var resp v0.ResponseEnvelope[[]model.ServerDetail]
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			names := make([]string, len(resp.Data))
+			for i, server := range resp.Data {
+				names[i] = server.Name
+			}
+			assert.ElementsMatch(t, expectedNames, names, query)
+		}
+	})
+}
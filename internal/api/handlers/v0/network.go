@@ -0,0 +1,50 @@
+package v0
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// NetworkHandler returns a handler for the ecosystem of a server: the servers sharing its publisher or a tag,
+// the servers it depends on and the servers depending on it. Only direct connections (depth 1) are supported.
+// Networks are cached, so a newly published server may not appear right away.
+func NetworkHandler(registry service.RegistryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		if depth := r.URL.Query().Get("depth"); depth != "" && depth != "1" {
+			WriteError(w, APIError{
+				Code:    ErrCodeInvalidRequest,
+				Message: "Invalid depth parameter: only depth 1 is supported",
+			}, http.StatusBadRequest)
+			return
+		}
+
+		network, err := registry.Network(r.Context(), id)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Server not found"}, http.StatusNotFound)
+				return
+			}
+			middleware.Logf(r.Context(), "network: Failed to build network of %s: %v", id, err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Error building server network"}, http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, network)
+	}
+}
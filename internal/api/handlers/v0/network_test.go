@@ -0,0 +1,110 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkHandler(t *testing.T) {
+	serverID := uuid.New().String()
+	publisherSibling := uuid.New().String()
+	network := &model.ServerNetwork{
+		Nodes: []model.ServerMinimal{
+			{ID: serverID, Name: "io.github.acme/center"},
+			{ID: publisherSibling, Name: "io.github.acme/sibling"},
+		},
+		Edges: []model.NetworkEdge{
+			{From: serverID, To: publisherSibling, Relation: model.NetworkRelationSamePublisher},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		id         string
+		query      string
+		setupMock  func(*MockRegistryService)
+		wantStatus int
+	}{
+		{
+			name: "returns network",
+			id:   serverID,
+			setupMock: func(registry *MockRegistryService) {
+				registry.Mock.On("Network", mock.Anything, serverID).Return(network, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:  "depth 1 is supported",
+			id:    serverID,
+			query: "?depth=1",
+			setupMock: func(registry *MockRegistryService) {
+				registry.Mock.On("Network", mock.Anything, serverID).Return(network, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "deeper networks are not supported",
+			id:         serverID,
+			query:      "?depth=2",
+			setupMock:  func(*MockRegistryService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "unknown server",
+			id:   serverID,
+			setupMock: func(registry *MockRegistryService) {
+				registry.Mock.On("Network", mock.Anything, serverID).Return(nil, database.ErrNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "database error",
+			id:   serverID,
+			setupMock: func(registry *MockRegistryService) {
+				registry.Mock.On("Network", mock.Anything, serverID).Return(nil, errors.New("connection lost"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "invalid ID",
+			id:         "not-a-uuid",
+			setupMock:  func(*MockRegistryService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := new(MockRegistryService)
+			tt.setupMock(registry)
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers/"+tt.id+"/network"+tt.query, nil)
+			require.NoError(t, err)
+			req.SetPathValue("id", tt.id)
+			rr := httptest.NewRecorder()
+			serveHTTP(t, v0.NetworkHandler(registry), rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code, rr.Body.String())
+			registry.Mock.AssertExpectations(t)
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var resp model.ServerNetwork
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+			assert.Equal(t, *network, resp)
+		})
+	}
+}
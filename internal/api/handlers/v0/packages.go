@@ -0,0 +1,298 @@
+// Package v0 contains API handlers for version 0 of the API
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// AppendPackagesRequest represents the request body for appending packages to a server
+type AppendPackagesRequest struct {
+	Packages []model.Package `json:"packages"`
+}
+
+// YankPackageRequest represents the request body for yanking a package version of a server
+type YankPackageRequest struct {
+	Version string `json:"version"`
+	Reason  string `json:"reason"`
+}
+
+// PackagesResponse represents the packages of a server after a modification
+type PackagesResponse struct {
+	Packages []model.Package `json:"packages"`
+}
+
+// AppendPackagesHandler handles requests to add packages to an existing server
+func AppendPackagesHandler(registry service.RegistryService, authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Only allow POST method
+		if r.Method != http.MethodPost {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Extract the server ID from the URL path
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		// Parse request body
+		var req AppendPackagesRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidPayload, Message: "Invalid request payload: " + err.Error()}, http.StatusBadRequest)
+			return
+		}
+		defer r.Body.Close()
+
+		if len(req.Packages) == 0 {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "At least one package is required"}, http.StatusBadRequest)
+			return
+		}
+
+		// Validate package fields and reject duplicates within the request itself
+		seen := make(map[string]bool)
+		for i, pkg := range req.Packages {
+			if pkg.RegistryName == "" || pkg.Name == "" || pkg.Version == "" {
+				WriteError(w, APIError{
+					Code:    ErrCodeInvalidRequest,
+					Message: fmt.Sprintf("Package %d: registry_name, name and version are required", i),
+				}, http.StatusBadRequest)
+				return
+			}
+			key := pkg.RegistryName + "/" + pkg.Name
+			if seen[key] {
+				WriteError(w, APIError{
+					Code:    ErrCodeConflict,
+					Message: fmt.Sprintf("Package %d: duplicate package %s", i, key),
+				}, http.StatusConflict)
+				return
+			}
+			seen[key] = true
+		}
+
+		serverDetail, ok := authorizeServerModification(w, r, registry, authService, id)
+		if !ok || !checkIfMatch(w, r, serverDetail) {
+			return
+		}
+
+		packages, err := registry.AppendPackages(r.Context(), id, req.Packages)
+		if err != nil {
+			if writeValidationErrors(w, err) {
+				return
+			}
+			switch {
+			case errors.Is(err, database.ErrNotFound):
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Server not found"}, http.StatusNotFound)
+			case errors.Is(err, database.ErrAlreadyExists):
+				WriteError(w, APIError{Code: ErrCodeConflict, Message: "Package already exists for this server"}, http.StatusConflict)
+			default:
+				middleware.Logf(r.Context(), "packages: Failed to append packages to server %s: %v", id, err)
+				WriteError(w, APIError{
+					Code:    ErrCodeInternal,
+					Message: "Failed to append packages: " + err.Error(),
+				}, http.StatusInternalServerError)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(PackagesResponse{Packages: packages}); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to encode response"}, http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// RemovePackageHandler handles requests to remove a single package from an existing server.
+// Package names containing slashes (e.g. scoped npm packages) must be URL-encoded.
+func RemovePackageHandler(registry service.RegistryService, authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Only allow DELETE method
+		if r.Method != http.MethodDelete {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Extract the server ID and package identifiers from the URL path
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		registryName := r.PathValue("registry")
+		packageName := r.PathValue("name")
+		if registryName == "" || packageName == "" {
+			WriteError(w, APIError{
+				Code:    ErrCodeInvalidRequest,
+				Message: "Registry name and package name are required",
+			}, http.StatusBadRequest)
+			return
+		}
+
+		serverDetail, ok := authorizeServerModification(w, r, registry, authService, id)
+		if !ok || !checkIfMatch(w, r, serverDetail) {
+			return
+		}
+
+		err := registry.RemovePackage(r.Context(), id, registryName, packageName)
+		if err != nil {
+			switch {
+			case errors.Is(err, database.ErrNotFound):
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Package not found"}, http.StatusNotFound)
+			case errors.Is(err, database.ErrLastPackage):
+				WriteError(w, APIError{
+					Code:    ErrCodeUnprocessable,
+					Message: "Cannot remove the last package of a server",
+				}, http.StatusUnprocessableEntity)
+			default:
+				middleware.Logf(r.Context(), "packages: Failed to remove package %s/%s from server %s: %v", registryName, packageName, id, err)
+				WriteError(w, APIError{
+					Code:    ErrCodeInternal,
+					Message: "Failed to remove package: " + err.Error(),
+				}, http.StatusInternalServerError)
+			}
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// YankPackageHandler handles requests to mark the packages of a server with the given registry name and
+// version as yanked, e.g. after they were deprecated or removed upstream
+func YankPackageHandler(registry service.RegistryService, authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Only allow POST method
+		if r.Method != http.MethodPost {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Extract the server ID and registry name from the URL path
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		registryName := r.PathValue("registry")
+		if registryName == "" {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Registry name is required"}, http.StatusBadRequest)
+			return
+		}
+
+		// Parse request body
+		var req YankPackageRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidPayload, Message: "Invalid request payload: " + err.Error()}, http.StatusBadRequest)
+			return
+		}
+		defer r.Body.Close()
+
+		if req.Version == "" {
+			WriteError(w, APIError{Code: ErrCodeInvalidVersion, Message: "Version is required"}, http.StatusBadRequest)
+			return
+		}
+
+		serverDetail, ok := authorizeServerModification(w, r, registry, authService, id)
+		if !ok || !checkIfMatch(w, r, serverDetail) {
+			return
+		}
+
+		packages, err := registry.YankPackage(r.Context(), id, registryName, req.Version, req.Reason)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Package not found"}, http.StatusNotFound)
+				return
+			}
+			middleware.Logf(r.Context(), "packages: Failed to yank %s package version %s of server %s: %v", registryName, req.Version, id, err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to yank package: " + err.Error()}, http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, PackagesResponse{Packages: packages})
+	}
+}
+
+// authorizeServerModification checks that the request is made by the registry owner, the publisher
+// of the server or one of its maintainers, and returns the current server. It writes an error
+// response and returns false if the request is not authorized.
+func authorizeServerModification(
+	w http.ResponseWriter, r *http.Request, registry service.RegistryService, authService auth.Service, id string,
+) (*model.ServerDetail, bool) {
+	serverDetail, _, ok := authorizePublisherClaims(w, r, registry, authService, id)
+	return serverDetail, ok
+}
+
+// authorizeServerOwner checks that the request is made by the registry owner or the current publisher
+// of the server, and returns the current server. Maintainers are rejected, so that they cannot take
+// over the server or change who maintains it. It writes an error response and returns false if the
+// request is not authorized.
+func authorizeServerOwner(
+	w http.ResponseWriter, r *http.Request, registry service.RegistryService, authService auth.Service, id string,
+) (*model.ServerDetail, bool) {
+	serverDetail, claims, ok := authenticateServerRequest(w, r, registry, authService, id)
+	if !ok {
+		return nil, false
+	}
+
+	// The registry owner may manage any server
+	if claims != nil && !strings.EqualFold(claims.GitHubUsername, serverDetail.Publisher()) {
+		WriteError(w, APIError{
+			Code:    ErrCodeForbidden,
+			Message: "Only the publisher or registry owner can manage ownership of this server",
+		}, http.StatusForbidden)
+		return nil, false
+	}
+
+	return serverDetail, true
+}
+
+// authenticateServerRequest authenticates the request and returns the server with the given ID together
+// with the claims of the caller, which are nil for the registry owner. It writes an error response and
+// returns false if the request is not authenticated or the server cannot be retrieved.
+func authenticateServerRequest(
+	w http.ResponseWriter, r *http.Request, registry service.RegistryService, authService auth.Service, id string,
+) (*model.ServerDetail, *auth.EphemeralTokenClaims, bool) {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		WriteError(w, APIError{Code: ErrCodeAuthRequired, Message: "Authorization header is required"}, http.StatusUnauthorized)
+		return nil, nil, false
+	}
+
+	token := auth.ParseAuthorizationHeader(authHeader)
+	valid, claims, err := authService.ValidateEphemeralOrOwnerToken(r.Context(), token)
+	if err != nil {
+		WriteError(w, APIError{Code: ErrCodeAuthFailed, Message: "Authentication failed: " + err.Error()}, http.StatusUnauthorized)
+		return nil, nil, false
+	}
+	if !valid {
+		WriteError(w, APIError{Code: ErrCodeForbidden, Message: "Invalid authentication token"}, http.StatusForbidden)
+		return nil, nil, false
+	}
+
+	serverDetail, err := registry.GetByID(id)
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Server not found"}, http.StatusNotFound)
+			return nil, nil, false
+		}
+		WriteError(w, APIError{Code: ErrCodeInternal, Message: "Error retrieving server details"}, http.StatusInternalServerError)
+		return nil, nil, false
+	}
+
+	return serverDetail, claims, true
+}
@@ -0,0 +1,490 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/uuid"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newPackagesTestRegistry creates a registry backed by an in-memory database containing one published server
+func newPackagesTestRegistry(t *testing.T) (service.RegistryService, *model.ServerDetail) {
+	t.Helper()
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+
+	serverDetail := &model.ServerDetail{
+		Server: model.Server{
+			Name:        "io.github.example/packages-server",
+			Description: "Packages test server",
+			Repository: model.Repository{
+				URL:    "https://github.com/example/packages-server",
+				Source: "github",
+				ID:     "example/packages-server",
+			},
+			VersionDetail: model.VersionDetail{
+				Version: "1.0.0",
+			},
+		},
+		Packages: []model.Package{
+			{RegistryName: "npm", Name: "@example/packages-server", Version: "1.0.0"},
+		},
+	}
+	require.NoError(t, registry.Publish(serverDetail))
+
+	return registry, serverDetail
+}
+
+func TestAppendPackagesHandler(t *testing.T) {
+	publisherClaims := &auth.EphemeralTokenClaims{GitHubUsername: "example"}
+
+	testCases := []struct {
+		name           string
+		serverID       func(*model.ServerDetail) string
+		packages       []model.Package
+		claims         *auth.EphemeralTokenClaims
+		expectedStatus int
+		expectedError  string
+		expectedCount  int
+	}{
+		{
+			name: "adds package from a new registry",
+			packages: []model.Package{
+				{RegistryName: "docker", Name: "example/packages-server", Version: "1.0.0"},
+			},
+			claims:         publisherClaims,
+			expectedStatus: http.StatusOK,
+			expectedCount:  2,
+		},
+		{
+			name: "registry owner can add packages",
+			packages: []model.Package{
+				{RegistryName: "pypi", Name: "packages-server", Version: "1.0.0"},
+			},
+			expectedStatus: http.StatusOK,
+			expectedCount:  2,
+		},
+		{
+			name: "rejects duplicate package",
+			packages: []model.Package{
+				{RegistryName: "npm", Name: "@example/packages-server", Version: "2.0.0"},
+			},
+			claims:         publisherClaims,
+			expectedStatus: http.StatusConflict,
+			expectedError:  "Package already exists",
+		},
+		{
+			name: "rejects exceeding max packages",
+			packages: func() []model.Package {
+				packages := make([]model.Package, service.MaxPackagesPerServer)
+				for i := range packages {
+					packages[i] = model.Package{RegistryName: "docker", Name: fmt.Sprintf("example/image-%d", i), Version: "1.0.0"}
+				}
+				return packages
+			}(),
+			claims:         publisherClaims,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "at most 20 packages are allowed",
+		},
+		{
+			name:     "non-existent server",
+			serverID: func(_ *model.ServerDetail) string { return uuid.New().String() },
+			packages: []model.Package{
+				{RegistryName: "docker", Name: "example/packages-server", Version: "1.0.0"},
+			},
+			claims:         publisherClaims,
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "Server not found",
+		},
+		{
+			name: "rejects user who is not the publisher",
+			packages: []model.Package{
+				{RegistryName: "docker", Name: "example/packages-server", Version: "1.0.0"},
+			},
+			claims:         &auth.EphemeralTokenClaims{GitHubUsername: "someone-else"},
+			expectedStatus: http.StatusForbidden,
+			expectedError:  "Only the publisher, maintainers or registry owner",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			registry, serverDetail := newPackagesTestRegistry(t)
+			serverID := serverDetail.ID
+			if tc.serverID != nil {
+				serverID = tc.serverID(serverDetail)
+			}
+
+			mockAuthService := new(MockAuthService)
+			mockAuthService.Mock.On("ValidateEphemeralOrOwnerToken", mock.Anything, "test-token").Return(true, tc.claims, nil)
+
+			body, err := json.Marshal(v0.AppendPackagesRequest{Packages: tc.packages})
+			require.NoError(t, err)
+
+			req, err := http.NewRequestWithContext(
+				context.Background(), http.MethodPost, "/v0/servers/"+serverID+"/packages", bytes.NewReader(body),
+			)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer test-token")
+			req.SetPathValue("id", serverID)
+
+			rr := httptest.NewRecorder()
+			serveHTTP(t, v0.AppendPackagesHandler(registry, mockAuthService), rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusOK {
+				var resp v0.PackagesResponse
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+				assert.Len(t, resp.Packages, tc.expectedCount)
+				assert.Equal(t, serverDetail.Packages[0], resp.Packages[0])
+				// Packages without a documentation URL link to their registry page
+				added := resp.Packages[1]
+				assert.NotEmpty(t, added.DocURL)
+				added.DocURL = ""
+				assert.Equal(t, tc.packages[0], added)
+			} else {
+				assert.Contains(t, rr.Body.String(), tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestAppendPackagesHandlerRequiresAuth(t *testing.T) {
+	registry, serverDetail := newPackagesTestRegistry(t)
+
+	body := `{"packages": [{"registry_name": "docker", "name": "example/packages-server", "version": "1.0.0"}]}`
+	req, err := http.NewRequestWithContext(
+		context.Background(), http.MethodPost, "/v0/servers/"+serverDetail.ID+"/packages", bytes.NewBufferString(body),
+	)
+	require.NoError(t, err)
+	req.SetPathValue("id", serverDetail.ID)
+
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.AppendPackagesHandler(registry, new(MockAuthService)), rr, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.Contains(t, rr.Body.String(), "Authorization header is required")
+}
+
+func TestRemovePackageHandler(t *testing.T) {
+	publisherClaims := &auth.EphemeralTokenClaims{GitHubUsername: "example"}
+	dockerPackage := model.Package{RegistryName: "docker", Name: "example/packages-server", Version: "1.0.0"}
+
+	testCases := []struct {
+		name             string
+		extraPackages    []model.Package
+		registryName     string
+		packageName      string
+		authHeader       string
+		claims           *auth.EphemeralTokenClaims
+		expectedStatus   int
+		expectedError    string
+		expectedPackages []string
+	}{
+		{
+			name:             "removes package with URL-encoded name",
+			extraPackages:    []model.Package{dockerPackage},
+			registryName:     "npm",
+			packageName:      "@example/packages-server",
+			authHeader:       "Bearer test-token",
+			claims:           publisherClaims,
+			expectedStatus:   http.StatusNoContent,
+			expectedPackages: []string{"example/packages-server"},
+		},
+		{
+			name:             "rejects removing the last package",
+			registryName:     "npm",
+			packageName:      "@example/packages-server",
+			authHeader:       "Bearer test-token",
+			claims:           publisherClaims,
+			expectedStatus:   http.StatusUnprocessableEntity,
+			expectedError:    "Cannot remove the last package",
+			expectedPackages: []string{"@example/packages-server"},
+		},
+		{
+			name:             "non-existent package",
+			extraPackages:    []model.Package{dockerPackage},
+			registryName:     "pypi",
+			packageName:      "packages-server",
+			authHeader:       "Bearer test-token",
+			claims:           publisherClaims,
+			expectedStatus:   http.StatusNotFound,
+			expectedError:    "Package not found",
+			expectedPackages: []string{"@example/packages-server", "example/packages-server"},
+		},
+		{
+			name:             "missing authorization header",
+			extraPackages:    []model.Package{dockerPackage},
+			registryName:     "npm",
+			packageName:      "@example/packages-server",
+			expectedStatus:   http.StatusUnauthorized,
+			expectedError:    "Authorization header is required",
+			expectedPackages: []string{"@example/packages-server", "example/packages-server"},
+		},
+		{
+			name:             "rejects user who is not the publisher",
+			extraPackages:    []model.Package{dockerPackage},
+			registryName:     "npm",
+			packageName:      "@example/packages-server",
+			authHeader:       "Bearer test-token",
+			claims:           &auth.EphemeralTokenClaims{GitHubUsername: "someone-else"},
+			expectedStatus:   http.StatusForbidden,
+			expectedError:    "Only the publisher, maintainers or registry owner",
+			expectedPackages: []string{"@example/packages-server", "example/packages-server"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			registry, serverDetail := newPackagesTestRegistry(t)
+			if len(tc.extraPackages) > 0 {
+				_, err := registry.AppendPackages(context.Background(), serverDetail.ID, tc.extraPackages)
+				require.NoError(t, err)
+			}
+
+			mockAuthService := new(MockAuthService)
+			mockAuthService.Mock.On("ValidateEphemeralOrOwnerToken", mock.Anything, "test-token").Return(true, tc.claims, nil)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/v0/servers/{id}/packages/{registry}/{name}", v0.RemovePackageHandler(registry, mockAuthService))
+
+			target := "/v0/servers/" + serverDetail.ID + "/packages/" + tc.registryName + "/" + url.PathEscape(tc.packageName)
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodDelete, target, nil)
+			require.NoError(t, err)
+			if tc.authHeader != "" {
+				req.Header.Set("Authorization", tc.authHeader)
+			}
+
+			rr := httptest.NewRecorder()
+			serveHTTP(t, mux, rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedError != "" {
+				assert.Contains(t, rr.Body.String(), tc.expectedError)
+			}
+
+			updated, err := registry.GetByID(serverDetail.ID)
+			require.NoError(t, err)
+			var names []string
+			for _, pkg := range updated.Packages {
+				names = append(names, pkg.Name)
+			}
+			assert.Equal(t, tc.expectedPackages, names)
+		})
+	}
+}
+
+func TestPackagesHandlersIfMatch(t *testing.T) {
+	registry, serverDetail := newPackagesTestRegistry(t)
+
+	mockAuthService := new(MockAuthService)
+	mockAuthService.Mock.On("ValidateEphemeralOrOwnerToken", mock.Anything, "test-token").
+		Return(true, &auth.EphemeralTokenClaims{GitHubUsername: "example"}, nil)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0/servers/{id}", v0.ServersDetailHandler(registry, nil))
+	mux.HandleFunc("/v0/servers/{id}/packages", v0.AppendPackagesHandler(registry, mockAuthService))
+	mux.HandleFunc("/v0/servers/{id}/packages/{registry}/{name}", v0.RemovePackageHandler(registry, mockAuthService))
+
+	getETag := func() string {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers/"+serverDetail.ID, nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, mux, rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		etag := rr.Header().Get("ETag")
+		require.NotEmpty(t, etag)
+		return etag
+	}
+
+	appendPackage := func(name, ifMatch string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(v0.AppendPackagesRequest{Packages: []model.Package{
+			{RegistryName: "docker", Name: name, Version: "1.0.0"},
+		}})
+		require.NoError(t, err)
+		req, err := http.NewRequestWithContext(
+			context.Background(), http.MethodPost, "/v0/servers/"+serverDetail.ID+"/packages", bytes.NewReader(body),
+		)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer test-token")
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		rr := httptest.NewRecorder()
+		serveHTTP(t, mux, rr, req)
+		return rr
+	}
+
+	// Two clients read the same version of the server
+	firstClientETag := getETag()
+	secondClientETag := getETag()
+	assert.Equal(t, firstClientETag, secondClientETag)
+
+	// The first update succeeds and changes the ETag
+	rr := appendPackage("example/first-client", firstClientETag)
+	require.Equal(t, http.StatusOK, rr.Code)
+	currentETag := getETag()
+	assert.NotEqual(t, firstClientETag, currentETag)
+
+	// The second update is based on a stale version and is rejected
+	rr = appendPackage("example/second-client", secondClientETag)
+	require.Equal(t, http.StatusPreconditionFailed, rr.Code)
+
+	var resp v0.PreconditionFailedResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Equal(t, "conflict", resp.Error)
+	assert.Equal(t, currentETag, resp.CurrentETag)
+
+	// Removing a package with a stale ETag is rejected as well
+	req, err := http.NewRequestWithContext(
+		context.Background(), http.MethodDelete, "/v0/servers/"+serverDetail.ID+"/packages/docker/"+url.PathEscape("example/first-client"), nil,
+	)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer test-token")
+	req.Header.Set("If-Match", secondClientETag)
+	rr = httptest.NewRecorder()
+	serveHTTP(t, mux, rr, req)
+	assert.Equal(t, http.StatusPreconditionFailed, rr.Code)
+
+	// Retrying with the current ETag succeeds, and requests without If-Match are unconditional
+	rr = appendPackage("example/second-client", currentETag)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	rr = appendPackage("example/third-client", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	updated, err := registry.GetByID(serverDetail.ID)
+	require.NoError(t, err)
+	assert.Len(t, updated.Packages, 4)
+}
+
+func TestYankPackageHandler(t *testing.T) {
+	publisherClaims := &auth.EphemeralTokenClaims{GitHubUsername: "example"}
+
+	testCases := []struct {
+		name           string
+		registryName   string
+		body           string
+		authHeader     string
+		claims         *auth.EphemeralTokenClaims
+		expectedStatus int
+		expectedError  string
+		expectedYanked bool
+	}{
+		{
+			name:           "yanks package version",
+			registryName:   "npm",
+			body:           `{"version": "1.0.0", "reason": "security vulnerability"}`,
+			authHeader:     "Bearer test-token",
+			claims:         publisherClaims,
+			expectedStatus: http.StatusOK,
+			expectedYanked: true,
+		},
+		{
+			name:           "registry owner can yank packages",
+			registryName:   "npm",
+			body:           `{"version": "1.0.0", "reason": "security vulnerability"}`,
+			authHeader:     "Bearer test-token",
+			expectedStatus: http.StatusOK,
+			expectedYanked: true,
+		},
+		{
+			name:           "unknown version",
+			registryName:   "npm",
+			body:           `{"version": "2.0.0", "reason": "security vulnerability"}`,
+			authHeader:     "Bearer test-token",
+			claims:         publisherClaims,
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "Package not found",
+		},
+		{
+			name:           "unknown registry",
+			registryName:   "pypi",
+			body:           `{"version": "1.0.0"}`,
+			authHeader:     "Bearer test-token",
+			claims:         publisherClaims,
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "Package not found",
+		},
+		{
+			name:           "version is required",
+			registryName:   "npm",
+			body:           `{"reason": "security vulnerability"}`,
+			authHeader:     "Bearer test-token",
+			claims:         publisherClaims,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Version is required",
+		},
+		{
+			name:           "missing authorization header",
+			registryName:   "npm",
+			body:           `{"version": "1.0.0"}`,
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "Authorization header is required",
+		},
+		{
+			name:           "rejects user who is not the publisher",
+			registryName:   "npm",
+			body:           `{"version": "1.0.0"}`,
+			authHeader:     "Bearer test-token",
+			claims:         &auth.EphemeralTokenClaims{GitHubUsername: "someone-else"},
+			expectedStatus: http.StatusForbidden,
+			expectedError:  "Only the publisher, maintainers or registry owner",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			registry, serverDetail := newPackagesTestRegistry(t)
+
+			mockAuthService := new(MockAuthService)
+			mockAuthService.Mock.On("ValidateEphemeralOrOwnerToken", mock.Anything, "test-token").Return(true, tc.claims, nil)
+
+			// The yank route takes precedence over removing a package named "yank"
+			mux := http.NewServeMux()
+			mux.HandleFunc("/v0/servers/{id}/packages/{registry}/{name}", v0.RemovePackageHandler(registry, mockAuthService))
+			mux.HandleFunc("/v0/servers/{id}/packages/{registry}/yank", v0.YankPackageHandler(registry, mockAuthService))
+
+			req, err := http.NewRequestWithContext(
+				context.Background(), http.MethodPost, "/v0/servers/"+serverDetail.ID+"/packages/"+tc.registryName+"/yank",
+				bytes.NewBufferString(tc.body),
+			)
+			require.NoError(t, err)
+			if tc.authHeader != "" {
+				req.Header.Set("Authorization", tc.authHeader)
+			}
+			rr := httptest.NewRecorder()
+			serveHTTP(t, mux, rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusOK {
+				var resp v0.PackagesResponse
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+				require.Len(t, resp.Packages, 1)
+				assert.True(t, resp.Packages[0].Yanked)
+			} else {
+				assert.Contains(t, rr.Body.String(), tc.expectedError)
+			}
+
+			// The yank is part of the server details
+			stored, err := registry.GetByID(serverDetail.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedYanked, stored.Packages[0].Yanked)
+			if tc.expectedYanked {
+				assert.Equal(t, "security vulnerability", stored.Packages[0].YankReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// roundTripFunc allows a function to be used as an http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// stubGitHubUserAPI replaces the default HTTP transport with a fake GitHub /user endpoint for the duration
+// of the test. Only the token "valid-pat" is accepted; it is reported to have the given scopes.
+func stubGitHubUserAPI(t *testing.T, scopes string) {
+	t.Helper()
+	original := http.DefaultTransport
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "api.github.com", req.URL.Host)
+		assert.Equal(t, "/user", req.URL.Path)
+
+		if req.Header.Get("Authorization") != "Bearer valid-pat" {
+			return &http.Response{
+				StatusCode: http.StatusUnauthorized,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(`{"message": "Bad credentials"}`)),
+			}, nil
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type":   []string{"application/json"},
+				"X-Oauth-Scopes": []string{scopes},
+			},
+			Body: io.NopCloser(strings.NewReader(`{"id": 42, "login": "octocat"}`)),
+		}, nil
+	})
+	t.Cleanup(func() {
+		http.DefaultTransport = original
+	})
+}
+
+func exchangePAT(t *testing.T, authService auth.Service, pat string) *httptest.ResponseRecorder {
+	t.Helper()
+	body, err := json.Marshal(v0.PATRequest{GitHubPAT: pat})
+	require.NoError(t, err)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/auth/pat", bytes.NewReader(body))
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.PATHandler(authService), rr, req)
+	return rr
+}
+
+func TestPATHandler(t *testing.T) {
+	authService := auth.NewAuthService(&config.Config{EphemeralTokenSecret: testEphemeralTokenSecret})
+
+	t.Run("valid PAT", func(t *testing.T) {
+		stubGitHubUserAPI(t, "public_repo, read:org")
+
+		rr := exchangePAT(t, authService, "valid-pat")
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp v0.AuthorizeResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		assert.Equal(t, 3600, resp.ExpiresIn)
+
+		valid, claims, err := authService.ValidateEphemeralOrOwnerToken(context.Background(), resp.EphemeralToken)
+		require.NoError(t, err)
+		assert.True(t, valid)
+		require.NotNil(t, claims)
+		assert.Equal(t, "octocat", claims.GitHubUsername)
+		assert.Equal(t, "42", claims.GitHubUserID)
+	})
+
+	t.Run("repo scope includes public_repo", func(t *testing.T) {
+		stubGitHubUserAPI(t, "repo")
+
+		rr := exchangePAT(t, authService, "valid-pat")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("invalid PAT", func(t *testing.T) {
+		stubGitHubUserAPI(t, "public_repo")
+
+		rr := exchangePAT(t, authService, "revoked-pat")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("insufficient scopes", func(t *testing.T) {
+		stubGitHubUserAPI(t, "read:user, gist")
+
+		rr := exchangePAT(t, authService, "valid-pat")
+		require.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+		var resp v0.InsufficientScopeResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		assert.Equal(t, "insufficient_scope", resp.Error)
+		assert.Equal(t, []string{"public_repo"}, resp.Required)
+		assert.Equal(t, []string{"read:user", "gist"}, resp.Provided)
+	})
+
+	t.Run("no scopes", func(t *testing.T) {
+		stubGitHubUserAPI(t, "")
+
+		rr := exchangePAT(t, authService, "valid-pat")
+		require.Equal(t, http.StatusForbidden, rr.Code)
+
+		var resp v0.InsufficientScopeResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		assert.Empty(t, resp.Provided)
+		assert.NotNil(t, resp.Provided)
+	})
+
+	t.Run("missing PAT", func(t *testing.T) {
+		rr := exchangePAT(t, authService, "")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -0,0 +1,80 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// PinVersionRequest represents the request body for pinning a server version
+type PinVersionRequest struct {
+	Version string `json:"version"`
+}
+
+// PinVersionHandler handles requests to pin the version of a server shown as latest.
+// The pin is released when a new version of the server is published or the pinned version is yanked.
+func PinVersionHandler(registry service.RegistryService, authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Only allow POST method
+		if r.Method != http.MethodPost {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Extract the server ID from the URL path
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		// Parse request body
+		var req PinVersionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidPayload, Message: "Invalid request payload: " + err.Error()}, http.StatusBadRequest)
+			return
+		}
+		defer r.Body.Close()
+
+		if req.Version == "" {
+			WriteError(w, APIError{Code: ErrCodeInvalidVersion, Message: "Version is required"}, http.StatusBadRequest)
+			return
+		}
+
+		serverDetail, ok := authorizeServerModification(w, r, registry, authService, id)
+		if !ok || !checkIfMatch(w, r, serverDetail) {
+			return
+		}
+
+		if err := registry.PinVersion(r.Context(), id, req.Version); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Version not found"}, http.StatusNotFound)
+				return
+			}
+			if errors.Is(err, database.ErrInvalidInput) {
+				WriteError(w, APIError{Code: ErrCodeInvalidVersion, Message: "Yanked versions cannot be pinned"}, http.StatusBadRequest)
+				return
+			}
+			middleware.Logf(r.Context(), "pin-version: Failed to pin version %s of server %s: %v", req.Version, id, err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to pin version: " + err.Error()}, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(map[string]any{
+			"message":        "Version pinned",
+			"name":           serverDetail.Name,
+			"version":        req.Version,
+			"pinned_version": true,
+		}); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to encode response"}, http.StatusInternalServerError)
+			return
+		}
+	}
+}
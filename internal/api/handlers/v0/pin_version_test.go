@@ -0,0 +1,164 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// publishVersion publishes a version of the pin test server and returns its ID
+func publishVersion(t *testing.T, registry service.RegistryService, version string) string {
+	t.Helper()
+	serverDetail := &model.ServerDetail{
+		Server: model.Server{
+			Name:          "io.github.example/pinned-server",
+			Repository:    model.Repository{URL: "https://github.com/example/pinned-server", Source: "github"},
+			VersionDetail: model.VersionDetail{Version: version},
+		},
+	}
+	require.NoError(t, registry.Publish(serverDetail))
+	return serverDetail.ID
+}
+
+// getServer fetches a server through the detail handler
+func getServer(t *testing.T, registry service.RegistryService, id string) model.ServerDetail {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers/"+id, nil)
+	require.NoError(t, err)
+	req.SetPathValue("id", id)
+
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.ServersDetailHandler(registry, nil), rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp v0.ResponseEnvelope[model.ServerDetail]
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	return resp.Data
+}
+
+func pinVersion(
+	t *testing.T, registry service.RegistryService, claims *auth.EphemeralTokenClaims, id, version string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+	mockAuthService := new(MockAuthService)
+	mockAuthService.Mock.On("ValidateEphemeralOrOwnerToken", mock.Anything, "test-token").Return(true, claims, nil)
+
+	body, err := json.Marshal(v0.PinVersionRequest{Version: version})
+	require.NoError(t, err)
+
+	req, err := http.NewRequestWithContext(
+		context.Background(), http.MethodPost, "/v0/servers/"+id+"/pin-version", bytes.NewReader(body),
+	)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer test-token")
+	req.SetPathValue("id", id)
+
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.PinVersionHandler(registry, mockAuthService), rr, req)
+	return rr
+}
+
+func TestPinVersionHandler(t *testing.T) {
+	publisherClaims := &auth.EphemeralTokenClaims{GitHubUsername: "example"}
+
+	t.Run("pins an older version and new publish releases the pin", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		stableID := publishVersion(t, registry, "1.0.0")
+		betaID := publishVersion(t, registry, "1.1.0-beta")
+
+		rr := pinVersion(t, registry, publisherClaims, betaID, "1.0.0")
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		stable := getServer(t, registry, stableID)
+		assert.True(t, stable.VersionDetail.IsLatest)
+		assert.True(t, stable.PinnedVersion)
+
+		beta := getServer(t, registry, betaID)
+		assert.False(t, beta.VersionDetail.IsLatest)
+		assert.False(t, beta.PinnedVersion)
+
+		// Publishing a new version makes it the latest and releases the pin
+		newID := publishVersion(t, registry, "1.2.0")
+
+		stable = getServer(t, registry, stableID)
+		assert.False(t, stable.VersionDetail.IsLatest)
+		assert.False(t, stable.PinnedVersion)
+
+		latest := getServer(t, registry, newID)
+		assert.True(t, latest.VersionDetail.IsLatest)
+		assert.False(t, latest.PinnedVersion)
+	})
+
+	t.Run("publishers cannot pin through the published document", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		serverDetail := &model.ServerDetail{
+			Server: model.Server{
+				Name:          "io.github.example/owned-server",
+				Repository:    model.Repository{URL: "https://github.com/example/owned-server", Source: "github"},
+				VersionDetail: model.VersionDetail{Version: "1.0.0"},
+				PinnedVersion: true,
+			},
+		}
+		require.NoError(t, registry.Publish(serverDetail))
+		assert.False(t, getServer(t, registry, serverDetail.ID).PinnedVersion)
+
+		body := `{"version_detail": {"version": "1.1.0"}, "pinned_version": true,
+			"packages": [{"registry_name": "npm", "name": "@example/owned-server", "version": "1.1.0"}]}`
+		rr := postVersion(t, registry, publisherClaims, serverDetail.ID, body)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var resp v0.PublishVersionResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.False(t, getServer(t, registry, resp.ID).PinnedVersion)
+	})
+
+	t.Run("registry owner can pin", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		stableID := publishVersion(t, registry, "1.0.0")
+		publishVersion(t, registry, "2.0.0")
+
+		rr := pinVersion(t, registry, nil, stableID, "1.0.0")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.True(t, getServer(t, registry, stableID).PinnedVersion)
+	})
+
+	t.Run("non-existent version", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersion(t, registry, "1.0.0")
+
+		rr := pinVersion(t, registry, publisherClaims, id, "9.9.9")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Version not found")
+
+		server := getServer(t, registry, id)
+		assert.True(t, server.VersionDetail.IsLatest)
+		assert.False(t, server.PinnedVersion)
+	})
+
+	t.Run("other users cannot pin", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersion(t, registry, "1.0.0")
+
+		rr := pinVersion(t, registry, &auth.EphemeralTokenClaims{GitHubUsername: "someone-else"}, id, "1.0.0")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("missing version", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersion(t, registry, "1.0.0")
+
+		rr := pinVersion(t, registry, publisherClaims, id, "")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -0,0 +1,108 @@
+package v0
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// AttestHandler handles requests to attach a SLSA provenance attestation to a server version.
+// The attestation is an in-toto statement and is stored verbatim; attesting a version again replaces it.
+func AttestHandler(registry service.RegistryService, authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		generatedAt := time.Now().UTC()
+
+		// Only allow POST method
+		if r.Method != http.MethodPost {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Extract the server ID from the URL path
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		// Read the attestation, rejecting bodies over the size limit
+		attestation, err := io.ReadAll(http.MaxBytesReader(w, r.Body, service.MaxAttestationSize))
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				WriteError(w, APIError{
+					Code:    ErrCodePayloadTooLarge,
+					Message: "Attestation exceeds the maximum size of 50 KB",
+				}, http.StatusRequestEntityTooLarge)
+				return
+			}
+			WriteError(w, APIError{Code: ErrCodeInvalidPayload, Message: "Error reading request body"}, http.StatusBadRequest)
+			return
+		}
+		defer r.Body.Close()
+
+		if _, ok := authorizeServerModification(w, r, registry, authService, id); !ok {
+			return
+		}
+
+		provenance, err := registry.SaveProvenance(r.Context(), id, attestation)
+		if err != nil {
+			switch {
+			case errors.Is(err, database.ErrInvalidAttestation):
+				WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: err.Error()}, http.StatusBadRequest)
+			case errors.Is(err, database.ErrNotFound):
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Server not found"}, http.StatusNotFound)
+			default:
+				middleware.Logf(r.Context(), "attest: Failed to save provenance of server %s: %v", id, err)
+				WriteError(w, APIError{
+					Code:    ErrCodeInternal,
+					Message: "Failed to save provenance: " + err.Error(),
+				}, http.StatusInternalServerError)
+			}
+			return
+		}
+
+		w.Header().Set("Location", "/v0/servers/"+id+"/provenance/"+provenance.Version)
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, NewResponseEnvelope(provenance, generatedAt))
+	}
+}
+
+// ProvenanceHandler returns a handler for the provenance attestation of a server. Without a version in the
+// path it returns the most recently submitted attestation of any version of the server.
+func ProvenanceHandler(registry service.RegistryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		generatedAt := time.Now().UTC()
+
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Extract the server ID from the URL path
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		provenance, err := registry.GetProvenance(r.Context(), id, r.PathValue("version"))
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Provenance not found"}, http.StatusNotFound)
+				return
+			}
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Error retrieving provenance"}, http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, NewResponseEnvelope(provenance, generatedAt))
+	}
+}
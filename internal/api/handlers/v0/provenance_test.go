@@ -0,0 +1,167 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// slsaAttestation returns an in-toto statement carrying SLSA provenance for the given subject digest
+func slsaAttestation(digest string) string {
+	return `{
+  "_type": "https://in-toto.io/Statement/v1",
+  "subject": [{"name": "example-server-1.0.0.tgz", "digest": {"sha256": "` + digest + `"}}],
+  "predicateType": "https://slsa.dev/provenance/v1",
+  "predicate": {
+    "buildDefinition": {
+      "buildType": "https://slsa-framework.github.io/github-actions-buildtypes/workflow/v1",
+      "externalParameters": {"workflow": {"ref": "refs/tags/v1.0.0", "repository": "https://github.com/example/pinned-server"}}
+    },
+    "runDetails": {"builder": {"id": "https://github.com/actions/runner"}}
+  }
+}`
+}
+
+// attest submits an attestation for a server version as the publisher of the server
+func attest(t *testing.T, registry service.RegistryService, id, attestation string) *httptest.ResponseRecorder {
+	t.Helper()
+	mockAuthService := new(MockAuthService)
+	mockAuthService.Mock.On("ValidateEphemeralOrOwnerToken", mock.Anything, "test-token").
+		Return(true, &auth.EphemeralTokenClaims{GitHubUsername: "example"}, nil)
+
+	req, err := http.NewRequestWithContext(
+		context.Background(), http.MethodPost, "/v0/servers/"+id+"/attest", strings.NewReader(attestation),
+	)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer test-token")
+	req.SetPathValue("id", id)
+
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.AttestHandler(registry, mockAuthService), rr, req)
+	return rr
+}
+
+// getProvenance fetches the provenance of a server, of the given version if it is not empty
+func getProvenance(t *testing.T, registry service.RegistryService, id, version string) *httptest.ResponseRecorder {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers/"+id+"/provenance", nil)
+	require.NoError(t, err)
+	req.SetPathValue("id", id)
+	if version != "" {
+		req.SetPathValue("version", version)
+	}
+
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.ProvenanceHandler(registry), rr, req)
+	return rr
+}
+
+func TestProvenance(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	oldID := publishVersion(t, registry, "1.0.0")
+	latestID := publishVersion(t, registry, "1.1.0")
+
+	t.Run("stores and returns the attestation verbatim", func(t *testing.T) {
+		rr := attest(t, registry, oldID, slsaAttestation("aaaa"))
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		rr = getProvenance(t, registry, latestID, "1.0.0")
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp v0.ResponseEnvelope[model.Provenance]
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Equal(t, oldID, resp.Data.ServerID)
+		assert.Equal(t, "1.0.0", resp.Data.Version)
+
+		var expected bytes.Buffer
+		require.NoError(t, json.Compact(&expected, []byte(slsaAttestation("aaaa"))))
+		assert.JSONEq(t, expected.String(), string(resp.Data.Attestation))
+	})
+
+	t.Run("latest attestation", func(t *testing.T) {
+		rr := attest(t, registry, latestID, slsaAttestation("bbbb"))
+		require.Equal(t, http.StatusCreated, rr.Code)
+
+		rr = getProvenance(t, registry, oldID, "")
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp v0.ResponseEnvelope[model.Provenance]
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Equal(t, "1.1.0", resp.Data.Version)
+		assert.Contains(t, string(resp.Data.Attestation), "bbbb")
+
+		// Historical versions keep their own attestation
+		rr = getProvenance(t, registry, latestID, "1.0.0")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "aaaa")
+	})
+
+	t.Run("rejects invalid attestations", func(t *testing.T) {
+		for name, attestation := range map[string]string{
+			"invalid JSON":           `{"_type": `,
+			"wrong statement type":   strings.Replace(slsaAttestation("cccc"), "in-toto.io/Statement/v1", "example.com/v0", 1),
+			"wrong predicate type":   strings.Replace(slsaAttestation("cccc"), "slsa.dev/provenance/v1", "slsa.dev/provenance/v0.2", 1),
+			"missing builder":        strings.Replace(slsaAttestation("cccc"), `"builder"`, `"runner"`, 1),
+			"subject without digest": strings.Replace(slsaAttestation("cccc"), `"digest"`, `"hashes"`, 1),
+		} {
+			rr := attest(t, registry, latestID, attestation)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, name)
+		}
+
+		// The stored attestation is unchanged
+		rr := getProvenance(t, registry, latestID, "1.1.0")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "bbbb")
+	})
+
+	t.Run("rejects attestations over 50 KB", func(t *testing.T) {
+		attestation := strings.Replace(slsaAttestation("cccc"), `"workflow"`, `"padding": "`+strings.Repeat("a", 50*1024)+`", "workflow"`, 1)
+		rr := attest(t, registry, latestID, attestation)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+	})
+
+	t.Run("version without attestation", func(t *testing.T) {
+		rr := getProvenance(t, registry, latestID, "2.0.0")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("non-existent server", func(t *testing.T) {
+		const missingID = "00000000-0000-0000-0000-000000000000"
+
+		rr := getProvenance(t, registry, missingID, "")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		rr = attest(t, registry, missingID, slsaAttestation("dddd"))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("only the publisher can attest", func(t *testing.T) {
+		mockAuthService := new(MockAuthService)
+		mockAuthService.Mock.On("ValidateEphemeralOrOwnerToken", mock.Anything, "test-token").
+			Return(true, &auth.EphemeralTokenClaims{GitHubUsername: "someone-else"}, nil)
+
+		req, err := http.NewRequestWithContext(
+			context.Background(), http.MethodPost, "/v0/servers/"+latestID+"/attest", strings.NewReader(slsaAttestation("eeee")),
+		)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer test-token")
+		req.SetPathValue("id", latestID)
+
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.AttestHandler(registry, mockAuthService), rr, req)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
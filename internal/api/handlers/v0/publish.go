@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
+	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/database"
@@ -20,14 +22,14 @@ func PublishHandler(registry service.RegistryService, authService auth.Service)
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Only allow POST method
 		if r.Method != http.MethodPost {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
 			return
 		}
 
 		// Read the request body
 		body, err := io.ReadAll(r.Body)
 		if err != nil {
-			http.Error(w, "Error reading request body", http.StatusBadRequest)
+			WriteError(w, APIError{Code: ErrCodeInvalidPayload, Message: "Error reading request body"}, http.StatusBadRequest)
 			return
 		}
 		defer r.Body.Close()
@@ -36,7 +38,7 @@ func PublishHandler(registry service.RegistryService, authService auth.Service)
 		var publishReq model.PublishRequest
 		err = json.Unmarshal(body, &publishReq)
 		if err != nil {
-			http.Error(w, "Invalid request payload: "+err.Error(), http.StatusBadRequest)
+			WriteError(w, APIError{Code: ErrCodeInvalidPayload, Message: "Invalid request payload: " + err.Error()}, http.StatusBadRequest)
 			return
 		}
 
@@ -45,25 +47,28 @@ func PublishHandler(registry service.RegistryService, authService auth.Service)
 
 		err = json.Unmarshal(body, &serverDetail)
 		if err != nil {
-			http.Error(w, "Invalid server detail payload: "+err.Error(), http.StatusBadRequest)
+			WriteError(w, APIError{
+				Code:    ErrCodeInvalidPayload,
+				Message: "Invalid server detail payload: " + err.Error(),
+			}, http.StatusBadRequest)
 			return
 		}
 		// Validate required fields
 		if serverDetail.Name == "" {
-			http.Error(w, "Name is required", http.StatusBadRequest)
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Name is required"}, http.StatusBadRequest)
 			return
 		}
 
 		// Version is required
 		if serverDetail.VersionDetail.Version == "" {
-			http.Error(w, "Version is required", http.StatusBadRequest)
+			WriteError(w, APIError{Code: ErrCodeInvalidVersion, Message: "Version is required"}, http.StatusBadRequest)
 			return
 		}
 
 		// Get auth token from Authorization header
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
-			http.Error(w, "Authorization header is required", http.StatusUnauthorized)
+			WriteError(w, APIError{Code: ErrCodeAuthRequired, Message: "Authorization header is required"}, http.StatusUnauthorized)
 			return
 		}
 
@@ -96,27 +101,47 @@ func PublishHandler(registry service.RegistryService, authService auth.Service)
 		valid, err := authService.ValidateAuth(r.Context(), a)
 		if err != nil {
 			if errors.Is(err, auth.ErrAuthRequired) {
-				http.Error(w, "Authentication is required for publishing", http.StatusUnauthorized)
+				WriteError(w, APIError{
+					Code:    ErrCodeAuthRequired,
+					Message: "Authentication is required for publishing",
+				}, http.StatusUnauthorized)
 				return
 			}
-			http.Error(w, "Authentication failed: "+err.Error(), http.StatusUnauthorized)
+			WriteError(w, APIError{Code: ErrCodeAuthFailed, Message: "Authentication failed: " + err.Error()}, http.StatusUnauthorized)
 			return
 		}
 
 		if !valid {
-			http.Error(w, "Invalid authentication credentials", http.StatusUnauthorized)
+			WriteError(w, APIError{Code: ErrCodeAuthFailed, Message: "Invalid authentication credentials"}, http.StatusUnauthorized)
 			return
 		}
 
+		// The publication time and publisher are recorded by the registry, whatever the request says.
+		// GitHub tokens are only valid for the owner of the server's namespace.
+		serverDetail.PublishedAt = time.Now().UTC()
+		serverDetail.PublishedBy = ""
+		if authMethod == model.AuthMethodGitHub {
+			serverDetail.PublishedBy = model.NamespaceOwner(serverDetail.Name)
+		}
+
 		// Call the publish method on the registry service
 		err = registry.Publish(&serverDetail)
 		if err != nil {
+			if writeValidationErrors(w, err) {
+				return
+			}
 			// Check for specific error types and return appropriate HTTP status codes
 			if errors.Is(err, database.ErrInvalidVersion) || errors.Is(err, database.ErrAlreadyExists) {
-				http.Error(w, "Failed to publish server details: "+err.Error(), http.StatusBadRequest)
+				WriteError(w, APIError{
+					Code:    ErrCodeInvalidRequest,
+					Message: "Failed to publish server details: " + err.Error(),
+				}, http.StatusBadRequest)
 				return
 			}
-			http.Error(w, "Failed to publish server details: "+err.Error(), http.StatusInternalServerError)
+			WriteError(w, APIError{
+				Code:    ErrCodeInternal,
+				Message: "Failed to publish server details: " + err.Error(),
+			}, http.StatusInternalServerError)
 			return
 		}
 
@@ -126,8 +151,33 @@ func PublishHandler(registry service.RegistryService, authService auth.Service)
 			"message": "Server publication successful",
 			"id":      serverDetail.ID,
 		}); err != nil {
-			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to encode response"}, http.StatusInternalServerError)
 			return
 		}
 	}
 }
+
+// ValidationErrorResponse is the body of responses rejecting server details that failed validation
+type ValidationErrorResponse struct {
+	Error  string                    `json:"error"`
+	Errors []service.ValidationError `json:"errors"`
+}
+
+// writeValidationErrors responds with 422 Unprocessable Entity listing the failed validation rules if err is a
+// validation failure, and reports whether it did
+func writeValidationErrors(w http.ResponseWriter, err error) bool {
+	var validationErrs service.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return false
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	if err := json.NewEncoder(w).Encode(ValidationErrorResponse{
+		Error:  "Validation failed",
+		Errors: validationErrs,
+	}); err != nil {
+		log.Printf("Failed to encode validation errors: %v", err)
+	}
+	return true
+}
@@ -2,63 +2,76 @@
 package v0
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
 	"github.com/modelcontextprotocol/registry/internal/model"
 	"github.com/modelcontextprotocol/registry/internal/service"
 )
 
+// defaultOSSVersion is used when no version is provided and the repository has no usable releases
+const defaultOSSVersion = "1.0.0"
+
 // PublishOSSHandler handles requests to publish open source MCP servers to the registry
 // This endpoint takes a GitHub URL and automatically constructs server details
 func PublishOSSHandler(registry service.RegistryService, authService auth.Service) http.HandlerFunc {
+	authenticated := middleware.RequireAuth(authService)(publishOSS(registry, authService))
+
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Only allow POST method
 		if r.Method != http.MethodPost {
-			log.Printf("publish-oss: Method not allowed: %s", r.Method)
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			middleware.Logf(r.Context(), "publish-oss: Method not allowed: %s", r.Method)
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
 			return
 		}
 
-		// Get auth token from Authorization header
-		authHeader := r.Header.Get("Authorization")
-		if authHeader == "" {
-			log.Printf("publish-oss: Missing Authorization header from %s", r.RemoteAddr)
-			http.Error(w, "Authorization header is required", http.StatusUnauthorized)
-			return
-		}
+		authenticated.ServeHTTP(w, r)
+	}
+}
 
-		// Parse token from header
-		token := auth.ParseAuthorizationHeader(authHeader)
+// ossPublishError is a failure to publish an open source repository, with the HTTP status and error code it is
+// reported with
+type ossPublishError struct {
+	status  int
+	code    string
+	message string
+	// existingName is set when a server with the repository's name has already been published
+	existingName string
+	// validation holds the failed rules when the server details failed validation
+	validation service.ValidationErrors
+}
 
-		// Validate either ephemeral token or registry owner token
-		valid, ephemeralClaims, err := authService.ValidateEphemeralOrOwnerToken(r.Context(), token)
-		if err != nil {
-			log.Printf("publish-oss: Authentication failed from %s: %v", r.RemoteAddr, err)
-			http.Error(w, "Authentication failed: "+err.Error(), http.StatusUnauthorized)
-			return
-		}
+func (e *ossPublishError) Error() string {
+	return e.message
+}
 
-		if !valid {
-			log.Printf("publish-oss: Invalid authentication token from %s", r.RemoteAddr)
-			http.Error(w, "Invalid authentication token", http.StatusForbidden)
-			return
-		}
+// newOSSPublishError creates an ossPublishError with the given status, error code and message
+func newOSSPublishError(status int, code, format string, args ...any) *ossPublishError {
+	return &ossPublishError{status: status, code: code, message: fmt.Sprintf(format, args...)}
+}
+
+// publishOSS handles an authenticated open source publish request
+func publishOSS(registry service.RegistryService, authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ephemeralClaims := middleware.GetAuthClaims(r.Context())
 
 		// Read the request body
 		body, err := io.ReadAll(r.Body)
 		if err != nil {
-			log.Printf("publish-oss: Error reading request body from %s: %v", r.RemoteAddr, err)
-			http.Error(w, "Error reading request body", http.StatusBadRequest)
+			middleware.Logf(r.Context(), "publish-oss: Error reading request body from %s: %v", middleware.GetRealIP(r), err)
+			WriteError(w, APIError{Code: ErrCodeInvalidPayload, Message: "Error reading request body"}, http.StatusBadRequest)
 			return
 		}
 		defer r.Body.Close()
@@ -67,151 +80,47 @@ func PublishOSSHandler(registry service.RegistryService, authService auth.Servic
 		var ossReq model.PublishOSSRequest
 		err = json.Unmarshal(body, &ossReq)
 		if err != nil {
-			log.Printf("publish-oss: Invalid request payload from %s: %v", r.RemoteAddr, err)
-			http.Error(w, "Invalid request payload: "+err.Error(), http.StatusBadRequest)
+			middleware.Logf(r.Context(), "publish-oss: Invalid request payload from %s: %v", middleware.GetRealIP(r), err)
+			WriteError(w, APIError{Code: ErrCodeInvalidPayload, Message: "Invalid request payload: " + err.Error()}, http.StatusBadRequest)
 			return
 		}
 
-		// Validate required fields
-		if ossReq.RepositoryURL == "" {
-			log.Printf("publish-oss: Missing repository URL from %s", r.RemoteAddr)
-			http.Error(w, "Repository URL is required", http.StatusBadRequest)
+		githubAuth, githubToken, ok := ossGitHubAuth(w, r, authService)
+		if !ok {
 			return
 		}
 
-		// Validate that at least one package is provided
-		if len(ossReq.Packages) == 0 {
-			log.Printf("publish-oss: No packages provided from %s for repo %s", r.RemoteAddr, ossReq.RepositoryURL)
-			http.Error(w, "At least one package is required", http.StatusBadRequest)
-			return
+		var githubUsername string
+		if ephemeralClaims != nil {
+			githubUsername = ephemeralClaims.GitHubUsername
 		}
 
-		// Validate package fields
-		for i, pkg := range ossReq.Packages {
-			if pkg.RegistryName == "" {
-				log.Printf("publish-oss: Package %d missing registry_name from %s for repo %s", i, r.RemoteAddr, ossReq.RepositoryURL)
-				http.Error(w, fmt.Sprintf("Package %d: registry_name is required", i), http.StatusBadRequest)
-				return
-			}
-			if pkg.Name == "" {
-				log.Printf("publish-oss: Package %d missing name from %s for repo %s", i, r.RemoteAddr, ossReq.RepositoryURL)
-				http.Error(w, fmt.Sprintf("Package %d: name is required", i), http.StatusBadRequest)
-				return
-			}
-			if pkg.Version == "" {
-				log.Printf("publish-oss: Package %d missing version from %s for repo %s", i, r.RemoteAddr, ossReq.RepositoryURL)
-				http.Error(w, fmt.Sprintf("Package %d: version is required", i), http.StatusBadRequest)
+		serverDetail, err := publishOSSRepository(
+			r.Context(), registry, githubAuth, githubToken, &ossReq, githubUsername, middleware.GetRealIP(r))
+		if err != nil {
+			var publishErr *ossPublishError
+			if !errors.As(err, &publishErr) {
+				WriteError(w, APIError{
+					Code:    ErrCodeInternal,
+					Message: "Failed to publish server details: " + err.Error(),
+				}, http.StatusInternalServerError)
 				return
 			}
-		}
-
-		// Check if owner and repo are provided in the request body
-		var owner, repo string
-		if ossReq.Owner != "" && ossReq.Repo != "" {
-			owner = ossReq.Owner
-			repo = ossReq.Repo
-		} else {
-			// Extract owner and repo from GitHub URL
-			var err error
-			owner, repo, err = extractGitHubRepo(ossReq.RepositoryURL)
-			if err != nil {
-				log.Printf("publish-oss: Invalid GitHub URL from %s: %s - %v", r.RemoteAddr, ossReq.RepositoryURL, err)
-				http.Error(w, "Invalid GitHub repository URL: "+err.Error(), http.StatusBadRequest)
+			if publishErr.validation != nil {
+				writeValidationErrors(w, publishErr.validation)
 				return
 			}
-		}
-
-		// Check if a server with this name already exists in the registry
-		expectedServerName := fmt.Sprintf("io.github.%s/%s", owner, repo)
-		existingServers, _, err := registry.Search(expectedServerName, "", "", "", 1)
-		if err != nil {
-			log.Printf("publish-oss: Failed to check existing servers for %s: %v", expectedServerName, err)
-			http.Error(w, "Failed to check existing servers: "+err.Error(), http.StatusInternalServerError)
-			return
-		}
-
-		// If we found any servers with this exact name, return a conflict error
-		for _, server := range existingServers {
-			if server.Name == expectedServerName {
-				log.Printf("publish-oss: Server already exists from %s: %s", r.RemoteAddr, expectedServerName)
+			if publishErr.existingName != "" {
 				w.Header().Set("Content-Type", "application/json")
 				w.WriteHeader(http.StatusConflict)
 				json.NewEncoder(w).Encode(map[string]interface{}{
 					"error":   "Server already exists",
-					"message": fmt.Sprintf("A server with name '%s' has already been published to the registry", expectedServerName),
-					"name":    expectedServerName,
+					"message": publishErr.message,
+					"name":    publishErr.existingName,
 				})
 				return
 			}
-		}
-
-		// Fetch repository information from GitHub
-		authServiceImpl, ok := authService.(*auth.ServiceImpl)
-		if !ok {
-			log.Printf("publish-oss: Internal authentication service error - type assertion failed")
-			http.Error(w, "Internal authentication service error", http.StatusInternalServerError)
-			return
-		}
-
-		githubAuth := authServiceImpl.GetGitHubAuth()
-		// When using ephemeral tokens, we pass empty string as token since we can't use ephemeral tokens with GitHub API
-		// The FetchRepositoryInfo method will handle fetching public repos without auth
-		githubToken := ""
-		if ephemeralClaims == nil {
-			// Registry owner is using a real GitHub token
-			githubToken = token
-		}
-		repoInfo, err := githubAuth.FetchRepositoryInfo(r.Context(), githubToken, owner, repo)
-		if err != nil {
-			log.Printf("publish-oss: Failed to fetch GitHub repo info for %s/%s from %s: %v", owner, repo, r.RemoteAddr, err)
-			http.Error(w, "Failed to fetch repository information: "+err.Error(), http.StatusBadRequest)
-			return
-		}
-
-		// Generate a unique server ID
-		serverID, err := generateServerID()
-		if err != nil {
-			log.Printf("publish-oss: Failed to generate server ID: %v", err)
-			http.Error(w, "Failed to generate server ID", http.StatusInternalServerError)
-			return
-		}
-
-		// Construct ServerDetail from GitHub repository information
-		serverDetail := model.ServerDetail{
-			Server: model.Server{
-				ID:          serverID,
-				Name:        fmt.Sprintf("io.github.%s/%s", owner, repo),
-				Description: repoInfo.Description,
-				Repository: model.Repository{
-					URL:    repoInfo.HTMLURL,
-					Source: "github",
-					ID:     strconv.Itoa(repoInfo.ID),
-				},
-				VersionDetail: model.VersionDetail{
-					Version:     "1.0.0-oss", // Default version for OSS publishing
-					ReleaseDate: time.Now().Format(time.RFC3339),
-					IsLatest:    true,
-				},
-			},
-			Packages: ossReq.Packages,
-		}
-
-		// Call the publish method on the registry service
-		err = registry.Publish(&serverDetail)
-		if err != nil {
-			// Check for specific error types and return appropriate HTTP status codes
-			if database.ErrInvalidVersion != nil && strings.Contains(err.Error(), "invalid version") {
-				log.Printf("publish-oss: Invalid version error for %s from %s: %v", serverDetail.Name, r.RemoteAddr, err)
-				http.Error(w, "Failed to publish server details: "+err.Error(), http.StatusBadRequest)
-				return
-			}
-			if database.ErrAlreadyExists != nil && strings.Contains(err.Error(), "already exists") {
-				log.Printf("publish-oss: Server already exists error for %s from %s: %v", serverDetail.Name, r.RemoteAddr, err)
-				http.Error(w, "Server already exists in registry", http.StatusConflict)
-				return
-			}
-			log.Printf("publish-oss: Failed to publish server %s from %s: %v", serverDetail.Name, r.RemoteAddr, err)
-			http.Error(w, "Failed to publish server details: "+err.Error(), http.StatusInternalServerError)
+			WriteError(w, APIError{Code: publishErr.code, Message: publishErr.message}, publishErr.status)
 			return
 		}
 
@@ -228,7 +137,8 @@ func PublishOSSHandler(registry service.RegistryService, authService auth.Servic
 		}
 
 		// Log successful publication
-		log.Printf("publish-oss: Successfully published server %s (ID: %s) by %s from %s", serverDetail.Name, serverDetail.ID, publishedBy, r.RemoteAddr)
+		middleware.Logf(r.Context(), "publish-oss: Successfully published server %s (ID: %s) by %s from %s",
+			serverDetail.Name, serverDetail.ID, publishedBy, middleware.GetRealIP(r))
 
 		if err := json.NewEncoder(w).Encode(map[string]interface{}{
 			"message":      "OSS server publication successful",
@@ -237,13 +147,197 @@ func PublishOSSHandler(registry service.RegistryService, authService auth.Servic
 			"repository":   serverDetail.Repository,
 			"published_by": publishedBy,
 		}); err != nil {
-			log.Printf("publish-oss: Failed to encode response for %s: %v", serverDetail.Name, err)
-			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+			middleware.Logf(r.Context(), "publish-oss: Failed to encode response for %s: %v", serverDetail.Name, err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to encode response"}, http.StatusInternalServerError)
 			return
 		}
 	}
 }
 
+// ossGitHubAuth returns the GitHub client and token used to fetch repository information for an authenticated
+// open source publish request. It writes an error response and returns false if the auth service cannot fetch it.
+func ossGitHubAuth(w http.ResponseWriter, r *http.Request, authService auth.Service) (auth.GitHubAuth, string, bool) {
+	authServiceImpl, ok := authService.(*auth.ServiceImpl)
+	if !ok {
+		middleware.Logf(r.Context(), "publish-oss: Internal authentication service error - type assertion failed")
+		WriteError(w, APIError{Code: ErrCodeInternal, Message: "Internal authentication service error"}, http.StatusInternalServerError)
+		return nil, "", false
+	}
+
+	// When using ephemeral tokens, we pass empty string as token since we can't use ephemeral tokens with GitHub API
+	// The FetchRepositoryInfo method will handle fetching public repos without auth
+	githubToken := ""
+	if middleware.GetAuthClaims(r.Context()) == nil {
+		// Registry owner is using a real GitHub token
+		githubToken = auth.ParseAuthorizationHeader(r.Header.Get("Authorization"))
+	}
+	return authServiceImpl.GetGitHubAuth(), githubToken, true
+}
+
+// publishOSSRepository validates an open source publish request, constructs the server details from the
+// GitHub repository information and publishes them. publishedBy is the GitHub user publishing the server,
+// empty for the registry owner, and remoteIP identifies the requester in logs.
+// Failures are returned as *ossPublishError with the HTTP status they are reported with.
+func publishOSSRepository(
+	ctx context.Context, registry service.RegistryService, githubAuth auth.GitHubAuth, githubToken string,
+	ossReq *model.PublishOSSRequest, publishedBy, remoteIP string,
+) (*model.ServerDetail, error) {
+	// Validate required fields
+	if ossReq.RepositoryURL == "" {
+		middleware.Logf(ctx, "publish-oss: Missing repository URL from %s", remoteIP)
+		return nil, newOSSPublishError(http.StatusBadRequest, ErrCodeInvalidRequest, "Repository URL is required")
+	}
+
+	// Validate that at least one package is provided
+	if len(ossReq.Packages) == 0 {
+		middleware.Logf(ctx, "publish-oss: No packages provided from %s for repo %s", remoteIP, ossReq.RepositoryURL)
+		return nil, newOSSPublishError(http.StatusBadRequest, ErrCodeInvalidRequest, "At least one package is required")
+	}
+
+	// Validate package versions; the other fields are validated when publishing
+	for i, pkg := range ossReq.Packages {
+		if pkg.Version == "" {
+			middleware.Logf(ctx, "publish-oss: Package %d missing version from %s for repo %s", i, remoteIP, ossReq.RepositoryURL)
+			return nil, newOSSPublishError(http.StatusBadRequest, ErrCodeInvalidVersion, "Package %d: version is required", i)
+		}
+	}
+
+	// Validate the custom version if provided
+	if ossReq.Version != "" && !model.IsValidSemVer(ossReq.Version) {
+		middleware.Logf(ctx, "publish-oss: Invalid version %q from %s for repo %s", ossReq.Version, remoteIP, ossReq.RepositoryURL)
+		return nil, newOSSPublishError(http.StatusBadRequest, ErrCodeInvalidVersion, "Version must be a valid semantic version")
+	}
+
+	// Check if owner and repo are provided in the request body
+	var owner, repo string
+	if ossReq.Owner != "" && ossReq.Repo != "" {
+		owner = ossReq.Owner
+		repo = ossReq.Repo
+	} else {
+		// Extract owner and repo from GitHub URL
+		var err error
+		owner, repo, err = extractGitHubRepo(ossReq.RepositoryURL)
+		if err != nil {
+			middleware.Logf(ctx, "publish-oss: Invalid GitHub URL from %s: %s - %v", remoteIP, ossReq.RepositoryURL, err)
+			return nil, newOSSPublishError(http.StatusBadRequest, ErrCodeInvalidRequest, "Invalid GitHub repository URL: %v", err)
+		}
+	}
+
+	// Check if a server with this name already exists in the registry
+	expectedServerName := fmt.Sprintf("io.github.%s/%s", owner, repo)
+	existingServers, _, err := registry.Search(service.SearchOptions{
+		Query:     expectedServerName,
+		Direction: database.CursorNext,
+		Limit:     1,
+	})
+	if err != nil {
+		middleware.Logf(ctx, "publish-oss: Failed to check existing servers for %s: %v", expectedServerName, err)
+		return nil, newOSSPublishError(http.StatusInternalServerError, ErrCodeInternal, "Failed to check existing servers: %v", err)
+	}
+
+	// If we found any servers with this exact name, return a conflict error
+	for _, server := range existingServers {
+		if server.Name == expectedServerName {
+			middleware.Logf(ctx, "publish-oss: Server already exists from %s: %s", remoteIP, expectedServerName)
+			return nil, &ossPublishError{
+				status:       http.StatusConflict,
+				code:         ErrCodeConflict,
+				message:      fmt.Sprintf("A server with name '%s' has already been published to the registry", expectedServerName),
+				existingName: expectedServerName,
+			}
+		}
+	}
+
+	// Fetch repository information from GitHub
+	repoInfo, err := githubAuth.FetchRepositoryInfo(ctx, githubToken, owner, repo)
+	if err != nil {
+		middleware.Logf(ctx, "publish-oss: Failed to fetch GitHub repo info for %s/%s from %s: %v", owner, repo, remoteIP, err)
+		return nil, newOSSPublishError(http.StatusBadRequest, ErrCodeInvalidRequest, "Failed to fetch repository information: %v", err)
+	}
+
+	// Use the requested version, or detect it from the latest GitHub release
+	version := ossReq.Version
+	if version == "" {
+		version = defaultOSSVersion
+		latestRelease, err := githubAuth.FetchLatestRelease(ctx, githubToken, owner, repo)
+		switch {
+		case err != nil:
+			middleware.Logf(ctx, "publish-oss: Failed to fetch latest release for %s/%s, using %s: %v", owner, repo, defaultOSSVersion, err)
+		case latestRelease != "" && model.IsValidSemVer(latestRelease):
+			version = latestRelease
+		}
+	}
+
+	// Generate a unique server ID
+	serverID, err := generateServerID()
+	if err != nil {
+		middleware.Logf(ctx, "publish-oss: Failed to generate server ID: %v", err)
+		return nil, newOSSPublishError(http.StatusInternalServerError, ErrCodeInternal, "Failed to generate server ID")
+	}
+
+	// Construct ServerDetail from GitHub repository information
+	serverDetail := &model.ServerDetail{
+		Server: model.Server{
+			ID:          serverID,
+			Name:        fmt.Sprintf("io.github.%s/%s", owner, repo),
+			Description: repoInfo.Description,
+			Repository: model.Repository{
+				URL:           repoInfo.HTMLURL,
+				Source:        "github",
+				ID:            strconv.Itoa(repoInfo.ID),
+				DefaultBranch: repoInfo.DefaultBranch,
+			},
+			VersionDetail: model.VersionDetail{
+				Version:     version,
+				ReleaseDate: time.Now().Format(time.RFC3339),
+				IsLatest:    true,
+			},
+			Category:      ossReq.Category,
+			Language:      repoInfo.Language,
+			Topics:        repoInfo.Topics,
+			MinMCPVersion: ossReq.MinMCPVersion,
+			Tags:          ossReq.Tags,
+			License:       ossReq.License,
+			Maintainers:   withPublisherMaintainer(ossReq.Maintainers, publishedBy),
+			// The publication time is recorded by the registry, never taken from the request
+			PublishedAt: time.Now().UTC(),
+			PublishedBy: publishedBy,
+		},
+		Packages:       ossReq.Packages,
+		Schema:         ossReq.Schema,
+		Tools:          ossReq.Tools,
+		GitHubMetadata: repoInfo.Raw,
+	}
+
+	// Call the publish method on the registry service
+	err = registry.Publish(serverDetail)
+	if err != nil {
+		// Check for specific error types and return appropriate HTTP status codes
+		if database.ErrInvalidVersion != nil && strings.Contains(err.Error(), "invalid version") {
+			middleware.Logf(ctx, "publish-oss: Invalid version error for %s from %s: %v", serverDetail.Name, remoteIP, err)
+			return nil, newOSSPublishError(http.StatusBadRequest, ErrCodeInvalidVersion, "Failed to publish server details: %v", err)
+		}
+		var validationErrs service.ValidationErrors
+		if errors.As(err, &validationErrs) {
+			middleware.Logf(ctx, "publish-oss: Invalid server details for %s from %s: %v", serverDetail.Name, remoteIP, err)
+			return nil, &ossPublishError{
+				status:     http.StatusUnprocessableEntity,
+				code:       ErrCodeUnprocessable,
+				message:    fmt.Sprintf("Failed to publish server details: %v", err),
+				validation: validationErrs,
+			}
+		}
+		if database.ErrAlreadyExists != nil && strings.Contains(err.Error(), "already exists") {
+			middleware.Logf(ctx, "publish-oss: Server already exists error for %s from %s: %v", serverDetail.Name, remoteIP, err)
+			return nil, newOSSPublishError(http.StatusConflict, ErrCodeConflict, "Server already exists in registry")
+		}
+		middleware.Logf(ctx, "publish-oss: Failed to publish server %s from %s: %v", serverDetail.Name, remoteIP, err)
+		return nil, newOSSPublishError(http.StatusInternalServerError, ErrCodeInternal, "Failed to publish server details: %v", err)
+	}
+
+	return serverDetail, nil
+}
+
 // extractGitHubRepo extracts the owner and repository name from a GitHub repository URL
 func extractGitHubRepo(repoURL string) (owner, repo string, err error) {
 	// Support various GitHub URL formats:
@@ -285,3 +379,14 @@ func generateServerID() (string, error) {
 
 	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
 }
+
+// withPublisherMaintainer returns the maintainers with the publisher appended, unless the publisher is the
+// registry owner or already listed. Appending keeps validation errors pointing at the maintainers of the request.
+func withPublisherMaintainer(maintainers []model.Maintainer, publishedBy string) []model.Maintainer {
+	if publishedBy == "" || slices.ContainsFunc(maintainers, func(maintainer model.Maintainer) bool {
+		return strings.EqualFold(maintainer.GitHubUsername, publishedBy)
+	}) {
+		return maintainers
+	}
+	return append(slices.Clone(maintainers), model.Maintainer{GitHubUsername: publishedBy})
+}
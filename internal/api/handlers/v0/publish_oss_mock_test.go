@@ -0,0 +1,83 @@
+//go:build test
+
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishOSSWithMockGitHubAuth(t *testing.T) {
+	githubAuth := auth.NewMockGitHubAuth(map[string]string{"owner-token": "registry-owner", "other-token": "someone-else"})
+	githubAuth.Description = "An example server"
+	githubAuth.Stars = 42
+	githubAuth.Topics = []string{"MCP", "Example"}
+	githubAuth.DefaultBranch = "develop"
+	githubAuth.LatestRelease = "v1.2.0"
+	authService := auth.NewAuthServiceWithGitHubAuth(&config.Config{
+		EphemeralTokenSecret:        testEphemeralTokenSecret,
+		RegistryOwnerGithubUsername: "registry-owner",
+	}, githubAuth)
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	handler := v0.PublishOSSHandler(registry, authService)
+
+	publish := func(t *testing.T, token, repo string) *httptest.ResponseRecorder {
+		t.Helper()
+		body, err := json.Marshal(ossRepository(repo))
+		require.NoError(t, err)
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/publish-oss", bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, handler, rr, req)
+		return rr
+	}
+
+	t.Run("registry owner publishes with repository information", func(t *testing.T) {
+		rr := publish(t, "owner-token", "mocked-server")
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var published struct {
+			ID string `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &published))
+		serverDetail, err := registry.GetByID(published.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "An example server", serverDetail.Description)
+		assert.Equal(t, "1.2.0", serverDetail.VersionDetail.Version)
+		assert.Equal(t, []string{"mcp", "example"}, serverDetail.Topics)
+		assert.Equal(t, "develop", serverDetail.Repository.DefaultBranch)
+		assert.WithinDuration(t, time.Now(), serverDetail.PublishedAt, time.Minute)
+		assert.Empty(t, serverDetail.PublishedBy, "the registry owner is not recorded as a GitHub user")
+
+		var metadata struct {
+			Stars int `json:"stargazers_count"`
+		}
+		require.NoError(t, json.Unmarshal(serverDetail.GitHubMetadata, &metadata))
+		assert.Equal(t, 42, metadata.Stars)
+	})
+
+	t.Run("token of another user", func(t *testing.T) {
+		rr := publish(t, "other-token", "other-server")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		rr := publish(t, "unknown-token", "unknown-server")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
@@ -7,12 +7,16 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockRegistryService is a mock implementation of the RegistryService interface
@@ -20,9 +24,33 @@ type MockRegistryService struct {
 	mock.Mock
 }
 
-func (m *MockRegistryService) List(cursor string, limit int) ([]model.Server, string, error) {
-	args := m.Mock.Called(cursor, limit)
-	return args.Get(0).([]model.Server), args.String(1), args.Error(2)
+func (m *MockRegistryService) List(
+	sortBy string, cursor string, direction database.CursorDirection, limit int,
+) ([]model.Server, database.PageCursors, error) {
+	args := m.Mock.Called(sortBy, cursor, direction, limit)
+	return args.Get(0).([]model.Server), args.Get(1).(database.PageCursors), args.Error(2)
+}
+
+func (m *MockRegistryService) ListUpdatedAfter(
+	ctx context.Context, updatedAfter time.Time, afterSeq int64, limit int,
+) ([]model.Server, error) {
+	args := m.Mock.Called(ctx, updatedAfter, afterSeq, limit)
+	return args.Get(0).([]model.Server), args.Error(1)
+}
+
+func (m *MockRegistryService) ListRecent(ctx context.Context, limit int) ([]model.Server, error) {
+	args := m.Mock.Called(ctx, limit)
+	return args.Get(0).([]model.Server), args.Error(1)
+}
+
+func (m *MockRegistryService) ListFeatured(ctx context.Context) ([]model.Server, error) {
+	args := m.Mock.Called(ctx)
+	return args.Get(0).([]model.Server), args.Error(1)
+}
+
+func (m *MockRegistryService) ReorderFeatured(ctx context.Context, ids []string) error {
+	args := m.Mock.Called(ctx, ids)
+	return args.Error(0)
 }
 
 func (m *MockRegistryService) GetByID(id string) (*model.ServerDetail, error) {
@@ -30,19 +58,238 @@ func (m *MockRegistryService) GetByID(id string) (*model.ServerDetail, error) {
 	return args.Get(0).(*model.ServerDetail), args.Error(1)
 }
 
+func (m *MockRegistryService) GetByName(ctx context.Context, name string) (*model.ServerDetail, error) {
+	args := m.Mock.Called(ctx, name)
+	return args.Get(0).(*model.ServerDetail), args.Error(1)
+}
+
+func (m *MockRegistryService) GetByIDs(ctx context.Context, ids []string) ([]model.ServerDetail, error) {
+	args := m.Mock.Called(ctx, ids)
+	return args.Get(0).([]model.ServerDetail), args.Error(1)
+}
+
+func (m *MockRegistryService) GetMetadata(ctx context.Context, id string) (*model.ServerDetail, error) {
+	args := m.Mock.Called(ctx, id)
+	return args.Get(0).(*model.ServerDetail), args.Error(1)
+}
+
 func (m *MockRegistryService) Publish(serverDetail *model.ServerDetail) error {
 	args := m.Mock.Called(serverDetail)
 	return args.Error(0)
 }
 
-func (m *MockRegistryService) Search(query string, registryName string, url string, cursor string, limit int) ([]model.Server, string, error) {
-	args := m.Mock.Called(query, registryName, url, cursor, limit)
-	return args.Get(0).([]model.Server), args.String(1), args.Error(2)
+func (m *MockRegistryService) Update(id string, detail *model.ServerDetail) error {
+	args := m.Mock.Called(id, detail)
+	return args.Error(0)
+}
+
+func (m *MockRegistryService) Delete(id string) error {
+	args := m.Mock.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockRegistryService) PublishVersion(
+	id string, versionDetail model.VersionDetail, packages []model.Package,
+) (*model.ServerDetail, error) {
+	args := m.Mock.Called(id, versionDetail, packages)
+	return args.Get(0).(*model.ServerDetail), args.Error(1)
+}
+
+func (m *MockRegistryService) AppendPackages(ctx context.Context, id string, packages []model.Package) ([]model.Package, error) {
+	args := m.Mock.Called(ctx, id, packages)
+	return args.Get(0).([]model.Package), args.Error(1)
+}
+
+func (m *MockRegistryService) RemovePackage(ctx context.Context, id, registryName, packageName string) error {
+	args := m.Mock.Called(ctx, id, registryName, packageName)
+	return args.Error(0)
+}
+
+func (m *MockRegistryService) Search(opts service.SearchOptions) ([]model.Server, database.PageCursors, error) {
+	args := m.Mock.Called(opts)
+	return args.Get(0).([]model.Server), args.Get(1).(database.PageCursors), args.Error(2)
+}
+
+func (m *MockRegistryService) SearchDetails(opts service.SearchOptions) ([]model.ServerDetail, database.PageCursors, error) {
+	args := m.Mock.Called(opts)
+	return args.Get(0).([]model.ServerDetail), args.Get(1).(database.PageCursors), args.Error(2)
+}
+
+func (m *MockRegistryService) SearchFacets(ctx context.Context, query string) (model.Facets, error) {
+	args := m.Mock.Called(ctx, query)
+	return args.Get(0).(model.Facets), args.Error(1)
+}
+
+func (m *MockRegistryService) CountServers(ctx context.Context) (int64, error) {
+	args := m.Mock.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRegistryService) CountSearchResults(ctx context.Context, opts service.SearchOptions) (int64, error) {
+	args := m.Mock.Called(ctx, opts)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRegistryService) TransferOwnership(ctx context.Context, id, newOwner string) error {
+	args := m.Mock.Called(ctx, id, newOwner)
+	return args.Error(0)
+}
+
+func (m *MockRegistryService) UpdateStatus(ctx context.Context, id, status string, byRegistryOwner bool) error {
+	args := m.Mock.Called(ctx, id, status, byRegistryOwner)
+	return args.Error(0)
+}
+
+func (m *MockRegistryService) AddMaintainer(ctx context.Context, id, username string) ([]string, error) {
+	args := m.Mock.Called(ctx, id, username)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockRegistryService) RemoveMaintainer(ctx context.Context, id, username string) ([]string, error) {
+	args := m.Mock.Called(ctx, id, username)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockRegistryService) PinVersion(ctx context.Context, id, version string) error {
+	args := m.Mock.Called(ctx, id, version)
+	return args.Error(0)
+}
+
+func (m *MockRegistryService) UpgradeVersion(ctx context.Context, id, version string) error {
+	args := m.Mock.Called(ctx, id, version)
+	return args.Error(0)
+}
+
+func (m *MockRegistryService) YankPackage(ctx context.Context, id, registryName, version, reason string) ([]model.Package, error) {
+	args := m.Mock.Called(ctx, id, registryName, version, reason)
+	return args.Get(0).([]model.Package), args.Error(1)
+}
+
+func (m *MockRegistryService) AddTags(ctx context.Context, id string, tags []string) ([]string, error) {
+	args := m.Mock.Called(ctx, id, tags)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockRegistryService) RemoveTag(ctx context.Context, id, tag string) error {
+	args := m.Mock.Called(ctx, id, tag)
+	return args.Error(0)
+}
+
+func (m *MockRegistryService) Stats() (model.RegistryStats, error) {
+	args := m.Mock.Called()
+	return args.Get(0).(model.RegistryStats), args.Error(1)
+}
+
+func (m *MockRegistryService) ListTags(ctx context.Context) ([]model.TagCount, error) {
+	args := m.Mock.Called(ctx)
+	return args.Get(0).([]model.TagCount), args.Error(1)
+}
+
+func (m *MockRegistryService) ListTopics(ctx context.Context) ([]model.TopicCount, error) {
+	args := m.Mock.Called(ctx)
+	return args.Get(0).([]model.TopicCount), args.Error(1)
+}
+
+func (m *MockRegistryService) ListLanguages(ctx context.Context) ([]model.LanguageCount, error) {
+	args := m.Mock.Called(ctx)
+	return args.Get(0).([]model.LanguageCount), args.Error(1)
+}
+
+func (m *MockRegistryService) ListCategories(ctx context.Context) ([]model.CategoryCount, error) {
+	args := m.Mock.Called(ctx)
+	return args.Get(0).([]model.CategoryCount), args.Error(1)
+}
+
+func (m *MockRegistryService) StartReindex(ctx context.Context) (*model.ReindexJob, error) {
+	args := m.Mock.Called(ctx)
+	return args.Get(0).(*model.ReindexJob), args.Error(1)
+}
+
+func (m *MockRegistryService) GetReindexJob(ctx context.Context, id string) (*model.ReindexJob, error) {
+	args := m.Mock.Called(ctx, id)
+	return args.Get(0).(*model.ReindexJob), args.Error(1)
+}
+
+func (m *MockRegistryService) StartVacuum(ctx context.Context) (*model.VacuumJob, error) {
+	args := m.Mock.Called(ctx)
+	return args.Get(0).(*model.VacuumJob), args.Error(1)
+}
+
+func (m *MockRegistryService) GetVacuumJob(ctx context.Context, id string) (*model.VacuumJob, error) {
+	args := m.Mock.Called(ctx, id)
+	return args.Get(0).(*model.VacuumJob), args.Error(1)
+}
+
+func (m *MockRegistryService) SaveSubscription(ctx context.Context, subscription *model.Subscription) (bool, error) {
+	args := m.Mock.Called(ctx, subscription)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRegistryService) GetSubscription(ctx context.Context, id string) (*model.Subscription, error) {
+	args := m.Mock.Called(ctx, id)
+	return args.Get(0).(*model.Subscription), args.Error(1)
+}
+
+func (m *MockRegistryService) DeleteSubscription(ctx context.Context, id string) error {
+	args := m.Mock.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockRegistryService) SaveProvenance(ctx context.Context, id string, attestation []byte) (*model.Provenance, error) {
+	args := m.Mock.Called(ctx, id, attestation)
+	return args.Get(0).(*model.Provenance), args.Error(1)
+}
+
+func (m *MockRegistryService) GetProvenance(ctx context.Context, id, version string) (*model.Provenance, error) {
+	args := m.Mock.Called(ctx, id, version)
+	return args.Get(0).(*model.Provenance), args.Error(1)
+}
+
+func (m *MockRegistryService) YankVersion(ctx context.Context, id, version string) error {
+	args := m.Mock.Called(ctx, id, version)
+	return args.Error(0)
 }
 
-func (m *MockRegistryService) SearchDetails(query string, registryName string, url string, cursor string, limit int) ([]model.ServerDetail, string, error) {
-	args := m.Mock.Called(query, registryName, url, cursor, limit)
-	return args.Get(0).([]model.ServerDetail), args.String(1), args.Error(2)
+func (m *MockRegistryService) ListVersions(
+	ctx context.Context, id string, includeYanked bool, cursor string, direction database.CursorDirection, limit int,
+) ([]model.ServerVersion, database.PageCursors, error) {
+	args := m.Mock.Called(ctx, id, includeYanked, cursor, direction, limit)
+	return args.Get(0).([]model.ServerVersion), args.Get(1).(database.PageCursors), args.Error(2)
+}
+
+func (m *MockRegistryService) GetSchema(ctx context.Context, id string) (json.RawMessage, error) {
+	args := m.Mock.Called(ctx, id)
+	return args.Get(0).(json.RawMessage), args.Error(1)
+}
+
+func (m *MockRegistryService) CountDependents(ctx context.Context, id string) (int64, error) {
+	args := m.Mock.Called(ctx, id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRegistryService) Network(ctx context.Context, id string) (*model.ServerNetwork, error) {
+	args := m.Mock.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ServerNetwork), args.Error(1)
+}
+
+func (m *MockRegistryService) GetGitHubMetadata(ctx context.Context, id string) (json.RawMessage, error) {
+	args := m.Mock.Called(ctx, id)
+	return args.Get(0).(json.RawMessage), args.Error(1)
+}
+
+func (m *MockRegistryService) SubmitReview(ctx context.Context, id string, review *model.Review) error {
+	args := m.Mock.Called(ctx, id, review)
+	return args.Error(0)
+}
+
+func (m *MockRegistryService) ListReviews(
+	ctx context.Context, id string, cursor string, direction database.CursorDirection, limit int,
+) ([]model.Review, database.PageCursors, error) {
+	args := m.Mock.Called(ctx, id, cursor, direction, limit)
+	return args.Get(0).([]model.Review), args.Get(1).(database.PageCursors), args.Error(2)
 }
 
 // MockAuthService is a mock implementation of the auth.Service interface
@@ -77,6 +324,11 @@ func (m *MockAuthService) GenerateEphemeralTokenForGitHubUser(ctx context.Contex
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockAuthService) GenerateEphemeralTokenForPAT(ctx context.Context, pat string) (string, error) {
+	args := m.Mock.Called(ctx, pat)
+	return args.String(0), args.Error(1)
+}
+
 func (m *MockAuthService) ValidateEphemeralOrOwnerToken(ctx context.Context, token string) (bool, *auth.EphemeralTokenClaims, error) {
 	args := m.Mock.Called(ctx, token)
 	if args.Get(1) == nil {
@@ -85,6 +337,41 @@ func (m *MockAuthService) ValidateEphemeralOrOwnerToken(ctx context.Context, tok
 	return args.Bool(0), args.Get(1).(*auth.EphemeralTokenClaims), args.Error(2)
 }
 
+func (m *MockAuthService) GitHubUserExists(ctx context.Context, username string) (bool, error) {
+	args := m.Mock.Called(ctx, username)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockAuthService) RefreshEphemeralToken(ctx context.Context, token string) (string, time.Time, error) {
+	args := m.Mock.Called(ctx, token)
+	return args.String(0), args.Get(1).(time.Time), args.Error(2)
+}
+
+func (m *MockAuthService) RevokeAllTokensForUser(ctx context.Context, githubUserID string, until time.Time) error {
+	args := m.Mock.Called(ctx, githubUserID, until)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) CreateAPIKey(
+	ctx context.Context, ownerGitHubUsername, description string, expiresAt time.Time,
+) (*model.APIKey, string, error) {
+	args := m.Mock.Called(ctx, ownerGitHubUsername, description, expiresAt)
+	if args.Get(0) == nil {
+		return nil, args.String(1), args.Error(2)
+	}
+	return args.Get(0).(*model.APIKey), args.String(1), args.Error(2)
+}
+
+func (m *MockAuthService) ListAPIKeys(ctx context.Context) ([]*model.APIKey, error) {
+	args := m.Mock.Called(ctx)
+	return args.Get(0).([]*model.APIKey), args.Error(1)
+}
+
+func (m *MockAuthService) RevokeAPIKey(ctx context.Context, id string) error {
+	args := m.Mock.Called(ctx, id)
+	return args.Error(0)
+}
+
 func TestPublishHandler(t *testing.T) {
 	testCases := []struct {
 		name             string
@@ -420,7 +707,7 @@ func TestPublishHandler(t *testing.T) {
 			rr := httptest.NewRecorder()
 
 			// Call the handler
-			handler.ServeHTTP(rr, req)
+			serveHTTP(t, handler, rr, req)
 
 			// Check status code
 			assert.Equal(t, tc.expectedStatus, rr.Code)
@@ -510,7 +797,7 @@ func TestPublishHandlerBearerTokenParsing(t *testing.T) {
 			req.Header.Set("Authorization", tc.authHeader)
 
 			rr := httptest.NewRecorder()
-			handler.ServeHTTP(rr, req)
+			serveHTTP(t, handler, rr, req)
 
 			assert.Equal(t, http.StatusCreated, rr.Code)
 			mockAuthService.Mock.AssertExpectations(t)
@@ -575,10 +862,93 @@ func TestPublishHandlerAuthMethodSelection(t *testing.T) {
 			req.Header.Set("Authorization", "Bearer test_token")
 
 			rr := httptest.NewRecorder()
-			handler.ServeHTTP(rr, req)
+			serveHTTP(t, handler, rr, req)
 
 			assert.Equal(t, http.StatusCreated, rr.Code)
 			mockAuthService.Mock.AssertExpectations(t)
 		})
 	}
 }
+
+func TestPublishHandlerRecordsPublishedAt(t *testing.T) {
+	mockRegistry := new(MockRegistryService)
+	mockAuthService := new(MockAuthService)
+	mockAuthService.Mock.On("ValidateAuth", mock.Anything, mock.Anything).Return(true, nil)
+
+	var published *model.ServerDetail
+	mockRegistry.Mock.On("Publish", mock.AnythingOfType("*model.ServerDetail")).Run(func(args mock.Arguments) {
+		published = args.Get(0).(*model.ServerDetail)
+	}).Return(nil)
+
+	// Neither the release date nor a publication time in the request may set the publication time,
+	// and the publisher is the owner of the namespace the GitHub token was validated for
+	requestBody, err := json.Marshal(model.ServerDetail{
+		Server: model.Server{
+			ID:            "test-id",
+			Name:          "io.github.example/test-server",
+			Description:   "A test server",
+			VersionDetail: model.VersionDetail{Version: "1.0.0", ReleaseDate: "2001-01-01T00:00:00Z"},
+			PublishedAt:   time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC),
+			PublishedBy:   "impostor",
+		},
+	})
+	require.NoError(t, err)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/publish", bytes.NewBuffer(requestBody))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer test_token")
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.PublishHandler(mockRegistry, mockAuthService), rr, req)
+
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	require.NotNil(t, published)
+	assert.WithinDuration(t, time.Now(), published.PublishedAt, time.Minute)
+	assert.Equal(t, "2001-01-01T00:00:00Z", published.VersionDetail.ReleaseDate)
+	assert.Equal(t, "example", published.PublishedBy)
+}
+
+func TestPublishHandlerAllowedRegistryNames(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(
+		database.NewMemoryDB(map[string]*model.Server{}), service.WithAllowedRegistryNames([]string{"npm", "pypi"}),
+	)
+	mockAuthService := new(MockAuthService)
+	mockAuthService.Mock.On("ValidateAuth", mock.Anything, mock.Anything).Return(true, nil)
+
+	publish := func(t *testing.T, registryNames ...string) *httptest.ResponseRecorder {
+		t.Helper()
+		serverDetail := model.ServerDetail{
+			Server: model.Server{
+				Name:          "io.github.example/registry-names",
+				Repository:    model.Repository{URL: "https://github.com/example/registry-names", Source: "github"},
+				VersionDetail: model.VersionDetail{Version: "1.0.0"},
+			},
+		}
+		for _, registryName := range registryNames {
+			serverDetail.Packages = append(serverDetail.Packages, model.Package{
+				RegistryName: registryName, Name: "package-" + registryName, Version: "1.0.0",
+			})
+		}
+		body, err := json.Marshal(serverDetail)
+		require.NoError(t, err)
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/publish", bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer test-token")
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.PublishHandler(registry, mockAuthService), rr, req)
+		return rr
+	}
+
+	rr := publish(t, "npm", "docker", "nuget")
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	var resp v0.ValidationErrorResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Len(t, resp.Errors, 2)
+	assert.Equal(t, "packages[1].registry_name", resp.Errors[0].Field)
+	assert.Contains(t, resp.Errors[0].Message, `"docker"`)
+	assert.Equal(t, "packages[2].registry_name", resp.Errors[1].Field)
+	assert.Contains(t, resp.Errors[1].Message, `"nuget"`)
+
+	rr = publish(t, "NPM", "PyPI")
+	assert.Equal(t, http.StatusCreated, rr.Code)
+}
@@ -0,0 +1,114 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// PublishVersionRequest represents the request body for publishing a new version of an existing server
+type PublishVersionRequest struct {
+	VersionDetail model.VersionDetail `json:"version_detail"`
+	Packages      []model.Package     `json:"packages"`
+}
+
+// PublishVersionResponse represents the response of a successful version publication
+type PublishVersionResponse struct {
+	Message string `json:"message"`
+	ID      string `json:"id"`
+	Version string `json:"version"`
+}
+
+// PublishVersionHandler handles requests to publish a new version of an existing server. The new version keeps
+// the name, description, repository and other server-wide fields of the server and gets the packages of the
+// request. Its version must be higher than every existing version, including yanked ones; it becomes the
+// latest version. Only the current publisher of the server, one of its maintainers or the registry owner may
+// add one.
+func PublishVersionHandler(registry service.RegistryService, authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Only allow POST method
+		if r.Method != http.MethodPost {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Extract the server ID from the URL path
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		serverDetail, publishedBy, ok := authorizePublisher(w, r, registry, authService, id)
+		if !ok {
+			return
+		}
+
+		// Parse request body
+		var req PublishVersionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidPayload, Message: "Invalid request payload: " + err.Error()}, http.StatusBadRequest)
+			return
+		}
+		defer r.Body.Close()
+
+		if req.VersionDetail.Version == "" {
+			WriteError(w, APIError{Code: ErrCodeInvalidVersion, Message: "Version is required"}, http.StatusBadRequest)
+			return
+		}
+		if len(req.Packages) == 0 {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "At least one package is required"}, http.StatusBadRequest)
+			return
+		}
+
+		published, err := registry.PublishVersion(id, req.VersionDetail, req.Packages)
+		if err != nil {
+			if writeValidationErrors(w, err) {
+				return
+			}
+			switch {
+			case errors.Is(err, database.ErrNotFound):
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Server not found"}, http.StatusNotFound)
+			case errors.Is(err, database.ErrAlreadyExists):
+				WriteError(w, APIError{
+					Code:    ErrCodeConflict,
+					Message: "Version " + req.VersionDetail.Version + " of the server already exists",
+				}, http.StatusConflict)
+			case errors.Is(err, database.ErrInvalidVersion):
+				WriteError(w, APIError{
+					Code:    ErrCodeInvalidVersion,
+					Message: "The version must be higher than every existing version of the server",
+				}, http.StatusBadRequest)
+			case errors.Is(err, database.ErrInvalidInput):
+				WriteError(w, APIError{Code: ErrCodeInvalidVersion, Message: "Invalid version: " + err.Error()}, http.StatusBadRequest)
+			default:
+				middleware.Logf(r.Context(), "publish-version: Failed to publish version %s of server %s: %v", req.VersionDetail.Version, id, err)
+				WriteError(w, APIError{
+					Code:    ErrCodeInternal,
+					Message: "Failed to publish version: " + err.Error(),
+				}, http.StatusInternalServerError)
+			}
+			return
+		}
+
+		middleware.Logf(r.Context(), "publish-version: Published version %s of server %s (ID: %s) by %s from %s",
+			published.VersionDetail.Version, serverDetail.Name, published.ID, publishedBy, middleware.GetRealIP(r))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(PublishVersionResponse{
+			Message: "Version publication successful",
+			ID:      published.ID,
+			Version: published.VersionDetail.Version,
+		}); err != nil {
+			middleware.Logf(r.Context(), "publish-version: Failed to encode response: %v", err)
+		}
+	}
+}
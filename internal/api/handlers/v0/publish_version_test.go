@@ -0,0 +1,149 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// postVersion calls the publish version handler as the user described by claims, or the registry owner if
+// claims is nil
+func postVersion(
+	t *testing.T, registry service.RegistryService, claims *auth.EphemeralTokenClaims, id, body string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+	mockAuthService := new(MockAuthService)
+	mockAuthService.Mock.On("ValidateEphemeralOrOwnerToken", mock.Anything, "test-token").Return(true, claims, nil)
+
+	req, err := http.NewRequestWithContext(
+		context.Background(), http.MethodPost, "/v0/servers/"+id+"/versions", bytes.NewBufferString(body),
+	)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer test-token")
+	req.SetPathValue("id", id)
+
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.PublishVersionHandler(registry, mockAuthService), rr, req)
+	return rr
+}
+
+// versionBody returns a publish version request for the given version with a single npm package
+func versionBody(version string) string {
+	return `{"version_detail": {"version": "` + version + `"},
+		"packages": [{"registry_name": "npm", "name": "@example/owned-server", "version": "` + version + `"}]}`
+}
+
+func TestPublishVersionHandler(t *testing.T) {
+	publisherClaims := &auth.EphemeralTokenClaims{GitHubUsername: "example"}
+
+	t.Run("publisher adds a version", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		oldID := publishVersionBy(t, registry, "1.9.0", "example")
+
+		rr := postVersion(t, registry, publisherClaims, oldID, versionBody("1.10.0"))
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var resp v0.PublishVersionResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Equal(t, "1.10.0", resp.Version)
+		require.NotEqual(t, oldID, resp.ID)
+
+		published := getServer(t, registry, resp.ID)
+		assert.Equal(t, "io.github.example/owned-server", published.Name)
+		assert.Equal(t, "https://github.com/example/owned-server", published.Repository.URL)
+		assert.Equal(t, "example", published.PublishedBy)
+		assert.True(t, published.VersionDetail.IsLatest)
+		assert.NotEmpty(t, published.VersionDetail.ReleaseDate)
+		require.Len(t, published.Packages, 1)
+		assert.Equal(t, "@example/owned-server", published.Packages[0].Name)
+		assert.False(t, getServer(t, registry, oldID).VersionDetail.IsLatest)
+	})
+
+	t.Run("registry owner adds a version", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersionBy(t, registry, "1.0.0", "")
+
+		rr := postVersion(t, registry, nil, id, versionBody("2.0.0"))
+		assert.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	})
+
+	t.Run("duplicate version is a conflict", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersionBy(t, registry, "1.0.0", "example")
+
+		rr := postVersion(t, registry, publisherClaims, id, versionBody("1.0.0"))
+		assert.Equal(t, http.StatusConflict, rr.Code, rr.Body.String())
+	})
+
+	t.Run("version must be higher than yanked versions", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersionBy(t, registry, "1.0.0", "example")
+		yankedID := publishVersionBy(t, registry, "2.0.0", "example")
+		require.Equal(t, http.StatusNoContent, yankVersion(t, registry, publisherClaims, yankedID, "2.0.0").Code)
+
+		rr := postVersion(t, registry, publisherClaims, id, versionBody("1.5.0"))
+		assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+	})
+
+	t.Run("other users are rejected", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersionBy(t, registry, "1.0.0", "example")
+
+		rr := postVersion(t, registry, &auth.EphemeralTokenClaims{GitHubUsername: "intruder"}, id, versionBody("2.0.0"))
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("previous owner is rejected after a transfer", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersionBy(t, registry, "1.0.0", "example")
+
+		rr := transferServer(t, registry, publisherClaims, id, "new-owner", true, nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		rr = postVersion(t, registry, publisherClaims, id, versionBody("2.0.0"))
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		rr = postVersion(t, registry, &auth.EphemeralTokenClaims{GitHubUsername: "new-owner"}, id, versionBody("2.0.0"))
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	})
+
+	t.Run("maintainer adds a version", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersionBy(t, registry, "1.0.0", "example")
+
+		rr := addMaintainer(t, registry, publisherClaims, id, "co-maintainer", true)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		rr = postVersion(t, registry, &auth.EphemeralTokenClaims{GitHubUsername: "co-maintainer"}, id, versionBody("1.1.0"))
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersionBy(t, registry, "1.0.0", "example")
+
+		for _, body := range []string{
+			`not json`,
+			`{"packages": [{"registry_name": "npm", "name": "@example/owned-server", "version": "2.0.0"}]}`,
+			`{"version_detail": {"version": "2.0.0"}, "packages": []}`,
+		} {
+			rr := postVersion(t, registry, publisherClaims, id, body)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+		}
+
+		rr := postVersion(t, registry, publisherClaims, "00000000-0000-0000-0000-000000000000", versionBody("2.0.0"))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
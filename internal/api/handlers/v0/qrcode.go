@@ -0,0 +1,73 @@
+package v0
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+const (
+	// defaultQRCodeSize is the width and height in pixels of QR codes when no size is requested
+	defaultQRCodeSize = 256
+	// minQRCodeSize is the smallest QR code size that remains reliably scannable
+	minQRCodeSize = 64
+	// maxQRCodeSize caps the size of generated QR codes
+	maxQRCodeSize = 1024
+)
+
+// QRCodeHandler returns a handler that renders a PNG QR code encoding the registry URL of a server
+func QRCodeHandler(registry service.RegistryService, cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		size := defaultQRCodeSize
+		if sizeStr := r.URL.Query().Get("size"); sizeStr != "" {
+			parsedSize, err := strconv.Atoi(sizeStr)
+			if err != nil || parsedSize < minQRCodeSize {
+				WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid size parameter"}, http.StatusBadRequest)
+				return
+			}
+			size = min(parsedSize, maxQRCodeSize)
+		}
+
+		if _, err := registry.GetByID(id); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Server not found"}, http.StatusNotFound)
+				return
+			}
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Error retrieving server details"}, http.StatusInternalServerError)
+			return
+		}
+
+		serverURL := strings.TrimSuffix(cfg.PublicURL, "/") + "/v0/servers/" + id
+		png, err := qrcode.Encode(serverURL, qrcode.Medium, size)
+		if err != nil {
+			middleware.Logf(r.Context(), "Error generating QR code for server %s: %v", id, err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to generate QR code"}, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Set("Cache-Control", "public, max-age=86400")
+		if _, err := w.Write(png); err != nil {
+			middleware.Logf(r.Context(), "Error writing QR code for server %s: %v", id, err)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package v0_test
+
+import (
+	"context"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQRCodeHandler(t *testing.T) {
+	serverID := uuid.New().String()
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{
+		serverID: {ID: serverID, Name: "io.github.example/qrcode"},
+	}))
+	cfg := &config.Config{PublicURL: "https://registry.mcp.io"}
+
+	request := func(t *testing.T, id, query string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers/"+id+"/qrcode"+query, nil)
+		require.NoError(t, err)
+		req.SetPathValue("id", id)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.QRCodeHandler(registry, cfg), rr, req)
+		return rr
+	}
+
+	t.Run("returns a PNG of the requested size", func(t *testing.T) {
+		testCases := []struct {
+			query        string
+			expectedSize int
+		}{
+			{query: "", expectedSize: 256},
+			{query: "?size=128", expectedSize: 128},
+			{query: "?size=512", expectedSize: 512},
+			{query: "?size=4096", expectedSize: 1024},
+		}
+
+		for _, tc := range testCases {
+			rr := request(t, serverID, tc.query)
+			require.Equal(t, http.StatusOK, rr.Code, tc.query)
+			assert.Equal(t, "image/png", rr.Header().Get("Content-Type"))
+			assert.Equal(t, "public, max-age=86400", rr.Header().Get("Cache-Control"))
+
+			img, err := png.Decode(rr.Body)
+			require.NoError(t, err, tc.query)
+			assert.Equal(t, tc.expectedSize, img.Bounds().Dx(), tc.query)
+			assert.Equal(t, tc.expectedSize, img.Bounds().Dy(), tc.query)
+		}
+	})
+
+	t.Run("rejects invalid sizes", func(t *testing.T) {
+		for _, query := range []string{"?size=abc", "?size=0", "?size=-256", "?size=32"} {
+			rr := request(t, serverID, query)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+		}
+	})
+
+	t.Run("server not found", func(t *testing.T) {
+		rr := request(t, uuid.New().String(), "")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("invalid server ID", func(t *testing.T) {
+		rr := request(t, "not-a-uuid", "")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
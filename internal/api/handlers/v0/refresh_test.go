@@ -0,0 +1,115 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testEphemeralTokenSecret = "test-ephemeral-token-secret"
+
+// signEphemeralToken creates an ephemeral token signed with the test secret that expires after the given duration
+func signEphemeralToken(t *testing.T, expiresIn time.Duration) string {
+	t.Helper()
+	now := time.Now()
+	claims := auth.EphemeralTokenClaims{
+		GitHubUserID:   "123456",
+		GitHubUsername: "testuser",
+		IssuedAt:       now.Add(expiresIn - time.Hour),
+		ExpiresAt:      now.Add(expiresIn),
+		Nonce:          base64.StdEncoding.EncodeToString([]byte(now.String())),
+	}
+
+	claimsJSON, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	h := hmac.New(sha256.New, []byte(testEphemeralTokenSecret))
+	h.Write(claimsJSON)
+
+	tokenJSON, err := json.Marshal(auth.EphemeralToken{
+		Claims:    claims,
+		Signature: base64.StdEncoding.EncodeToString(h.Sum(nil)),
+	})
+	require.NoError(t, err)
+
+	return base64.StdEncoding.EncodeToString(tokenJSON)
+}
+
+func refreshToken(t *testing.T, handler http.HandlerFunc, token string) *httptest.ResponseRecorder {
+	t.Helper()
+	body, err := json.Marshal(v0.RefreshRequest{Token: token})
+	require.NoError(t, err)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/auth/refresh", bytes.NewReader(body))
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	serveHTTP(t, handler, rr, req)
+	return rr
+}
+
+func TestRefreshHandler(t *testing.T) {
+	authService := auth.NewAuthService(&config.Config{
+		EphemeralTokenSecret:  testEphemeralTokenSecret,
+		RefreshWindowDuration: 10 * time.Minute,
+	})
+	handler := v0.RefreshHandler(authService)
+
+	t.Run("early refresh returns the same token", func(t *testing.T) {
+		token := signEphemeralToken(t, 50*time.Minute)
+
+		rr := refreshToken(t, handler, token)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp v0.AuthorizeResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		assert.Equal(t, token, resp.EphemeralToken)
+		assert.InDelta(t, 50*60, resp.ExpiresIn, 5)
+	})
+
+	t.Run("late refresh returns a new token and revokes the old one", func(t *testing.T) {
+		token := signEphemeralToken(t, 5*time.Minute)
+
+		rr := refreshToken(t, handler, token)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp v0.AuthorizeResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		assert.NotEqual(t, token, resp.EphemeralToken)
+		assert.InDelta(t, 60*60, resp.ExpiresIn, 5)
+
+		valid, claims, err := authService.ValidateEphemeralOrOwnerToken(context.Background(), resp.EphemeralToken)
+		require.NoError(t, err)
+		assert.True(t, valid)
+		assert.Equal(t, "testuser", claims.GitHubUsername)
+
+		// The old token has been revoked
+		rr = refreshToken(t, handler, token)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Contains(t, rr.Body.String(), "revoked")
+	})
+
+	t.Run("expired token is rejected", func(t *testing.T) {
+		rr := refreshToken(t, handler, signEphemeralToken(t, -time.Minute))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Contains(t, rr.Body.String(), "expired")
+	})
+
+	t.Run("missing token is rejected", func(t *testing.T) {
+		rr := refreshToken(t, handler, "")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -0,0 +1,55 @@
+package v0_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveHTTP serves the request with the handler behind the request ID middleware, as the router does, and checks
+// that the response carries the ID of the request: the one the client sent, or a generated one otherwise
+func serveHTTP(t *testing.T, handler http.Handler, rr *httptest.ResponseRecorder, req *http.Request) {
+	t.Helper()
+	middleware.RequestID(handler).ServeHTTP(rr, req)
+
+	if id := req.Header.Get(middleware.RequestIDHeader); id != "" {
+		assert.Equal(t, id, rr.Header().Get(middleware.RequestIDHeader))
+	} else {
+		assert.NotEmpty(t, rr.Header().Get(middleware.RequestIDHeader))
+	}
+}
+
+func TestRequestIDHeader(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	id := publishVersion(t, registry, "1.0.0")
+
+	for _, tc := range []struct {
+		name      string
+		serverID  string
+		requestID string
+	}{
+		{name: "success", serverID: id, requestID: "client-request-1"},
+		{name: "not found", serverID: "00000000-0000-0000-0000-000000000000", requestID: "client-request-2"},
+		{name: "invalid ID", serverID: "not-a-uuid", requestID: "client-request-3"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers/"+tc.serverID, nil)
+			require.NoError(t, err)
+			req.SetPathValue("id", tc.serverID)
+			req.Header.Set(middleware.RequestIDHeader, tc.requestID)
+
+			rr := httptest.NewRecorder()
+			serveHTTP(t, v0.ServersDetailHandler(registry, nil), rr, req)
+			assert.Equal(t, tc.requestID, rr.Header().Get(middleware.RequestIDHeader))
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// defaultReviewsLimit is the number of reviews returned per page when no limit is given
+const defaultReviewsLimit = 20
+
+// ReviewRequest represents the request body for reviewing a server
+type ReviewRequest struct {
+	Rating  int    `json:"rating"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// ReviewHandler handles requests to rate a server. Reviews are submitted by GitHub users with a
+// registry token, and each user may review a server once.
+func ReviewHandler(registry service.RegistryService, authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		generatedAt := time.Now().UTC()
+
+		// Only allow POST method
+		if r.Method != http.MethodPost {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Extract the server ID from the URL path
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		authHeader := r.Header.Get("Authorization")
+		if authHeader == "" {
+			WriteError(w, APIError{Code: ErrCodeAuthRequired, Message: "Authorization header is required"}, http.StatusUnauthorized)
+			return
+		}
+
+		token := auth.ParseAuthorizationHeader(authHeader)
+		valid, claims, err := authService.ValidateEphemeralOrOwnerToken(r.Context(), token)
+		if err != nil {
+			WriteError(w, APIError{Code: ErrCodeAuthFailed, Message: "Authentication failed: " + err.Error()}, http.StatusUnauthorized)
+			return
+		}
+		if !valid {
+			WriteError(w, APIError{Code: ErrCodeForbidden, Message: "Invalid authentication token"}, http.StatusForbidden)
+			return
+		}
+		// Reviews are attributed to a GitHub user, which the registry owner token does not identify
+		if claims == nil || claims.GitHubUserID == "" {
+			WriteError(w, APIError{
+				Code:    ErrCodeForbidden,
+				Message: "Reviews must be submitted with a GitHub user token",
+			}, http.StatusForbidden)
+			return
+		}
+
+		// Parse request body
+		var req ReviewRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidPayload, Message: "Invalid request payload: " + err.Error()}, http.StatusBadRequest)
+			return
+		}
+		defer r.Body.Close()
+
+		review := &model.Review{
+			GitHubUserID:   claims.GitHubUserID,
+			GitHubUsername: claims.GitHubUsername,
+			Rating:         req.Rating,
+			Comment:        req.Comment,
+		}
+		if err := registry.SubmitReview(r.Context(), id, review); err != nil {
+			switch {
+			case errors.Is(err, database.ErrInvalidReview):
+				WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: err.Error()}, http.StatusBadRequest)
+			case errors.Is(err, database.ErrNotFound):
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Server not found"}, http.StatusNotFound)
+			case errors.Is(err, database.ErrAlreadyExists):
+				WriteError(w, APIError{Code: ErrCodeConflict, Message: "You have already reviewed this server"}, http.StatusConflict)
+			default:
+				middleware.Logf(r.Context(), "review: Failed to save review of server %s: %v", id, err)
+				WriteError(w, APIError{
+					Code:    ErrCodeInternal,
+					Message: "Failed to save review: " + err.Error(),
+				}, http.StatusInternalServerError)
+			}
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, NewResponseEnvelope(review, generatedAt))
+	}
+}
+
+// ReviewsHandler returns a handler for the reviews of a server, newest first
+func ReviewsHandler(registry service.RegistryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		generatedAt := time.Now().UTC()
+
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Extract the server ID from the URL path
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		cursor, direction, ok := parseCursor(w, r)
+		if !ok {
+			return
+		}
+
+		limit := defaultReviewsLimit
+		if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+			parsedLimit, err := strconv.Atoi(limitStr)
+			if err != nil {
+				WriteError(w, APIError{Code: ErrCodeInvalidLimit, Message: "Invalid limit parameter"}, http.StatusBadRequest)
+				return
+			}
+			if parsedLimit <= 0 {
+				WriteError(w, APIError{Code: ErrCodeInvalidLimit, Message: "Limit must be greater than 0"}, http.StatusBadRequest)
+				return
+			}
+			limit = min(parsedLimit, maxLimit)
+		}
+
+		reviews, cursors, err := registry.ListReviews(r.Context(), id, cursor, direction, limit)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Server not found"}, http.StatusNotFound)
+				return
+			}
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: err.Error()}, http.StatusInternalServerError)
+			return
+		}
+
+		response := NewResponseEnvelope(reviews, generatedAt)
+		response.Metadata = paginationMetadata(cursors, len(reviews))
+		writeJSON(w, response)
+	}
+}
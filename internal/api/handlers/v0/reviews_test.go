@@ -0,0 +1,281 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// submitReview calls the review handler as the user described by claims
+func submitReview(
+	t *testing.T, registry service.RegistryService, claims *auth.EphemeralTokenClaims, id string, req v0.ReviewRequest,
+) *httptest.ResponseRecorder {
+	t.Helper()
+	mockAuthService := new(MockAuthService)
+	mockAuthService.Mock.On("ValidateEphemeralOrOwnerToken", mock.Anything, "test-token").Return(true, claims, nil)
+
+	body, err := json.Marshal(req)
+	require.NoError(t, err)
+
+	httpReq, err := http.NewRequestWithContext(
+		context.Background(), http.MethodPost, "/v0/servers/"+id+"/review", bytes.NewReader(body),
+	)
+	require.NoError(t, err)
+	httpReq.Header.Set("Authorization", "Bearer test-token")
+	httpReq.SetPathValue("id", id)
+
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.ReviewHandler(registry, mockAuthService), rr, httpReq)
+	return rr
+}
+
+// listReviews calls the reviews handler with the given query string
+func listReviews(t *testing.T, registry service.RegistryService, id, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers/"+id+"/reviews"+query, nil)
+	require.NoError(t, err)
+	req.SetPathValue("id", id)
+
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.ReviewsHandler(registry), rr, req)
+	return rr
+}
+
+// reviewer returns the token claims of a GitHub user reviewing a server
+func reviewer(userID string) *auth.EphemeralTokenClaims {
+	return &auth.EphemeralTokenClaims{GitHubUserID: userID, GitHubUsername: "user-" + userID}
+}
+
+func TestReviewHandler(t *testing.T) {
+	t.Run("submits a review and updates the rating summary", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersion(t, registry, "1.0.0")
+
+		rr := submitReview(t, registry, reviewer("1"), id, v0.ReviewRequest{Rating: 5, Comment: " Works well "})
+		require.Equal(t, http.StatusCreated, rr.Code)
+
+		var resp v0.ResponseEnvelope[model.Review]
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Equal(t, id, resp.Data.ServerID)
+		assert.Equal(t, "user-1", resp.Data.GitHubUsername)
+		assert.Equal(t, 5, resp.Data.Rating)
+		assert.Equal(t, "Works well", resp.Data.Comment)
+
+		rr = submitReview(t, registry, reviewer("2"), id, v0.ReviewRequest{Rating: 2})
+		require.Equal(t, http.StatusCreated, rr.Code)
+		rr = submitReview(t, registry, reviewer("3"), id, v0.ReviewRequest{Rating: 4})
+		require.Equal(t, http.StatusCreated, rr.Code)
+
+		server := getServer(t, registry, id)
+		assert.InDelta(t, 11.0/3.0, server.AverageRating, 1e-9)
+		assert.Equal(t, 3, server.ReviewCount)
+	})
+
+	t.Run("rejects a second review by the same user", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersion(t, registry, "1.0.0")
+
+		rr := submitReview(t, registry, reviewer("1"), id, v0.ReviewRequest{Rating: 5})
+		require.Equal(t, http.StatusCreated, rr.Code)
+
+		rr = submitReview(t, registry, reviewer("1"), id, v0.ReviewRequest{Rating: 1})
+		assert.Equal(t, http.StatusConflict, rr.Code)
+
+		server := getServer(t, registry, id)
+		assert.InDelta(t, 5.0, server.AverageRating, 1e-9)
+		assert.Equal(t, 1, server.ReviewCount)
+	})
+
+	t.Run("invalid reviews", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersion(t, registry, "1.0.0")
+
+		for name, req := range map[string]v0.ReviewRequest{
+			"rating too low":   {Rating: 0},
+			"rating too high":  {Rating: 6},
+			"comment too long": {Rating: 3, Comment: strings.Repeat("a", model.MaxReviewCommentLength+1)},
+		} {
+			rr := submitReview(t, registry, reviewer("1"), id, req)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, name)
+		}
+		assert.Zero(t, getServer(t, registry, id).ReviewCount)
+	})
+
+	t.Run("publishers cannot set their own rating", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		serverDetail := &model.ServerDetail{
+			Server: model.Server{
+				Name:          "io.github.example/owned-server",
+				Repository:    model.Repository{URL: "https://github.com/example/owned-server", Source: "github"},
+				VersionDetail: model.VersionDetail{Version: "1.0.0"},
+			},
+			AverageRating: 5,
+			ReviewCount:   9999,
+		}
+		require.NoError(t, registry.Publish(serverDetail))
+
+		server := getServer(t, registry, serverDetail.ID)
+		assert.Zero(t, server.AverageRating)
+		assert.Zero(t, server.ReviewCount)
+
+		rr := postVersion(t, registry, &auth.EphemeralTokenClaims{GitHubUsername: "example"}, serverDetail.ID,
+			`{"version_detail": {"version": "1.1.0"}, "average_rating": 5, "review_count": 9999,
+			"packages": [{"registry_name": "npm", "name": "@example/owned-server", "version": "1.1.0"}]}`)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var published v0.PublishVersionResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &published))
+		server = getServer(t, registry, published.ID)
+		assert.Zero(t, server.AverageRating)
+		assert.Zero(t, server.ReviewCount)
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/search?min_rating=4.5", nil)
+		require.NoError(t, err)
+		rr = httptest.NewRecorder()
+		serveHTTP(t, v0.SearchHandler(registry, &config.Config{}), rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		var resp v0.ResponseEnvelope[[]model.Server]
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Empty(t, resp.Data)
+	})
+
+	t.Run("registry owner token cannot review", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersion(t, registry, "1.0.0")
+
+		rr := submitReview(t, registry, nil, id, v0.ReviewRequest{Rating: 5})
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("server not found", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+
+		rr := submitReview(t, registry, reviewer("1"), "00000000-0000-0000-0000-000000000000", v0.ReviewRequest{Rating: 5})
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestReviewsHandler(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	id := publishVersion(t, registry, "1.0.0")
+	for _, userID := range []string{"1", "2", "3"} {
+		rr := submitReview(t, registry, reviewer(userID), id, v0.ReviewRequest{Rating: 4, Comment: "review " + userID})
+		require.Equal(t, http.StatusCreated, rr.Code)
+	}
+
+	t.Run("lists reviews newest first with pagination", func(t *testing.T) {
+		rr := listReviews(t, registry, id, "?limit=2")
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp v0.ResponseEnvelope[[]model.Review]
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		require.Len(t, resp.Data, 2)
+		assert.Equal(t, "review 3", resp.Data[0].Comment)
+		assert.Equal(t, "review 2", resp.Data[1].Comment)
+		require.NotNil(t, resp.Metadata)
+		require.NotEmpty(t, resp.Metadata.NextCursor)
+
+		rr = listReviews(t, registry, id, "?limit=2&cursor="+resp.Metadata.NextCursor)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		resp = v0.ResponseEnvelope[[]model.Review]{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		require.Len(t, resp.Data, 1)
+		assert.Equal(t, "review 1", resp.Data[0].Comment)
+		require.NotNil(t, resp.Metadata)
+		assert.Empty(t, resp.Metadata.NextCursor)
+		require.NotEmpty(t, resp.Metadata.PrevCursor)
+
+		rr = listReviews(t, registry, id, "?limit=2&cursor_direction=prev&cursor="+resp.Metadata.PrevCursor)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		resp = v0.ResponseEnvelope[[]model.Review]{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		require.Len(t, resp.Data, 2)
+		assert.Equal(t, "review 3", resp.Data[0].Comment)
+		assert.Equal(t, "review 2", resp.Data[1].Comment)
+		require.NotNil(t, resp.Metadata)
+		assert.NotEmpty(t, resp.Metadata.NextCursor)
+		assert.Empty(t, resp.Metadata.PrevCursor)
+	})
+
+	t.Run("server not found", func(t *testing.T) {
+		rr := listReviews(t, registry, "00000000-0000-0000-0000-000000000000", "")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		rr := listReviews(t, registry, id, "?cursor=not-a-cursor")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestSearchHandlerRatingFilter(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	ratings := map[string]int{"low": 2, "mid": 3, "high": 5}
+	for name, rating := range ratings {
+		serverDetail := &model.ServerDetail{
+			Server: model.Server{
+				Name:          "io.github.example/" + name,
+				Repository:    model.Repository{URL: "https://github.com/example/" + name, Source: "github"},
+				VersionDetail: model.VersionDetail{Version: "1.0.0"},
+			},
+		}
+		require.NoError(t, registry.Publish(serverDetail))
+		rr := submitReview(t, registry, reviewer("1"), serverDetail.ID, v0.ReviewRequest{Rating: rating})
+		require.Equal(t, http.StatusCreated, rr.Code)
+	}
+
+	search := func(t *testing.T, query string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/search"+query, nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.SearchHandler(registry, &config.Config{}), rr, req)
+		return rr
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		expectedNames []string
+	}{
+		{name: "minimum rating", query: "?min_rating=3", expectedNames: []string{"io.github.example/high", "io.github.example/mid"}},
+		{name: "maximum rating", query: "?max_rating=2.5", expectedNames: []string{"io.github.example/low"}},
+		{name: "rating range", query: "?min_rating=2.5&max_rating=4", expectedNames: []string{"io.github.example/mid"}},
+		{name: "minimal format", query: "?min_rating=5&format=minimal", expectedNames: []string{"io.github.example/high"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := search(t, tc.query)
+			require.Equal(t, http.StatusOK, rr.Code)
+
+			var resp v0.ResponseEnvelope[[]model.ServerMinimal]
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			names := make([]string, len(resp.Data))
+			for i, server := range resp.Data {
+				names[i] = server.Name
+			}
+			assert.ElementsMatch(t, tc.expectedNames, names)
+		})
+	}
+
+	for _, query := range []string{"?min_rating=0", "?max_rating=6", "?min_rating=abc", "?min_rating=4&max_rating=2"} {
+		rr := search(t, query)
+		assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+	}
+}
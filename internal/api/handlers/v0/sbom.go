@@ -0,0 +1,129 @@
+package v0
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/sbom"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/vcs"
+)
+
+// sbomTTL is how long the bill of materials of a server version is cached
+const sbomTTL = time.Hour
+
+// cachedSBOM holds an encoded bill of materials together with its expiry time
+type cachedSBOM struct {
+	document  []byte
+	expiresAt time.Time
+}
+
+// sbomCache builds the bills of materials of server versions, caching them per server version and format
+type sbomCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedSBOM
+}
+
+// get returns the encoded bill of materials of a server version in the given format, building it if it is not
+// cached. The dependencies of npm packages are read from their manifests on the npm registry; packages whose
+// manifest cannot be fetched are listed without dependencies.
+func (c *sbomCache) get(ctx context.Context, serverDetail *model.ServerDetail, format sbom.Format) ([]byte, error) {
+	key := serverDetail.ID + ":" + string(format)
+	c.mu.Lock()
+	cached, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.document, nil
+	}
+
+	dependencies := make(sbom.Dependencies)
+	for _, pkg := range serverDetail.Packages {
+		if pkg.RegistryName != "npm" || pkg.Version == "" {
+			continue
+		}
+		manifestDependencies, err := vcs.FetchNPMDependencies(ctx, pkg.Name, pkg.Version)
+		if err != nil {
+			middleware.Logf(ctx, "sbom: Failed to fetch dependencies of npm package %s@%s: %v", pkg.Name, pkg.Version, err)
+			continue
+		}
+		dependencies[sbom.PackageURL(pkg.RegistryName, pkg.Name, pkg.Version)] = manifestDependencies
+	}
+
+	var doc any
+	if format == sbom.FormatSPDX {
+		doc = sbom.NewSPDX(serverDetail, dependencies, time.Now())
+	} else {
+		doc = sbom.NewCycloneDX(serverDetail, dependencies, time.Now())
+	}
+	document, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.entries[key] = cachedSBOM{document: document, expiresAt: time.Now().Add(sbomTTL)}
+	c.mu.Unlock()
+
+	return document, nil
+}
+
+// SBOMHandler returns a handler for the software bill of materials of a server version, listing its packages
+// and the dependencies of its npm packages. The format parameter selects CycloneDX 1.4 JSON (the default) or
+// SPDX 2.3 JSON. Bills of materials are cached for an hour.
+func SBOMHandler(registry service.RegistryService) http.HandlerFunc {
+	documents := &sbomCache{entries: make(map[string]cachedSBOM)}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		format := sbom.FormatCycloneDX
+		if formatParam := r.URL.Query().Get("format"); formatParam != "" {
+			format = sbom.Format(formatParam)
+			if !format.IsValid() {
+				WriteError(w, APIError{
+					Code:    ErrCodeInvalidRequest,
+					Message: "Invalid format parameter: must be cyclonedx or spdx",
+				}, http.StatusBadRequest)
+				return
+			}
+		}
+
+		serverDetail, err := registry.GetByID(id)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Server not found"}, http.StatusNotFound)
+				return
+			}
+			middleware.Logf(r.Context(), "sbom: Failed to look up server %s: %v", id, err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Error retrieving server"}, http.StatusInternalServerError)
+			return
+		}
+
+		document, err := documents.get(r.Context(), serverDetail, format)
+		if err != nil {
+			middleware.Logf(r.Context(), "sbom: Failed to build bill of materials of %s: %v", id, err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Error building bill of materials"}, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", format.ContentType())
+		_, _ = w.Write(document)
+	}
+}
@@ -0,0 +1,117 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/sbom"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSBOMHandler(t *testing.T) {
+	var npmRequests atomic.Int32
+	original := http.DefaultTransport
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		npmRequests.Add(1)
+		assert.Equal(t, "registry.npmjs.org", req.URL.Host)
+		assert.Equal(t, "/@example%2Fsbom-server/1.0.0", req.URL.EscapedPath())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"dependencies": {"zod": "^3.22.0"}}`)),
+		}, nil
+	})
+	t.Cleanup(func() { http.DefaultTransport = original })
+
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	serverDetail := &model.ServerDetail{
+		Server: model.Server{
+			Name:          "io.github.example/sbom-server",
+			Repository:    model.Repository{URL: "https://github.com/example/sbom-server", Source: "github"},
+			VersionDetail: model.VersionDetail{Version: "1.0.0"},
+		},
+		Packages: []model.Package{
+			{RegistryName: "npm", Name: "@example/sbom-server", Version: "1.0.0"},
+			{RegistryName: "pypi", Name: "sbom-server", Version: "1.0.0"},
+		},
+	}
+	require.NoError(t, registry.Publish(serverDetail))
+	handler := v0.SBOMHandler(registry)
+
+	getSBOM := func(t *testing.T, id, query string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers/"+id+"/sbom"+query, nil)
+		require.NoError(t, err)
+		req.SetPathValue("id", id)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, handler, rr, req)
+		return rr
+	}
+
+	t.Run("CycloneDX by default", func(t *testing.T) {
+		rr := getSBOM(t, serverDetail.ID, "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Equal(t, "application/vnd.cyclonedx+json; version=1.4", rr.Header().Get("Content-Type"))
+
+		var doc sbom.CycloneDXDocument
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&doc))
+		assert.Equal(t, "CycloneDX", doc.BOMFormat)
+		assert.Equal(t, "1.4", doc.SpecVersion)
+		assert.Equal(t, "io.github.example/sbom-server", doc.Metadata.Component.Name)
+
+		var purls []string
+		for _, component := range doc.Components {
+			purls = append(purls, component.PURL)
+		}
+		assert.Equal(t, []string{
+			"pkg:npm/%40example/sbom-server@1.0.0",
+			"pkg:npm/zod",
+			"pkg:pypi/sbom-server@1.0.0",
+		}, purls)
+	})
+
+	t.Run("bills of materials are cached", func(t *testing.T) {
+		requests := npmRequests.Load()
+		rr := getSBOM(t, serverDetail.ID, "?format=cyclonedx")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, requests, npmRequests.Load())
+	})
+
+	t.Run("SPDX", func(t *testing.T) {
+		rr := getSBOM(t, serverDetail.ID, "?format=spdx")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Equal(t, "application/spdx+json", rr.Header().Get("Content-Type"))
+
+		var doc sbom.SPDXDocument
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&doc))
+		assert.Equal(t, "SPDX-2.3", doc.SPDXVersion)
+		assert.Len(t, doc.Packages, 4)
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		rr := getSBOM(t, serverDetail.ID, "?format=swid")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("unknown server", func(t *testing.T) {
+		rr := getSBOM(t, uuid.New().String(), "")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("invalid ID", func(t *testing.T) {
+		rr := getSBOM(t, "not-a-uuid", "")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -0,0 +1,43 @@
+package v0
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// SchemaHandler returns a handler for the JSON schema a server exposes as an MCP tool.
+// The schema is returned exactly as it was published.
+func SchemaHandler(registry service.RegistryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		schema, err := registry.GetSchema(r.Context(), id)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Schema not found"}, http.StatusNotFound)
+				return
+			}
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Error retrieving schema"}, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/schema+json")
+		if _, err := w.Write(schema); err != nil {
+			middleware.Logf(r.Context(), "schema: Failed to write schema for %s: %v", id, err)
+		}
+	}
+}
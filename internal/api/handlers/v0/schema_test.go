@@ -0,0 +1,141 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// publishWithSchema publishes a server with the given name and optional schema and returns its ID
+func publishWithSchema(t *testing.T, registry service.RegistryService, name string, schema *json.RawMessage) string {
+	t.Helper()
+	serverDetail := &model.ServerDetail{
+		Server: model.Server{
+			Name:          "io.github.example/" + name,
+			Repository:    model.Repository{URL: "https://github.com/example/" + name, Source: "github"},
+			VersionDetail: model.VersionDetail{Version: "1.0.0"},
+		},
+		Schema: schema,
+	}
+	require.NoError(t, registry.Publish(serverDetail))
+	return serverDetail.ID
+}
+
+func TestSchemaHandler(t *testing.T) {
+	// Key order and whitespace are kept exactly as published
+	schema := json.RawMessage(`{"type":"object", "required":["path"],"properties": {"path": {"type": "string"}}}`)
+
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	withSchema := publishWithSchema(t, registry, "with-schema", &schema)
+	withoutSchema := publishWithSchema(t, registry, "without-schema", nil)
+
+	getSchema := func(t *testing.T, id string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers/"+id+"/schema", nil)
+		require.NoError(t, err)
+		req.SetPathValue("id", id)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.SchemaHandler(registry), rr, req)
+		return rr
+	}
+
+	t.Run("returns the published schema unchanged", func(t *testing.T) {
+		rr := getSchema(t, withSchema)
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/schema+json", rr.Header().Get("Content-Type"))
+		assert.Equal(t, string(schema), rr.Body.String())
+	})
+
+	t.Run("server details report the schema without embedding it", func(t *testing.T) {
+		server := getServer(t, registry, withSchema)
+		assert.True(t, server.HasSchema)
+		assert.Nil(t, server.Schema)
+		assert.False(t, getServer(t, registry, withoutSchema).HasSchema)
+	})
+
+	t.Run("server without a schema", func(t *testing.T) {
+		rr := getSchema(t, withoutSchema)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("server not found", func(t *testing.T) {
+		rr := getSchema(t, "00000000-0000-0000-0000-000000000000")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("invalid server ID", func(t *testing.T) {
+		rr := getSchema(t, "not-a-uuid")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("malformed schema is rejected on publish", func(t *testing.T) {
+		malformed := json.RawMessage(`{"type": "object"`)
+		err := registry.Publish(&model.ServerDetail{
+			Server: model.Server{
+				Name:          "io.github.example/malformed-schema",
+				Repository:    model.Repository{URL: "https://github.com/example/malformed-schema", Source: "github"},
+				VersionDetail: model.VersionDetail{Version: "1.0.0"},
+			},
+			Schema: &malformed,
+		})
+		assert.ErrorIs(t, err, database.ErrInvalidSchema)
+	})
+}
+
+func TestSearchHandlerSchemaFilter(t *testing.T) {
+	schema := json.RawMessage(`{"type":"object"}`)
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	publishWithSchema(t, registry, "with-schema", &schema)
+	publishWithSchema(t, registry, "without-schema", nil)
+
+	search := func(t *testing.T, query string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/search"+query, nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.SearchHandler(registry, &config.Config{}), rr, req)
+		return rr
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		expectedNames []string
+	}{
+		{name: "schema required", query: "?has_schema=true", expectedNames: []string{"io.github.example/with-schema"}},
+		{name: "minimal format", query: "?has_schema=true&format=minimal", expectedNames: []string{"io.github.example/with-schema"}},
+		{
+			name:          "not applied when false",
+			query:         "?has_schema=false",
+			expectedNames: []string{"io.github.example/with-schema", "io.github.example/without-schema"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := search(t, tc.query)
+			require.Equal(t, http.StatusOK, rr.Code)
+
+			var resp v0.ResponseEnvelope[[]model.ServerMinimal]
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			names := make([]string, len(resp.Data))
+			for i, server := range resp.Data {
+				names[i] = server.Name
+			}
+			assert.ElementsMatch(t, tc.expectedNames, names)
+		})
+	}
+
+	rr := search(t, "?has_schema=maybe")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
@@ -3,26 +3,28 @@ package v0
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
-	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/model"
 	"github.com/modelcontextprotocol/registry/internal/service"
 )
 
 // PaginatedResponseDetails is a paginated API response for server details
-type PaginatedResponseDetails struct {
-	Data     []model.ServerDetail `json:"servers"`
-	Metadata Metadata             `json:"metadata,omitempty"`
-}
+type PaginatedResponseDetails = ResponseEnvelope[[]model.ServerDetail]
 
 // SearchHandler returns a handler for searching registry items
-func SearchHandler(registry service.RegistryService) http.HandlerFunc {
+func SearchHandler(registry service.RegistryService, cfg *config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		generatedAt := time.Now().UTC()
+
 		if r.Method != http.MethodGet {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
 			return
 		}
 
@@ -30,27 +32,185 @@ func SearchHandler(registry service.RegistryService) http.HandlerFunc {
 		query := r.URL.Query().Get("q")
 		registryName := r.URL.Query().Get("registry_name")
 		urlParam := r.URL.Query().Get("url")
-		cursor := r.URL.Query().Get("cursor")
+		category := r.URL.Query().Get("category")
+		language := r.URL.Query().Get("language")
+		topic := r.URL.Query().Get("topic")
+		var tags []string
+		if value := r.URL.Query().Get("tags"); value != "" {
+			tags = strings.Split(value, ",")
+		}
+		license := r.URL.Query().Get("license")
+		maintainer := r.URL.Query().Get("maintainer")
+		status := r.URL.Query().Get("status")
+		if status != "" && !model.IsValidStatus(status) {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid status parameter"}, http.StatusBadRequest)
+			return
+		}
 		limitStr := r.URL.Query().Get("limit")
+		sortBy := r.URL.Query().Get("sort")
+		hasTool := r.URL.Query().Get("has_tool")
+
+		// Validate sort parameter if provided; star counts are only available when repository stats are synced,
+		// and relevance only with a search query
+		switch {
+		case sortBy == "", sortBy == service.SortByReleaseDate, service.IsFieldSort(sortBy):
+		case sortBy == service.SortByStars:
+			if !cfg.RepoStatsSyncEnabled {
+				WriteError(w, APIError{
+					Code:    ErrCodeInvalidRequest,
+					Message: "Sorting by stars requires repository stats sync to be enabled",
+				}, http.StatusBadRequest)
+				return
+			}
+		case sortBy == service.SortByRelevance:
+			if query == "" {
+				WriteError(w, APIError{
+					Code:    ErrCodeInvalidRequest,
+					Message: "Sorting by relevance requires a search query",
+				}, http.StatusBadRequest)
+				return
+			}
+		default:
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid sort parameter"}, http.StatusBadRequest)
+			return
+		}
 
 		// Validate URL parameter if provided
 		if urlParam != "" {
 			_, err := url.ParseRequestURI(urlParam)
 			if err != nil {
-				http.Error(w, "Invalid URL parameter", http.StatusBadRequest)
+				WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid URL parameter"}, http.StatusBadRequest)
+				return
+			}
+		}
+
+		// Validate category if provided
+		if category != "" && !model.Category(category).IsValid() {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid category parameter"}, http.StatusBadRequest)
+			return
+		}
+
+		// Validate rating range if provided
+		minRating, err := parseRating(r.URL.Query().Get("min_rating"))
+		if err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid min_rating parameter"}, http.StatusBadRequest)
+			return
+		}
+		maxRating, err := parseRating(r.URL.Query().Get("max_rating"))
+		if err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid max_rating parameter"}, http.StatusBadRequest)
+			return
+		}
+		if minRating > 0 && maxRating > 0 && minRating > maxRating {
+			WriteError(w, APIError{
+				Code:    ErrCodeInvalidRequest,
+				Message: "min_rating must not be greater than max_rating",
+			}, http.StatusBadRequest)
+			return
+		}
+
+		// Validate the schema filter if provided; only true restricts the results
+		hasSchema := false
+		if value := r.URL.Query().Get("has_schema"); value != "" {
+			hasSchema, err = strconv.ParseBool(value)
+			if err != nil {
+				WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid has_schema parameter"}, http.StatusBadRequest)
+				return
+			}
+		}
+
+		// Validate the verified checksum filter if provided; only true restricts the results
+		hasVerifiedChecksum := false
+		if value := r.URL.Query().Get("has_verified_checksum"); value != "" {
+			hasVerifiedChecksum, err = strconv.ParseBool(value)
+			if err != nil {
+				WriteError(w, APIError{
+					Code:    ErrCodeInvalidRequest,
+					Message: "Invalid has_verified_checksum parameter",
+				}, http.StatusBadRequest)
+				return
+			}
+		}
+
+		// Validate the MCP version bounds if provided
+		minMCPVersion := r.URL.Query().Get("min_mcp_version")
+		maxMCPVersion := r.URL.Query().Get("max_mcp_version")
+		if minMCPVersion != "" && !model.IsValidSemVer(minMCPVersion) {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid min_mcp_version parameter"}, http.StatusBadRequest)
+			return
+		}
+		if maxMCPVersion != "" && !model.IsValidSemVer(maxMCPVersion) {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid max_mcp_version parameter"}, http.StatusBadRequest)
+			return
+		}
+		if minMCPVersion != "" && maxMCPVersion != "" {
+			if c, _ := model.CompareSemVer(minMCPVersion, maxMCPVersion); c > 0 {
+				WriteError(w, APIError{
+					Code:    ErrCodeInvalidRequest,
+					Message: "min_mcp_version must not be greater than max_mcp_version",
+				}, http.StatusBadRequest)
+				return
+			}
+		}
+
+		// Validate the publication date range if provided
+		var publishedAfter, publishedBefore time.Time
+		if value := r.URL.Query().Get("published_after"); value != "" {
+			publishedAfter, err = time.Parse(time.RFC3339, value)
+			if err != nil {
+				WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid published_after parameter"}, http.StatusBadRequest)
+				return
+			}
+		}
+		if value := r.URL.Query().Get("published_before"); value != "" {
+			publishedBefore, err = time.Parse(time.RFC3339, value)
+			if err != nil {
+				WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid published_before parameter"}, http.StatusBadRequest)
 				return
 			}
 		}
+		if !publishedAfter.IsZero() && !publishedBefore.IsZero() && !publishedAfter.Before(publishedBefore) {
+			WriteError(w, APIError{
+				Code:    ErrCodeInvalidRequest,
+				Message: "published_after must be before published_before",
+			}, http.StatusBadRequest)
+			return
+		}
 
 		// Validate cursor if provided
-		if cursor != "" {
-			_, err := uuid.Parse(cursor)
+		cursor, direction, ok := parseCursor(w, r)
+		if !ok {
+			return
+		}
+
+		minimal, err := parseFormat(r)
+		if err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid format parameter"}, http.StatusBadRequest)
+			return
+		}
+
+		includeTotal, err := parseIncludeTotal(r)
+		if err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid include_total parameter"}, http.StatusBadRequest)
+			return
+		}
+
+		// Highlights of the matched text are only included on request; minimal responses never include them
+		highlight := false
+		if value := r.URL.Query().Get("highlight"); value != "" {
+			highlight, err = strconv.ParseBool(value)
 			if err != nil {
-				http.Error(w, "Invalid cursor parameter", http.StatusBadRequest)
+				WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid highlight parameter"}, http.StatusBadRequest)
 				return
 			}
 		}
 
+		// Minimal responses are small enough to allow larger pages
+		limitCap := maxLimit
+		if minimal {
+			limitCap = maxMinimalLimit
+		}
+
 		// Default limit if not specified
 		limit := 30
 
@@ -58,48 +218,128 @@ func SearchHandler(registry service.RegistryService) http.HandlerFunc {
 		if limitStr != "" {
 			parsedLimit, err := strconv.Atoi(limitStr)
 			if err != nil {
-				http.Error(w, "Invalid limit parameter", http.StatusBadRequest)
+				WriteError(w, APIError{Code: ErrCodeInvalidLimit, Message: "Invalid limit parameter"}, http.StatusBadRequest)
 				return
 			}
 
 			// Check if limit is within reasonable bounds
 			if parsedLimit <= 0 {
-				http.Error(w, "Limit must be greater than 0", http.StatusBadRequest)
+				WriteError(w, APIError{Code: ErrCodeInvalidLimit, Message: "Limit must be greater than 0"}, http.StatusBadRequest)
 				return
 			}
 
-			if parsedLimit > 100 {
+			if parsedLimit > limitCap {
 				// Cap maximum limit to prevent excessive queries
-				limit = 100
+				limit = limitCap
 			} else {
 				limit = parsedLimit
 			}
 		}
 
+		opts := service.SearchOptions{
+			Query:               query,
+			RegistryName:        registryName,
+			URL:                 urlParam,
+			Category:            category,
+			Language:            language,
+			Topic:               topic,
+			Tags:                tags,
+			License:             license,
+			Maintainer:          maintainer,
+			Status:              status,
+			MinRating:           minRating,
+			MaxRating:           maxRating,
+			HasSchema:           hasSchema,
+			HasVerifiedChecksum: hasVerifiedChecksum,
+			HasTool:             hasTool,
+			MinMCPVersion:       minMCPVersion,
+			MaxMCPVersion:       maxMCPVersion,
+			PublishedAfter:      publishedAfter,
+			PublishedBefore:     publishedBefore,
+			Sort:                sortBy,
+			Cursor:              cursor,
+			Direction:           direction,
+			Limit:               limit,
+		}
+
+		// Tags, licenses, maintainers, statuses, ratings, schemas, checksums, tools, MCP versions and publication dates are
+		// only filtered on the full server details, and totals are counted like full server detail searches
+		if minimal && !includeTotal && sortBy == "" && len(tags) == 0 && license == "" && maintainer == "" && status == "" &&
+			minRating == 0 && maxRating == 0 && !hasSchema && !hasVerifiedChecksum && hasTool == "" && minMCPVersion == "" &&
+			maxMCPVersion == "" && publishedAfter.IsZero() && publishedBefore.IsZero() {
+			servers, cursors, err := registry.Search(opts)
+			if err != nil {
+				WriteError(w, APIError{Code: ErrCodeInternal, Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			response := NewResponseEnvelope(toMinimal(servers), generatedAt)
+			response.Metadata = paginationMetadata(cursors, len(servers))
+
+			writeJSON(w, response)
+			return
+		}
+
 		// Use the SearchDetails method to get filtered results with full server details
-		registries, nextCursor, err := registry.SearchDetails(query, registryName, urlParam, cursor, limit)
+		registries, cursors, err := registry.SearchDetails(opts)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: err.Error()}, http.StatusInternalServerError)
 			return
 		}
 
-		// Create paginated response with full server details
-		response := PaginatedResponseDetails{
-			Data: registries,
+		metadata := paginationMetadata(cursors, len(registries))
+		if includeTotal {
+			total, err := registry.CountSearchResults(r.Context(), opts)
+			if err != nil {
+				WriteError(w, APIError{Code: ErrCodeInternal, Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+			metadata = withTotalCount(metadata, len(registries), total)
 		}
 
-		// Add metadata if there's a next cursor
-		if nextCursor != "" {
-			response.Metadata = Metadata{
-				NextCursor: nextCursor,
-				Count:      len(registries),
+		if minimal {
+			servers := make([]model.Server, len(registries))
+			for i, serverDetail := range registries {
+				servers[i] = serverDetail.Server
+			}
+
+			response := NewResponseEnvelope(toMinimal(servers), generatedAt)
+			response.Metadata = metadata
+
+			writeJSON(w, response)
+			return
+		}
+
+		// Create paginated response with full server details
+		for i := range registries {
+			registries[i].RedactContactEmail()
+			if !highlight {
+				registries[i].Highlights = nil
 			}
 		}
+		response := NewResponseEnvelope(registries, generatedAt)
+		response.Metadata = metadata
 
 		w.Header().Set("Content-Type", "application/json")
 		if err := json.NewEncoder(w).Encode(response); err != nil {
-			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to encode response"}, http.StatusInternalServerError)
 			return
 		}
 	}
 }
+
+// parseRating parses an optional rating filter between model.MinRating and model.MaxRating.
+// An empty value yields 0, which leaves the bound unset.
+func parseRating(value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	rating, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	if rating < model.MinRating || rating > model.MaxRating {
+		return 0, fmt.Errorf("rating must be between %d and %d", model.MinRating, model.MaxRating)
+	}
+	return rating, nil
+}
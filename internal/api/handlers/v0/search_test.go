@@ -6,13 +6,18 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
-	"github.com/google/uuid"
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockRegistryService Search and SearchDetails methods are defined in publish_test.go
@@ -59,7 +64,12 @@ func TestSearchHandler(t *testing.T) {
 						},
 					},
 				}
-				registry.Mock.On("SearchDetails", "test", "", "", "", 30).Return(servers, "", nil)
+				registry.Mock.On("SearchDetails", service.SearchOptions{
+					Query:     "test",
+					Direction: database.CursorNext,
+					Limit:     30,
+				}).
+					Return(servers, database.PageCursors{}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedServers: []model.ServerDetail{
@@ -120,7 +130,13 @@ func TestSearchHandler(t *testing.T) {
 						},
 					},
 				}
-				registry.Mock.On("SearchDetails", "server", "npm", "", "", 30).Return(servers, "", nil)
+				registry.Mock.On("SearchDetails", service.SearchOptions{
+					Query:        "server",
+					RegistryName: "npm",
+					Direction:    database.CursorNext,
+					Limit:        30,
+				}).
+					Return(servers, database.PageCursors{}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedServers: []model.ServerDetail{
@@ -150,10 +166,44 @@ func TestSearchHandler(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:        "successful search with license filter",
+			method:      http.MethodGet,
+			queryParams: "?license=MIT",
+			setupMocks: func(registry *MockRegistryService) {
+				servers := []model.ServerDetail{
+					{
+						Server: model.Server{
+							ID:            "550e8400-e29b-41d4-a716-446655440004",
+							Name:          "mit-server",
+							VersionDetail: model.VersionDetail{Version: "1.0.0", IsLatest: true},
+							License:       "MIT",
+						},
+					},
+				}
+				registry.Mock.On("SearchDetails", service.SearchOptions{
+					License:   "MIT",
+					Direction: database.CursorNext,
+					Limit:     30,
+				}).
+					Return(servers, database.PageCursors{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedServers: []model.ServerDetail{
+				{
+					Server: model.Server{
+						ID:            "550e8400-e29b-41d4-a716-446655440004",
+						Name:          "mit-server",
+						VersionDetail: model.VersionDetail{Version: "1.0.0", IsLatest: true},
+						License:       "MIT",
+					},
+				},
+			},
+		},
 		{
 			name:        "successful search with pagination",
 			method:      http.MethodGet,
-			queryParams: "?q=test&cursor=550e8400-e29b-41d4-a716-446655440000&limit=10",
+			queryParams: "?q=test&cursor=" + database.EncodeCursor(10) + "&limit=10",
 			setupMocks: func(registry *MockRegistryService) {
 				servers := []model.ServerDetail{
 					{
@@ -174,8 +224,11 @@ func TestSearchHandler(t *testing.T) {
 						},
 					},
 				}
-				nextCursor := uuid.New().String()
-				registry.Mock.On("SearchDetails", "test", "", "", mock.AnythingOfType("string"), 10).Return(servers, nextCursor, nil)
+				nextCursor := database.EncodeCursor(11)
+				registry.Mock.On("SearchDetails", mock.MatchedBy(func(opts service.SearchOptions) bool {
+					return opts.Query == "test" && opts.Direction == database.CursorNext && opts.Limit == 10
+				})).
+					Return(servers, database.PageCursors{Next: nextCursor}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedServers: []model.ServerDetail{
@@ -207,7 +260,12 @@ func TestSearchHandler(t *testing.T) {
 			method:      http.MethodGet,
 			queryParams: "?q=nonexistent",
 			setupMocks: func(registry *MockRegistryService) {
-				registry.Mock.On("SearchDetails", "nonexistent", "", "", "", 30).Return([]model.ServerDetail{}, "", nil)
+				registry.Mock.On("SearchDetails", service.SearchOptions{
+					Query:     "nonexistent",
+					Direction: database.CursorNext,
+					Limit:     30,
+				}).
+					Return([]model.ServerDetail{}, database.PageCursors{}, nil)
 			},
 			expectedStatus:  http.StatusOK,
 			expectedServers: []model.ServerDetail{},
@@ -249,7 +307,12 @@ func TestSearchHandler(t *testing.T) {
 			method:      http.MethodGet,
 			queryParams: "?q=test",
 			setupMocks: func(registry *MockRegistryService) {
-				registry.Mock.On("SearchDetails", "test", "", "", "", 30).Return([]model.ServerDetail{}, "", errors.New("database connection error"))
+				registry.Mock.On("SearchDetails", service.SearchOptions{
+					Query:     "test",
+					Direction: database.CursorNext,
+					Limit:     30,
+				}).
+					Return([]model.ServerDetail{}, database.PageCursors{}, errors.New("database connection error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedError:  "database connection error",
@@ -267,7 +330,12 @@ func TestSearchHandler(t *testing.T) {
 			queryParams: "?q=test&limit=150",
 			setupMocks: func(registry *MockRegistryService) {
 				servers := []model.ServerDetail{}
-				registry.Mock.On("SearchDetails", "test", "", "", "", 100).Return(servers, "", nil)
+				registry.Mock.On("SearchDetails", service.SearchOptions{
+					Query:     "test",
+					Direction: database.CursorNext,
+					Limit:     100,
+				}).
+					Return(servers, database.PageCursors{}, nil)
 			},
 			expectedStatus:  http.StatusOK,
 			expectedServers: []model.ServerDetail{},
@@ -281,7 +349,7 @@ func TestSearchHandler(t *testing.T) {
 			tc.setupMocks(mockRegistry)
 
 			// Create handler
-			handler := v0.SearchHandler(mockRegistry)
+			handler := v0.SearchHandler(mockRegistry, &config.Config{})
 
 			// Create request
 			url := "/v0/search" + tc.queryParams
@@ -294,7 +362,7 @@ func TestSearchHandler(t *testing.T) {
 			rr := httptest.NewRecorder()
 
 			// Call the handler
-			handler.ServeHTTP(rr, req)
+			serveHTTP(t, handler, rr, req)
 
 			// Check status code
 			assert.Equal(t, tc.expectedStatus, rr.Code)
@@ -313,6 +381,7 @@ func TestSearchHandler(t *testing.T) {
 
 				// Check metadata if expected
 				if tc.expectedMeta != nil {
+					require.NotNil(t, resp.Metadata)
 					assert.Equal(t, tc.expectedMeta.Count, resp.Metadata.Count)
 					if tc.expectedMeta.NextCursor != "" {
 						assert.NotEmpty(t, resp.Metadata.NextCursor)
@@ -361,10 +430,15 @@ func TestSearchHandlerIntegration(t *testing.T) {
 		},
 	}
 
-	mockRegistry.Mock.On("SearchDetails", "integration", "", "", "", 30).Return(servers, "", nil)
+	mockRegistry.Mock.On("SearchDetails", service.SearchOptions{
+		Query:     "integration",
+		Direction: database.CursorNext,
+		Limit:     30,
+	}).
+		Return(servers, database.PageCursors{}, nil)
 
 	// Create test server
-	server := httptest.NewServer(v0.SearchHandler(mockRegistry))
+	server := httptest.NewServer(v0.SearchHandler(mockRegistry, &config.Config{}))
 	defer server.Close()
 
 	// Send request to the test server
@@ -394,8 +468,493 @@ func TestSearchHandlerIntegration(t *testing.T) {
 
 	// Check the response data
 	assert.Equal(t, servers, paginatedResp.Data)
-	assert.Empty(t, paginatedResp.Metadata.NextCursor)
+	assert.Nil(t, paginatedResp.Metadata)
 
 	// Verify mock expectations
 	mockRegistry.Mock.AssertExpectations(t)
 }
+
+func TestSearchHandlerMinimalFormat(t *testing.T) {
+	servers := []model.Server{
+		{
+			ID:          "550e8400-e29b-41d4-a716-446655440002",
+			Name:        "npm-server",
+			Description: "NPM server",
+			Repository: model.Repository{
+				URL:    "https://github.com/example/npm-server",
+				Source: "github",
+				ID:     "example/npm-server",
+			},
+			VersionDetail: model.VersionDetail{
+				Version: "2.0.0",
+			},
+		},
+	}
+
+	mockRegistry := new(MockRegistryService)
+	mockRegistry.Mock.On("Search", service.SearchOptions{
+		Query:        "server",
+		RegistryName: "npm",
+		Direction:    database.CursorNext,
+		Limit:        500,
+	}).Return(servers, database.PageCursors{}, nil)
+
+	req, err := http.NewRequestWithContext(
+		context.Background(), http.MethodGet, "/v0/search?q=server&registry_name=npm&format=minimal&limit=1000", nil,
+	)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.SearchHandler(mockRegistry, &config.Config{}), rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	// Only id and name are returned, without package data
+	assert.NotContains(t, rr.Body.String(), "packages")
+	var raw struct {
+		Servers []map[string]any `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &raw))
+	assert.Equal(t, []map[string]any{{"id": servers[0].ID, "name": servers[0].Name}}, raw.Servers)
+
+	mockRegistry.Mock.AssertExpectations(t)
+}
+
+func TestSearchHandlerInvalidFormat(t *testing.T) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/search?q=server&format=xml", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.SearchHandler(new(MockRegistryService), &config.Config{}), rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "Invalid format parameter")
+}
+
+func TestSearchHandlerSortByStars(t *testing.T) {
+	newServer := func(id, name string, stats *model.RepositoryStats) *model.Server {
+		return &model.Server{
+			ID:   id,
+			Name: name,
+			Repository: model.Repository{
+				URL:    "https://github.com/" + name,
+				Source: "github",
+			},
+			VersionDetail: model.VersionDetail{
+				Version:  "1.0.0",
+				IsLatest: true,
+			},
+			RepositoryStats: stats,
+		}
+	}
+
+	// Creation order differs from star order
+	db := database.NewMemoryDB(map[string]*model.Server{
+		"1": newServer("1", "example/few-stars", &model.RepositoryStats{Stars: 10}),
+		"2": newServer("2", "example/no-stats", nil),
+		"3": newServer("3", "example/many-stars", &model.RepositoryStats{Stars: 500}),
+	})
+	registry := service.NewRegistryServiceWithDB(db)
+
+	search := func(t *testing.T, cfg *config.Config, query string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/search"+query, nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.SearchHandler(registry, cfg), rr, req)
+		return rr
+	}
+
+	names := func(t *testing.T, rr *httptest.ResponseRecorder) ([]string, *v0.Metadata) {
+		t.Helper()
+		require.Equal(t, http.StatusOK, rr.Code)
+		var resp v0.PaginatedResponseDetails
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		var result []string
+		for _, server := range resp.Data {
+			result = append(result, server.Name)
+		}
+		return result, resp.Metadata
+	}
+
+	t.Run("orders by stars descending", func(t *testing.T) {
+		result, _ := names(t, search(t, &config.Config{RepoStatsSyncEnabled: true}, "?sort=stars"))
+		assert.Equal(t, []string{"example/many-stars", "example/few-stars", "example/no-stats"}, result)
+	})
+
+	t.Run("paginates in star order", func(t *testing.T) {
+		cfg := &config.Config{RepoStatsSyncEnabled: true}
+
+		first, metadata := names(t, search(t, cfg, "?sort=stars&limit=2"))
+		assert.Equal(t, []string{"example/many-stars", "example/few-stars"}, first)
+		require.NotNil(t, metadata)
+
+		second, metadata := names(t, search(t, cfg, "?sort=stars&limit=2&cursor="+metadata.NextCursor))
+		assert.Equal(t, []string{"example/no-stats"}, second)
+		require.NotNil(t, metadata)
+		assert.Empty(t, metadata.NextCursor)
+
+		previous, metadata := names(t, search(t, cfg, "?sort=stars&limit=2&cursor_direction=prev&cursor="+metadata.PrevCursor))
+		assert.Equal(t, first, previous)
+		require.NotNil(t, metadata)
+		assert.Empty(t, metadata.PrevCursor)
+	})
+
+	t.Run("rejected when repository stats sync is disabled", func(t *testing.T) {
+		rr := search(t, &config.Config{RepoStatsSyncEnabled: false}, "?sort=stars")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "repository stats sync")
+	})
+
+	t.Run("rejects unknown sort value", func(t *testing.T) {
+		rr := search(t, &config.Config{RepoStatsSyncEnabled: true}, "?sort=downloads")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Invalid sort parameter")
+	})
+}
+
+func TestSearchHandlerSortByReleaseDate(t *testing.T) {
+	newServer := func(id, name string, publishedAt time.Time) *model.Server {
+		return &model.Server{
+			ID:            id,
+			Name:          name,
+			Repository:    model.Repository{URL: "https://github.com/" + name, Source: "github"},
+			VersionDetail: model.VersionDetail{Version: "1.0.0", IsLatest: true},
+			PublishedAt:   publishedAt,
+		}
+	}
+
+	// Creation order differs from publication order
+	now := time.Now().UTC()
+	db := database.NewMemoryDB(map[string]*model.Server{
+		"1": newServer("1", "example/last-week", now.Add(-7*24*time.Hour)),
+		"2": newServer("2", "example/today", now),
+		"3": newServer("3", "example/yesterday", now.Add(-24*time.Hour)),
+	})
+	registry := service.NewRegistryServiceWithDB(db)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/search?sort=release_date", nil)
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.SearchHandler(registry, &config.Config{}), rr, req)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	var resp v0.PaginatedResponseDetails
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	var names []string
+	for _, server := range resp.Data {
+		names = append(names, server.Name)
+	}
+	assert.Equal(t, []string{"example/today", "example/yesterday", "example/last-week"}, names)
+}
+
+func TestSearchHandlerSortByField(t *testing.T) {
+	newServer := func(id, name string) *model.Server {
+		return &model.Server{
+			ID:            id,
+			Name:          name,
+			Repository:    model.Repository{URL: "https://github.com/" + name, Source: "github"},
+			VersionDetail: model.VersionDetail{Version: "1.0.0", IsLatest: true},
+		}
+	}
+
+	db := database.NewMemoryDB(map[string]*model.Server{
+		"1": newServer("1", "example/postgres-tools"),
+		"2": newServer("2", "example/files"),
+		"3": newServer("3", "example/postgres"),
+	})
+	registry := service.NewRegistryServiceWithDB(db)
+
+	search := func(t *testing.T, query string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/search"+query, nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.SearchHandler(registry, &config.Config{}), rr, req)
+		return rr
+	}
+
+	names := func(t *testing.T, rr *httptest.ResponseRecorder) []string {
+		t.Helper()
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var resp v0.PaginatedResponseDetails
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		var result []string
+		for _, server := range resp.Data {
+			result = append(result, server.Name)
+		}
+		return result
+	}
+
+	t.Run("orders by name", func(t *testing.T) {
+		assert.Equal(t, []string{"example/files", "example/postgres", "example/postgres-tools"}, names(t, search(t, "?sort=name")))
+	})
+
+	t.Run("orders by name descending", func(t *testing.T) {
+		assert.Equal(t, []string{"example/postgres-tools", "example/postgres", "example/files"}, names(t, search(t, "?sort=-name")))
+	})
+
+	t.Run("orders text search results by relevance", func(t *testing.T) {
+		assert.Equal(t, []string{"example/postgres", "example/postgres-tools"}, names(t, search(t, "?q=postgres&sort=relevance")))
+	})
+
+	t.Run("rejects relevance without a query", func(t *testing.T) {
+		rr := search(t, "?sort=relevance")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "requires a search query")
+	})
+
+	t.Run("rejects descending orders other than field orders", func(t *testing.T) {
+		rr := search(t, "?sort=-release_date")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Invalid sort parameter")
+	})
+}
+
+func TestSearchHandlerCategory(t *testing.T) {
+	newServer := func(id, name string, category model.Category) *model.Server {
+		return &model.Server{
+			ID:            id,
+			Name:          name,
+			Repository:    model.Repository{URL: "https://github.com/" + name, Source: "github"},
+			VersionDetail: model.VersionDetail{Version: "1.0.0", IsLatest: true},
+			Category:      category,
+		}
+	}
+
+	db := database.NewMemoryDB(map[string]*model.Server{
+		"1": newServer("1", "example/files", model.CategoryFilesystem),
+		"2": newServer("2", "example/postgres", model.CategoryDatabase),
+		"3": newServer("3", "example/sqlite", model.CategoryDatabase),
+	})
+	registry := service.NewRegistryServiceWithDB(db)
+
+	search := func(t *testing.T, query string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/search"+query, nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.SearchHandler(registry, &config.Config{}), rr, req)
+		return rr
+	}
+
+	t.Run("filters by category", func(t *testing.T) {
+		rr := search(t, "?category=database")
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp v0.PaginatedResponseDetails
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		var names []string
+		for _, server := range resp.Data {
+			assert.Equal(t, model.CategoryDatabase, server.Category)
+			names = append(names, server.Name)
+		}
+		assert.ElementsMatch(t, []string{"example/postgres", "example/sqlite"}, names)
+	})
+
+	t.Run("filters minimal results by category", func(t *testing.T) {
+		rr := search(t, "?category=filesystem&format=minimal")
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp v0.MinimalPaginatedResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		require.Len(t, resp.Data, 1)
+		assert.Equal(t, "example/files", resp.Data[0].Name)
+	})
+
+	t.Run("rejects unknown category", func(t *testing.T) {
+		rr := search(t, "?category=games")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Invalid category parameter")
+	})
+}
+
+func TestSearchHandlerMCPVersionFilter(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	for name, minMCPVersion := range map[string]string{"legacy": "", "current": "1.0.0", "next": "2.0.0"} {
+		require.NoError(t, registry.Publish(&model.ServerDetail{
+			Server: model.Server{
+				Name:          "io.github.example/" + name,
+				Repository:    model.Repository{URL: "https://github.com/example/" + name, Source: "github"},
+				VersionDetail: model.VersionDetail{Version: "1.0.0"},
+				MinMCPVersion: minMCPVersion,
+			},
+		}))
+	}
+
+	search := func(t *testing.T, query string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/search"+query, nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.SearchHandler(registry, &config.Config{}), rr, req)
+		return rr
+	}
+
+	rr := search(t, "?max_mcp_version=1.5.0&format=minimal")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var resp v0.ResponseEnvelope[[]model.ServerMinimal]
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	names := make([]string, len(resp.Data))
+	for i, server := range resp.Data {
+		names[i] = server.Name
+	}
+	assert.ElementsMatch(t, []string{"io.github.example/legacy", "io.github.example/current"}, names)
+
+	for _, query := range []string{"?min_mcp_version=1", "?max_mcp_version=v2.0.0", "?min_mcp_version=2.0.0&max_mcp_version=1.0.0"} {
+		rr := search(t, query)
+		assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+	}
+}
+
+func TestSearchHandlerIncludeTotal(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	servers := map[string]string{"postgres": "1.0.0", "postgres-tools": "2.0.0", "files": "1.0.0"}
+	for name, minMCPVersion := range servers {
+		require.NoError(t, registry.Publish(&model.ServerDetail{
+			Server: model.Server{
+				Name:          "io.github.example/" + name,
+				Repository:    model.Repository{URL: "https://github.com/example/" + name, Source: "github"},
+				VersionDetail: model.VersionDetail{Version: "1.0.0"},
+				MinMCPVersion: minMCPVersion,
+			},
+		}))
+	}
+
+	search := func(t *testing.T, query string) *v0.Metadata {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/search"+query, nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.SearchHandler(registry, &config.Config{}), rr, req)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var resp v0.ResponseEnvelope[json.RawMessage]
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		require.NotNil(t, resp.Metadata)
+		require.NotNil(t, resp.Metadata.TotalCount)
+		return resp.Metadata
+	}
+
+	tests := map[string]int{
+		"?include_total=true":                                  3,
+		"?q=postgres&limit=1&include_total=true":               2,
+		"?q=postgres&include_total=true&format=minimal":        2,
+		"?max_mcp_version=1.5.0&limit=1&include_total=true":    2,
+		"?q=postgres&max_mcp_version=1.5.0&include_total=true": 1,
+		"?q=gres&include_total=true":                           2,
+		"?registry_name=npm&include_total=true":                0,
+		"?q=postgres&sort=-name&limit=1&include_total=true":    2,
+	}
+	for query, expected := range tests {
+		t.Run(query, func(t *testing.T) {
+			assert.Equal(t, expected, *search(t, query).TotalCount)
+		})
+	}
+}
+
+func TestSearchHandlerPublishedRange(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	publish := func(name string, publishedAt time.Time) {
+		require.NoError(t, registry.Publish(&model.ServerDetail{
+			Server: model.Server{
+				Name:          "io.github.example/" + name,
+				Repository:    model.Repository{URL: "https://github.com/example/" + name, Source: "github"},
+				VersionDetail: model.VersionDetail{Version: "1.0.0"},
+				PublishedAt:   publishedAt,
+			},
+		}))
+	}
+	publish("old-server", time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC))
+	publish("new-server", time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC))
+
+	search := func(t *testing.T, query string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/search"+query, nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.SearchHandler(registry, &config.Config{}), rr, req)
+		return rr
+	}
+	names := func(t *testing.T, query string) []string {
+		t.Helper()
+		rr := search(t, query)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var resp v0.PaginatedResponseDetails
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		var result []string
+		for _, server := range resp.Data {
+			result = append(result, server.Name)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"io.github.example/new-server"}, names(t, "?published_after=2025-02-01T00:00:00Z"))
+	assert.Equal(t, []string{"io.github.example/old-server"}, names(t, "?published_before="+url.QueryEscape("2025-02-01T01:00:00+01:00")))
+	assert.Equal(t, []string{"io.github.example/new-server"}, names(t, "?format=minimal&published_after=2025-02-01T00:00:00Z"))
+	assert.Empty(t, names(t, "?published_after=2025-03-10T12:00:00Z"))
+
+	for _, query := range []string{
+		"?published_after=yesterday",
+		"?published_before=2024-01-01",
+		"?published_after=2024-02-01T00:00:00Z&published_before=2024-01-01T00:00:00Z",
+	} {
+		t.Run(query, func(t *testing.T) {
+			assert.Equal(t, http.StatusBadRequest, search(t, query).Code)
+		})
+	}
+}
+
+func TestSearchHandlerHighlight(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	serverDetail := &model.ServerDetail{
+		Server: model.Server{
+			Name:          "io.github.example/memory-server",
+			Description:   "Gives agents a persistent memory backed by a local knowledge graph",
+			Repository:    model.Repository{URL: "https://github.com/example/memory-server", Source: "github"},
+			VersionDetail: model.VersionDetail{Version: "1.0.0"},
+		},
+	}
+	require.NoError(t, registry.Publish(serverDetail))
+
+	search := func(t *testing.T, query string) []model.ServerDetail {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/search"+query, nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.SearchHandler(registry, &config.Config{}), rr, req)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var resp v0.PaginatedResponseDetails
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		require.Len(t, resp.Data, 1)
+		return resp.Data
+	}
+
+	t.Run("matched fragments on request", func(t *testing.T) {
+		results := search(t, "?q=memory&highlight=true")
+		assert.Equal(t, map[string][]string{
+			"name":        {"io.github.example/memory-server"},
+			"description": {"agents a persistent memory backed by a local k"},
+		}, results[0].Highlights)
+	})
+
+	t.Run("omitted by default", func(t *testing.T) {
+		assert.Nil(t, search(t, "?q=memory")[0].Highlights)
+	})
+
+	t.Run("omitted in direct lookups", func(t *testing.T) {
+		found, err := registry.GetByID(serverDetail.ID)
+		require.NoError(t, err)
+		assert.Nil(t, found.Highlights)
+	})
+
+	t.Run("rejects invalid value", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/search?q=memory&highlight=maybe", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.SearchHandler(registry, &config.Config{}), rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Invalid highlight parameter")
+	})
+}
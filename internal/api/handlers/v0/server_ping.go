@@ -0,0 +1,140 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// serverPingInterval is how often a client may ping the same server
+const serverPingInterval = time.Minute
+
+// ServerPingStatus is the availability of a server as seen by a health check
+type ServerPingStatus string
+
+const (
+	// ServerPingUp indicates the health check endpoint answered
+	ServerPingUp ServerPingStatus = "up"
+	// ServerPingDown indicates the health check endpoint could not be reached or failed
+	ServerPingDown ServerPingStatus = "down"
+	// ServerPingUnknown indicates the server has no health check endpoint the registry may call
+	ServerPingUnknown ServerPingStatus = "unknown"
+)
+
+// ServerPingResponse represents the result of checking whether a server is reachable
+type ServerPingResponse struct {
+	Status    ServerPingStatus `json:"status"`
+	LatencyMS int64            `json:"latency_ms,omitempty"`
+	CheckedAt *time.Time       `json:"checked_at,omitempty"`
+}
+
+// serverRateLimiter tracks when each client last made a request about each server
+type serverRateLimiter struct {
+	mu          sync.Mutex
+	interval    time.Duration
+	lastRequest map[string]time.Time
+}
+
+// newServerRateLimiter returns a limiter allowing each client one request per server per interval
+func newServerRateLimiter(interval time.Duration) *serverRateLimiter {
+	return &serverRateLimiter{interval: interval, lastRequest: make(map[string]time.Time)}
+}
+
+// allow reports whether the client may make a request about the server now, and if so records the request.
+// Requests older than the interval are forgotten.
+func (l *serverRateLimiter) allow(serverID, clientIP string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for key, requestedAt := range l.lastRequest {
+		if now.Sub(requestedAt) >= l.interval {
+			delete(l.lastRequest, key)
+		}
+	}
+
+	key := serverID + "|" + clientIP
+	if _, ok := l.lastRequest[key]; ok {
+		return false
+	}
+	l.lastRequest[key] = now
+	return true
+}
+
+// ServerPingHandler returns a handler that checks whether a server is reachable by sending a HEAD request to its
+// health check URL with client, usually service.NewHealthCheckClient. Checks run on every request rather than
+// being cached, and each client may ping a server once a minute.
+func ServerPingHandler(registry service.RegistryService, client *http.Client) http.HandlerFunc {
+	limiter := newServerRateLimiter(serverPingInterval)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		serverMeta, err := registry.GetMetadata(r.Context(), id)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Server not found"}, http.StatusNotFound)
+				return
+			}
+			middleware.Logf(r.Context(), "ping: Failed to look up server %s: %v", id, err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Error retrieving server"}, http.StatusInternalServerError)
+			return
+		}
+
+		if serverMeta.HealthCheckURL == "" {
+			writeJSON(w, ServerPingResponse{Status: ServerPingUnknown})
+			return
+		}
+
+		if !limiter.allow(id, middleware.GetRealIP(r), time.Now()) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(serverPingInterval.Seconds())))
+			WriteError(w, APIError{Code: ErrCodeRateLimited, Message: "Server may be pinged once a minute"}, http.StatusTooManyRequests)
+			return
+		}
+
+		writeJSON(w, pingServer(r.Context(), client, serverMeta.HealthCheckURL))
+	}
+}
+
+// pingServer sends a HEAD request to the health check URL. The server is up if it answers without a server error.
+// Health checks the client refuses to send, to private addresses or through insecure redirects, are unknown, so
+// that nothing is revealed about what answers there.
+func pingServer(ctx context.Context, client *http.Client, healthCheckURL string) ServerPingResponse {
+	checkedAt := time.Now().UTC()
+	response := ServerPingResponse{Status: ServerPingDown, CheckedAt: &checkedAt}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, healthCheckURL, nil)
+	if err != nil {
+		return response
+	}
+	resp, err := client.Do(req)
+	if errors.Is(err, service.ErrPrivateAddress) || errors.Is(err, service.ErrInsecureRedirect) {
+		return ServerPingResponse{Status: ServerPingUnknown}
+	}
+	if err != nil {
+		return response
+	}
+	resp.Body.Close()
+
+	response.LatencyMS = time.Since(checkedAt).Milliseconds()
+	if resp.StatusCode < http.StatusInternalServerError {
+		response.Status = ServerPingUp
+	}
+	return response
+}
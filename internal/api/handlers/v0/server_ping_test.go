@@ -0,0 +1,146 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newHealthCheckServer starts an HTTPS server answering HEAD requests with the given status. Its client trusts the
+// self-signed certificate and, unlike the health check client of the registry, may connect to the loopback address
+// the server listens on.
+func newHealthCheckServer(t *testing.T, status int) *httptest.Server {
+	t.Helper()
+	upstream := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodHead, r.Method)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(upstream.Close)
+	return upstream
+}
+
+func TestServerPingHandler(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+
+	publishHosted := func(t *testing.T, name, healthCheckURL string) string {
+		t.Helper()
+		serverDetail := &model.ServerDetail{
+			Server: model.Server{
+				Name:           "io.github.example/" + name,
+				Repository:     model.Repository{URL: "https://github.com/example/" + name, Source: "github"},
+				VersionDetail:  model.VersionDetail{Version: "1.0.0"},
+				HealthCheckURL: healthCheckURL,
+			},
+		}
+		require.NoError(t, registry.Publish(serverDetail))
+		return serverDetail.ID
+	}
+
+	ping := func(t *testing.T, handler http.Handler, id string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers/"+id+"/ping", nil)
+		require.NoError(t, err)
+		req.SetPathValue("id", id)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, handler, rr, req)
+		return rr
+	}
+
+	decode := func(t *testing.T, rr *httptest.ResponseRecorder) v0.ServerPingResponse {
+		t.Helper()
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var resp v0.ServerPingResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		return resp
+	}
+
+	t.Run("reachable server is up", func(t *testing.T) {
+		upstream := newHealthCheckServer(t, http.StatusNoContent)
+		id := publishHosted(t, "up-server", upstream.URL+"/health")
+
+		resp := decode(t, ping(t, v0.ServerPingHandler(registry, upstream.Client()), id))
+		assert.Equal(t, v0.ServerPingUp, resp.Status)
+		assert.GreaterOrEqual(t, resp.LatencyMS, int64(0))
+		assert.NotNil(t, resp.CheckedAt)
+	})
+
+	t.Run("server error is down", func(t *testing.T) {
+		upstream := newHealthCheckServer(t, http.StatusServiceUnavailable)
+		id := publishHosted(t, "failing-server", upstream.URL+"/health")
+
+		resp := decode(t, ping(t, v0.ServerPingHandler(registry, upstream.Client()), id))
+		assert.Equal(t, v0.ServerPingDown, resp.Status)
+		assert.NotNil(t, resp.CheckedAt)
+	})
+
+	t.Run("unreachable server is down", func(t *testing.T) {
+		upstream := newHealthCheckServer(t, http.StatusOK)
+		id := publishHosted(t, "unreachable-server", upstream.URL+"/health")
+		client := upstream.Client()
+		upstream.Close()
+
+		resp := decode(t, ping(t, v0.ServerPingHandler(registry, client), id))
+		assert.Equal(t, v0.ServerPingDown, resp.Status)
+	})
+
+	t.Run("server without health check is unknown", func(t *testing.T) {
+		id := publishHosted(t, "local-server", "")
+
+		resp := decode(t, ping(t, v0.ServerPingHandler(registry, service.NewHealthCheckClient()), id))
+		assert.Equal(t, v0.ServerPingResponse{Status: v0.ServerPingUnknown}, resp)
+	})
+
+	t.Run("private health check addresses are not pinged", func(t *testing.T) {
+		called := false
+		upstream := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			called = true
+		}))
+		defer upstream.Close()
+		handler := v0.ServerPingHandler(registry, service.NewHealthCheckClient())
+
+		for name, healthCheckURL := range map[string]string{
+			"loopback-server": upstream.URL + "/health",
+			"rfc1918-server":  "https://10.0.0.1/health",
+		} {
+			id := publishHosted(t, name, healthCheckURL)
+			resp := decode(t, ping(t, handler, id))
+			assert.Equal(t, v0.ServerPingResponse{Status: v0.ServerPingUnknown}, resp, name)
+		}
+		assert.False(t, called)
+	})
+
+	t.Run("each client may ping a server once a minute", func(t *testing.T) {
+		upstream := newHealthCheckServer(t, http.StatusOK)
+		id := publishHosted(t, "limited-server", upstream.URL+"/health")
+		otherID := publishHosted(t, "other-limited-server", upstream.URL+"/health")
+		handler := v0.ServerPingHandler(registry, upstream.Client())
+
+		assert.Equal(t, v0.ServerPingUp, decode(t, ping(t, handler, id)).Status)
+
+		rr := ping(t, handler, id)
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+		assert.Equal(t, "60", rr.Header().Get("Retry-After"))
+
+		// Other servers are limited separately
+		assert.Equal(t, v0.ServerPingUp, decode(t, ping(t, handler, otherID)).Status)
+	})
+
+	t.Run("unknown server", func(t *testing.T) {
+		rr := ping(t, v0.ServerPingHandler(registry, service.NewHealthCheckClient()), "00000000-0000-0000-0000-000000000000")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("invalid server ID", func(t *testing.T) {
+		rr := ping(t, v0.ServerPingHandler(registry, service.NewHealthCheckClient()), "not-a-uuid")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
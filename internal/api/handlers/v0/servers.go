@@ -2,47 +2,143 @@
 package v0
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/analytics"
+	"github.com/modelcontextprotocol/registry/internal/api/versioning"
+	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/scoring"
 	"github.com/modelcontextprotocol/registry/internal/service"
 )
 
-// Response is a paginated API response
-type PaginatedResponse struct {
-	Data     []model.Server `json:"servers"`
-	Metadata Metadata       `json:"metadata,omitempty"`
-}
+// PaginatedResponse is a paginated API response
+type PaginatedResponse = ResponseEnvelope[[]model.Server]
 
 // Metadata contains pagination metadata
 type Metadata struct {
 	NextCursor string `json:"next_cursor,omitempty"`
+	// PrevCursor points to the first item of the page; pass it with cursor_direction=prev for the previous page
+	PrevCursor string `json:"prev_cursor,omitempty"`
 	Count      int    `json:"count,omitempty"`
-	Total      int    `json:"total,omitempty"`
+	// TotalCount is the number of results across all pages; it is only counted if include_total=true is requested
+	TotalCount *int `json:"total_count,omitempty"`
+	// SyncCursor points to the last server of an updated_after listing, to pass as the next updated_after
+	SyncCursor string `json:"sync_cursor,omitempty"`
+}
+
+// MinimalPaginatedResponse is a paginated API response containing only server IDs and names
+type MinimalPaginatedResponse = ResponseEnvelope[[]model.ServerMinimal]
+
+const (
+	// formatMinimal is the value of the format query parameter requesting minimal responses
+	formatMinimal = "minimal"
+	// maxLimit caps the number of items returned per request
+	maxLimit = 100
+	// maxMinimalLimit caps the number of items returned per request in minimal format
+	maxMinimalLimit = 500
+)
+
+// parseFormat validates the format query parameter and reports whether minimal format was requested
+func parseFormat(r *http.Request) (bool, error) {
+	switch format := r.URL.Query().Get("format"); format {
+	case "":
+		return false, nil
+	case formatMinimal:
+		return true, nil
+	default:
+		return false, fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+// toMinimal converts servers to their minimal representation
+func toMinimal(servers []model.Server) []model.ServerMinimal {
+	result := make([]model.ServerMinimal, len(servers))
+	for i, server := range servers {
+		result[i] = model.ServerMinimal{ID: server.ID, Name: server.Name}
+	}
+	return result
+}
+
+// redactContactEmails masks the contact emails of servers for public responses
+func redactContactEmails(servers []model.Server) {
+	for i := range servers {
+		servers[i].RedactContactEmail()
+	}
+}
+
+// writeJSON encodes the response as JSON
+func writeJSON(w http.ResponseWriter, response any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to encode response"}, http.StatusInternalServerError)
+		return
+	}
 }
 
 // ServersHandler returns a handler for listing registry items
 func ServersHandler(registry service.RegistryService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		generatedAt := time.Now().UTC()
+
 		if r.Method != http.MethodGet {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
 			return
 		}
 
-		// Parse cursor and limit from query parameters
-		cursor := r.URL.Query().Get("cursor")
-		if cursor != "" {
-			_, err := uuid.Parse(cursor)
+		includeTotal, err := parseIncludeTotal(r)
+		if err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid include_total parameter"}, http.StatusBadRequest)
+			return
+		}
+
+		// A list of IDs fetches those servers instead of listing, for clients that cannot send POST requests
+		if r.URL.Query().Has("ids") {
+			ids, err := parseBatchIDs(r.URL.Query().Get("ids"), maxBatchQueryIDs)
 			if err != nil {
-				http.Error(w, "Invalid cursor parameter", http.StatusBadRequest)
+				WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid ids parameter: " + err.Error()}, http.StatusBadRequest)
 				return
 			}
+			serveBatch(w, r, registry, ids, includeTotal, generatedAt)
+			return
+		}
+
+		// Parse cursor and limit from query parameters
+		cursor, direction, ok := parseCursor(w, r)
+		if !ok {
+			return
 		}
 		limitStr := r.URL.Query().Get("limit")
 
+		// Validate sort parameter if provided; without it servers are listed in creation order
+		sortBy := r.URL.Query().Get("sort")
+		if sortBy != "" && !service.IsFieldSort(sortBy) {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid sort parameter"}, http.StatusBadRequest)
+			return
+		}
+
+		minimal, err := parseFormat(r)
+		if err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid format parameter"}, http.StatusBadRequest)
+			return
+		}
+
+		// Minimal responses are small enough to allow larger pages
+		limitCap := maxLimit
+		if minimal {
+			limitCap = maxMinimalLimit
+		}
+
 		// Default limit if not specified
 		limit := 30
 
@@ -50,67 +146,164 @@ func ServersHandler(registry service.RegistryService) http.HandlerFunc {
 		if limitStr != "" {
 			parsedLimit, err := strconv.Atoi(limitStr)
 			if err != nil {
-				http.Error(w, "Invalid limit parameter", http.StatusBadRequest)
+				WriteError(w, APIError{Code: ErrCodeInvalidLimit, Message: "Invalid limit parameter"}, http.StatusBadRequest)
 				return
 			}
 
 			// Check if limit is within reasonable bounds
 			if parsedLimit <= 0 {
-				http.Error(w, "Limit must be greater than 0", http.StatusBadRequest)
+				WriteError(w, APIError{Code: ErrCodeInvalidLimit, Message: "Limit must be greater than 0"}, http.StatusBadRequest)
 				return
 			}
 
-			if parsedLimit > 100 {
+			if parsedLimit > limitCap {
 				// Cap maximum limit to prevent excessive queries
-				limit = 100
+				limit = limitCap
 			} else {
 				limit = parsedLimit
 			}
 		}
 
+		// Incremental sync lists servers by update time instead of paginating with a cursor
+		if updatedAfter := r.URL.Query().Get("updated_after"); updatedAfter != "" {
+			if cursor != "" {
+				WriteError(w, APIError{
+					Code:    ErrCodeInvalidCursor,
+					Message: "updated_after cannot be combined with cursor",
+				}, http.StatusBadRequest)
+				return
+			}
+			serveUpdatedAfter(w, r, registry, updatedAfter, limit, minimal, generatedAt)
+			return
+		}
+
 		// Use the GetAll method to get paginated results
-		registries, nextCursor, err := registry.List(cursor, limit)
+		registries, cursors, err := registry.List(sortBy, cursor, direction, limit)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: err.Error()}, http.StatusInternalServerError)
 			return
 		}
 
-		// Create paginated response
-		response := PaginatedResponse{
-			Data: registries,
+		metadata := paginationMetadata(cursors, len(registries))
+		if includeTotal {
+			total, err := registry.CountServers(r.Context())
+			if err != nil {
+				WriteError(w, APIError{Code: ErrCodeInternal, Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+			metadata = withTotalCount(metadata, len(registries), total)
 		}
 
-		// Add metadata if there's a next cursor
-		if nextCursor != "" {
-			response.Metadata = Metadata{
-				NextCursor: nextCursor,
-				Count:      len(registries),
-			}
+		if minimal {
+			response := NewResponseEnvelope(toMinimal(registries), generatedAt)
+			response.Metadata = metadata
+			writeJSON(w, response)
+			return
 		}
 
+		// Create paginated response
+		redactContactEmails(registries)
+		response := NewResponseEnvelope(registries, generatedAt)
+		response.Metadata = metadata
+
 		w.Header().Set("Content-Type", "application/json")
 		if err := json.NewEncoder(w).Encode(response); err != nil {
-			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to encode response"}, http.StatusInternalServerError)
 			return
 		}
 	}
 }
 
-// ServersDetailHandler returns a handler for getting details of a specific server by ID
-func ServersDetailHandler(registry service.RegistryService) http.HandlerFunc {
+// serveUpdatedAfter writes the servers updated after the given RFC 3339 time or sync cursor, least recently
+// updated first. The sync cursor in the metadata points to the last server, or to the requested position if
+// nothing was updated since.
+func serveUpdatedAfter(
+	w http.ResponseWriter, r *http.Request, registry service.RegistryService,
+	updatedAfterStr string, limit int, minimal bool, generatedAt time.Time,
+) {
+	updatedAfter, afterSeq, err := parseUpdatedAfter(updatedAfterStr)
+	if err != nil {
+		WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid updated_after parameter"}, http.StatusBadRequest)
+		return
+	}
+
+	servers, err := registry.ListUpdatedAfter(r.Context(), updatedAfter, afterSeq, limit)
+	if err != nil {
+		WriteError(w, APIError{Code: ErrCodeInternal, Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+
+	if len(servers) > 0 {
+		last := servers[len(servers)-1]
+		updatedAfter, afterSeq = last.UpdatedAt, last.CreatedSeq
+	}
+	metadata := &Metadata{
+		Count:      len(servers),
+		SyncCursor: database.EncodeSyncCursor(updatedAfter, afterSeq),
+	}
+
+	if minimal {
+		response := NewResponseEnvelope(toMinimal(servers), generatedAt)
+		response.Metadata = metadata
+		writeJSON(w, response)
+		return
+	}
+
+	redactContactEmails(servers)
+	response := NewResponseEnvelope(servers, generatedAt)
+	response.Metadata = metadata
+	writeJSON(w, response)
+}
+
+// parseUpdatedAfter parses the updated_after parameter into the update time and creation sequence number to list
+// the servers after. A plain RFC 3339 time excludes every server updated at that time.
+func parseUpdatedAfter(updatedAfterStr string) (time.Time, int64, error) {
+	if updatedAfter, err := time.Parse(time.RFC3339, updatedAfterStr); err == nil {
+		return updatedAfter, math.MaxInt64, nil
+	}
+	return database.DecodeSyncCursor(updatedAfterStr)
+}
+
+// ServersDetailHandler returns a handler for getting details of a specific server by ID.
+// Views are counted for the trending ranking if trending is not nil.
+func ServersDetailHandler(registry service.RegistryService, trending *analytics.Trending) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		generatedAt := time.Now().UTC()
+
+		// HEAD checks whether a server exists without transferring its details
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Only the v0 response schema is available so far; reject versions the client cannot be served
+		if _, err := versioning.ParseVersionHeader(r); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: err.Error()}, http.StatusBadRequest)
 			return
 		}
 
 		// Extract the server ID from the URL path
 		id := r.PathValue("id")
 
-		// Validate that the ID is a valid UUID
+		// Validate that the ID is a valid UUID. Server names, which contain a slash, are redirected to the lookup by
+		// name; they only arrive here with the slash encoded.
 		_, err := uuid.Parse(id)
 		if err != nil {
-			http.Error(w, "Invalid server ID format", http.StatusBadRequest)
+			if strings.Contains(id, "/") {
+				target := "/v0/servers/by-name/" + (&url.URL{Path: id}).EscapedPath()
+				if r.URL.RawQuery != "" {
+					target += "?" + r.URL.RawQuery
+				}
+				http.Redirect(w, r, target, http.StatusMovedPermanently)
+				return
+			}
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		followSuccessor, err := parseFollowSuccessor(r)
+		if err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid follow_successor parameter"}, http.StatusBadRequest)
 			return
 		}
 
@@ -118,16 +311,115 @@ func ServersDetailHandler(registry service.RegistryService) http.HandlerFunc {
 		serverDetail, err := registry.GetByID(id)
 		if err != nil {
 			if err.Error() == "record not found" {
-				http.Error(w, "Server not found", http.StatusNotFound)
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Server not found"}, http.StatusNotFound)
+				return
+			}
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Error retrieving server details"}, http.StatusInternalServerError)
+			return
+		}
+
+		writeServerDetail(w, r, registry, trending, serverDetail, followSuccessor, generatedAt)
+	}
+}
+
+// parseFollowSuccessor parses the optional follow_successor query parameter of server detail requests
+func parseFollowSuccessor(r *http.Request) (bool, error) {
+	value := r.URL.Query().Get("follow_successor")
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// writeServerDetail responds with the details of a server, or redirects to its successor if it is deprecated and
+// followSuccessor is set. The view is counted towards trending servers. HEAD requests get the headers of the
+// response, including its Content-Length, without the body, and are not counted as views. Requests whose
+// If-None-Match lists the current ETag get 304 Not Modified.
+func writeServerDetail(
+	w http.ResponseWriter, r *http.Request, registry service.RegistryService, trending *analytics.Trending,
+	serverDetail *model.ServerDetail, followSuccessor bool, generatedAt time.Time,
+) {
+	// Deprecated servers redirect to their successor on request, unless the successors lead back to the server
+	if followSuccessor && serverDetail.Deprecated && serverDetail.SuccessorID != "" {
+		if !hasSuccessorCycle(registry, serverDetail) {
+			http.Redirect(w, r, "/v0/servers/"+serverDetail.SuccessorID+"?"+r.URL.RawQuery, http.StatusMovedPermanently)
+			return
+		}
+		serverDetail.DeprecationCycle = true
+	}
+
+	if r.Method != http.MethodHead {
+		recordView(r.Context(), trending, serverDetail.ID)
+	}
+
+	// Clients pass the ETag back in If-Match to guard modifications against concurrent updates
+	etag, err := serverETag(serverDetail)
+	if err != nil {
+		WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to compute ETag"}, http.StatusInternalServerError)
+		return
+	}
+
+	// Clients revalidating a cached copy with If-None-Match get an empty response while the server is unchanged
+	if notModified(r, etag) {
+		w.Header().Set("ETag", etag)
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	// The score is derived on every read, so it is left out of the ETag
+	installScore := scoring.ComputeScore(serverDetail)
+	serverDetail.InstallScore = &installScore
+	serverDetail.RedactContactEmail()
+
+	var body bytes.Buffer
+	if err := json.NewEncoder(&body).Encode(NewResponseEnvelope(serverDetail, generatedAt)); err != nil {
+		WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to encode response"}, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(body.Len()))
+	if r.Method == http.MethodHead {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	_, _ = w.Write(body.Bytes())
+}
+
+// ServerMetadataHandler returns a handler for getting a server by ID without its packages
+func ServerMetadataHandler(registry service.RegistryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		generatedAt := time.Now().UTC()
+
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Extract the server ID from the URL path
+		id := r.PathValue("id")
+
+		// Validate that the ID is a valid UUID
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		serverMeta, err := registry.GetMetadata(r.Context(), id)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Server not found"}, http.StatusNotFound)
 				return
 			}
-			http.Error(w, "Error retrieving server details", http.StatusInternalServerError)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Error retrieving server metadata"}, http.StatusInternalServerError)
 			return
 		}
 
+		serverMeta.RedactContactEmail()
 		w.Header().Set("Content-Type", "application/json")
-		if err := json.NewEncoder(w).Encode(serverDetail); err != nil {
-			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		if err := json.NewEncoder(w).Encode(NewResponseEnvelope(serverMeta, generatedAt)); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to encode response"}, http.StatusInternalServerError)
 			return
 		}
 	}
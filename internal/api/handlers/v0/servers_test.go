@@ -4,15 +4,27 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/api/router"
+	"github.com/modelcontextprotocol/registry/internal/api/versioning"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/events"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
 	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestServersHandler(t *testing.T) {
@@ -62,7 +74,7 @@ func TestServersHandler(t *testing.T) {
 						},
 					},
 				}
-				registry.Mock.On("List", "", 30).Return(servers, "", nil)
+				registry.Mock.On("List", "", "", database.CursorNext, 30).Return(servers, database.PageCursors{}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedServers: []model.Server{
@@ -101,7 +113,7 @@ func TestServersHandler(t *testing.T) {
 		{
 			name:        "successful list with cursor and limit",
 			method:      http.MethodGet,
-			queryParams: "?cursor=550e8400-e29b-41d4-a716-446655440000" + "&limit=10",
+			queryParams: "?cursor=" + database.EncodeCursor(2) + "&limit=10",
 			setupMocks: func(registry *MockRegistryService) {
 				servers := []model.Server{
 					{
@@ -120,8 +132,8 @@ func TestServersHandler(t *testing.T) {
 						},
 					},
 				}
-				nextCursor := uuid.New().String()
-				registry.Mock.On("List", mock.AnythingOfType("string"), 10).Return(servers, nextCursor, nil)
+				nextCursor := database.EncodeCursor(3)
+				registry.Mock.On("List", "", mock.AnythingOfType("string"), database.CursorNext, 10).Return(servers, database.PageCursors{Next: nextCursor}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedServers: []model.Server{
@@ -152,7 +164,7 @@ func TestServersHandler(t *testing.T) {
 			queryParams: "?limit=150",
 			setupMocks: func(registry *MockRegistryService) {
 				servers := []model.Server{}
-				registry.Mock.On("List", "", 100).Return(servers, "", nil)
+				registry.Mock.On("List", "", "", database.CursorNext, 100).Return(servers, database.PageCursors{}, nil)
 			},
 			expectedStatus:  http.StatusOK,
 			expectedServers: []model.Server{},
@@ -193,7 +205,8 @@ func TestServersHandler(t *testing.T) {
 			name:   "registry service error",
 			method: http.MethodGet,
 			setupMocks: func(registry *MockRegistryService) {
-				registry.Mock.On("List", "", 30).Return([]model.Server{}, "", errors.New("database connection error"))
+				registry.Mock.On("List", "", "", database.CursorNext, 30).
+					Return([]model.Server{}, database.PageCursors{}, errors.New("database connection error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedError:  "database connection error",
@@ -227,7 +240,7 @@ func TestServersHandler(t *testing.T) {
 			rr := httptest.NewRecorder()
 
 			// Call the handler
-			handler.ServeHTTP(rr, req)
+			serveHTTP(t, handler, rr, req)
 
 			// Check status code
 			assert.Equal(t, tc.expectedStatus, rr.Code)
@@ -246,6 +259,7 @@ func TestServersHandler(t *testing.T) {
 
 				// Check metadata if expected
 				if tc.expectedMeta != nil {
+					require.NotNil(t, resp.Metadata)
 					assert.Equal(t, tc.expectedMeta.Count, resp.Metadata.Count)
 					if tc.expectedMeta.NextCursor != "" {
 						assert.NotEmpty(t, resp.Metadata.NextCursor)
@@ -285,7 +299,7 @@ func TestServersHandlerIntegration(t *testing.T) {
 		},
 	}
 
-	mockRegistry.Mock.On("List", "", 30).Return(servers, "", nil)
+	mockRegistry.Mock.On("List", "", "", database.CursorNext, 30).Return(servers, database.PageCursors{}, nil)
 
 	// Create test server
 	server := httptest.NewServer(v0.ServersHandler(mockRegistry))
@@ -318,7 +332,7 @@ func TestServersHandlerIntegration(t *testing.T) {
 
 	// Check the response data
 	assert.Equal(t, servers, paginatedResp.Data)
-	assert.Empty(t, paginatedResp.Metadata.NextCursor)
+	assert.Nil(t, paginatedResp.Metadata)
 
 	// Verify mock expectations
 	mockRegistry.Mock.AssertExpectations(t)
@@ -354,7 +368,7 @@ func TestServersDetailHandlerIntegration(t *testing.T) {
 	// Create test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		r.SetPathValue("id", serverID)
-		v0.ServersDetailHandler(mockRegistry).ServeHTTP(w, r)
+		v0.ServersDetailHandler(mockRegistry, nil).ServeHTTP(w, r)
 	}))
 	defer server.Close()
 
@@ -379,13 +393,781 @@ func TestServersDetailHandlerIntegration(t *testing.T) {
 	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
 
 	// Parse response body
-	var serverDetailResp model.ServerDetail
+	var serverDetailResp v0.ResponseEnvelope[model.ServerDetail]
 	err = json.NewDecoder(resp.Body).Decode(&serverDetailResp)
 	assert.NoError(t, err)
 
 	// Check the response data
-	assert.Equal(t, *serverDetail, serverDetailResp)
+	assert.Equal(t, *serverDetail, serverDetailResp.Data)
+
+	// The server has a description, a semantic version and no undocumented env vars, but no stars or verified namespace
+	require.NotNil(t, serverDetailResp.Data.InstallScore)
+	assert.Equal(t, 30, *serverDetailResp.Data.InstallScore)
 
 	// Verify mock expectations
 	mockRegistry.Mock.AssertExpectations(t)
 }
+
+func TestServersDetailHandlerHead(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	require.NoError(t, registry.Publish(&model.ServerDetail{
+		Server: model.Server{
+			Name:          "io.github.example/weather",
+			Repository:    model.Repository{URL: "https://github.com/example/weather", Source: "github"},
+			VersionDetail: model.VersionDetail{Version: "1.0.0"},
+		},
+	}))
+	serverDetail, err := registry.GetByName(context.Background(), "io.github.example/weather")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(router.NewHandler(&config.Config{}, registry, nil, events.NewHub(), nil))
+	defer server.Close()
+
+	do := func(t *testing.T, method, path string) (*http.Response, []byte) {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), method, server.URL+path, nil)
+		require.NoError(t, err)
+		// Uncompressed, so the Content-Length is the size of the JSON body
+		req.Header.Set("Accept-Encoding", "identity")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.NotEmpty(t, resp.Header.Get(middleware.RequestIDHeader))
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp, body
+	}
+
+	t.Run("existing server", func(t *testing.T) {
+		get, getBody := do(t, http.MethodGet, "/v0/servers/"+serverDetail.ID)
+		require.Equal(t, http.StatusOK, get.StatusCode)
+		assert.Equal(t, int64(len(getBody)), get.ContentLength)
+
+		head, headBody := do(t, http.MethodHead, "/v0/servers/"+serverDetail.ID)
+		assert.Equal(t, http.StatusOK, head.StatusCode)
+		assert.Empty(t, headBody)
+		// The generation time in the body drops trailing zeros of its fraction of a second
+		assert.InDelta(t, len(getBody), head.ContentLength, 9)
+		assert.Equal(t, get.Header.Get("ETag"), head.Header.Get("ETag"))
+		assert.Equal(t, "application/json", head.Header.Get("Content-Type"))
+	})
+
+	t.Run("unknown server", func(t *testing.T) {
+		head, headBody := do(t, http.MethodHead, "/v0/servers/"+uuid.New().String())
+		assert.Equal(t, http.StatusNotFound, head.StatusCode)
+		assert.Empty(t, headBody)
+	})
+}
+
+func TestServersDetailHandlerConditionalGet(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	serverDetail := &model.ServerDetail{
+		Server: model.Server{
+			Name:          "io.github.example/weather",
+			Repository:    model.Repository{URL: "https://github.com/example/weather", Source: "github"},
+			VersionDetail: model.VersionDetail{Version: "1.0.0"},
+		},
+	}
+	require.NoError(t, registry.Publish(serverDetail))
+
+	get := func(t *testing.T, ifNoneMatch string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers/"+serverDetail.ID, nil)
+		require.NoError(t, err)
+		req.SetPathValue("id", serverDetail.ID)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.ServersDetailHandler(registry, nil), rr, req)
+		return rr
+	}
+
+	rr := get(t, "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	etag := rr.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	t.Run("matching ETag is not modified", func(t *testing.T) {
+		for _, ifNoneMatch := range []string{etag, `"other", ` + etag, "W/" + etag, "*"} {
+			rr := get(t, ifNoneMatch)
+			assert.Equal(t, http.StatusNotModified, rr.Code, ifNoneMatch)
+			assert.Empty(t, rr.Body.String())
+			assert.Equal(t, etag, rr.Header().Get("ETag"))
+		}
+	})
+
+	t.Run("other ETags get the details", func(t *testing.T) {
+		rr := get(t, `"other"`)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, etag, rr.Header().Get("ETag"))
+	})
+
+	t.Run("changes invalidate the ETag", func(t *testing.T) {
+		_, err := registry.AddTags(context.Background(), serverDetail.ID, []string{"weather"})
+		require.NoError(t, err)
+
+		rr := get(t, etag)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.NotEqual(t, etag, rr.Header().Get("ETag"))
+	})
+}
+
+// TestServerMetadataHandler tests that the metadata endpoint matches the detail endpoint without packages
+func TestServerMetadataHandler(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	dependencyID := publishWithSchema(t, registry, "metadata-dependency", nil)
+
+	schema := json.RawMessage(`{"type":"object","properties":{"path":{"type":"string"}}}`)
+	serverDetail := &model.ServerDetail{
+		Server: model.Server{
+			Name:        "io.github.example/metadata-server",
+			Description: "Metadata test server",
+			Repository: model.Repository{
+				URL:    "https://github.com/example/metadata-server",
+				Source: "github",
+				ID:     "example/metadata-server",
+			},
+			VersionDetail: model.VersionDetail{
+				Version: "1.0.0",
+			},
+			Category:       model.CategoryFilesystem,
+			Language:       "typescript",
+			Topics:         []string{"files"},
+			Tags:           []string{"storage"},
+			License:        "MIT",
+			Maintainers:    []model.Maintainer{{Name: "Example", GitHubUsername: "example"}},
+			MinMCPVersion:  "1.0.0",
+			ContactEmail:   "maintainer@example.com",
+			HealthCheckURL: "https://example.com/health",
+		},
+		Packages: []model.Package{
+			{RegistryName: "npm", Name: "@example/metadata-server", Version: "1.0.0"},
+		},
+		Remotes: []model.Remote{
+			{TransportType: "sse", URL: "https://example.com/sse"},
+		},
+		Schema:       &schema,
+		Tools:        []model.MCPTool{{Name: "read_file", Description: "Reads a file", InputSchema: schema}},
+		Dependencies: []string{dependencyID},
+	}
+	require.NoError(t, registry.Publish(serverDetail))
+	rr := submitReview(t, registry, reviewer("1"), serverDetail.ID, v0.ReviewRequest{Rating: 4})
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+	serve := func(handler http.HandlerFunc, id string) *httptest.ResponseRecorder {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers/"+id, nil)
+		require.NoError(t, err)
+		req.SetPathValue("id", id)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, handler, rr, req)
+		return rr
+	}
+
+	t.Run("response has no packages and otherwise matches detail", func(t *testing.T) {
+		detailResp := serve(v0.ServersDetailHandler(registry, nil), serverDetail.ID)
+		metaResp := serve(v0.ServerMetadataHandler(registry), serverDetail.ID)
+		require.Equal(t, http.StatusOK, detailResp.Code)
+		require.Equal(t, http.StatusOK, metaResp.Code)
+		assert.Equal(t, "application/json", metaResp.Header().Get("Content-Type"))
+
+		var detail, meta v0.ResponseEnvelope[map[string]interface{}]
+		require.NoError(t, json.Unmarshal(detailResp.Body.Bytes(), &detail))
+		require.NoError(t, json.Unmarshal(metaResp.Body.Bytes(), &meta))
+
+		for _, key := range []string{"tools", "has_schema", "dependencies", "install_score", "average_rating", "review_count"} {
+			assert.NotEmpty(t, detail.Data[key], key)
+		}
+		assert.Contains(t, detail.Data, "packages")
+		assert.NotContains(t, meta.Data, "packages")
+
+		delete(detail.Data, "packages")
+		assert.Equal(t, detail.Data, meta.Data)
+	})
+
+	t.Run("server not found", func(t *testing.T) {
+		rr := serve(v0.ServerMetadataHandler(registry), uuid.New().String())
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Server not found")
+	})
+
+	t.Run("invalid server ID", func(t *testing.T) {
+		rr := serve(v0.ServerMetadataHandler(registry), "not-a-uuid")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Invalid server ID format")
+	})
+}
+
+// TestServersHandlerPaginationConsistency verifies that servers published between page requests
+// are neither skipped nor duplicated
+func TestServersHandlerPaginationConsistency(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+
+	publish := func(name string) {
+		require.NoError(t, registry.Publish(&model.ServerDetail{
+			Server: model.Server{
+				Name:          name,
+				Repository:    model.Repository{URL: "https://github.com/" + name, Source: "github"},
+				VersionDetail: model.VersionDetail{Version: "1.0.0"},
+			},
+		}))
+	}
+
+	listPage := func(cursor string) v0.PaginatedResponse {
+		target := "/v0/servers?limit=2"
+		if cursor != "" {
+			target += "&cursor=" + cursor
+		}
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, target, nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.ServersHandler(registry), rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp v0.PaginatedResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		return resp
+	}
+
+	for i := 0; i < 4; i++ {
+		publish(fmt.Sprintf("example/server-%d", i))
+	}
+
+	firstPage := listPage("")
+	require.Len(t, firstPage.Data, 2)
+	require.NotNil(t, firstPage.Metadata)
+
+	// Publish more servers mid-traversal; their random IDs may sort before the cursor
+	for i := 4; i < 8; i++ {
+		publish(fmt.Sprintf("example/server-%d", i))
+	}
+
+	seen := make(map[string]bool)
+	var names []string
+	for _, server := range firstPage.Data {
+		seen[server.ID] = true
+		names = append(names, server.Name)
+	}
+
+	cursor := firstPage.Metadata.NextCursor
+	for cursor != "" {
+		page := listPage(cursor)
+		for _, server := range page.Data {
+			assert.False(t, seen[server.ID], "server %s returned twice", server.Name)
+			seen[server.ID] = true
+			names = append(names, server.Name)
+		}
+		cursor = ""
+		if page.Metadata != nil {
+			cursor = page.Metadata.NextCursor
+		}
+	}
+
+	expected := make([]string, 8)
+	for i := range expected {
+		expected[i] = fmt.Sprintf("example/server-%d", i)
+	}
+	assert.Equal(t, expected, names)
+}
+
+func TestServersHandlerBackwardPagination(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	for i := 0; i < 5; i++ {
+		name := fmt.Sprintf("example/server-%d", i)
+		require.NoError(t, registry.Publish(&model.ServerDetail{
+			Server: model.Server{
+				Name:          name,
+				Repository:    model.Repository{URL: "https://github.com/" + name, Source: "github"},
+				VersionDetail: model.VersionDetail{Version: "1.0.0"},
+			},
+		}))
+	}
+
+	listPage := func(t *testing.T, query string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers?limit=2"+query, nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.ServersHandler(registry), rr, req)
+		return rr
+	}
+
+	page := func(t *testing.T, query string) ([]string, *v0.Metadata) {
+		t.Helper()
+		rr := listPage(t, query)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var resp v0.PaginatedResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		require.NotNil(t, resp.Metadata)
+		names := make([]string, len(resp.Data))
+		for i, server := range resp.Data {
+			names[i] = server.Name
+		}
+		return names, resp.Metadata
+	}
+
+	t.Run("forward then backward navigation returns the same pages", func(t *testing.T) {
+		var forward [][]string
+		names, metadata := page(t, "")
+		assert.Empty(t, metadata.PrevCursor, "first page has no previous page")
+		forward = append(forward, names)
+		for metadata.NextCursor != "" {
+			require.Less(t, len(forward), 10, "pagination did not terminate")
+			names, metadata = page(t, "&cursor="+metadata.NextCursor)
+			forward = append(forward, names)
+		}
+		require.Equal(t, [][]string{
+			{"example/server-0", "example/server-1"},
+			{"example/server-2", "example/server-3"},
+			{"example/server-4"},
+		}, forward)
+
+		for i := len(forward) - 2; i >= 0; i-- {
+			require.NotEmpty(t, metadata.PrevCursor)
+			names, metadata = page(t, "&cursor_direction=prev&cursor="+metadata.PrevCursor)
+			assert.Equal(t, forward[i], names)
+			assert.NotEmpty(t, metadata.NextCursor)
+		}
+		assert.Empty(t, metadata.PrevCursor, "first page has no previous page")
+	})
+
+	t.Run("next is the default direction", func(t *testing.T) {
+		_, metadata := page(t, "")
+		names, _ := page(t, "&cursor_direction=next&cursor="+metadata.NextCursor)
+		assert.Equal(t, []string{"example/server-2", "example/server-3"}, names)
+	})
+
+	t.Run("invalid direction", func(t *testing.T) {
+		rr := listPage(t, "&cursor_direction=sideways")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Invalid cursor_direction parameter")
+	})
+
+	t.Run("paging backwards needs a cursor", func(t *testing.T) {
+		rr := listPage(t, "&cursor_direction=prev")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestServersHandlerMinimalFormat(t *testing.T) {
+	servers := []model.Server{
+		{
+			ID:          "550e8400-e29b-41d4-a716-446655440001",
+			Name:        "test-server-1",
+			Description: "First test server",
+			Repository: model.Repository{
+				URL:    "https://github.com/example/test-server-1",
+				Source: "github",
+				ID:     "example/test-server-1",
+			},
+			VersionDetail: model.VersionDetail{
+				Version: "1.0.0",
+			},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		queryParams   string
+		expectedLimit int
+	}{
+		{
+			name:          "default limit",
+			queryParams:   "?format=minimal",
+			expectedLimit: 30,
+		},
+		{
+			name:          "limit above the standard cap",
+			queryParams:   "?format=minimal&limit=300",
+			expectedLimit: 300,
+		},
+		{
+			name:          "limit capped at 500",
+			queryParams:   "?format=minimal&limit=1000",
+			expectedLimit: 500,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRegistry := new(MockRegistryService)
+			mockRegistry.Mock.On("List", "", "", database.CursorNext, tc.expectedLimit).
+				Return(servers, database.PageCursors{Next: database.EncodeCursor(1)}, nil)
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers"+tc.queryParams, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			serveHTTP(t, v0.ServersHandler(mockRegistry), rr, req)
+
+			require.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+			var raw struct {
+				Servers []map[string]any `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &raw))
+			require.Len(t, raw.Servers, 1)
+			assert.Equal(t, map[string]any{"id": servers[0].ID, "name": servers[0].Name}, raw.Servers[0])
+
+			var resp v0.MinimalPaginatedResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			require.NotNil(t, resp.Metadata)
+			assert.Equal(t, database.EncodeCursor(1), resp.Metadata.NextCursor)
+			assert.Equal(t, 1, resp.Metadata.Count)
+
+			mockRegistry.Mock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestServersHandlerStandardFormatLimitCap(t *testing.T) {
+	mockRegistry := new(MockRegistryService)
+	mockRegistry.Mock.On("List", "", "", database.CursorNext, 100).Return([]model.Server{}, database.PageCursors{}, nil)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers?limit=500", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.ServersHandler(mockRegistry), rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockRegistry.Mock.AssertExpectations(t)
+}
+
+func TestServersHandlerSort(t *testing.T) {
+	newServer := func(id, name string, publishedAt, updatedAt time.Time) *model.Server {
+		return &model.Server{
+			ID:            id,
+			Name:          name,
+			Repository:    model.Repository{URL: "https://github.com/" + name, Source: "github"},
+			VersionDetail: model.VersionDetail{Version: "1.0.0", IsLatest: true},
+			PublishedAt:   publishedAt,
+			UpdatedAt:     updatedAt,
+		}
+	}
+
+	// Creation, name, publication and update orders all differ
+	now := time.Now().UTC()
+	db := database.NewMemoryDB(map[string]*model.Server{
+		"1": newServer("1", "example/bravo", now.Add(-time.Hour), now),
+		"2": newServer("2", "example/charlie", now.Add(-2*time.Hour), now.Add(-time.Hour)),
+		"3": newServer("3", "example/alpha", now, now.Add(-2*time.Hour)),
+	})
+	registry := service.NewRegistryServiceWithDB(db)
+
+	list := func(t *testing.T, query string) ([]string, *v0.Metadata) {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers"+query, nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.ServersHandler(registry), rr, req)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var resp v0.PaginatedResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		var names []string
+		for _, server := range resp.Data {
+			names = append(names, server.Name)
+		}
+		return names, resp.Metadata
+	}
+
+	sorts := map[string][]string{
+		"name":          {"example/alpha", "example/bravo", "example/charlie"},
+		"-name":         {"example/charlie", "example/bravo", "example/alpha"},
+		"published_at":  {"example/charlie", "example/bravo", "example/alpha"},
+		"-published_at": {"example/alpha", "example/bravo", "example/charlie"},
+		"updated_at":    {"example/alpha", "example/charlie", "example/bravo"},
+		"-updated_at":   {"example/bravo", "example/charlie", "example/alpha"},
+	}
+	for sortBy, expected := range sorts {
+		t.Run(sortBy, func(t *testing.T) {
+			names, _ := list(t, "?sort="+sortBy)
+			assert.Equal(t, expected, names)
+		})
+	}
+
+	t.Run("paginates in sort order", func(t *testing.T) {
+		first, metadata := list(t, "?sort=-name&limit=2")
+		assert.Equal(t, []string{"example/charlie", "example/bravo"}, first)
+		require.NotNil(t, metadata)
+
+		second, metadata := list(t, "?sort=-name&limit=2&cursor="+metadata.NextCursor)
+		assert.Equal(t, []string{"example/alpha"}, second)
+		require.NotNil(t, metadata)
+		assert.Empty(t, metadata.NextCursor)
+
+		previous, _ := list(t, "?sort=-name&limit=2&cursor_direction=prev&cursor="+metadata.PrevCursor)
+		assert.Equal(t, first, previous)
+	})
+
+	t.Run("rejects unknown sort value", func(t *testing.T) {
+		for _, sortBy := range []string{"stars", "relevance", "--name", "-"} {
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers?sort="+sortBy, nil)
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			serveHTTP(t, v0.ServersHandler(registry), rr, req)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, sortBy)
+			assert.Contains(t, rr.Body.String(), "Invalid sort parameter")
+		}
+	})
+}
+
+func TestServersHandlerIncludeTotal(t *testing.T) {
+	servers := make(map[string]*model.Server)
+	for _, id := range []string{"1", "2", "3"} {
+		servers[id] = &model.Server{
+			ID:            id,
+			Name:          "example/server-" + id,
+			Repository:    model.Repository{URL: "https://github.com/example/server-" + id, Source: "github"},
+			VersionDetail: model.VersionDetail{Version: "1.0.0", IsLatest: true},
+		}
+	}
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(servers))
+
+	list := func(t *testing.T, query string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers"+query, nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.ServersHandler(registry), rr, req)
+		return rr
+	}
+
+	metadata := func(t *testing.T, rr *httptest.ResponseRecorder) *v0.Metadata {
+		t.Helper()
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var resp v0.PaginatedResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		return resp.Metadata
+	}
+
+	t.Run("counts all pages", func(t *testing.T) {
+		md := metadata(t, list(t, "?limit=2&include_total=true"))
+		require.NotNil(t, md)
+		require.NotNil(t, md.TotalCount)
+		assert.Equal(t, 3, *md.TotalCount)
+		assert.Equal(t, 2, md.Count)
+	})
+
+	t.Run("adds metadata to single pages", func(t *testing.T) {
+		md := metadata(t, list(t, "?include_total=true&format=minimal"))
+		require.NotNil(t, md)
+		require.NotNil(t, md.TotalCount)
+		assert.Equal(t, 3, *md.TotalCount)
+	})
+
+	t.Run("is not counted by default", func(t *testing.T) {
+		md := metadata(t, list(t, "?limit=2"))
+		require.NotNil(t, md)
+		assert.Nil(t, md.TotalCount)
+	})
+
+	t.Run("rejects invalid value", func(t *testing.T) {
+		rr := list(t, "?include_total=maybe")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Invalid include_total parameter")
+	})
+}
+
+func TestServersHandlerInvalidFormat(t *testing.T) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers?format=full", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.ServersHandler(new(MockRegistryService)), rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "Invalid format parameter")
+}
+
+func TestServersHandlerUpdatedAfter(t *testing.T) {
+	base := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	newServers := func() map[string]*model.Server {
+		return map[string]*model.Server{
+			"a": {ID: "a", Name: "io.github.example/a", UpdatedAt: base},
+			"b": {ID: "b", Name: "io.github.example/b", UpdatedAt: base.Add(2 * time.Minute)},
+			"c": {ID: "c", Name: "io.github.example/c", UpdatedAt: base.Add(time.Minute)},
+			"d": {ID: "d", Name: "io.github.example/d", UpdatedAt: base.Add(-time.Minute)},
+		}
+	}
+
+	listUpdatedAfter := func(t *testing.T, registry service.RegistryService, query string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers?"+query, nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.ServersHandler(registry), rr, req)
+		return rr
+	}
+
+	t.Run("returns only servers updated after the timestamp, oldest update first", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(newServers()))
+
+		// "a" was updated exactly at the boundary and is excluded
+		rr := listUpdatedAfter(t, registry, "updated_after="+url.QueryEscape(base.Format(time.RFC3339)))
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp v0.PaginatedResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		require.Len(t, resp.Data, 2)
+		assert.Equal(t, "c", resp.Data[0].ID)
+		assert.Equal(t, "b", resp.Data[1].ID)
+
+		require.NotNil(t, resp.Metadata)
+		assert.Equal(t, 2, resp.Metadata.Count)
+		syncedUntil, _, err := database.DecodeSyncCursor(resp.Metadata.SyncCursor)
+		require.NoError(t, err)
+		assert.True(t, base.Add(2*time.Minute).Equal(syncedUntil))
+	})
+
+	t.Run("sync cursor continues from the last page", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(newServers()))
+
+		rr := listUpdatedAfter(t, registry, "limit=1&updated_after="+url.QueryEscape(base.Format(time.RFC3339)))
+		require.Equal(t, http.StatusOK, rr.Code)
+		var first v0.PaginatedResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &first))
+		require.Len(t, first.Data, 1)
+		assert.Equal(t, "c", first.Data[0].ID)
+
+		rr = listUpdatedAfter(t, registry, "limit=1&updated_after="+url.QueryEscape(first.Metadata.SyncCursor))
+		require.Equal(t, http.StatusOK, rr.Code)
+		var second v0.PaginatedResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &second))
+		require.Len(t, second.Data, 1)
+		assert.Equal(t, "b", second.Data[0].ID)
+	})
+
+	t.Run("sync cursor pages through versions updated together", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		start := time.Now().Add(-time.Minute)
+		published := map[string]bool{}
+		for _, version := range []string{"1.0.0", "1.1.0", "1.2.0", "2.0.0", "2.1.0"} {
+			published[publishVersion(t, registry, version)] = true
+		}
+
+		// Publishing a version also updates the older ones, so most versions share an update time
+		synced := map[string]bool{}
+		updatedAfter := start.Format(time.RFC3339)
+		for range published {
+			rr := listUpdatedAfter(t, registry, "limit=2&updated_after="+url.QueryEscape(updatedAfter))
+			require.Equal(t, http.StatusOK, rr.Code)
+			var resp v0.PaginatedResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			if len(resp.Data) == 0 {
+				break
+			}
+			for _, server := range resp.Data {
+				assert.False(t, synced[server.ID], "version %s synced twice", server.VersionDetail.Version)
+				synced[server.ID] = true
+			}
+			updatedAfter = resp.Metadata.SyncCursor
+		}
+		assert.Equal(t, published, synced)
+	})
+
+	t.Run("no updates echoes the timestamp as sync cursor", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(newServers()))
+		after := base.Add(time.Hour)
+
+		rr := listUpdatedAfter(t, registry, "updated_after="+url.QueryEscape(after.Format(time.RFC3339)))
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp v0.PaginatedResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Empty(t, resp.Data)
+		syncedUntil, _, err := database.DecodeSyncCursor(resp.Metadata.SyncCursor)
+		require.NoError(t, err)
+		assert.True(t, after.Equal(syncedUntil))
+
+		// Passing the sync cursor back still lists nothing
+		rr = listUpdatedAfter(t, registry, "updated_after="+url.QueryEscape(resp.Metadata.SyncCursor))
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Empty(t, resp.Data)
+	})
+
+	t.Run("modifications update the timestamp", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersion(t, registry, "1.0.0")
+		before := getServer(t, registry, id).UpdatedAt
+		require.False(t, before.IsZero())
+
+		// Ensure the next update gets a later millisecond timestamp
+		time.Sleep(2 * time.Millisecond)
+		_, err := registry.AppendPackages(context.Background(), id, []model.Package{{RegistryName: "npm", Name: "example"}})
+		require.NoError(t, err)
+
+		rr := listUpdatedAfter(t, registry, "updated_after="+url.QueryEscape(before.Format(time.RFC3339Nano)))
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp v0.PaginatedResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		require.Len(t, resp.Data, 1)
+		assert.Equal(t, id, resp.Data[0].ID)
+		assert.True(t, resp.Data[0].UpdatedAt.After(before))
+	})
+
+	t.Run("invalid timestamp", func(t *testing.T) {
+		rr := listUpdatedAfter(t, new(MockRegistryService), "updated_after=yesterday")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Invalid updated_after parameter")
+	})
+
+	t.Run("cannot be combined with cursor", func(t *testing.T) {
+		query := "cursor=" + database.EncodeCursor(1) + "&updated_after=" + url.QueryEscape(base.Format(time.RFC3339))
+		rr := listUpdatedAfter(t, new(MockRegistryService), query)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestServersDetailHandlerVersionNegotiation(t *testing.T) {
+	serverID := uuid.New().String()
+	serverDetail := &model.ServerDetail{
+		Server: model.Server{
+			ID:            serverID,
+			Name:          "io.github.example/versioned",
+			VersionDetail: model.VersionDetail{Version: "1.0.0", IsLatest: true},
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		version        string
+		expectedStatus int
+	}{
+		{name: "no version header", expectedStatus: http.StatusOK},
+		{name: "supported version", version: "v0", expectedStatus: http.StatusOK},
+		{name: "unsupported version", version: "v1", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRegistry := new(MockRegistryService)
+			mockRegistry.Mock.On("GetByID", serverID).Return(serverDetail, nil)
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers/"+serverID, nil)
+			require.NoError(t, err)
+			req.SetPathValue("id", serverID)
+			if tc.version != "" {
+				req.Header.Set(versioning.HeaderName, tc.version)
+			}
+			rr := httptest.NewRecorder()
+
+			serveHTTP(t, versioning.SetVersionHeader(v0.ServersDetailHandler(mockRegistry, nil)), rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			assert.Equal(t, "v0", rr.Header().Get(versioning.HeaderName))
+			if tc.expectedStatus == http.StatusOK {
+				var resp v0.ResponseEnvelope[model.ServerDetail]
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+				assert.Equal(t, "v0", resp.APIVersion)
+			} else {
+				assert.Contains(t, rr.Body.String(), "unsupported API version")
+			}
+		})
+	}
+}
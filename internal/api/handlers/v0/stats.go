@@ -0,0 +1,34 @@
+package v0
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// StatsResponse is the response of the stats endpoint. Fields are only ever added to the stats of v0.
+type StatsResponse = ResponseEnvelope[model.RegistryStats]
+
+// StatsHandler returns a handler for aggregate metrics of the registry
+func StatsHandler(registry service.RegistryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		generatedAt := time.Now().UTC()
+
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		stats, err := registry.Stats()
+		if err != nil {
+			middleware.Logf(r.Context(), "Error computing registry stats: %v", err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to compute registry stats"}, http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, NewResponseEnvelope(stats, generatedAt))
+	}
+}
@@ -0,0 +1,124 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifiedChecksums(t *testing.T) {
+	db := database.NewMemoryDB(map[string]*model.Server{})
+	registry := service.NewRegistryServiceWithDB(db)
+	for _, name := range []string{"verified-server", "unverified-server"} {
+		serverDetail := &model.ServerDetail{
+			Server: model.Server{
+				Name:          "io.github.example/" + name,
+				Repository:    model.Repository{URL: "https://github.com/example/" + name, Source: "github"},
+				VersionDetail: model.VersionDetail{Version: "1.0.0"},
+			},
+			Packages: []model.Package{{RegistryName: "npm", Name: "@example/" + name, Version: "1.0.0"}},
+		}
+		require.NoError(t, registry.Publish(serverDetail))
+		if name == "verified-server" {
+			require.NoError(t, db.SetPackageChecksum(context.Background(), serverDetail.ID, "npm", "@example/"+name, "abc123"))
+		}
+	}
+
+	t.Run("search by verified checksum", func(t *testing.T) {
+		for query, expectedNames := range map[string][]string{
+			"?has_verified_checksum=true":                {"io.github.example/verified-server"},
+			"?has_verified_checksum=true&format=minimal": {"io.github.example/verified-server"},
+			"?has_verified_checksum=false":               {"io.github.example/verified-server", "io.github.example/unverified-server"},
+		} {
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/search"+query, nil)
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			serveHTTP(t, v0.SearchHandler(registry, &config.Config{}), rr, req)
+			require.Equal(t, http.StatusOK, rr.Code, query)
+
+			var resp v0.ResponseEnvelope[[]model.ServerMinimal]
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			names := make([]string, len(resp.Data))
+			for i, server := range resp.Data {
+				names[i] = server.Name
+			}
+			assert.ElementsMatch(t, expectedNames, names, query)
+		}
+	})
+
+	t.Run("invalid filter value", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/search?has_verified_checksum=maybe", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.SearchHandler(registry, &config.Config{}), rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("stats count servers with verified checksums", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/stats", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.StatsHandler(registry), rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp v0.ResponseEnvelope[model.RegistryStats]
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		assert.Equal(t, 1, resp.Data.VerifiedChecksumCount)
+	})
+}
+
+func TestStatsHandler(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	now := time.Now().UTC()
+	for _, server := range []struct {
+		name, version string
+		publishedAt   time.Time
+		registries    []string
+	}{
+		{"io.github.alice/first", "1.0.0", now.Add(-40 * 24 * time.Hour), []string{"npm"}},
+		{"io.github.alice/first", "1.1.0", now.Add(-2 * time.Hour), []string{"npm", "npm", "docker"}},
+		{"io.github.Alice/second", "1.0.0", now.Add(-3 * 24 * time.Hour), []string{"pypi"}},
+		{"io.github.bob/third", "1.0.0", now.Add(-20 * 24 * time.Hour), []string{"npm"}},
+		{"com.example/unowned", "1.0.0", time.Time{}, nil},
+	} {
+		serverDetail := &model.ServerDetail{
+			Server: model.Server{
+				Name:          server.name,
+				Repository:    model.Repository{URL: "https://github.com/example/" + server.name, Source: "github"},
+				VersionDetail: model.VersionDetail{Version: server.version},
+				PublishedAt:   server.publishedAt,
+			},
+		}
+		for _, registryName := range server.registries {
+			serverDetail.Packages = append(serverDetail.Packages,
+				model.Package{RegistryName: registryName, Name: "example-" + registryName, Version: server.version})
+		}
+		require.NoError(t, registry.Publish(serverDetail))
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/stats", nil)
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.StatsHandler(registry), rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp v0.StatsResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Equal(t, v0.APIVersion, resp.APIVersion)
+	assert.Equal(t, 4, resp.Data.ServerCount)
+	assert.Equal(t, map[string]int{"npm": 2, "docker": 1, "pypi": 1}, resp.Data.ServersByRegistry)
+	assert.Equal(t, model.PublishedCounts{Last24Hours: 1, Last7Days: 2, Last30Days: 3}, resp.Data.Published)
+	// Namespace owners are compared case-insensitively and servers outside io.github have no publisher
+	assert.Equal(t, 2, resp.Data.PublisherCount)
+}
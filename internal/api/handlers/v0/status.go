@@ -0,0 +1,83 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// StatusRequest represents the request body for changing the lifecycle status of a server
+type StatusRequest struct {
+	Status string `json:"status"`
+}
+
+// StatusHandler handles requests to change the lifecycle status of a server: active, deprecated or archived.
+// The status covers all versions of the server. Only the current publisher, one of its maintainers or the
+// registry owner may change it, and only the registry owner may change the status of an archived server.
+func StatusHandler(registry service.RegistryService, authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Only allow PATCH method
+		if r.Method != http.MethodPatch {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Extract the server ID from the URL path
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		// Parse request body
+		var req StatusRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidPayload, Message: "Invalid request payload: " + err.Error()}, http.StatusBadRequest)
+			return
+		}
+		defer r.Body.Close()
+
+		if !model.IsValidStatus(req.Status) {
+			WriteError(w, APIError{
+				Code:    ErrCodeInvalidRequest,
+				Message: "Status must be one of active, deprecated or archived",
+			}, http.StatusBadRequest)
+			return
+		}
+
+		serverDetail, claims, ok := authorizePublisherClaims(w, r, registry, authService, id)
+		if !ok || !checkIfMatch(w, r, serverDetail) {
+			return
+		}
+
+		if err := registry.UpdateStatus(r.Context(), id, req.Status, claims == nil); err != nil {
+			switch {
+			case errors.Is(err, database.ErrNotFound):
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Server not found"}, http.StatusNotFound)
+			case errors.Is(err, database.ErrStatusTransition), errors.Is(err, database.ErrInvalidStatus):
+				WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: err.Error()}, http.StatusBadRequest)
+			default:
+				middleware.Logf(r.Context(), "status: Failed to set status of server %s to %s: %v", id, req.Status, err)
+				WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to update status: " + err.Error()}, http.StatusInternalServerError)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(map[string]any{
+			"message": "Status updated",
+			"name":    serverDetail.Name,
+			"status":  req.Status,
+		}); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to encode response"}, http.StatusInternalServerError)
+			return
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// setStatus calls the status handler as the user described by claims, or as the registry owner if claims is nil
+func setStatus(
+	t *testing.T, registry service.RegistryService, claims *auth.EphemeralTokenClaims, id, status string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+	mockAuthService := new(MockAuthService)
+	mockAuthService.Mock.On("ValidateEphemeralOrOwnerToken", mock.Anything, "test-token").Return(true, claims, nil)
+
+	body, err := json.Marshal(v0.StatusRequest{Status: status})
+	require.NoError(t, err)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPatch, "/v0/servers/"+id+"/status", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer test-token")
+	req.SetPathValue("id", id)
+
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.StatusHandler(registry, mockAuthService), rr, req)
+	return rr
+}
+
+// searchStatus returns the names of the servers the search handler returns for the status filter
+func searchStatus(t *testing.T, registry service.RegistryService, status string) []string {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/search?status="+status, nil)
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.SearchHandler(registry, &config.Config{}), rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp v0.ResponseEnvelope[[]model.ServerDetail]
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	names := make([]string, len(resp.Data))
+	for i, server := range resp.Data {
+		names[i] = server.Name
+	}
+	return names
+}
+
+func TestStatusHandler(t *testing.T) {
+	publisherClaims := &auth.EphemeralTokenClaims{GitHubUsername: "example"}
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	publish := func(name, version string) string {
+		serverDetail := &model.ServerDetail{
+			Server: model.Server{
+				Name:          name,
+				Repository:    model.Repository{URL: "https://github.com/" + name, Source: "github"},
+				VersionDetail: model.VersionDetail{Version: version},
+				PublishedBy:   "example",
+			},
+		}
+		require.NoError(t, registry.Publish(serverDetail))
+		return serverDetail.ID
+	}
+	firstID := publish("io.github.example/lifecycle", "1.0.0")
+	latestID := publish("io.github.example/lifecycle", "1.1.0")
+	publish("io.github.example/other", "1.0.0")
+
+	t.Run("servers are active when published", func(t *testing.T) {
+		assert.Equal(t, model.StatusActive, getServer(t, registry, latestID).Status)
+		assert.ElementsMatch(t, []string{
+			"io.github.example/lifecycle", "io.github.example/lifecycle", "io.github.example/other",
+		}, searchStatus(t, registry, model.StatusActive))
+	})
+
+	t.Run("publisher deprecates all versions", func(t *testing.T) {
+		rr := setStatus(t, registry, publisherClaims, latestID, model.StatusDeprecated)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Equal(t, model.StatusDeprecated, getServer(t, registry, firstID).Status)
+		assert.Equal(t, []string{"io.github.example/lifecycle", "io.github.example/lifecycle"},
+			searchStatus(t, registry, model.StatusDeprecated))
+		assert.Equal(t, []string{"io.github.example/other"}, searchStatus(t, registry, model.StatusActive))
+	})
+
+	t.Run("new versions keep the status", func(t *testing.T) {
+		id := publish("io.github.example/lifecycle", "1.2.0")
+		assert.Equal(t, model.StatusDeprecated, getServer(t, registry, id).Status)
+	})
+
+	t.Run("only the registry owner reactivates archived servers", func(t *testing.T) {
+		rr := setStatus(t, registry, publisherClaims, latestID, model.StatusArchived)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		rr = setStatus(t, registry, publisherClaims, latestID, model.StatusActive)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, model.StatusArchived, getServer(t, registry, latestID).Status)
+
+		rr = setStatus(t, registry, nil, latestID, model.StatusActive)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Equal(t, model.StatusActive, getServer(t, registry, latestID).Status)
+	})
+
+	t.Run("other users are rejected", func(t *testing.T) {
+		rr := setStatus(t, registry, &auth.EphemeralTokenClaims{GitHubUsername: "someone-else"}, latestID, model.StatusArchived)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("ownership follows transfers and maintainers", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersionBy(t, registry, "1.0.0", "example")
+
+		rr := transferServer(t, registry, publisherClaims, id, "new-owner", true, nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+		newOwnerClaims := &auth.EphemeralTokenClaims{GitHubUsername: "new-owner"}
+		rr = addMaintainer(t, registry, newOwnerClaims, id, "co-maintainer", true)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		rr = setStatus(t, registry, publisherClaims, id, model.StatusArchived)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		rr = setStatus(t, registry, &auth.EphemeralTokenClaims{GitHubUsername: "co-maintainer"}, id, model.StatusDeprecated)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		rr = setStatus(t, registry, newOwnerClaims, id, model.StatusArchived)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Equal(t, model.StatusArchived, getServer(t, registry, id).Status)
+	})
+
+	t.Run("unknown statuses are rejected", func(t *testing.T) {
+		rr := setStatus(t, registry, publisherClaims, latestID, "retired")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/search?status=retired", nil)
+		require.NoError(t, err)
+		rr = httptest.NewRecorder()
+		serveHTTP(t, v0.SearchHandler(registry, &config.Config{}), rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("rejects other methods", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/servers/"+latestID+"/status", nil)
+		require.NoError(t, err)
+		req.SetPathValue("id", latestID)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.StatusHandler(registry, new(MockAuthService)), rr, req)
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
@@ -0,0 +1,203 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/events"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// SubscriptionRequest represents the request body for subscribing a webhook to a server
+type SubscriptionRequest struct {
+	ServerID   string                    `json:"server_id"`
+	WebhookURL string                    `json:"webhook_url"`
+	Events     []model.SubscriptionEvent `json:"events"`
+}
+
+// SubscriptionsHandler handles requests to subscribe a webhook to the changes of a server.
+// Subscribing a webhook URL the server is already subscribed to updates the events of the existing subscription.
+func SubscriptionsHandler(registry service.RegistryService, authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		generatedAt := time.Now().UTC()
+
+		// Only allow POST method
+		if r.Method != http.MethodPost {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		claims, ok := authenticateSubscriber(w, r, authService)
+		if !ok {
+			return
+		}
+
+		// Parse request body
+		var req SubscriptionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidPayload, Message: "Invalid request payload: " + err.Error()}, http.StatusBadRequest)
+			return
+		}
+		defer r.Body.Close()
+
+		if _, err := uuid.Parse(req.ServerID); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		subscription := &model.Subscription{
+			ServerID:       req.ServerID,
+			WebhookURL:     req.WebhookURL,
+			Events:         req.Events,
+			GitHubUserID:   claims.GitHubUserID,
+			GitHubUsername: claims.GitHubUsername,
+		}
+		created, err := registry.SaveSubscription(r.Context(), subscription)
+		if err != nil {
+			switch {
+			case errors.Is(err, database.ErrInvalidWebhook):
+				WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: err.Error()}, http.StatusBadRequest)
+			case errors.Is(err, database.ErrNotFound):
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Server not found"}, http.StatusNotFound)
+			case errors.Is(err, database.ErrAlreadyExists):
+				WriteError(w, APIError{
+					Code:    ErrCodeConflict,
+					Message: "The server is already subscribed to this webhook by another user",
+				}, http.StatusConflict)
+			case errors.Is(err, database.ErrTooManyWebhooks):
+				WriteError(w, APIError{Code: ErrCodeRateLimited, Message: "Subscription limit reached"}, http.StatusTooManyRequests)
+			default:
+				middleware.Logf(r.Context(), "subscriptions: Failed to subscribe to server %s: %v", req.ServerID, err)
+				WriteError(w, APIError{
+					Code:    ErrCodeInternal,
+					Message: "Failed to save subscription: " + err.Error(),
+				}, http.StatusInternalServerError)
+			}
+			return
+		}
+
+		if created {
+			w.WriteHeader(http.StatusCreated)
+		}
+		writeJSON(w, NewResponseEnvelope(subscription, generatedAt))
+	}
+}
+
+// DeleteSubscriptionHandler handles requests to remove a webhook subscription. Only the user who
+// created the subscription may remove it.
+func DeleteSubscriptionHandler(registry service.RegistryService, authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Extract the subscription ID from the URL path
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid subscription ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		claims, ok := authenticateSubscriber(w, r, authService)
+		if !ok {
+			return
+		}
+
+		subscription, err := registry.GetSubscription(r.Context(), id)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Subscription not found"}, http.StatusNotFound)
+				return
+			}
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Error retrieving subscription"}, http.StatusInternalServerError)
+			return
+		}
+		if subscription.GitHubUserID != claims.GitHubUserID {
+			WriteError(w, APIError{
+				Code:    ErrCodeForbidden,
+				Message: "Only the subscriber can remove this subscription",
+			}, http.StatusForbidden)
+			return
+		}
+
+		if err := registry.DeleteSubscription(r.Context(), id); err != nil && !errors.Is(err, database.ErrNotFound) {
+			middleware.Logf(r.Context(), "subscriptions: Failed to delete subscription %s: %v", id, err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to delete subscription"}, http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// NotifySubscribersHandler handles requests to notify the subscribers of a server that it was updated,
+// e.g. after changing it outside of the API. The webhooks are called in the background.
+func NotifySubscribersHandler(registry service.RegistryService, authService auth.Service, hub *events.Hub) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Only allow POST method
+		if r.Method != http.MethodPost {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Extract the server ID from the URL path
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		serverDetail, ok := authorizeServerModification(w, r, registry, authService, id)
+		if !ok {
+			return
+		}
+
+		hub.Publish(events.Event{
+			Type:     events.EventUpdated,
+			ServerID: serverDetail.ID,
+			Name:     serverDetail.Name,
+		})
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+// authenticateSubscriber verifies the request is authenticated with the registry token of a GitHub user and
+// returns its claims. Subscriptions belong to a GitHub user, which the registry owner token does not identify.
+// It writes an error response and returns false if the request is not authenticated.
+func authenticateSubscriber(
+	w http.ResponseWriter, r *http.Request, authService auth.Service,
+) (*auth.EphemeralTokenClaims, bool) {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		WriteError(w, APIError{Code: ErrCodeAuthRequired, Message: "Authorization header is required"}, http.StatusUnauthorized)
+		return nil, false
+	}
+
+	token := auth.ParseAuthorizationHeader(authHeader)
+	valid, claims, err := authService.ValidateEphemeralOrOwnerToken(r.Context(), token)
+	if err != nil {
+		WriteError(w, APIError{Code: ErrCodeAuthFailed, Message: "Authentication failed: " + err.Error()}, http.StatusUnauthorized)
+		return nil, false
+	}
+	if !valid {
+		WriteError(w, APIError{Code: ErrCodeForbidden, Message: "Invalid authentication token"}, http.StatusForbidden)
+		return nil, false
+	}
+	if claims == nil || claims.GitHubUserID == "" {
+		WriteError(w, APIError{
+			Code:    ErrCodeForbidden,
+			Message: "Subscriptions must be managed with a GitHub user token",
+		}, http.StatusForbidden)
+		return nil, false
+	}
+
+	return claims, true
+}
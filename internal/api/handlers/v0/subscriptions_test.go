@@ -0,0 +1,310 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/events"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// subscribe calls the subscriptions handler as the user described by claims
+func subscribe(
+	t *testing.T, registry service.RegistryService, claims *auth.EphemeralTokenClaims, req v0.SubscriptionRequest,
+) *httptest.ResponseRecorder {
+	t.Helper()
+	mockAuthService := new(MockAuthService)
+	mockAuthService.Mock.On("ValidateEphemeralOrOwnerToken", mock.Anything, "test-token").Return(true, claims, nil)
+
+	body, err := json.Marshal(req)
+	require.NoError(t, err)
+
+	httpReq, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/subscriptions", bytes.NewReader(body))
+	require.NoError(t, err)
+	httpReq.Header.Set("Authorization", "Bearer test-token")
+
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.SubscriptionsHandler(registry, mockAuthService), rr, httpReq)
+	return rr
+}
+
+// unsubscribe calls the delete subscription handler as the user described by claims
+func unsubscribe(
+	t *testing.T, registry service.RegistryService, claims *auth.EphemeralTokenClaims, id string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+	mockAuthService := new(MockAuthService)
+	mockAuthService.Mock.On("ValidateEphemeralOrOwnerToken", mock.Anything, "test-token").Return(true, claims, nil)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/v0/subscriptions/"+id, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer test-token")
+	req.SetPathValue("id", id)
+
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.DeleteSubscriptionHandler(registry, mockAuthService), rr, req)
+	return rr
+}
+
+// decodeSubscription decodes the subscription returned by the subscriptions handler
+func decodeSubscription(t *testing.T, rr *httptest.ResponseRecorder) model.Subscription {
+	t.Helper()
+	var resp v0.ResponseEnvelope[model.Subscription]
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	return resp.Data
+}
+
+// resolveWebhookHost stands in for DNS in the subscription tests: internal.example.com resolves to a private
+// address and every other host to a public one
+func resolveWebhookHost(_ context.Context, host string) ([]netip.Addr, error) {
+	if host == "internal.example.com" {
+		return []netip.Addr{netip.MustParseAddr("10.0.0.7")}, nil
+	}
+	return []netip.Addr{netip.MustParseAddr("93.184.215.14")}, nil
+}
+
+// newSubscriptionRegistry creates a registry service that resolves webhook hosts with resolveWebhookHost
+//
+//nolint:ireturn // Returns the service interface like the constructor it wraps
+func newSubscriptionRegistry(db database.Database) service.RegistryService {
+	return service.NewRegistryServiceWithDB(db, service.WithWebhookResolver(resolveWebhookHost))
+}
+
+// webhookReceiver starts an HTTPS webhook endpoint that forwards the payloads it receives. It returns a webhook
+// URL on a public host name together with a client that connects to the endpoint whatever the host resolves to.
+func webhookReceiver(t *testing.T) (string, *http.Client, <-chan model.WebhookPayload) {
+	t.Helper()
+	payloads := make(chan model.WebhookPayload, 10)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload model.WebhookPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		assert.Equal(t, string(payload.Event), r.Header.Get("X-Registry-Event"))
+		payloads <- payload
+	}))
+	t.Cleanup(server.Close)
+
+	client := server.Client()
+	transport := client.Transport.(*http.Transport)
+	transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+	}
+	// The certificate of the test server is valid for example.com
+	return "https://example.com/registry", client, payloads
+}
+
+// receivePayload waits for the next payload delivered to a webhook receiver
+func receivePayload(t *testing.T, payloads <-chan model.WebhookPayload) model.WebhookPayload {
+	t.Helper()
+	select {
+	case payload := <-payloads:
+		return payload
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "webhook was not called")
+		return model.WebhookPayload{}
+	}
+}
+
+func TestSubscriptionsHandler(t *testing.T) {
+	subscriber := reviewer("1")
+
+	t.Run("creates a subscription", func(t *testing.T) {
+		registry := newSubscriptionRegistry(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersion(t, registry, "1.0.0")
+
+		rr := subscribe(t, registry, subscriber, v0.SubscriptionRequest{
+			ServerID:   id,
+			WebhookURL: "https://hooks.example.com/registry",
+			Events:     []model.SubscriptionEvent{model.SubscriptionEventVersionUpdated},
+		})
+		require.Equal(t, http.StatusCreated, rr.Code)
+
+		subscription := decodeSubscription(t, rr)
+		assert.NotEmpty(t, subscription.ID)
+		assert.Equal(t, id, subscription.ServerID)
+		assert.Equal(t, "io.github.example/pinned-server", subscription.ServerName)
+		assert.Equal(t, "user-1", subscription.GitHubUsername)
+		assert.Equal(t, []model.SubscriptionEvent{model.SubscriptionEventVersionUpdated}, subscription.Events)
+	})
+
+	t.Run("same webhook and server updates the subscription", func(t *testing.T) {
+		registry := newSubscriptionRegistry(database.NewMemoryDB(map[string]*model.Server{}))
+		oldID := publishVersion(t, registry, "1.0.0")
+		latestID := publishVersion(t, registry, "1.1.0")
+		req := v0.SubscriptionRequest{
+			ServerID:   oldID,
+			WebhookURL: "https://hooks.example.com/registry",
+			Events:     []model.SubscriptionEvent{model.SubscriptionEventVersionUpdated},
+		}
+
+		rr := subscribe(t, registry, subscriber, req)
+		require.Equal(t, http.StatusCreated, rr.Code)
+		created := decodeSubscription(t, rr)
+
+		// Subscriptions cover all versions, so another version of the server is the same server
+		req.ServerID = latestID
+		req.Events = []model.SubscriptionEvent{model.SubscriptionEventVersionPublished, model.SubscriptionEventVersionPublished}
+		rr = subscribe(t, registry, subscriber, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		updated := decodeSubscription(t, rr)
+		assert.Equal(t, created.ID, updated.ID)
+		assert.Equal(t, []model.SubscriptionEvent{model.SubscriptionEventVersionPublished}, updated.Events)
+
+		stored, err := registry.GetSubscription(context.Background(), created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []model.SubscriptionEvent{model.SubscriptionEventVersionPublished}, stored.Events)
+
+		// Another user cannot take over the subscription
+		rr = subscribe(t, registry, reviewer("2"), req)
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("invalid subscriptions", func(t *testing.T) {
+		registry := newSubscriptionRegistry(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersion(t, registry, "1.0.0")
+		events := []model.SubscriptionEvent{model.SubscriptionEventVersionUpdated}
+
+		for name, req := range map[string]v0.SubscriptionRequest{
+			"relative webhook URL": {ServerID: id, WebhookURL: "/registry", Events: events},
+			"unsupported scheme":   {ServerID: id, WebhookURL: "ftp://hooks.example.com", Events: events},
+			"plain HTTP":           {ServerID: id, WebhookURL: "http://hooks.example.com", Events: events},
+			"loopback address":     {ServerID: id, WebhookURL: "https://127.0.0.1:8080/registry", Events: events},
+			"link-local address":   {ServerID: id, WebhookURL: "https://[fe80::1]/registry", Events: events},
+			"private host":         {ServerID: id, WebhookURL: "https://internal.example.com/registry", Events: events},
+			"no events":            {ServerID: id, WebhookURL: "https://hooks.example.com"},
+			"unknown event":        {ServerID: id, WebhookURL: "https://hooks.example.com", Events: []model.SubscriptionEvent{"deleted"}},
+			"invalid server ID":    {ServerID: "not-a-uuid", WebhookURL: "https://hooks.example.com", Events: events},
+		} {
+			rr := subscribe(t, registry, subscriber, req)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, name)
+		}
+
+		rr := subscribe(t, registry, subscriber, v0.SubscriptionRequest{
+			ServerID: "00000000-0000-0000-0000-000000000000", WebhookURL: "https://hooks.example.com", Events: events,
+		})
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		rr = subscribe(t, registry, nil, v0.SubscriptionRequest{ServerID: id, WebhookURL: "https://hooks.example.com", Events: events})
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("limits subscriptions per user", func(t *testing.T) {
+		registry := newSubscriptionRegistry(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersion(t, registry, "1.0.0")
+		events := []model.SubscriptionEvent{model.SubscriptionEventVersionUpdated}
+
+		for i := range model.MaxSubscriptionsPerUser {
+			webhookURL := fmt.Sprintf("https://hooks.example.com/%d", i)
+			rr := subscribe(t, registry, subscriber, v0.SubscriptionRequest{ServerID: id, WebhookURL: webhookURL, Events: events})
+			require.Equal(t, http.StatusCreated, rr.Code)
+		}
+
+		rr := subscribe(t, registry, subscriber, v0.SubscriptionRequest{ServerID: id, WebhookURL: "https://hooks.example.com/new", Events: events})
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+
+		// Updating an existing subscription is still allowed
+		rr = subscribe(t, registry, subscriber, v0.SubscriptionRequest{ServerID: id, WebhookURL: "https://hooks.example.com/0", Events: events})
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestDeleteSubscriptionHandler(t *testing.T) {
+	registry := newSubscriptionRegistry(database.NewMemoryDB(map[string]*model.Server{}))
+	id := publishVersion(t, registry, "1.0.0")
+
+	rr := subscribe(t, registry, reviewer("1"), v0.SubscriptionRequest{
+		ServerID:   id,
+		WebhookURL: "https://hooks.example.com/registry",
+		Events:     []model.SubscriptionEvent{model.SubscriptionEventVersionUpdated},
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+	subscription := decodeSubscription(t, rr)
+
+	rr = unsubscribe(t, registry, reviewer("2"), subscription.ID)
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+
+	rr = unsubscribe(t, registry, reviewer("1"), subscription.ID)
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+
+	_, err := registry.GetSubscription(context.Background(), subscription.ID)
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	rr = unsubscribe(t, registry, reviewer("1"), subscription.ID)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	db := database.NewMemoryDB(map[string]*model.Server{})
+	hub := events.NewHub()
+	defer hub.Close()
+	registry := service.NewEventingRegistryService(newSubscriptionRegistry(db), hub)
+	webhookURL, client, payloads := webhookReceiver(t)
+	service.NewWebhookDispatcher(db, client).Start(hub)
+
+	id := publishVersion(t, registry, "1.0.0")
+	rr := subscribe(t, registry, reviewer("1"), v0.SubscriptionRequest{
+		ServerID:   id,
+		WebhookURL: webhookURL,
+		Events:     []model.SubscriptionEvent{model.SubscriptionEventVersionUpdated},
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+	subscription := decodeSubscription(t, rr)
+
+	t.Run("delivers updates", func(t *testing.T) {
+		_, err := registry.AppendPackages(context.Background(), id, []model.Package{{RegistryName: "npm", Name: "extra-package"}})
+		require.NoError(t, err)
+
+		payload := receivePayload(t, payloads)
+		assert.Equal(t, subscription.ID, payload.SubscriptionID)
+		assert.Equal(t, model.SubscriptionEventVersionUpdated, payload.Event)
+		assert.Equal(t, id, payload.ServerID)
+		assert.Equal(t, "io.github.example/pinned-server", payload.Name)
+	})
+
+	t.Run("notify-subscribers triggers a delivery", func(t *testing.T) {
+		mockAuthService := new(MockAuthService)
+		mockAuthService.Mock.On("ValidateEphemeralOrOwnerToken", mock.Anything, "test-token").
+			Return(true, &auth.EphemeralTokenClaims{GitHubUsername: "example"}, nil)
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/servers/"+id+"/notify-subscribers", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer test-token")
+		req.SetPathValue("id", id)
+
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.NotifySubscribersHandler(registry, mockAuthService, hub), rr, req)
+		require.Equal(t, http.StatusAccepted, rr.Code)
+
+		payload := receivePayload(t, payloads)
+		assert.Equal(t, model.SubscriptionEventVersionUpdated, payload.Event)
+		assert.Equal(t, id, payload.ServerID)
+	})
+
+	t.Run("events the subscription did not opt in to are not delivered", func(t *testing.T) {
+		publishVersion(t, registry, "1.1.0")
+		_, err := registry.AppendPackages(context.Background(), id, []model.Package{{RegistryName: "npm", Name: "another-package"}})
+		require.NoError(t, err)
+
+		// Only the update arrives; the published event of the new version was skipped
+		payload := receivePayload(t, payloads)
+		assert.Equal(t, model.SubscriptionEventVersionUpdated, payload.Event)
+		assert.Empty(t, payloads)
+	})
+}
@@ -36,7 +36,7 @@ func SwaggerJSONHandler() http.HandlerFunc {
 		// Find the project root directory
 		workDir, err := os.Getwd()
 		if err != nil {
-			http.Error(w, "Unable to determine working directory", http.StatusInternalServerError)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Unable to determine working directory"}, http.StatusInternalServerError)
 			return
 		}
 
@@ -0,0 +1,142 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// TagsRequest represents the request body for adding tags to a server
+type TagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// TagsResponse represents the tags of a server after a modification
+type TagsResponse struct {
+	Tags []string `json:"tags"`
+}
+
+// ListTagsHandler returns a handler listing the tags of servers with the number of servers carrying each,
+// sorted by tag
+func ListTagsHandler(registry service.RegistryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		generatedAt := time.Now().UTC()
+
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		tags, err := registry.ListTags(r.Context())
+		if err != nil {
+			middleware.Logf(r.Context(), "Error listing tags: %v", err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to list tags"}, http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, NewResponseEnvelope(tags, generatedAt))
+	}
+}
+
+// AddTagsHandler handles requests to add tags to an existing server. Tags the server already has are ignored.
+func AddTagsHandler(registry service.RegistryService, authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Only allow POST method
+		if r.Method != http.MethodPost {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Extract the server ID from the URL path
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		// Parse request body
+		var req TagsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidPayload, Message: "Invalid request payload: " + err.Error()}, http.StatusBadRequest)
+			return
+		}
+		defer r.Body.Close()
+
+		if len(req.Tags) == 0 {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "At least one tag is required"}, http.StatusBadRequest)
+			return
+		}
+
+		serverDetail, ok := authorizeServerModification(w, r, registry, authService, id)
+		if !ok || !checkIfMatch(w, r, serverDetail) {
+			return
+		}
+
+		tags, err := registry.AddTags(r.Context(), id, req.Tags)
+		if err != nil {
+			if writeValidationErrors(w, err) {
+				return
+			}
+			switch {
+			case errors.Is(err, database.ErrNotFound):
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Server not found"}, http.StatusNotFound)
+			case errors.Is(err, database.ErrInvalidInput):
+				WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "At least one tag is required"}, http.StatusBadRequest)
+			default:
+				middleware.Logf(r.Context(), "tags: Failed to add tags to server %s: %v", id, err)
+				WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to add tags: " + err.Error()}, http.StatusInternalServerError)
+			}
+			return
+		}
+
+		writeJSON(w, TagsResponse{Tags: tags})
+	}
+}
+
+// RemoveTagHandler handles requests to remove a single tag from an existing server
+func RemoveTagHandler(registry service.RegistryService, authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Only allow DELETE method
+		if r.Method != http.MethodDelete {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Extract the server ID and tag from the URL path
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		tag := r.PathValue("tag")
+		if tag == "" {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Tag is required"}, http.StatusBadRequest)
+			return
+		}
+
+		serverDetail, ok := authorizeServerModification(w, r, registry, authService, id)
+		if !ok || !checkIfMatch(w, r, serverDetail) {
+			return
+		}
+
+		if err := registry.RemoveTag(r.Context(), id, tag); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Tag not found"}, http.StatusNotFound)
+				return
+			}
+			middleware.Logf(r.Context(), "tags: Failed to remove tag %s from server %s: %v", tag, id, err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to remove tag: " + err.Error()}, http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
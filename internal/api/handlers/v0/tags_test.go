@@ -0,0 +1,333 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddTagsHandler(t *testing.T) {
+	publisherClaims := &auth.EphemeralTokenClaims{GitHubUsername: "example"}
+
+	testCases := []struct {
+		name           string
+		existingTags   []string
+		tags           []string
+		authHeader     string
+		claims         *auth.EphemeralTokenClaims
+		expectedStatus int
+		expectedError  string
+		expectedTags   []string
+	}{
+		{
+			name:           "adds tags in lowercase",
+			existingTags:   []string{"llm"},
+			tags:           []string{"Filesystem", "llm"},
+			authHeader:     "Bearer test-token",
+			claims:         publisherClaims,
+			expectedStatus: http.StatusOK,
+			expectedTags:   []string{"llm", "filesystem"},
+		},
+		{
+			name:           "registry owner can add tags",
+			tags:           []string{"search"},
+			authHeader:     "Bearer test-token",
+			expectedStatus: http.StatusOK,
+			expectedTags:   []string{"search"},
+		},
+		{
+			name: "adds tags up to the limit",
+			tags: func() []string {
+				tags := make([]string, service.MaxTagsPerServer)
+				for i := range tags {
+					tags[i] = fmt.Sprintf("tag-%d", i)
+				}
+				return tags
+			}(),
+			authHeader:     "Bearer test-token",
+			claims:         publisherClaims,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:         "rejects exceeding max tags",
+			existingTags: []string{"llm"},
+			tags: func() []string {
+				tags := make([]string, service.MaxTagsPerServer)
+				for i := range tags {
+					tags[i] = fmt.Sprintf("tag-%d", i)
+				}
+				return tags
+			}(),
+			authHeader:     "Bearer test-token",
+			claims:         publisherClaims,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "at most 10 tags are allowed",
+			expectedTags:   []string{"llm"},
+		},
+		{
+			name:           "rejects invalid tag",
+			tags:           []string{"not a tag"},
+			authHeader:     "Bearer test-token",
+			claims:         publisherClaims,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "must match",
+		},
+		{
+			name:           "rejects empty tags",
+			tags:           []string{},
+			authHeader:     "Bearer test-token",
+			claims:         publisherClaims,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "At least one tag is required",
+		},
+		{
+			name:           "missing authorization header",
+			tags:           []string{"search"},
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "Authorization header is required",
+		},
+		{
+			name:           "rejects user who is not the publisher",
+			existingTags:   []string{"llm"},
+			tags:           []string{"search"},
+			authHeader:     "Bearer test-token",
+			claims:         &auth.EphemeralTokenClaims{GitHubUsername: "someone-else"},
+			expectedStatus: http.StatusForbidden,
+			expectedError:  "Only the publisher, maintainers or registry owner",
+			expectedTags:   []string{"llm"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			registry, serverDetail := newPackagesTestRegistry(t)
+			if len(tc.existingTags) > 0 {
+				_, err := registry.AddTags(context.Background(), serverDetail.ID, tc.existingTags)
+				require.NoError(t, err)
+			}
+
+			mockAuthService := new(MockAuthService)
+			mockAuthService.Mock.On("ValidateEphemeralOrOwnerToken", mock.Anything, "test-token").Return(true, tc.claims, nil)
+
+			body, err := json.Marshal(v0.TagsRequest{Tags: tc.tags})
+			require.NoError(t, err)
+			req, err := http.NewRequestWithContext(
+				context.Background(), http.MethodPost, "/v0/servers/"+serverDetail.ID+"/tags", bytes.NewReader(body),
+			)
+			require.NoError(t, err)
+			if tc.authHeader != "" {
+				req.Header.Set("Authorization", tc.authHeader)
+			}
+			req.SetPathValue("id", serverDetail.ID)
+
+			rr := httptest.NewRecorder()
+			serveHTTP(t, v0.AddTagsHandler(registry, mockAuthService), rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusOK {
+				var resp v0.TagsResponse
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+				if tc.expectedTags != nil {
+					assert.Equal(t, tc.expectedTags, resp.Tags)
+				} else {
+					assert.Len(t, resp.Tags, service.MaxTagsPerServer)
+				}
+			} else {
+				assert.Contains(t, rr.Body.String(), tc.expectedError)
+			}
+
+			stored, err := registry.GetByID(serverDetail.ID)
+			require.NoError(t, err)
+			if tc.expectedStatus != http.StatusOK {
+				assert.Equal(t, tc.expectedTags, stored.Tags)
+			}
+		})
+	}
+}
+
+func TestRemoveTagHandler(t *testing.T) {
+	publisherClaims := &auth.EphemeralTokenClaims{GitHubUsername: "example"}
+
+	testCases := []struct {
+		name           string
+		tag            string
+		authHeader     string
+		claims         *auth.EphemeralTokenClaims
+		expectedStatus int
+		expectedError  string
+		expectedTags   []string
+	}{
+		{
+			name:           "removes tag",
+			tag:            "llm",
+			authHeader:     "Bearer test-token",
+			claims:         publisherClaims,
+			expectedStatus: http.StatusNoContent,
+			expectedTags:   []string{"filesystem"},
+		},
+		{
+			name:           "ignores case",
+			tag:            "FileSystem",
+			authHeader:     "Bearer test-token",
+			claims:         publisherClaims,
+			expectedStatus: http.StatusNoContent,
+			expectedTags:   []string{"llm"},
+		},
+		{
+			name:           "non-existent tag",
+			tag:            "search",
+			authHeader:     "Bearer test-token",
+			claims:         publisherClaims,
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "Tag not found",
+			expectedTags:   []string{"llm", "filesystem"},
+		},
+		{
+			name:           "missing authorization header",
+			tag:            "llm",
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "Authorization header is required",
+			expectedTags:   []string{"llm", "filesystem"},
+		},
+		{
+			name:           "rejects user who is not the publisher",
+			tag:            "llm",
+			authHeader:     "Bearer test-token",
+			claims:         &auth.EphemeralTokenClaims{GitHubUsername: "someone-else"},
+			expectedStatus: http.StatusForbidden,
+			expectedError:  "Only the publisher, maintainers or registry owner",
+			expectedTags:   []string{"llm", "filesystem"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			registry, serverDetail := newPackagesTestRegistry(t)
+			_, err := registry.AddTags(context.Background(), serverDetail.ID, []string{"llm", "filesystem"})
+			require.NoError(t, err)
+
+			mockAuthService := new(MockAuthService)
+			mockAuthService.Mock.On("ValidateEphemeralOrOwnerToken", mock.Anything, "test-token").Return(true, tc.claims, nil)
+
+			req, err := http.NewRequestWithContext(
+				context.Background(), http.MethodDelete, "/v0/servers/"+serverDetail.ID+"/tags/"+tc.tag, nil,
+			)
+			require.NoError(t, err)
+			if tc.authHeader != "" {
+				req.Header.Set("Authorization", tc.authHeader)
+			}
+			req.SetPathValue("id", serverDetail.ID)
+			req.SetPathValue("tag", tc.tag)
+
+			rr := httptest.NewRecorder()
+			serveHTTP(t, v0.RemoveTagHandler(registry, mockAuthService), rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedError != "" {
+				assert.Contains(t, rr.Body.String(), tc.expectedError)
+			}
+
+			stored, err := registry.GetByID(serverDetail.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedTags, stored.Tags)
+		})
+	}
+}
+
+func TestTagsFromPublishOSS(t *testing.T) {
+	stubGitHubRepoAPI(t)
+	authService := auth.NewAuthService(&config.Config{EphemeralTokenSecret: testEphemeralTokenSecret})
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+
+	ossReq := ossRepository("tagged-server")
+	ossReq.Tags = []string{"memory", "tools"}
+	body, err := json.Marshal(ossReq)
+	require.NoError(t, err)
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/publish-oss", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+signEphemeralToken(t, time.Hour))
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.PublishOSSHandler(registry, authService), rr, req)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+	var published struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &published))
+	serverDetail, err := registry.GetByID(published.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"memory", "tools"}, serverDetail.Tags)
+
+	require.NoError(t, registry.Publish(&model.ServerDetail{
+		Server: model.Server{
+			Name:          "io.github.example/tools-only",
+			Repository:    model.Repository{URL: "https://github.com/example/tools-only", Source: "github"},
+			VersionDetail: model.VersionDetail{Version: "1.0.0"},
+			Tags:          []string{"tools"},
+		},
+	}))
+	publishWithSchema(t, registry, "untagged-server", nil)
+
+	t.Run("search by tags", func(t *testing.T) {
+		for query, expectedNames := range map[string][]string{
+			"?tags=tools":                  {"io.github.example/tagged-server", "io.github.example/tools-only"},
+			"?tags=TOOLS&format=minimal":   {"io.github.example/tagged-server", "io.github.example/tools-only"},
+			"?tags=tools,memory":           {"io.github.example/tagged-server"},
+			"?tags=tools,%20memory,":       {"io.github.example/tagged-server"},
+			"?tags=data":                   {},
+			"?tags=memory&q=tools-only":    {},
+			"?tags=tools&language=python":  {},
+			"?tags=memory&format=minimal":  {"io.github.example/tagged-server"},
+			"?tags=tools&registry_name=go": {},
+		} {
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/search"+query, nil)
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			serveHTTP(t, v0.SearchHandler(registry, &config.Config{}), rr, req)
+			require.Equal(t, http.StatusOK, rr.Code, query)
+
+			var resp v0.ResponseEnvelope[[]model.ServerMinimal]
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			names := make([]string, len(resp.Data))
+			for i, server := range resp.Data {
+				names[i] = server.Name
+			}
+			assert.ElementsMatch(t, expectedNames, names, query)
+		}
+	})
+
+	t.Run("list tags", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/tags", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.ListTagsHandler(registry), rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp v0.ResponseEnvelope[[]model.TagCount]
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		assert.Equal(t, []model.TagCount{{Tag: "memory", Count: 1}, {Tag: "tools", Count: 2}}, resp.Data)
+	})
+
+	t.Run("list tags rejects other methods", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/tags", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.ListTagsHandler(registry), rr, req)
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
@@ -0,0 +1,131 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// publishWithTools publishes a server with the given name and tools and returns its ID
+func publishWithTools(t *testing.T, registry service.RegistryService, name string, tools []model.MCPTool) string {
+	t.Helper()
+	serverDetail := &model.ServerDetail{
+		Server: model.Server{
+			Name:          "io.github.example/" + name,
+			Repository:    model.Repository{URL: "https://github.com/example/" + name, Source: "github"},
+			VersionDetail: model.VersionDetail{Version: "1.0.0"},
+		},
+		Tools: tools,
+	}
+	require.NoError(t, registry.Publish(serverDetail))
+	return serverDetail.ID
+}
+
+func TestToolsHandler(t *testing.T) {
+	tools := []model.MCPTool{
+		{
+			Name:        "read_file",
+			Description: "Reads a file",
+			InputSchema: json.RawMessage(`{"type":"object","properties":{"path":{"type":"string"}}}`),
+		},
+		{Name: "list_directory"},
+	}
+
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	withTools := publishWithTools(t, registry, "with-tools", tools)
+	withoutTools := publishWithTools(t, registry, "without-tools", nil)
+
+	getTools := func(t *testing.T, id string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers/"+id+"/tools", nil)
+		require.NoError(t, err)
+		req.SetPathValue("id", id)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.ToolsHandler(registry), rr, req)
+		return rr
+	}
+
+	t.Run("returns the declared tools", func(t *testing.T) {
+		rr := getTools(t, withTools)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp v0.ResponseEnvelope[[]model.MCPTool]
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Equal(t, tools, resp.Data)
+	})
+
+	t.Run("server without tools", func(t *testing.T) {
+		rr := getTools(t, withoutTools)
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"data":[]`)
+	})
+
+	t.Run("server not found", func(t *testing.T) {
+		rr := getTools(t, "00000000-0000-0000-0000-000000000000")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("invalid tools are rejected on publish", func(t *testing.T) {
+		for name, invalid := range map[string][]model.MCPTool{
+			"missing name":      {{Description: "No name"}},
+			"duplicate name":    {{Name: "read_file"}, {Name: "read_file"}},
+			"non-object schema": {{Name: "read_file", InputSchema: json.RawMessage(`"string"`)}},
+		} {
+			err := registry.Publish(&model.ServerDetail{
+				Server: model.Server{
+					Name:          "io.github.example/invalid-tools",
+					Repository:    model.Repository{URL: "https://github.com/example/invalid-tools", Source: "github"},
+					VersionDetail: model.VersionDetail{Version: "1.0.0"},
+				},
+				Tools: invalid,
+			})
+			assert.ErrorIs(t, err, database.ErrInvalidTool, name)
+		}
+	})
+}
+
+func TestSearchHandlerToolFilter(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	publishWithTools(t, registry, "files", []model.MCPTool{{Name: "read_file"}, {Name: "write_file"}})
+	publishWithTools(t, registry, "weather", []model.MCPTool{{Name: "get_forecast"}})
+	publishWithTools(t, registry, "no-tools", nil)
+
+	testCases := []struct {
+		name          string
+		query         string
+		expectedNames []string
+	}{
+		{name: "matching tool", query: "?has_tool=read_file", expectedNames: []string{"io.github.example/files"}},
+		{name: "minimal format", query: "?has_tool=get_forecast&format=minimal", expectedNames: []string{"io.github.example/weather"}},
+		{name: "combined with text search", query: "?q=weather&has_tool=read_file", expectedNames: []string{}},
+		{name: "partial names do not match", query: "?has_tool=read", expectedNames: []string{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/search"+tc.query, nil)
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			serveHTTP(t, v0.SearchHandler(registry, &config.Config{}), rr, req)
+			require.Equal(t, http.StatusOK, rr.Code)
+
+			var resp v0.ResponseEnvelope[[]model.ServerMinimal]
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			names := make([]string, len(resp.Data))
+			for i, server := range resp.Data {
+				names[i] = server.Name
+			}
+			assert.ElementsMatch(t, tc.expectedNames, names)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package v0
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// TopicsHandler returns a handler listing the repository topics of servers with the number of servers tagged
+// with each, most common first
+func TopicsHandler(registry service.RegistryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		generatedAt := time.Now().UTC()
+
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		topics, err := registry.ListTopics(r.Context())
+		if err != nil {
+			middleware.Logf(r.Context(), "Error listing topics: %v", err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to list topics"}, http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, NewResponseEnvelope(topics, generatedAt))
+	}
+}
@@ -0,0 +1,99 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// githubUsernameRegex matches valid GitHub usernames: alphanumerics and single inner hyphens, at most 39 characters
+var githubUsernameRegex = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9-]{0,37}[A-Za-z0-9])?$`)
+
+// TransferRequest represents the request body for transferring ownership of a server
+type TransferRequest struct {
+	NewOwnerUsername string `json:"new_owner_username"`
+}
+
+// TransferHandler handles requests to hand ownership of a server over to another GitHub user.
+// Ownership covers all versions of the server; only the current publisher or the registry owner may transfer it.
+func TransferHandler(registry service.RegistryService, authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Only allow POST method
+		if r.Method != http.MethodPost {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Extract the server ID from the URL path
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		// Parse request body
+		var req TransferRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidPayload, Message: "Invalid request payload: " + err.Error()}, http.StatusBadRequest)
+			return
+		}
+		defer r.Body.Close()
+
+		if req.NewOwnerUsername == "" {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "New owner username is required"}, http.StatusBadRequest)
+			return
+		}
+		if !githubUsernameRegex.MatchString(req.NewOwnerUsername) {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid GitHub username"}, http.StatusBadRequest)
+			return
+		}
+
+		serverDetail, ok := authorizeServerOwner(w, r, registry, authService, id)
+		if !ok || !checkIfMatch(w, r, serverDetail) {
+			return
+		}
+
+		exists, err := authService.GitHubUserExists(r.Context(), req.NewOwnerUsername)
+		if err != nil {
+			middleware.Logf(r.Context(), "transfer: Failed to look up GitHub user %s: %v", req.NewOwnerUsername, err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to look up GitHub user"}, http.StatusInternalServerError)
+			return
+		}
+		if !exists {
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "GitHub user not found"}, http.StatusBadRequest)
+			return
+		}
+
+		previousOwner := serverDetail.Publisher()
+		if err := registry.TransferOwnership(r.Context(), id, req.NewOwnerUsername); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Server not found"}, http.StatusNotFound)
+				return
+			}
+			middleware.Logf(r.Context(), "transfer: Failed to transfer server %s to %s: %v", id, req.NewOwnerUsername, err)
+			WriteError(w, APIError{
+				Code:    ErrCodeInternal,
+				Message: "Failed to transfer ownership: " + err.Error(),
+			}, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(map[string]any{
+			"message":        "Ownership transferred",
+			"name":           serverDetail.Name,
+			"previous_owner": previousOwner,
+			"new_owner":      req.NewOwnerUsername,
+		}); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to encode response"}, http.StatusInternalServerError)
+			return
+		}
+	}
+}
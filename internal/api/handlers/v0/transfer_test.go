@@ -0,0 +1,179 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/events"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// transferServer calls the transfer handler as the user described by claims.
+// The GitHub user lookup answers with exists and lookupErr.
+func transferServer(
+	t *testing.T, registry service.RegistryService, claims *auth.EphemeralTokenClaims, id, newOwner string,
+	exists bool, lookupErr error,
+) *httptest.ResponseRecorder {
+	t.Helper()
+	mockAuthService := new(MockAuthService)
+	mockAuthService.Mock.On("ValidateEphemeralOrOwnerToken", mock.Anything, "test-token").Return(true, claims, nil)
+	mockAuthService.Mock.On("GitHubUserExists", mock.Anything, newOwner).Return(exists, lookupErr)
+
+	body, err := json.Marshal(v0.TransferRequest{NewOwnerUsername: newOwner})
+	require.NoError(t, err)
+
+	req, err := http.NewRequestWithContext(
+		context.Background(), http.MethodPost, "/v0/servers/"+id+"/transfer", bytes.NewReader(body),
+	)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer test-token")
+	req.SetPathValue("id", id)
+
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.TransferHandler(registry, mockAuthService), rr, req)
+	return rr
+}
+
+func TestTransferHandler(t *testing.T) {
+	publisherClaims := &auth.EphemeralTokenClaims{GitHubUsername: "example"}
+
+	t.Run("transfers all versions to the new owner", func(t *testing.T) {
+		hub := events.NewHub()
+		defer hub.Close()
+		eventsCh, unsubscribe := hub.Subscribe()
+		defer unsubscribe()
+
+		registry := service.NewEventingRegistryService(
+			service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{})), hub,
+		)
+		oldID := publishVersion(t, registry, "1.0.0")
+		latestID := publishVersion(t, registry, "1.1.0")
+
+		rr := transferServer(t, registry, publisherClaims, latestID, "new-maintainer", true, nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp map[string]any
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Equal(t, "example", resp["previous_owner"])
+		assert.Equal(t, "new-maintainer", resp["new_owner"])
+
+		assert.Equal(t, "new-maintainer", getServer(t, registry, oldID).PublisherUsername)
+		assert.Equal(t, "new-maintainer", getServer(t, registry, latestID).PublisherUsername)
+
+		// Skip the published events to reach the audit event
+		var event events.Event
+		for event.Type != events.EventOwnershipTransferred {
+			event = <-eventsCh
+		}
+		assert.Equal(t, latestID, event.ServerID)
+		assert.Equal(t, "example", event.PreviousOwner)
+		assert.Equal(t, "new-maintainer", event.NewOwner)
+
+		// New versions keep the new owner
+		newID := publishVersion(t, registry, "1.2.0")
+		assert.Equal(t, "new-maintainer", getServer(t, registry, newID).PublisherUsername)
+	})
+
+	t.Run("only the new owner can modify the server afterwards", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersion(t, registry, "1.0.0")
+
+		rr := transferServer(t, registry, publisherClaims, id, "new-maintainer", true, nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		rr = pinVersion(t, registry, publisherClaims, id, "1.0.0")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		rr = pinVersion(t, registry, &auth.EphemeralTokenClaims{GitHubUsername: "New-Maintainer"}, id, "1.0.0")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("maintainer cannot transfer the server", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersion(t, registry, "1.0.0")
+
+		rr := addMaintainer(t, registry, publisherClaims, id, "co-maintainer", true)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		rr = transferServer(t, registry, &auth.EphemeralTokenClaims{GitHubUsername: "co-maintainer"}, id, "co-maintainer", true, nil)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Empty(t, getServer(t, registry, id).PublisherUsername)
+	})
+
+	t.Run("published owner is ignored", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		serverDetail := &model.ServerDetail{
+			Server: model.Server{
+				Name:              "io.github.example/pinned-server",
+				Repository:        model.Repository{URL: "https://github.com/example/pinned-server", Source: "github"},
+				VersionDetail:     model.VersionDetail{Version: "1.0.0"},
+				PublisherUsername: "impostor",
+			},
+		}
+		require.NoError(t, registry.Publish(serverDetail))
+		assert.Equal(t, "example", getServer(t, registry, serverDetail.ID).Publisher())
+
+		// Nor can a new version take over a transferred server
+		rr := transferServer(t, registry, publisherClaims, serverDetail.ID, "new-owner", true, nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+		serverDetail.VersionDetail.Version = "1.1.0"
+		serverDetail.PublisherUsername = "impostor"
+		require.NoError(t, registry.Publish(serverDetail))
+		assert.Equal(t, "new-owner", getServer(t, registry, serverDetail.ID).Publisher())
+	})
+
+	t.Run("unknown GitHub user", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersion(t, registry, "1.0.0")
+
+		rr := transferServer(t, registry, publisherClaims, id, "ghost-user", false, nil)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "GitHub user not found")
+		assert.Empty(t, getServer(t, registry, id).PublisherUsername)
+	})
+
+	t.Run("GitHub lookup failure", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersion(t, registry, "1.0.0")
+
+		rr := transferServer(t, registry, publisherClaims, id, "new-maintainer", false, errors.New("rate limited"))
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.Empty(t, getServer(t, registry, id).PublisherUsername)
+	})
+
+	t.Run("invalid GitHub username", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersion(t, registry, "1.0.0")
+
+		rr := transferServer(t, registry, publisherClaims, id, "../orgs/example", true, nil)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("server not found", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+
+		rr := transferServer(t, registry, publisherClaims, "00000000-0000-0000-0000-000000000000", "new-maintainer", true, nil)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("caller does not own the server", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersion(t, registry, "1.0.0")
+
+		rr := transferServer(t, registry, &auth.EphemeralTokenClaims{GitHubUsername: "someone-else"}, id, "someone-else", true, nil)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Empty(t, getServer(t, registry, id).PublisherUsername)
+	})
+}
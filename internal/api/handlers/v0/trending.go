@@ -0,0 +1,118 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/analytics"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// trendingLimit is the number of servers returned by the trending endpoint
+const trendingLimit = 10
+
+// recordView counts a view of a server for the trending ranking.
+// Failures are logged rather than failing the request that viewed the server.
+func recordView(ctx context.Context, trending *analytics.Trending, serverID string) {
+	if trending == nil {
+		return
+	}
+	if err := trending.RecordView(ctx, serverID); err != nil {
+		middleware.Logf(ctx, "Error recording view of server %s: %v", serverID, err)
+	}
+}
+
+// TrendingHandler returns a handler for the most viewed servers of the current day or week.
+// When view counting is unavailable, the client is redirected to the most recently published servers.
+func TrendingHandler(registry service.RegistryService, trending *analytics.Trending) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		generatedAt := time.Now().UTC()
+
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		period := r.URL.Query().Get("period")
+		if period == "" {
+			period = analytics.PeriodDay
+		}
+		if period != analytics.PeriodDay && period != analytics.PeriodWeek {
+			WriteError(w, APIError{
+				Code:    ErrCodeInvalidRequest,
+				Message: "Invalid period parameter: must be day or week",
+			}, http.StatusBadRequest)
+			return
+		}
+
+		if trending == nil {
+			http.Redirect(w, r, "/v0/servers/recent", http.StatusTemporaryRedirect)
+			return
+		}
+
+		entries, err := trending.GetTrending(r.Context(), period, trendingLimit)
+		if err != nil {
+			middleware.Logf(r.Context(), "trending: Failed to read trending servers, falling back to recent servers: %v", err)
+			http.Redirect(w, r, "/v0/servers/recent", http.StatusTemporaryRedirect)
+			return
+		}
+
+		// Add the names of the servers, leaving out servers that no longer exist
+		result := make([]model.TrendingEntry, 0, len(entries))
+		for _, entry := range entries {
+			serverMeta, err := registry.GetMetadata(r.Context(), entry.ServerID)
+			if err != nil {
+				if !errors.Is(err, database.ErrNotFound) {
+					middleware.Logf(r.Context(), "trending: Failed to look up server %s: %v", entry.ServerID, err)
+				}
+				continue
+			}
+			entry.Name = serverMeta.Name
+			result = append(result, entry)
+		}
+
+		writeJSON(w, NewResponseEnvelope(result, generatedAt))
+	}
+}
+
+// RecentServersHandler returns a handler for the most recently published servers, newest first.
+// The limit query parameter sets the number of servers, 10 by default and at most maxLimit.
+func RecentServersHandler(registry service.RegistryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		generatedAt := time.Now().UTC()
+
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		limit := trendingLimit
+		if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+			parsedLimit, err := strconv.Atoi(limitStr)
+			if err != nil {
+				WriteError(w, APIError{Code: ErrCodeInvalidLimit, Message: "Invalid limit parameter"}, http.StatusBadRequest)
+				return
+			}
+			if parsedLimit <= 0 {
+				WriteError(w, APIError{Code: ErrCodeInvalidLimit, Message: "Limit must be greater than 0"}, http.StatusBadRequest)
+				return
+			}
+			limit = min(parsedLimit, maxLimit)
+		}
+
+		servers, err := registry.ListRecent(r.Context(), limit)
+		if err != nil {
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: err.Error()}, http.StatusInternalServerError)
+			return
+		}
+
+		redactContactEmails(servers)
+		writeJSON(w, NewResponseEnvelope(servers, generatedAt))
+	}
+}
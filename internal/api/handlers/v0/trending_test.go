@@ -0,0 +1,128 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/modelcontextprotocol/registry/internal/analytics"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrendingHandler(t *testing.T) {
+	redisServer := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: redisServer.Addr()})
+	defer client.Close()
+	trending := analytics.NewTrending(client, 1)
+
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	publish := func(name string) string {
+		serverDetail := &model.ServerDetail{
+			Server: model.Server{
+				Name:          name,
+				Repository:    model.Repository{URL: "https://github.com/" + name, Source: "github"},
+				VersionDetail: model.VersionDetail{Version: "1.0.0"},
+			},
+		}
+		require.NoError(t, registry.Publish(serverDetail))
+		return serverDetail.ID
+	}
+	popularID := publish("io.github.example/popular")
+	quietID := publish("io.github.example/quiet")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0/servers/trending", v0.TrendingHandler(registry, trending))
+	mux.HandleFunc("/v0/servers/recent", v0.RecentServersHandler(registry))
+	mux.HandleFunc("/v0/servers/{id}", v0.ServersDetailHandler(registry, trending))
+
+	get := func(t *testing.T, handler http.Handler, target string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, target, nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, handler, rr, req)
+		return rr
+	}
+
+	for range 3 {
+		require.Equal(t, http.StatusOK, get(t, mux, "/v0/servers/"+popularID).Code)
+	}
+	require.Equal(t, http.StatusOK, get(t, mux, "/v0/servers/"+quietID).Code)
+	// Unknown servers are not counted
+	require.Equal(t, http.StatusNotFound, get(t, mux, "/v0/servers/00000000-0000-0000-0000-000000000000").Code)
+
+	t.Run("ranks servers by views", func(t *testing.T) {
+		for _, period := range []string{"", "?period=day", "?period=week"} {
+			rr := get(t, mux, "/v0/servers/trending"+period)
+			require.Equal(t, http.StatusOK, rr.Code, period)
+
+			var resp v0.ResponseEnvelope[[]model.TrendingEntry]
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			assert.Equal(t, []model.TrendingEntry{
+				{ServerID: popularID, Name: "io.github.example/popular", Views: 3},
+				{ServerID: quietID, Name: "io.github.example/quiet", Views: 1},
+			}, resp.Data, period)
+		}
+	})
+
+	t.Run("invalid period", func(t *testing.T) {
+		rr := get(t, mux, "/v0/servers/trending?period=month")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("falls back to recent servers when Redis is unavailable", func(t *testing.T) {
+		redisServer.SetError("connection refused")
+		defer redisServer.SetError("")
+
+		rr := get(t, mux, "/v0/servers/trending")
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/v0/servers/recent", rr.Header().Get("Location"))
+
+		// Viewing a server still succeeds
+		assert.Equal(t, http.StatusOK, get(t, mux, "/v0/servers/"+quietID).Code)
+	})
+
+	t.Run("falls back to recent servers when Redis is not configured", func(t *testing.T) {
+		rr := get(t, v0.TrendingHandler(registry, nil), "/v0/servers/trending")
+		assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/v0/servers/recent", rr.Header().Get("Location"))
+	})
+
+	t.Run("recent servers are newest first", func(t *testing.T) {
+		rr := get(t, mux, "/v0/servers/recent")
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp v0.ResponseEnvelope[[]model.Server]
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		require.Len(t, resp.Data, 2)
+		assert.Equal(t, quietID, resp.Data[0].ID)
+		assert.Equal(t, popularID, resp.Data[1].ID)
+		assert.False(t, resp.Data[0].PublishedAt.Before(resp.Data[1].PublishedAt))
+	})
+
+	t.Run("recent servers are limited", func(t *testing.T) {
+		rr := get(t, mux, "/v0/servers/recent?limit=1")
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp v0.ResponseEnvelope[[]model.Server]
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		require.Len(t, resp.Data, 1)
+		assert.Equal(t, quietID, resp.Data[0].ID)
+	})
+
+	t.Run("invalid limits are rejected", func(t *testing.T) {
+		for _, limit := range []string{"0", "-1", "ten"} {
+			rr := get(t, mux, "/v0/servers/recent?limit="+limit)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, limit)
+		}
+	})
+}
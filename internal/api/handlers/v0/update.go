@@ -0,0 +1,128 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// UpdateHandler handles requests to correct the metadata of a published server version without republishing
+// it. The description, packages, and version number and release date of version_detail may be changed; fields
+// left out of the request keep their value and the packages list is replaced as a whole. Changing the ID, name
+// or repository ID is rejected, and other fields are ignored. Only the current publisher of the server, one of
+// its maintainers or the registry owner may update it.
+func UpdateHandler(registry service.RegistryService, authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Only allow PUT method
+		if r.Method != http.MethodPut {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Extract the server ID from the URL path
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		serverDetail, updatedBy, ok := authorizePublisher(w, r, registry, authService, id)
+		if !ok {
+			return
+		}
+
+		// Parse request body
+		var req model.ServerDetail
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidPayload, Message: "Invalid request payload: " + err.Error()}, http.StatusBadRequest)
+			return
+		}
+		defer r.Body.Close()
+
+		switch {
+		case req.ID != "" && req.ID != serverDetail.ID:
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "The server ID cannot be changed"}, http.StatusBadRequest)
+			return
+		case req.Name != "" && req.Name != serverDetail.Name:
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "The server name cannot be changed"}, http.StatusBadRequest)
+			return
+		case req.Repository.ID != "" && req.Repository.ID != serverDetail.Repository.ID:
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "The repository ID cannot be changed"}, http.StatusBadRequest)
+			return
+		case req.Packages != nil && len(req.Packages) == 0:
+			WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "At least one package is required"}, http.StatusBadRequest)
+			return
+		}
+
+		if !checkIfMatch(w, r, serverDetail) {
+			return
+		}
+
+		updated := *serverDetail
+		if req.Description != "" {
+			updated.Description = req.Description
+		}
+		if req.Packages != nil {
+			updated.Packages = slices.Clone(req.Packages)
+		}
+		if req.VersionDetail.Version != "" {
+			updated.VersionDetail.Version = req.VersionDetail.Version
+		}
+		if req.VersionDetail.ReleaseDate != "" {
+			updated.VersionDetail.ReleaseDate = req.VersionDetail.ReleaseDate
+		}
+
+		if err := registry.Update(id, &updated); err != nil {
+			if writeValidationErrors(w, err) {
+				return
+			}
+			switch {
+			case errors.Is(err, database.ErrNotFound):
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Server not found"}, http.StatusNotFound)
+			case errors.Is(err, database.ErrAlreadyExists):
+				WriteError(w, APIError{
+					Code:    ErrCodeInvalidVersion,
+					Message: "Another version of the server has version " + updated.VersionDetail.Version,
+				}, http.StatusBadRequest)
+			case errors.Is(err, database.ErrInvalidVersion):
+				WriteError(w, APIError{
+					Code:    ErrCodeInvalidVersion,
+					Message: "The latest version must remain the highest version of the server",
+				}, http.StatusBadRequest)
+			default:
+				middleware.Logf(r.Context(), "update: Failed to update server %s: %v", id, err)
+				WriteError(w, APIError{
+					Code:    ErrCodeInternal,
+					Message: "Failed to update server: " + err.Error(),
+				}, http.StatusInternalServerError)
+			}
+			return
+		}
+
+		middleware.Logf(r.Context(), "update: Updated server %s (ID: %s) by %s from %s", serverDetail.Name, id, updatedBy, middleware.GetRealIP(r))
+
+		result, err := registry.GetByID(id)
+		if err != nil {
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Error retrieving server details"}, http.StatusInternalServerError)
+			return
+		}
+
+		etag, err := serverETag(result)
+		if err != nil {
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to compute ETag"}, http.StatusInternalServerError)
+			return
+		}
+		result.RedactContactEmail()
+
+		w.Header().Set("ETag", etag)
+		writeJSON(w, result)
+	}
+}
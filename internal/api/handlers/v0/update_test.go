@@ -0,0 +1,151 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// updateServer calls the update handler with the JSON body as the user described by claims,
+// or the registry owner if claims is nil
+func updateServer(
+	t *testing.T, registry service.RegistryService, claims *auth.EphemeralTokenClaims, id, body string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+	mockAuthService := new(MockAuthService)
+	mockAuthService.Mock.On("ValidateEphemeralOrOwnerToken", mock.Anything, "test-token").Return(true, claims, nil)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPut, "/v0/servers/"+id, bytes.NewBufferString(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer test-token")
+	req.SetPathValue("id", id)
+
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.UpdateHandler(registry, mockAuthService), rr, req)
+	return rr
+}
+
+func TestUpdateHandler(t *testing.T) {
+	publisherClaims := &auth.EphemeralTokenClaims{GitHubUsername: "example"}
+
+	t.Run("publisher corrects the mutable fields", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersionBy(t, registry, "1.0.0", "example")
+
+		rr := updateServer(t, registry, publisherClaims, id, `{
+			"name": "io.github.example/owned-server",
+			"description": "Corrected description",
+			"packages": [{"registry_name": "npm", "name": "@example/server", "version": "1.0.1"}],
+			"version_detail": {"version": "1.0.1", "release_date": "2025-05-17T17:34:22Z"},
+			"tags": ["ignored"]
+		}`)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.NotEmpty(t, rr.Header().Get("ETag"))
+
+		var resp model.ServerDetail
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		assert.Equal(t, id, resp.ID)
+		assert.Equal(t, "Corrected description", resp.Description)
+		require.Len(t, resp.Packages, 1)
+		assert.Equal(t, "@example/server", resp.Packages[0].Name)
+		assert.Equal(t, "1.0.1", resp.VersionDetail.Version)
+		assert.Equal(t, "2025-05-17T17:34:22Z", resp.VersionDetail.ReleaseDate)
+		assert.True(t, resp.VersionDetail.IsLatest)
+		assert.Empty(t, resp.Tags)
+
+		stored := getServer(t, registry, id)
+		assert.Equal(t, "Corrected description", stored.Description)
+		assert.Equal(t, "1.0.1", stored.VersionDetail.Version)
+	})
+
+	t.Run("fields left out keep their value", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersionBy(t, registry, "1.0.0", "")
+
+		rr := updateServer(t, registry, nil, id, `{"description": "Only the description"}`)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		stored := getServer(t, registry, id)
+		assert.Equal(t, "Only the description", stored.Description)
+		assert.Equal(t, "1.0.0", stored.VersionDetail.Version)
+	})
+
+	t.Run("immutable fields and bad input are rejected", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		oldID := publishVersionBy(t, registry, "1.0.0", "example")
+		latestID := publishVersionBy(t, registry, "1.1.0", "example")
+
+		for name, body := range map[string]string{
+			"id":             `{"id": "00000000-0000-0000-0000-000000000000"}`,
+			"name":           `{"name": "io.github.example/renamed"}`,
+			"repository id":  `{"repository": {"id": "12345"}}`,
+			"no packages":    `{"packages": []}`,
+			"invalid json":   `{"description": `,
+			"taken version":  `{"version_detail": {"version": "1.1.0"}}`,
+			"lowered latest": `{"version_detail": {"version": "0.9.0"}}`,
+		} {
+			target := latestID
+			if name == "taken version" {
+				target = oldID
+			}
+			rr := updateServer(t, registry, publisherClaims, target, body)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, name+": "+rr.Body.String())
+		}
+
+		// Invalid server details are reported field by field, as on publish
+		rr := updateServer(t, registry, publisherClaims, latestID, `{"packages": [{"registry_name": "npm"}]}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		assert.Contains(t, rr.Body.String(), "packages[0].name")
+
+		assert.Equal(t, "1.0.0", getServer(t, registry, oldID).VersionDetail.Version)
+		assert.Equal(t, "1.1.0", getServer(t, registry, latestID).VersionDetail.Version)
+	})
+
+	t.Run("other users are forbidden", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersionBy(t, registry, "1.0.0", "example")
+
+		rr := updateServer(t, registry, &auth.EphemeralTokenClaims{GitHubUsername: "someone-else"}, id, `{"description": "Hijacked"}`)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.NotEqual(t, "Hijacked", getServer(t, registry, id).Description)
+	})
+
+	t.Run("ownership follows transfers and maintainers", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersionBy(t, registry, "1.0.0", "example")
+
+		rr := transferServer(t, registry, publisherClaims, id, "new-owner", true, nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+		newOwnerClaims := &auth.EphemeralTokenClaims{GitHubUsername: "new-owner"}
+		rr = addMaintainer(t, registry, newOwnerClaims, id, "co-maintainer", true)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		rr = updateServer(t, registry, publisherClaims, id, `{"description": "Stale owner"}`)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		rr = updateServer(t, registry, newOwnerClaims, id, `{"description": "New owner"}`)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		rr = updateServer(t, registry, &auth.EphemeralTokenClaims{GitHubUsername: "co-maintainer"}, id, `{"description": "Maintainer"}`)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Equal(t, "Maintainer", getServer(t, registry, id).Description)
+	})
+
+	t.Run("unknown server", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		rr := updateServer(t, registry, nil, "00000000-0000-0000-0000-000000000000", `{"description": "Missing"}`)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
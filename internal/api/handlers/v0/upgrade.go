@@ -0,0 +1,86 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// UpgradeVersionRequest represents the request body for promoting a server version to latest
+type UpgradeVersionRequest struct {
+	Version string `json:"version"`
+}
+
+// UpgradeVersionHandler handles requests to promote an existing version of a server to latest without
+// re-publishing it. Any version may be promoted, including older ones. Unlike a pin, the promoted version
+// is not kept as latest once a newer version is published.
+func UpgradeVersionHandler(registry service.RegistryService, authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Only allow POST method
+		if r.Method != http.MethodPost {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Extract the server ID from the URL path
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		// Parse request body
+		var req UpgradeVersionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidPayload, Message: "Invalid request payload: " + err.Error()}, http.StatusBadRequest)
+			return
+		}
+		defer r.Body.Close()
+
+		if req.Version == "" {
+			WriteError(w, APIError{Code: ErrCodeInvalidVersion, Message: "Version is required"}, http.StatusBadRequest)
+			return
+		}
+
+		serverDetail, ok := authorizeServerModification(w, r, registry, authService, id)
+		if !ok || !checkIfMatch(w, r, serverDetail) {
+			return
+		}
+
+		if err := registry.UpgradeVersion(r.Context(), id, req.Version); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Version not found"}, http.StatusNotFound)
+				return
+			}
+			if errors.Is(err, database.ErrInvalidInput) {
+				WriteError(w, APIError{
+					Code:    ErrCodeInvalidVersion,
+					Message: "Yanked versions cannot be promoted to latest",
+				}, http.StatusBadRequest)
+				return
+			}
+			middleware.Logf(r.Context(), "upgrade: Failed to promote version %s of server %s: %v", req.Version, id, err)
+			WriteError(w, APIError{
+				Code:    ErrCodeInternal,
+				Message: "Failed to upgrade version: " + err.Error(),
+			}, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(map[string]any{
+			"message": "Version promoted to latest",
+			"name":    serverDetail.Name,
+			"version": req.Version,
+		}); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to encode response"}, http.StatusInternalServerError)
+			return
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func upgradeVersion(
+	t *testing.T, registry service.RegistryService, claims *auth.EphemeralTokenClaims, id, version string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+	mockAuthService := new(MockAuthService)
+	mockAuthService.Mock.On("ValidateEphemeralOrOwnerToken", mock.Anything, "test-token").Return(true, claims, nil)
+
+	body, err := json.Marshal(v0.UpgradeVersionRequest{Version: version})
+	require.NoError(t, err)
+
+	req, err := http.NewRequestWithContext(
+		context.Background(), http.MethodPost, "/v0/servers/"+id+"/upgrade", bytes.NewReader(body),
+	)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer test-token")
+	req.SetPathValue("id", id)
+
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.UpgradeVersionHandler(registry, mockAuthService), rr, req)
+	return rr
+}
+
+func TestUpgradeVersionHandler(t *testing.T) {
+	publisherClaims := &auth.EphemeralTokenClaims{GitHubUsername: "example"}
+
+	t.Run("promotes a version to latest", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		stableID := publishVersion(t, registry, "1.0.0")
+		betaID := publishVersion(t, registry, "2.0.0-beta")
+
+		rr := upgradeVersion(t, registry, publisherClaims, stableID, "2.0.0-beta")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), "Version promoted to latest")
+
+		assert.False(t, getServer(t, registry, stableID).VersionDetail.IsLatest)
+		beta := getServer(t, registry, betaID)
+		assert.True(t, beta.VersionDetail.IsLatest)
+		assert.False(t, beta.PinnedVersion)
+	})
+
+	t.Run("downgrades to an older version until a new one is published", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		oldID := publishVersion(t, registry, "1.0.0")
+		newID := publishVersion(t, registry, "2.0.0")
+
+		rr := upgradeVersion(t, registry, nil, newID, "1.0.0")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		old := getServer(t, registry, oldID)
+		assert.True(t, old.VersionDetail.IsLatest)
+		assert.False(t, old.PinnedVersion)
+		assert.False(t, getServer(t, registry, newID).VersionDetail.IsLatest)
+
+		// The downgrade is not a pin, so publishing a new version makes it the latest
+		latestID := publishVersion(t, registry, "2.1.0")
+		assert.False(t, getServer(t, registry, oldID).VersionDetail.IsLatest)
+		assert.True(t, getServer(t, registry, latestID).VersionDetail.IsLatest)
+	})
+
+	t.Run("non-existent version", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersion(t, registry, "1.0.0")
+
+		rr := upgradeVersion(t, registry, publisherClaims, id, "9.9.9")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Version not found")
+		assert.True(t, getServer(t, registry, id).VersionDetail.IsLatest)
+	})
+
+	t.Run("other users cannot upgrade", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersion(t, registry, "1.0.0")
+
+		rr := upgradeVersion(t, registry, &auth.EphemeralTokenClaims{GitHubUsername: "someone-else"}, id, "1.0.0")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("invalidates cached servers", func(t *testing.T) {
+		registry := service.NewCachedRegistryService(
+			service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{})), time.Hour,
+		)
+		oldID := publishVersion(t, registry, "1.0.0")
+		newID := publishVersion(t, registry, "2.0.0")
+
+		// Prime the cache with both versions
+		assert.False(t, getServer(t, registry, oldID).VersionDetail.IsLatest)
+		assert.True(t, getServer(t, registry, newID).VersionDetail.IsLatest)
+
+		rr := upgradeVersion(t, registry, publisherClaims, newID, "1.0.0")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		assert.True(t, getServer(t, registry, oldID).VersionDetail.IsLatest)
+		assert.False(t, getServer(t, registry, newID).VersionDetail.IsLatest)
+	})
+}
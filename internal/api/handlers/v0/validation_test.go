@@ -0,0 +1,59 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishOSSValidationErrors(t *testing.T) {
+	stubGitHubRepoAPI(t)
+	authService := auth.NewAuthService(&config.Config{EphemeralTokenSecret: testEphemeralTokenSecret})
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+
+	ossReq := ossRepository("invalid-server")
+	ossReq.Category = "blockchain"
+	ossReq.Packages = append(ossReq.Packages, model.Package{RegistryName: "Docker", Name: "example/image", Version: "1.0.0"})
+	body, err := json.Marshal(ossReq)
+	require.NoError(t, err)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/publish-oss", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+signEphemeralToken(t, time.Hour))
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.PublishOSSHandler(registry, authService), rr, req)
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+	var resp v0.ValidationErrorResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Equal(t, "Validation failed", resp.Error)
+	fields := make([]string, len(resp.Errors))
+	for i, validationErr := range resp.Errors {
+		fields[i] = validationErr.Field
+		assert.NotEmpty(t, validationErr.Message)
+	}
+	assert.Equal(t, []string{"category", "packages[1].registry_name"}, fields)
+
+	// Nothing was published
+	servers, _, err := registry.Search(service.SearchOptions{
+		Query:     "io.github.example/invalid-server",
+		Direction: database.CursorNext,
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, servers)
+}
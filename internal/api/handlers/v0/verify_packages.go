@@ -0,0 +1,107 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/vcs"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// verifyPackagesTimeout bounds how long checking all the packages of a server may take
+	verifyPackagesTimeout = 10 * time.Second
+	// verifyPackagesInterval is how often a client may verify the packages of the same server
+	verifyPackagesInterval = 10 * time.Minute
+	// verifyPackagesConcurrency is how many package registries are queried at once
+	verifyPackagesConcurrency = 4
+)
+
+// PackageAvailability is whether the registry of a package still serves it
+type PackageAvailability struct {
+	RegistryName string    `json:"registry_name"`
+	Name         string    `json:"name"`
+	Version      string    `json:"version"`
+	Available    bool      `json:"available"`
+	CheckedAt    time.Time `json:"checked_at"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// VerifyPackagesResponse represents the availability of the packages of a server
+type VerifyPackagesResponse struct {
+	Packages []PackageAvailability `json:"packages"`
+}
+
+// VerifyPackagesHandler returns a handler that checks whether the packages of a server can still be installed
+// from npm, PyPI and Docker Hub. The registries are queried in parallel on every request, and each client may
+// verify a server every ten minutes. Nothing is stored.
+func VerifyPackagesHandler(registry service.RegistryService) http.HandlerFunc {
+	limiter := newServerRateLimiter(verifyPackagesInterval)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		serverDetail, err := registry.GetByID(id)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Server not found"}, http.StatusNotFound)
+				return
+			}
+			middleware.Logf(r.Context(), "verify-packages: Failed to look up server %s: %v", id, err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Error retrieving server"}, http.StatusInternalServerError)
+			return
+		}
+
+		if !limiter.allow(id, middleware.GetRealIP(r), time.Now()) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(verifyPackagesInterval.Seconds())))
+			WriteError(w, APIError{
+				Code:    ErrCodeRateLimited,
+				Message: "Server packages may be verified every ten minutes",
+			}, http.StatusTooManyRequests)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), verifyPackagesTimeout)
+		defer cancel()
+
+		results := make([]PackageAvailability, len(serverDetail.Packages))
+		group, groupCtx := errgroup.WithContext(ctx)
+		group.SetLimit(verifyPackagesConcurrency)
+		for i, pkg := range serverDetail.Packages {
+			group.Go(func() error {
+				available, err := vcs.PackageAvailable(groupCtx, pkg.RegistryName, pkg.Name, pkg.Version)
+				results[i] = PackageAvailability{
+					RegistryName: pkg.RegistryName,
+					Name:         pkg.Name,
+					Version:      pkg.Version,
+					Available:    available,
+					CheckedAt:    time.Now().UTC(),
+				}
+				if err != nil {
+					results[i].Error = err.Error()
+				}
+				// A failed check is reported with its package rather than cancelling the others
+				return nil
+			})
+		}
+		_ = group.Wait()
+
+		writeJSON(w, VerifyPackagesResponse{Packages: results})
+	}
+}
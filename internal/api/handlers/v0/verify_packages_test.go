@@ -0,0 +1,130 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyPackagesHandler(t *testing.T) {
+	var mu sync.Mutex
+	var requested []string
+	original := http.DefaultTransport
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		requested = append(requested, req.URL.Host)
+		mu.Unlock()
+
+		status, body := http.StatusNotFound, `{}`
+		switch req.URL.Host + req.URL.EscapedPath() {
+		case "registry.npmjs.org/@example%2Fserver/1.0.0":
+			status, body = http.StatusOK, `{"name": "@example/server"}`
+		case "pypi.org/simple/example-server/":
+			status, body = http.StatusOK, `{"versions": ["0.9.0"]}`
+		case "hub.docker.com/v2/namespaces/example/repositories/server/tags/1.0.0":
+			status = http.StatusInternalServerError
+		}
+		return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+	t.Cleanup(func() {
+		http.DefaultTransport = original
+	})
+
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	serverDetail := &model.ServerDetail{
+		Server: model.Server{
+			Name:          "io.github.example/verify-packages",
+			Repository:    model.Repository{URL: "https://github.com/example/verify-packages", Source: "github"},
+			VersionDetail: model.VersionDetail{Version: "1.0.0"},
+		},
+		Packages: []model.Package{
+			{RegistryName: "npm", Name: "@example/server", Version: "1.0.0"},
+			{RegistryName: "pypi", Name: "example-server", Version: "1.0.0"},
+			{RegistryName: "docker", Name: "example/server", Version: "1.0.0"},
+			{RegistryName: "cargo", Name: "example-server", Version: "1.0.0"},
+		},
+	}
+	require.NoError(t, registry.Publish(serverDetail))
+
+	verify := func(t *testing.T, handler http.Handler, id, clientIP string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers/"+id+"/verify-packages", nil)
+		require.NoError(t, err)
+		req.SetPathValue("id", id)
+		req.RemoteAddr = clientIP + ":1234"
+		rr := httptest.NewRecorder()
+		serveHTTP(t, handler, rr, req)
+		return rr
+	}
+
+	t.Run("reports the availability of each package", func(t *testing.T) {
+		rr := verify(t, v0.VerifyPackagesHandler(registry), serverDetail.ID, "192.0.2.1")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var resp v0.VerifyPackagesResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		require.Len(t, resp.Packages, 4)
+
+		npm := resp.Packages[0]
+		assert.Equal(t, "npm", npm.RegistryName)
+		assert.Equal(t, "@example/server", npm.Name)
+		assert.Equal(t, "1.0.0", npm.Version)
+		assert.True(t, npm.Available)
+		assert.Empty(t, npm.Error)
+		assert.False(t, npm.CheckedAt.IsZero())
+
+		pypi := resp.Packages[1]
+		assert.False(t, pypi.Available, "version 1.0.0 is not on PyPI")
+		assert.Empty(t, pypi.Error)
+
+		docker := resp.Packages[2]
+		assert.False(t, docker.Available)
+		assert.Contains(t, docker.Error, "status 500")
+
+		cargo := resp.Packages[3]
+		assert.False(t, cargo.Available)
+		assert.Contains(t, cargo.Error, "unsupported package registry")
+
+		assert.ElementsMatch(t, []string{"registry.npmjs.org", "pypi.org", "hub.docker.com"}, requested)
+	})
+
+	t.Run("clients may verify a server every ten minutes", func(t *testing.T) {
+		handler := v0.VerifyPackagesHandler(registry)
+		require.Equal(t, http.StatusOK, verify(t, handler, serverDetail.ID, "192.0.2.2").Code)
+
+		rr := verify(t, handler, serverDetail.ID, "192.0.2.2")
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+		assert.Equal(t, "600", rr.Header().Get("Retry-After"))
+
+		assert.Equal(t, http.StatusOK, verify(t, handler, serverDetail.ID, "192.0.2.3").Code)
+	})
+
+	t.Run("verifying does not modify the server", func(t *testing.T) {
+		stored, err := registry.GetByID(serverDetail.ID)
+		require.NoError(t, err)
+		assert.Equal(t, serverDetail.Packages, stored.Packages)
+	})
+
+	t.Run("unknown server", func(t *testing.T) {
+		rr := verify(t, v0.VerifyPackagesHandler(registry), uuid.New().String(), "192.0.2.1")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("invalid server ID", func(t *testing.T) {
+		rr := verify(t, v0.VerifyPackagesHandler(registry), "not-a-uuid", "192.0.2.1")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -0,0 +1,23 @@
+package v0
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/modelcontextprotocol/registry/internal/build"
+)
+
+// VersionHandler returns a handler for the version endpoint, which reports the build of the running registry
+func VersionHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(build.Get()); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to encode response"}, http.StatusInternalServerError)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/build"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionHandler(t *testing.T) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/version", nil)
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.VersionHandler(), rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+	var info build.Info
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &info))
+	assert.NotEmpty(t, info.Version)
+	assert.Equal(t, build.Version, info.Version)
+	assert.Equal(t, build.GitCommit, info.GitCommit)
+	assert.Equal(t, build.BuildTime, info.BuildTime)
+	assert.Equal(t, runtime.Version(), info.GoVersion)
+}
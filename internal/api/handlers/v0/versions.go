@@ -0,0 +1,122 @@
+package v0
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// defaultVersionsLimit is the number of versions returned per page when no limit is given
+const defaultVersionsLimit = 30
+
+// VersionsHandler returns a handler listing the versions of a server in publish order, paginated with
+// ?cursor= and ?limit= like the server list. Yanked versions are only listed with ?include_yanked=true.
+func VersionsHandler(registry service.RegistryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		generatedAt := time.Now().UTC()
+
+		if r.Method != http.MethodGet {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		includeYanked := false
+		if value := r.URL.Query().Get("include_yanked"); value != "" {
+			var err error
+			includeYanked, err = strconv.ParseBool(value)
+			if err != nil {
+				WriteError(w, APIError{Code: ErrCodeInvalidRequest, Message: "Invalid include_yanked parameter"}, http.StatusBadRequest)
+				return
+			}
+		}
+
+		cursor, direction, ok := parseCursor(w, r)
+		if !ok {
+			return
+		}
+
+		limit := defaultVersionsLimit
+		if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+			parsedLimit, err := strconv.Atoi(limitStr)
+			if err != nil {
+				WriteError(w, APIError{Code: ErrCodeInvalidLimit, Message: "Invalid limit parameter"}, http.StatusBadRequest)
+				return
+			}
+			if parsedLimit <= 0 {
+				WriteError(w, APIError{Code: ErrCodeInvalidLimit, Message: "Limit must be greater than 0"}, http.StatusBadRequest)
+				return
+			}
+			limit = min(parsedLimit, maxLimit)
+		}
+
+		versions, cursors, err := registry.ListVersions(r.Context(), id, includeYanked, cursor, direction, limit)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Server not found"}, http.StatusNotFound)
+				return
+			}
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Error retrieving versions"}, http.StatusInternalServerError)
+			return
+		}
+
+		response := NewResponseEnvelope(versions, generatedAt)
+		response.Metadata = paginationMetadata(cursors, len(versions))
+		writeJSON(w, response)
+	}
+}
+
+// YankVersionHandler handles requests to yank a version of a server. Yanked versions stay in the
+// registry but are never shown as latest; yanking the latest version makes the highest remaining
+// version the latest.
+func YankVersionHandler(registry service.RegistryService, authService auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Only allow DELETE method
+		if r.Method != http.MethodDelete {
+			WriteError(w, APIError{Code: ErrCodeMethodNotAllowed, Message: "Method not allowed"}, http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Extract the server ID and version from the URL path
+		id := r.PathValue("id")
+		if _, err := uuid.Parse(id); err != nil {
+			WriteError(w, APIError{Code: ErrCodeInvalidID, Message: "Invalid server ID format"}, http.StatusBadRequest)
+			return
+		}
+
+		version := r.PathValue("version")
+		if version == "" {
+			WriteError(w, APIError{Code: ErrCodeInvalidVersion, Message: "Version is required"}, http.StatusBadRequest)
+			return
+		}
+
+		serverDetail, ok := authorizeServerModification(w, r, registry, authService, id)
+		if !ok || !checkIfMatch(w, r, serverDetail) {
+			return
+		}
+
+		if err := registry.YankVersion(r.Context(), id, version); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				WriteError(w, APIError{Code: ErrCodeNotFound, Message: "Version not found"}, http.StatusNotFound)
+				return
+			}
+			middleware.Logf(r.Context(), "versions: Failed to yank version %s of server %s: %v", version, id, err)
+			WriteError(w, APIError{Code: ErrCodeInternal, Message: "Failed to yank version: " + err.Error()}, http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,214 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// yankVersion calls the yank version handler as the user described by claims
+func yankVersion(
+	t *testing.T, registry service.RegistryService, claims *auth.EphemeralTokenClaims, id, version string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+	mockAuthService := new(MockAuthService)
+	mockAuthService.Mock.On("ValidateEphemeralOrOwnerToken", mock.Anything, "test-token").Return(true, claims, nil)
+
+	req, err := http.NewRequestWithContext(
+		context.Background(), http.MethodDelete, "/v0/servers/"+id+"/versions/"+version, nil,
+	)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer test-token")
+	req.SetPathValue("id", id)
+	req.SetPathValue("version", version)
+
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.YankVersionHandler(registry, mockAuthService), rr, req)
+	return rr
+}
+
+// listVersions calls the versions handler with the given query string
+func listVersions(t *testing.T, registry service.RegistryService, id, query string) []model.ServerVersion {
+	t.Helper()
+	return listVersionsPage(t, registry, id, query).Data
+}
+
+// listVersionsPage calls the versions handler with the given query string and returns the whole response
+func listVersionsPage(
+	t *testing.T, registry service.RegistryService, id, query string,
+) v0.ResponseEnvelope[[]model.ServerVersion] {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers/"+id+"/versions"+query, nil)
+	require.NoError(t, err)
+	req.SetPathValue("id", id)
+
+	rr := httptest.NewRecorder()
+	serveHTTP(t, v0.VersionsHandler(registry), rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp v0.ResponseEnvelope[[]model.ServerVersion]
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	return resp
+}
+
+func TestYankVersionHandler(t *testing.T) {
+	publisherClaims := &auth.EphemeralTokenClaims{GitHubUsername: "example"}
+
+	t.Run("yanking the latest version moves latest to the highest remaining version", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		oldID := publishVersion(t, registry, "1.0.0")
+		stableID := publishVersion(t, registry, "1.1.0")
+		brokenID := publishVersion(t, registry, "1.2.0")
+
+		rr := yankVersion(t, registry, publisherClaims, oldID, "1.2.0")
+		require.Equal(t, http.StatusNoContent, rr.Code)
+
+		broken := getServer(t, registry, brokenID)
+		assert.True(t, broken.VersionDetail.Yanked)
+		assert.False(t, broken.VersionDetail.IsLatest)
+		assert.True(t, getServer(t, registry, stableID).VersionDetail.IsLatest)
+		assert.False(t, getServer(t, registry, oldID).VersionDetail.IsLatest)
+
+		// Yanking again is a no-op
+		rr = yankVersion(t, registry, publisherClaims, oldID, "1.2.0")
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	})
+
+	t.Run("yanking an older version keeps the latest version", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		oldID := publishVersion(t, registry, "1.0.0")
+		latestID := publishVersion(t, registry, "1.1.0")
+
+		rr := yankVersion(t, registry, publisherClaims, latestID, "1.0.0")
+		require.Equal(t, http.StatusNoContent, rr.Code)
+
+		assert.True(t, getServer(t, registry, oldID).VersionDetail.Yanked)
+		assert.True(t, getServer(t, registry, latestID).VersionDetail.IsLatest)
+	})
+
+	t.Run("yanking a pinned version releases the pin", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		pinnedID := publishVersion(t, registry, "1.0.0")
+		latestID := publishVersion(t, registry, "1.1.0")
+		require.Equal(t, http.StatusOK, pinVersion(t, registry, publisherClaims, latestID, "1.0.0").Code)
+
+		rr := yankVersion(t, registry, publisherClaims, latestID, "1.0.0")
+		require.Equal(t, http.StatusNoContent, rr.Code)
+
+		pinned := getServer(t, registry, pinnedID)
+		assert.False(t, pinned.PinnedVersion)
+		assert.False(t, pinned.VersionDetail.IsLatest)
+		assert.True(t, getServer(t, registry, latestID).VersionDetail.IsLatest)
+
+		// A yanked version cannot be pinned again
+		rr = pinVersion(t, registry, publisherClaims, latestID, "1.0.0")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("non-publisher is rejected", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersion(t, registry, "1.0.0")
+
+		rr := yankVersion(t, registry, &auth.EphemeralTokenClaims{GitHubUsername: "someone-else"}, id, "1.0.0")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.False(t, getServer(t, registry, id).VersionDetail.Yanked)
+	})
+
+	t.Run("version not found", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		id := publishVersion(t, registry, "1.0.0")
+
+		rr := yankVersion(t, registry, publisherClaims, id, "9.9.9")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestVersionsHandler(t *testing.T) {
+	publisherClaims := &auth.EphemeralTokenClaims{GitHubUsername: "example"}
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	oldID := publishVersion(t, registry, "1.0.0")
+	latestID := publishVersion(t, registry, "1.1.0")
+	require.Equal(t, http.StatusNoContent, yankVersion(t, registry, publisherClaims, latestID, "1.1.0").Code)
+
+	t.Run("yanked versions are excluded by default", func(t *testing.T) {
+		versions := listVersions(t, registry, latestID, "")
+		require.Len(t, versions, 1)
+		assert.Equal(t, oldID, versions[0].ID)
+		assert.Equal(t, "1.0.0", versions[0].Version)
+		assert.True(t, versions[0].IsLatest)
+	})
+
+	t.Run("yanked versions are included with a flag", func(t *testing.T) {
+		versions := listVersions(t, registry, oldID, "?include_yanked=true")
+		require.Len(t, versions, 2)
+		assert.Equal(t, "1.0.0", versions[0].Version)
+		assert.False(t, versions[0].Yanked)
+		assert.Equal(t, latestID, versions[1].ID)
+		assert.True(t, versions[1].Yanked)
+		assert.False(t, versions[1].IsLatest)
+	})
+
+	t.Run("yanked flag is part of the response", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(
+			context.Background(), http.MethodGet, "/v0/servers/"+oldID+"/versions?include_yanked=true", nil,
+		)
+		require.NoError(t, err)
+		req.SetPathValue("id", oldID)
+		rr := httptest.NewRecorder()
+		serveHTTP(t, v0.VersionsHandler(registry), rr, req)
+		assert.Contains(t, rr.Body.String(), `"version":"1.1.0","release_date"`)
+		assert.Contains(t, rr.Body.String(), `"yanked":true`)
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		for _, target := range []struct{ id, query string }{
+			{id: "not-a-uuid"},
+			{id: oldID, query: "?include_yanked=maybe"},
+			{id: oldID, query: "?limit=abc"},
+			{id: oldID, query: "?limit=0"},
+			{id: oldID, query: "?cursor=!!!"},
+		} {
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers/versions"+target.query, nil)
+			require.NoError(t, err)
+			req.SetPathValue("id", target.id)
+			rr := httptest.NewRecorder()
+			serveHTTP(t, v0.VersionsHandler(registry), rr, req)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, target)
+		}
+	})
+
+	t.Run("versions are paginated", func(t *testing.T) {
+		thirdID := publishVersion(t, registry, "1.2.0")
+
+		first := listVersionsPage(t, registry, oldID, "?include_yanked=true&limit=2")
+		require.Len(t, first.Data, 2)
+		assert.Equal(t, oldID, first.Data[0].ID)
+		assert.Equal(t, latestID, first.Data[1].ID)
+		require.NotNil(t, first.Metadata)
+		require.NotEmpty(t, first.Metadata.NextCursor)
+
+		second := listVersionsPage(t, registry, oldID, "?include_yanked=true&limit=2&cursor="+first.Metadata.NextCursor)
+		require.Len(t, second.Data, 1)
+		assert.Equal(t, thirdID, second.Data[0].ID)
+		assert.Equal(t, "1.2.0", second.Data[0].Version)
+		assert.NotEmpty(t, second.Data[0].ReleaseDate)
+		require.NotNil(t, second.Metadata)
+		assert.Empty(t, second.Metadata.NextCursor)
+		assert.NotEmpty(t, second.Metadata.PrevCursor)
+
+		unpaged := listVersionsPage(t, registry, oldID, "")
+		assert.Len(t, unpaged.Data, 2)
+		assert.Nil(t, unpaged.Metadata)
+	})
+}
@@ -4,17 +4,58 @@ package router
 import (
 	"net/http"
 
+	"github.com/modelcontextprotocol/registry/internal/analytics"
+	"github.com/modelcontextprotocol/registry/internal/api/versioning"
 	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/events"
+	"github.com/modelcontextprotocol/registry/internal/metrics"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
 	"github.com/modelcontextprotocol/registry/internal/service"
 )
 
 // New creates a new router with all API versions registered
-func New(cfg *config.Config, registry service.RegistryService, authService auth.Service) *http.ServeMux {
+func New(
+	cfg *config.Config, registry service.RegistryService, authService auth.Service, hub *events.Hub, trending *analytics.Trending,
+) *http.ServeMux {
 	mux := http.NewServeMux()
 
 	// Register routes for all API versions
-	RegisterV0Routes(mux, cfg, registry, authService)
+	RegisterV0Routes(mux, cfg, registry, authService, hub, trending)
 
-	return mux
+	// Shortcut routes overlap the API routes without being more specific, which a single ServeMux rejects.
+	// They are registered on a parent mux that hands all other requests to the API routes.
+	root := http.NewServeMux()
+	RegisterV0ShortcutRoutes(root, registry, trending)
+	root.Handle("/", mux)
+
+	// Metrics are served here unless the operator moved them to a separate address
+	if cfg.MetricsAddress == "" {
+		root.Handle("GET /metrics", metrics.Default)
+	}
+
+	return root
+}
+
+// NewHandler creates the handler serving all API versions: the router wrapped in the middleware every request
+// passes through, outermost first. Security headers are only set if enabled in the configuration, and rate limits
+// only apply if configured. Metrics wrap the router directly so they can label requests with the matched route.
+func NewHandler(
+	cfg *config.Config, registry service.RegistryService, authService auth.Service, hub *events.Hub, trending *analytics.Trending,
+) http.Handler {
+	var chain []func(http.Handler) http.Handler
+	if cfg.SecurityHeaders {
+		chain = append(chain, middleware.SecurityHeaders(cfg))
+	}
+	chain = append(chain,
+		middleware.RealIP(cfg),
+		middleware.RequestID,
+		middleware.RateLimit(cfg.RateLimitRPM, cfg.RateLimitBurst),
+		middleware.Logging,
+		middleware.Compress,
+		versioning.SetVersionHeader,
+		middleware.Metrics(metrics.Default),
+	)
+
+	return middleware.Chain(New(cfg, registry, authService, hub, trending), chain...)
 }
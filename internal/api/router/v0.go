@@ -4,27 +4,130 @@ package router
 import (
 	"net/http"
 
+	"github.com/modelcontextprotocol/registry/internal/analytics"
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/events"
 	"github.com/modelcontextprotocol/registry/internal/service"
 )
 
 // RegisterV0Routes registers all v0 API routes to the provided router
 func RegisterV0Routes(
-	mux *http.ServeMux, cfg *config.Config, registry service.RegistryService, authService auth.Service,
+	mux *http.ServeMux, cfg *config.Config, registry service.RegistryService, authService auth.Service, hub *events.Hub,
+	trending *analytics.Trending,
 ) {
+	bulkPublishJobs := v0.NewBulkPublishJobs()
+
 	// Register v0 endpoints
 	mux.HandleFunc("/v0/health", v0.HealthHandler(cfg))
 	mux.HandleFunc("/v0/servers", v0.ServersHandler(registry))
-	mux.HandleFunc("/v0/servers/{id}", v0.ServersDetailHandler(registry))
-	mux.HandleFunc("/v0/search", v0.SearchHandler(registry))
+	mux.HandleFunc("/v0/servers/search", v0.SearchHandler(registry, cfg))
+	mux.HandleFunc("/v0/servers/trending", v0.TrendingHandler(registry, trending))
+	mux.HandleFunc("/v0/servers/recent", v0.RecentServersHandler(registry))
+	mux.HandleFunc("/v0/servers/featured", v0.FeaturedServersHandler(registry))
+	mux.HandleFunc("/v0/servers/batch", v0.BatchHandler(registry))
+	serverDetail := v0.ServersDetailHandler(registry, trending)
+	updateServer := v0.UpdateHandler(registry, authService)
+	deleteServer := v0.DeleteHandler(registry, authService)
+	mux.HandleFunc("/v0/servers/{id}", func(w http.ResponseWriter, r *http.Request) {
+		// Reads, updates and deletes share the path, which cannot be split by method without conflicting with
+		// the other /v0/servers/ routes
+		switch r.Method {
+		case http.MethodPut:
+			updateServer(w, r)
+		case http.MethodDelete:
+			deleteServer(w, r)
+		default:
+			serverDetail(w, r)
+		}
+	})
+	mux.HandleFunc("/v0/servers/{id}/metadata", v0.ServerMetadataHandler(registry))
+	mux.HandleFunc("/v0/servers/{id}/qrcode", v0.QRCodeHandler(registry, cfg))
+	mux.HandleFunc("/v0/servers/{id}/schema", v0.SchemaHandler(registry))
+	mux.HandleFunc("/v0/servers/{id}/dependents/count", v0.DependentsCountHandler(registry))
+	mux.HandleFunc("/v0/servers/{id}/network", v0.NetworkHandler(registry))
+	mux.HandleFunc("/v0/servers/{id}/sbom", v0.SBOMHandler(registry))
+	mux.HandleFunc("/v0/servers/{id}/install-script", v0.InstallScriptHandler(registry, cfg))
+	mux.HandleFunc("/v0/servers/{id}/config-template", v0.ConfigTemplateHandler(registry))
+	mux.HandleFunc("/v0/servers/{id}/tools", v0.ToolsHandler(registry))
+	mux.HandleFunc("/v0/servers/{id}/ping", v0.ServerPingHandler(registry, service.NewHealthCheckClient()))
+	mux.HandleFunc("/v0/servers/{id}/verify-packages", v0.VerifyPackagesHandler(registry))
+	mux.HandleFunc("/v0/servers/{id}/changelog", v0.ChangelogHandler(registry))
+	mux.HandleFunc("/v0/servers/{id}/raw-github-metadata", v0.GitHubMetadataHandler(registry, authService))
+	mux.HandleFunc("/v0/servers/{id}/packages", v0.AppendPackagesHandler(registry, authService))
+	mux.HandleFunc("/v0/servers/{id}/packages/{registry}/{name}", v0.RemovePackageHandler(registry, authService))
+	mux.HandleFunc("/v0/servers/{id}/packages/{registry}/yank", v0.YankPackageHandler(registry, authService))
+	mux.HandleFunc("/v0/servers/{id}/tags", v0.AddTagsHandler(registry, authService))
+	mux.HandleFunc("/v0/servers/{id}/tags/{tag}", v0.RemoveTagHandler(registry, authService))
+	mux.HandleFunc("/v0/servers/{id}/pin-version", v0.PinVersionHandler(registry, authService))
+	mux.HandleFunc("/v0/servers/{id}/upgrade", v0.UpgradeVersionHandler(registry, authService))
+	listVersions := v0.VersionsHandler(registry)
+	publishVersion := v0.PublishVersionHandler(registry, authService)
+	mux.HandleFunc("/v0/servers/{id}/versions", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			publishVersion(w, r)
+			return
+		}
+		listVersions(w, r)
+	})
+	mux.HandleFunc("/v0/servers/{id}/versions/{version}", v0.YankVersionHandler(registry, authService))
+	mux.HandleFunc("/v0/servers/{id}/transfer", v0.TransferHandler(registry, authService))
+	mux.HandleFunc("/v0/servers/{id}/status", v0.StatusHandler(registry, authService))
+	mux.HandleFunc("/v0/servers/{id}/maintainers", v0.AddMaintainerHandler(registry, authService))
+	mux.HandleFunc("/v0/servers/{id}/maintainers/{username}", v0.RemoveMaintainerHandler(registry, authService))
+	mux.HandleFunc("/v0/servers/{id}/attest", v0.AttestHandler(registry, authService))
+	mux.HandleFunc("/v0/servers/{id}/provenance", v0.ProvenanceHandler(registry))
+	mux.HandleFunc("/v0/servers/{id}/provenance/{version}", v0.ProvenanceHandler(registry))
+	mux.HandleFunc("/v0/servers/{id}/review", v0.ReviewHandler(registry, authService))
+	mux.HandleFunc("/v0/servers/{id}/reviews", v0.ReviewsHandler(registry))
+	mux.HandleFunc("/v0/servers/{id}/notify-subscribers", v0.NotifySubscribersHandler(registry, authService, hub))
+	mux.HandleFunc("/v0/subscriptions", v0.SubscriptionsHandler(registry, authService))
+	mux.HandleFunc("/v0/subscriptions/{id}", v0.DeleteSubscriptionHandler(registry, authService))
+	mux.HandleFunc("/v0/search", v0.SearchHandler(registry, cfg))
+	mux.HandleFunc("/v0/search/facets", v0.SearchFacetsHandler(registry))
+	mux.HandleFunc("/v0/categories", v0.CategoriesHandler(registry))
+	mux.HandleFunc("/v0/languages", v0.LanguagesHandler(registry))
+	mux.HandleFunc("/v0/topics", v0.TopicsHandler(registry))
+	mux.HandleFunc("/v0/tags", v0.ListTagsHandler(registry))
+	mux.HandleFunc("/v0/stats", v0.StatsHandler(registry))
 	mux.HandleFunc("/v0/ping", v0.PingHandler(cfg))
+	mux.HandleFunc("/v0/version", v0.VersionHandler())
 	mux.HandleFunc("/v0/publish", v0.PublishHandler(registry, authService))
 	mux.HandleFunc("/v0/publish-oss", v0.PublishOSSHandler(registry, authService))
+	mux.HandleFunc("/v0/bulk-publish-oss", v0.BulkPublishOSSHandler(registry, authService, bulkPublishJobs))
 	mux.HandleFunc("/v0/authorize", v0.AuthorizeHandler(authService))
+	mux.HandleFunc("/v0/auth/refresh", v0.RefreshHandler(authService))
+	mux.HandleFunc("/v0/auth/logout", v0.LogoutHandler(authService))
+	mux.HandleFunc("/v0/auth/pat", v0.PATHandler(authService))
+	listAPIKeys := v0.ListAPIKeysHandler(authService)
+	createAPIKey := v0.CreateAPIKeyHandler(authService)
+	mux.HandleFunc("/v0/auth/api-keys", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			createAPIKey(w, r)
+			return
+		}
+		listAPIKeys(w, r)
+	})
+	mux.HandleFunc("/v0/auth/api-keys/{id}", v0.RevokeAPIKeyHandler(authService))
+	mux.HandleFunc("/v0/events", v0.EventsHandler(hub))
+	mux.HandleFunc("/v0/admin/servers", v0.AdminServersHandler(registry, authService))
+	mux.HandleFunc("/v0/admin/reorder-featured", v0.ReorderFeaturedHandler(registry, authService))
+	mux.HandleFunc("/v0/admin/reindex", v0.StartReindexHandler(registry, authService))
+	mux.HandleFunc("/v0/admin/reindex/{job_id}", v0.ReindexStatusHandler(registry, authService))
+	mux.HandleFunc("/v0/admin/vacuum", v0.StartVacuumHandler(registry, authService))
+	mux.HandleFunc("/v0/admin/vacuum/{job_id}", v0.VacuumStatusHandler(registry, authService))
+	mux.HandleFunc("/v0/admin/bulk-publish-jobs/{id}", v0.BulkPublishJobHandler(authService, bulkPublishJobs))
 
 	// Register Swagger UI routes
 	mux.HandleFunc("/v0/swagger/", v0.SwaggerHandler())
 	mux.HandleFunc("/v0/swagger/doc.json", v0.SwaggerJSONHandler())
 }
+
+// RegisterV0ShortcutRoutes registers v0 routes whose patterns conflict with the routes registered by
+// RegisterV0Routes, e.g. /v0/servers/by-repo/{owner}/{repo} and /v0/servers/{id}/versions/{version}
+// both match /v0/servers/by-repo/versions/1.0.0. The provided router must take precedence over them.
+func RegisterV0ShortcutRoutes(mux *http.ServeMux, registry service.RegistryService, trending *analytics.Trending) {
+	mux.HandleFunc("/v0/servers/by-repo/{owner}/{repo}", v0.ServerByRepoHandler(registry))
+	mux.HandleFunc("/v0/servers/by-name/{name...}", v0.ServerByNameHandler(registry, trending))
+}
@@ -2,14 +2,22 @@ package api
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"time"
 
+	"github.com/modelcontextprotocol/registry/internal/analytics"
 	"github.com/modelcontextprotocol/registry/internal/api/router"
 	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/events"
+	"github.com/modelcontextprotocol/registry/internal/metrics"
 	"github.com/modelcontextprotocol/registry/internal/service"
+	"golang.org/x/net/http2"
 )
 
 // Server represents the HTTP server
@@ -17,37 +25,124 @@ type Server struct {
 	config      *config.Config
 	registry    service.RegistryService
 	authService auth.Service
-	router      *http.ServeMux
+	handler     http.Handler
 	server      *http.Server
+	// metricsServer serves the metrics on their own address; it is nil if they are served with the API
+	metricsServer *http.Server
 }
 
 // NewServer creates a new HTTP server
-func NewServer(cfg *config.Config, registryService service.RegistryService, authService auth.Service) *Server {
-	// Create router with all API versions registered
-	mux := router.New(cfg, registryService, authService)
+func NewServer(
+	cfg *config.Config, registryService service.RegistryService, authService auth.Service, hub *events.Hub,
+	trending *analytics.Trending,
+) *Server {
+	// Create the handler with all API versions registered behind the middleware chain
+	handler := router.NewHandler(cfg, registryService, authService, hub, trending)
 
 	server := &Server{
 		config:      cfg,
 		registry:    registryService,
 		authService: authService,
-		router:      mux,
+		handler:     handler,
 		server: &http.Server{
 			Addr:              cfg.ServerAddress,
-			Handler:           mux,
+			Handler:           handler,
 			ReadHeaderTimeout: 10 * time.Second,
 		},
 	}
 
+	if cfg.MetricsAddress != "" {
+		metricsMux := http.NewServeMux()
+		metricsMux.Handle("GET /metrics", metrics.Default)
+		server.metricsServer = &http.Server{
+			Addr:              cfg.MetricsAddress,
+			Handler:           metricsMux,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+	}
+
 	return server
 }
 
-// Start begins listening for incoming HTTP requests
+// Start begins listening for incoming HTTP requests, and for metrics requests if they have their own address
 func (s *Server) Start() error {
-	log.Printf("HTTP server starting on %s", s.config.ServerAddress)
-	return s.server.ListenAndServe()
+	listener, err := net.Listen("tcp", s.config.ServerAddress)
+	if err != nil {
+		return err
+	}
+
+	if s.metricsServer != nil {
+		metricsListener, err := net.Listen("tcp", s.metricsServer.Addr)
+		if err != nil {
+			listener.Close()
+			return err
+		}
+		go s.serveMetrics(metricsListener)
+	}
+
+	return s.Serve(listener)
 }
 
-// Shutdown gracefully shuts down the server
+// serveMetrics accepts metrics requests on the listener until the server shuts down
+func (s *Server) serveMetrics(listener net.Listener) {
+	log.Printf("Metrics server starting on %s", listener.Addr())
+	if err := s.metricsServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Printf("Metrics server failed: %v", err)
+	}
+}
+
+// Serve accepts incoming HTTP requests on the listener.
+// Requests are served over TLS when a certificate is configured, negotiating HTTP/2 if it is enabled.
+// Without a certificate the server speaks plain HTTP/1.1.
+func (s *Server) Serve(listener net.Listener) error {
+	if s.config.TLSCertFile == "" {
+		log.Printf("HTTP server starting on %s (HTTP/1.1)", listener.Addr())
+		return s.server.Serve(listener)
+	}
+
+	if err := s.configureTLS(); err != nil {
+		listener.Close()
+		return err
+	}
+
+	protocols := "HTTP/1.1"
+	if s.config.HTTP2Enabled {
+		protocols = "HTTP/2, HTTP/1.1"
+	}
+	log.Printf("HTTPS server starting on %s (%s)", listener.Addr(), protocols)
+	return s.server.Serve(tls.NewListener(listener, s.server.TLSConfig))
+}
+
+// configureTLS loads the certificate and sets up the protocols offered during the TLS handshake
+func (s *Server) configureTLS() error {
+	cert, err := tls.LoadX509KeyPair(s.config.TLSCertFile, s.config.TLSKeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	s.server.TLSConfig = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if !s.config.HTTP2Enabled {
+		// A non-nil empty map stops net/http from enabling HTTP/2 on its own
+		s.server.TLSNextProto = map[string]func(*http.Server, *tls.Conn, http.Handler){}
+		s.server.TLSConfig.NextProtos = []string{"http/1.1"}
+		return nil
+	}
+
+	// ConfigureServer adds "h2" to the offered protocols and registers the HTTP/2 connection handler
+	return http2.ConfigureServer(s.server, &http2.Server{
+		MaxConcurrentStreams: s.config.HTTP2MaxConcurrentStreams,
+		IdleTimeout:          s.config.HTTP2IdleTimeout,
+		MaxReadFrameSize:     s.config.HTTP2MaxReadFrameSize,
+	})
+}
+
+// Shutdown gracefully shuts down the server and the metrics server
 func (s *Server) Shutdown(ctx context.Context) error {
-	return s.server.Shutdown(ctx)
+	if s.metricsServer == nil {
+		return s.server.Shutdown(ctx)
+	}
+	return errors.Join(s.server.Shutdown(ctx), s.metricsServer.Shutdown(ctx))
 }
@@ -0,0 +1,126 @@
+package api_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/api"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/events"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeSelfSignedCert writes a certificate for 127.0.0.1 and its key to a temporary directory
+func writeSelfSignedCert(t *testing.T) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "registry-test"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
+
+// startServer serves the registry API on a random local port and returns its address
+func startServer(t *testing.T, cfg *config.Config) string {
+	t.Helper()
+	hub := events.NewHub()
+	t.Cleanup(hub.Close)
+	server := api.NewServer(cfg, service.NewFakeRegistryService(), nil, hub, nil)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(func() { _ = server.Shutdown(context.Background()) })
+
+	return listener.Addr().String()
+}
+
+func TestServerProtocols(t *testing.T) {
+	certFile, keyFile := writeSelfSignedCert(t)
+
+	testCases := []struct {
+		name          string
+		cfg           config.Config
+		scheme        string
+		expectedProto string
+	}{
+		{
+			name: "HTTP/2 over TLS",
+			cfg: config.Config{
+				TLSCertFile: certFile, TLSKeyFile: keyFile, HTTP2Enabled: true,
+				HTTP2MaxConcurrentStreams: 100, HTTP2IdleTimeout: time.Minute, HTTP2MaxReadFrameSize: 1 << 20,
+			},
+			scheme:        "https",
+			expectedProto: "HTTP/2.0",
+		},
+		{
+			name:          "HTTP/2 disabled",
+			cfg:           config.Config{TLSCertFile: certFile, TLSKeyFile: keyFile},
+			scheme:        "https",
+			expectedProto: "HTTP/1.1",
+		},
+		{
+			name:          "no certificate falls back to HTTP/1.1",
+			cfg:           config.Config{HTTP2Enabled: true},
+			scheme:        "http",
+			expectedProto: "HTTP/1.1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			addr := startServer(t, &tc.cfg)
+			client := &http.Client{
+				Transport: &http.Transport{
+					TLSClientConfig:   &tls.Config{InsecureSkipVerify: true}, //nolint:gosec // self-signed test certificate
+					ForceAttemptHTTP2: true,
+				},
+				Timeout: 5 * time.Second,
+			}
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, tc.scheme+"://"+addr+"/v0/ping", nil)
+			require.NoError(t, err)
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, tc.expectedProto, resp.Proto)
+			assert.NotEmpty(t, resp.Header.Get(middleware.RequestIDHeader), "every response should carry a request ID")
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// Package versioning implements API version negotiation through the X-Registry-API-Version header
+package versioning
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// HeaderName is the header clients use to request a response schema version.
+// The registry sets it on every response to the version it served.
+const HeaderName = "X-Registry-API-Version"
+
+// DefaultVersion is the schema version used when a request does not specify one
+const DefaultVersion = "v0"
+
+// SupportedVersions lists the response schema versions the registry can serve
+var SupportedVersions = []string{DefaultVersion}
+
+// ErrUnsupportedVersion is returned when a request asks for a version that is not supported
+var ErrUnsupportedVersion = errors.New("unsupported API version")
+
+// ParseVersionHeader returns the schema version requested by the X-Registry-API-Version header.
+// Requests without the header get DefaultVersion.
+func ParseVersionHeader(r *http.Request) (string, error) {
+	version := strings.TrimSpace(r.Header.Get(HeaderName))
+	if version == "" {
+		return DefaultVersion, nil
+	}
+
+	if !slices.Contains(SupportedVersions, version) {
+		return "", fmt.Errorf("%w: %q (supported: %s)", ErrUnsupportedVersion, version, strings.Join(SupportedVersions, ", "))
+	}
+
+	return version, nil
+}
+
+// SetVersionHeader returns a middleware that reports the served API version on all responses
+func SetVersionHeader(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderName, DefaultVersion)
+		next.ServeHTTP(w, r)
+	})
+}
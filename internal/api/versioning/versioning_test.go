@@ -0,0 +1,55 @@
+package versioning_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/api/versioning"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersionHeader(t *testing.T) {
+	testCases := []struct {
+		name            string
+		header          string
+		expectedVersion string
+		expectError     bool
+	}{
+		{name: "missing header defaults to v0", expectedVersion: "v0"},
+		{name: "supported version", header: "v0", expectedVersion: "v0"},
+		{name: "surrounding whitespace is ignored", header: " v0 ", expectedVersion: "v0"},
+		{name: "unknown version", header: "v1", expectError: true},
+		{name: "malformed version", header: "latest", expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v0/servers", nil)
+			if tc.header != "" {
+				req.Header.Set(versioning.HeaderName, tc.header)
+			}
+
+			version, err := versioning.ParseVersionHeader(req)
+			if tc.expectError {
+				require.ErrorIs(t, err, versioning.ErrUnsupportedVersion)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedVersion, version)
+		})
+	}
+}
+
+func TestSetVersionHeader(t *testing.T) {
+	handler := versioning.SetVersionHeader(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "Not found", http.StatusNotFound)
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v0/unknown", nil))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, "v0", rr.Header().Get(versioning.HeaderName))
+}
@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// APIKeyPrefix starts every API key, telling API keys apart from ephemeral tokens and GitHub tokens
+const APIKeyPrefix = "mcpr_"
+
+// apiKeySecretBytes is the number of random bytes in the secret part of an API key
+const apiKeySecretBytes = 32
+
+// CreateAPIKey issues an API key acting as the given GitHub user. The returned key has the form
+// mcpr_<id>_<secret>; only a bcrypt hash of the secret is stored.
+func (s *ServiceImpl) CreateAPIKey(
+	ctx context.Context, ownerGitHubUsername, description string, expiresAt time.Time,
+) (*model.APIKey, string, error) {
+	if s.apiKeys == nil {
+		return nil, "", ErrAPIKeysUnavailable
+	}
+
+	secretBytes := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return nil, "", fmt.Errorf("failed to generate API key secret: %w", err)
+	}
+	secret := hex.EncodeToString(secretBytes)
+
+	hashedSecret, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to hash API key secret: %w", err)
+	}
+
+	key := &model.APIKey{
+		ID:                  uuid.New().String(),
+		HashedSecret:        hashedSecret,
+		OwnerGitHubUsername: ownerGitHubUsername,
+		CreatedAt:           time.Now().UTC(),
+		Description:         description,
+	}
+	if !expiresAt.IsZero() {
+		key.ExpiresAt = expiresAt.UTC()
+	}
+	if err := s.apiKeys.SaveAPIKey(ctx, key); err != nil {
+		return nil, "", fmt.Errorf("failed to save API key: %w", err)
+	}
+
+	return key, APIKeyPrefix + key.ID + "_" + secret, nil
+}
+
+// ListAPIKeys returns all issued API keys, oldest first
+func (s *ServiceImpl) ListAPIKeys(ctx context.Context) ([]*model.APIKey, error) {
+	if s.apiKeys == nil {
+		return nil, ErrAPIKeysUnavailable
+	}
+	return s.apiKeys.ListAPIKeys(ctx)
+}
+
+// RevokeAPIKey deletes an API key so it is no longer accepted
+func (s *ServiceImpl) RevokeAPIKey(ctx context.Context, id string) error {
+	if s.apiKeys == nil {
+		return ErrAPIKeysUnavailable
+	}
+	return s.apiKeys.DeleteAPIKey(ctx, id)
+}
+
+// validateAPIKey checks an API key against the stored hash of its secret and returns claims for its owner.
+// The claims carry no GitHub user ID, so operations tied to an ephemeral token session, such as logging out,
+// are not available with API keys.
+func (s *ServiceImpl) validateAPIKey(ctx context.Context, token string) (*EphemeralTokenClaims, error) {
+	if s.apiKeys == nil {
+		return nil, ErrAPIKeysUnavailable
+	}
+
+	id, secret, ok := strings.Cut(strings.TrimPrefix(token, APIKeyPrefix), "_")
+	if !ok || id == "" || secret == "" {
+		return nil, errors.New("malformed API key")
+	}
+
+	key, err := s.apiKeys.GetAPIKey(ctx, id)
+	if err != nil {
+		// Do not tell unknown keys apart from database failures to the caller
+		return nil, errors.New("unknown API key")
+	}
+	if !key.ExpiresAt.IsZero() && time.Now().After(key.ExpiresAt) {
+		return nil, errors.New("API key has expired")
+	}
+	if err := bcrypt.CompareHashAndPassword(key.HashedSecret, []byte(secret)); err != nil {
+		return nil, errors.New("API key does not match")
+	}
+
+	return &EphemeralTokenClaims{
+		GitHubUsername: key.OwnerGitHubUsername,
+		IssuedAt:       key.CreatedAt,
+		ExpiresAt:      key.ExpiresAt,
+	}, nil
+}
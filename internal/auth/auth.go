@@ -4,6 +4,8 @@ package auth
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/modelcontextprotocol/registry/internal/model"
@@ -14,8 +16,26 @@ var (
 	ErrAuthRequired = errors.New("authentication required")
 	// ErrUnsupportedAuthMethod is returned when an unsupported auth method is used
 	ErrUnsupportedAuthMethod = errors.New("unsupported authentication method")
+	// ErrTokenRevoked is returned when an ephemeral token has been revoked
+	ErrTokenRevoked = errors.New("token has been revoked")
+	// ErrAPIKeysUnavailable is returned when API keys are used but the service was created without an APIKeyStore
+	ErrAPIKeysUnavailable = errors.New("API keys are not available")
 )
 
+// InsufficientScopeError is returned when a GitHub token lacks the OAuth scopes required by the registry
+type InsufficientScopeError struct {
+	Required []string
+	Provided []string
+}
+
+func (e *InsufficientScopeError) Error() string {
+	return fmt.Sprintf("token is missing required scopes: required %s, provided %s",
+		strings.Join(e.Required, ", "), strings.Join(e.Provided, ", "))
+}
+
+// EphemeralTokenLifetime is how long ephemeral tokens are valid
+const EphemeralTokenLifetime = time.Hour
+
 // EphemeralTokenClaims represents the claims in an ephemeral token
 type EphemeralTokenClaims struct {
 	GitHubUserID   string    `json:"github_user_id"`
@@ -42,6 +62,63 @@ type Service interface {
 	// GenerateEphemeralTokenForGitHubUser validates a GitHub token and generates an ephemeral token
 	GenerateEphemeralTokenForGitHubUser(ctx context.Context, githubToken string) (string, error)
 
+	// GenerateEphemeralTokenForPAT validates a GitHub personal access token, including its scopes,
+	// and generates an ephemeral token
+	GenerateEphemeralTokenForPAT(ctx context.Context, pat string) (string, error)
+
 	// ValidateEphemeralOrOwnerToken validates either an ephemeral token or registry owner token
 	ValidateEphemeralOrOwnerToken(ctx context.Context, token string) (bool, *EphemeralTokenClaims, error)
+
+	// GitHubUserExists reports whether a GitHub user with the given username exists
+	GitHubUserExists(ctx context.Context, username string) (bool, error)
+
+	// RefreshEphemeralToken issues a new ephemeral token if the given one is close to expiry
+	// and returns the resulting token along with its expiry time
+	RefreshEphemeralToken(ctx context.Context, token string) (string, time.Time, error)
+
+	// RevokeAllTokensForUser revokes every ephemeral token issued to the GitHub user so far. Tokens issued later
+	// remain valid. The revocation is kept until the given time, by which all revoked tokens must have expired.
+	RevokeAllTokensForUser(ctx context.Context, githubUserID string, until time.Time) error
+
+	// CreateAPIKey issues an API key acting as the given GitHub user and returns it along with the key to
+	// present as bearer token, which cannot be retrieved again. A zero expiry time never expires.
+	CreateAPIKey(
+		ctx context.Context, ownerGitHubUsername, description string, expiresAt time.Time,
+	) (*model.APIKey, string, error)
+
+	// ListAPIKeys returns all issued API keys, oldest first
+	ListAPIKeys(ctx context.Context) ([]*model.APIKey, error)
+
+	// RevokeAPIKey deletes an API key so it is no longer accepted
+	RevokeAPIKey(ctx context.Context, id string) error
+}
+
+// APIKeyStore stores the API keys issued by the registry owner. database.Database implements it.
+type APIKeyStore interface {
+	SaveAPIKey(ctx context.Context, key *model.APIKey) error
+	GetAPIKey(ctx context.Context, id string) (*model.APIKey, error)
+	ListAPIKeys(ctx context.Context) ([]*model.APIKey, error)
+	DeleteAPIKey(ctx context.Context, id string) error
+}
+
+// GitHubAuth defines the GitHub API operations the registry uses to authenticate publishers and
+// fetch repository information. GitHubDeviceAuth implements it against the real GitHub API.
+type GitHubAuth interface {
+	// ValidateToken validates that a GitHub token may publish the given repository
+	ValidateToken(ctx context.Context, token string, requiredRepo string) (bool, error)
+
+	// ValidateTokenForOwner validates that a GitHub token belongs to the given user
+	ValidateTokenForOwner(ctx context.Context, token string, expectedUsername string) (bool, error)
+
+	// FetchRepositoryInfo returns information about a GitHub repository
+	FetchRepositoryInfo(ctx context.Context, token, owner, repo string) (*GitHubRepoInfo, error)
+
+	// FetchLatestRelease returns the version of the latest release of a GitHub repository
+	FetchLatestRelease(ctx context.Context, token, owner, repo string) (string, error)
+
+	// UserExists reports whether a GitHub user with the given username exists
+	UserExists(ctx context.Context, username string) (bool, error)
+
+	// IsOrgMember reports whether a user is a member of a GitHub organization
+	IsOrgMember(ctx context.Context, token, username, org string) (bool, error)
 }
@@ -9,7 +9,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	neturl "net/url"
 	"regexp"
+	"strings"
 )
 
 var (
@@ -63,14 +65,32 @@ type GitHubRepoInfo struct {
 	Description string `json:"description"`
 	HTMLURL     string `json:"html_url"`
 	Private     bool   `json:"private"`
-	Owner       struct {
+	Language    string `json:"language"`
+	Stars       int    `json:"stargazers_count"`
+	// DefaultBranch is the branch checked out when cloning the repository
+	DefaultBranch string `json:"default_branch"`
+	Owner         struct {
 		Login string `json:"login"`
 	} `json:"owner"`
+
+	// Topics are the repository topics, returned when requested with the mercy-preview media type
+	Topics []string `json:"topics"`
+
+	// Raw is the response body exactly as returned by the GitHub API
+	Raw json.RawMessage `json:"-"`
+}
+
+// GitHubReleaseInfo represents release information from GitHub API
+type GitHubReleaseInfo struct {
+	TagName string `json:"tag_name"`
+	Name    string `json:"name"`
 }
 
 // GitHubDeviceAuth provides methods for GitHub device OAuth authentication
 type GitHubDeviceAuth struct {
 	config GitHubOAuthConfig
+	// app, when set, provides the tokens for fetching repository information
+	app *GitHubAppAuth
 }
 
 // NewGitHubDeviceAuth creates a new GitHub device auth instance
@@ -195,7 +215,7 @@ func (g *GitHubDeviceAuth) ValidateToken(ctx context.Context, token string, requ
 	// Verify that the authenticated user matches the owner
 	if userInfo.Login != owner {
 		// Check if the user is a member of the organization
-		isMember, err := g.checkOrgMembership(ctx, token, userInfo.Login, owner)
+		isMember, err := g.IsOrgMember(ctx, token, userInfo.Login, owner)
 		if err != nil {
 			return false, fmt.Errorf("failed to check org membership: %s", owner)
 		}
@@ -232,8 +252,8 @@ func (g *GitHubDeviceAuth) ExtractGitHubRepo(repoURL string) (owner, repo string
 	return matches[1], matches[2], nil
 }
 
-// checkOrgMembership checks if a user is a member of an organization
-func (g *GitHubDeviceAuth) checkOrgMembership(ctx context.Context, token, username, org string) (bool, error) {
+// IsOrgMember checks if a user is a member of an organization
+func (g *GitHubDeviceAuth) IsOrgMember(ctx context.Context, token, username, org string) (bool, error) {
 	// Create request to check if user is a member of the organization
 	// GitHub API endpoint: GET /orgs/{org}/members/{username}
 	// true if status code is 204 No Content
@@ -345,7 +365,19 @@ func (g *GitHubDeviceAuth) ValidateTokenForOwner(ctx context.Context, token stri
 
 // FetchRepositoryInfo fetches repository information from GitHub API
 // For public repositories, we don't need authentication
+// If a GitHub App is configured, its installation token is used instead of the given token;
+// the given token is only used for repositories the app is not installed on.
 func (g *GitHubDeviceAuth) FetchRepositoryInfo(ctx context.Context, token, owner, repo string) (*GitHubRepoInfo, error) {
+	if g.app != nil {
+		appToken, err := g.app.GetRepositoryToken(ctx, owner, repo)
+		switch {
+		case err == nil:
+			token = appToken
+		case !errors.Is(err, ErrAppNotInstalled):
+			return nil, fmt.Errorf("failed to get GitHub App token: %w", err)
+		}
+	}
+
 	url := fmt.Sprintf("https://api.github.com/repos/%s/%s", owner, repo)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -353,7 +385,7 @@ func (g *GitHubDeviceAuth) FetchRepositoryInfo(ctx context.Context, token, owner
 		return nil, err
 	}
 
-	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Accept", "application/vnd.github.mercy-preview+json")
 	
 	// Only add authorization header if we have a valid GitHub token
 	// For public repositories, we can fetch info without authentication
@@ -386,6 +418,7 @@ func (g *GitHubDeviceAuth) FetchRepositoryInfo(ctx context.Context, token, owner
 	if err := json.Unmarshal(body, &repoInfo); err != nil {
 		return nil, err
 	}
+	repoInfo.Raw = body
 
 	// Check if repository is private
 	if repoInfo.Private {
@@ -394,3 +427,75 @@ func (g *GitHubDeviceAuth) FetchRepositoryInfo(ctx context.Context, token, owner
 
 	return &repoInfo, nil
 }
+
+// FetchLatestRelease fetches the tag name of the latest release of a repository from GitHub API.
+// The leading "v" is stripped from the tag name. An empty string is returned if the repository has no releases.
+func (g *GitHubDeviceAuth) FetchLatestRelease(ctx context.Context, token, owner, repo string) (string, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/latest", owner, repo)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	// GitHub returns 404 when the repository has no published releases
+	if resp.StatusCode == http.StatusNotFound {
+		return "", nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch latest release: status %d", resp.StatusCode)
+	}
+
+	var releaseInfo GitHubReleaseInfo
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if err := json.Unmarshal(body, &releaseInfo); err != nil {
+		return "", err
+	}
+
+	return strings.TrimPrefix(releaseInfo.TagName, "v"), nil
+}
+
+// UserExists reports whether a GitHub user or organization with the given login exists
+func (g *GitHubDeviceAuth) UserExists(ctx context.Context, username string) (bool, error) {
+	url := "https://api.github.com/users/" + neturl.PathEscape(username)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to look up GitHub user: status %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,219 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultGitHubAPIURL is the base URL of the GitHub REST API
+const DefaultGitHubAPIURL = "https://api.github.com"
+
+// ErrAppNotInstalled is returned when the GitHub App is not installed on a repository
+var ErrAppNotInstalled = errors.New("GitHub App is not installed on the repository")
+
+// appJWTLifetime is how long the JWTs authenticating as the GitHub App are valid; GitHub allows at most 10 minutes
+const appJWTLifetime = 9 * time.Minute
+
+// installationTokenRefreshMargin is how long before expiry a cached installation token is replaced
+const installationTokenRefreshMargin = time.Minute
+
+// installationToken is an installation access token along with its expiry time
+type installationToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// GitHubAppAuth provides installation access tokens of a GitHub App.
+// Installation tokens have higher rate limits than OAuth user tokens.
+type GitHubAppAuth struct {
+	AppID      int64
+	PrivateKey []byte
+	// BaseURL is the base URL of the GitHub API; DefaultGitHubAPIURL is used when empty
+	BaseURL string
+
+	// tokens caches installation tokens by installation ID until shortly before they expire
+	tokens map[int64]installationToken
+	mu     sync.Mutex
+}
+
+// NewGitHubAppAuth creates a new GitHub App auth instance from the app ID and its PEM encoded private key
+func NewGitHubAppAuth(appID int64, privateKey []byte) (*GitHubAppAuth, error) {
+	if _, err := parseRSAPrivateKey(privateKey); err != nil {
+		return nil, err
+	}
+	return &GitHubAppAuth{
+		AppID:      appID,
+		PrivateKey: privateKey,
+		BaseURL:    DefaultGitHubAPIURL,
+	}, nil
+}
+
+// GetInstallationToken returns an access token for the given installation of the app.
+// Tokens are cached and reused until shortly before they expire.
+func (a *GitHubAppAuth) GetInstallationToken(ctx context.Context, installationID int64) (string, error) {
+	a.mu.Lock()
+	cached, ok := a.tokens[installationID]
+	a.mu.Unlock()
+	if ok && time.Until(cached.ExpiresAt) > installationTokenRefreshMargin {
+		return cached.Token, nil
+	}
+
+	url := a.apiURL("/app/installations/" + strconv.FormatInt(installationID, 10) + "/access_tokens")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return "", err
+	}
+	if err := a.authorize(req); err != nil {
+		return "", err
+	}
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("failed to create installation token: status %d", resp.StatusCode)
+	}
+
+	var token installationToken
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to decode installation token: %w", err)
+	}
+	if token.Token == "" {
+		return "", fmt.Errorf("GitHub returned an empty installation token")
+	}
+
+	a.mu.Lock()
+	if a.tokens == nil {
+		a.tokens = make(map[int64]installationToken)
+	}
+	a.tokens[installationID] = token
+	a.mu.Unlock()
+
+	return token.Token, nil
+}
+
+// GetRepositoryToken returns an installation access token for the installation of the app covering a repository.
+// ErrAppNotInstalled is returned if the app is not installed on the repository.
+func (a *GitHubAppAuth) GetRepositoryToken(ctx context.Context, owner, repo string) (string, error) {
+	url := a.apiURL("/repos/" + neturl.PathEscape(owner) + "/" + neturl.PathEscape(repo) + "/installation")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	if err := a.authorize(req); err != nil {
+		return "", err
+	}
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", ErrAppNotInstalled
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to look up app installation: status %d", resp.StatusCode)
+	}
+
+	var installation struct {
+		ID int64 `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&installation); err != nil {
+		return "", fmt.Errorf("failed to decode app installation: %w", err)
+	}
+
+	return a.GetInstallationToken(ctx, installation.ID)
+}
+
+// apiURL returns the URL of a GitHub API path
+func (a *GitHubAppAuth) apiURL(path string) string {
+	if a.BaseURL == "" {
+		return DefaultGitHubAPIURL + path
+	}
+	return a.BaseURL + path
+}
+
+// authorize sets the headers authenticating a request as the app itself
+func (a *GitHubAppAuth) authorize(req *http.Request) error {
+	jwt, err := a.signJWT(time.Now())
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	return nil
+}
+
+// signJWT creates an RS256 signed JWT identifying the app, as required by the GitHub App APIs
+func (a *GitHubAppAuth) signJWT(now time.Time) (string, error) {
+	key, err := parseRSAPrivateKey(a.PrivateKey)
+	if err != nil {
+		return "", err
+	}
+
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	// Backdate the issue time to allow for clock drift between us and GitHub
+	claims, err := json.Marshal(map[string]any{
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": strconv.FormatInt(a.AppID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign app JWT: %w", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// parseRSAPrivateKey parses a PEM encoded RSA private key in PKCS #1 or PKCS #8 form
+func parseRSAPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("invalid GitHub App private key: no PEM data found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GitHub App private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("invalid GitHub App private key: not an RSA key")
+	}
+	return key, nil
+}
@@ -0,0 +1,164 @@
+package auth_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// generateAppKey returns a new RSA private key and its PKCS #1 PEM encoding
+func generateAppKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+// verifyAppJWT checks that the request is authenticated with a JWT for the app signed by key
+func verifyAppJWT(t *testing.T, r *http.Request, key *rsa.PrivateKey, appID string) {
+	t.Helper()
+	jwt, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	require.True(t, ok, "missing bearer token")
+
+	parts := strings.Split(jwt, ".")
+	require.Len(t, parts, 3)
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	require.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	var claims struct {
+		IssuedAt  int64  `json:"iat"`
+		ExpiresAt int64  `json:"exp"`
+		Issuer    string `json:"iss"`
+	}
+	require.NoError(t, json.Unmarshal(payload, &claims))
+	assert.Equal(t, appID, claims.Issuer)
+	assert.LessOrEqual(t, claims.IssuedAt, time.Now().Unix())
+	assert.LessOrEqual(t, claims.ExpiresAt, time.Now().Add(10*time.Minute).Unix())
+}
+
+func TestGitHubAppAuth(t *testing.T) {
+	key, keyPEM := generateAppKey(t)
+
+	var tokenRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		verifyAppJWT(t, r, key, "12345")
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/app/installations/42/access_tokens":
+			tokenRequests.Add(1)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"token":      "ghs_installation",
+				"expires_at": time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/example/server/installation":
+			_, _ = w.Write([]byte(`{"id": 42}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+		}
+	}))
+	defer server.Close()
+
+	appAuth, err := auth.NewGitHubAppAuth(12345, keyPEM)
+	require.NoError(t, err)
+	appAuth.BaseURL = server.URL
+
+	t.Run("creates and caches installation tokens", func(t *testing.T) {
+		token, err := appAuth.GetInstallationToken(context.Background(), 42)
+		require.NoError(t, err)
+		assert.Equal(t, "ghs_installation", token)
+
+		token, err = appAuth.GetInstallationToken(context.Background(), 42)
+		require.NoError(t, err)
+		assert.Equal(t, "ghs_installation", token)
+		assert.Equal(t, int32(1), tokenRequests.Load())
+	})
+
+	t.Run("unknown installation", func(t *testing.T) {
+		_, err := appAuth.GetInstallationToken(context.Background(), 7)
+		assert.Error(t, err)
+	})
+
+	t.Run("looks up the installation of a repository", func(t *testing.T) {
+		token, err := appAuth.GetRepositoryToken(context.Background(), "example", "server")
+		require.NoError(t, err)
+		assert.Equal(t, "ghs_installation", token)
+	})
+
+	t.Run("app not installed on the repository", func(t *testing.T) {
+		_, err := appAuth.GetRepositoryToken(context.Background(), "example", "other")
+		assert.ErrorIs(t, err, auth.ErrAppNotInstalled)
+	})
+
+	t.Run("invalid private key", func(t *testing.T) {
+		_, err := auth.NewGitHubAppAuth(12345, []byte("not a key"))
+		assert.Error(t, err)
+	})
+}
+
+func TestFetchRepositoryInfoWithGitHubApp(t *testing.T) {
+	key, keyPEM := generateAppKey(t)
+	keyPath := filepath.Join(t.TempDir(), "app.pem")
+	require.NoError(t, os.WriteFile(keyPath, keyPEM, 0o600))
+
+	authService := auth.NewAuthService(&config.Config{GithubAppID: 12345, GithubAppPrivateKeyPath: keyPath})
+	authServiceImpl, ok := authService.(*auth.ServiceImpl)
+	require.True(t, ok)
+	githubAuth := authServiceImpl.GetGitHubAuth()
+
+	// authorizations records the Authorization header of each repository info request
+	authorizations := map[string]string{}
+	stubGitHubAPI(t, func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/repos/example/server/installation":
+			verifyAppJWT(t, req, key, "12345")
+			return jsonResponse(http.StatusOK, `{"id": 42}`), nil
+		case "/repos/example/other/installation":
+			return jsonResponse(http.StatusNotFound, `{"message": "Not Found"}`), nil
+		case "/app/installations/42/access_tokens":
+			verifyAppJWT(t, req, key, "12345")
+			return jsonResponse(http.StatusCreated, `{"token": "ghs_installation", "expires_at": "2099-01-01T00:00:00Z"}`), nil
+		default:
+			authorizations[req.URL.Path] = req.Header.Get("Authorization")
+			return jsonResponse(http.StatusOK, `{"name": "repo"}`), nil
+		}
+	})
+
+	t.Run("uses the installation token instead of the user token", func(t *testing.T) {
+		_, err := githubAuth.FetchRepositoryInfo(context.Background(), "user-token", "example", "server")
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer ghs_installation", authorizations["/repos/example/server"])
+	})
+
+	t.Run("falls back to the user token when the app is not installed", func(t *testing.T) {
+		_, err := githubAuth.FetchRepositoryInfo(context.Background(), "user-token", "example", "other")
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer user-token", authorizations["/repos/example/other"])
+	})
+}
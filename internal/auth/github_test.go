@@ -0,0 +1,123 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// roundTripFunc allows a function to be used as an http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// stubGitHubAPI replaces the default HTTP transport for the duration of the test
+func stubGitHubAPI(t *testing.T, fn roundTripFunc) {
+	t.Helper()
+	original := http.DefaultTransport
+	http.DefaultTransport = fn
+	t.Cleanup(func() {
+		http.DefaultTransport = original
+	})
+}
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestFetchLatestRelease(t *testing.T) {
+	githubAuth := auth.NewGitHubDeviceAuth(auth.GitHubOAuthConfig{})
+
+	t.Run("returns tag without leading v", func(t *testing.T) {
+		stubGitHubAPI(t, func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/repos/example/server/releases/latest", req.URL.Path)
+			return jsonResponse(http.StatusOK, `{"tag_name": "v2.3.1", "name": "Release 2.3.1"}`), nil
+		})
+
+		version, err := githubAuth.FetchLatestRelease(context.Background(), "", "example", "server")
+		require.NoError(t, err)
+		assert.Equal(t, "2.3.1", version)
+	})
+
+	t.Run("returns empty version when repository has no releases", func(t *testing.T) {
+		stubGitHubAPI(t, func(_ *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusNotFound, `{"message": "Not Found"}`), nil
+		})
+
+		version, err := githubAuth.FetchLatestRelease(context.Background(), "", "example", "server")
+		require.NoError(t, err)
+		assert.Empty(t, version)
+	})
+
+	t.Run("returns error on network failure", func(t *testing.T) {
+		stubGitHubAPI(t, func(_ *http.Request) (*http.Response, error) {
+			return nil, errors.New("connection refused")
+		})
+
+		_, err := githubAuth.FetchLatestRelease(context.Background(), "", "example", "server")
+		assert.Error(t, err)
+	})
+}
+
+func TestUserExists(t *testing.T) {
+	githubAuth := auth.NewGitHubDeviceAuth(auth.GitHubOAuthConfig{})
+
+	t.Run("returns true for existing user", func(t *testing.T) {
+		stubGitHubAPI(t, func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/users/octocat", req.URL.Path)
+			return jsonResponse(http.StatusOK, `{"login": "octocat", "id": 1}`), nil
+		})
+
+		exists, err := githubAuth.UserExists(context.Background(), "octocat")
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("returns false for unknown user", func(t *testing.T) {
+		stubGitHubAPI(t, func(_ *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusNotFound, `{"message": "Not Found"}`), nil
+		})
+
+		exists, err := githubAuth.UserExists(context.Background(), "nobody")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("returns error on unexpected status", func(t *testing.T) {
+		stubGitHubAPI(t, func(_ *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusForbidden, `{"message": "API rate limit exceeded"}`), nil
+		})
+
+		_, err := githubAuth.UserExists(context.Background(), "octocat")
+		assert.Error(t, err)
+	})
+}
+
+func TestFetchRepositoryInfoKeepsRawResponse(t *testing.T) {
+	githubAuth := auth.NewGitHubDeviceAuth(auth.GitHubOAuthConfig{})
+	const body = `{"id": 42, "full_name": "example/server", "html_url": "https://github.com/example/server", ` +
+		`"private": false, "stargazers_count": 7, "topics": ["mcp"]}`
+	stubGitHubAPI(t, func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/repos/example/server", req.URL.Path)
+		return jsonResponse(http.StatusOK, body), nil
+	})
+
+	repoInfo, err := githubAuth.FetchRepositoryInfo(context.Background(), "", "example", "server")
+	require.NoError(t, err)
+	assert.Equal(t, 42, repoInfo.ID)
+	// Fields the registry does not parse are kept
+	assert.Equal(t, body, string(repoInfo.Raw))
+}
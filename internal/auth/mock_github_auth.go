@@ -0,0 +1,100 @@
+//go:build test
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MockGitHubDeviceAuth is a GitHubAuth that answers from memory instead of calling the GitHub API,
+// so handler tests can authenticate publishers and fetch repository information without a fake server.
+// Tokens are valid if they are in ValidTokens; every repository exists and is public.
+type MockGitHubDeviceAuth struct {
+	// ValidTokens maps each valid GitHub token to the username of its owner
+	ValidTokens map[string]string
+	// Description, Stars, Language, Topics and DefaultBranch are reported for every repository
+	Description   string
+	Stars         int
+	Language      string
+	Topics        []string
+	DefaultBranch string
+	// LatestRelease is the tag of the latest release of every repository; empty if there are no releases
+	LatestRelease string
+	// OrgMember is reported by IsOrgMember for every user and organization
+	OrgMember bool
+}
+
+var _ GitHubAuth = (*MockGitHubDeviceAuth)(nil)
+
+// NewMockGitHubAuth creates a mock GitHub auth accepting the given tokens, mapped to the usernames of their owners
+func NewMockGitHubAuth(validTokens map[string]string) *MockGitHubDeviceAuth {
+	return &MockGitHubDeviceAuth{ValidTokens: validTokens}
+}
+
+// ValidateToken returns true if the token is valid
+func (m *MockGitHubDeviceAuth) ValidateToken(_ context.Context, token string, _ string) (bool, error) {
+	if _, ok := m.ValidTokens[token]; !ok {
+		return false, errors.New("invalid token")
+	}
+	return true, nil
+}
+
+// ValidateTokenForOwner returns true if the token is valid and belongs to expectedUsername
+func (m *MockGitHubDeviceAuth) ValidateTokenForOwner(_ context.Context, token string, expectedUsername string) (bool, error) {
+	username, ok := m.ValidTokens[token]
+	if !ok {
+		return false, errors.New("invalid token")
+	}
+	if !strings.EqualFold(username, expectedUsername) {
+		return false, fmt.Errorf("token belongs to %s, not %s", username, expectedUsername)
+	}
+	return true, nil
+}
+
+// FetchRepositoryInfo returns the configured information for any repository
+func (m *MockGitHubDeviceAuth) FetchRepositoryInfo(_ context.Context, _ string, owner, repo string) (*GitHubRepoInfo, error) {
+	repoInfo := &GitHubRepoInfo{
+		ID:            1,
+		Name:          repo,
+		FullName:      owner + "/" + repo,
+		Description:   m.Description,
+		HTMLURL:       "https://github.com/" + owner + "/" + repo,
+		Language:      m.Language,
+		Stars:         m.Stars,
+		Topics:        slices.Clone(m.Topics),
+		DefaultBranch: m.DefaultBranch,
+	}
+	repoInfo.Owner.Login = owner
+
+	raw, err := json.Marshal(repoInfo)
+	if err != nil {
+		return nil, err
+	}
+	repoInfo.Raw = raw
+	return repoInfo, nil
+}
+
+// FetchLatestRelease returns the configured latest release without its "v" prefix
+func (m *MockGitHubDeviceAuth) FetchLatestRelease(_ context.Context, _ string, _, _ string) (string, error) {
+	return strings.TrimPrefix(m.LatestRelease, "v"), nil
+}
+
+// UserExists returns true if a valid token belongs to the user
+func (m *MockGitHubDeviceAuth) UserExists(_ context.Context, username string) (bool, error) {
+	for _, owner := range m.ValidTokens {
+		if strings.EqualFold(owner, username) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// IsOrgMember returns the configured membership
+func (m *MockGitHubDeviceAuth) IsOrgMember(_ context.Context, _ string, _, _ string) (bool, error) {
+	return m.OrgMember, nil
+}
@@ -11,7 +11,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/modelcontextprotocol/registry/internal/config"
@@ -21,8 +24,31 @@ import (
 // ServiceImpl implements the Service interface
 type ServiceImpl struct {
 	config               *config.Config
-	githubAuth           *GitHubDeviceAuth
+	githubAuth           GitHubAuth
 	ephemeralTokenSecret []byte
+	// revokedNonces maps the nonce of each revoked ephemeral token to its expiry time
+	revokedNonces map[string]time.Time
+	// revokedUsers maps the GitHub user ID of each user whose tokens were revoked to the revocation
+	revokedUsers map[string]userRevocation
+	// apiKeys stores the issued API keys; API keys are rejected if it is nil
+	apiKeys APIKeyStore
+	mu      sync.Mutex
+}
+
+// Option configures optional behavior of the authentication service
+type Option func(*ServiceImpl)
+
+// WithAPIKeyStore enables API keys, storing them in store
+func WithAPIKeyStore(store APIKeyStore) Option {
+	return func(s *ServiceImpl) {
+		s.apiKeys = store
+	}
+}
+
+// userRevocation invalidates the ephemeral tokens a user was issued up to a point in time
+type userRevocation struct {
+	revokedAt time.Time
+	until     time.Time
 }
 
 // EphemeralToken represents a signed ephemeral token
@@ -34,12 +60,32 @@ type EphemeralToken struct {
 // NewAuthService creates a new authentication service
 //
 //nolint:ireturn // Factory function intentionally returns interface for dependency injection
-func NewAuthService(cfg *config.Config) Service {
+func NewAuthService(cfg *config.Config, opts ...Option) Service {
 	githubConfig := GitHubOAuthConfig{
 		ClientID:     cfg.GithubClientID,
 		ClientSecret: cfg.GithubClientSecret,
 	}
 
+	githubAuth := NewGitHubDeviceAuth(githubConfig)
+	if cfg.AuthMethod() == config.AuthMethodGitHubApp {
+		privateKey, err := os.ReadFile(cfg.GithubAppPrivateKeyPath)
+		if err != nil {
+			panic("failed to read GitHub App private key: " + err.Error())
+		}
+		githubAuth.app, err = NewGitHubAppAuth(cfg.GithubAppID, privateKey)
+		if err != nil {
+			panic("failed to load GitHub App private key: " + err.Error())
+		}
+	}
+
+	return NewAuthServiceWithGitHubAuth(cfg, githubAuth, opts...)
+}
+
+// NewAuthServiceWithGitHubAuth creates a new authentication service that talks to GitHub through githubAuth,
+// for example a MockGitHubDeviceAuth in tests
+//
+//nolint:ireturn // Factory function intentionally returns interface for dependency injection
+func NewAuthServiceWithGitHubAuth(cfg *config.Config, githubAuth GitHubAuth, opts ...Option) Service {
 	// Initialize ephemeral token secret
 	var ephemeralSecret []byte
 	if cfg.EphemeralTokenSecret == "" {
@@ -53,11 +99,17 @@ func NewAuthService(cfg *config.Config) Service {
 		ephemeralSecret = []byte(cfg.EphemeralTokenSecret)
 	}
 
-	return &ServiceImpl{
+	s := &ServiceImpl{
 		config:               cfg,
-		githubAuth:           NewGitHubDeviceAuth(githubConfig),
+		githubAuth:           githubAuth,
 		ephemeralTokenSecret: ephemeralSecret,
+		revokedNonces:        make(map[string]time.Time),
+		revokedUsers:         make(map[string]userRevocation),
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 func (s *ServiceImpl) StartAuthFlow(_ context.Context, _ model.AuthMethod,
@@ -105,16 +157,74 @@ func (s *ServiceImpl) ValidateRegistryOwnerAuth(ctx context.Context, token strin
 }
 
 // GetGitHubAuth returns the GitHub auth instance (needed for OSS publishing)
-func (s *ServiceImpl) GetGitHubAuth() *GitHubDeviceAuth {
+func (s *ServiceImpl) GetGitHubAuth() GitHubAuth {
 	return s.githubAuth
 }
 
+// GitHubUserExists reports whether a GitHub user with the given username exists
+func (s *ServiceImpl) GitHubUserExists(ctx context.Context, username string) (bool, error) {
+	return s.githubAuth.UserExists(ctx, username)
+}
+
+// requiredPATScopes are the OAuth scopes a personal access token needs to publish servers
+var requiredPATScopes = []string{"public_repo"}
+
+// githubUser represents the authenticated GitHub user returned by the /user endpoint
+type githubUser struct {
+	ID    int    `json:"id"`
+	Login string `json:"login"`
+}
+
 // GenerateEphemeralTokenForGitHubUser validates a GitHub token and generates an ephemeral token
 func (s *ServiceImpl) GenerateEphemeralTokenForGitHubUser(ctx context.Context, githubToken string) (string, error) {
+	userInfo, _, err := fetchGitHubUser(ctx, githubToken)
+	if err != nil {
+		return "", err
+	}
+
+	return s.ephemeralTokenForUser(userInfo)
+}
+
+// GenerateEphemeralTokenForPAT validates a GitHub personal access token and generates an ephemeral token.
+// Classic tokens report their scopes in the X-OAuth-Scopes header; tokens without the required scopes
+// are rejected with an *InsufficientScopeError.
+func (s *ServiceImpl) GenerateEphemeralTokenForPAT(ctx context.Context, pat string) (string, error) {
+	userInfo, header, err := fetchGitHubUser(ctx, pat)
+	if err != nil {
+		return "", err
+	}
+
+	provided := parseOAuthScopes(header.Get("X-OAuth-Scopes"))
+	for _, scope := range requiredPATScopes {
+		// The repo scope grants everything public_repo does
+		if !slices.Contains(provided, scope) && !(scope == "public_repo" && slices.Contains(provided, "repo")) {
+			return "", &InsufficientScopeError{Required: requiredPATScopes, Provided: provided}
+		}
+	}
+
+	return s.ephemeralTokenForUser(userInfo)
+}
+
+// ephemeralTokenForUser generates an ephemeral token for the given GitHub user
+func (s *ServiceImpl) ephemeralTokenForUser(userInfo *githubUser) (string, error) {
+	ephemeralToken, err := s.generateEphemeralToken(
+		fmt.Sprintf("%d", userInfo.ID),
+		userInfo.Login,
+		EphemeralTokenLifetime,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate ephemeral token: %w", err)
+	}
+
+	return ephemeralToken, nil
+}
+
+// fetchGitHubUser retrieves the user a GitHub token belongs to, along with the response headers
+func fetchGitHubUser(ctx context.Context, githubToken string) (*githubUser, http.Header, error) {
 	// Get user info from GitHub
 	userReq, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/user", nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to create user request: %w", err)
+		return nil, nil, fmt.Errorf("failed to create user request: %w", err)
 	}
 
 	userReq.Header.Set("Accept", "application/vnd.github+json")
@@ -123,43 +233,50 @@ func (s *ServiceImpl) GenerateEphemeralTokenForGitHubUser(ctx context.Context, g
 	client := &http.Client{}
 	userResp, err := client.Do(userReq)
 	if err != nil {
-		return "", fmt.Errorf("failed to get user info: %w", err)
+		return nil, nil, fmt.Errorf("failed to get user info: %w", err)
 	}
 	defer userResp.Body.Close()
 
 	if userResp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("failed to authenticate with GitHub: status %d", userResp.StatusCode)
-	}
-
-	var userInfo struct {
-		ID    int    `json:"id"`
-		Login string `json:"login"`
+		return nil, nil, fmt.Errorf("failed to authenticate with GitHub: status %d", userResp.StatusCode)
 	}
 
 	userBody, err := io.ReadAll(userResp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read user info: %w", err)
+		return nil, nil, fmt.Errorf("failed to read user info: %w", err)
 	}
 
+	var userInfo githubUser
 	if err := json.Unmarshal(userBody, &userInfo); err != nil {
-		return "", fmt.Errorf("failed to parse user info: %w", err)
+		return nil, nil, fmt.Errorf("failed to parse user info: %w", err)
 	}
 
-	// Generate ephemeral token valid for 1 hour
-	ephemeralToken, err := s.generateEphemeralToken(
-		fmt.Sprintf("%d", userInfo.ID),
-		userInfo.Login,
-		time.Hour,
-	)
-	if err != nil {
-		return "", fmt.Errorf("failed to generate ephemeral token: %w", err)
-	}
+	return &userInfo, userResp.Header, nil
+}
 
-	return ephemeralToken, nil
+// parseOAuthScopes parses a comma separated X-OAuth-Scopes header value
+func parseOAuthScopes(header string) []string {
+	scopes := []string{}
+	for _, scope := range strings.Split(header, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
 }
 
-// ValidateEphemeralOrOwnerToken validates either an ephemeral token or registry owner token
+// ValidateEphemeralOrOwnerToken validates either an ephemeral token or registry owner token. API keys are
+// validated instead if the token carries their prefix; like ephemeral tokens they return claims for their owner.
 func (s *ServiceImpl) ValidateEphemeralOrOwnerToken(ctx context.Context, token string) (bool, *EphemeralTokenClaims, error) {
+	// API keys are recognized by their prefix, so they are never sent to GitHub
+	if strings.HasPrefix(token, APIKeyPrefix) {
+		claims, err := s.validateAPIKey(ctx, token)
+		if err != nil {
+			return false, nil, fmt.Errorf("invalid token: not a valid API key (%v)", err)
+		}
+		return true, claims, nil
+	}
+
 	// First, try to validate as ephemeral token
 	claims, err := s.validateEphemeralToken(token)
 	if err == nil {
@@ -178,6 +295,84 @@ func (s *ServiceImpl) ValidateEphemeralOrOwnerToken(ctx context.Context, token s
 	return false, nil, fmt.Errorf("invalid token: not a valid ephemeral token (%v) or registry owner token (%v)", err, ownerErr)
 }
 
+// RefreshEphemeralToken issues a new ephemeral token if the given token expires within the configured
+// refresh window, revoking the old token. Tokens with more time remaining are returned unchanged.
+func (s *ServiceImpl) RefreshEphemeralToken(_ context.Context, token string) (string, time.Time, error) {
+	claims, err := s.validateEphemeralToken(token)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	if time.Until(claims.ExpiresAt) > s.config.RefreshWindowDuration {
+		return token, claims.ExpiresAt, nil
+	}
+
+	// Generate a new ephemeral token
+	expiresAt := time.Now().Add(EphemeralTokenLifetime)
+	newToken, err := s.generateEphemeralToken(claims.GitHubUserID, claims.GitHubUsername, EphemeralTokenLifetime)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to generate ephemeral token: %w", err)
+	}
+
+	s.revokeNonce(claims.Nonce, claims.ExpiresAt)
+
+	return newToken, expiresAt, nil
+}
+
+// revokeNonce marks an ephemeral token nonce as revoked and prunes revocations of expired tokens
+func (s *ServiceImpl) revokeNonce(nonce string, expiresAt time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for n, exp := range s.revokedNonces {
+		if now.After(exp) {
+			delete(s.revokedNonces, n)
+		}
+	}
+
+	s.revokedNonces[nonce] = expiresAt
+}
+
+// isNonceRevoked reports whether an ephemeral token nonce has been revoked
+func (s *ServiceImpl) isNonceRevoked(nonce string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, revoked := s.revokedNonces[nonce]
+	return revoked
+}
+
+// RevokeAllTokensForUser revokes every ephemeral token issued to the GitHub user so far, keeping the revocation
+// until the given time. Revocations of users whose revoked tokens have all expired are pruned.
+func (s *ServiceImpl) RevokeAllTokensForUser(_ context.Context, githubUserID string, until time.Time) error {
+	if githubUserID == "" {
+		return errors.New("GitHub user ID is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for userID, revocation := range s.revokedUsers {
+		if now.After(revocation.until) {
+			delete(s.revokedUsers, userID)
+		}
+	}
+
+	s.revokedUsers[githubUserID] = userRevocation{revokedAt: now, until: until}
+	return nil
+}
+
+// isUserRevoked reports whether the tokens of a GitHub user issued at the given time have been revoked
+func (s *ServiceImpl) isUserRevoked(githubUserID string, issuedAt time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	revocation, ok := s.revokedUsers[githubUserID]
+	return ok && !issuedAt.After(revocation.revokedAt)
+}
+
 // generateEphemeralToken creates a new ephemeral token for a GitHub user
 func (s *ServiceImpl) generateEphemeralToken(githubUserID, githubUsername string, duration time.Duration) (string, error) {
 	// Generate a random nonce
@@ -253,6 +448,11 @@ func (s *ServiceImpl) validateEphemeralToken(tokenString string) (*EphemeralToke
 		return nil, errors.New("token has expired")
 	}
 
+	// Check revocation
+	if s.isNonceRevoked(token.Claims.Nonce) || s.isUserRevoked(token.Claims.GitHubUserID, token.Claims.IssuedAt) {
+		return nil, ErrTokenRevoked
+	}
+
 	return &token.Claims, nil
 }
 
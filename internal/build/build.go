@@ -0,0 +1,35 @@
+// Package build describes the build of the running MCP Registry binary
+package build
+
+import "runtime"
+
+// Build information, overridden at link time with
+// -ldflags "-X github.com/modelcontextprotocol/registry/internal/build.GitCommit=..."
+var (
+	// Version is the current version of the MCP Registry application
+	Version = "0.1.0"
+
+	// BuildTime is the time at which the binary was built
+	BuildTime = "undefined"
+
+	// GitCommit is the git commit that was compiled
+	GitCommit = "undefined"
+)
+
+// Info describes the build of the running binary
+type Info struct {
+	Version   string `json:"version"`
+	GitCommit string `json:"git_commit"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+}
+
+// Get returns the build information of the running binary
+func Get() Info {
+	return Info{
+		Version:   Version,
+		GitCommit: GitCommit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+}
@@ -2,7 +2,9 @@ package config
 
 import (
 	"fmt"
+	"net/netip"
 	"strings"
+	"time"
 
 	env "github.com/caarlos0/env/v11"
 )
@@ -14,22 +16,55 @@ const (
 	DatabaseTypeMemory  DatabaseType = "memory"
 )
 
+// Methods the registry uses to authenticate its own GitHub API requests
+const (
+	AuthMethodOAuth     = "oauth"
+	AuthMethodGitHubApp = "github_app"
+)
+
 // Config holds the application configuration
 type Config struct {
-	ServerAddress               string       `env:"SERVER_ADDRESS" envDefault:":8080"`
-	Environment                 string       `env:"ENVIRONMENT" envDefault:"development"`
-	DatabaseType                DatabaseType `env:"DATABASE_TYPE" envDefault:"mongodb"`
-	DatabaseURL                 string       `env:"DATABASE_URL" envDefault:"mongodb://localhost:27017"`
-	DatabaseName                string       `env:"DATABASE_NAME" envDefault:"mcp-registry"`
-	CollectionName              string       `env:"COLLECTION_NAME" envDefault:"servers_v2"`
-	LogLevel                    string       `env:"LOG_LEVEL" envDefault:"info"`
-	SeedFilePath                string       `env:"SEED_FILE_PATH" envDefault:"data/seed.json"`
-	SeedImport                  bool         `env:"SEED_IMPORT" envDefault:"true"`
-	Version                     string       `env:"VERSION" envDefault:"dev"`
-	GithubClientID              string       `env:"GITHUB_CLIENT_ID" envDefault:""`
-	GithubClientSecret          string       `env:"GITHUB_CLIENT_SECRET" envDefault:""`
-	RegistryOwnerGithubUsername string       `env:"REGISTRY_OWNER_GITHUB_USERNAME" envDefault:""`
-	EphemeralTokenSecret        string       `env:"EPHEMERAL_TOKEN_SECRET" envDefault:""`
+	ServerAddress               string        `env:"SERVER_ADDRESS" envDefault:":8080"`
+	Environment                 string        `env:"ENVIRONMENT" envDefault:"development"`
+	DatabaseType                DatabaseType  `env:"DATABASE_TYPE" envDefault:"mongodb"`
+	DatabaseURL                 string        `env:"DATABASE_URL" envDefault:"mongodb://localhost:27017"`
+	DatabaseName                string        `env:"DATABASE_NAME" envDefault:"mcp-registry"`
+	CollectionName              string        `env:"COLLECTION_NAME" envDefault:"servers_v2"`
+	LogLevel                    string        `env:"LOG_LEVEL" envDefault:"info"`
+	SeedFilePath                string        `env:"SEED_FILE_PATH" envDefault:"data/seed.json"`
+	SeedImport                  bool          `env:"SEED_IMPORT" envDefault:"true"`
+	Version                     string        `env:"VERSION" envDefault:"dev"`
+	GithubClientID              string        `env:"GITHUB_CLIENT_ID" envDefault:""`
+	GithubClientSecret          string        `env:"GITHUB_CLIENT_SECRET" envDefault:""`
+	GithubAppID                 int64         `env:"GITHUB_APP_ID" envDefault:"0"`
+	GithubAppPrivateKeyPath     string        `env:"GITHUB_APP_PRIVATE_KEY_PATH" envDefault:""`
+	RegistryOwnerGithubUsername string        `env:"REGISTRY_OWNER_GITHUB_USERNAME" envDefault:""`
+	EphemeralTokenSecret        string        `env:"EPHEMERAL_TOKEN_SECRET" envDefault:""`
+	CacheTTL                    time.Duration `env:"CACHE_TTL" envDefault:"0s"`
+	RefreshWindowDuration       time.Duration `env:"REFRESH_WINDOW_DURATION" envDefault:"10m"`
+	SecurityHeaders             bool          `env:"SECURITY_HEADERS" envDefault:"true"`
+	RepoStatsSyncEnabled        bool          `env:"REPO_STATS_SYNC_ENABLED" envDefault:"false"`
+	RepoStatsSyncInterval       time.Duration `env:"REPO_STATS_SYNC_INTERVAL" envDefault:"6h"`
+	PublicURL                   string        `env:"PUBLIC_URL" envDefault:"https://registry.mcp.io"`
+	TrustedProxyCIDRs           []string      `env:"TRUSTED_PROXY_CIDRS" envSeparator:","`
+	VerifyPackageChecksums      bool          `env:"VERIFY_PACKAGE_CHECKSUMS" envDefault:"true"`
+	RedisURL                    string        `env:"REDIS_URL" envDefault:""`
+	TrendingSampleRate          float64       `env:"TRENDING_SAMPLE_RATE" envDefault:"1"`
+	VacuumRetention             time.Duration `env:"VACUUM_RETENTION" envDefault:"720h"`
+	AllowedRegistryNames        []string      `env:"ALLOWED_REGISTRY_NAMES" envSeparator:","`
+	MaxPackagesPerServer        int           `env:"MAX_PACKAGES_PER_SERVER" envDefault:"20"`
+	RequireSemVer               bool          `env:"REQUIRE_SEMVER" envDefault:"false"`
+	ScriptGenerationEnabled     bool          `env:"SCRIPT_GENERATION_ENABLED" envDefault:"true"`
+	SlowQueryThreshold          time.Duration `env:"SLOW_QUERY_THRESHOLD" envDefault:"500ms"`
+	TLSCertFile                 string        `env:"TLS_CERT_FILE" envDefault:""`
+	TLSKeyFile                  string        `env:"TLS_KEY_FILE" envDefault:""`
+	HTTP2Enabled                bool          `env:"HTTP2_ENABLED" envDefault:"true"`
+	HTTP2MaxConcurrentStreams   uint32        `env:"HTTP2_MAX_CONCURRENT_STREAMS" envDefault:"250"`
+	HTTP2IdleTimeout            time.Duration `env:"HTTP2_IDLE_TIMEOUT" envDefault:"2m"`
+	HTTP2MaxReadFrameSize       uint32        `env:"HTTP2_MAX_READ_FRAME_SIZE" envDefault:"1048576"`
+	MetricsAddress              string        `env:"METRICS_ADDRESS" envDefault:""`
+	RateLimitRPM                int           `env:"RATE_LIMIT_RPM" envDefault:"0"`
+	RateLimitBurst              int           `env:"RATE_LIMIT_BURST" envDefault:"20"`
 }
 
 // NewConfig creates a new configuration with default values
@@ -63,5 +98,52 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("missing required environment variables: %s", strings.Join(missingVars, ", "))
 	}
 
+	if (c.GithubAppID != 0) != (c.GithubAppPrivateKeyPath != "") {
+		return fmt.Errorf("MCP_REGISTRY_GITHUB_APP_ID and MCP_REGISTRY_GITHUB_APP_PRIVATE_KEY_PATH must be set together")
+	}
+
+	if c.RedisURL != "" && (c.TrendingSampleRate <= 0 || c.TrendingSampleRate > 1) {
+		return fmt.Errorf("MCP_REGISTRY_TRENDING_SAMPLE_RATE must be greater than 0 and at most 1")
+	}
+
+	if c.RepoStatsSyncEnabled && c.RepoStatsSyncInterval <= 0 {
+		return fmt.Errorf("MCP_REGISTRY_REPO_STATS_SYNC_INTERVAL must be positive when repository stats sync is enabled")
+	}
+
+	if (c.TLSCertFile != "") != (c.TLSKeyFile != "") {
+		return fmt.Errorf("MCP_REGISTRY_TLS_CERT_FILE and MCP_REGISTRY_TLS_KEY_FILE must be set together")
+	}
+
+	// HTTP/2 limits the frame size to between 16 KiB and 16 MiB
+	if c.HTTP2Enabled && (c.HTTP2MaxReadFrameSize < 1<<14 || c.HTTP2MaxReadFrameSize > 1<<24-1) {
+		return fmt.Errorf("MCP_REGISTRY_HTTP2_MAX_READ_FRAME_SIZE must be between 16384 and 16777215")
+	}
+
+	if c.RateLimitRPM < 0 {
+		return fmt.Errorf("MCP_REGISTRY_RATE_LIMIT_RPM must not be negative")
+	}
+	if c.RateLimitRPM > 0 && c.RateLimitBurst < 1 {
+		return fmt.Errorf("MCP_REGISTRY_RATE_LIMIT_BURST must be at least 1 when rate limiting is enabled")
+	}
+
+	if c.MetricsAddress != "" && c.MetricsAddress == c.ServerAddress {
+		return fmt.Errorf("MCP_REGISTRY_METRICS_ADDRESS must differ from MCP_REGISTRY_SERVER_ADDRESS")
+	}
+
+	for _, cidr := range c.TrustedProxyCIDRs {
+		if _, err := netip.ParsePrefix(strings.TrimSpace(cidr)); err != nil {
+			return fmt.Errorf("invalid trusted proxy CIDR in MCP_REGISTRY_TRUSTED_PROXY_CIDRS: %w", err)
+		}
+	}
+
 	return nil
 }
+
+// AuthMethod returns how the registry authenticates its own GitHub API requests:
+// with GitHub App installation tokens when the app is configured, otherwise with OAuth user tokens.
+func (c *Config) AuthMethod() string {
+	if c.GithubAppID != 0 && c.GithubAppPrivateKeyPath != "" {
+		return AuthMethodGitHubApp
+	}
+	return AuthMethodOAuth
+}
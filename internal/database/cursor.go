@@ -0,0 +1,136 @@
+package database
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EncodeCursor encodes a creation sequence number into an opaque pagination cursor
+func EncodeCursor(seq int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(seq, 10)))
+}
+
+// DecodeCursor decodes an opaque pagination cursor into the creation sequence number or offset it points to
+func DecodeCursor(cursor string) (int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor format: %w", err)
+	}
+
+	seq, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil || seq < 0 {
+		return 0, fmt.Errorf("invalid cursor format: %q", cursor)
+	}
+
+	return seq, nil
+}
+
+// EncodeSyncCursor encodes the update time and creation sequence number of the last server version of an
+// incremental sync listing into an opaque cursor
+func EncodeSyncCursor(updatedAt time.Time, seq int64) string {
+	raw := updatedAt.UTC().Format(time.RFC3339Nano) + "," + strconv.FormatInt(seq, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeSyncCursor decodes an opaque incremental sync cursor into the update time and creation sequence number
+// it points to
+func DecodeSyncCursor(cursor string) (time.Time, int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("invalid sync cursor format: %w", err)
+	}
+
+	updatedAtStr, seqStr, ok := strings.Cut(string(raw), ",")
+	if !ok {
+		return time.Time{}, 0, fmt.Errorf("invalid sync cursor format: %q", cursor)
+	}
+	updatedAt, err := time.Parse(time.RFC3339Nano, updatedAtStr)
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("invalid sync cursor format: %q", cursor)
+	}
+	seq, err := strconv.ParseInt(seqStr, 10, 64)
+	if err != nil || seq < 0 {
+		return time.Time{}, 0, fmt.Errorf("invalid sync cursor format: %q", cursor)
+	}
+
+	return updatedAt, seq, nil
+}
+
+// CursorDirection is the direction in which a pagination cursor pages through results
+type CursorDirection string
+
+const (
+	// CursorNext pages forwards, to the results after the cursor
+	CursorNext CursorDirection = "next"
+	// CursorPrev pages backwards, to the results before the cursor
+	CursorPrev CursorDirection = "prev"
+)
+
+// ParseCursorDirection parses a cursor direction, defaulting to CursorNext if it is empty
+func ParseCursorDirection(direction string) (CursorDirection, error) {
+	switch CursorDirection(direction) {
+	case "", CursorNext:
+		return CursorNext, nil
+	case CursorPrev:
+		return CursorPrev, nil
+	default:
+		return "", fmt.Errorf("invalid cursor direction: %q", direction)
+	}
+}
+
+// PageCursors are the cursors of the pages next to a page of results. Each is empty if there is no such page.
+type PageCursors struct {
+	// Next points to the last result of the page and is passed with CursorNext
+	Next string
+	// Prev points to the first result of the page and is passed with CursorPrev
+	Prev string
+}
+
+// seqCursors returns the cursors around a non-empty page of results in creation sequence order.
+// hasBefore and hasAfter report whether there are results before and after the page.
+func seqCursors(firstSeq, lastSeq int64, hasBefore, hasAfter bool) PageCursors {
+	var cursors PageCursors
+	if hasAfter {
+		cursors.Next = EncodeCursor(lastSeq)
+	}
+	if hasBefore {
+		cursors.Prev = EncodeCursor(firstSeq)
+	}
+	return cursors
+}
+
+// offsetWindow returns the offset of the first result and the maximum number of results of the page selected
+// by an offset cursor. Going forwards, the page starts at the offset; going backwards, it ends before it.
+func offsetWindow(cursor string, direction CursorDirection, limit int) (int64, int, error) {
+	if cursor == "" {
+		return 0, limit, nil
+	}
+	offset, err := DecodeCursor(cursor)
+	if err != nil {
+		return 0, 0, err
+	}
+	if direction != CursorPrev {
+		return offset, limit, nil
+	}
+	start := max(0, offset-int64(limit))
+	return start, int(offset - start), nil
+}
+
+// offsetCursors returns the cursors around a page of count results starting at the given offset.
+// hasAfter reports whether there are results after the page.
+func offsetCursors(offset int64, count int, hasAfter bool) PageCursors {
+	var cursors PageCursors
+	if count == 0 {
+		return cursors
+	}
+	if hasAfter {
+		cursors.Next = EncodeCursor(offset + int64(count))
+	}
+	if offset > 0 {
+		cursors.Prev = EncodeCursor(offset)
+	}
+	return cursors
+}
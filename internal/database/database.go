@@ -2,32 +2,229 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/modelcontextprotocol/registry/internal/model"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 // Common database errors
 var (
-	ErrNotFound       = errors.New("record not found")
-	ErrAlreadyExists  = errors.New("record already exists")
-	ErrInvalidInput   = errors.New("invalid input")
-	ErrDatabase       = errors.New("database error")
-	ErrInvalidVersion = errors.New("invalid version: cannot publish older version after newer version")
+	ErrNotFound           = errors.New("record not found")
+	ErrValidation         = errors.New("validation failed")
+	ErrAlreadyExists      = errors.New("record already exists")
+	ErrInvalidInput       = errors.New("invalid input")
+	ErrDatabase           = errors.New("database error")
+	ErrInvalidVersion     = errors.New("invalid version: cannot publish older version after newer version")
+	ErrTooManyPackages    = errors.New("too many packages")
+	ErrLastPackage        = errors.New("cannot remove the last package of a server")
+	ErrInvalidCategory    = errors.New("invalid category")
+	ErrInvalidEmail       = errors.New("invalid contact email")
+	ErrInvalidAttestation = errors.New("invalid attestation")
+	ErrInvalidReview      = errors.New("invalid review")
+	ErrInvalidSchema      = errors.New("invalid schema")
+	ErrInvalidDocURL      = errors.New("invalid documentation URL")
+	ErrInvalidHealthCheck = errors.New("invalid health check URL")
+	ErrInvalidTool        = errors.New("invalid tool")
+	ErrInvalidMCPVersion  = errors.New("invalid minimum MCP version")
+	ErrInvalidRegistry    = errors.New("registry name not allowed")
+	ErrInvalidDependency  = errors.New("invalid dependency")
+	ErrInvalidWebhook     = errors.New("invalid webhook subscription")
+	ErrTooManyWebhooks    = errors.New("too many webhook subscriptions")
+	ErrInvalidTag         = errors.New("invalid tag")
+	ErrInvalidLicense     = errors.New("invalid license")
+	ErrInvalidMaintainer  = errors.New("invalid maintainer")
+	ErrInvalidStatus      = errors.New("invalid status")
+	ErrStatusTransition   = errors.New("status transition not allowed")
+	ErrTooManyTags        = errors.New("too many tags")
+)
+
+// SortOrder defines the order in which ListDetails returns entries
+type SortOrder string
+
+const (
+	// SortByCreation orders entries by creation sequence
+	SortByCreation SortOrder = ""
+	// SortByStars orders entries by repository star count, highest first
+	SortByStars SortOrder = "stars"
+	// SortByReleaseDate orders entries by the time the registry received them, most recent first
+	SortByReleaseDate SortOrder = "release_date"
+	// SortByName orders entries by server name, alphabetically. Like the other field sort orders, it is reversed
+	// by the DescendingPrefix.
+	SortByName SortOrder = "name"
+	// SortByPublishedAt orders entries by the time the registry received them, least recent first
+	SortByPublishedAt SortOrder = "published_at"
+	// SortByUpdatedAt orders entries by the time they were last modified, least recent first
+	SortByUpdatedAt SortOrder = "updated_at"
+	// SortByRelevance orders text search results by their relevance to the query, best match first.
+	// Without a text search condition entries are in creation order.
+	SortByRelevance SortOrder = "relevance"
 )
 
 // Database defines the interface for database operations on MCPRegistry entries
 type Database interface {
-	// List retrieves all MCPRegistry entries with optional filtering
-	List(ctx context.Context, filter map[string]interface{}, cursor string, limit int) ([]*model.Server, string, error)
-	// ListDetails retrieves all ServerDetail entries with optional filtering
-	ListDetails(ctx context.Context, filter map[string]interface{}, cursor string, limit int) ([]*model.ServerDetail, string, error)
+	// List retrieves all MCPRegistry entries with optional filtering, in creation order or the given field sort order.
+	// It returns the page after the cursor, or before it if direction is CursorPrev.
+	List(
+		ctx context.Context, filter bson.D, sortBy SortOrder, cursor string, direction CursorDirection, limit int,
+	) ([]*model.Server, PageCursors, error)
+	// ListUpdatedAfter retrieves the versions of all servers updated strictly after the given time, or at that
+	// time with a creation sequence number greater than afterSeq, least recently updated first
+	ListUpdatedAfter(ctx context.Context, updatedAfter time.Time, afterSeq int64, limit int) ([]*model.Server, error)
+	// ListRecent retrieves the latest versions of up to limit servers, most recently published first
+	ListRecent(ctx context.Context, limit int) ([]*model.Server, error)
+	// ListFeatured retrieves the featured servers in their display order
+	ListFeatured(ctx context.Context) ([]*model.Server, error)
+	// ListDetails retrieves all ServerDetail entries with optional filtering in the given sort order.
+	// It returns the page after the cursor, or before it if direction is CursorPrev.
+	ListDetails(
+		ctx context.Context, filter bson.D, sortBy SortOrder, cursor string, direction CursorDirection, limit int,
+	) ([]*model.ServerDetail, PageCursors, error)
+	// Count returns the number of servers whose latest version matches the filter, which takes the same conditions
+	// as the filter of ListDetails
+	Count(ctx context.Context, filter bson.D) (int64, error)
+	// CountFacets returns the number of servers whose latest version matches the filter with each package registry,
+	// tag, license and status
+	CountFacets(ctx context.Context, filter bson.D) (model.Facets, error)
+	// ListVersions retrieves the versions of the server with the given ID in publish order. Yanked versions are
+	// only included if includeYanked is set. It returns the page after the cursor, or before it if direction is
+	// CursorPrev, and ErrNotFound if the server does not exist.
+	ListVersions(
+		ctx context.Context, id string, includeYanked bool, cursor string, direction CursorDirection, limit int,
+	) ([]*model.ServerDetail, PageCursors, error)
 	// GetByID retrieves a single ServerDetail by it's ID
 	GetByID(ctx context.Context, id string) (*model.ServerDetail, error)
-	// Publish adds a new ServerDetail to the database
+	// GetByIDs retrieves the ServerDetails with the given IDs, in no particular order; unknown IDs are skipped
+	GetByIDs(ctx context.Context, ids []string) ([]*model.ServerDetail, error)
+	// Publish adds a new ServerDetail to the database, storing its schema if one is provided
 	Publish(ctx context.Context, serverDetail *model.ServerDetail) error
+	// Update replaces the mutable fields of an existing ServerDetail with those of detail: the description,
+	// the packages, and the version number and release date. It returns ErrAlreadyExists if another version of
+	// the server has the new version number, and ErrInvalidVersion if the latest version would no longer be
+	// the highest.
+	Update(ctx context.Context, id string, detail *model.ServerDetail) error
+	// Delete removes a ServerDetail. If it was the latest version of its server, the highest remaining version
+	// that is not yanked becomes the latest.
+	Delete(ctx context.Context, id string) error
+	// AppendPackages adds packages to an existing ServerDetail. It returns ErrTooManyPackages if the server would
+	// then have more than maxPackages packages; the number is not limited if maxPackages is not positive.
+	AppendPackages(ctx context.Context, id string, packages []model.Package, maxPackages int) error
+	// RemovePackage removes a package from an existing ServerDetail
+	RemovePackage(ctx context.Context, id, registryName, packageName string) error
+	// AddTags adds tags to an existing ServerDetail, ignoring tags it already has
+	AddTags(ctx context.Context, id string, tags []string) error
+	// RemoveTag removes a tag from an existing ServerDetail
+	RemoveTag(ctx context.Context, id, tag string) error
+	// YankPackage marks the packages of an existing ServerDetail with the given registry name and version as yanked
+	YankPackage(ctx context.Context, id, registryName, version, reason string) error
+	// SetPackageChecksum records the verified checksum of a package of an existing ServerDetail
+	SetPackageChecksum(ctx context.Context, id, registryName, packageName, checksum string) error
+	// PinVersion marks the given version of a server as the latest version, regardless of version order
+	PinVersion(ctx context.Context, id, version string) error
+	// UpgradeVersion marks the given version of a server as the latest version, regardless of version order,
+	// without pinning it: publishing a new version makes that the latest as usual
+	UpgradeVersion(ctx context.Context, id, version string) error
+	// YankVersion marks the given version of a server as yanked. If it was the latest version,
+	// the highest remaining version that is not yanked becomes the latest.
+	YankVersion(ctx context.Context, id, version string) error
+	// TransferOwnership sets the publisher of all versions of a server to the given GitHub user
+	TransferOwnership(ctx context.Context, id, newOwner string) error
+	// SetMaintainers replaces the maintainers of all versions of a server
+	SetMaintainers(ctx context.Context, id string, maintainers []string) error
+	// SetStatus sets the lifecycle status of all versions of a server
+	SetStatus(ctx context.Context, id, status string) error
+	// SetRepositoryStats sets the repository stats of all versions of a server. Their update time is kept, as
+	// the stats are refreshed by the registry rather than changed by the publisher.
+	SetRepositoryStats(ctx context.Context, id string, stats model.RepositoryStats) error
+	// ReorderFeatured sets the display order of the featured servers to the order of the given IDs.
+	// It returns ErrInvalidInput unless the IDs list every featured server exactly once.
+	ReorderFeatured(ctx context.Context, ids []string) error
+	// CountDependents returns the number of server versions that list the server with the given ID as a dependency
+	CountDependents(ctx context.Context, id string) (int64, error)
+	// ListRelated returns up to limit servers directly connected to the given server, sorted by name: the latest
+	// versions of other servers with the same publisher, sharing a tag or depending on it, and its dependencies
+	ListRelated(ctx context.Context, serverDetail *model.ServerDetail, limit int) ([]*model.ServerDetail, error)
+	// CountByCategory returns the number of servers in each category; servers without a category are not counted
+	CountByCategory(ctx context.Context) (map[model.Category]int, error)
+	// CountByLanguage returns the number of servers written in each language; servers without a language are not counted
+	CountByLanguage(ctx context.Context) (map[string]int, error)
+	// CountVerifiedChecksums returns the number of servers whose latest version has at least one package with a
+	// verified checksum
+	CountVerifiedChecksums(ctx context.Context) (int, error)
+	// AggregateStats returns the number of servers, the number of servers with a package in each package
+	// registry, the number of versions published in the 24 hours, 7 days and 30 days before now, and the number
+	// of distinct publishers. The verified checksum count is left to CountVerifiedChecksums.
+	AggregateStats(ctx context.Context, now time.Time) (model.RegistryStats, error)
+	// CountByTopic returns the number of servers tagged with each topic; servers without topics are not counted
+	CountByTopic(ctx context.Context) (map[string]int, error)
+	// CountByTag returns the number of servers whose latest version carries each tag; servers without tags are not
+	// counted
+	CountByTag(ctx context.Context) (map[string]int, error)
+	// RebuildTextIndex drops and recreates the text search index and returns the number of indexed documents
+	RebuildTextIndex(ctx context.Context) (int64, error)
+	// SaveReindexJob creates or updates a text index rebuild job
+	SaveReindexJob(ctx context.Context, job *model.ReindexJob) error
+	// GetReindexJob retrieves a text index rebuild job by its ID
+	GetReindexJob(ctx context.Context, id string) (*model.ReindexJob, error)
+	// RemoveOrphanedSchemas deletes stored schemas whose server version no longer exists.
+	// It returns the number of removed schemas and the storage they used.
+	RemoveOrphanedSchemas(ctx context.Context) (removed int64, bytesReclaimed int64, err error)
+	// RemoveReindexJobs deletes the reindex jobs that completed before the given time.
+	// It returns the number of removed jobs and the storage they used.
+	RemoveReindexJobs(ctx context.Context, completedBefore time.Time) (removed int64, bytesReclaimed int64, err error)
+	// Compact defragments the storage of the server collections and returns the number of bytes freed
+	Compact(ctx context.Context) (int64, error)
+	// SaveVacuumJob creates or updates a vacuum job
+	SaveVacuumJob(ctx context.Context, job *model.VacuumJob) error
+	// GetVacuumJob retrieves a vacuum job by its ID
+	GetVacuumJob(ctx context.Context, id string) (*model.VacuumJob, error)
+	// SaveProvenance creates or replaces the provenance attestation of a server version
+	SaveProvenance(ctx context.Context, provenance *model.Provenance) error
+	// GetProvenance retrieves the provenance attestation of a version of the named server,
+	// or the most recently attested version if version is empty
+	GetProvenance(ctx context.Context, serverName, version string) (*model.Provenance, error)
+	// GetSchema retrieves the JSON schema published with a server version.
+	// It returns ErrNotFound if the server does not exist or was published without a schema.
+	GetSchema(ctx context.Context, id string) (json.RawMessage, error)
+	// GetGitHubMetadata retrieves the GitHub repository information stored when a server version was published.
+	// It returns ErrNotFound if the server does not exist or has no stored metadata.
+	GetGitHubMetadata(ctx context.Context, id string) (json.RawMessage, error)
+	// SaveReview adds a review of a server and updates the rating summary of the server.
+	// It returns ErrAlreadyExists if the user has already reviewed the server.
+	SaveReview(ctx context.Context, review *model.Review) error
+	// ListReviews retrieves the reviews of a server, newest first
+	ListReviews(
+		ctx context.Context, serverID string, cursor string, direction CursorDirection, limit int,
+	) ([]*model.Review, PageCursors, error)
+	// SaveSubscription creates or updates a webhook subscription.
+	// It returns ErrAlreadyExists if another subscription of the server uses the same webhook URL.
+	SaveSubscription(ctx context.Context, subscription *model.Subscription) error
+	// GetSubscription retrieves a webhook subscription by its ID
+	GetSubscription(ctx context.Context, id string) (*model.Subscription, error)
+	// FindSubscription retrieves the subscription of the named server with the given webhook URL
+	FindSubscription(ctx context.Context, serverName, webhookURL string) (*model.Subscription, error)
+	// ListSubscriptions retrieves all subscriptions of the named server
+	ListSubscriptions(ctx context.Context, serverName string) ([]*model.Subscription, error)
+	// CountSubscriptions returns the number of subscriptions held by a GitHub user
+	CountSubscriptions(ctx context.Context, githubUserID string) (int64, error)
+	// DeleteSubscription removes a webhook subscription
+	DeleteSubscription(ctx context.Context, id string) error
+	// SaveAPIKey stores a new API key
+	SaveAPIKey(ctx context.Context, key *model.APIKey) error
+	// GetAPIKey retrieves an API key by its ID
+	GetAPIKey(ctx context.Context, id string) (*model.APIKey, error)
+	// ListAPIKeys retrieves all API keys, oldest first
+	ListAPIKeys(ctx context.Context) ([]*model.APIKey, error)
+	// DeleteAPIKey removes an API key
+	DeleteAPIKey(ctx context.Context, id string) error
 	// ImportSeed imports initial data from a seed file
 	ImportSeed(ctx context.Context, seedFilePath string) error
+	// WithTransaction runs fn atomically: if fn returns an error, none of the changes made through the context
+	// passed to fn are kept. Transactions started within fn join the outer transaction.
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 	// Close closes the database connection
 	Close() error
 }
@@ -53,3 +250,66 @@ type ConnectionInfo struct {
 	// For MemoryDB, this will be map[string]*model.MCPRegistry
 	Raw any
 }
+
+// checkVersionChange checks that a version of a server may be renumbered to the given version, given all the
+// versions of the server: the version must stay unique and, unless it is pinned, the latest version the highest
+func checkVersionChange(versions []*model.ServerDetail, entry *model.ServerDetail, version string) error {
+	for _, other := range versions {
+		if other.ID == entry.ID {
+			continue
+		}
+		if other.VersionDetail.Version == version {
+			return ErrAlreadyExists
+		}
+
+		cmp := compareSemanticVersions(version, other.VersionDetail.Version)
+		if entry.VersionDetail.IsLatest && !entry.PinnedVersion && cmp < 0 {
+			return ErrInvalidVersion
+		}
+		if other.VersionDetail.IsLatest && !other.PinnedVersion && cmp > 0 {
+			return ErrInvalidVersion
+		}
+	}
+	return nil
+}
+
+// CheckNewVersion checks that a version may be added to a server, given all the versions of the server
+// including yanked ones: it returns ErrAlreadyExists if the version exists and ErrInvalidVersion unless it is
+// higher than every existing version
+func CheckNewVersion(versions []*model.ServerDetail, version string) error {
+	for _, other := range versions {
+		if other.VersionDetail.Version == version {
+			return ErrAlreadyExists
+		}
+		if compareSemanticVersions(version, other.VersionDetail.Version) <= 0 {
+			return ErrInvalidVersion
+		}
+	}
+	return nil
+}
+
+// updateTime returns the time to record as UpdatedAt for a modification.
+// MongoDB stores times with millisecond precision, so all implementations truncate to milliseconds.
+func updateTime() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
+
+// checkFeaturedOrder verifies that a new display order lists every featured server exactly once
+func checkFeaturedOrder(order []string, featured map[string]bool) error {
+	listed := make(map[string]bool, len(order))
+	for _, id := range order {
+		if !featured[id] {
+			return fmt.Errorf("%w: server %s does not exist or is not featured", ErrInvalidInput, id)
+		}
+		if listed[id] {
+			return fmt.Errorf("%w: server %s is listed more than once", ErrInvalidInput, id)
+		}
+		listed[id] = true
+	}
+	for id := range featured {
+		if !listed[id] {
+			return fmt.Errorf("%w: featured server %s is missing from the order", ErrInvalidInput, id)
+		}
+	}
+	return nil
+}
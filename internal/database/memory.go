@@ -2,36 +2,130 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"maps"
+	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
+	"unicode"
 
 	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/database/memory"
 	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/search"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 // MemoryDB is an in-memory implementation of the Database interface
 type MemoryDB struct {
-	entries map[string]*model.ServerDetail
+	entries     map[string]*model.ServerDetail
+	reindexJobs map[string]*model.ReindexJob
+	vacuumJobs  map[string]*model.VacuumJob
+	// provenances holds the provenance attestations keyed by server ID and version
+	provenances map[provenanceKey]*model.Provenance
+	// reviews holds the reviews of each server in submission order, keyed by server ID
+	reviews map[string][]*model.Review
+	// schemas holds the published JSON schemas keyed by server ID
+	schemas map[string]json.RawMessage
+	// subscriptions holds the webhook subscriptions keyed by subscription ID
+	subscriptions map[string]*model.Subscription
+	// apiKeys holds the API keys keyed by key ID
+	apiKeys map[string]*model.APIKey
+	nextSeq int64
 	mu      sync.RWMutex
+	// txMu serializes transactions so that a rollback only discards the changes of its own transaction
+	txMu sync.Mutex
 }
 
+// memoryTransactionKey marks the contexts of functions running in a MemoryDB transaction
+type memoryTransactionKey struct{}
+
 // NewMemoryDB creates a new instance of the in-memory database
 func NewMemoryDB(e map[string]*model.Server) *MemoryDB {
 	// Convert Server entries to ServerDetail entries
+	// Assign creation sequence numbers in ID order so pagination is deterministic
+	keys := make([]string, 0, len(e))
+	for k := range e {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
 	serverDetails := make(map[string]*model.ServerDetail)
-	for k, v := range e {
+	var seq int64
+	for _, k := range keys {
+		seq++
 		serverDetails[k] = &model.ServerDetail{
-			Server: *v,
+			Server: *e[k],
 		}
+		serverDetails[k].CreatedSeq = seq
 	}
 	return &MemoryDB{
-		entries: serverDetails,
+		entries:       serverDetails,
+		reindexJobs:   make(map[string]*model.ReindexJob),
+		vacuumJobs:    make(map[string]*model.VacuumJob),
+		provenances:   make(map[provenanceKey]*model.Provenance),
+		reviews:       make(map[string][]*model.Review),
+		schemas:       make(map[string]json.RawMessage),
+		subscriptions: make(map[string]*model.Subscription),
+		apiKeys:       make(map[string]*model.APIKey),
+		nextSeq:       seq,
+	}
+}
+
+// cloneServerDetail copies a ServerDetail together with its packages and remotes,
+// so callers never share slices with the stored entry
+func cloneServerDetail(serverDetail *model.ServerDetail) *model.ServerDetail {
+	serverDetailCopy := *serverDetail
+	serverDetailCopy.Packages = slices.Clone(serverDetail.Packages)
+	serverDetailCopy.Remotes = slices.Clone(serverDetail.Remotes)
+	serverDetailCopy.MaintainedBy = slices.Clone(serverDetail.MaintainedBy)
+	serverDetailCopy.Topics = slices.Clone(serverDetail.Topics)
+	serverDetailCopy.Tags = slices.Clone(serverDetail.Tags)
+	serverDetailCopy.Tools = slices.Clone(serverDetail.Tools)
+	serverDetailCopy.Dependencies = slices.Clone(serverDetail.Dependencies)
+	serverDetailCopy.GitHubMetadata = slices.Clone(serverDetail.GitHubMetadata)
+	return &serverDetailCopy
+}
+
+// WithTransaction runs fn and, if it fails, restores the servers and schemas to how they were before.
+// Transactions run one at a time and calls made within a transaction join it. Changes made outside of
+// transactions are not isolated from them: a failed transaction also discards the changes made by other
+// callers while it ran.
+func (db *MemoryDB) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if ctx.Value(memoryTransactionKey{}) != nil {
+		return fn(ctx)
+	}
+
+	db.txMu.Lock()
+	defer db.txMu.Unlock()
+
+	// Begin by copying the state a rollback restores
+	db.mu.RLock()
+	entries := make(map[string]*model.ServerDetail, len(db.entries))
+	for id, entry := range db.entries {
+		entries[id] = cloneServerDetail(entry)
 	}
+	schemas := maps.Clone(db.schemas)
+	nextSeq := db.nextSeq
+	db.mu.RUnlock()
+
+	if err := fn(context.WithValue(ctx, memoryTransactionKey{}, true)); err != nil {
+		// Roll back
+		db.mu.Lock()
+		db.entries, db.schemas, db.nextSeq = entries, schemas, nextSeq
+		db.mu.Unlock()
+		return err
+	}
+	return nil
 }
 
 // compareSemanticVersions compares two semantic version strings
@@ -85,17 +179,183 @@ func compareSemanticVersions(version1, version2 string) int {
 	return 0
 }
 
+// latestUnyankedVersion returns the highest version that is not yanked, or nil if all versions are yanked
+func latestUnyankedVersion(versions []*model.ServerDetail) *model.ServerDetail {
+	var latest *model.ServerDetail
+	for _, candidate := range versions {
+		if candidate.VersionDetail.Yanked {
+			continue
+		}
+		if latest == nil || compareSemanticVersions(candidate.VersionDetail.Version, latest.VersionDetail.Version) > 0 {
+			latest = candidate
+		}
+	}
+	return latest
+}
+
+// matchesRegexFilter reports whether the value matches a {"$regex": ..., "$options": ...} filter condition.
+// Conditions without a valid $regex match nothing.
+func matchesRegexFilter(value string, condition bson.M) bool {
+	pattern, ok := condition["$regex"].(string)
+	if !ok {
+		return false
+	}
+	if options, _ := condition["$options"].(string); strings.Contains(options, "i") {
+		pattern = "(?i)" + pattern
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(value)
+}
+
+// matchesRangeFilter evaluates the $gte and $lte operators of a range filter against a value
+func matchesRangeFilter(value float64, condition bson.M) bool {
+	if lower, ok := condition["$gte"].(float64); ok && value < lower {
+		return false
+	}
+	if upper, ok := condition["$lte"].(float64); ok && value > upper {
+		return false
+	}
+	return true
+}
+
+// matchesTimeRangeFilter reports whether a time satisfies the $gt, $gte, $lt and $lte bounds of a filter condition
+func matchesTimeRangeFilter(value time.Time, condition bson.M) bool {
+	if lower, ok := condition["$gt"].(time.Time); ok && !value.After(lower) {
+		return false
+	}
+	if lower, ok := condition["$gte"].(time.Time); ok && value.Before(lower) {
+		return false
+	}
+	if upper, ok := condition["$lt"].(time.Time); ok && !value.Before(upper) {
+		return false
+	}
+	if upper, ok := condition["$lte"].(time.Time); ok && value.After(upper) {
+		return false
+	}
+	return true
+}
+
+// matchesAllFilter reports whether the values contain every element of an {"$all": [...]} filter condition
+func matchesAllFilter(values []string, condition bson.M) bool {
+	required, ok := condition["$all"].([]string)
+	if !ok {
+		return false
+	}
+	for _, value := range required {
+		if !slices.Contains(values, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesStatusFilter reports whether a status matches a status filter condition: either a status, or an
+// {"$in": [...]} condition in which nil matches servers without a status
+func matchesStatusFilter(status string, condition any) bool {
+	switch condition := condition.(type) {
+	case string:
+		return status == condition
+	case bson.M:
+		values, _ := condition["$in"].(bson.A)
+		for _, value := range values {
+			if value == nil && status == "" || value == status {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// textIndexed returns the text of a server covered by the text index
+func textIndexed(server *model.Server) string {
+	return server.Name + " " + server.Language + " " + strings.Join(server.Topics, " ")
+}
+
+// hasVerifiedChecksum reports whether the registry verified the checksum of a package
+func hasVerifiedChecksum(pkg model.Package) bool {
+	return pkg.VerifiedChecksum != ""
+}
+
+// matchesTextFilter reports whether the text matches a {"$search": ...} text search condition, following
+// MongoDB semantics: if the search contains quoted phrases the text must contain all of them, otherwise it
+// must contain at least one of the search terms as a word. Matching is case-insensitive.
+func matchesTextFilter(text string, condition bson.M) bool {
+	query, _ := condition["$search"].(string)
+	text = strings.ToLower(text)
+
+	if phrases := search.Phrases(query); len(phrases) > 0 {
+		for _, phrase := range phrases {
+			if !strings.Contains(text, strings.ToLower(phrase)) {
+				return false
+			}
+		}
+		return true
+	}
+
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, term := range strings.Fields(strings.ReplaceAll(query, `"`, " ")) {
+		if slices.Contains(words, strings.ToLower(term)) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAnyPattern reports whether a server matches any of the regex conditions of an $or filter
+func matchesAnyPattern(entry *model.ServerDetail, conditions bson.A) bool {
+	if entry == nil {
+		return false
+	}
+	for _, condition := range conditions {
+		fields, ok := condition.(bson.M)
+		if !ok {
+			continue
+		}
+		for field, value := range fields {
+			regex, ok := value.(bson.M)
+			if !ok {
+				continue
+			}
+			switch field {
+			case "name":
+				if matchesRegexFilter(entry.Name, regex) {
+					return true
+				}
+			case "description":
+				if matchesRegexFilter(entry.Description, regex) {
+					return true
+				}
+			case "packages.name":
+				for _, pkg := range entry.Packages {
+					if matchesRegexFilter(pkg.Name, regex) {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
 // List retrieves all MCPRegistry entries with optional filtering and pagination
 //
 //gocognit:ignore
 func (db *MemoryDB) List(
 	ctx context.Context,
-	filter map[string]interface{},
+	filter bson.D,
+	sortBy SortOrder,
 	cursor string,
+	direction CursorDirection,
 	limit int,
-) ([]*model.Server, string, error) {
+) ([]*model.Server, PageCursors, error) {
 	if ctx.Err() != nil {
-		return nil, "", ctx.Err()
+		return nil, PageCursors{}, ctx.Err()
 	}
 
 	if limit <= 0 {
@@ -118,23 +378,14 @@ func (db *MemoryDB) List(
 		include := true
 
 		// Apply filters if any
-		for key, value := range filter {
+		for _, elem := range filter {
+			key, value := elem.Key, elem.Value
 			switch key {
 			case "name":
 				// Handle regex filter for name
-				if valueMap, ok := value.(map[string]interface{}); ok {
-					if regexPattern, hasRegex := valueMap["$regex"].(string); hasRegex {
-						// Simple case-insensitive regex matching
-						options, _ := valueMap["$options"].(string)
-						if strings.Contains(options, "i") {
-							if !strings.Contains(strings.ToLower(entry.Name), strings.ToLower(regexPattern)) {
-								include = false
-							}
-						} else {
-							if !strings.Contains(entry.Name, regexPattern) {
-								include = false
-							}
-						}
+				if valueMap, ok := value.(bson.M); ok {
+					if !matchesRegexFilter(entry.Name, valueMap) {
+						include = false
 					}
 				} else if entry.Name != value.(string) {
 					include = false
@@ -160,6 +411,44 @@ func (db *MemoryDB) List(
 				if entry.Repository.URL != value.(string) {
 					include = false
 				}
+			case "category":
+				if string(entry.Category) != value.(string) {
+					include = false
+				}
+			case "language":
+				if entry.Language != value.(string) {
+					include = false
+				}
+			case "license":
+				if entry.License != value.(string) {
+					include = false
+				}
+			case "status":
+				if !matchesStatusFilter(entry.Status, value) {
+					include = false
+				}
+			case "maintainers.github_username":
+				if !slices.ContainsFunc(entry.Maintainers, func(maintainer model.Maintainer) bool {
+					return maintainer.GitHubUsername == value.(string)
+				}) {
+					include = false
+				}
+			case "topics":
+				if !slices.Contains(entry.Topics, value.(string)) {
+					include = false
+				}
+			case "tags":
+				if condition, ok := value.(bson.M); !ok || !matchesAllFilter(entry.Tags, condition) {
+					include = false
+				}
+			case "$text":
+				if condition, ok := value.(bson.M); !ok || !matchesTextFilter(textIndexed(entry), condition) {
+					include = false
+				}
+			case "$or":
+				if conditions, ok := value.(bson.A); !ok || !matchesAnyPattern(db.entries[entry.ID], conditions) {
+					include = false
+				}
 			case "serverDetail.id":
 				if entry.ID != value.(string) {
 					include = false
@@ -177,42 +466,112 @@ func (db *MemoryDB) List(
 		}
 	}
 
-	// Find starting point for cursor-based pagination
-	startIdx := 0
-	if cursor != "" {
-		for i, entry := range filteredEntries {
-			if entry.ID == cursor {
-				startIdx = i + 1 // Start after the cursor
-				break
-			}
+	if IsFieldSort(sortBy) {
+		return paginateByField(filteredEntries, func(entry *model.Server) *model.Server { return entry }, sortBy, cursor, direction, limit)
+	}
+	return paginateBySeq(filteredEntries, func(entry *model.Server) int64 { return entry.CreatedSeq }, cursor, direction, limit)
+}
+
+// ListUpdatedAfter retrieves the versions of all servers updated strictly after the given time, or at that time
+// with a creation sequence number greater than afterSeq, least recently updated first
+func (db *MemoryDB) ListUpdatedAfter(
+	ctx context.Context, updatedAfter time.Time, afterSeq int64, limit int,
+) ([]*model.Server, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if limit <= 0 {
+		limit = 10 // Default limit
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	result := []*model.Server{}
+	for _, entry := range db.entries {
+		if entry.UpdatedAt.After(updatedAfter) || (entry.UpdatedAt.Equal(updatedAfter) && entry.CreatedSeq > afterSeq) {
+			serverCopy := entry.Server
+			result = append(result, &serverCopy)
+		}
+	}
+
+	// Entries updated together are ordered by creation sequence to keep pages deterministic
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].UpdatedAt.Equal(result[j].UpdatedAt) {
+			return result[i].UpdatedAt.Before(result[j].UpdatedAt)
+		}
+		return result[i].CreatedSeq < result[j].CreatedSeq
+	})
+
+	if len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result, nil
+}
+
+// ListRecent retrieves the latest versions of the most recently published servers, newest first
+func (db *MemoryDB) ListRecent(ctx context.Context, limit int) ([]*model.Server, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if limit <= 0 {
+		limit = 10 // Default limit
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	result := []*model.Server{}
+	for _, entry := range db.entries {
+		if entry.VersionDetail.IsLatest {
+			serverCopy := entry.Server
+			result = append(result, &serverCopy)
 		}
 	}
 
-	// Sort filteredEntries by ID for consistent pagination
-	sort.Slice(filteredEntries, func(i, j int) bool {
-		return filteredEntries[i].ID < filteredEntries[j].ID
+	// Servers published at the same time are ordered by creation, newest first
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].PublishedAt.Equal(result[j].PublishedAt) {
+			return result[i].PublishedAt.After(result[j].PublishedAt)
+		}
+		return result[i].CreatedSeq > result[j].CreatedSeq
 	})
 
-	// Apply pagination
-	endIdx := startIdx + limit
-	if endIdx > len(filteredEntries) {
-		endIdx = len(filteredEntries)
+	if len(result) > limit {
+		result = result[:limit]
 	}
 
-	var result []*model.Server
-	if startIdx < len(filteredEntries) {
-		result = filteredEntries[startIdx:endIdx]
-	} else {
-		result = []*model.Server{}
+	return result, nil
+}
+
+// ListFeatured retrieves the featured servers in their display order
+func (db *MemoryDB) ListFeatured(ctx context.Context) ([]*model.Server, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
 	}
 
-	// Determine next cursor
-	nextCursor := ""
-	if endIdx < len(filteredEntries) {
-		nextCursor = filteredEntries[endIdx-1].ID
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	result := []*model.Server{}
+	for _, entry := range db.entries {
+		if entry.FeaturedOrder != nil {
+			serverCopy := entry.Server
+			result = append(result, &serverCopy)
+		}
 	}
 
-	return result, nextCursor, nil
+	sort.Slice(result, func(i, j int) bool {
+		if *result[i].FeaturedOrder != *result[j].FeaturedOrder {
+			return *result[i].FeaturedOrder < *result[j].FeaturedOrder
+		}
+		return result[i].CreatedSeq < result[j].CreatedSeq
+	})
+
+	return result, nil
 }
 
 // GetByID retrieves a single ServerDetail by its ID
@@ -226,13 +585,62 @@ func (db *MemoryDB) GetByID(ctx context.Context, id string) (*model.ServerDetail
 
 	if entry, exists := db.entries[id]; exists {
 		// Return a copy of the ServerDetail
-		serverDetailCopy := *entry
-		return &serverDetailCopy, nil
+		return cloneServerDetail(entry), nil
 	}
 
 	return nil, ErrNotFound
 }
 
+// ListVersions retrieves the versions of the server with the given ID in publish order
+func (db *MemoryDB) ListVersions(
+	ctx context.Context, id string, includeYanked bool, cursor string, direction CursorDirection, limit int,
+) ([]*model.ServerDetail, PageCursors, error) {
+	if ctx.Err() != nil {
+		return nil, PageCursors{}, ctx.Err()
+	}
+
+	if limit <= 0 {
+		limit = 10 // Default limit
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	server, exists := db.entries[id]
+	if !exists {
+		return nil, PageCursors{}, ErrNotFound
+	}
+
+	var versions []*model.ServerDetail
+	for _, entry := range db.entries {
+		if entry.Name != server.Name || (entry.VersionDetail.Yanked && !includeYanked) {
+			continue
+		}
+		versions = append(versions, cloneServerDetail(entry))
+	}
+
+	return paginateBySeq(versions, func(entry *model.ServerDetail) int64 { return entry.CreatedSeq }, cursor, direction, limit)
+}
+
+// GetByIDs retrieves the ServerDetails with the given IDs, skipping unknown IDs
+func (db *MemoryDB) GetByIDs(ctx context.Context, ids []string) ([]*model.ServerDetail, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	result := make([]*model.ServerDetail, 0, len(ids))
+	for _, id := range ids {
+		if entry, exists := db.entries[id]; exists {
+			result = append(result, cloneServerDetail(entry))
+		}
+	}
+
+	return result, nil
+}
+
 // Publish adds a new ServerDetail to the database
 func (db *MemoryDB) Publish(ctx context.Context, serverDetail *model.ServerDetail) error {
 	if ctx.Err() != nil {
@@ -272,195 +680,1646 @@ func (db *MemoryDB) Publish(ctx context.Context, serverDetail *model.ServerDetai
 		return ErrInvalidInput
 	}
 
-	// Generate a new ID for the server detail
+	// The new version replaces any existing or pinned latest version and keeps a transferred owner, maintainers,
+	// the lifecycle status and the synced repository stats
+	updatedAt := updateTime()
+	serverDetail.PublisherUsername = ""
+	serverDetail.RepositoryStats = nil
+	for _, entry := range db.entries {
+		if entry.Name == serverDetail.Name {
+			entry.VersionDetail.IsLatest = false
+			entry.PinnedVersion = false
+			entry.UpdatedAt = updatedAt
+			serverDetail.PublisherUsername = entry.PublisherUsername
+			serverDetail.MaintainedBy = slices.Clone(entry.MaintainedBy)
+			if entry.Status != "" {
+				serverDetail.Status = entry.Status
+			}
+			if entry.RepositoryStats != nil {
+				stats := *entry.RepositoryStats
+				serverDetail.RepositoryStats = &stats
+			}
+		}
+	}
+	serverDetail.AddMaintainer(serverDetail.Publisher())
+
+	// Generate a new ID and creation sequence number for the server detail
+	db.nextSeq++
+	serverDetail.CreatedSeq = db.nextSeq
 	serverDetail.ID = uuid.New().String()
 	serverDetail.VersionDetail.IsLatest = true // Assume the new version is the latest
 	serverDetail.VersionDetail.ReleaseDate = time.Now().Format(time.RFC3339)
-	// Store a copy of the entire ServerDetail
-	serverDetailCopy := *serverDetail
-	db.entries[serverDetail.ID] = &serverDetailCopy
+	serverDetail.UpdatedAt = updatedAt
+	serverDetail.HasSchema = serverDetail.Schema != nil
+	// Store a copy of the entire ServerDetail; the schema is kept apart as in MongoDB
+	entry := cloneServerDetail(serverDetail)
+	if serverDetail.HasSchema {
+		db.schemas[serverDetail.ID] = slices.Clone(*serverDetail.Schema)
+		entry.Schema = nil
+	}
+	db.entries[serverDetail.ID] = entry
 
 	return nil
 }
 
-// ImportSeed imports initial data from a seed file into memory database
-func (db *MemoryDB) ImportSeed(ctx context.Context, seedFilePath string) error {
+// Update replaces the description, packages, version number and release date of an existing ServerDetail
+func (db *MemoryDB) Update(ctx context.Context, id string, detail *model.ServerDetail) error {
 	if ctx.Err() != nil {
 		return ctx.Err()
 	}
 
-	// Read the seed file
-	seedData, err := ReadSeedFile(seedFilePath)
-	if err != nil {
-		return fmt.Errorf("failed to read seed file: %w", err)
-	}
-
-	log.Printf("Importing %d servers into memory database", len(seedData))
-
 	db.mu.Lock()
 	defer db.mu.Unlock()
 
-	for i, server := range seedData {
-		if server.ID == "" || server.Name == "" {
-			log.Printf("Skipping server %d: ID or Name is empty", i+1)
-			continue
-		}
+	entry, exists := db.entries[id]
+	if !exists {
+		return ErrNotFound
+	}
 
-		// Set default version information if missing
-		if server.VersionDetail.Version == "" {
-			server.VersionDetail.Version = "0.0.1-seed"
-			server.VersionDetail.ReleaseDate = time.Now().Format(time.RFC3339)
-			server.VersionDetail.IsLatest = true
+	if detail.VersionDetail.Version != entry.VersionDetail.Version {
+		var versions []*model.ServerDetail
+		for _, candidate := range db.entries {
+			if candidate.Name == entry.Name {
+				versions = append(versions, candidate)
+			}
+		}
+		if err := checkVersionChange(versions, entry, detail.VersionDetail.Version); err != nil {
+			return err
 		}
-
-		// Store a copy of the server detail
-		serverDetailCopy := server
-		db.entries[server.ID] = &serverDetailCopy
-
-		log.Printf("[%d/%d] Imported server: %s", i+1, len(seedData), server.Name)
 	}
 
-	log.Println("Memory database import completed successfully")
-	return nil
-}
+	// Replace the entry so copies handed out by GetByID are not affected
+	serverDetailCopy := *entry
+	serverDetailCopy.Description = detail.Description
+	serverDetailCopy.Packages = slices.Clone(detail.Packages)
+	serverDetailCopy.VersionDetail.Version = detail.VersionDetail.Version
+	serverDetailCopy.VersionDetail.ReleaseDate = detail.VersionDetail.ReleaseDate
+	serverDetailCopy.UpdatedAt = updateTime()
+	db.entries[id] = &serverDetailCopy
 
-// Close closes the database connection
-// For an in-memory database, this is a no-op
-func (db *MemoryDB) Close() error {
 	return nil
 }
 
-// ListDetails retrieves all ServerDetail entries with optional filtering and pagination
-func (db *MemoryDB) ListDetails(
-	ctx context.Context,
-	filter map[string]interface{},
-	cursor string,
-	limit int,
-) ([]*model.ServerDetail, string, error) {
+// Delete removes a ServerDetail, making the highest remaining version the latest if it was the latest version.
+// Its schema is left for RemoveOrphanedSchemas.
+func (db *MemoryDB) Delete(ctx context.Context, id string) error {
 	if ctx.Err() != nil {
-		return nil, "", ctx.Err()
+		return ctx.Err()
 	}
 
-	if limit <= 0 {
-		limit = 10 // Default limit
-	}
+	db.mu.Lock()
+	defer db.mu.Unlock()
 
-	db.mu.RLock()
-	defer db.mu.RUnlock()
+	entry, exists := db.entries[id]
+	if !exists {
+		return ErrNotFound
+	}
+	delete(db.entries, id)
+	if !entry.VersionDetail.IsLatest {
+		return nil
+	}
 
-	// Convert all entries to a slice for pagination
-	var allEntries []*model.ServerDetail
-	for _, entry := range db.entries {
-		// Create a deep copy of the ServerDetail
-		entryCopy := *entry
-		allEntries = append(allEntries, &entryCopy)
+	var versions []*model.ServerDetail
+	for _, candidate := range db.entries {
+		if candidate.Name == entry.Name {
+			versions = append(versions, candidate)
+		}
+	}
+	if latest := latestUnyankedVersion(versions); latest != nil {
+		latest.VersionDetail.IsLatest = true
+		latest.UpdatedAt = updateTime()
 	}
 
-	// Simple filtering implementation
-	var filteredEntries []*model.ServerDetail
-	for _, entry := range allEntries {
-		include := true
+	return nil
+}
 
-		// Apply filters if any
-		for key, value := range filter {
-			switch key {
-			case "name":
-				// Handle regex filter for name
-				if valueMap, ok := value.(map[string]interface{}); ok {
-					if regexPattern, hasRegex := valueMap["$regex"].(string); hasRegex {
-						// Simple case-insensitive regex matching
-						options, _ := valueMap["$options"].(string)
-						if strings.Contains(options, "i") {
-							if !strings.Contains(strings.ToLower(entry.Name), strings.ToLower(regexPattern)) {
-								include = false
-							}
-						} else {
-							if !strings.Contains(entry.Name, regexPattern) {
-								include = false
-							}
-						}
-					}
-				} else if entry.Name != value.(string) {
-					include = false
+// AppendPackages adds packages to an existing ServerDetail, checking the package limit under the same lock
+func (db *MemoryDB) AppendPackages(ctx context.Context, id string, packages []model.Package, maxPackages int) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	entry, exists := db.entries[id]
+	if !exists {
+		return ErrNotFound
+	}
+
+	if maxPackages > 0 && len(entry.Packages)+len(packages) > maxPackages {
+		return ErrTooManyPackages
+	}
+
+	// Reject packages that are already registered for this server
+	for _, pkg := range packages {
+		for _, existing := range entry.Packages {
+			if existing.RegistryName == pkg.RegistryName && existing.Name == pkg.Name {
+				return ErrAlreadyExists
+			}
+		}
+	}
+
+	// Build a new slice so copies handed out by GetByID are not affected
+	updated := make([]model.Package, 0, len(entry.Packages)+len(packages))
+	updated = append(updated, entry.Packages...)
+	updated = append(updated, packages...)
+
+	serverDetailCopy := *entry
+	serverDetailCopy.Packages = updated
+	serverDetailCopy.UpdatedAt = updateTime()
+	db.entries[id] = &serverDetailCopy
+
+	return nil
+}
+
+// RemovePackage removes a package from an existing ServerDetail
+func (db *MemoryDB) RemovePackage(ctx context.Context, id, registryName, packageName string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	entry, exists := db.entries[id]
+	if !exists {
+		return ErrNotFound
+	}
+
+	updated := make([]model.Package, 0, len(entry.Packages))
+	for _, pkg := range entry.Packages {
+		if pkg.RegistryName == registryName && pkg.Name == packageName {
+			continue
+		}
+		updated = append(updated, pkg)
+	}
+
+	if len(updated) == len(entry.Packages) {
+		return ErrNotFound
+	}
+	if len(updated) == 0 {
+		return ErrLastPackage
+	}
+
+	serverDetailCopy := *entry
+	serverDetailCopy.Packages = updated
+	serverDetailCopy.UpdatedAt = updateTime()
+	db.entries[id] = &serverDetailCopy
+
+	return nil
+}
+
+// AddTags adds tags to an existing ServerDetail, ignoring tags it already has
+func (db *MemoryDB) AddTags(ctx context.Context, id string, tags []string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	entry, exists := db.entries[id]
+	if !exists {
+		return ErrNotFound
+	}
+
+	// Build a new slice so copies handed out by GetByID are not affected
+	updated := slices.Clone(entry.Tags)
+	for _, tag := range tags {
+		if !slices.Contains(updated, tag) {
+			updated = append(updated, tag)
+		}
+	}
+
+	serverDetailCopy := *entry
+	serverDetailCopy.Tags = updated
+	serverDetailCopy.UpdatedAt = updateTime()
+	db.entries[id] = &serverDetailCopy
+
+	return nil
+}
+
+// RemoveTag removes a tag from an existing ServerDetail
+func (db *MemoryDB) RemoveTag(ctx context.Context, id, tag string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	entry, exists := db.entries[id]
+	if !exists || !slices.Contains(entry.Tags, tag) {
+		return ErrNotFound
+	}
+
+	serverDetailCopy := *entry
+	serverDetailCopy.Tags = slices.DeleteFunc(slices.Clone(entry.Tags), func(existing string) bool { return existing == tag })
+	serverDetailCopy.UpdatedAt = updateTime()
+	db.entries[id] = &serverDetailCopy
+
+	return nil
+}
+
+// SetPackageChecksum records the verified checksum of a package of an existing ServerDetail
+func (db *MemoryDB) SetPackageChecksum(ctx context.Context, id, registryName, packageName, checksum string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	entry, exists := db.entries[id]
+	if !exists {
+		return ErrNotFound
+	}
+
+	// Build a new slice so copies handed out by GetByID are not affected
+	updated := slices.Clone(entry.Packages)
+	index := slices.IndexFunc(updated, func(pkg model.Package) bool {
+		return pkg.RegistryName == registryName && pkg.Name == packageName
+	})
+	if index < 0 {
+		return ErrNotFound
+	}
+	updated[index].VerifiedChecksum = checksum
+
+	serverDetailCopy := *entry
+	serverDetailCopy.Packages = updated
+	serverDetailCopy.UpdatedAt = updateTime()
+	db.entries[id] = &serverDetailCopy
+
+	return nil
+}
+
+// YankPackage marks the packages of an existing ServerDetail with the given registry name and version as yanked
+func (db *MemoryDB) YankPackage(ctx context.Context, id, registryName, version, reason string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	entry, exists := db.entries[id]
+	if !exists {
+		return ErrNotFound
+	}
+
+	// Build a new slice so copies handed out by GetByID are not affected
+	updated := slices.Clone(entry.Packages)
+	matched := false
+	for i, pkg := range updated {
+		if pkg.RegistryName == registryName && pkg.Version == version {
+			updated[i].Yanked = true
+			updated[i].YankReason = reason
+			matched = true
+		}
+	}
+	if !matched {
+		return ErrNotFound
+	}
+
+	serverDetailCopy := *entry
+	serverDetailCopy.Packages = updated
+	serverDetailCopy.UpdatedAt = updateTime()
+	db.entries[id] = &serverDetailCopy
+
+	return nil
+}
+
+// PinVersion marks the given version of a server as the latest version
+func (db *MemoryDB) PinVersion(ctx context.Context, id, version string) error {
+	return db.setLatestVersion(ctx, id, version, true)
+}
+
+// UpgradeVersion marks the given version of a server as the latest version without pinning it
+func (db *MemoryDB) UpgradeVersion(ctx context.Context, id, version string) error {
+	return db.setLatestVersion(ctx, id, version, false)
+}
+
+// setLatestVersion marks the given version of a server as the latest version, optionally pinning it,
+// and releases any existing pin
+func (db *MemoryDB) setLatestVersion(ctx context.Context, id, version string, pin bool) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	entry, exists := db.entries[id]
+	if !exists {
+		return ErrNotFound
+	}
+
+	var latest *model.ServerDetail
+	for _, candidate := range db.entries {
+		if candidate.Name == entry.Name && candidate.VersionDetail.Version == version {
+			latest = candidate
+			break
+		}
+	}
+	if latest == nil {
+		return ErrNotFound
+	}
+	if latest.VersionDetail.Yanked {
+		return ErrInvalidInput
+	}
+
+	updatedAt := updateTime()
+	for _, candidate := range db.entries {
+		if candidate.Name == entry.Name {
+			candidate.VersionDetail.IsLatest = false
+			candidate.PinnedVersion = false
+			candidate.UpdatedAt = updatedAt
+		}
+	}
+	latest.VersionDetail.IsLatest = true
+	latest.PinnedVersion = pin
+
+	return nil
+}
+
+// YankVersion marks the given version of a server as yanked and moves the latest flag off it
+func (db *MemoryDB) YankVersion(ctx context.Context, id, version string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	entry, exists := db.entries[id]
+	if !exists {
+		return ErrNotFound
+	}
+
+	var yanked *model.ServerDetail
+	var versions []*model.ServerDetail
+	for _, candidate := range db.entries {
+		if candidate.Name != entry.Name {
+			continue
+		}
+		versions = append(versions, candidate)
+		if candidate.VersionDetail.Version == version {
+			yanked = candidate
+		}
+	}
+	if yanked == nil {
+		return ErrNotFound
+	}
+	if yanked.VersionDetail.Yanked {
+		return nil
+	}
+
+	updatedAt := updateTime()
+	yanked.VersionDetail.Yanked = true
+	yanked.UpdatedAt = updatedAt
+	if !yanked.VersionDetail.IsLatest {
+		return nil
+	}
+
+	yanked.VersionDetail.IsLatest = false
+	yanked.PinnedVersion = false
+	if latest := latestUnyankedVersion(versions); latest != nil {
+		latest.VersionDetail.IsLatest = true
+		latest.UpdatedAt = updatedAt
+	}
+
+	return nil
+}
+
+// TransferOwnership sets the publisher of all versions of a server to the given GitHub user
+func (db *MemoryDB) TransferOwnership(ctx context.Context, id, newOwner string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	entry, exists := db.entries[id]
+	if !exists {
+		return ErrNotFound
+	}
+
+	// The new owner takes the place of the previous owner among the maintainers
+	name := entry.Name
+	previousOwner := entry.Publisher()
+	updatedAt := updateTime()
+	for _, candidate := range db.entries {
+		if candidate.Name == name {
+			candidate.RemoveMaintainer(previousOwner)
+			candidate.AddMaintainer(newOwner)
+			candidate.PublisherUsername = newOwner
+			candidate.UpdatedAt = updatedAt
+		}
+	}
+
+	return nil
+}
+
+// SetMaintainers replaces the maintainers of all versions of a server
+func (db *MemoryDB) SetMaintainers(ctx context.Context, id string, maintainers []string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	entry, exists := db.entries[id]
+	if !exists {
+		return ErrNotFound
+	}
+
+	name := entry.Name
+	updatedAt := updateTime()
+	for _, candidate := range db.entries {
+		if candidate.Name == name {
+			candidate.MaintainedBy = slices.Clone(maintainers)
+			candidate.UpdatedAt = updatedAt
+		}
+	}
+
+	return nil
+}
+
+// SetStatus sets the lifecycle status of all versions of a server
+func (db *MemoryDB) SetStatus(ctx context.Context, id, status string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	entry, exists := db.entries[id]
+	if !exists {
+		return ErrNotFound
+	}
+
+	name := entry.Name
+	updatedAt := updateTime()
+	for _, candidate := range db.entries {
+		if candidate.Name == name {
+			candidate.Status = status
+			candidate.UpdatedAt = updatedAt
+		}
+	}
+
+	return nil
+}
+
+// SetRepositoryStats sets the repository stats of all versions of a server
+func (db *MemoryDB) SetRepositoryStats(ctx context.Context, id string, stats model.RepositoryStats) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	entry, exists := db.entries[id]
+	if !exists {
+		return ErrNotFound
+	}
+
+	name := entry.Name
+	for _, candidate := range db.entries {
+		if candidate.Name == name {
+			candidateStats := stats
+			candidate.RepositoryStats = &candidateStats
+		}
+	}
+
+	return nil
+}
+
+// ReorderFeatured sets the display order of the featured servers to the order of the given IDs
+func (db *MemoryDB) ReorderFeatured(ctx context.Context, ids []string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	featured := make(map[string]bool)
+	for id, entry := range db.entries {
+		if entry.FeaturedOrder != nil {
+			featured[id] = true
+		}
+	}
+	if err := checkFeaturedOrder(ids, featured); err != nil {
+		return err
+	}
+
+	updatedAt := updateTime()
+	for position, id := range ids {
+		entry := db.entries[id]
+		entry.FeaturedOrder = &position
+		entry.UpdatedAt = updatedAt
+	}
+
+	return nil
+}
+
+// provenanceKey identifies the provenance attestation of a server version
+type provenanceKey struct {
+	serverID string
+	version  string
+}
+
+// SaveProvenance creates or replaces the provenance attestation of a server version
+func (db *MemoryDB) SaveProvenance(ctx context.Context, provenance *model.Provenance) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	provenanceCopy := *provenance
+	provenanceCopy.Attestation = slices.Clone(provenance.Attestation)
+	db.provenances[provenanceKey{serverID: provenance.ServerID, version: provenance.Version}] = &provenanceCopy
+	return nil
+}
+
+// GetProvenance retrieves the provenance attestation of a version of the named server,
+// or the most recently attested version if version is empty
+func (db *MemoryDB) GetProvenance(ctx context.Context, serverName, version string) (*model.Provenance, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	var found *model.Provenance
+	for _, provenance := range db.provenances {
+		if provenance.ServerName != serverName || (version != "" && provenance.Version != version) {
+			continue
+		}
+		// Attestations submitted at the same time are ordered by version
+		if found == nil || provenance.CreatedAt.After(found.CreatedAt) ||
+			(provenance.CreatedAt.Equal(found.CreatedAt) && compareSemanticVersions(provenance.Version, found.Version) > 0) {
+			found = provenance
+		}
+	}
+	if found == nil {
+		return nil, ErrNotFound
+	}
+
+	provenanceCopy := *found
+	provenanceCopy.Attestation = slices.Clone(found.Attestation)
+	return &provenanceCopy, nil
+}
+
+// GetSchema retrieves the JSON schema published with a server version
+func (db *MemoryDB) GetSchema(ctx context.Context, id string) (json.RawMessage, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	schema, ok := db.schemas[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return slices.Clone(schema), nil
+}
+
+// GetGitHubMetadata retrieves the GitHub repository information stored when a server version was published
+func (db *MemoryDB) GetGitHubMetadata(ctx context.Context, id string) (json.RawMessage, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	entry, ok := db.entries[id]
+	if !ok || len(entry.GitHubMetadata) == 0 {
+		return nil, ErrNotFound
+	}
+	return slices.Clone(entry.GitHubMetadata), nil
+}
+
+// SaveReview adds a review of a server and updates the rating summary of the server
+func (db *MemoryDB) SaveReview(ctx context.Context, review *model.Review) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	entry, exists := db.entries[review.ServerID]
+	if !exists {
+		return ErrNotFound
+	}
+
+	reviews := db.reviews[review.ServerID]
+	for _, existing := range reviews {
+		if existing.GitHubUserID == review.GitHubUserID {
+			return ErrAlreadyExists
+		}
+	}
+
+	reviewCopy := *review
+	reviews = append(reviews, &reviewCopy)
+	db.reviews[review.ServerID] = reviews
+
+	total := 0
+	for _, existing := range reviews {
+		total += existing.Rating
+	}
+	entry.ReviewCount = len(reviews)
+	entry.AverageRating = float64(total) / float64(len(reviews))
+
+	return nil
+}
+
+// ListReviews retrieves the reviews of a server, newest first
+func (db *MemoryDB) ListReviews(
+	ctx context.Context, serverID string, cursor string, direction CursorDirection, limit int,
+) ([]*model.Review, PageCursors, error) {
+	if ctx.Err() != nil {
+		return nil, PageCursors{}, ctx.Err()
+	}
+
+	if limit <= 0 {
+		limit = 10 // Default limit
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	// Reviews are stored in submission order, so walking them backwards yields the newest first
+	stored := db.reviews[serverID]
+	reviews := make([]*model.Review, 0, len(stored))
+	for i := len(stored) - 1; i >= 0; i-- {
+		reviewCopy := *stored[i]
+		reviews = append(reviews, &reviewCopy)
+	}
+
+	return paginateByOffset(reviews, cursor, direction, limit)
+}
+
+// cloneSubscription copies a subscription so callers cannot modify the stored events
+func cloneSubscription(subscription *model.Subscription) *model.Subscription {
+	subscriptionCopy := *subscription
+	subscriptionCopy.Events = slices.Clone(subscription.Events)
+	return &subscriptionCopy
+}
+
+// SaveSubscription creates or updates a webhook subscription
+func (db *MemoryDB) SaveSubscription(ctx context.Context, subscription *model.Subscription) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for id, existing := range db.subscriptions {
+		if id != subscription.ID && existing.ServerName == subscription.ServerName &&
+			existing.WebhookURL == subscription.WebhookURL {
+			return ErrAlreadyExists
+		}
+	}
+
+	db.subscriptions[subscription.ID] = cloneSubscription(subscription)
+	return nil
+}
+
+// GetSubscription retrieves a webhook subscription by its ID
+func (db *MemoryDB) GetSubscription(ctx context.Context, id string) (*model.Subscription, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	subscription, exists := db.subscriptions[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return cloneSubscription(subscription), nil
+}
+
+// FindSubscription retrieves the subscription of the named server with the given webhook URL
+func (db *MemoryDB) FindSubscription(ctx context.Context, serverName, webhookURL string) (*model.Subscription, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	for _, subscription := range db.subscriptions {
+		if subscription.ServerName == serverName && subscription.WebhookURL == webhookURL {
+			return cloneSubscription(subscription), nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// ListSubscriptions retrieves all subscriptions of the named server, oldest first
+func (db *MemoryDB) ListSubscriptions(ctx context.Context, serverName string) ([]*model.Subscription, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	subscriptions := []*model.Subscription{}
+	for _, subscription := range db.subscriptions {
+		if subscription.ServerName == serverName {
+			subscriptions = append(subscriptions, cloneSubscription(subscription))
+		}
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].CreatedAt.Before(subscriptions[j].CreatedAt)
+	})
+	return subscriptions, nil
+}
+
+// CountSubscriptions returns the number of subscriptions held by a GitHub user
+func (db *MemoryDB) CountSubscriptions(ctx context.Context, githubUserID string) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	var count int64
+	for _, subscription := range db.subscriptions {
+		if subscription.GitHubUserID == githubUserID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// DeleteSubscription removes a webhook subscription
+func (db *MemoryDB) DeleteSubscription(ctx context.Context, id string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if _, exists := db.subscriptions[id]; !exists {
+		return ErrNotFound
+	}
+	delete(db.subscriptions, id)
+	return nil
+}
+
+// cloneAPIKey copies an API key so callers cannot modify the stored hash
+func cloneAPIKey(key *model.APIKey) *model.APIKey {
+	keyCopy := *key
+	keyCopy.HashedSecret = slices.Clone(key.HashedSecret)
+	return &keyCopy
+}
+
+// SaveAPIKey stores a new API key
+func (db *MemoryDB) SaveAPIKey(ctx context.Context, key *model.APIKey) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if _, exists := db.apiKeys[key.ID]; exists {
+		return ErrAlreadyExists
+	}
+	db.apiKeys[key.ID] = cloneAPIKey(key)
+	return nil
+}
+
+// GetAPIKey retrieves an API key by its ID
+func (db *MemoryDB) GetAPIKey(ctx context.Context, id string) (*model.APIKey, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	key, exists := db.apiKeys[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return cloneAPIKey(key), nil
+}
+
+// ListAPIKeys retrieves all API keys, oldest first
+func (db *MemoryDB) ListAPIKeys(ctx context.Context) ([]*model.APIKey, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	keys := make([]*model.APIKey, 0, len(db.apiKeys))
+	for _, key := range db.apiKeys {
+		keys = append(keys, cloneAPIKey(key))
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+	})
+	return keys, nil
+}
+
+// DeleteAPIKey removes an API key
+func (db *MemoryDB) DeleteAPIKey(ctx context.Context, id string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if _, exists := db.apiKeys[id]; !exists {
+		return ErrNotFound
+	}
+	delete(db.apiKeys, id)
+	return nil
+}
+
+// ImportSeed imports initial data from a seed file into memory database
+func (db *MemoryDB) ImportSeed(ctx context.Context, seedFilePath string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	// Read the seed file
+	seedData, err := ReadSeedFile(seedFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read seed file: %w", err)
+	}
+
+	log.Printf("Importing %d servers into memory database", len(seedData))
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for i, server := range seedData {
+		if server.ID == "" || server.Name == "" {
+			log.Printf("Skipping server %d: ID or Name is empty", i+1)
+			continue
+		}
+
+		// Set default version information if missing
+		if server.VersionDetail.Version == "" {
+			server.VersionDetail.Version = "0.0.1-seed"
+			server.VersionDetail.ReleaseDate = time.Now().Format(time.RFC3339)
+			server.VersionDetail.IsLatest = true
+		}
+
+		if server.CreatedSeq == 0 {
+			db.nextSeq++
+			server.CreatedSeq = db.nextSeq
+		}
+
+		// Store a copy of the server detail
+		serverDetailCopy := server
+		db.entries[server.ID] = &serverDetailCopy
+
+		log.Printf("[%d/%d] Imported server: %s", i+1, len(seedData), server.Name)
+	}
+
+	log.Println("Memory database import completed successfully")
+	return nil
+}
+
+// Close closes the database connection
+// For an in-memory database, this is a no-op
+func (db *MemoryDB) Close() error {
+	return nil
+}
+
+// ListDetails retrieves all ServerDetail entries with optional filtering and pagination
+func (db *MemoryDB) ListDetails(
+	ctx context.Context,
+	filter bson.D,
+	sortBy SortOrder,
+	cursor string,
+	direction CursorDirection,
+	limit int,
+) ([]*model.ServerDetail, PageCursors, error) {
+	if ctx.Err() != nil {
+		return nil, PageCursors{}, ctx.Err()
+	}
+
+	if limit <= 0 {
+		limit = 10 // Default limit
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	// Convert all entries to a slice for pagination
+	var allEntries []*model.ServerDetail
+	for _, entry := range db.entries {
+		// Create a deep copy of the ServerDetail
+		allEntries = append(allEntries, cloneServerDetail(entry))
+	}
+
+	var filteredEntries []*model.ServerDetail
+	for _, entry := range allEntries {
+		if matchesDetailFilter(entry, filter) {
+			filteredEntries = append(filteredEntries, entry)
+		}
+	}
+
+	switch sortBy {
+	case SortByStars:
+		return paginateByStars(filteredEntries, cursor, direction, limit)
+	case SortByReleaseDate:
+		return paginateByPublishedAt(filteredEntries, cursor, direction, limit)
+	}
+	if IsFieldSort(sortBy) {
+		return paginateByField(
+			filteredEntries, func(entry *model.ServerDetail) *model.Server { return &entry.Server }, sortBy, cursor, direction, limit,
+		)
+	}
+	if query, ok := textSearchQuery(filter); ok && (sortBy == SortByCreation || sortBy == SortByRelevance) {
+		return paginateByRelevance(filteredEntries, query, cursor, direction, limit)
+	}
+
+	return paginateBySeq(
+		filteredEntries, func(entry *model.ServerDetail) int64 { return entry.CreatedSeq }, cursor, direction, limit,
+	)
+}
+
+// Count returns the number of servers whose latest version matches the filter
+func (db *MemoryDB) Count(ctx context.Context, filter bson.D) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	var count int64
+	for _, entry := range db.entries {
+		if entry.VersionDetail.IsLatest && matchesDetailFilter(entry, filter) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// CountFacets returns the number of servers whose latest version matches the filter with each package registry,
+// tag, license and status
+func (db *MemoryDB) CountFacets(ctx context.Context, filter bson.D) (model.Facets, error) {
+	if ctx.Err() != nil {
+		return model.Facets{}, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	facets := model.Facets{
+		RegistryNames: make(map[string]int),
+		Tags:          make(map[string]int),
+		Licenses:      make(map[string]int),
+		Statuses:      make(map[string]int),
+	}
+	for _, entry := range db.entries {
+		if !entry.VersionDetail.IsLatest || !matchesDetailFilter(entry, filter) {
+			continue
+		}
+
+		registryNames := make(map[string]bool)
+		for _, pkg := range entry.Packages {
+			registryNames[pkg.RegistryName] = true
+		}
+		for registryName := range registryNames {
+			facets.RegistryNames[registryName]++
+		}
+		for _, tag := range entry.Tags {
+			facets.Tags[tag]++
+		}
+		if entry.License != "" {
+			facets.Licenses[entry.License]++
+		}
+		status := entry.Status
+		if status == "" {
+			status = model.StatusActive
+		}
+		facets.Statuses[status]++
+	}
+
+	return facets, nil
+}
+
+// matchesDetailFilter reports whether a server version meets all conditions of a ListDetails filter
+func matchesDetailFilter(entry *model.ServerDetail, filter bson.D) bool {
+	for _, elem := range filter {
+		key, value := elem.Key, elem.Value
+		switch key {
+		case "name":
+			// Handle regex filter for name
+			if valueMap, ok := value.(bson.M); ok {
+				if !matchesRegexFilter(entry.Name, valueMap) {
+					return false
 				}
-			case "packages.registry_name":
-				// Check if any package has the specified registry_name
-				hasRegistry := false
-				if registryName, ok := value.(string); ok {
-					for _, pkg := range entry.Packages {
-						if pkg.RegistryName == registryName {
-							hasRegistry = true
-							break
-						}
+			} else if entry.Name != value.(string) {
+				return false
+			}
+		case "packages.registry_name":
+			// Check if any package has the specified registry_name
+			hasRegistry := false
+			if registryName, ok := value.(string); ok {
+				for _, pkg := range entry.Packages {
+					if pkg.RegistryName == registryName {
+						hasRegistry = true
+						break
 					}
 				}
-				if !hasRegistry {
-					include = false
-				}
-			case "repository.url":
-				if entry.Repository.URL != value.(string) {
-					include = false
-				}
-			case "serverDetail.id":
-				if entry.ID != value.(string) {
-					include = false
-				}
-			case "version":
-				if entry.VersionDetail.Version != value.(string) {
-					include = false
-				}
-				// Add more filter options as needed
 			}
+			if !hasRegistry {
+				return false
+			}
+		case "repository.url":
+			if entry.Repository.URL != value.(string) {
+				return false
+			}
+		case "category":
+			if string(entry.Category) != value.(string) {
+				return false
+			}
+		case "language":
+			if entry.Language != value.(string) {
+				return false
+			}
+		case "license":
+			if entry.License != value.(string) {
+				return false
+			}
+		case "status":
+			if !matchesStatusFilter(entry.Status, value) {
+				return false
+			}
+		case "maintainers.github_username":
+			if !slices.ContainsFunc(entry.Maintainers, func(maintainer model.Maintainer) bool {
+				return maintainer.GitHubUsername == value.(string)
+			}) {
+				return false
+			}
+		case "topics":
+			if !slices.Contains(entry.Topics, value.(string)) {
+				return false
+			}
+		case "tags":
+			if condition, ok := value.(bson.M); !ok || !matchesAllFilter(entry.Tags, condition) {
+				return false
+			}
+		case "$text":
+			if condition, ok := value.(bson.M); !ok || !matchesTextFilter(textIndexed(&entry.Server), condition) {
+				return false
+			}
+		case "$or":
+			if conditions, ok := value.(bson.A); !ok || !matchesAnyPattern(entry, conditions) {
+				return false
+			}
+		case "serverDetail.id":
+			if entry.ID != value.(string) {
+				return false
+			}
+		case "version":
+			if entry.VersionDetail.Version != value.(string) {
+				return false
+			}
+		case "tools.name":
+			if !slices.ContainsFunc(entry.Tools, func(tool model.MCPTool) bool { return tool.Name == value }) {
+				return false
+			}
+		case "has_schema":
+			if hasSchema, ok := value.(bool); !ok || entry.HasSchema != hasSchema {
+				return false
+			}
+		case "packages":
+			// The only package condition used is the verified checksum filter
+			if !slices.ContainsFunc(entry.Packages, hasVerifiedChecksum) {
+				return false
+			}
+		case "average_rating":
+			if condition, ok := value.(bson.M); !ok || !matchesRangeFilter(entry.AverageRating, condition) {
+				return false
+			}
+		case "published_at":
+			if condition, ok := value.(bson.M); !ok || !matchesTimeRangeFilter(entry.PublishedAt, condition) {
+				return false
+			}
+			// Add more filter options as needed
 		}
+	}
+	return true
+}
 
-		if include {
-			filteredEntries = append(filteredEntries, entry)
+// Connection returns information about the database connection
+func (db *MemoryDB) Connection() *ConnectionInfo {
+	return &ConnectionInfo{
+		Type:        ConnectionTypeMemory,
+		IsConnected: true, // Memory DB is always connected
+		Raw:         db.entries,
+	}
+}
+
+// paginateByStars orders entries by star count (highest first, missing stats counted as zero) and
+// returns the page next to the offset encoded in the cursor
+func paginateByStars(
+	entries []*model.ServerDetail, cursor string, direction CursorDirection, limit int,
+) ([]*model.ServerDetail, PageCursors, error) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		starsI, starsJ := starCount(entries[i]), starCount(entries[j])
+		if starsI != starsJ {
+			return starsI > starsJ
+		}
+		return entries[i].CreatedSeq < entries[j].CreatedSeq
+	})
+
+	return paginateByOffset(entries, cursor, direction, limit)
+}
+
+// paginateByPublishedAt orders entries by publication time (most recent first, ties in creation order) and
+// returns the page next to the offset encoded in the cursor
+func paginateByPublishedAt(
+	entries []*model.ServerDetail, cursor string, direction CursorDirection, limit int,
+) ([]*model.ServerDetail, PageCursors, error) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].PublishedAt.Equal(entries[j].PublishedAt) {
+			return entries[i].PublishedAt.After(entries[j].PublishedAt)
+		}
+		return entries[i].CreatedSeq < entries[j].CreatedSeq
+	})
+
+	return paginateByOffset(entries, cursor, direction, limit)
+}
+
+// paginateByField orders entries by the field of a field sort order, ties in creation order in the same direction,
+// and returns the page next to the offset encoded in the cursor
+func paginateByField[T any](
+	entries []T, server func(T) *model.Server, sortBy SortOrder, cursor string, direction CursorDirection, limit int,
+) ([]T, PageCursors, error) {
+	key, order, _ := fieldSort(sortBy)
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := server(entries[i]), server(entries[j])
+		if c := compareField(key, a, b); c != 0 {
+			return c*order < 0
+		}
+		return a.CreatedSeq*int64(order) < b.CreatedSeq*int64(order)
+	})
+
+	return paginateByOffset(entries, cursor, direction, limit)
+}
+
+// textSearchQuery returns the search string of the $text condition of a filter, if there is one
+func textSearchQuery(filter bson.D) (string, bool) {
+	for _, elem := range filter {
+		if elem.Key != "$text" {
+			continue
+		}
+		if condition, ok := elem.Value.(bson.M); ok {
+			query, ok := condition["$search"].(string)
+			return query, ok
 		}
 	}
+	return "", false
+}
 
-	// Find starting point for cursor-based pagination
-	startIdx := 0
-	if cursor != "" {
-		for i, entry := range filteredEntries {
-			if entry.ID == cursor {
-				startIdx = i + 1 // Start after the cursor
-				break
-			}
+// paginateByRelevance orders text search results by their relevance score (highest first, ties in
+// creation order) and returns the page next to the offset encoded in the cursor
+func paginateByRelevance(
+	entries []*model.ServerDetail, query, cursor string, direction CursorDirection, limit int,
+) ([]*model.ServerDetail, PageCursors, error) {
+	scores := make(map[string]int, len(entries))
+	for _, entry := range entries {
+		scores[entry.ID] = memory.ScoreServer(entry, query)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		scoreI, scoreJ := scores[entries[i].ID], scores[entries[j].ID]
+		if scoreI != scoreJ {
+			return scoreI > scoreJ
 		}
+		return entries[i].CreatedSeq < entries[j].CreatedSeq
+	})
+
+	return paginateByOffset(entries, cursor, direction, limit)
+}
+
+// paginateByOffset returns the page of the sorted entries starting at the offset encoded in the cursor,
+// or ending before it with CursorPrev
+func paginateByOffset[T any](entries []T, cursor string, direction CursorDirection, limit int) ([]T, PageCursors, error) {
+	offset, count, err := offsetWindow(cursor, direction, limit)
+	if err != nil {
+		return nil, PageCursors{}, err
 	}
 
-	// Sort filteredEntries by ID for consistent pagination
-	sort.Slice(filteredEntries, func(i, j int) bool {
-		return filteredEntries[i].ID < filteredEntries[j].ID
+	startIdx := int(min(offset, int64(len(entries))))
+	endIdx := min(startIdx+count, len(entries))
+
+	return entries[startIdx:endIdx], offsetCursors(int64(startIdx), endIdx-startIdx, endIdx < len(entries)), nil
+}
+
+// paginateBySeq sorts entries by creation sequence, so records published mid-traversal are appended at the end,
+// and returns the page after the creation sequence encoded in the cursor, or before it with CursorPrev
+func paginateBySeq[T any](
+	entries []T, seq func(T) int64, cursor string, direction CursorDirection, limit int,
+) ([]T, PageCursors, error) {
+	sort.Slice(entries, func(i, j int) bool {
+		return seq(entries[i]) < seq(entries[j])
 	})
 
-	// Apply pagination
-	endIdx := startIdx + limit
-	if endIdx > len(filteredEntries) {
-		endIdx = len(filteredEntries)
+	startIdx, endIdx := 0, min(limit, len(entries))
+	if cursor != "" {
+		cursorSeq, err := DecodeCursor(cursor)
+		if err != nil {
+			return nil, PageCursors{}, err
+		}
+		if direction == CursorPrev {
+			endIdx = sort.Search(len(entries), func(i int) bool { return seq(entries[i]) >= cursorSeq })
+			startIdx = max(0, endIdx-limit)
+		} else {
+			startIdx = sort.Search(len(entries), func(i int) bool { return seq(entries[i]) > cursorSeq })
+			endIdx = min(startIdx+limit, len(entries))
+		}
+	}
+
+	page := entries[startIdx:endIdx]
+	if len(page) == 0 {
+		return page, PageCursors{}, nil
+	}
+	return page, seqCursors(seq(page[0]), seq(page[len(page)-1]), startIdx > 0, endIdx < len(entries)), nil
+}
+
+// starCount returns the repository star count of an entry, treating missing stats as zero
+func starCount(entry *model.ServerDetail) int {
+	if entry.RepositoryStats == nil {
+		return 0
+	}
+	return entry.RepositoryStats.Stars
+}
+
+// CountDependents returns the number of server versions that list the server with the given ID as a dependency
+func (db *MemoryDB) CountDependents(ctx context.Context, id string) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	var count int64
+	for _, entry := range db.entries {
+		if slices.Contains(entry.Dependencies, id) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// ListRelated returns up to limit servers directly connected to the given server, sorted by name: the latest
+// versions of other servers with the same publisher, sharing a tag or depending on it, and its dependencies
+func (db *MemoryDB) ListRelated(ctx context.Context, serverDetail *model.ServerDetail, limit int) ([]*model.ServerDetail, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
 	}
 
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	publisher := serverDetail.Publisher()
 	var result []*model.ServerDetail
-	if startIdx < len(filteredEntries) {
-		result = filteredEntries[startIdx:endIdx]
-	} else {
-		result = []*model.ServerDetail{}
+	for _, entry := range db.entries {
+		if entry.Name == serverDetail.Name {
+			continue
+		}
+		related := slices.Contains(serverDetail.Dependencies, entry.ID)
+		if entry.VersionDetail.IsLatest {
+			related = related ||
+				(publisher != "" && strings.EqualFold(entry.Publisher(), publisher)) ||
+				slices.ContainsFunc(entry.Tags, func(tag string) bool { return slices.Contains(serverDetail.Tags, tag) }) ||
+				slices.Contains(entry.Dependencies, serverDetail.ID)
+		}
+		if related {
+			result = append(result, cloneServerDetail(entry))
+		}
 	}
 
-	// Determine next cursor
-	nextCursor := ""
-	if endIdx < len(filteredEntries) {
-		nextCursor = filteredEntries[endIdx-1].ID
+	slices.SortFunc(result, func(a, b *model.ServerDetail) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
 	}
 
-	return result, nextCursor, nil
+	return result, nil
 }
 
-// Connection returns information about the database connection
-func (db *MemoryDB) Connection() *ConnectionInfo {
-	return &ConnectionInfo{
-		Type:        ConnectionTypeMemory,
-		IsConnected: true, // Memory DB is always connected
-		Raw:         db.entries,
+// CountByCategory returns the number of servers in each category
+func (db *MemoryDB) CountByCategory(ctx context.Context) (map[model.Category]int, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	counts := make(map[model.Category]int)
+	for _, entry := range db.entries {
+		if entry.Category != "" {
+			counts[entry.Category]++
+		}
+	}
+
+	return counts, nil
+}
+
+// CountByLanguage returns the number of servers written in each language
+func (db *MemoryDB) CountByLanguage(ctx context.Context) (map[string]int, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for _, entry := range db.entries {
+		if entry.Language != "" {
+			counts[entry.Language]++
+		}
+	}
+
+	return counts, nil
+}
+
+// AggregateStats returns the server, package registry, recently published and publisher counts of the registry
+func (db *MemoryDB) AggregateStats(ctx context.Context, now time.Time) (model.RegistryStats, error) {
+	if ctx.Err() != nil {
+		return model.RegistryStats{}, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	stats := model.RegistryStats{ServersByRegistry: make(map[string]int)}
+	publishers := make(map[string]bool)
+	for _, entry := range db.entries {
+		if entry.VersionDetail.IsLatest {
+			stats.ServerCount++
+			registries := make(map[string]bool)
+			for _, pkg := range entry.Packages {
+				registries[pkg.RegistryName] = true
+			}
+			for registryName := range registries {
+				stats.ServersByRegistry[registryName]++
+			}
+			if publisher := entry.Publisher(); publisher != "" {
+				publishers[strings.ToLower(publisher)] = true
+			}
+		}
+
+		if entry.PublishedAt.IsZero() {
+			continue
+		}
+		age := now.Sub(entry.PublishedAt)
+		if age <= 30*24*time.Hour {
+			stats.Published.Last30Days++
+		}
+		if age <= 7*24*time.Hour {
+			stats.Published.Last7Days++
+		}
+		if age <= 24*time.Hour {
+			stats.Published.Last24Hours++
+		}
+	}
+	stats.PublisherCount = len(publishers)
+
+	return stats, nil
+}
+
+// CountVerifiedChecksums returns the number of servers whose latest version has at least one package
+// with a verified checksum
+func (db *MemoryDB) CountVerifiedChecksums(ctx context.Context) (int, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	count := 0
+	for _, entry := range db.entries {
+		if entry.VersionDetail.IsLatest && slices.ContainsFunc(entry.Packages, hasVerifiedChecksum) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// CountByTopic returns the number of servers tagged with each topic
+func (db *MemoryDB) CountByTopic(ctx context.Context) (map[string]int, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for _, entry := range db.entries {
+		for _, topic := range entry.Topics {
+			counts[topic]++
+		}
+	}
+
+	return counts, nil
+}
+
+// CountByTag returns the number of servers whose latest version carries each tag
+func (db *MemoryDB) CountByTag(ctx context.Context) (map[string]int, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for _, entry := range db.entries {
+		if !entry.VersionDetail.IsLatest {
+			continue
+		}
+		for _, tag := range entry.Tags {
+			counts[tag]++
+		}
+	}
+
+	return counts, nil
+}
+
+// RebuildTextIndex returns the number of stored entries; the in-memory database has no text index to rebuild
+func (db *MemoryDB) RebuildTextIndex(ctx context.Context) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return int64(len(db.entries)), nil
+}
+
+// SaveReindexJob creates or updates a text index rebuild job
+func (db *MemoryDB) SaveReindexJob(ctx context.Context, job *model.ReindexJob) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	jobCopy := *job
+	db.reindexJobs[job.ID] = &jobCopy
+	return nil
+}
+
+// GetReindexJob retrieves a text index rebuild job by its ID
+func (db *MemoryDB) GetReindexJob(ctx context.Context, id string) (*model.ReindexJob, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	job, exists := db.reindexJobs[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	jobCopy := *job
+	return &jobCopy, nil
+}
+
+// RemoveOrphanedSchemas deletes stored schemas whose server version no longer exists
+func (db *MemoryDB) RemoveOrphanedSchemas(ctx context.Context) (int64, int64, error) {
+	if ctx.Err() != nil {
+		return 0, 0, ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var removed, bytesReclaimed int64
+	for id, schema := range db.schemas {
+		if _, exists := db.entries[id]; exists {
+			continue
+		}
+		delete(db.schemas, id)
+		removed++
+		bytesReclaimed += int64(len(schema))
+	}
+
+	return removed, bytesReclaimed, nil
+}
+
+// RemoveReindexJobs deletes the reindex jobs that completed before the given time.
+// The storage used by a job is measured as the size of its BSON encoding, as it would be stored in MongoDB.
+func (db *MemoryDB) RemoveReindexJobs(ctx context.Context, completedBefore time.Time) (int64, int64, error) {
+	if ctx.Err() != nil {
+		return 0, 0, ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var removed, bytesReclaimed int64
+	for id, job := range db.reindexJobs {
+		if job.CompletedAt == nil || !job.CompletedAt.Before(completedBefore) {
+			continue
+		}
+		document, err := bson.Marshal(job)
+		if err != nil {
+			return removed, bytesReclaimed, fmt.Errorf("error measuring reindex job: %w", err)
+		}
+		delete(db.reindexJobs, id)
+		removed++
+		bytesReclaimed += int64(len(document))
+	}
+
+	return removed, bytesReclaimed, nil
+}
+
+// Compact copies the maps holding the entries and their secondary records into new maps,
+// since Go maps do not release the memory of deleted keys. The freed memory is not measured.
+func (db *MemoryDB) Compact(ctx context.Context) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.entries = maps.Clone(db.entries)
+	db.reindexJobs = maps.Clone(db.reindexJobs)
+	db.schemas = maps.Clone(db.schemas)
+
+	return 0, nil
+}
+
+// SaveVacuumJob creates or updates a vacuum job
+func (db *MemoryDB) SaveVacuumJob(ctx context.Context, job *model.VacuumJob) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
 	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	jobCopy := *job
+	jobCopy.Phases = slices.Clone(job.Phases)
+	db.vacuumJobs[job.ID] = &jobCopy
+	return nil
+}
+
+// GetVacuumJob retrieves a vacuum job by its ID
+func (db *MemoryDB) GetVacuumJob(ctx context.Context, id string) (*model.VacuumJob, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	job, exists := db.vacuumJobs[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	jobCopy := *job
+	jobCopy.Phases = slices.Clone(job.Phases)
+	return &jobCopy, nil
 }
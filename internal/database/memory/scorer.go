@@ -0,0 +1,48 @@
+// Package memory contains helpers used by the in-memory database implementation
+package memory
+
+import (
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/model"
+)
+
+// Relevance scores of the text search rules, from the strongest to the weakest match
+const (
+	ScoreExactName       = 100
+	ScoreNamePrefix      = 80
+	ScoreNameContains    = 60
+	ScoreDescription     = 40
+	ScorePackageContains = 30
+)
+
+// ScoreServer returns the relevance of a server for a text search query, approximating the ranking of the
+// MongoDB text index. The score is that of the strongest matching rule, or zero if nothing matches.
+// Names are compared both in full and by their last path segment, so "weather" is an exact match for
+// "io.github.example/weather". Matching is case-insensitive and quotes in the query are ignored.
+func ScoreServer(server *model.ServerDetail, query string) int {
+	query = strings.ToLower(strings.TrimSpace(strings.ReplaceAll(query, `"`, "")))
+	if server == nil || query == "" {
+		return 0
+	}
+
+	name := strings.ToLower(server.Name)
+	shortName := name[strings.LastIndex(name, "/")+1:]
+	switch {
+	case name == query || shortName == query:
+		return ScoreExactName
+	case strings.HasPrefix(name, query) || strings.HasPrefix(shortName, query):
+		return ScoreNamePrefix
+	case strings.Contains(name, query):
+		return ScoreNameContains
+	case strings.Contains(strings.ToLower(server.Description), query):
+		return ScoreDescription
+	}
+
+	for _, pkg := range server.Packages {
+		if strings.Contains(strings.ToLower(pkg.Name), query) {
+			return ScorePackageContains
+		}
+	}
+	return 0
+}
@@ -0,0 +1,44 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/database/memory"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScoreServer(t *testing.T) {
+	server := &model.ServerDetail{
+		Server: model.Server{
+			Name:        "io.github.example/weather-server",
+			Description: "Forecasts from the National Weather Service",
+		},
+		Packages: []model.Package{{RegistryName: "npm", Name: "@example/mcp-forecast"}},
+	}
+
+	testCases := []struct {
+		name     string
+		query    string
+		expected int
+	}{
+		{name: "exact full name", query: "io.github.example/weather-server", expected: memory.ScoreExactName},
+		{name: "exact short name", query: "Weather-Server", expected: memory.ScoreExactName},
+		{name: "quoted exact name", query: `"weather-server"`, expected: memory.ScoreExactName},
+		{name: "full name prefix", query: "io.github", expected: memory.ScoreNamePrefix},
+		{name: "short name prefix", query: "weather", expected: memory.ScoreNamePrefix},
+		{name: "name contains", query: "server", expected: memory.ScoreNameContains},
+		{name: "description contains", query: "national", expected: memory.ScoreDescription},
+		{name: "package name contains", query: "mcp-forecast", expected: memory.ScorePackageContains},
+		{name: "no match", query: "filesystem", expected: 0},
+		{name: "empty query", query: "  ", expected: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, memory.ScoreServer(server, tc.query))
+		})
+	}
+
+	assert.Zero(t, memory.ScoreServer(nil, "weather"))
+}
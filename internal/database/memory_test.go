@@ -0,0 +1,276 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMemoryDatabaseRaceConditions(t *testing.T) {
+	t.Parallel()
+
+	const (
+		servers  = 10
+		versions = 5
+	)
+
+	ctx := context.Background()
+	db := database.NewMemoryDB(map[string]*model.Server{})
+
+	var wg sync.WaitGroup
+	for i := range servers {
+		for v := range versions {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				serverDetail := &model.ServerDetail{
+					Server: model.Server{
+						Name:       fmt.Sprintf("io.github.example/server-%d", i),
+						Repository: model.Repository{URL: fmt.Sprintf("https://github.com/example/server-%d", i)},
+						VersionDetail: model.VersionDetail{
+							Version: fmt.Sprintf("1.0.%d", v),
+						},
+					},
+					Packages: []model.Package{{RegistryName: "npm", Name: "example", Version: "1.0.0"}},
+				}
+				// Versions published out of order are rejected; only the data race matters here
+				_ = db.Publish(ctx, serverDetail)
+				serverDetail.Packages[0].Name = "mutated-by-caller"
+			}()
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				entries, _, err := db.ListDetails(ctx, nil, database.SortByCreation, "", database.CursorNext, 100)
+				assert.NoError(t, err)
+				for _, entry := range entries {
+					if _, err := db.GetByID(ctx, entry.ID); err != nil {
+						assert.ErrorIs(t, err, database.ErrNotFound)
+					}
+					_ = db.AppendPackages(ctx, entry.ID, []model.Package{{
+						RegistryName: "pypi",
+						Name:         fmt.Sprintf("example-%d-%d", i, v),
+						Version:      "1.0.0",
+					}}, 0)
+					_ = db.PinVersion(ctx, entry.ID, entry.VersionDetail.Version)
+				}
+			}()
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _, err := db.List(ctx, nil, database.SortByCreation, "", database.CursorNext, 100)
+				assert.NoError(t, err)
+				_, err = db.CountByCategory(ctx)
+				assert.NoError(t, err)
+			}()
+		}
+	}
+	wg.Wait()
+
+	entries, _, err := db.ListDetails(ctx, nil, database.SortByCreation, "", database.CursorNext, 1000)
+	require.NoError(t, err)
+	require.NotEmpty(t, entries)
+
+	latest := make(map[string]int)
+	for _, entry := range entries {
+		if entry.VersionDetail.IsLatest {
+			latest[entry.Name]++
+		}
+		for _, pkg := range entry.Packages {
+			assert.NotEqual(t, "mutated-by-caller", pkg.Name, "stored packages must not alias the caller's slice")
+		}
+	}
+	for name, count := range latest {
+		assert.Equal(t, 1, count, "server %s should have exactly one latest version", name)
+	}
+}
+
+func TestMemoryDatabaseTextSearchRelevance(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewMemoryDB(map[string]*model.Server{})
+	for _, server := range []struct{ name, description string }{
+		{"io.github.example/git-weather", "Weather for git users"},
+		{"io.github.example/weather-tools", "Tools"},
+		{"io.github.example/weather", "Forecasts"},
+		{"io.github.other/weather", "Another weather forecast"},
+	} {
+		require.NoError(t, db.Publish(ctx, &model.ServerDetail{
+			Server: model.Server{
+				Name:          server.name,
+				Description:   server.description,
+				Repository:    model.Repository{URL: "https://github.com/" + server.name[len("io.github."):]},
+				VersionDetail: model.VersionDetail{Version: "1.0.0"},
+			},
+		}))
+	}
+
+	filter := bson.D{{Key: "$text", Value: bson.M{"$search": "weather"}}}
+	var names []string
+	cursor := ""
+	for {
+		entries, cursors, err := db.ListDetails(ctx, filter, database.SortByCreation, cursor, database.CursorNext, 3)
+		require.NoError(t, err)
+		for _, entry := range entries {
+			names = append(names, entry.Name)
+		}
+		if cursors.Next == "" {
+			break
+		}
+		cursor = cursors.Next
+	}
+
+	// Exact name matches come first in publish order, followed by prefix and substring matches
+	assert.Equal(t, []string{
+		"io.github.example/weather",
+		"io.github.other/weather",
+		"io.github.example/weather-tools",
+		"io.github.example/git-weather",
+	}, names)
+}
+
+func TestMemoryDatabaseTransaction(t *testing.T) {
+	ctx := context.Background()
+	errAbort := errors.New("abort")
+
+	publish := func(t *testing.T, db *database.MemoryDB, version string) *model.ServerDetail {
+		t.Helper()
+		serverDetail := &model.ServerDetail{
+			Server: model.Server{
+				Name:          "io.github.example/transactional",
+				Repository:    model.Repository{URL: "https://github.com/example/transactional", Source: "github"},
+				VersionDetail: model.VersionDetail{Version: version},
+			},
+		}
+		require.NoError(t, db.Publish(ctx, serverDetail))
+		return serverDetail
+	}
+
+	t.Run("failed transaction rolls back every change", func(t *testing.T) {
+		db := database.NewMemoryDB(map[string]*model.Server{})
+		first := publish(t, db, "1.0.0")
+
+		var second *model.ServerDetail
+		err := db.WithTransaction(ctx, func(ctx context.Context) error {
+			second = &model.ServerDetail{
+				Server: model.Server{Name: first.Name, Repository: first.Repository, VersionDetail: model.VersionDetail{Version: "2.0.0"}},
+			}
+			require.NoError(t, db.Publish(ctx, second))
+			require.NoError(t, db.TransferOwnership(ctx, first.ID, "new-owner"))
+			// A nested transaction joins the outer one and is rolled back with it
+			require.NoError(t, db.WithTransaction(ctx, func(ctx context.Context) error {
+				return db.YankVersion(ctx, first.ID, "1.0.0")
+			}))
+			return errAbort
+		})
+		require.ErrorIs(t, err, errAbort)
+
+		_, err = db.GetByID(ctx, second.ID)
+		require.ErrorIs(t, err, database.ErrNotFound)
+
+		stored, err := db.GetByID(ctx, first.ID)
+		require.NoError(t, err)
+		assert.True(t, stored.VersionDetail.IsLatest)
+		assert.False(t, stored.VersionDetail.Yanked)
+		assert.Equal(t, "example", stored.Publisher())
+
+		// Sequence numbers of rolled back servers are reused
+		third := publish(t, db, "3.0.0")
+		assert.Equal(t, first.CreatedSeq+1, third.CreatedSeq)
+	})
+
+	t.Run("successful transaction commits", func(t *testing.T) {
+		db := database.NewMemoryDB(map[string]*model.Server{})
+		first := publish(t, db, "1.0.0")
+
+		require.NoError(t, db.WithTransaction(ctx, func(ctx context.Context) error {
+			return db.TransferOwnership(ctx, first.ID, "new-owner")
+		}))
+
+		stored, err := db.GetByID(ctx, first.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "new-owner", stored.Publisher())
+	})
+}
+
+func TestMemoryDatabasePublishAfterPin(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewMemoryDB(map[string]*model.Server{})
+	newVersion := func(version string) *model.ServerDetail {
+		return &model.ServerDetail{
+			Server: model.Server{
+				Name:          "io.github.example/pinned",
+				Repository:    model.Repository{URL: "https://github.com/example/pinned", Source: "github"},
+				VersionDetail: model.VersionDetail{Version: version},
+			},
+		}
+	}
+
+	first := newVersion("1.0.0")
+	require.NoError(t, db.Publish(ctx, first))
+	require.NoError(t, db.Publish(ctx, newVersion("2.0.0")))
+	require.NoError(t, db.PinVersion(ctx, first.ID, "1.0.0"))
+
+	// Versions between the pinned and the highest version are still older than the highest one
+	require.ErrorIs(t, db.Publish(ctx, newVersion("1.5.0")), database.ErrInvalidVersion)
+
+	// A higher version becomes the latest and releases the pin
+	third := newVersion("3.0.0")
+	require.NoError(t, db.Publish(ctx, third))
+	stored, err := db.GetByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.False(t, stored.VersionDetail.IsLatest)
+	assert.False(t, stored.PinnedVersion)
+}
+
+func TestMemoryDatabaseAppendPackagesLimit(t *testing.T) {
+	const maxPackages = 5
+
+	ctx := context.Background()
+	db := database.NewMemoryDB(map[string]*model.Server{})
+	serverDetail := &model.ServerDetail{
+		Server: model.Server{
+			Name:          "io.github.example/limited",
+			Repository:    model.Repository{URL: "https://github.com/example/limited", Source: "github"},
+			VersionDetail: model.VersionDetail{Version: "1.0.0"},
+		},
+		Packages: []model.Package{{RegistryName: "npm", Name: "limited", Version: "1.0.0"}},
+	}
+	require.NoError(t, db.Publish(ctx, serverDetail))
+
+	// Every append fits on its own, but only some of them fit together
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = db.AppendPackages(ctx, serverDetail.ID, []model.Package{{
+				RegistryName: "pypi", Name: fmt.Sprintf("limited-%d", i), Version: "1.0.0",
+			}}, maxPackages)
+		}()
+	}
+	wg.Wait()
+
+	appended := 0
+	for _, err := range errs {
+		if err == nil {
+			appended++
+			continue
+		}
+		require.ErrorIs(t, err, database.ErrTooManyPackages)
+	}
+	assert.Equal(t, maxPackages-1, appended)
+
+	stored, err := db.GetByID(ctx, serverDetail.ID)
+	require.NoError(t, err)
+	assert.Len(t, stored.Packages, maxPackages)
+}
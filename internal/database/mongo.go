@@ -1,13 +1,22 @@
 package database
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
+	"regexp"
+	"slices"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/database/mongodb"
+	"github.com/modelcontextprotocol/registry/internal/metrics"
 	"github.com/modelcontextprotocol/registry/internal/model"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -16,15 +25,49 @@ import (
 
 // MongoDB is an implementation of the Database interface using MongoDB
 type MongoDB struct {
-	client     *mongo.Client
-	database   *mongo.Database
-	collection *mongo.Collection
+	client      *mongo.Client
+	database    *mongo.Database
+	collection  *mongo.Collection
+	counters    *mongo.Collection
+	reindexJobs *mongo.Collection
+	vacuumJobs  *mongo.Collection
+	provenances *mongo.Collection
+	reviews     *mongo.Collection
+	schemas     *mongo.Collection
+	// subscriptions holds the webhook subscriptions of servers
+	subscriptions *mongo.Collection
+	// apiKeys holds the API keys issued by the registry owner
+	apiKeys *mongo.Collection
+	// slowQueries logs the plans of slow finds; it is nil unless LogSlowQueries was called
+	slowQueries *mongodb.SlowQueryLog
 }
 
+// schemaDocument stores the gzip-compressed JSON schema of a server version
+type schemaDocument struct {
+	ServerID string `bson:"server_id"`
+	Schema   []byte `bson:"schema"`
+}
+
+// textIndexModel returns the definition of the text index used for search.
+// Changing it requires rebuilding the index of existing databases, see RebuildTextIndex.
+func textIndexModel() mongo.IndexModel {
+	return mongo.IndexModel{
+		Keys: bson.D{
+			bson.E{Key: "name", Value: "text"},
+			bson.E{Key: "language", Value: "text"},
+			bson.E{Key: "topics", Value: "text"},
+		},
+	}
+}
+
+// serversSequenceID is the ID of the counter document used to generate server creation sequence numbers
+const serversSequenceID = "servers"
+
 // NewMongoDB creates a new instance of the MongoDB database
 func NewMongoDB(ctx context.Context, connectionURI, databaseName, collectionName string) (*MongoDB, error) {
-	// Set client options and connect to MongoDB
-	clientOptions := options.Client().ApplyURI(connectionURI)
+	// Set client options and connect to MongoDB, recording the duration of every command in the metrics
+	clientOptions := options.Client().ApplyURI(connectionURI).
+		SetMonitor(mongodb.CommandDurationMonitor(metrics.Default.ObserveDatabaseOperation))
 	client, err := mongo.Connect(ctx, clientOptions)
 	if err != nil {
 		return nil, err
@@ -50,12 +93,67 @@ func NewMongoDB(ctx context.Context, connectionURI, databaseName, collectionName
 		},
 		// add an index for the combination of name and version
 		{
-			Keys:    bson.D{bson.E{Key: "name", Value: 1}, bson.E{Key: "versiondetail.version", Value: 1}},
+			Keys:    bson.D{bson.E{Key: "name", Value: 1}, bson.E{Key: "version_detail.version", Value: 1}},
 			Options: options.Index().SetUnique(true),
 		},
-		// Add text index on name field for text search (prevents ReDoS attacks)
+		// Add an index on the creation sequence used for stable pagination
+		{
+			Keys: bson.D{bson.E{Key: "created_seq", Value: 1}},
+		},
+		// Add an index for incremental sync by update time
+		{
+			Keys: bson.D{bson.E{Key: "updated_at", Value: 1}, bson.E{Key: "created_seq", Value: 1}},
+		},
+		// Add an index for filtering and counting by category
+		{
+			Keys: bson.D{bson.E{Key: "category", Value: 1}},
+		},
+		// Add an index for filtering and counting by language
+		{
+			Keys: bson.D{bson.E{Key: "language", Value: 1}},
+		},
+		// Add an index for filtering and counting by topic
+		{
+			Keys: bson.D{bson.E{Key: "topics", Value: 1}},
+		},
+		// Add an index for filtering and counting by tag
+		{
+			Keys: bson.D{bson.E{Key: "tags", Value: 1}},
+		},
+		// Add an index for filtering by license
+		{
+			Keys: bson.D{bson.E{Key: "license", Value: 1}},
+		},
+		// Add an index for filtering by maintainer
+		{
+			Keys: bson.D{bson.E{Key: "maintainers.github_username", Value: 1}},
+		},
+		// Add an index for filtering by lifecycle status
+		{
+			Keys: bson.D{bson.E{Key: "status", Value: 1}},
+		},
+		// Add an index for filtering by declared tool name
+		{
+			Keys: bson.D{bson.E{Key: "tools.name", Value: 1}},
+		},
+		// Add an index for counting the dependents of a server
+		{
+			Keys: bson.D{bson.E{Key: "dependencies", Value: 1}},
+		},
+		// Add an index for sorting by repository stars
+		{
+			Keys: bson.D{bson.E{Key: "repository_stats.stars", Value: -1}, bson.E{Key: "created_seq", Value: 1}},
+		},
+		// Add an index for sorting and filtering by publication time
+		{
+			Keys: bson.D{bson.E{Key: "published_at", Value: -1}, bson.E{Key: "created_seq", Value: 1}},
+		},
+		// Add indexes for the name and publication time sort parameters, in either direction
+		{
+			Keys: bson.D{bson.E{Key: "name", Value: 1}, bson.E{Key: "created_seq", Value: 1}},
+		},
 		{
-			Keys: bson.D{bson.E{Key: "name", Value: "text"}},
+			Keys: bson.D{bson.E{Key: "published_at", Value: 1}, bson.E{Key: "created_seq", Value: 1}},
 		},
 	}
 
@@ -69,35 +167,207 @@ func NewMongoDB(ctx context.Context, connectionURI, databaseName, collectionName
 		log.Printf("Indexes already exists, skipping.")
 	}
 
-	return &MongoDB{
-		client:     client,
-		database:   database,
-		collection: collection,
-	}, nil
+	// Add the text index used for text search (prevents ReDoS attacks). It is created separately since a
+	// collection holds a single text index: databases created with an older definition keep it until it is
+	// rebuilt through a reindex job, and text search keeps working meanwhile.
+	if _, err := collection.Indexes().CreateOne(ctx, textIndexModel()); err != nil {
+		var commandError mongo.CommandError
+		if !errors.As(err, &commandError) {
+			return nil, err
+		}
+		log.Printf("Text index differs from the current definition, a reindex is needed to update it: %v", err)
+	}
+
+	db := &MongoDB{
+		client:        client,
+		database:      database,
+		collection:    collection,
+		counters:      database.Collection("counters"),
+		reindexJobs:   database.Collection("reindex_jobs"),
+		vacuumJobs:    database.Collection("vacuum_jobs"),
+		provenances:   database.Collection("provenances"),
+		reviews:       database.Collection("reviews"),
+		schemas:       database.Collection("schemas"),
+		subscriptions: database.Collection("subscriptions"),
+		apiKeys:       database.Collection("api_keys"),
+	}
+
+	// Provenance attestations are unique per server version and looked up by server name
+	_, err = db.provenances.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{bson.E{Key: "server_id", Value: 1}, bson.E{Key: "version", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{bson.E{Key: "server_name", Value: 1}, bson.E{Key: "created_at", Value: -1}},
+		},
+	})
+	if err != nil {
+		var commandError mongo.CommandError
+		if errors.As(err, &commandError) && commandError.Code != 86 {
+			return nil, err
+		}
+	}
+
+	// Each user reviews a server at most once; reviews are listed newest first
+	_, err = db.reviews.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{bson.E{Key: "server_id", Value: 1}, bson.E{Key: "github_user_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{bson.E{Key: "server_id", Value: 1}, bson.E{Key: "created_at", Value: -1}},
+		},
+	})
+	if err != nil {
+		var commandError mongo.CommandError
+		if errors.As(err, &commandError) && commandError.Code != 86 {
+			return nil, err
+		}
+	}
+
+	// Each server version has at most one schema
+	_, err = db.schemas.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{bson.E{Key: "server_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		var commandError mongo.CommandError
+		if errors.As(err, &commandError) && commandError.Code != 86 {
+			return nil, err
+		}
+	}
+
+	// Each server has at most one subscription per webhook URL; subscriptions are counted per user
+	_, err = db.subscriptions.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{bson.E{Key: "server_name", Value: 1}, bson.E{Key: "webhook_url", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{bson.E{Key: "id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{bson.E{Key: "github_user_id", Value: 1}},
+		},
+	})
+	if err != nil {
+		var commandError mongo.CommandError
+		if errors.As(err, &commandError) && commandError.Code != 86 {
+			return nil, err
+		}
+	}
+
+	// API keys are looked up by ID when validating them
+	_, err = db.apiKeys.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{bson.E{Key: "id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		var commandError mongo.CommandError
+		if errors.As(err, &commandError) && commandError.Code != 86 {
+			return nil, err
+		}
+	}
+
+	// Assign creation sequence numbers to documents created before sequences were introduced
+	if err := db.backfillSequences(ctx); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// LogSlowQueries logs the query plans of finds taking longer than threshold. Explaining a query costs another
+// round trip, so it is meant for development. It must be called before the database is used.
+func (db *MongoDB) LogSlowQueries(threshold time.Duration) {
+	db.slowQueries = &mongodb.SlowQueryLog{Threshold: threshold}
+}
+
+// GetNextSequence atomically increments and returns the server creation sequence number
+func (db *MongoDB) GetNextSequence(ctx context.Context) (int64, error) {
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var counter struct {
+		Seq int64 `bson:"seq"`
+	}
+	err := db.counters.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": serversSequenceID},
+		bson.M{"$inc": bson.M{"seq": 1}},
+		opts,
+	).Decode(&counter)
+	if err != nil {
+		return 0, fmt.Errorf("error generating sequence number: %w", err)
+	}
+
+	return counter.Seq, nil
+}
+
+// backfillSequences assigns creation sequence numbers, in ID order, to documents that do not have one
+func (db *MongoDB) backfillSequences(ctx context.Context) error {
+	findOptions := options.Find().SetSort(bson.M{"id": 1}).SetProjection(bson.M{"id": 1})
+	cursor, err := db.slowQueries.Find(ctx, db.collection, bson.M{"created_seq": bson.M{"$exists": false}}, findOptions)
+	if err != nil {
+		return fmt.Errorf("error finding documents without sequence: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID string `bson:"id"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return err
+		}
+
+		seq, err := db.GetNextSequence(ctx)
+		if err != nil {
+			return err
+		}
+
+		if _, err := db.collection.UpdateOne(ctx, bson.M{"id": doc.ID}, bson.M{"$set": bson.M{"created_seq": seq}}); err != nil {
+			return fmt.Errorf("error assigning sequence to %s: %w", doc.ID, err)
+		}
+	}
+
+	return cursor.Err()
 }
 
 // List retrieves MCPRegistry entries with optional filtering and pagination
 func (db *MongoDB) List(
 	ctx context.Context,
-	filter map[string]interface{},
+	filter bson.D,
+	sortBy SortOrder,
 	cursor string,
+	direction CursorDirection,
 	limit int,
-) ([]*model.Server, string, error) {
+) ([]*model.Server, PageCursors, error) {
 	if limit <= 0 {
 		// Set default limit if not provided
 		limit = 10
 	}
 
 	if ctx.Err() != nil {
-		return nil, "", ctx.Err()
+		return nil, PageCursors{}, ctx.Err()
 	}
 
-	// Convert Go map to MongoDB filter
+	mongoFilter := latestFilter(filter)
+
+	return findPage(ctx, db, mongoFilter, sortBy, cursor, direction, limit, func(server *model.Server) int64 {
+		return server.CreatedSeq
+	})
+}
+
+// latestFilter converts a List or ListDetails filter into a MongoDB filter on the latest versions of servers
+func latestFilter(filter bson.D) bson.M {
 	mongoFilter := bson.M{
 		"version_detail.is_latest": true,
 	}
 	// Map common filter keys to MongoDB document paths
-	for k, v := range filter {
+	for _, elem := range filter {
+		k, v := elem.Key, elem.Value
 		// Handle nested fields with dot notation
 		switch k {
 		case "version":
@@ -116,156 +386,320 @@ func (db *MongoDB) List(
 			mongoFilter[k] = v
 		}
 	}
+	return mongoFilter
+}
 
-	// Setup pagination options
-	findOptions := options.Find()
+// findPage reads the page of documents matching the filter next to the cursor in the given sort order
+func findPage[T any](
+	ctx context.Context,
+	db *MongoDB,
+	mongoFilter bson.M,
+	sortBy SortOrder,
+	cursor string,
+	direction CursorDirection,
+	limit int,
+	seq func(T) int64,
+) ([]T, PageCursors, error) {
+	// Pages not in creation order use the cursor as an offset
+	if sort, ok := offsetSort(sortBy, mongoFilter); ok {
+		offset, count, err := offsetWindow(cursor, direction, limit)
+		if err != nil {
+			return nil, PageCursors{}, err
+		}
+		if count == 0 {
+			return []T{}, PageCursors{}, nil
+		}
+		findOptions := options.Find().SetSkip(offset).SetLimit(int64(count)).SetSort(sort)
 
-	// If cursor is provided, add condition to filter to only get records after the cursor
-	if cursor != "" {
-		// Validate that the cursor is a valid UUID
-		if _, err := uuid.Parse(cursor); err != nil {
-			return nil, "", fmt.Errorf("invalid cursor format: %w", err)
+		var results []T
+		if err := db.findAll(ctx, mongoFilter, findOptions, &results); err != nil {
+			return nil, PageCursors{}, err
 		}
+		return results, offsetCursors(offset, len(results), direction == CursorPrev || len(results) >= limit), nil
+	}
+
+	findOptions := options.Find().SetLimit(int64(limit))
+	if err := seqPage(mongoFilter, findOptions, cursor, direction); err != nil {
+		return nil, PageCursors{}, err
+	}
+
+	var results []T
+	if err := db.findAll(ctx, mongoFilter, findOptions, &results); err != nil {
+		return nil, PageCursors{}, err
+	}
+
+	return results, seqPageCursors(results, seq, cursor, direction, limit), nil
+}
+
+// offsetSort returns the sort of the sort orders paginated by offset, ties broken by creation so pages are stable.
+// Field sort orders break ties in their own direction, so each is served by a single index in both directions.
+// It returns false for creation order, and for relevance order without a text search condition.
+func offsetSort(sortBy SortOrder, mongoFilter bson.M) (bson.D, bool) {
+	if key, order, ok := fieldSort(sortBy); ok {
+		return bson.D{bson.E{Key: key, Value: order}, bson.E{Key: "created_seq", Value: order}}, true
+	}
+
+	var sort bson.D
+	if key, ok := offsetSortKeys[sortBy]; ok {
+		sort = bson.D{bson.E{Key: key, Value: -1}}
+	} else if _, textSearch := mongoFilter["$text"]; sortBy == SortByRelevance && textSearch {
+		sort = bson.D{bson.E{Key: "score", Value: bson.M{"$meta": "textScore"}}}
+	} else {
+		return nil, false
+	}
+	return append(sort, bson.E{Key: "created_seq", Value: 1}), true
+}
+
+// seqPage sets up a find of documents in creation sequence order (stable when records are published
+// mid-traversal) to read the page after the cursor, or before it with CursorPrev. Pages before the cursor
+// are read in reverse order, nearest first.
+func seqPage(mongoFilter bson.M, findOptions *options.FindOptions, cursor string, direction CursorDirection) error {
+	operator, order := "$gt", 1
+	if direction == CursorPrev {
+		operator, order = "$lt", -1
+	}
 
-		// Fetch the document at the cursor to get its sort values
-		var cursorDoc model.Server
-		err := db.collection.FindOne(ctx, bson.M{"id": cursor}).Decode(&cursorDoc)
+	if cursor != "" {
+		cursorSeq, err := DecodeCursor(cursor)
 		if err != nil {
-			if !errors.Is(err, mongo.ErrNoDocuments) {
-				return nil, "", err
-			}
-			// If cursor document not found, start from beginning
-		} else {
-			// Use the cursor document's ID to paginate (records with ID > cursor's ID)
-			mongoFilter["id"] = bson.M{"$gt": cursor}
+			return err
 		}
+		mongoFilter["created_seq"] = bson.M{operator: cursorSeq}
+	}
+	findOptions.SetSort(bson.M{"created_seq": order})
+	return nil
+}
+
+// seqPageCursors puts a page read by seqPage back in creation order and returns its cursors.
+// A full page is assumed to be followed by more results in the direction it was read.
+func seqPageCursors[T any](results []T, seq func(T) int64, cursor string, direction CursorDirection, limit int) PageCursors {
+	if len(results) == 0 {
+		return PageCursors{}
 	}
 
-	// Set sort order by ID (for consistent pagination)
-	findOptions.SetSort(bson.M{"id": 1})
+	full := len(results) >= limit
+	if direction == CursorPrev {
+		slices.Reverse(results)
+		return seqCursors(seq(results[0]), seq(results[len(results)-1]), full, cursor != "")
+	}
+	return seqCursors(seq(results[0]), seq(results[len(results)-1]), cursor != "", full)
+}
 
-	// Set limit if provided and valid
-	if limit > 0 {
-		findOptions.SetLimit(int64(limit))
+// ListUpdatedAfter retrieves the versions of all servers updated strictly after the given time, or at that time
+// with a creation sequence number greater than afterSeq, least recently updated first
+func (db *MongoDB) ListUpdatedAfter(
+	ctx context.Context, updatedAfter time.Time, afterSeq int64, limit int,
+) ([]*model.Server, error) {
+	if limit <= 0 {
+		// Set default limit if not provided
+		limit = 10
 	}
 
-	// Execute find operation with options
-	mongoCursor, err := db.collection.Find(ctx, mongoFilter, findOptions)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{bson.E{Key: "updated_at", Value: 1}, bson.E{Key: "created_seq", Value: 1}}).
+		SetLimit(int64(limit))
+
+	// Versions updated together are told apart by creation sequence, so that no page boundary skips any of them
+	filter := bson.M{"$or": []bson.M{
+		{"updated_at": bson.M{"$gt": updatedAfter}},
+		{"updated_at": updatedAfter, "created_seq": bson.M{"$gt": afterSeq}},
+	}}
+	mongoCursor, err := db.slowQueries.Find(ctx, db.collection, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer mongoCursor.Close(ctx)
+
+	results := []*model.Server{}
+	if err = mongoCursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// ListRecent retrieves the latest versions of the most recently published servers, newest first
+func (db *MongoDB) ListRecent(ctx context.Context, limit int) ([]*model.Server, error) {
+	if limit <= 0 {
+		// Set default limit if not provided
+		limit = 10
+	}
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	// Servers published at the same time are ordered by creation, newest first
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "published_at", Value: -1}, {Key: "created_seq", Value: -1}}).
+		SetLimit(int64(limit))
+
+	mongoCursor, err := db.slowQueries.Find(ctx, db.collection, bson.M{"version_detail.is_latest": true}, findOptions)
 	if err != nil {
-		return nil, "", err
+		return nil, err
 	}
 	defer mongoCursor.Close(ctx)
 
-	// Decode results
-	var results []*model.Server
+	results := []*model.Server{}
 	if err = mongoCursor.All(ctx, &results); err != nil {
-		return nil, "", err
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// ListFeatured retrieves the featured servers in their display order
+func (db *MongoDB) ListFeatured(ctx context.Context) ([]*model.Server, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "featured_order", Value: 1}, {Key: "created_seq", Value: 1}})
+
+	mongoCursor, err := db.slowQueries.Find(ctx, db.collection, bson.M{"featured_order": bson.M{"$exists": true}}, findOptions)
+	if err != nil {
+		return nil, err
 	}
+	defer mongoCursor.Close(ctx)
 
-	// Determine the next cursor
-	nextCursor := ""
-	if len(results) > 0 && limit > 0 && len(results) >= limit {
-		// Use the last item's ID as the next cursor
-		nextCursor = results[len(results)-1].ID
+	results := []*model.Server{}
+	if err = mongoCursor.All(ctx, &results); err != nil {
+		return nil, err
 	}
 
-	return results, nextCursor, nil
+	return results, nil
 }
 
 // ListDetails retrieves ServerDetail entries with optional filtering and pagination
 func (db *MongoDB) ListDetails(
 	ctx context.Context,
-	filter map[string]interface{},
+	filter bson.D,
+	sortBy SortOrder,
 	cursor string,
+	direction CursorDirection,
 	limit int,
-) ([]*model.ServerDetail, string, error) {
+) ([]*model.ServerDetail, PageCursors, error) {
 	if limit <= 0 {
 		// Set default limit if not provided
 		limit = 10
 	}
 
 	if ctx.Err() != nil {
-		return nil, "", ctx.Err()
+		return nil, PageCursors{}, ctx.Err()
 	}
 
-	// Convert Go map to MongoDB filter
-	mongoFilter := bson.M{
-		"version_detail.is_latest": true,
-	}
-	// Map common filter keys to MongoDB document paths
-	for k, v := range filter {
-		// Handle nested fields with dot notation
-		switch k {
-		case "version":
-			mongoFilter["version_detail.version"] = v
-		case "name":
-			mongoFilter["name"] = v
-		case "packages.registry_name":
-			mongoFilter["packages.registry_name"] = v
-		case "$text":
-			// MongoDB text search - pass through as-is
-			mongoFilter["$text"] = v
-		case "repository.url":
-			// Repository URL filter
-			mongoFilter["repository.url"] = v
-		default:
-			mongoFilter[k] = v
-		}
-	}
+	mongoFilter := latestFilter(filter)
 
-	// Setup pagination options
-	findOptions := options.Find()
+	return findPage(ctx, db, mongoFilter, sortBy, cursor, direction, limit, func(entry *model.ServerDetail) int64 {
+		return entry.CreatedSeq
+	})
+}
 
-	// If cursor is provided, add condition to filter to only get records after the cursor
-	if cursor != "" {
-		// Validate that the cursor is a valid UUID
-		if _, err := uuid.Parse(cursor); err != nil {
-			return nil, "", fmt.Errorf("invalid cursor format: %w", err)
-		}
+// Count returns the number of servers whose latest version matches the filter
+func (db *MongoDB) Count(ctx context.Context, filter bson.D) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
 
-		// Fetch the document at the cursor to get its sort values
-		var cursorDoc model.ServerDetail
-		err := db.collection.FindOne(ctx, bson.M{"id": cursor}).Decode(&cursorDoc)
-		if err != nil {
-			if !errors.Is(err, mongo.ErrNoDocuments) {
-				return nil, "", err
-			}
-			// If cursor document not found, start from beginning
-		} else {
-			// Use the cursor document's ID to paginate (records with ID > cursor's ID)
-			mongoFilter["id"] = bson.M{"$gt": cursor}
-		}
+	count, err := db.collection.CountDocuments(ctx, latestFilter(filter))
+	if err != nil {
+		return 0, fmt.Errorf("error counting servers: %w", err)
 	}
 
-	// Set sort order by ID (for consistent pagination)
-	findOptions.SetSort(bson.M{"id": 1})
+	return count, nil
+}
 
-	// Set limit if provided and valid
-	if limit > 0 {
-		findOptions.SetLimit(int64(limit))
+// CountFacets returns the number of servers whose latest version matches the filter with each package registry,
+// tag, license and status, counted in a single aggregation
+func (db *MongoDB) CountFacets(ctx context.Context, filter bson.D) (model.Facets, error) {
+	group := bson.D{{Key: "$group", Value: bson.M{"_id": "$value", "count": bson.M{"$sum": 1}}}}
+	pipeline := mongo.Pipeline{
+		// A text search condition is only allowed in the first stage
+		{{Key: "$match", Value: latestFilter(filter)}},
+		{{Key: "$facet", Value: bson.M{
+			"registry_names": bson.A{
+				bson.D{{Key: "$project", Value: bson.M{"value": bson.M{"$setUnion": bson.A{"$packages.registry_name"}}}}},
+				bson.D{{Key: "$unwind", Value: "$value"}},
+				group,
+			},
+			"tags": bson.A{
+				bson.D{{Key: "$project", Value: bson.M{"value": "$tags"}}},
+				bson.D{{Key: "$unwind", Value: "$value"}},
+				group,
+			},
+			"licenses": bson.A{
+				bson.D{{Key: "$match", Value: bson.M{"license": bson.M{"$gt": ""}}}},
+				bson.D{{Key: "$project", Value: bson.M{"value": "$license"}}},
+				group,
+			},
+			"statuses": bson.A{
+				bson.D{{Key: "$project", Value: bson.M{"value": bson.M{"$ifNull": bson.A{"$status", model.StatusActive}}}}},
+				group,
+			},
+		}}},
 	}
 
-	// Execute find operation with options
-	mongoCursor, err := db.collection.Find(ctx, mongoFilter, findOptions)
+	cursor, err := db.collection.Aggregate(ctx, pipeline)
 	if err != nil {
-		return nil, "", err
+		return model.Facets{}, fmt.Errorf("error counting facets: %w", err)
 	}
-	defer mongoCursor.Close(ctx)
+	defer cursor.Close(ctx)
 
-	// Decode results
-	var results []*model.ServerDetail
-	if err = mongoCursor.All(ctx, &results); err != nil {
-		return nil, "", err
+	type valueCount struct {
+		Value string `bson:"_id"`
+		Count int    `bson:"count"`
+	}
+	type facetCounts struct {
+		RegistryNames []valueCount `bson:"registry_names"`
+		Tags          []valueCount `bson:"tags"`
+		Licenses      []valueCount `bson:"licenses"`
+		Statuses      []valueCount `bson:"statuses"`
+	}
+	var results []facetCounts
+	if err := cursor.All(ctx, &results); err != nil {
+		return model.Facets{}, fmt.Errorf("error decoding facets: %w", err)
+	}
+
+	var result facetCounts
+	if len(results) > 0 {
+		result = results[0]
+	}
+	counts := func(values []valueCount) map[string]int {
+		counts := make(map[string]int, len(values))
+		for _, value := range values {
+			counts[value.Value] = value.Count
+		}
+		return counts
 	}
 
-	// Determine the next cursor
-	nextCursor := ""
-	if len(results) > 0 && limit > 0 && len(results) >= limit {
-		// Use the last item's ID as the next cursor
-		nextCursor = results[len(results)-1].ID
+	return model.Facets{
+		RegistryNames: counts(result.RegistryNames),
+		Tags:          counts(result.Tags),
+		Licenses:      counts(result.Licenses),
+		Statuses:      counts(result.Statuses),
+	}, nil
+}
+
+// offsetSortKeys maps the sort orders other than field and relevance orders that are paginated by offset to the
+// field they sort by, in descending order
+var offsetSortKeys = map[SortOrder]string{
+	SortByStars:       "repository_stats.stars",
+	SortByReleaseDate: "published_at",
+}
+
+// findAll decodes all server documents matching the filter into results
+func (db *MongoDB) findAll(ctx context.Context, mongoFilter bson.M, findOptions *options.FindOptions, results any) error {
+	mongoCursor, err := db.slowQueries.Find(ctx, db.collection, mongoFilter, findOptions)
+	if err != nil {
+		return err
 	}
+	defer mongoCursor.Close(ctx)
 
-	return results, nextCursor, nil
+	return mongoCursor.All(ctx, results)
 }
 
 // GetByID retrieves a single ServerDetail by its ID
@@ -291,50 +725,1116 @@ func (db *MongoDB) GetByID(ctx context.Context, id string) (*model.ServerDetail,
 	return &entry, nil
 }
 
-// Publish adds a new ServerDetail to the database
-func (db *MongoDB) Publish(ctx context.Context, serverDetail *model.ServerDetail) error {
-	if ctx.Err() != nil {
-		return ctx.Err()
+// ListVersions retrieves the versions of the server with the given ID in publish order
+func (db *MongoDB) ListVersions(
+	ctx context.Context, id string, includeYanked bool, cursor string, direction CursorDirection, limit int,
+) ([]*model.ServerDetail, PageCursors, error) {
+	if limit <= 0 {
+		limit = 10
 	}
-	// find a server detail with the same name and check that the current version is greater than the existing one
+
+	server, err := db.GetByID(ctx, id)
+	if err != nil {
+		return nil, PageCursors{}, err
+	}
+
+	mongoFilter := bson.M{"name": server.Name}
+	if !includeYanked {
+		mongoFilter["version_detail.yanked"] = bson.M{"$ne": true}
+	}
+
+	findOptions := options.Find().SetLimit(int64(limit))
+	if err := seqPage(mongoFilter, findOptions, cursor, direction); err != nil {
+		return nil, PageCursors{}, err
+	}
+
+	results := []*model.ServerDetail{}
+	if err := db.findAll(ctx, mongoFilter, findOptions, &results); err != nil {
+		return nil, PageCursors{}, fmt.Errorf("error listing versions: %w", err)
+	}
+
+	cursors := seqPageCursors(results, func(entry *model.ServerDetail) int64 { return entry.CreatedSeq }, cursor, direction, limit)
+	return results, cursors, nil
+}
+
+// GetByIDs retrieves the ServerDetails with the given IDs, skipping unknown IDs
+func (db *MongoDB) GetByIDs(ctx context.Context, ids []string) ([]*model.ServerDetail, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	cursor, err := db.slowQueries.Find(ctx, db.collection, bson.M{"id": bson.M{"$in": ids}}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving entries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	result := []*model.ServerDetail{}
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, fmt.Errorf("error decoding entries: %w", err)
+	}
+
+	return result, nil
+}
+
+// Publish adds a new ServerDetail to the database
+func (db *MongoDB) Publish(ctx context.Context, serverDetail *model.ServerDetail) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	// check that the version is unique and not older than any existing version. A pinned or upgraded older
+	// version may be the latest, so the highest version is looked up among all of them.
+	var existingVersions []model.ServerDetail
+	err := db.findAll(ctx, bson.M{"name": serverDetail.Name},
+		options.Find().SetProjection(bson.M{"version_detail.version": 1}), &existingVersions)
+	if err != nil {
+		return fmt.Errorf("error checking existing versions: %w", err)
+	}
+
+	var highestVersion string
+	for _, existing := range existingVersions {
+		if existing.VersionDetail.Version == serverDetail.VersionDetail.Version {
+			return ErrAlreadyExists
+		}
+		if highestVersion == "" || compareSemanticVersions(existing.VersionDetail.Version, highestVersion) > 0 {
+			highestVersion = existing.VersionDetail.Version
+		}
+	}
+	if highestVersion != "" && compareSemanticVersions(serverDetail.VersionDetail.Version, highestVersion) < 0 {
+		return ErrInvalidVersion
+	}
+
+	// the latest version holds the server-wide fields carried over to the new version
+	var existingEntry model.ServerDetail
+	err = db.collection.FindOne(ctx, bson.M{"name": serverDetail.Name, "version_detail.is_latest": true}).Decode(&existingEntry)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return fmt.Errorf("error checking existing entry: %w", err)
+	}
+
+	seq, err := db.GetNextSequence(ctx)
+	if err != nil {
+		return err
+	}
+
+	serverDetail.ID = uuid.New().String()
+	serverDetail.CreatedSeq = seq
+	serverDetail.VersionDetail.IsLatest = true
+	// A transferred owner carries over to new versions
+	serverDetail.PublisherUsername = existingEntry.PublisherUsername
+	serverDetail.MaintainedBy = existingEntry.MaintainedBy
+	serverDetail.AddMaintainer(serverDetail.Publisher())
+	// So does the lifecycle status, which only changes through SetStatus
+	if existingEntry.Status != "" {
+		serverDetail.Status = existingEntry.Status
+	}
+	// And the repository stats, which only change through SetRepositoryStats
+	serverDetail.RepositoryStats = existingEntry.RepositoryStats
+	serverDetail.VersionDetail.ReleaseDate = time.Now().Format(time.RFC3339)
+	serverDetail.UpdatedAt = updateTime()
+	serverDetail.HasSchema = serverDetail.Schema != nil
+
+	// Store the schema first so a published server never lacks the schema it advertises.
+	// A schema left behind by a failed insert is keyed by an unused ID and never read.
+	if serverDetail.HasSchema {
+		compressed, err := compressSchema(*serverDetail.Schema)
+		if err != nil {
+			return err
+		}
+		if _, err := db.schemas.InsertOne(ctx, schemaDocument{ServerID: serverDetail.ID, Schema: compressed}); err != nil {
+			return fmt.Errorf("error inserting schema: %w", err)
+		}
+	}
+
+	// Insert the entry into the database
+	_, err = db.collection.InsertOne(ctx, serverDetail)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrAlreadyExists
+		}
+		return fmt.Errorf("error inserting entry: %w", err)
+	}
+
+	// update the existing versions to no longer be the latest version, which also releases any pinned version
+	if len(existingVersions) > 0 {
+		_, err = db.collection.UpdateMany(
+			ctx,
+			bson.M{"name": serverDetail.Name, "id": bson.M{"$ne": serverDetail.ID}},
+			bson.M{
+				"$set":   bson.M{"version_detail.is_latest": false, "updated_at": serverDetail.UpdatedAt},
+				"$unset": bson.M{"pinned_version": ""},
+			})
+		if err != nil {
+			return fmt.Errorf("error updating existing entry: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Update replaces the description, packages, version number and release date of an existing ServerDetail.
+// The versions of the server are read in a transaction so that a concurrent publish cannot reuse the version.
+func (db *MongoDB) Update(ctx context.Context, id string, detail *model.ServerDetail) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return db.WithTransaction(ctx, func(ctx context.Context) error {
+		var entry model.ServerDetail
+		if err := db.collection.FindOne(ctx, bson.M{"id": id}).Decode(&entry); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return ErrNotFound
+			}
+			return fmt.Errorf("error retrieving entry: %w", err)
+		}
+
+		if detail.VersionDetail.Version != entry.VersionDetail.Version {
+			cursor, err := db.slowQueries.Find(ctx, db.collection, bson.M{"name": entry.Name}, nil)
+			if err != nil {
+				return fmt.Errorf("error retrieving versions: %w", err)
+			}
+			var versions []*model.ServerDetail
+			if err := cursor.All(ctx, &versions); err != nil {
+				return fmt.Errorf("error decoding versions: %w", err)
+			}
+			if err := checkVersionChange(versions, &entry, detail.VersionDetail.Version); err != nil {
+				return err
+			}
+		}
+
+		packages := detail.Packages
+		if packages == nil {
+			packages = []model.Package{}
+		}
+		_, err := db.collection.UpdateOne(ctx, bson.M{"id": id}, bson.M{"$set": bson.M{
+			"description":                 detail.Description,
+			"packages":                    packages,
+			"version_detail.version":      detail.VersionDetail.Version,
+			"version_detail.release_date": detail.VersionDetail.ReleaseDate,
+			"updated_at":                  updateTime(),
+		}})
+		if err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return ErrAlreadyExists
+			}
+			return fmt.Errorf("error updating entry: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Delete removes a ServerDetail, making the highest remaining version the latest if it was the latest version.
+// Its schema is left for RemoveOrphanedSchemas.
+func (db *MongoDB) Delete(ctx context.Context, id string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return db.WithTransaction(ctx, func(ctx context.Context) error {
+		var entry model.ServerDetail
+		if err := db.collection.FindOneAndDelete(ctx, bson.M{"id": id}).Decode(&entry); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return ErrNotFound
+			}
+			return fmt.Errorf("error deleting entry: %w", err)
+		}
+		if !entry.VersionDetail.IsLatest {
+			return nil
+		}
+
+		cursor, err := db.slowQueries.Find(ctx, db.collection, bson.M{"name": entry.Name}, nil)
+		if err != nil {
+			return fmt.Errorf("error retrieving versions: %w", err)
+		}
+		var versions []*model.ServerDetail
+		if err := cursor.All(ctx, &versions); err != nil {
+			return fmt.Errorf("error decoding versions: %w", err)
+		}
+
+		if latest := latestUnyankedVersion(versions); latest != nil {
+			_, err := db.collection.UpdateOne(ctx, bson.M{"id": latest.ID},
+				bson.M{"$set": bson.M{"version_detail.is_latest": true, "updated_at": updateTime()}})
+			if err != nil {
+				return fmt.Errorf("error updating latest version: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// WithTransaction runs fn in a multi-document transaction, which requires a replica set. fn must use the
+// context it is given for its database calls to be part of the transaction; it may be run again if the
+// transaction fails with a transient error. Calls made within a transaction join it rather than starting
+// another one.
+func (db *MongoDB) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if mongo.SessionFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	session, err := db.client.StartSession()
+	if err != nil {
+		return fmt.Errorf("error starting session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
+
+// PinVersion marks the given version of a server as the latest version.
+// The updates run in a transaction so that exactly one version is marked as latest.
+func (db *MongoDB) PinVersion(ctx context.Context, id, version string) error {
+	return db.setLatestVersion(ctx, id, version, true)
+}
+
+// UpgradeVersion marks the given version of a server as the latest version without pinning it.
+// The updates run in a transaction so that exactly one version is marked as latest.
+func (db *MongoDB) UpgradeVersion(ctx context.Context, id, version string) error {
+	return db.setLatestVersion(ctx, id, version, false)
+}
+
+// setLatestVersion marks the given version of a server as the latest version, optionally pinning it,
+// and releases any existing pin
+func (db *MongoDB) setLatestVersion(ctx context.Context, id, version string, pin bool) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	var entry model.ServerDetail
+	if err := db.collection.FindOne(ctx, bson.M{"id": id}).Decode(&entry); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("error retrieving entry: %w", err)
+	}
+
+	updatedAt := updateTime()
+	return db.WithTransaction(ctx, func(ctx context.Context) error {
+		versionFilter := bson.M{"name": entry.Name, "version_detail.version": version}
+		var latest model.ServerDetail
+		if err := db.collection.FindOne(ctx, versionFilter).Decode(&latest); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return ErrNotFound
+			}
+			return fmt.Errorf("error retrieving version: %w", err)
+		}
+		if latest.VersionDetail.Yanked {
+			return ErrInvalidInput
+		}
+
+		_, err := db.collection.UpdateMany(ctx,
+			bson.M{"name": entry.Name},
+			bson.M{
+				"$set":   bson.M{"version_detail.is_latest": false, "updated_at": updatedAt},
+				"$unset": bson.M{"pinned_version": ""},
+			})
+		if err != nil {
+			return fmt.Errorf("error clearing latest version: %w", err)
+		}
+
+		set := bson.M{"version_detail.is_latest": true}
+		if pin {
+			set["pinned_version"] = true
+		}
+		_, err = db.collection.UpdateOne(ctx, versionFilter, bson.M{"$set": set})
+		if err != nil {
+			return fmt.Errorf("error marking latest version: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// YankVersion marks the given version of a server as yanked and moves the latest flag off it.
+// The updates run in a transaction so that a concurrent publish or pin cannot leave two latest versions.
+func (db *MongoDB) YankVersion(ctx context.Context, id, version string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	var entry model.ServerDetail
+	if err := db.collection.FindOne(ctx, bson.M{"id": id}).Decode(&entry); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("error retrieving entry: %w", err)
+	}
+
+	updatedAt := updateTime()
+	return db.WithTransaction(ctx, func(ctx context.Context) error {
+		cursor, err := db.slowQueries.Find(ctx, db.collection, bson.M{"name": entry.Name}, nil)
+		if err != nil {
+			return fmt.Errorf("error retrieving versions: %w", err)
+		}
+		var versions []*model.ServerDetail
+		if err := cursor.All(ctx, &versions); err != nil {
+			return fmt.Errorf("error decoding versions: %w", err)
+		}
+
+		var yanked *model.ServerDetail
+		for _, candidate := range versions {
+			if candidate.VersionDetail.Version == version {
+				yanked = candidate
+			}
+		}
+		if yanked == nil {
+			return ErrNotFound
+		}
+		if yanked.VersionDetail.Yanked {
+			return nil
+		}
+
+		update := bson.M{"$set": bson.M{"version_detail.yanked": true, "updated_at": updatedAt}}
+		wasLatest := yanked.VersionDetail.IsLatest
+		if wasLatest {
+			update = bson.M{
+				"$set":   bson.M{"version_detail.yanked": true, "version_detail.is_latest": false, "updated_at": updatedAt},
+				"$unset": bson.M{"pinned_version": ""},
+			}
+		}
+		if _, err := db.collection.UpdateOne(ctx, bson.M{"id": yanked.ID}, update); err != nil {
+			return fmt.Errorf("error yanking version: %w", err)
+		}
+		yanked.VersionDetail.Yanked = true
+
+		if latest := latestUnyankedVersion(versions); wasLatest && latest != nil {
+			_, err := db.collection.UpdateOne(ctx, bson.M{"id": latest.ID},
+				bson.M{"$set": bson.M{"version_detail.is_latest": true, "updated_at": updatedAt}})
+			if err != nil {
+				return fmt.Errorf("error updating latest version: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// TransferOwnership sets the publisher of all versions of a server to the given GitHub user.
+// The maintainers are read and rewritten in a transaction so that concurrent changes to them are not lost.
+func (db *MongoDB) TransferOwnership(ctx context.Context, id, newOwner string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return db.WithTransaction(ctx, func(ctx context.Context) error {
+		var entry model.ServerDetail
+		if err := db.collection.FindOne(ctx, bson.M{"id": id}).Decode(&entry); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return ErrNotFound
+			}
+			return fmt.Errorf("error retrieving entry: %w", err)
+		}
+
+		// The new owner takes the place of the previous owner among the maintainers
+		entry.RemoveMaintainer(entry.Publisher())
+		entry.AddMaintainer(newOwner)
+
+		_, err := db.collection.UpdateMany(ctx,
+			bson.M{"name": entry.Name},
+			bson.M{"$set": bson.M{
+				"publisher_username": newOwner,
+				"maintained_by":      entry.MaintainedBy,
+				"updated_at":         updateTime(),
+			}})
+		if err != nil {
+			return fmt.Errorf("error transferring ownership: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// SetMaintainers replaces the maintainers of all versions of a server
+func (db *MongoDB) SetMaintainers(ctx context.Context, id string, maintainers []string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	var entry model.ServerDetail
+	if err := db.collection.FindOne(ctx, bson.M{"id": id}).Decode(&entry); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("error retrieving entry: %w", err)
+	}
+
+	_, err := db.collection.UpdateMany(ctx,
+		bson.M{"name": entry.Name},
+		bson.M{"$set": bson.M{"maintained_by": maintainers, "updated_at": updateTime()}})
+	if err != nil {
+		return fmt.Errorf("error updating maintainers: %w", err)
+	}
+
+	return nil
+}
+
+// SetStatus sets the lifecycle status of all versions of a server
+func (db *MongoDB) SetStatus(ctx context.Context, id, status string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	var entry model.ServerDetail
+	if err := db.collection.FindOne(ctx, bson.M{"id": id}).Decode(&entry); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("error retrieving entry: %w", err)
+	}
+
+	_, err := db.collection.UpdateMany(ctx,
+		bson.M{"name": entry.Name},
+		bson.M{"$set": bson.M{"status": status, "updated_at": updateTime()}})
+	if err != nil {
+		return fmt.Errorf("error updating status: %w", err)
+	}
+
+	return nil
+}
+
+// SetRepositoryStats sets the repository stats of all versions of a server
+func (db *MongoDB) SetRepositoryStats(ctx context.Context, id string, stats model.RepositoryStats) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	var entry model.ServerDetail
+	if err := db.collection.FindOne(ctx, bson.M{"id": id}).Decode(&entry); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("error retrieving entry: %w", err)
+	}
+
+	_, err := db.collection.UpdateMany(ctx, bson.M{"name": entry.Name}, bson.M{"$set": bson.M{"repository_stats": stats}})
+	if err != nil {
+		return fmt.Errorf("error updating repository stats: %w", err)
+	}
+
+	return nil
+}
+
+// ReorderFeatured sets the display order of the featured servers to the order of the given IDs.
+// The check and the updates run in a transaction, so servers featured concurrently cannot be left out.
+func (db *MongoDB) ReorderFeatured(ctx context.Context, ids []string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	updatedAt := updateTime()
+	return db.WithTransaction(ctx, func(ctx context.Context) error {
+		mongoCursor, err := db.slowQueries.Find(ctx, db.collection,
+			bson.M{"featured_order": bson.M{"$exists": true}},
+			options.Find().SetProjection(bson.M{"id": 1}))
+		if err != nil {
+			return fmt.Errorf("error retrieving featured servers: %w", err)
+		}
+		var entries []struct {
+			ID string `bson:"id"`
+		}
+		if err := mongoCursor.All(ctx, &entries); err != nil {
+			return fmt.Errorf("error decoding featured servers: %w", err)
+		}
+
+		featured := make(map[string]bool, len(entries))
+		for _, entry := range entries {
+			featured[entry.ID] = true
+		}
+		if err := checkFeaturedOrder(ids, featured); err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		updates := make([]mongo.WriteModel, len(ids))
+		for position, id := range ids {
+			updates[position] = mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"id": id}).
+				SetUpdate(bson.M{"$set": bson.M{"featured_order": position, "updated_at": updatedAt}})
+		}
+		if _, err := db.collection.BulkWrite(ctx, updates); err != nil {
+			return fmt.Errorf("error reordering featured servers: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// AppendPackages adds packages to an existing ServerDetail. The package limit and duplicates are checked in the
+// update filter, so that concurrent appends cannot exceed the limit together.
+func (db *MongoDB) AppendPackages(ctx context.Context, id string, packages []model.Package, maxPackages int) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if maxPackages > 0 && len(packages) > maxPackages {
+		return ErrTooManyPackages
+	}
+
+	// Only match the document if none of the new packages are already registered
+	filter := bson.M{"id": id}
+	if maxPackages > 0 {
+		// The server has room for the new packages if it has no package at index maxPackages-len(packages)
+		filter["packages."+strconv.Itoa(maxPackages-len(packages))] = bson.M{"$exists": false}
+	}
+	var duplicates []bson.M
+	for _, pkg := range packages {
+		duplicates = append(duplicates, bson.M{
+			"packages": bson.M{"$elemMatch": bson.M{"registry_name": pkg.RegistryName, "name": pkg.Name}},
+		})
+	}
+	if len(duplicates) > 0 {
+		filter["$nor"] = duplicates
+	}
+
+	update := bson.M{
+		"$push": bson.M{"packages": bson.M{"$each": packages}},
+		"$set":  bson.M{"updated_at": updateTime()},
+	}
+
+	result, err := db.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("error appending packages: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		// Distinguish between a missing server, a server without room and a duplicate package
+		var existing model.ServerDetail
+		err := db.collection.FindOne(
+			ctx, bson.M{"id": id}, options.FindOne().SetProjection(bson.M{"packages": 1}),
+		).Decode(&existing)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ErrNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("error checking existing entry: %w", err)
+		}
+		if maxPackages > 0 && len(existing.Packages)+len(packages) > maxPackages {
+			return ErrTooManyPackages
+		}
+		return ErrAlreadyExists
+	}
+
+	return nil
+}
+
+// RemovePackage removes a package from an existing ServerDetail
+func (db *MongoDB) RemovePackage(ctx context.Context, id, registryName, packageName string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	match := bson.M{"registry_name": registryName, "name": packageName}
+
+	// Only match the document if it contains the package and at least one other package
 	filter := bson.M{
-		"name":                     serverDetail.Name,
-		"version_detail.is_latest": true,
+		"id":         id,
+		"packages":   bson.M{"$elemMatch": match},
+		"packages.1": bson.M{"$exists": true},
+	}
+	update := bson.M{
+		"$pull": bson.M{"packages": match},
+		"$set":  bson.M{"updated_at": updateTime()},
+	}
+
+	result, err := db.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("error removing package: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		// Distinguish between a missing server or package and removing the last package
+		count, err := db.collection.CountDocuments(ctx, bson.M{"id": id, "packages": bson.M{"$elemMatch": match}})
+		if err != nil {
+			return fmt.Errorf("error checking existing entry: %w", err)
+		}
+		if count == 0 {
+			return ErrNotFound
+		}
+		return ErrLastPackage
+	}
+
+	return nil
+}
+
+// AddTags adds tags to an existing ServerDetail, ignoring tags it already has
+func (db *MongoDB) AddTags(ctx context.Context, id string, tags []string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	update := bson.M{
+		"$addToSet": bson.M{"tags": bson.M{"$each": tags}},
+		"$set":      bson.M{"updated_at": updateTime()},
+	}
+
+	result, err := db.collection.UpdateOne(ctx, bson.M{"id": id}, update)
+	if err != nil {
+		return fmt.Errorf("error adding tags: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// RemoveTag removes a tag from an existing ServerDetail
+func (db *MongoDB) RemoveTag(ctx context.Context, id, tag string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	// Only match the document if it has the tag
+	filter := bson.M{"id": id, "tags": tag}
+	update := bson.M{
+		"$pull": bson.M{"tags": tag},
+		"$set":  bson.M{"updated_at": updateTime()},
+	}
+
+	result, err := db.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("error removing tag: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// SetPackageChecksum records the verified checksum of a package of an existing ServerDetail
+func (db *MongoDB) SetPackageChecksum(ctx context.Context, id, registryName, packageName, checksum string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	filter := bson.M{
+		"id":       id,
+		"packages": bson.M{"$elemMatch": bson.M{"registry_name": registryName, "name": packageName}},
+	}
+	update := bson.M{"$set": bson.M{"packages.$.verified_checksum": checksum, "updated_at": updateTime()}}
+
+	result, err := db.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("error setting package checksum: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// YankPackage marks the packages of an existing ServerDetail with the given registry name and version as yanked
+func (db *MongoDB) YankPackage(ctx context.Context, id, registryName, version, reason string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	match := bson.M{"registry_name": registryName, "version": version}
+	filter := bson.M{"id": id, "packages": bson.M{"$elemMatch": match}}
+	update := bson.M{"$set": bson.M{
+		"packages.$[pkg].yanked":      true,
+		"packages.$[pkg].yank_reason": reason,
+		"updated_at":                  updateTime(),
+	}}
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []any{bson.M{"pkg.registry_name": registryName, "pkg.version": version}},
+	})
+
+	result, err := db.collection.UpdateOne(ctx, filter, update, opts)
+	if err != nil {
+		return fmt.Errorf("error yanking package: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// SaveProvenance creates or replaces the provenance attestation of a server version
+func (db *MongoDB) SaveProvenance(ctx context.Context, provenance *model.Provenance) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	filter := bson.M{"server_id": provenance.ServerID, "version": provenance.Version}
+	opts := options.Replace().SetUpsert(true)
+	if _, err := db.provenances.ReplaceOne(ctx, filter, provenance, opts); err != nil {
+		return fmt.Errorf("error saving provenance: %w", err)
+	}
+
+	return nil
+}
+
+// GetProvenance retrieves the provenance attestation of a version of the named server,
+// or the most recently attested version if version is empty
+func (db *MongoDB) GetProvenance(ctx context.Context, serverName, version string) (*model.Provenance, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	filter := bson.M{"server_name": serverName}
+	if version != "" {
+		filter["version"] = version
+	}
+	opts := options.FindOne().SetSort(bson.D{bson.E{Key: "created_at", Value: -1}, bson.E{Key: "_id", Value: -1}})
+
+	var provenance model.Provenance
+	err := db.provenances.FindOne(ctx, filter, opts).Decode(&provenance)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("error retrieving provenance: %w", err)
+	}
+
+	return &provenance, nil
+}
+
+// GetSchema retrieves the JSON schema published with a server version
+func (db *MongoDB) GetSchema(ctx context.Context, id string) (json.RawMessage, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var document schemaDocument
+	err := db.schemas.FindOne(ctx, bson.M{"server_id": id}).Decode(&document)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("error retrieving schema: %w", err)
+	}
+
+	return decompressSchema(document.Schema)
+}
+
+// GetGitHubMetadata retrieves the GitHub repository information stored when a server version was published
+func (db *MongoDB) GetGitHubMetadata(ctx context.Context, id string) (json.RawMessage, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var document struct {
+		GitHubMetadata json.RawMessage `bson:"github_metadata"`
+	}
+	findOptions := options.FindOne().SetProjection(bson.M{"github_metadata": 1})
+	err := db.collection.FindOne(ctx, bson.M{"id": id}, findOptions).Decode(&document)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("error retrieving GitHub metadata: %w", err)
+	}
+	if len(document.GitHubMetadata) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return document.GitHubMetadata, nil
+}
+
+// compressSchema gzips a JSON schema for storage
+func compressSchema(schema json.RawMessage) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(schema); err != nil {
+		return nil, fmt.Errorf("error compressing schema: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("error compressing schema: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decompressSchema restores a JSON schema stored by compressSchema
+func decompressSchema(compressed []byte) (json.RawMessage, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing schema: %w", err)
+	}
+	defer reader.Close()
+
+	schema, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing schema: %w", err)
+	}
+	return schema, nil
+}
+
+// SaveReview adds a review of a server and updates the rating summary of the server.
+// The summary is recomputed from all reviews of the server so concurrent submissions cannot skew it.
+func (db *MongoDB) SaveReview(ctx context.Context, review *model.Review) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	count, err := db.collection.CountDocuments(ctx, bson.M{"id": review.ServerID})
+	if err != nil {
+		return fmt.Errorf("error checking server: %w", err)
+	}
+	if count == 0 {
+		return ErrNotFound
+	}
+
+	if _, err := db.reviews.InsertOne(ctx, review); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrAlreadyExists
+		}
+		return fmt.Errorf("error saving review: %w", err)
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"server_id": review.ServerID}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":     nil,
+			"average": bson.M{"$avg": "$rating"},
+			"count":   bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := db.reviews.Aggregate(ctx, pipeline)
+	if err != nil {
+		return fmt.Errorf("error computing rating summary: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Average float64 `bson:"average"`
+		Count   int     `bson:"count"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return fmt.Errorf("error decoding rating summary: %w", err)
+	}
+	if len(results) == 0 {
+		return nil
+	}
+
+	_, err = db.collection.UpdateOne(
+		ctx,
+		bson.M{"id": review.ServerID},
+		bson.M{"$set": bson.M{"average_rating": results[0].Average, "review_count": results[0].Count}},
+	)
+	if err != nil {
+		return fmt.Errorf("error updating rating summary: %w", err)
+	}
+
+	return nil
+}
+
+// ListReviews retrieves the reviews of a server, newest first
+func (db *MongoDB) ListReviews(
+	ctx context.Context, serverID string, cursor string, direction CursorDirection, limit int,
+) ([]*model.Review, PageCursors, error) {
+	if ctx.Err() != nil {
+		return nil, PageCursors{}, ctx.Err()
+	}
+
+	if limit <= 0 {
+		limit = 10
+	}
+
+	// The cursor is an offset since reviews are ordered by submission time rather than creation sequence
+	offset, count, err := offsetWindow(cursor, direction, limit)
+	if err != nil {
+		return nil, PageCursors{}, err
+	}
+	if count == 0 {
+		return []*model.Review{}, PageCursors{}, nil
+	}
+	findOptions := options.Find().
+		SetSort(bson.D{bson.E{Key: "created_at", Value: -1}, bson.E{Key: "_id", Value: -1}}).
+		SetSkip(offset).
+		SetLimit(int64(count))
+
+	mongoCursor, err := db.slowQueries.Find(ctx, db.reviews, bson.M{"server_id": serverID}, findOptions)
+	if err != nil {
+		return nil, PageCursors{}, fmt.Errorf("error listing reviews: %w", err)
+	}
+	defer mongoCursor.Close(ctx)
+
+	reviews := []*model.Review{}
+	if err := mongoCursor.All(ctx, &reviews); err != nil {
+		return nil, PageCursors{}, fmt.Errorf("error decoding reviews: %w", err)
+	}
+
+	return reviews, offsetCursors(offset, len(reviews), direction == CursorPrev || len(reviews) >= limit), nil
+}
+
+// SaveSubscription creates or updates a webhook subscription
+func (db *MongoDB) SaveSubscription(ctx context.Context, subscription *model.Subscription) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	opts := options.Replace().SetUpsert(true)
+	if _, err := db.subscriptions.ReplaceOne(ctx, bson.M{"id": subscription.ID}, subscription, opts); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrAlreadyExists
+		}
+		return fmt.Errorf("error saving subscription: %w", err)
+	}
+
+	return nil
+}
+
+// GetSubscription retrieves a webhook subscription by its ID
+func (db *MongoDB) GetSubscription(ctx context.Context, id string) (*model.Subscription, error) {
+	return db.findSubscription(ctx, bson.M{"id": id})
+}
+
+// FindSubscription retrieves the subscription of the named server with the given webhook URL
+func (db *MongoDB) FindSubscription(ctx context.Context, serverName, webhookURL string) (*model.Subscription, error) {
+	return db.findSubscription(ctx, bson.M{"server_name": serverName, "webhook_url": webhookURL})
+}
+
+// findSubscription retrieves the subscription matching filter
+func (db *MongoDB) findSubscription(ctx context.Context, filter bson.M) (*model.Subscription, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var subscription model.Subscription
+	err := db.subscriptions.FindOne(ctx, filter).Decode(&subscription)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("error retrieving subscription: %w", err)
+	}
+
+	return &subscription, nil
+}
+
+// ListSubscriptions retrieves all subscriptions of the named server, oldest first
+func (db *MongoDB) ListSubscriptions(ctx context.Context, serverName string) ([]*model.Subscription, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	findOptions := options.Find().SetSort(bson.D{bson.E{Key: "created_at", Value: 1}})
+	cursor, err := db.slowQueries.Find(ctx, db.subscriptions, bson.M{"server_name": serverName}, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("error listing subscriptions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	subscriptions := []*model.Subscription{}
+	if err := cursor.All(ctx, &subscriptions); err != nil {
+		return nil, fmt.Errorf("error decoding subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+// CountSubscriptions returns the number of subscriptions held by a GitHub user
+func (db *MongoDB) CountSubscriptions(ctx context.Context, githubUserID string) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	count, err := db.subscriptions.CountDocuments(ctx, bson.M{"github_user_id": githubUserID})
+	if err != nil {
+		return 0, fmt.Errorf("error counting subscriptions: %w", err)
+	}
+
+	return count, nil
+}
+
+// DeleteSubscription removes a webhook subscription
+func (db *MongoDB) DeleteSubscription(ctx context.Context, id string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.subscriptions.DeleteOne(ctx, bson.M{"id": id})
+	if err != nil {
+		return fmt.Errorf("error deleting subscription: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// SaveAPIKey stores a new API key
+func (db *MongoDB) SaveAPIKey(ctx context.Context, key *model.APIKey) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
 	}
 
-	var existingEntry model.ServerDetail
-	err := db.collection.FindOne(ctx, filter).Decode(&existingEntry)
-	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
-		return fmt.Errorf("error checking existing entry: %w", err)
+	if _, err := db.apiKeys.InsertOne(ctx, key); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrAlreadyExists
+		}
+		return fmt.Errorf("error saving API key: %w", err)
 	}
 
-	// check that the current version is greater than the existing one
-	if serverDetail.VersionDetail.Version <= existingEntry.VersionDetail.Version {
-		return fmt.Errorf("version must be greater than existing version")
-	}
+	return nil
+}
 
-	serverDetail.ID = uuid.New().String()
-	serverDetail.VersionDetail.IsLatest = true
-	serverDetail.VersionDetail.ReleaseDate = time.Now().Format(time.RFC3339)
+// GetAPIKey retrieves an API key by its ID
+func (db *MongoDB) GetAPIKey(ctx context.Context, id string) (*model.APIKey, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
 
-	// Insert the entry into the database
-	_, err = db.collection.InsertOne(ctx, serverDetail)
+	var key model.APIKey
+	err := db.apiKeys.FindOne(ctx, bson.M{"id": id}).Decode(&key)
 	if err != nil {
-		if mongo.IsDuplicateKeyError(err) {
-			return ErrAlreadyExists
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNotFound
 		}
-		return fmt.Errorf("error inserting entry: %w", err)
+		return nil, fmt.Errorf("error retrieving API key: %w", err)
 	}
 
-	// update the existing entry to not be the latest version
-	if existingEntry.ID != "" {
-		_, err = db.collection.UpdateOne(
-			ctx,
-			bson.M{"id": existingEntry.ID},
-			bson.M{"$set": bson.M{"versiondetail.islatest": false}})
-		if err != nil {
-			return fmt.Errorf("error updating existing entry: %w", err)
-		}
+	return &key, nil
+}
+
+// ListAPIKeys retrieves all API keys, oldest first
+func (db *MongoDB) ListAPIKeys(ctx context.Context) ([]*model.APIKey, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	findOptions := options.Find().SetSort(bson.D{bson.E{Key: "created_at", Value: 1}})
+	cursor, err := db.slowQueries.Find(ctx, db.apiKeys, bson.M{}, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("error listing API keys: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	keys := []*model.APIKey{}
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, fmt.Errorf("error decoding API keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// DeleteAPIKey removes an API key
+func (db *MongoDB) DeleteAPIKey(ctx context.Context, id string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.apiKeys.DeleteOne(ctx, bson.M{"id": id})
+	if err != nil {
+		return fmt.Errorf("error deleting API key: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return ErrNotFound
 	}
 
 	return nil
@@ -367,8 +1867,16 @@ func (db *MongoDB) ImportSeed(ctx context.Context, seedFilePath string) error {
 		// Create filter based on server ID
 		filter := bson.M{"id": server.ID}
 
-		// Create update document
+		// Create update document, assigning a creation sequence only to new documents
 		update := bson.M{"$set": server}
+		if server.CreatedSeq == 0 {
+			seq, err := db.GetNextSequence(ctx)
+			if err != nil {
+				log.Printf("Error generating sequence for server %s: %v", server.ID, err)
+				continue
+			}
+			update["$setOnInsert"] = bson.M{"created_seq": seq}
+		}
 
 		// Use upsert to create if not exists or update if exists
 		opts := options.Update().SetUpsert(true)
@@ -415,3 +1923,495 @@ func (db *MongoDB) Connection() *ConnectionInfo {
 		Raw:         db.client,
 	}
 }
+
+// CountDependents returns the number of server versions that list the server with the given ID as a dependency
+func (db *MongoDB) CountDependents(ctx context.Context, id string) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	count, err := db.collection.CountDocuments(ctx, bson.M{"dependencies": id})
+	if err != nil {
+		return 0, fmt.Errorf("error counting dependents: %w", err)
+	}
+
+	return count, nil
+}
+
+// ListRelated returns up to limit servers directly connected to the given server, sorted by name: the latest
+// versions of other servers with the same publisher, sharing a tag or depending on it, and its dependencies
+func (db *MongoDB) ListRelated(ctx context.Context, serverDetail *model.ServerDetail, limit int) ([]*model.ServerDetail, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	connected := bson.A{bson.M{"dependencies": serverDetail.ID}}
+	if len(serverDetail.Tags) > 0 {
+		connected = append(connected, bson.M{"tags": bson.M{"$in": serverDetail.Tags}})
+	}
+	if publisher := serverDetail.Publisher(); publisher != "" {
+		// Servers without a transferred owner belong to the owner of their io.github namespace
+		quoted := regexp.QuoteMeta(publisher)
+		connected = append(connected,
+			bson.M{"publisher_username": bson.M{"$regex": "^" + quoted + "$", "$options": "i"}},
+			bson.M{
+				"publisher_username": bson.M{"$in": bson.A{nil, ""}},
+				"name":               bson.M{"$regex": `^io\.github\.` + quoted + "/", "$options": "i"},
+			},
+		)
+	}
+
+	filter := bson.M{
+		"name": bson.M{"$ne": serverDetail.Name},
+		"$or": bson.A{
+			bson.M{"id": bson.M{"$in": append([]string{}, serverDetail.Dependencies...)}},
+			bson.M{"version_detail.is_latest": true, "$or": connected},
+		},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	cursor, err := db.slowQueries.Find(ctx, db.collection, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error listing related servers: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	result := []*model.ServerDetail{}
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, fmt.Errorf("error decoding related servers: %w", err)
+	}
+
+	return result, nil
+}
+
+// CountByCategory returns the number of servers in each category
+func (db *MongoDB) CountByCategory(ctx context.Context) (map[model.Category]int, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"category": bson.M{"$nin": bson.A{nil, ""}}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$category", "count": bson.M{"$sum": 1}}}},
+	}
+
+	cursor, err := db.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("error counting servers by category: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Category model.Category `bson:"_id"`
+		Count    int            `bson:"count"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("error decoding category counts: %w", err)
+	}
+
+	counts := make(map[model.Category]int, len(results))
+	for _, result := range results {
+		counts[result.Category] = result.Count
+	}
+
+	return counts, nil
+}
+
+// CountByLanguage returns the number of servers written in each language
+func (db *MongoDB) CountByLanguage(ctx context.Context) (map[string]int, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"language": bson.M{"$nin": bson.A{nil, ""}}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$language", "count": bson.M{"$sum": 1}}}},
+	}
+
+	cursor, err := db.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("error counting servers by language: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Language string `bson:"_id"`
+		Count    int    `bson:"count"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("error decoding language counts: %w", err)
+	}
+
+	counts := make(map[string]int, len(results))
+	for _, result := range results {
+		counts[result.Language] = result.Count
+	}
+
+	return counts, nil
+}
+
+// AggregateStats returns the server, package registry, recently published and publisher counts of the registry
+// in a single aggregation
+func (db *MongoDB) AggregateStats(ctx context.Context, now time.Time) (model.RegistryStats, error) {
+	latest := bson.D{{Key: "$match", Value: bson.M{"version_detail.is_latest": true}}}
+	publishedSince := func(age time.Duration) bson.M {
+		return bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$gte": bson.A{"$published_at", now.Add(-age)}}, 1, 0}}}
+	}
+	// The publisher is the user ownership was transferred to, or else the owner of the io.github namespace
+	publisher := bson.M{"$toLower": bson.M{"$cond": bson.A{
+		bson.M{"$gt": bson.A{"$publisher_username", ""}},
+		"$publisher_username",
+		bson.M{"$let": bson.M{
+			"vars": bson.M{"match": bson.M{"$regexFind": bson.M{"input": "$name", "regex": `^io\.github\.([^/]+)/`}}},
+			"in":   bson.M{"$arrayElemAt": bson.A{"$$match.captures", 0}},
+		}},
+	}}}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$facet", Value: bson.M{
+			"servers": bson.A{latest, bson.D{{Key: "$count", Value: "count"}}},
+			"registries": bson.A{
+				latest,
+				bson.D{{Key: "$project", Value: bson.M{"registry": bson.M{"$setUnion": bson.A{"$packages.registry_name"}}}}},
+				bson.D{{Key: "$unwind", Value: "$registry"}},
+				bson.D{{Key: "$group", Value: bson.M{"_id": "$registry", "count": bson.M{"$sum": 1}}}},
+			},
+			"published": bson.A{
+				bson.D{{Key: "$match", Value: bson.M{"published_at": bson.M{"$gte": now.Add(-30 * 24 * time.Hour)}}}},
+				bson.D{{Key: "$group", Value: bson.M{
+					"_id":      nil,
+					"last_24h": publishedSince(24 * time.Hour),
+					"last_7d":  publishedSince(7 * 24 * time.Hour),
+					"last_30d": bson.M{"$sum": 1},
+				}}},
+			},
+			"publishers": bson.A{
+				latest,
+				bson.D{{Key: "$group", Value: bson.M{"_id": publisher}}},
+				bson.D{{Key: "$match", Value: bson.M{"_id": bson.M{"$ne": ""}}}},
+				bson.D{{Key: "$count", Value: "count"}},
+			},
+		}}},
+	}
+
+	cursor, err := db.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return model.RegistryStats{}, fmt.Errorf("error aggregating stats: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	type count struct {
+		Count int `bson:"count"`
+	}
+	var results []struct {
+		Servers    []count `bson:"servers"`
+		Registries []struct {
+			RegistryName string `bson:"_id"`
+			Count        int    `bson:"count"`
+		} `bson:"registries"`
+		Published  []model.PublishedCounts `bson:"published"`
+		Publishers []count                 `bson:"publishers"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return model.RegistryStats{}, fmt.Errorf("error decoding stats: %w", err)
+	}
+
+	stats := model.RegistryStats{ServersByRegistry: make(map[string]int)}
+	if len(results) == 0 {
+		return stats, nil
+	}
+	result := results[0]
+	if len(result.Servers) > 0 {
+		stats.ServerCount = result.Servers[0].Count
+	}
+	for _, registry := range result.Registries {
+		stats.ServersByRegistry[registry.RegistryName] = registry.Count
+	}
+	if len(result.Published) > 0 {
+		stats.Published = result.Published[0]
+	}
+	if len(result.Publishers) > 0 {
+		stats.PublisherCount = result.Publishers[0].Count
+	}
+
+	return stats, nil
+}
+
+// CountVerifiedChecksums returns the number of servers whose latest version has at least one package
+// with a verified checksum
+func (db *MongoDB) CountVerifiedChecksums(ctx context.Context) (int, error) {
+	filter := bson.M{
+		"version_detail.is_latest": true,
+		"packages":                 bson.M{"$elemMatch": bson.M{"verified_checksum": bson.M{"$exists": true, "$ne": ""}}},
+	}
+
+	count, err := db.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("error counting verified checksums: %w", err)
+	}
+
+	return int(count), nil
+}
+
+// CountByTopic returns the number of servers tagged with each topic
+func (db *MongoDB) CountByTopic(ctx context.Context) (map[string]int, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$unwind", Value: "$topics"}},
+		{{Key: "$group", Value: bson.M{"_id": "$topics", "count": bson.M{"$sum": 1}}}},
+	}
+
+	cursor, err := db.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("error counting servers by topic: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Topic string `bson:"_id"`
+		Count int    `bson:"count"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("error decoding topic counts: %w", err)
+	}
+
+	counts := make(map[string]int, len(results))
+	for _, result := range results {
+		counts[result.Topic] = result.Count
+	}
+
+	return counts, nil
+}
+
+// CountByTag returns the number of servers whose latest version carries each tag
+func (db *MongoDB) CountByTag(ctx context.Context) (map[string]int, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"version_detail.is_latest": true}}},
+		{{Key: "$unwind", Value: "$tags"}},
+		{{Key: "$group", Value: bson.M{"_id": "$tags", "count": bson.M{"$sum": 1}}}},
+	}
+
+	cursor, err := db.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("error counting servers by tag: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Tag   string `bson:"_id"`
+		Count int    `bson:"count"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("error decoding tag counts: %w", err)
+	}
+
+	counts := make(map[string]int, len(results))
+	for _, result := range results {
+		counts[result.Tag] = result.Count
+	}
+
+	return counts, nil
+}
+
+// RebuildTextIndex drops the existing text index and recreates it from the current definition.
+// A collection can only have one text index, so text searches fail until the new index is created.
+func (db *MongoDB) RebuildTextIndex(ctx context.Context) (int64, error) {
+	cursor, err := db.collection.Indexes().List(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error listing indexes: %w", err)
+	}
+
+	var indexes []bson.M
+	if err := cursor.All(ctx, &indexes); err != nil {
+		return 0, fmt.Errorf("error decoding indexes: %w", err)
+	}
+
+	for _, index := range indexes {
+		// Text indexes are stored with an internal "_fts" key
+		keys, ok := index["key"].(bson.M)
+		if !ok {
+			continue
+		}
+		if _, isText := keys["_fts"]; !isText {
+			continue
+		}
+
+		name, _ := index["name"].(string)
+		if _, err := db.collection.Indexes().DropOne(ctx, name); err != nil {
+			return 0, fmt.Errorf("error dropping text index %s: %w", name, err)
+		}
+	}
+
+	if _, err := db.collection.Indexes().CreateOne(ctx, textIndexModel()); err != nil {
+		return 0, fmt.Errorf("error creating text index: %w", err)
+	}
+
+	count, err := db.collection.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return 0, fmt.Errorf("error counting indexed documents: %w", err)
+	}
+
+	return count, nil
+}
+
+// SaveReindexJob creates or updates a text index rebuild job
+func (db *MongoDB) SaveReindexJob(ctx context.Context, job *model.ReindexJob) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	opts := options.Replace().SetUpsert(true)
+	if _, err := db.reindexJobs.ReplaceOne(ctx, bson.M{"id": job.ID}, job, opts); err != nil {
+		return fmt.Errorf("error saving reindex job: %w", err)
+	}
+
+	return nil
+}
+
+// GetReindexJob retrieves a text index rebuild job by its ID
+func (db *MongoDB) GetReindexJob(ctx context.Context, id string) (*model.ReindexJob, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var job model.ReindexJob
+	err := db.reindexJobs.FindOne(ctx, bson.M{"id": id}).Decode(&job)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("error retrieving reindex job: %w", err)
+	}
+
+	return &job, nil
+}
+
+// RemoveOrphanedSchemas deletes stored schemas whose server version no longer exists.
+// Schemas are stored before their server document, so a failed publish can leave one behind.
+func (db *MongoDB) RemoveOrphanedSchemas(ctx context.Context) (int64, int64, error) {
+	if ctx.Err() != nil {
+		return 0, 0, ctx.Err()
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$lookup", Value: bson.M{
+			"from":         db.collection.Name(),
+			"localField":   "server_id",
+			"foreignField": "id",
+			"as":           "servers",
+		}}},
+		{{Key: "$match", Value: bson.M{"servers": bson.M{"$size": 0}}}},
+		{{Key: "$project", Value: bson.M{"server_id": 1, "size": bson.M{"$bsonSize": "$$ROOT"}}}},
+	}
+	cursor, err := db.schemas.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error finding orphaned schemas: %w", err)
+	}
+
+	var orphans []struct {
+		ServerID string `bson:"server_id"`
+		Size     int64  `bson:"size"`
+	}
+	if err := cursor.All(ctx, &orphans); err != nil {
+		return 0, 0, fmt.Errorf("error finding orphaned schemas: %w", err)
+	}
+	if len(orphans) == 0 {
+		return 0, 0, nil
+	}
+
+	serverIDs := make([]string, len(orphans))
+	var bytesReclaimed int64
+	for i, orphan := range orphans {
+		serverIDs[i] = orphan.ServerID
+		bytesReclaimed += orphan.Size
+	}
+
+	result, err := db.schemas.DeleteMany(ctx, bson.M{"server_id": bson.M{"$in": serverIDs}})
+	if err != nil {
+		return 0, 0, fmt.Errorf("error removing orphaned schemas: %w", err)
+	}
+
+	return result.DeletedCount, bytesReclaimed, nil
+}
+
+// RemoveReindexJobs deletes the reindex jobs that completed before the given time
+func (db *MongoDB) RemoveReindexJobs(ctx context.Context, completedBefore time.Time) (int64, int64, error) {
+	if ctx.Err() != nil {
+		return 0, 0, ctx.Err()
+	}
+
+	filter := bson.M{"completed_at": bson.M{"$lt": completedBefore}}
+	cursor, err := db.slowQueries.Find(ctx, db.reindexJobs, filter, nil)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error finding reindex jobs: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var jobIDs []string
+	var bytesReclaimed int64
+	for cursor.Next(ctx) {
+		jobIDs = append(jobIDs, cursor.Current.Lookup("id").StringValue())
+		bytesReclaimed += int64(len(cursor.Current))
+	}
+	if err := cursor.Err(); err != nil {
+		return 0, 0, fmt.Errorf("error finding reindex jobs: %w", err)
+	}
+	if len(jobIDs) == 0 {
+		return 0, 0, nil
+	}
+
+	result, err := db.reindexJobs.DeleteMany(ctx, bson.M{"id": bson.M{"$in": jobIDs}})
+	if err != nil {
+		return 0, 0, fmt.Errorf("error removing reindex jobs: %w", err)
+	}
+
+	return result.DeletedCount, bytesReclaimed, nil
+}
+
+// Compact runs the compact command on the server collection and the collections vacuuming removes
+// documents from, returning the total number of bytes freed
+func (db *MongoDB) Compact(ctx context.Context) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	var bytesFreed int64
+	for _, collection := range []*mongo.Collection{db.collection, db.schemas, db.reindexJobs} {
+		var result struct {
+			BytesFreed int64 `bson:"bytesFreed"`
+		}
+		err := db.database.RunCommand(ctx, bson.D{{Key: "compact", Value: collection.Name()}}).Decode(&result)
+		if err != nil {
+			return bytesFreed, fmt.Errorf("error compacting collection %s: %w", collection.Name(), err)
+		}
+		bytesFreed += result.BytesFreed
+	}
+
+	return bytesFreed, nil
+}
+
+// SaveVacuumJob creates or updates a vacuum job
+func (db *MongoDB) SaveVacuumJob(ctx context.Context, job *model.VacuumJob) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	opts := options.Replace().SetUpsert(true)
+	if _, err := db.vacuumJobs.ReplaceOne(ctx, bson.M{"id": job.ID}, job, opts); err != nil {
+		return fmt.Errorf("error saving vacuum job: %w", err)
+	}
+
+	return nil
+}
+
+// GetVacuumJob retrieves a vacuum job by its ID
+func (db *MongoDB) GetVacuumJob(ctx context.Context, id string) (*model.VacuumJob, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var job model.VacuumJob
+	err := db.vacuumJobs.FindOne(ctx, bson.M{"id": id}).Decode(&job)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("error retrieving vacuum job: %w", err)
+	}
+
+	return &job, nil
+}
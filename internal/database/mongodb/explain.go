@@ -0,0 +1,134 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// explainTimeout bounds explaining a slow query, independently of the deadline of the query itself
+const explainTimeout = 5 * time.Second
+
+// ExplainQuery returns the plan MongoDB chooses for a find on the collection as extended JSON.
+// The query planner runs without executing the query.
+func ExplainQuery(ctx context.Context, coll *mongo.Collection, filter bson.D, opts *options.FindOptions) (string, error) {
+	if filter == nil {
+		filter = bson.D{}
+	}
+	find := bson.D{{Key: "find", Value: coll.Name()}, {Key: "filter", Value: filter}}
+	if opts != nil {
+		if opts.Sort != nil {
+			find = append(find, bson.E{Key: "sort", Value: opts.Sort})
+		}
+		if opts.Projection != nil {
+			find = append(find, bson.E{Key: "projection", Value: opts.Projection})
+		}
+		if opts.Hint != nil {
+			find = append(find, bson.E{Key: "hint", Value: opts.Hint})
+		}
+		if opts.Skip != nil {
+			find = append(find, bson.E{Key: "skip", Value: *opts.Skip})
+		}
+		if opts.Limit != nil {
+			find = append(find, bson.E{Key: "limit", Value: *opts.Limit})
+		}
+	}
+
+	var result bson.M
+	command := bson.D{{Key: "explain", Value: find}, {Key: "verbosity", Value: "queryPlanner"}}
+	if err := coll.Database().RunCommand(ctx, command).Decode(&result); err != nil {
+		return "", err
+	}
+
+	// Sharded clusters report the plans of their shards elsewhere, so fall back to the whole result
+	plan, ok := result["queryPlanner"]
+	if !ok {
+		plan = result
+	}
+	data, err := bson.MarshalExtJSON(plan, false, false)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Collection is the part of a *mongo.Collection SlowQueryLog uses
+type Collection interface {
+	Name() string
+	Find(ctx context.Context, filter any, opts ...*options.FindOptions) (*mongo.Cursor, error)
+}
+
+// SlowQueryLog times finds and logs the plans of those slower than a threshold.
+// Explaining a query costs another round trip, so it is meant for development.
+type SlowQueryLog struct {
+	// Threshold is the duration from which finds are explained
+	Threshold time.Duration
+	// Explain returns the plan of a find; finds on MongoDB collections are explained with ExplainQuery if it is nil
+	Explain func(ctx context.Context, coll Collection, filter bson.D, opts *options.FindOptions) (string, error)
+}
+
+// explainCollection explains finds on MongoDB collections
+func explainCollection(ctx context.Context, coll Collection, filter bson.D, opts *options.FindOptions) (string, error) {
+	mongoColl, ok := coll.(*mongo.Collection)
+	if !ok {
+		return "", fmt.Errorf("cannot explain finds on %T", coll)
+	}
+	return ExplainQuery(ctx, mongoColl, filter, opts)
+}
+
+// Find runs a find on the collection and logs its plan if it took longer than the threshold.
+// A nil log only runs the find.
+func (l *SlowQueryLog) Find(ctx context.Context, coll Collection, filter any, opts *options.FindOptions) (*mongo.Cursor, error) {
+	start := time.Now()
+	cursor, err := coll.Find(ctx, filter, opts)
+	elapsed := time.Since(start)
+	if l == nil || elapsed < l.Threshold {
+		return cursor, err
+	}
+
+	document, convErr := toDocument(filter)
+	if convErr != nil {
+		log.Printf("DEBUG: Slow find on %s took %s; cannot explain filter: %v", coll.Name(), elapsed, convErr)
+		return cursor, err
+	}
+
+	// The query may have used up its deadline, which must not prevent explaining it
+	explainCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), explainTimeout)
+	defer cancel()
+	explain := l.Explain
+	if explain == nil {
+		explain = explainCollection
+	}
+	plan, explainErr := explain(explainCtx, coll, document, opts)
+	if explainErr != nil {
+		log.Printf("DEBUG: Slow find on %s took %s; failed to explain it: %v", coll.Name(), elapsed, explainErr)
+		return cursor, err
+	}
+	log.Printf("DEBUG: Slow find on %s took %s; plan: %s", coll.Name(), elapsed, plan)
+	return cursor, err
+}
+
+// toDocument converts a filter such as a bson.M to an ordered document
+func toDocument(filter any) (bson.D, error) {
+	switch f := filter.(type) {
+	case nil:
+		return bson.D{}, nil
+	case bson.D:
+		return f, nil
+	}
+
+	data, err := bson.Marshal(filter)
+	if err != nil {
+		return nil, err
+	}
+	var document bson.D
+	if err := bson.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
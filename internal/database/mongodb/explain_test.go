@@ -0,0 +1,110 @@
+package mongodb_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database/mongodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// slowCollection is a collection whose finds take a fixed time and return no cursor
+type slowCollection struct {
+	delay time.Duration
+	finds int
+}
+
+func (c *slowCollection) Name() string {
+	return "servers_v2"
+}
+
+func (c *slowCollection) Find(_ context.Context, _ any, _ ...*options.FindOptions) (*mongo.Cursor, error) {
+	c.finds++
+	time.Sleep(c.delay)
+	return nil, nil
+}
+
+// captureLog redirects the standard logger to a buffer for the duration of the test
+func captureLog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+	return &buf
+}
+
+func TestSlowQueryLog(t *testing.T) {
+	ctx := context.Background()
+	filter := bson.M{"name": "io.github.example/slow"}
+	opts := options.Find().SetLimit(10)
+
+	t.Run("logs the plan of slow finds", func(t *testing.T) {
+		logs := captureLog(t)
+		var explained bson.D
+		slowQueries := &mongodb.SlowQueryLog{
+			Threshold: 10 * time.Millisecond,
+			Explain: func(_ context.Context, coll mongodb.Collection, filter bson.D, opts *options.FindOptions) (string, error) {
+				assert.Equal(t, "servers_v2", coll.Name())
+				assert.Equal(t, int64(10), *opts.Limit)
+				explained = filter
+				return `{"winningPlan":{"stage":"COLLSCAN"}}`, nil
+			},
+		}
+
+		coll := &slowCollection{delay: 20 * time.Millisecond}
+		_, err := slowQueries.Find(ctx, coll, filter, opts)
+		require.NoError(t, err)
+		assert.Equal(t, 1, coll.finds)
+		assert.Equal(t, bson.D{{Key: "name", Value: "io.github.example/slow"}}, explained)
+		assert.Contains(t, logs.String(), "DEBUG: Slow find on servers_v2")
+		assert.Contains(t, logs.String(), `{"winningPlan":{"stage":"COLLSCAN"}}`)
+	})
+
+	t.Run("fast finds are not explained", func(t *testing.T) {
+		logs := captureLog(t)
+		slowQueries := &mongodb.SlowQueryLog{
+			Threshold: time.Second,
+			Explain: func(context.Context, mongodb.Collection, bson.D, *options.FindOptions) (string, error) {
+				t.Error("fast find was explained")
+				return "", nil
+			},
+		}
+
+		_, err := slowQueries.Find(ctx, &slowCollection{}, filter, opts)
+		require.NoError(t, err)
+		assert.Empty(t, logs.String())
+	})
+
+	t.Run("explain failures are logged", func(t *testing.T) {
+		logs := captureLog(t)
+		slowQueries := &mongodb.SlowQueryLog{
+			Explain: func(context.Context, mongodb.Collection, bson.D, *options.FindOptions) (string, error) {
+				return "", errors.New("explain not allowed")
+			},
+		}
+
+		_, err := slowQueries.Find(ctx, &slowCollection{}, filter, nil)
+		require.NoError(t, err)
+		assert.Contains(t, logs.String(), "failed to explain it: explain not allowed")
+	})
+
+	t.Run("a nil log only runs the find", func(t *testing.T) {
+		logs := captureLog(t)
+		var slowQueries *mongodb.SlowQueryLog
+
+		coll := &slowCollection{delay: time.Millisecond}
+		_, err := slowQueries.Find(ctx, coll, filter, opts)
+		require.NoError(t, err)
+		assert.Equal(t, 1, coll.finds)
+		assert.Empty(t, logs.String())
+	})
+}
@@ -0,0 +1,906 @@
+//go:build integration
+
+package mongodb_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/database/mongodb"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	tcmongodb "github.com/testcontainers/testcontainers-go/modules/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// defaultMongoImages are the MongoDB versions the integration tests run against.
+// MCP_REGISTRY_TEST_MONGODB_IMAGES overrides them with a comma-separated list of images.
+var defaultMongoImages = []string{"mongo:6.0", "mongo:7.0"}
+
+// mongoImages returns the container images to run the integration tests against
+func mongoImages() []string {
+	value := os.Getenv("MCP_REGISTRY_TEST_MONGODB_IMAGES")
+	if value == "" {
+		return defaultMongoImages
+	}
+
+	var images []string
+	for _, image := range strings.Split(value, ",") {
+		if image = strings.TrimSpace(image); image != "" {
+			images = append(images, image)
+		}
+	}
+	return images
+}
+
+// newTestDB connects to the MongoDB instance at connectionURI using a fresh database,
+// so each test starts with empty collections and indexes
+func newTestDB(t *testing.T, connectionURI string) *database.MongoDB {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	databaseName := "registry_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+	db, err := database.NewMongoDB(ctx, connectionURI, databaseName, "servers_v2")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, db.Close())
+	})
+	return db
+}
+
+// newServerDetail returns a publishable server with the given name and version
+func newServerDetail(name, version string) *model.ServerDetail {
+	return &model.ServerDetail{
+		Server: model.Server{
+			Name:          name,
+			Description:   "Integration test server " + name,
+			Repository:    model.Repository{URL: "https://github.com/example/" + name, Source: "github"},
+			VersionDetail: model.VersionDetail{Version: version},
+		},
+		Packages: []model.Package{{RegistryName: "npm", Name: "@example/" + name, Version: version}},
+	}
+}
+
+func TestMongoDBIntegration(t *testing.T) {
+	for _, image := range mongoImages() {
+		t.Run(image, func(t *testing.T) {
+			ctx := context.Background()
+			// Transactions need a replica set
+			container, err := tcmongodb.Run(ctx, image, tcmongodb.WithReplicaSet("rs0"))
+			testcontainers.CleanupContainer(t, container)
+			require.NoError(t, err)
+
+			connectionURI, err := container.ConnectionString(ctx)
+			require.NoError(t, err)
+
+			t.Run("publish and get by ID round-trip", func(t *testing.T) {
+				testPublishRoundTrip(t, newTestDB(t, connectionURI))
+			})
+			t.Run("text search uses the text index", func(t *testing.T) {
+				testTextSearch(t, newTestDB(t, connectionURI))
+			})
+			t.Run("cursor pagination across pages", func(t *testing.T) {
+				testCursorPagination(t, newTestDB(t, connectionURI))
+			})
+			t.Run("regex fallback for partial words", func(t *testing.T) {
+				testRegexFallback(t, newTestDB(t, connectionURI))
+			})
+			t.Run("duplicate detection", func(t *testing.T) {
+				testDuplicateDetection(t, newTestDB(t, connectionURI))
+			})
+			t.Run("compressed schema storage", func(t *testing.T) {
+				testSchemaStorage(t, newTestDB(t, connectionURI))
+			})
+			t.Run("vacuum removes finished jobs and compacts", func(t *testing.T) {
+				testVacuum(t, newTestDB(t, connectionURI))
+			})
+			t.Run("subscriptions are unique per server and webhook", func(t *testing.T) {
+				testSubscriptions(t, newTestDB(t, connectionURI))
+			})
+			t.Run("count dependents", func(t *testing.T) {
+				testCountDependents(t, newTestDB(t, connectionURI))
+			})
+			t.Run("reorder featured servers", func(t *testing.T) {
+				testReorderFeatured(t, newTestDB(t, connectionURI))
+			})
+			t.Run("failed transaction rolls back every change", func(t *testing.T) {
+				testTransactionRollback(t, newTestDB(t, connectionURI))
+			})
+			t.Run("add and remove tags", func(t *testing.T) {
+				testTags(t, newTestDB(t, connectionURI))
+			})
+			t.Run("verified checksum filter", func(t *testing.T) {
+				testVerifiedChecksums(t, newTestDB(t, connectionURI))
+			})
+			t.Run("aggregate stats", func(t *testing.T) {
+				testAggregateStats(t, newTestDB(t, connectionURI))
+			})
+			t.Run("yank package", func(t *testing.T) {
+				testYankPackage(t, newTestDB(t, connectionURI))
+			})
+			t.Run("list related", func(t *testing.T) {
+				testListRelated(t, newTestDB(t, connectionURI))
+			})
+			t.Run("update", func(t *testing.T) {
+				testUpdate(t, newTestDB(t, connectionURI))
+			})
+			t.Run("delete", func(t *testing.T) {
+				testDelete(t, newTestDB(t, connectionURI))
+			})
+			t.Run("append packages limit", func(t *testing.T) {
+				testAppendPackagesLimit(t, newTestDB(t, connectionURI))
+			})
+			t.Run("publish after pinning", func(t *testing.T) {
+				testPublishAfterPin(t, newTestDB(t, connectionURI))
+			})
+			t.Run("list versions", func(t *testing.T) {
+				testListVersions(t, newTestDB(t, connectionURI))
+			})
+			t.Run("list updated after", func(t *testing.T) {
+				testListUpdatedAfter(t, newTestDB(t, connectionURI))
+			})
+			t.Run("explain query", func(t *testing.T) {
+				testExplainQuery(t, connectionURI)
+			})
+		})
+	}
+}
+
+func testPublishRoundTrip(t *testing.T, db *database.MongoDB) {
+	ctx := context.Background()
+	serverDetail := newServerDetail("io.github.example/round-trip", "1.0.0")
+	serverDetail.Remotes = []model.Remote{{TransportType: "sse", URL: "https://example.com/sse"}}
+	serverDetail.GitHubMetadata = []byte(`{"id": 42, "full_name": "example/round-trip"}`)
+	require.NoError(t, db.Publish(ctx, serverDetail))
+	require.NotEmpty(t, serverDetail.ID)
+
+	metadata, err := db.GetGitHubMetadata(ctx, serverDetail.ID)
+	require.NoError(t, err)
+	assert.Equal(t, serverDetail.GitHubMetadata, metadata)
+
+	stored, err := db.GetByID(ctx, serverDetail.ID)
+	require.NoError(t, err)
+	assert.Equal(t, serverDetail.Name, stored.Name)
+	assert.Equal(t, serverDetail.Description, stored.Description)
+	assert.Equal(t, serverDetail.Repository, stored.Repository)
+	assert.Equal(t, "1.0.0", stored.VersionDetail.Version)
+	assert.True(t, stored.VersionDetail.IsLatest)
+	assert.Equal(t, serverDetail.Packages, stored.Packages)
+	assert.Equal(t, serverDetail.Remotes, stored.Remotes)
+	assert.Equal(t, serverDetail.CreatedSeq, stored.CreatedSeq)
+	assert.WithinDuration(t, serverDetail.UpdatedAt, stored.UpdatedAt, time.Millisecond)
+
+	// Publishing a new version keeps both versions and moves the latest flag
+	newVersion := newServerDetail("io.github.example/round-trip", "1.1.0")
+	require.NoError(t, db.Publish(ctx, newVersion))
+
+	stored, err = db.GetByID(ctx, serverDetail.ID)
+	require.NoError(t, err)
+	assert.False(t, stored.VersionDetail.IsLatest)
+
+	stored, err = db.GetByID(ctx, newVersion.ID)
+	require.NoError(t, err)
+	assert.True(t, stored.VersionDetail.IsLatest)
+
+	_, err = db.GetByID(ctx, uuid.New().String())
+	assert.ErrorIs(t, err, database.ErrNotFound)
+
+	// Versions not published through publish-oss have no GitHub metadata
+	_, err = db.GetGitHubMetadata(ctx, newVersion.ID)
+	assert.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func testTextSearch(t *testing.T, db *database.MongoDB) {
+	ctx := context.Background()
+	for _, name := range []string{"io.github.example/filesystem", "io.github.example/weather", "io.github.example/git-tools"} {
+		require.NoError(t, db.Publish(ctx, newServerDetail(name, "1.0.0")))
+	}
+
+	entries, _, err := db.ListDetails(
+		ctx, bson.D{{Key: "$text", Value: bson.M{"$search": "weather"}}}, database.SortByCreation, "", database.CursorNext, 10,
+	)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "io.github.example/weather", entries[0].Name)
+
+	registry := service.NewRegistryServiceWithDB(db)
+	servers, _, err := registry.SearchDetails(service.SearchOptions{
+		Query:     "filesystem",
+		Direction: database.CursorNext,
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+	assert.Equal(t, "io.github.example/filesystem", servers[0].Name)
+
+	// The language is part of the text index
+	rustServer := newServerDetail("io.github.example/fast-server", "1.0.0")
+	rustServer.Language = "rust"
+	require.NoError(t, db.Publish(ctx, rustServer))
+	servers, _, err = registry.SearchDetails(service.SearchOptions{
+		Query:     "rust",
+		Direction: database.CursorNext,
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+	assert.Equal(t, "io.github.example/fast-server", servers[0].Name)
+
+	languages, err := registry.ListLanguages(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []model.LanguageCount{{Language: "rust", Count: 1}}, languages)
+
+	// So are the topics
+	taggedServer := newServerDetail("io.github.example/tagged-server", "1.0.0")
+	taggedServer.Topics = []string{"mcp", "observability"}
+	require.NoError(t, db.Publish(ctx, taggedServer))
+	servers, _, err = registry.SearchDetails(service.SearchOptions{
+		Query:     "observability",
+		Direction: database.CursorNext,
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+	assert.Equal(t, "io.github.example/tagged-server", servers[0].Name)
+
+	taggedServers, _, err := registry.Search(service.SearchOptions{
+		Topic:     "mcp",
+		Direction: database.CursorNext,
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, taggedServers, 1)
+	assert.Equal(t, "io.github.example/tagged-server", taggedServers[0].Name)
+
+	topics, err := registry.ListTopics(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []model.TopicCount{{Topic: "mcp", Count: 1}, {Topic: "observability", Count: 1}}, topics)
+}
+
+func testCursorPagination(t *testing.T, db *database.MongoDB) {
+	ctx := context.Background()
+	var expected []string
+	for _, name := range []string{"alpha", "bravo", "charlie", "delta", "echo"} {
+		serverDetail := newServerDetail("io.github.example/"+name, "1.0.0")
+		require.NoError(t, db.Publish(ctx, serverDetail))
+		expected = append(expected, serverDetail.Name)
+	}
+
+	pageNames := func(entries []*model.ServerDetail) []string {
+		names := make([]string, len(entries))
+		for i, entry := range entries {
+			names[i] = entry.Name
+		}
+		return names
+	}
+
+	var pages [][]string
+	var cursors database.PageCursors
+	cursor := ""
+	for {
+		entries, pageCursors, err := db.ListDetails(ctx, bson.D{}, database.SortByCreation, cursor, database.CursorNext, 2)
+		require.NoError(t, err)
+		if len(pages) == 0 {
+			assert.Empty(t, pageCursors.Prev)
+		}
+		pages = append(pages, pageNames(entries))
+		cursors = pageCursors
+		if pageCursors.Next == "" {
+			break
+		}
+		require.Less(t, len(pages), 10, "pagination did not terminate")
+		cursor = pageCursors.Next
+	}
+
+	require.Len(t, pages, 3)
+	assert.Equal(t, expected, slices.Concat(pages...))
+
+	// Paging backwards from the last page returns the same pages
+	for i := len(pages) - 2; i >= 0; i-- {
+		require.NotEmpty(t, cursors.Prev)
+		entries, pageCursors, err := db.ListDetails(ctx, bson.D{}, database.SortByCreation, cursors.Prev, database.CursorPrev, 2)
+		require.NoError(t, err)
+		assert.Equal(t, pages[i], pageNames(entries))
+		assert.NotEmpty(t, pageCursors.Next)
+		cursors = pageCursors
+	}
+
+	// A server published mid-traversal is appended after the existing entries
+	entries, cursors, err := db.ListDetails(ctx, bson.D{}, database.SortByCreation, "", database.CursorNext, 4)
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+	require.NoError(t, db.Publish(ctx, newServerDetail("io.github.example/aardvark", "1.0.0")))
+
+	entries, _, err = db.ListDetails(ctx, bson.D{}, database.SortByCreation, cursors.Next, database.CursorNext, 4)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "io.github.example/echo", entries[0].Name)
+	assert.Equal(t, "io.github.example/aardvark", entries[1].Name)
+}
+
+func testRegexFallback(t *testing.T, db *database.MongoDB) {
+	ctx := context.Background()
+	require.NoError(t, db.Publish(ctx, newServerDetail("io.github.example/filesystem-server", "1.0.0")))
+	require.NoError(t, db.Publish(ctx, newServerDetail("io.github.example/weather", "1.0.0")))
+
+	// A partial word does not match the text index on its own
+	entries, _, err := db.ListDetails(
+		ctx, bson.D{{Key: "$text", Value: bson.M{"$search": "filesys"}}}, database.SortByCreation, "", database.CursorNext, 10,
+	)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	// The registry service falls back to a case-insensitive regex search
+	registry := service.NewRegistryServiceWithDB(db)
+	servers, _, err := registry.SearchDetails(service.SearchOptions{
+		Query:     "FileSys",
+		Direction: database.CursorNext,
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+	assert.Equal(t, "io.github.example/filesystem-server", servers[0].Name)
+
+	// Regex metacharacters in the query are matched literally
+	servers, _, err = registry.SearchDetails(service.SearchOptions{
+		Query:     "files.*",
+		Direction: database.CursorNext,
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, servers)
+}
+
+func testDuplicateDetection(t *testing.T, db *database.MongoDB) {
+	ctx := context.Background()
+	require.NoError(t, db.Publish(ctx, newServerDetail("io.github.example/duplicate", "1.0.0")))
+
+	err := db.Publish(ctx, newServerDetail("io.github.example/duplicate", "1.0.0"))
+	assert.ErrorIs(t, err, database.ErrAlreadyExists)
+
+	entries, _, err := db.ListDetails(
+		ctx, bson.D{{Key: "name", Value: "io.github.example/duplicate"}}, database.SortByCreation, "", database.CursorNext, 10,
+	)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	// Versions are compared numerically rather than as strings
+	require.NoError(t, db.Publish(ctx, newServerDetail("io.github.example/duplicate", "1.9.0")))
+	require.NoError(t, db.Publish(ctx, newServerDetail("io.github.example/duplicate", "1.10.0")))
+	err = db.Publish(ctx, newServerDetail("io.github.example/duplicate", "1.2.0"))
+	assert.ErrorIs(t, err, database.ErrInvalidVersion)
+}
+
+func testAppendPackagesLimit(t *testing.T, db *database.MongoDB) {
+	const maxPackages = 5
+
+	ctx := context.Background()
+	serverDetail := newServerDetail("io.github.example/limited", "1.0.0")
+	require.NoError(t, db.Publish(ctx, serverDetail))
+
+	// Every append fits on its own, but only some of them fit together
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = db.AppendPackages(ctx, serverDetail.ID, []model.Package{{
+				RegistryName: "pypi", Name: fmt.Sprintf("limited-%d", i), Version: "1.0.0",
+			}}, maxPackages)
+		}()
+	}
+	wg.Wait()
+
+	appended := 0
+	for _, err := range errs {
+		if err == nil {
+			appended++
+			continue
+		}
+		require.ErrorIs(t, err, database.ErrTooManyPackages)
+	}
+	assert.Equal(t, maxPackages-1, appended)
+
+	stored, err := db.GetByID(ctx, serverDetail.ID)
+	require.NoError(t, err)
+	assert.Len(t, stored.Packages, maxPackages)
+
+	// Duplicates are still told apart from a full server
+	err = db.AppendPackages(ctx, serverDetail.ID, stored.Packages[:1], maxPackages+1)
+	require.ErrorIs(t, err, database.ErrAlreadyExists)
+	err = db.AppendPackages(ctx, serverDetail.ID, []model.Package{{RegistryName: "docker", Name: "limited"}}, maxPackages)
+	require.ErrorIs(t, err, database.ErrTooManyPackages)
+}
+
+func testPublishAfterPin(t *testing.T, db *database.MongoDB) {
+	ctx := context.Background()
+	first := newServerDetail("io.github.example/pinned", "1.0.0")
+	require.NoError(t, db.Publish(ctx, first))
+	require.NoError(t, db.Publish(ctx, newServerDetail("io.github.example/pinned", "2.0.0")))
+	require.NoError(t, db.PinVersion(ctx, first.ID, "1.0.0"))
+
+	// Versions between the pinned and the highest version are still older than the highest one
+	err := db.Publish(ctx, newServerDetail("io.github.example/pinned", "1.5.0"))
+	require.ErrorIs(t, err, database.ErrInvalidVersion)
+	err = db.Publish(ctx, newServerDetail("io.github.example/pinned", "2.0.0"))
+	require.ErrorIs(t, err, database.ErrAlreadyExists)
+
+	// A higher version becomes the latest and releases the pin
+	third := newServerDetail("io.github.example/pinned", "3.0.0")
+	require.NoError(t, db.Publish(ctx, third))
+	stored, err := db.GetByID(ctx, third.ID)
+	require.NoError(t, err)
+	assert.True(t, stored.VersionDetail.IsLatest)
+	stored, err = db.GetByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.False(t, stored.VersionDetail.IsLatest)
+	assert.False(t, stored.PinnedVersion)
+}
+
+func testCountDependents(t *testing.T, db *database.MongoDB) {
+	ctx := context.Background()
+	target := newServerDetail("io.github.example/target", "1.0.0")
+	require.NoError(t, db.Publish(ctx, target))
+
+	for _, name := range []string{"first", "second", "third"} {
+		dependent := newServerDetail("io.github.example/"+name, "1.0.0")
+		dependent.Dependencies = []string{target.ID}
+		require.NoError(t, db.Publish(ctx, dependent))
+	}
+
+	count, err := db.CountDependents(ctx, target.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+}
+
+func testTransactionRollback(t *testing.T, db *database.MongoDB) {
+	ctx := context.Background()
+	errAbort := errors.New("abort")
+	first := newServerDetail("io.github.example/transactional", "1.0.0")
+	require.NoError(t, db.Publish(ctx, first))
+
+	second := newServerDetail(first.Name, "2.0.0")
+	err := db.WithTransaction(ctx, func(ctx context.Context) error {
+		require.NoError(t, db.Publish(ctx, second))
+		require.NoError(t, db.TransferOwnership(ctx, first.ID, "new-owner"))
+		// A nested transaction joins the outer one and is rolled back with it
+		require.NoError(t, db.WithTransaction(ctx, func(ctx context.Context) error {
+			return db.YankVersion(ctx, first.ID, "1.0.0")
+		}))
+		return errAbort
+	})
+	require.ErrorIs(t, err, errAbort)
+
+	_, err = db.GetByID(ctx, second.ID)
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	stored, err := db.GetByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.True(t, stored.VersionDetail.IsLatest)
+	assert.False(t, stored.VersionDetail.Yanked)
+	assert.Equal(t, "example", stored.Publisher())
+
+	require.NoError(t, db.WithTransaction(ctx, func(ctx context.Context) error {
+		return db.TransferOwnership(ctx, first.ID, "new-owner")
+	}))
+	stored, err = db.GetByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "new-owner", stored.Publisher())
+}
+
+func testTags(t *testing.T, db *database.MongoDB) {
+	ctx := context.Background()
+	serverDetail := newServerDetail("io.github.example/tagged", "1.0.0")
+	require.NoError(t, db.Publish(ctx, serverDetail))
+
+	// Tags the server already has are not added twice
+	require.NoError(t, db.AddTags(ctx, serverDetail.ID, []string{"llm", "filesystem"}))
+	require.NoError(t, db.AddTags(ctx, serverDetail.ID, []string{"filesystem", "search"}))
+	stored, err := db.GetByID(ctx, serverDetail.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"llm", "filesystem", "search"}, stored.Tags)
+
+	require.NoError(t, db.RemoveTag(ctx, serverDetail.ID, "filesystem"))
+	stored, err = db.GetByID(ctx, serverDetail.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"llm", "search"}, stored.Tags)
+
+	require.ErrorIs(t, db.RemoveTag(ctx, serverDetail.ID, "filesystem"), database.ErrNotFound)
+	require.ErrorIs(t, db.AddTags(ctx, "00000000-0000-0000-0000-000000000000", []string{"llm"}), database.ErrNotFound)
+
+	other := newServerDetail("io.github.example/other-tagged", "1.0.0")
+	other.Tags = []string{"llm"}
+	require.NoError(t, db.Publish(ctx, other))
+
+	// Servers must carry every tag of the filter
+	entries, _, err := db.ListDetails(
+		ctx, mongodb.NewQueryBuilder().WithTags([]string{"llm", "search"}).Build(), database.SortByCreation, "", database.CursorNext, 10,
+	)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, serverDetail.ID, entries[0].ID)
+
+	counts, err := db.CountByTag(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"llm": 2, "search": 1}, counts)
+}
+
+func testYankPackage(t *testing.T, db *database.MongoDB) {
+	ctx := context.Background()
+	serverDetail := newServerDetail("io.github.example/yanked", "1.0.0")
+	serverDetail.Packages = []model.Package{
+		{RegistryName: "npm", Name: "yanked", Version: "1.0.0"},
+		{RegistryName: "docker", Name: "example/yanked", Version: "1.0.0"},
+	}
+	require.NoError(t, db.Publish(ctx, serverDetail))
+
+	// Only packages of the given registry are yanked
+	require.NoError(t, db.YankPackage(ctx, serverDetail.ID, "npm", "1.0.0", "security vulnerability"))
+	stored, err := db.GetByID(ctx, serverDetail.ID)
+	require.NoError(t, err)
+	assert.True(t, stored.Packages[0].Yanked)
+	assert.Equal(t, "security vulnerability", stored.Packages[0].YankReason)
+	assert.False(t, stored.Packages[1].Yanked)
+
+	require.ErrorIs(t, db.YankPackage(ctx, serverDetail.ID, "npm", "2.0.0", ""), database.ErrNotFound)
+	require.ErrorIs(t, db.YankPackage(ctx, "00000000-0000-0000-0000-000000000000", "npm", "1.0.0", ""), database.ErrNotFound)
+}
+
+func testUpdate(t *testing.T, db *database.MongoDB) {
+	ctx := context.Background()
+	first := newServerDetail("io.github.example/updated", "1.0.0")
+	require.NoError(t, db.Publish(ctx, first))
+	second := newServerDetail("io.github.example/updated", "1.1.0")
+	require.NoError(t, db.Publish(ctx, second))
+
+	update := *second
+	update.Description = "Corrected description"
+	update.Packages = []model.Package{{RegistryName: "npm", Name: "updated", Version: "1.1.1"}}
+	update.VersionDetail.Version = "1.1.1"
+	require.NoError(t, db.Update(ctx, second.ID, &update))
+
+	stored, err := db.GetByID(ctx, second.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Corrected description", stored.Description)
+	assert.Equal(t, update.Packages, stored.Packages)
+	assert.Equal(t, "1.1.1", stored.VersionDetail.Version)
+	assert.True(t, stored.VersionDetail.IsLatest)
+
+	// Versions stay unique and the latest version stays the highest
+	update.VersionDetail.Version = "1.0.0"
+	require.ErrorIs(t, db.Update(ctx, second.ID, &update), database.ErrAlreadyExists)
+	update.VersionDetail.Version = "0.9.0"
+	require.ErrorIs(t, db.Update(ctx, second.ID, &update), database.ErrInvalidVersion)
+	require.ErrorIs(t, db.Update(ctx, "00000000-0000-0000-0000-000000000000", &update), database.ErrNotFound)
+}
+
+func testDelete(t *testing.T, db *database.MongoDB) {
+	ctx := context.Background()
+	first := newServerDetail("io.github.example/deleted", "1.0.0")
+	require.NoError(t, db.Publish(ctx, first))
+	second := newServerDetail("io.github.example/deleted", "1.1.0")
+	require.NoError(t, db.Publish(ctx, second))
+
+	// Deleting the latest version makes the remaining version the latest
+	require.NoError(t, db.Delete(ctx, second.ID))
+	_, err := db.GetByID(ctx, second.ID)
+	require.ErrorIs(t, err, database.ErrNotFound)
+	stored, err := db.GetByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.True(t, stored.VersionDetail.IsLatest)
+
+	require.ErrorIs(t, db.Delete(ctx, second.ID), database.ErrNotFound)
+}
+
+func testListVersions(t *testing.T, db *database.MongoDB) {
+	ctx := context.Background()
+	var ids []string
+	for _, version := range []string{"1.0.0", "1.1.0", "1.2.0"} {
+		serverDetail := newServerDetail("io.github.example/versioned", version)
+		require.NoError(t, db.Publish(ctx, serverDetail))
+		ids = append(ids, serverDetail.ID)
+	}
+	require.NoError(t, db.Publish(ctx, newServerDetail("io.github.example/other", "1.0.0")))
+	require.NoError(t, db.YankVersion(ctx, ids[1], "1.1.0"))
+
+	// Older versions are listed along with the latest, in publish order
+	page, cursors, err := db.ListVersions(ctx, ids[2], true, "", database.CursorNext, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, ids[0], page[0].ID)
+	assert.Equal(t, ids[1], page[1].ID)
+	require.NotEmpty(t, cursors.Next)
+
+	page, cursors, err = db.ListVersions(ctx, ids[2], true, cursors.Next, database.CursorNext, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, ids[2], page[0].ID)
+	assert.Empty(t, cursors.Next)
+
+	page, _, err = db.ListVersions(ctx, ids[0], false, "", database.CursorNext, 10)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, "1.2.0", page[1].VersionDetail.Version)
+
+	_, _, err = db.ListVersions(ctx, "00000000-0000-0000-0000-000000000000", true, "", database.CursorNext, 10)
+	require.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func testListUpdatedAfter(t *testing.T, db *database.MongoDB) {
+	ctx := context.Background()
+	start := time.Now().Add(-time.Minute)
+	published := map[string]bool{}
+	for _, version := range []string{"1.0.0", "1.1.0", "1.2.0", "2.0.0", "2.1.0"} {
+		serverDetail := newServerDetail("io.github.example/synced", version)
+		require.NoError(t, db.Publish(ctx, serverDetail))
+		published[serverDetail.ID] = true
+	}
+
+	// Publishing a version also updates the older ones, so pages must split versions updated together
+	synced := map[string]bool{}
+	updatedAfter, afterSeq := start, int64(math.MaxInt64)
+	for range published {
+		page, err := db.ListUpdatedAfter(ctx, updatedAfter, afterSeq, 2)
+		require.NoError(t, err)
+		if len(page) == 0 {
+			break
+		}
+		for _, server := range page {
+			assert.False(t, synced[server.ID], "version %s synced twice", server.VersionDetail.Version)
+			synced[server.ID] = true
+		}
+		last := page[len(page)-1]
+		updatedAfter, afterSeq = last.UpdatedAt, last.CreatedSeq
+	}
+	assert.Equal(t, published, synced)
+}
+
+func testListRelated(t *testing.T, db *database.MongoDB) {
+	ctx := context.Background()
+	publish := func(name, version string, tags []string, dependencies ...string) *model.ServerDetail {
+		serverDetail := newServerDetail(name, version)
+		serverDetail.Tags = tags
+		serverDetail.Dependencies = dependencies
+		require.NoError(t, db.Publish(ctx, serverDetail))
+		return serverDetail
+	}
+
+	library := publish("io.github.lib-author/library", "1.0.0", nil)
+	publish("io.github.lib-author/library", "1.1.0", nil)
+	center := publish("io.github.acme/center", "1.0.0", []string{"llm"}, library.ID)
+	publish("io.github.ACME/sibling", "1.0.0", nil)
+	transferred := publish("io.github.someone/transferred", "1.0.0", nil)
+	require.NoError(t, db.TransferOwnership(ctx, transferred.ID, "acme"))
+	publish("io.github.other/tagged", "1.0.0", []string{"llm", "search"})
+	publish("io.github.third/dependent", "1.0.0", nil, center.ID)
+	publish("io.github.nobody/unrelated", "1.0.0", []string{"memory"})
+
+	// The dependency is the version the server depends on, not the latest version
+	related, err := db.ListRelated(ctx, center, 10)
+	require.NoError(t, err)
+	var names []string
+	for _, serverDetail := range related {
+		names = append(names, serverDetail.Name+"@"+serverDetail.VersionDetail.Version)
+	}
+	assert.Equal(t, []string{
+		"io.github.ACME/sibling@1.0.0",
+		"io.github.lib-author/library@1.0.0",
+		"io.github.other/tagged@1.0.0",
+		"io.github.someone/transferred@1.0.0",
+		"io.github.third/dependent@1.0.0",
+	}, names)
+
+	related, err = db.ListRelated(ctx, center, 2)
+	require.NoError(t, err)
+	assert.Len(t, related, 2)
+}
+
+func testVerifiedChecksums(t *testing.T, db *database.MongoDB) {
+	ctx := context.Background()
+	verified := newServerDetail("io.github.example/verified", "1.0.0")
+	verified.Packages = []model.Package{{RegistryName: "npm", Name: "verified", Version: "1.0.0"}}
+	require.NoError(t, db.Publish(ctx, verified))
+	unverified := newServerDetail("io.github.example/unverified", "1.0.0")
+	unverified.Packages = []model.Package{{RegistryName: "npm", Name: "unverified", Version: "1.0.0"}}
+	require.NoError(t, db.Publish(ctx, unverified))
+	require.NoError(t, db.SetPackageChecksum(ctx, verified.ID, "npm", "verified", "abc123"))
+
+	registry := service.NewRegistryServiceWithDB(db)
+	servers, _, err := registry.SearchDetails(service.SearchOptions{
+		HasVerifiedChecksum: true,
+		Direction:           database.CursorNext,
+		Limit:               10,
+	})
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+	assert.Equal(t, verified.Name, servers[0].Name)
+
+	stats, err := registry.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.VerifiedChecksumCount)
+}
+
+func testAggregateStats(t *testing.T, db *database.MongoDB) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	publish := func(name, version string, publishedAt time.Time, registries ...string) {
+		serverDetail := newServerDetail(name, version)
+		serverDetail.PublishedAt = publishedAt
+		for _, registryName := range registries {
+			serverDetail.Packages = append(serverDetail.Packages,
+				model.Package{RegistryName: registryName, Name: "example-" + registryName, Version: version})
+		}
+		require.NoError(t, db.Publish(ctx, serverDetail))
+	}
+
+	publish("io.github.alice/first", "1.0.0", now.Add(-40*24*time.Hour))
+	publish("io.github.alice/first", "1.1.0", now.Add(-2*time.Hour), "npm", "docker")
+	publish("io.github.Alice/second", "1.0.0", now.Add(-3*24*time.Hour), "pypi")
+	publish("io.github.bob/third", "1.0.0", now.Add(-20*24*time.Hour))
+	publish("com.example/unowned", "1.0.0", time.Time{})
+
+	stats, err := db.AggregateStats(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 4, stats.ServerCount)
+	assert.Equal(t, map[string]int{"npm": 4, "docker": 1, "pypi": 1}, stats.ServersByRegistry)
+	assert.Equal(t, model.PublishedCounts{Last24Hours: 1, Last7Days: 2, Last30Days: 3}, stats.Published)
+	assert.Equal(t, 2, stats.PublisherCount)
+}
+
+func testReorderFeatured(t *testing.T, db *database.MongoDB) {
+	ctx := context.Background()
+	featured := make([]*model.ServerDetail, 3)
+	for i := range featured {
+		position := i
+		featured[i] = newServerDetail(fmt.Sprintf("io.github.example/featured-%d", i), "1.0.0")
+		featured[i].FeaturedOrder = &position
+		require.NoError(t, db.Publish(ctx, featured[i]))
+	}
+	regular := newServerDetail("io.github.example/not-featured", "1.0.0")
+	require.NoError(t, db.Publish(ctx, regular))
+
+	err := db.ReorderFeatured(ctx, []string{featured[2].ID, featured[0].ID})
+	require.ErrorIs(t, err, database.ErrInvalidInput)
+	err = db.ReorderFeatured(ctx, []string{featured[2].ID, featured[0].ID, featured[1].ID, regular.ID})
+	require.ErrorIs(t, err, database.ErrInvalidInput)
+
+	require.NoError(t, db.ReorderFeatured(ctx, []string{featured[2].ID, featured[0].ID, featured[1].ID}))
+	servers, err := db.ListFeatured(ctx)
+	require.NoError(t, err)
+	require.Len(t, servers, 3)
+	for i, expected := range []string{featured[2].ID, featured[0].ID, featured[1].ID} {
+		assert.Equal(t, expected, servers[i].ID)
+		require.NotNil(t, servers[i].FeaturedOrder)
+		assert.Equal(t, i, *servers[i].FeaturedOrder)
+	}
+}
+
+func testSchemaStorage(t *testing.T, db *database.MongoDB) {
+	ctx := context.Background()
+	schema := json.RawMessage(`{"type": "object",  "properties": {"path": {"type": "string"}}, "required": ["path"]}`)
+	withSchema := newServerDetail("io.github.example/with-schema", "1.0.0")
+	withSchema.Schema = &schema
+	require.NoError(t, db.Publish(ctx, withSchema))
+	require.NoError(t, db.Publish(ctx, newServerDetail("io.github.example/without-schema", "1.0.0")))
+
+	// The schema is returned byte for byte, including its original whitespace
+	stored, err := db.GetSchema(ctx, withSchema.ID)
+	require.NoError(t, err)
+	assert.Equal(t, string(schema), string(stored))
+
+	// The server document only records that a schema exists
+	serverDetail, err := db.GetByID(ctx, withSchema.ID)
+	require.NoError(t, err)
+	assert.True(t, serverDetail.HasSchema)
+	assert.Nil(t, serverDetail.Schema)
+
+	entries, _, err := db.ListDetails(ctx, bson.D{{Key: "has_schema", Value: true}}, database.SortByCreation, "", database.CursorNext, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, withSchema.Name, entries[0].Name)
+
+	_, err = db.GetSchema(ctx, uuid.New().String())
+	assert.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func testVacuum(t *testing.T, db *database.MongoDB) {
+	ctx := context.Background()
+	require.NoError(t, db.Publish(ctx, newServerDetail("io.github.example/vacuum", "1.0.0")))
+
+	now := time.Now().UTC()
+	for _, completedAt := range []time.Time{now.Add(-48 * time.Hour), now.Add(-time.Hour)} {
+		require.NoError(t, db.SaveReindexJob(ctx, &model.ReindexJob{
+			ID:          uuid.New().String(),
+			Status:      model.ReindexJobCompleted,
+			StartedAt:   completedAt,
+			CompletedAt: &completedAt,
+		}))
+	}
+
+	removed, bytesReclaimed, err := db.RemoveReindexJobs(ctx, now.Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), removed)
+	assert.Positive(t, bytesReclaimed)
+
+	// Every schema belongs to a stored server
+	removed, _, err = db.RemoveOrphanedSchemas(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, removed)
+
+	_, err = db.Compact(ctx)
+	require.NoError(t, err)
+}
+
+func testSubscriptions(t *testing.T, db *database.MongoDB) {
+	ctx := context.Background()
+	subscription := &model.Subscription{
+		ID:           uuid.New().String(),
+		ServerName:   "io.github.example/subscribed",
+		WebhookURL:   "https://hooks.example.com/registry",
+		Events:       []model.SubscriptionEvent{model.SubscriptionEventVersionUpdated},
+		GitHubUserID: "1",
+		CreatedAt:    time.Now().UTC(),
+	}
+	require.NoError(t, db.SaveSubscription(ctx, subscription))
+
+	found, err := db.FindSubscription(ctx, subscription.ServerName, subscription.WebhookURL)
+	require.NoError(t, err)
+	assert.Equal(t, subscription.ID, found.ID)
+
+	duplicate := *subscription
+	duplicate.ID = uuid.New().String()
+	require.ErrorIs(t, db.SaveSubscription(ctx, &duplicate), database.ErrAlreadyExists)
+
+	count, err := db.CountSubscriptions(ctx, "1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	require.NoError(t, db.DeleteSubscription(ctx, subscription.ID))
+	subscriptions, err := db.ListSubscriptions(ctx, subscription.ServerName)
+	require.NoError(t, err)
+	assert.Empty(t, subscriptions)
+}
+
+func testExplainQuery(t *testing.T, connectionURI string) {
+	ctx := context.Background()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(connectionURI))
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, client.Disconnect(ctx)) })
+
+	coll := client.Database("registry_" + strings.ReplaceAll(uuid.New().String(), "-", "")).Collection("servers_v2")
+	_, err = coll.Indexes().CreateOne(ctx, mongo.IndexModel{Keys: bson.D{{Key: "name", Value: 1}}})
+	require.NoError(t, err)
+	_, err = coll.InsertOne(ctx, bson.M{"name": "io.github.example/explain"})
+	require.NoError(t, err)
+
+	plan, err := mongodb.ExplainQuery(ctx, coll, bson.D{{Key: "name", Value: "io.github.example/explain"}},
+		options.Find().SetSort(bson.M{"name": 1}).SetLimit(10))
+	require.NoError(t, err)
+
+	var queryPlanner struct {
+		Namespace   string         `json:"namespace"`
+		WinningPlan map[string]any `json:"winningPlan"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(plan), &queryPlanner))
+	assert.True(t, strings.HasSuffix(queryPlanner.Namespace, ".servers_v2"))
+	assert.NotEmpty(t, queryPlanner.WinningPlan)
+	assert.Contains(t, plan, "IXSCAN")
+}
@@ -0,0 +1,21 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// CommandDurationMonitor returns a command monitor reporting the name and duration of every command the client
+// finishes, whether it succeeded or failed
+func CommandDurationMonitor(observe func(command string, duration time.Duration)) *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			observe(e.CommandName, e.Duration)
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			observe(e.CommandName, e.Duration)
+		},
+	}
+}
@@ -0,0 +1,28 @@
+package mongodb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database/mongodb"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+func TestCommandDurationMonitor(t *testing.T) {
+	observed := map[string]time.Duration{}
+	monitor := mongodb.CommandDurationMonitor(func(command string, duration time.Duration) {
+		observed[command] = duration
+	})
+
+	monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{
+		CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "find", Duration: 3 * time.Millisecond},
+	})
+	monitor.Failed(context.Background(), &event.CommandFailedEvent{
+		CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "insert", Duration: 5 * time.Millisecond},
+		Failure:              "duplicate key",
+	})
+
+	assert.Equal(t, map[string]time.Duration{"find": 3 * time.Millisecond, "insert": 5 * time.Millisecond}, observed)
+}
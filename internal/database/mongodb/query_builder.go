@@ -0,0 +1,250 @@
+// Package mongodb contains helpers for building and debugging MongoDB queries against the servers collection
+package mongodb
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// QueryBuilder incrementally builds a filter document for server queries.
+// Methods called with empty arguments leave the filter unchanged; setting the same field twice
+// replaces the earlier condition.
+type QueryBuilder struct {
+	filter bson.D
+}
+
+// NewQueryBuilder creates an empty query builder
+func NewQueryBuilder() *QueryBuilder {
+	return &QueryBuilder{}
+}
+
+// WithTextSearch adds a full-text search using the collection's text index
+func (b *QueryBuilder) WithTextSearch(query string) *QueryBuilder {
+	if query == "" {
+		return b
+	}
+	return b.set("$text", bson.M{"$search": query})
+}
+
+// WithPatternSearch adds a case-insensitive substring match on the server name, description and package names.
+// The query is escaped so it is matched literally.
+func (b *QueryBuilder) WithPatternSearch(query string) *QueryBuilder {
+	if query == "" {
+		return b
+	}
+
+	pattern := caseInsensitiveRegex(regexp.QuoteMeta(query))
+	return b.set("$or", bson.A{
+		bson.M{"name": pattern},
+		bson.M{"description": pattern},
+		bson.M{"packages.name": pattern},
+	})
+}
+
+// WithNameSearch adds a case-insensitive substring match on the server name.
+// The query is escaped so it is matched literally.
+func (b *QueryBuilder) WithNameSearch(query string) *QueryBuilder {
+	if query == "" {
+		return b
+	}
+	return b.set("name", caseInsensitiveRegex(regexp.QuoteMeta(query)))
+}
+
+// WithName restricts results to the versions of the server with the given name (exact match)
+func (b *QueryBuilder) WithName(name string) *QueryBuilder {
+	if name == "" {
+		return b
+	}
+	return b.set("name", name)
+}
+
+// WithRegistryName restricts results to servers with a package in the given registry
+func (b *QueryBuilder) WithRegistryName(name string) *QueryBuilder {
+	if name == "" {
+		return b
+	}
+	return b.set("packages.registry_name", name)
+}
+
+// WithURL restricts results to servers with the given repository URL (exact match)
+func (b *QueryBuilder) WithURL(url string) *QueryBuilder {
+	if url == "" {
+		return b
+	}
+	return b.set("repository.url", url)
+}
+
+// WithCategory restricts results to servers in the given category
+func (b *QueryBuilder) WithCategory(category string) *QueryBuilder {
+	if category == "" {
+		return b
+	}
+	return b.set("category", category)
+}
+
+// WithLanguage restricts results to servers written in the given language, ignoring case
+func (b *QueryBuilder) WithLanguage(language string) *QueryBuilder {
+	if language == "" {
+		return b
+	}
+	return b.set("language", strings.ToLower(language))
+}
+
+// WithTopic restricts results to servers tagged with the given repository topic, ignoring case
+func (b *QueryBuilder) WithTopic(topic string) *QueryBuilder {
+	if topic == "" {
+		return b
+	}
+	return b.set("topics", strings.ToLower(topic))
+}
+
+// WithLicense restricts results to servers under the license with the given SPDX identifier, matched exactly
+func (b *QueryBuilder) WithLicense(license string) *QueryBuilder {
+	if license == "" {
+		return b
+	}
+	return b.set("license", license)
+}
+
+// WithMaintainer restricts results to servers listing a maintainer with the given GitHub username
+func (b *QueryBuilder) WithMaintainer(username string) *QueryBuilder {
+	if username == "" {
+		return b
+	}
+	return b.set("maintainers.github_username", username)
+}
+
+// WithStatus restricts results to servers with the given lifecycle status. Servers without a status, published
+// before statuses were recorded, count as active.
+func (b *QueryBuilder) WithStatus(status string) *QueryBuilder {
+	if status == "" {
+		return b
+	}
+	if status == model.StatusActive {
+		return b.set("status", bson.M{"$in": bson.A{status, nil}})
+	}
+	return b.set("status", status)
+}
+
+// WithSource restricts results to servers hosted on the given repository source (e.g. "github")
+func (b *QueryBuilder) WithSource(source string) *QueryBuilder {
+	if source == "" {
+		return b
+	}
+	return b.set("repository.source", source)
+}
+
+// WithTags restricts results to servers that have all of the given tags
+func (b *QueryBuilder) WithTags(tags []string) *QueryBuilder {
+	if len(tags) == 0 {
+		return b
+	}
+	return b.set("tags", bson.M{"$all": tags})
+}
+
+// WithPublisher restricts results to servers in the io.github namespace of the given GitHub user
+func (b *QueryBuilder) WithPublisher(username string) *QueryBuilder {
+	if username == "" {
+		return b
+	}
+	return b.set("name", caseInsensitiveRegex("^"+regexp.QuoteMeta("io.github."+username+"/")))
+}
+
+// WithSince restricts results to servers the registry received at or after the given time
+func (b *QueryBuilder) WithSince(t time.Time) *QueryBuilder {
+	if t.IsZero() {
+		return b
+	}
+	return b.set("published_at", bson.M{"$gte": t.UTC()})
+}
+
+// WithPublishedRange restricts results to servers the registry received strictly after and strictly before the
+// given times. A zero bound is not applied.
+func (b *QueryBuilder) WithPublishedRange(after, before time.Time) *QueryBuilder {
+	condition := bson.M{}
+	if !after.IsZero() {
+		condition["$gt"] = after.UTC()
+	}
+	if !before.IsZero() {
+		condition["$lt"] = before.UTC()
+	}
+	if len(condition) == 0 {
+		return b
+	}
+	return b.set("published_at", condition)
+}
+
+// ExcludeArchived removes archived servers from the results
+func (b *QueryBuilder) ExcludeArchived() *QueryBuilder {
+	return b.set("archived", bson.M{"$ne": true})
+}
+
+// WithRatingRange restricts results to servers whose average rating lies within the given bounds.
+// A zero bound is not applied.
+func (b *QueryBuilder) WithRatingRange(minRating, maxRating float64) *QueryBuilder {
+	condition := bson.M{}
+	if minRating > 0 {
+		condition["$gte"] = minRating
+	}
+	if maxRating > 0 {
+		condition["$lte"] = maxRating
+	}
+	if len(condition) == 0 {
+		return b
+	}
+	return b.set("average_rating", condition)
+}
+
+// WithSchema restricts results to servers published with a JSON schema when required is true
+func (b *QueryBuilder) WithSchema(required bool) *QueryBuilder {
+	if !required {
+		return b
+	}
+	return b.set("has_schema", true)
+}
+
+// WithVerifiedChecksum restricts results to servers with at least one package whose checksum the registry
+// verified when required is true
+func (b *QueryBuilder) WithVerifiedChecksum(required bool) *QueryBuilder {
+	if !required {
+		return b
+	}
+	return b.set("packages", bson.M{"$elemMatch": bson.M{"verified_checksum": bson.M{"$exists": true, "$ne": ""}}})
+}
+
+// WithTool restricts results to servers declaring an MCP tool with the given name.
+// An empty name is not applied.
+func (b *QueryBuilder) WithTool(name string) *QueryBuilder {
+	if name == "" {
+		return b
+	}
+	return b.set("tools.name", name)
+}
+
+// Build returns the filter document
+func (b *QueryBuilder) Build() bson.D {
+	filter := make(bson.D, len(b.filter))
+	copy(filter, b.filter)
+	return filter
+}
+
+// set adds a condition on the given key, replacing any existing condition on the same key
+func (b *QueryBuilder) set(key string, value interface{}) *QueryBuilder {
+	for i := range b.filter {
+		if b.filter[i].Key == key {
+			b.filter[i].Value = value
+			return b
+		}
+	}
+	b.filter = append(b.filter, bson.E{Key: key, Value: value})
+	return b
+}
+
+// caseInsensitiveRegex returns a case-insensitive regular expression condition
+func caseInsensitiveRegex(pattern string) bson.M {
+	return bson.M{"$regex": pattern, "$options": "i"}
+}
@@ -0,0 +1,210 @@
+package mongodb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database/mongodb"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestQueryBuilderMethods(t *testing.T) {
+	since := time.Date(2025, 5, 17, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	testCases := []struct {
+		name     string
+		build    func(*mongodb.QueryBuilder) *mongodb.QueryBuilder
+		expected bson.D
+	}{
+		{
+			name:     "text search",
+			build:    func(b *mongodb.QueryBuilder) *mongodb.QueryBuilder { return b.WithTextSearch("filesystem") },
+			expected: bson.D{{Key: "$text", Value: bson.M{"$search": "filesystem"}}},
+		},
+		{
+			name:  "pattern search escapes the query",
+			build: func(b *mongodb.QueryBuilder) *mongodb.QueryBuilder { return b.WithPatternSearch("file.system") },
+			expected: bson.D{{Key: "$or", Value: bson.A{
+				bson.M{"name": bson.M{"$regex": `file\.system`, "$options": "i"}},
+				bson.M{"description": bson.M{"$regex": `file\.system`, "$options": "i"}},
+				bson.M{"packages.name": bson.M{"$regex": `file\.system`, "$options": "i"}},
+			}}},
+		},
+		{
+			name:     "name search escapes the query",
+			build:    func(b *mongodb.QueryBuilder) *mongodb.QueryBuilder { return b.WithNameSearch("a+b") },
+			expected: bson.D{{Key: "name", Value: bson.M{"$regex": `a\+b`, "$options": "i"}}},
+		},
+		{
+			name:     "registry name",
+			build:    func(b *mongodb.QueryBuilder) *mongodb.QueryBuilder { return b.WithRegistryName("npm") },
+			expected: bson.D{{Key: "packages.registry_name", Value: "npm"}},
+		},
+		{
+			name: "url",
+			build: func(b *mongodb.QueryBuilder) *mongodb.QueryBuilder {
+				return b.WithURL("https://github.com/example/server")
+			},
+			expected: bson.D{{Key: "repository.url", Value: "https://github.com/example/server"}},
+		},
+		{
+			name:     "language is lowercased",
+			build:    func(b *mongodb.QueryBuilder) *mongodb.QueryBuilder { return b.WithLanguage("TypeScript") },
+			expected: bson.D{{Key: "language", Value: "typescript"}},
+		},
+		{
+			name:     "topic is lowercased",
+			build:    func(b *mongodb.QueryBuilder) *mongodb.QueryBuilder { return b.WithTopic("Database") },
+			expected: bson.D{{Key: "topics", Value: "database"}},
+		},
+		{
+			name:     "source",
+			build:    func(b *mongodb.QueryBuilder) *mongodb.QueryBuilder { return b.WithSource("github") },
+			expected: bson.D{{Key: "repository.source", Value: "github"}},
+		},
+		{
+			name:     "tags",
+			build:    func(b *mongodb.QueryBuilder) *mongodb.QueryBuilder { return b.WithTags([]string{"ai", "files"}) },
+			expected: bson.D{{Key: "tags", Value: bson.M{"$all": []string{"ai", "files"}}}},
+		},
+		{
+			name:     "publisher",
+			build:    func(b *mongodb.QueryBuilder) *mongodb.QueryBuilder { return b.WithPublisher("example") },
+			expected: bson.D{{Key: "name", Value: bson.M{"$regex": `^io\.github\.example/`, "$options": "i"}}},
+		},
+		{
+			name:     "since is converted to UTC",
+			build:    func(b *mongodb.QueryBuilder) *mongodb.QueryBuilder { return b.WithSince(since) },
+			expected: bson.D{{Key: "published_at", Value: bson.M{"$gte": since.UTC()}}},
+		},
+		{
+			name:     "exclude archived",
+			build:    func(b *mongodb.QueryBuilder) *mongodb.QueryBuilder { return b.ExcludeArchived() },
+			expected: bson.D{{Key: "archived", Value: bson.M{"$ne": true}}},
+		},
+		{
+			name:     "active status matches servers without a status",
+			build:    func(b *mongodb.QueryBuilder) *mongodb.QueryBuilder { return b.WithStatus(model.StatusActive) },
+			expected: bson.D{{Key: "status", Value: bson.M{"$in": bson.A{"active", nil}}}},
+		},
+		{
+			name:     "archived status",
+			build:    func(b *mongodb.QueryBuilder) *mongodb.QueryBuilder { return b.WithStatus(model.StatusArchived) },
+			expected: bson.D{{Key: "status", Value: "archived"}},
+		},
+		{
+			name:     "rating range",
+			build:    func(b *mongodb.QueryBuilder) *mongodb.QueryBuilder { return b.WithRatingRange(3, 4.5) },
+			expected: bson.D{{Key: "average_rating", Value: bson.M{"$gte": 3.0, "$lte": 4.5}}},
+		},
+		{
+			name: "published range",
+			build: func(b *mongodb.QueryBuilder) *mongodb.QueryBuilder {
+				return b.WithPublishedRange(since, since.Add(time.Hour))
+			},
+			expected: bson.D{{Key: "published_at", Value: bson.M{"$gt": since.UTC(), "$lt": since.Add(time.Hour).UTC()}}},
+		},
+		{
+			name:     "published before only",
+			build:    func(b *mongodb.QueryBuilder) *mongodb.QueryBuilder { return b.WithPublishedRange(time.Time{}, since) },
+			expected: bson.D{{Key: "published_at", Value: bson.M{"$lt": since.UTC()}}},
+		},
+		{
+			name:     "minimum rating only",
+			build:    func(b *mongodb.QueryBuilder) *mongodb.QueryBuilder { return b.WithRatingRange(4, 0) },
+			expected: bson.D{{Key: "average_rating", Value: bson.M{"$gte": 4.0}}},
+		},
+		{
+			name:     "exact name",
+			build:    func(b *mongodb.QueryBuilder) *mongodb.QueryBuilder { return b.WithName("io.github.example/server") },
+			expected: bson.D{{Key: "name", Value: "io.github.example/server"}},
+		},
+		{
+			name:     "schema required",
+			build:    func(b *mongodb.QueryBuilder) *mongodb.QueryBuilder { return b.WithSchema(true) },
+			expected: bson.D{{Key: "has_schema", Value: true}},
+		},
+		{
+			name:  "verified checksum required",
+			build: func(b *mongodb.QueryBuilder) *mongodb.QueryBuilder { return b.WithVerifiedChecksum(true) },
+			expected: bson.D{{Key: "packages", Value: bson.M{
+				"$elemMatch": bson.M{"verified_checksum": bson.M{"$exists": true, "$ne": ""}},
+			}}},
+		},
+		{
+			name:     "tool name",
+			build:    func(b *mongodb.QueryBuilder) *mongodb.QueryBuilder { return b.WithTool("read_file") },
+			expected: bson.D{{Key: "tools.name", Value: "read_file"}},
+		},
+		{
+			name: "empty arguments leave the filter unchanged",
+			build: func(b *mongodb.QueryBuilder) *mongodb.QueryBuilder {
+				return b.WithTextSearch("").
+					WithPatternSearch("").
+					WithNameSearch("").
+					WithName("").
+					WithRegistryName("").
+					WithURL("").
+					WithSource("").
+					WithTags(nil).
+					WithPublisher("").
+					WithSince(time.Time{}).
+					WithRatingRange(0, 0).
+					WithPublishedRange(time.Time{}, time.Time{}).
+					WithSchema(false).
+					WithVerifiedChecksum(false).
+					WithTool("")
+			},
+			expected: bson.D{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.build(mongodb.NewQueryBuilder()).Build())
+		})
+	}
+}
+
+func TestQueryBuilderCombined(t *testing.T) {
+	filter := mongodb.NewQueryBuilder().
+		WithTextSearch("filesystem").
+		WithRegistryName("npm").
+		WithURL("https://github.com/example/server").
+		WithSource("github").
+		WithTags([]string{"files"}).
+		WithPublisher("example").
+		WithSince(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)).
+		ExcludeArchived().
+		Build()
+
+	assert.Equal(t, bson.D{
+		{Key: "$text", Value: bson.M{"$search": "filesystem"}},
+		{Key: "packages.registry_name", Value: "npm"},
+		{Key: "repository.url", Value: "https://github.com/example/server"},
+		{Key: "repository.source", Value: "github"},
+		{Key: "tags", Value: bson.M{"$all": []string{"files"}}},
+		{Key: "name", Value: bson.M{"$regex": `^io\.github\.example/`, "$options": "i"}},
+		{Key: "published_at", Value: bson.M{"$gte": time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}},
+		{Key: "archived", Value: bson.M{"$ne": true}},
+	}, filter)
+}
+
+func TestQueryBuilderReplacesRepeatedField(t *testing.T) {
+	builder := mongodb.NewQueryBuilder().
+		WithRegistryName("npm").
+		WithURL("https://github.com/example/server").
+		WithRegistryName("docker")
+
+	assert.Equal(t, bson.D{
+		{Key: "packages.registry_name", Value: "docker"},
+		{Key: "repository.url", Value: "https://github.com/example/server"},
+	}, builder.Build())
+
+	// Build returns a copy that is not affected by later changes
+	filter := builder.Build()
+	builder.WithSource("github")
+	assert.Len(t, filter, 2)
+}
@@ -0,0 +1,47 @@
+package database
+
+import (
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/model"
+)
+
+// DescendingPrefix reverses a field sort order when prefixed to it, as in "-name"
+const DescendingPrefix = "-"
+
+// fieldSortKeys maps the field sort orders to the document field they sort by
+var fieldSortKeys = map[SortOrder]string{
+	SortByName:        "name",
+	SortByPublishedAt: "published_at",
+	SortByUpdatedAt:   "updated_at",
+}
+
+// IsFieldSort reports whether a sort order sorts by a server field, ascending or, with the DescendingPrefix,
+// descending
+func IsFieldSort(sortBy SortOrder) bool {
+	_, _, ok := fieldSort(sortBy)
+	return ok
+}
+
+// fieldSort returns the document field a field sort order sorts by and its direction, 1 for ascending and -1 for
+// descending. It returns false for the other sort orders.
+func fieldSort(sortBy SortOrder) (string, int, bool) {
+	order := 1
+	if field, ok := strings.CutPrefix(string(sortBy), DescendingPrefix); ok {
+		sortBy, order = SortOrder(field), -1
+	}
+	key, ok := fieldSortKeys[sortBy]
+	return key, order, ok
+}
+
+// compareField compares two servers by a field returned by fieldSort, in ascending order
+func compareField(key string, a, b *model.Server) int {
+	switch key {
+	case "name":
+		return strings.Compare(a.Name, b.Name)
+	case "published_at":
+		return a.PublishedAt.Compare(b.PublishedAt)
+	default:
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	}
+}
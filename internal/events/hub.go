@@ -0,0 +1,131 @@
+// Package events broadcasts registry change notifications to subscribers
+package events
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// EventType identifies the kind of change made to the registry
+type EventType string
+
+const (
+	// EventPublished is sent when a new server is published
+	EventPublished EventType = "published"
+	// EventUpdated is sent when an existing server is modified
+	EventUpdated EventType = "updated"
+	// EventDeleted is sent when a server is removed
+	EventDeleted EventType = "deleted"
+	// EventOwnershipTransferred is sent when a server is handed over to a new publisher
+	EventOwnershipTransferred EventType = "ownership_transferred"
+)
+
+const (
+	// broadcastBufferSize is the number of events queued for fanout before new events are dropped
+	broadcastBufferSize = 64
+	// subscriberBufferSize is the number of events buffered per subscriber before events are dropped
+	subscriberBufferSize = 16
+)
+
+// Event is a registry change notification
+type Event struct {
+	Type      EventType `json:"type"`
+	ServerID  string    `json:"server_id"`
+	Name      string    `json:"name"`
+	Timestamp time.Time `json:"timestamp"`
+	// PreviousOwner and NewOwner are set on ownership transferred events
+	PreviousOwner string `json:"previous_owner,omitempty"`
+	NewOwner      string `json:"new_owner,omitempty"`
+}
+
+// Hub fans out events to all subscribers.
+// Subscriber bookkeeping happens exclusively on the hub's own goroutine.
+type Hub struct {
+	subscribe   chan chan Event
+	unsubscribe chan chan Event
+	broadcast   chan Event
+	done        chan struct{}
+	closeOnce   sync.Once
+}
+
+// NewHub creates a new event hub and starts delivering events
+func NewHub() *Hub {
+	h := &Hub{
+		subscribe:   make(chan chan Event),
+		unsubscribe: make(chan chan Event),
+		broadcast:   make(chan Event, broadcastBufferSize),
+		done:        make(chan struct{}),
+	}
+	go h.run()
+	return h
+}
+
+// run delivers events to subscribers until the hub is closed
+func (h *Hub) run() {
+	subscribers := make(map[chan Event]struct{})
+	for {
+		select {
+		case <-h.done:
+			for ch := range subscribers {
+				close(ch)
+			}
+			return
+		case ch := <-h.subscribe:
+			subscribers[ch] = struct{}{}
+		case ch := <-h.unsubscribe:
+			if _, ok := subscribers[ch]; ok {
+				delete(subscribers, ch)
+				close(ch)
+			}
+		case event := <-h.broadcast:
+			for ch := range subscribers {
+				select {
+				case ch <- event:
+				default:
+					// Drop the event for subscribers that are not keeping up
+				}
+			}
+		}
+	}
+}
+
+// Subscribe registers a new subscriber and returns its event channel together with a function
+// that unregisters it. The channel is closed once the subscriber is unregistered or the hub is closed.
+func (h *Hub) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBufferSize)
+	select {
+	case h.subscribe <- ch:
+	case <-h.done:
+		close(ch)
+		return ch, func() {}
+	}
+
+	return ch, func() {
+		select {
+		case h.unsubscribe <- ch:
+		case <-h.done:
+		}
+	}
+}
+
+// Publish broadcasts an event to all subscribers without blocking the caller
+func (h *Hub) Publish(event Event) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now().UTC()
+	}
+
+	select {
+	case h.broadcast <- event:
+	case <-h.done:
+	default:
+		log.Printf("Event hub is full, dropping %s event for server %s", event.Type, event.ServerID)
+	}
+}
+
+// Close stops the hub and closes all subscriber channels
+func (h *Hub) Close() {
+	h.closeOnce.Do(func() {
+		close(h.done)
+	})
+}
@@ -0,0 +1,66 @@
+package events_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// receive waits for the next event on the channel
+func receive(t *testing.T, ch <-chan events.Event) events.Event {
+	t.Helper()
+	select {
+	case event, ok := <-ch:
+		require.True(t, ok, "channel closed")
+		return event
+	case <-time.After(time.Second):
+		require.FailNow(t, "timed out waiting for event")
+		return events.Event{}
+	}
+}
+
+func TestHubFanout(t *testing.T) {
+	hub := events.NewHub()
+	defer hub.Close()
+
+	first, unsubscribeFirst := hub.Subscribe()
+	second, unsubscribeSecond := hub.Subscribe()
+	defer unsubscribeSecond()
+
+	hub.Publish(events.Event{Type: events.EventPublished, ServerID: "1", Name: "example/one"})
+
+	for _, ch := range []<-chan events.Event{first, second} {
+		event := receive(t, ch)
+		assert.Equal(t, events.EventPublished, event.Type)
+		assert.Equal(t, "1", event.ServerID)
+		assert.Equal(t, "example/one", event.Name)
+		assert.WithinDuration(t, time.Now(), event.Timestamp, time.Second)
+	}
+
+	// Unsubscribed channels are closed and receive no further events
+	unsubscribeFirst()
+	_, ok := <-first
+	assert.False(t, ok)
+
+	hub.Publish(events.Event{Type: events.EventUpdated, ServerID: "1"})
+	assert.Equal(t, events.EventUpdated, receive(t, second).Type)
+}
+
+func TestHubClose(t *testing.T) {
+	hub := events.NewHub()
+	ch, unsubscribe := hub.Subscribe()
+
+	hub.Close()
+	_, ok := <-ch
+	assert.False(t, ok)
+
+	// Operations on a closed hub do not block
+	unsubscribe()
+	hub.Publish(events.Event{Type: events.EventDeleted, ServerID: "1"})
+	closed, _ := hub.Subscribe()
+	_, ok = <-closed
+	assert.False(t, ok)
+}
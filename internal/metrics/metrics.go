@@ -0,0 +1,201 @@
+// Package metrics records request and database metrics and exposes them in the Prometheus text format
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// contentType is the content type of the Prometheus text exposition format
+const contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultBuckets are the upper bounds in seconds of the duration histograms, the Prometheus client defaults
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Default is the registry the API and the database record their metrics in
+var Default = NewRegistry()
+
+// requestKey identifies the series of the request counter
+type requestKey struct {
+	endpoint string
+	method   string
+	status   int
+}
+
+// routeKey identifies the series of the request duration histogram
+type routeKey struct {
+	endpoint string
+	method   string
+}
+
+// histogram counts observations in cumulative buckets
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// observe adds an observation of the given number of seconds
+func (h *histogram) observe(buckets []float64, seconds float64) {
+	for i, bound := range buckets {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// Registry holds the metrics of the registry. It is safe for concurrent use.
+type Registry struct {
+	buckets []float64
+
+	mu                 sync.Mutex
+	requests           map[requestKey]uint64
+	requestDurations   map[routeKey]*histogram
+	databaseOperations map[string]*histogram
+}
+
+// NewRegistry creates a registry without any recorded metrics
+func NewRegistry() *Registry {
+	return &Registry{
+		buckets:            DefaultBuckets,
+		requests:           make(map[requestKey]uint64),
+		requestDurations:   make(map[routeKey]*histogram),
+		databaseOperations: make(map[string]*histogram),
+	}
+}
+
+// ObserveRequest records an HTTP request to the endpoint, the route pattern that served it, and its duration
+func (r *Registry) ObserveRequest(endpoint, method string, status int, duration time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.requests[requestKey{endpoint: endpoint, method: method, status: status}]++
+	key := routeKey{endpoint: endpoint, method: method}
+	h, ok := r.requestDurations[key]
+	if !ok {
+		h = r.newHistogram()
+		r.requestDurations[key] = h
+	}
+	h.observe(r.buckets, duration.Seconds())
+}
+
+// ObserveDatabaseOperation records the duration of a database operation, such as a find or an aggregate
+func (r *Registry) ObserveDatabaseOperation(operation string, duration time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	h, ok := r.databaseOperations[operation]
+	if !ok {
+		h = r.newHistogram()
+		r.databaseOperations[operation] = h
+	}
+	h.observe(r.buckets, duration.Seconds())
+}
+
+// ServeHTTP responds with the recorded metrics in the Prometheus text format
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", contentType)
+	_ = r.Write(w)
+}
+
+// Write writes the recorded metrics in the Prometheus text format. Series are sorted by their labels.
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := bufio.NewWriter(w)
+
+	writeHeader(out, "mcp_registry_http_requests_total", "counter", "Number of HTTP requests by endpoint, method and status.")
+	requestKeys := make([]requestKey, 0, len(r.requests))
+	for key := range r.requests {
+		requestKeys = append(requestKeys, key)
+	}
+	sort.Slice(requestKeys, func(i, j int) bool {
+		a, b := requestKeys[i], requestKeys[j]
+		if a.endpoint != b.endpoint {
+			return a.endpoint < b.endpoint
+		}
+		if a.method != b.method {
+			return a.method < b.method
+		}
+		return a.status < b.status
+	})
+	for _, key := range requestKeys {
+		fmt.Fprintf(out, "mcp_registry_http_requests_total{endpoint=%s,method=%s,status=\"%d\"} %d\n",
+			quote(key.endpoint), quote(key.method), key.status, r.requests[key])
+	}
+
+	writeHeader(out, "mcp_registry_http_request_duration_seconds", "histogram",
+		"Duration of HTTP requests by endpoint and method.")
+	routeKeys := make([]routeKey, 0, len(r.requestDurations))
+	for key := range r.requestDurations {
+		routeKeys = append(routeKeys, key)
+	}
+	sort.Slice(routeKeys, func(i, j int) bool {
+		a, b := routeKeys[i], routeKeys[j]
+		if a.endpoint != b.endpoint {
+			return a.endpoint < b.endpoint
+		}
+		return a.method < b.method
+	})
+	for _, key := range routeKeys {
+		labels := "endpoint=" + quote(key.endpoint) + ",method=" + quote(key.method)
+		r.writeHistogram(out, "mcp_registry_http_request_duration_seconds", labels, r.requestDurations[key])
+	}
+
+	writeHeader(out, "mcp_registry_database_operation_duration_seconds", "histogram",
+		"Duration of database operations by operation.")
+	operations := make([]string, 0, len(r.databaseOperations))
+	for operation := range r.databaseOperations {
+		operations = append(operations, operation)
+	}
+	sort.Strings(operations)
+	for _, operation := range operations {
+		r.writeHistogram(out, "mcp_registry_database_operation_duration_seconds", "operation="+quote(operation),
+			r.databaseOperations[operation])
+	}
+
+	return out.Flush()
+}
+
+// newHistogram creates an empty histogram with the buckets of the registry
+func (r *Registry) newHistogram() *histogram {
+	return &histogram{counts: make([]uint64, len(r.buckets))}
+}
+
+// writeHistogram writes the bucket, sum and count series of a histogram with the given labels
+func (r *Registry) writeHistogram(out io.Writer, name, labels string, h *histogram) {
+	for i, bound := range r.buckets {
+		fmt.Fprintf(out, "%s_bucket{%s,le=\"%s\"} %d\n", name, labels, formatFloat(bound), h.counts[i])
+	}
+	fmt.Fprintf(out, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, labels, h.count)
+	fmt.Fprintf(out, "%s_sum{%s} %s\n", name, labels, formatFloat(h.sum))
+	fmt.Fprintf(out, "%s_count{%s} %d\n", name, labels, h.count)
+}
+
+// writeHeader writes the HELP and TYPE lines of a metric
+func writeHeader(out io.Writer, name, metricType, help string) {
+	fmt.Fprintf(out, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, metricType)
+}
+
+// labelEscaper escapes label values as required by the text format
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// quote returns a label value in double quotes, escaped
+func quote(value string) string {
+	return `"` + labelEscaper.Replace(value) + `"`
+}
+
+// formatFloat formats a sample value or bucket bound
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,60 @@
+package metrics_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	registry := metrics.NewRegistry()
+	registry.ObserveRequest("/v0/servers/{id}", http.MethodGet, http.StatusOK, 20*time.Millisecond)
+	registry.ObserveRequest("/v0/servers/{id}", http.MethodGet, http.StatusOK, 3*time.Second)
+	registry.ObserveRequest("/v0/servers/{id}", http.MethodGet, http.StatusNotFound, time.Millisecond)
+	registry.ObserveRequest(`/v0/"quoted"`, http.MethodPost, http.StatusCreated, time.Millisecond)
+	registry.ObserveDatabaseOperation("find", 40*time.Millisecond)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/metrics", nil)
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	registry.ServeHTTP(rr, req)
+
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rr.Header().Get("Content-Type"))
+	body := rr.Body.String()
+	for _, line := range []string{
+		"# TYPE mcp_registry_http_requests_total counter",
+		`mcp_registry_http_requests_total{endpoint="/v0/servers/{id}",method="GET",status="200"} 2`,
+		`mcp_registry_http_requests_total{endpoint="/v0/servers/{id}",method="GET",status="404"} 1`,
+		`mcp_registry_http_requests_total{endpoint="/v0/\"quoted\"",method="POST",status="201"} 1`,
+		"# TYPE mcp_registry_http_request_duration_seconds histogram",
+		`mcp_registry_http_request_duration_seconds_bucket{endpoint="/v0/servers/{id}",method="GET",le="0.005"} 1`,
+		`mcp_registry_http_request_duration_seconds_bucket{endpoint="/v0/servers/{id}",method="GET",le="0.025"} 2`,
+		`mcp_registry_http_request_duration_seconds_bucket{endpoint="/v0/servers/{id}",method="GET",le="2.5"} 2`,
+		`mcp_registry_http_request_duration_seconds_bucket{endpoint="/v0/servers/{id}",method="GET",le="+Inf"} 3`,
+		`mcp_registry_http_request_duration_seconds_sum{endpoint="/v0/servers/{id}",method="GET"} 3.021`,
+		`mcp_registry_http_request_duration_seconds_count{endpoint="/v0/servers/{id}",method="GET"} 3`,
+		"# TYPE mcp_registry_database_operation_duration_seconds histogram",
+		`mcp_registry_database_operation_duration_seconds_bucket{operation="find",le="0.025"} 0`,
+		`mcp_registry_database_operation_duration_seconds_bucket{operation="find",le="0.05"} 1`,
+		`mcp_registry_database_operation_duration_seconds_count{operation="find"} 1`,
+	} {
+		assert.Contains(t, body, line+"\n")
+	}
+
+	// Series are sorted, so scrapes of the same metrics are identical
+	assert.Less(t, strings.Index(body, `endpoint="/v0/\"quoted\""`), strings.Index(body, `endpoint="/v0/servers/{id}"`))
+}
+
+func TestRegistryWithoutMetrics(t *testing.T) {
+	var body strings.Builder
+	require.NoError(t, metrics.NewRegistry().Write(&body))
+	assert.Contains(t, body.String(), "# TYPE mcp_registry_http_requests_total counter\n")
+	assert.NotContains(t, body.String(), "{")
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+)
+
+// contextKey is the type of keys stored in the request context by this package
+type contextKey string
+
+// authClaimsKey is the context key holding the authenticated ephemeral token claims
+const authClaimsKey contextKey = "auth_claims"
+
+// RequireAuth returns a middleware that requires either an ephemeral token or the registry owner token.
+// On success the ephemeral token claims are stored in the request context; they are nil for the registry owner.
+func RequireAuth(authService auth.Service) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Get auth token from Authorization header
+			authHeader := r.Header.Get("Authorization")
+			if authHeader == "" {
+				Logf(r.Context(), "auth: Missing Authorization header from %s", GetRealIP(r))
+				writeError(w, http.StatusUnauthorized, "ERR_AUTH_REQUIRED", "Authorization header is required")
+				return
+			}
+
+			token := auth.ParseAuthorizationHeader(authHeader)
+
+			// Validate either ephemeral token or registry owner token
+			valid, claims, err := authService.ValidateEphemeralOrOwnerToken(r.Context(), token)
+			if err != nil {
+				Logf(r.Context(), "auth: Authentication failed from %s: %v", GetRealIP(r), err)
+				writeError(w, http.StatusUnauthorized, "ERR_AUTH_FAILED", "Authentication failed: "+err.Error())
+				return
+			}
+
+			if !valid {
+				Logf(r.Context(), "auth: Invalid authentication token from %s", GetRealIP(r))
+				writeError(w, http.StatusForbidden, "ERR_FORBIDDEN", "Invalid authentication token")
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), authClaimsKey, claims)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetAuthClaims returns the ephemeral token claims stored by RequireAuth.
+// It returns nil when the request was authenticated with the registry owner token.
+func GetAuthClaims(ctx context.Context) *auth.EphemeralTokenClaims {
+	claims, _ := ctx.Value(authClaimsKey).(*auth.EphemeralTokenClaims)
+	return claims
+}
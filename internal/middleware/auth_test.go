@@ -0,0 +1,117 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubAuthService implements auth.Service, answering token validation with fixed results
+type stubAuthService struct {
+	auth.Service
+	valid  bool
+	claims *auth.EphemeralTokenClaims
+	err    error
+}
+
+func (s *stubAuthService) ValidateEphemeralOrOwnerToken(_ context.Context, _ string) (bool, *auth.EphemeralTokenClaims, error) {
+	return s.valid, s.claims, s.err
+}
+
+func TestRequireAuth(t *testing.T) {
+	userClaims := &auth.EphemeralTokenClaims{GitHubUserID: "42", GitHubUsername: "octocat"}
+
+	testCases := []struct {
+		name           string
+		authHeader     string
+		authService    *stubAuthService
+		expectedStatus int
+		expectedCode   string
+		expectNext     bool
+		expectedClaims *auth.EphemeralTokenClaims
+	}{
+		{
+			name:           "missing authorization header",
+			authService:    &stubAuthService{},
+			expectedStatus: http.StatusUnauthorized,
+			expectedCode:   "ERR_AUTH_REQUIRED",
+		},
+		{
+			name:           "validation error",
+			authHeader:     "Bearer broken",
+			authService:    &stubAuthService{err: errors.New("invalid token")},
+			expectedStatus: http.StatusUnauthorized,
+			expectedCode:   "ERR_AUTH_FAILED",
+		},
+		{
+			name:           "invalid token",
+			authHeader:     "Bearer invalid",
+			authService:    &stubAuthService{valid: false},
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   "ERR_FORBIDDEN",
+		},
+		{
+			name:           "valid ephemeral token",
+			authHeader:     "Bearer ephemeral",
+			authService:    &stubAuthService{valid: true, claims: userClaims},
+			expectedStatus: http.StatusOK,
+			expectNext:     true,
+			expectedClaims: userClaims,
+		},
+		{
+			name:           "registry owner token",
+			authHeader:     "Bearer owner",
+			authService:    &stubAuthService{valid: true},
+			expectedStatus: http.StatusOK,
+			expectNext:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				called bool
+				claims *auth.EphemeralTokenClaims
+			)
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				claims = middleware.GetAuthClaims(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/v0/publish-oss", nil)
+			if tc.authHeader != "" {
+				req.Header.Set("Authorization", tc.authHeader)
+			}
+			rr := httptest.NewRecorder()
+
+			middleware.RequireAuth(tc.authService)(next).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedCode != "" {
+				assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+				var body struct {
+					Code    string `json:"code"`
+					Message string `json:"message"`
+				}
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+				assert.Equal(t, tc.expectedCode, body.Code)
+				assert.NotEmpty(t, body.Message)
+			}
+			assert.Equal(t, tc.expectNext, called)
+			assert.Equal(t, tc.expectedClaims, claims)
+		})
+	}
+}
+
+func TestGetAuthClaimsWithoutMiddleware(t *testing.T) {
+	assert.Nil(t, middleware.GetAuthClaims(context.Background()))
+}
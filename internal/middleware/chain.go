@@ -0,0 +1,12 @@
+package middleware
+
+import "net/http"
+
+// Chain wraps the handler in the given middleware, applied in order: the first middleware is the outermost,
+// so it sees each request first and each response last.
+func Chain(handler http.Handler, middleware ...func(http.Handler) http.Handler) http.Handler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+	return handler
+}
@@ -0,0 +1,127 @@
+package middleware_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/api/router"
+	"github.com/modelcontextprotocol/registry/internal/api/versioning"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/events"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingMiddleware returns a middleware appending its name to calls before and after the wrapped handler
+func recordingMiddleware(name string, calls *[]string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*calls = append(*calls, name+" before")
+			next.ServeHTTP(w, r)
+			*calls = append(*calls, name+" after")
+		})
+	}
+}
+
+func TestChain(t *testing.T) {
+	var calls []string
+	handler := middleware.Chain(
+		http.HandlerFunc(func(http.ResponseWriter, *http.Request) { calls = append(calls, "handler") }),
+		recordingMiddleware("first", &calls),
+		recordingMiddleware("second", &calls),
+		recordingMiddleware("third", &calls),
+	)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/", nil)
+	require.NoError(t, err)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, []string{
+		"first before", "second before", "third before",
+		"handler",
+		"third after", "second after", "first after",
+	}, calls)
+
+	t.Run("without middleware", func(t *testing.T) {
+		calls = nil
+		middleware.Chain(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { calls = append(calls, "handler") })).
+			ServeHTTP(httptest.NewRecorder(), req)
+		assert.Equal(t, []string{"handler"}, calls)
+	})
+}
+
+func TestNewHandler(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	cfg := &config.Config{SecurityHeaders: true, TrustedProxyCIDRs: []string{"10.0.0.0/8"}}
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	handler := router.NewHandler(cfg, registry, nil, events.NewHub(), nil)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers?limit=5", nil)
+	require.NoError(t, err)
+	req.RemoteAddr = "10.0.0.1:5000"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+	req.Header.Set("Accept-Encoding", "gzip")
+	req.Header.Set(middleware.RequestIDHeader, "trace-42")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "nosniff", rr.Header().Get("X-Content-Type-Options"), "security headers should be set")
+	assert.Equal(t, versioning.DefaultVersion, rr.Header().Get(versioning.HeaderName))
+	assert.Equal(t, "gzip", rr.Header().Get("Content-Encoding"))
+	assert.Equal(t, "trace-42", rr.Header().Get(middleware.RequestIDHeader))
+
+	reader, err := gzip.NewReader(rr.Body)
+	require.NoError(t, err)
+	body, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"data":[]`)
+
+	// Logging runs inside RealIP and RequestID, so it logs the forwarded client IP and the request ID, and outside
+	// compression, so it logs the status
+	line := strings.TrimSpace(logs.String())
+	assert.Contains(t, line, "[trace-42] 203.0.113.7 GET /v0/servers 200 ")
+	assert.NotContains(t, line, "limit=5")
+}
+
+func TestNewHandlerMetrics(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+
+	get := func(t *testing.T, handler http.Handler, path string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, path, nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("metrics are served with the API", func(t *testing.T) {
+		handler := router.NewHandler(&config.Config{}, registry, nil, events.NewHub(), nil)
+		get(t, handler, "/v0/servers/by-repo/example/server")
+
+		rr := get(t, handler, "/metrics")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `endpoint="/v0/servers/by-repo/{owner}/{repo}",method="GET",status="404"}`)
+	})
+
+	t.Run("metrics on a separate address are not served with the API", func(t *testing.T) {
+		handler := router.NewHandler(&config.Config{MetricsAddress: ":9090"}, registry, nil, events.NewHub(), nil)
+		assert.Equal(t, http.StatusNotFound, get(t, handler, "/metrics").Code)
+	})
+}
@@ -0,0 +1,137 @@
+package middleware_test
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompress(t *testing.T) {
+	const body = `{"message": "hello"}`
+
+	testCases := []struct {
+		name           string
+		method         string
+		acceptEncoding string
+		contentType    string
+		status         int
+		expectGzip     bool
+	}{
+		{
+			name:           "compresses JSON for clients accepting gzip",
+			acceptEncoding: "gzip, deflate, br",
+			contentType:    "application/json",
+			expectGzip:     true,
+		},
+		{
+			name:           "compresses error responses",
+			acceptEncoding: "gzip",
+			contentType:    "text/plain; charset=utf-8",
+			status:         http.StatusNotFound,
+			expectGzip:     true,
+		},
+		{
+			name:        "client does not accept gzip",
+			contentType: "application/json",
+		},
+		{
+			name:           "client refuses gzip",
+			acceptEncoding: "gzip;q=0, deflate",
+			contentType:    "application/json",
+		},
+		{
+			name:           "images are not compressed",
+			acceptEncoding: "gzip",
+			contentType:    "image/png",
+		},
+		{
+			name:           "event streams are not compressed",
+			acceptEncoding: "gzip",
+			contentType:    "text/event-stream",
+		},
+		{
+			name:           "HEAD requests are not compressed",
+			method:         http.MethodHead,
+			acceptEncoding: "gzip",
+			contentType:    "application/json",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := middleware.Compress(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", tc.contentType)
+				if tc.status != 0 {
+					w.WriteHeader(tc.status)
+				}
+				_, _ = w.Write([]byte(body))
+			}))
+
+			method := tc.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req, err := http.NewRequestWithContext(context.Background(), method, "/", nil)
+			require.NoError(t, err)
+			if tc.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tc.acceptEncoding)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, "Accept-Encoding", rr.Header().Get("Vary"))
+			if tc.status != 0 {
+				assert.Equal(t, tc.status, rr.Code)
+			}
+			if !tc.expectGzip {
+				assert.Empty(t, rr.Header().Get("Content-Encoding"))
+				assert.Equal(t, body, rr.Body.String())
+				return
+			}
+
+			assert.Equal(t, "gzip", rr.Header().Get("Content-Encoding"))
+			reader, err := gzip.NewReader(rr.Body)
+			require.NoError(t, err)
+			decompressed, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			assert.Equal(t, body, string(decompressed))
+		})
+	}
+
+	t.Run("flushed data reaches the client", func(t *testing.T) {
+		flushed := make(chan struct{})
+		done := make(chan struct{})
+		handler := middleware.Compress(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			_, _ = w.Write([]byte("first\n"))
+			w.(http.Flusher).Flush()
+			close(flushed)
+			<-done
+		}))
+
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		<-flushed
+
+		// The client transparently decompresses the response it asked to be compressed
+		line := make([]byte, len("first\n"))
+		_, err = io.ReadFull(resp.Body, line)
+		require.NoError(t, err)
+		assert.Equal(t, "first\n", string(line))
+		assert.True(t, resp.Uncompressed)
+		close(done)
+	})
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// writeError responds with the given status in the error format of the API. The body matches v0.APIError,
+// which this package cannot import.
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}{
+		Code:    code,
+		Message: message,
+	}); err != nil {
+		log.Printf("Failed to encode error response: %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package middleware_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogging(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	testCases := []struct {
+		name     string
+		handler  http.HandlerFunc
+		expected string
+	}{
+		{
+			name:     "implicit status",
+			handler:  func(w http.ResponseWriter, _ *http.Request) { _, _ = w.Write([]byte("ok")) },
+			expected: "192.0.2.1 GET /v0/servers 200 ",
+		},
+		{
+			name:     "explicit status",
+			handler:  func(w http.ResponseWriter, _ *http.Request) { http.Error(w, "Not found", http.StatusNotFound) },
+			expected: "192.0.2.1 GET /v0/servers 404 ",
+		},
+		{
+			name:     "no response body",
+			handler:  func(http.ResponseWriter, *http.Request) {},
+			expected: "192.0.2.1 GET /v0/servers 200 ",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logs.Reset()
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers?token=secret", nil)
+			require.NoError(t, err)
+			req.RemoteAddr = "192.0.2.1:1234"
+			middleware.Logging(tc.handler).ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Contains(t, logs.String(), tc.expected)
+			assert.NotContains(t, logs.String(), "secret", "query strings should not be logged")
+		})
+	}
+}
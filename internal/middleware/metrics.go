@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/metrics"
+)
+
+// unmatchedEndpoint is the endpoint label of requests no route matched, so unknown paths cannot create new series
+const unmatchedEndpoint = "unmatched"
+
+// Metrics returns a middleware that records the count and duration of requests in the registry, labeled with the
+// route pattern that served them rather than the path. It must wrap the router directly: the router records the
+// matched pattern on the request it receives, which middleware replacing the request would hide.
+func Metrics(registry *metrics.Registry) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			recorder := &statusRecorder{ResponseWriter: w}
+			next.ServeHTTP(recorder, r)
+
+			status := recorder.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			endpoint := r.Pattern
+			if endpoint == "" {
+				endpoint = unmatchedEndpoint
+			}
+			registry.ObserveRequest(endpoint, r.Method, status, time.Since(start))
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/metrics"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	registry := metrics.NewRegistry()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0/servers/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") == "missing" {
+			http.Error(w, "Server not found", http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("{}"))
+	})
+	handler := middleware.Metrics(registry)(mux)
+
+	for _, path := range []string{"/v0/servers/a", "/v0/servers/b", "/v0/servers/missing", "/v0/unknown/path"} {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, path, nil)
+		require.NoError(t, err)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	var body strings.Builder
+	require.NoError(t, registry.Write(&body))
+	// Requests are labeled with the route pattern, so each server ID does not create a series
+	assert.Contains(t, body.String(), `mcp_registry_http_requests_total{endpoint="/v0/servers/{id}",method="GET",status="200"} 2`+"\n")
+	assert.Contains(t, body.String(), `mcp_registry_http_requests_total{endpoint="/v0/servers/{id}",method="GET",status="404"} 1`+"\n")
+	assert.Contains(t, body.String(), `mcp_registry_http_requests_total{endpoint="unmatched",method="GET",status="404"} 1`+"\n")
+	assert.NotContains(t, body.String(), "/v0/unknown/path")
+}
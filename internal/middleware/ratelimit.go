@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// rateLimitIdleTimeout is how long the limiter of a client is kept after its last request. Clients idle for
+// longer have a full bucket again, so dropping their limiter does not change their limit.
+const rateLimitIdleTimeout = 10 * time.Minute
+
+// clientLimiter is the token bucket of one client IP
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// rateLimiter holds the token buckets of all clients that sent requests recently
+type rateLimiter struct {
+	limit rate.Limit
+	burst int
+
+	mu        sync.Mutex
+	clients   map[string]*clientLimiter
+	lastSweep time.Time
+}
+
+// RateLimit returns a middleware limiting each client IP, as determined by RealIP, to requestsPerMinute requests
+// per minute on average, with bursts of up to burstSize requests. Requests over the limit are answered with
+// 429 Too Many Requests and a Retry-After header. A requestsPerMinute of 0 or less disables the limit.
+func RateLimit(requestsPerMinute, burstSize int) func(http.Handler) http.Handler {
+	if requestsPerMinute <= 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	limiter := &rateLimiter{
+		limit:   rate.Limit(float64(requestsPerMinute) / 60),
+		burst:   max(burstSize, 1),
+		clients: make(map[string]*clientLimiter),
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if delay := limiter.reserve(GetRealIP(r), time.Now()); delay > 0 {
+				writeRateLimited(w, delay)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// reserve takes a token from the bucket of the client. It returns zero if the request may proceed, and otherwise
+// how long the client has to wait for a token.
+func (l *rateLimiter) reserve(ip string, now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+	client, ok := l.clients[ip]
+	if !ok {
+		client = &clientLimiter{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.clients[ip] = client
+	}
+	client.lastSeen = now
+
+	reservation := client.limiter.ReserveN(now, 1)
+	delay := reservation.DelayFrom(now)
+	if delay > 0 {
+		// Rejected requests do not use up tokens
+		reservation.CancelAt(now)
+	}
+	return delay
+}
+
+// sweep drops the limiters of idle clients, at most once per idle timeout
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < rateLimitIdleTimeout {
+		return
+	}
+	l.lastSweep = now
+	for ip, client := range l.clients {
+		if now.Sub(client.lastSeen) >= rateLimitIdleTimeout {
+			delete(l.clients, ip)
+		}
+	}
+}
+
+// writeRateLimited responds with 429 Too Many Requests, telling the client when to retry
+func writeRateLimited(w http.ResponseWriter, delay time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+	writeError(w, http.StatusTooManyRequests, "ERR_RATE_LIMITED", "Rate limit exceeded, try again later")
+}
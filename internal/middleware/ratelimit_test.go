@@ -0,0 +1,81 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimit(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+
+	// request sends a request from the client IP through the handler and returns the response
+	request := func(t *testing.T, handler http.Handler, remoteAddr string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/search?q=files", nil)
+		require.NoError(t, err)
+		req.RemoteAddr = remoteAddr
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("requests over the burst are rejected", func(t *testing.T) {
+		handler := middleware.RateLimit(60, 3)(ok)
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, http.StatusOK, request(t, handler, "192.0.2.1:1234").Code, "request %d", i+1)
+		}
+
+		rr := request(t, handler, "192.0.2.1:1234")
+		require.Equal(t, http.StatusTooManyRequests, rr.Code)
+		retryAfter, err := strconv.Atoi(rr.Header().Get("Retry-After"))
+		require.NoError(t, err)
+		assert.Equal(t, 1, retryAfter, "a token is added every second at 60 requests per minute")
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+		assert.Equal(t, "ERR_RATE_LIMITED", body["code"])
+		assert.NotEmpty(t, body["message"])
+	})
+
+	t.Run("clients are limited separately", func(t *testing.T) {
+		handler := middleware.RateLimit(1, 1)(ok)
+		assert.Equal(t, http.StatusOK, request(t, handler, "192.0.2.1:1234").Code)
+		assert.Equal(t, http.StatusTooManyRequests, request(t, handler, "192.0.2.1:5678").Code)
+		assert.Equal(t, http.StatusOK, request(t, handler, "192.0.2.2:1234").Code)
+	})
+
+	t.Run("forwarded client IPs are limited behind trusted proxies", func(t *testing.T) {
+		cfg := &config.Config{TrustedProxyCIDRs: []string{"10.0.0.0/8"}}
+		handler := middleware.RealIP(cfg)(middleware.RateLimit(1, 1)(ok))
+
+		forwarded := func(clientIP string) int {
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/publish", nil)
+			require.NoError(t, err)
+			req.RemoteAddr = "10.0.0.1:5000"
+			req.Header.Set("X-Forwarded-For", clientIP)
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			return rr.Code
+		}
+		assert.Equal(t, http.StatusOK, forwarded("203.0.113.7"))
+		assert.Equal(t, http.StatusOK, forwarded("203.0.113.8"), "the proxy itself should not be limited")
+		assert.Equal(t, http.StatusTooManyRequests, forwarded("203.0.113.7"))
+	})
+
+	t.Run("zero disables the limit", func(t *testing.T) {
+		handler := middleware.RateLimit(0, 0)(ok)
+		for i := 0; i < 100; i++ {
+			require.Equal(t, http.StatusOK, request(t, handler, "192.0.2.1:1234").Code)
+		}
+	})
+}
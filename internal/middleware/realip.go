@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// realIPKey is the context key holding the client IP determined by RealIP
+const realIPKey contextKey = "real_ip"
+
+// RealIP returns a middleware that determines the client IP of each request and stores it in the request context.
+// X-Forwarded-For and X-Real-IP are only honored when the request arrives from a trusted proxy; otherwise, or if
+// neither header holds a valid address, the remote address of the connection is used.
+// Invalid CIDR blocks are ignored here; they are rejected by config.Config.Validate at startup.
+func RealIP(cfg *config.Config) func(http.Handler) http.Handler {
+	var trustedProxies []netip.Prefix
+	for _, cidr := range cfg.TrustedProxyCIDRs {
+		if prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr)); err == nil {
+			trustedProxies = append(trustedProxies, prefix.Masked())
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := clientIP(r, trustedProxies)
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), realIPKey, ip)))
+		})
+	}
+}
+
+// GetRealIP returns the client IP stored in the request context by RealIP.
+// It falls back to the remote address of the request if the middleware did not run.
+func GetRealIP(r *http.Request) string {
+	if ip, ok := r.Context().Value(realIPKey).(string); ok {
+		return ip
+	}
+	return remoteIP(r)
+}
+
+// clientIP determines the client IP of a request given the trusted proxy networks
+func clientIP(r *http.Request, trustedProxies []netip.Prefix) string {
+	remote := remoteIP(r)
+	if !isTrusted(remote, trustedProxies) {
+		return remote
+	}
+
+	// Each proxy appends the address it received the request from, so walking the chain back from the nearest
+	// proxy, the first address that is not a trusted proxy is the client. Entries further left are client
+	// supplied and cannot be trusted.
+	if forwardedFor := r.Header.Get("X-Forwarded-For"); forwardedFor != "" {
+		hops := strings.Split(forwardedFor, ",")
+		client := ""
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			if _, err := netip.ParseAddr(hop); err != nil {
+				break
+			}
+			client = hop
+			if !isTrusted(hop, trustedProxies) {
+				break
+			}
+		}
+		if client != "" {
+			return client
+		}
+	}
+
+	if realIP := strings.TrimSpace(r.Header.Get("X-Real-IP")); realIP != "" {
+		if _, err := netip.ParseAddr(realIP); err == nil {
+			return realIP
+		}
+	}
+
+	return remote
+}
+
+// remoteIP returns the IP of the remote address of a request, without the port
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// isTrusted reports whether the IP belongs to one of the trusted proxy networks
+func isTrusted(ip string, trustedProxies []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
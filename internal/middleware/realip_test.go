@@ -0,0 +1,119 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRealIP(t *testing.T) {
+	cfg := &config.Config{TrustedProxyCIDRs: []string{"10.0.0.0/8", " 192.168.1.1/32"}}
+
+	testCases := []struct {
+		name          string
+		remoteAddr    string
+		forwardedFor  string
+		realIP        string
+		expectedIP    string
+		trustedConfig *config.Config
+	}{
+		{
+			name:         "trusted proxy forwards the client IP",
+			remoteAddr:   "10.0.0.1:5000",
+			forwardedFor: "203.0.113.7",
+			expectedIP:   "203.0.113.7",
+		},
+		{
+			name:       "trusted proxy sets X-Real-IP",
+			remoteAddr: "192.168.1.1:5000",
+			realIP:     "203.0.113.8",
+			expectedIP: "203.0.113.8",
+		},
+		{
+			name:         "untrusted proxy headers are ignored",
+			remoteAddr:   "198.51.100.1:5000",
+			forwardedFor: "203.0.113.7",
+			realIP:       "203.0.113.8",
+			expectedIP:   "198.51.100.1",
+		},
+		{
+			name:         "multiple hops select the leftmost untrusted IP of the proxy chain",
+			remoteAddr:   "10.0.0.1:5000",
+			forwardedFor: "203.0.113.7, 10.0.0.3, 10.0.0.2",
+			expectedIP:   "203.0.113.7",
+		},
+		{
+			name:         "spoofed entries left of the client are ignored",
+			remoteAddr:   "10.0.0.1:5000",
+			forwardedFor: "1.2.3.4, 203.0.113.7, 10.0.0.2",
+			expectedIP:   "203.0.113.7",
+		},
+		{
+			name:         "invalid forwarded address falls back to the remote address",
+			remoteAddr:   "10.0.0.1:5000",
+			forwardedFor: "not-an-ip",
+			expectedIP:   "10.0.0.1",
+		},
+		{
+			name:          "no trusted proxies configured",
+			remoteAddr:    "10.0.0.1:5000",
+			forwardedFor:  "203.0.113.7",
+			expectedIP:    "10.0.0.1",
+			trustedConfig: &config.Config{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handlerCfg := cfg
+			if tc.trustedConfig != nil {
+				handlerCfg = tc.trustedConfig
+			}
+
+			var gotIP string
+			handler := middleware.RealIP(handlerCfg)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				gotIP = middleware.GetRealIP(r)
+			}))
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers", nil)
+			require.NoError(t, err)
+			req.RemoteAddr = tc.remoteAddr
+			if tc.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tc.forwardedFor)
+			}
+			if tc.realIP != "" {
+				req.Header.Set("X-Real-IP", tc.realIP)
+			}
+
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+			assert.Equal(t, tc.expectedIP, gotIP)
+		})
+	}
+}
+
+func TestGetRealIPWithoutMiddleware(t *testing.T) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers", nil)
+	require.NoError(t, err)
+	req.RemoteAddr = "198.51.100.1:5000"
+
+	assert.Equal(t, "198.51.100.1", middleware.GetRealIP(req))
+}
+
+func TestValidateTrustedProxyCIDRs(t *testing.T) {
+	cfg := &config.Config{
+		GithubClientID:              "id",
+		GithubClientSecret:          "secret",
+		RegistryOwnerGithubUsername: "owner",
+		TrustedProxyCIDRs:           []string{"10.0.0.0/8"},
+	}
+	require.NoError(t, cfg.Validate())
+
+	cfg.TrustedProxyCIDRs = append(cfg.TrustedProxyCIDRs, "10.0.0.1")
+	assert.Error(t, cfg.Validate())
+}
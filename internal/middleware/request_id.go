@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader is the header carrying the ID of a request, both in requests and responses
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength is the maximum length of request IDs accepted from clients
+const maxRequestIDLength = 128
+
+// requestIDKey is the context key holding the ID of the request
+const requestIDKey contextKey = "request_id"
+
+// RequestID is a middleware that assigns each request an ID, for correlating logs across instances.
+// The ID is taken from the X-Request-ID header if the client or a proxy set a valid one, and generated otherwise.
+// It is stored in the request context and returned in the X-Request-ID response header.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIDKey, id)))
+	})
+}
+
+// GetRequestID returns the request ID stored in the context by RequestID, or an empty string if there is none
+func GetRequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// Logf logs a message like log.Printf, prefixed with the ID of the request the context belongs to
+func Logf(ctx context.Context, format string, args ...any) {
+	if id := GetRequestID(ctx); id != "" {
+		format = "[" + id + "] " + format
+	}
+	log.Printf(format, args...)
+}
+
+// validRequestID reports whether a client supplied request ID may be used. IDs are limited to printable ASCII
+// without spaces, so they cannot break up or forge log lines.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
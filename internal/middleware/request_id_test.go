@@ -0,0 +1,88 @@
+package middleware_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestID(t *testing.T) {
+	testCases := []struct {
+		name       string
+		header     string
+		expectedID string
+	}{
+		{
+			name:       "client ID is propagated",
+			header:     "5f1c2e9a-trace",
+			expectedID: "5f1c2e9a-trace",
+		},
+		{
+			name: "missing ID is generated",
+		},
+		{
+			name:   "ID with spaces is replaced",
+			header: "forged id\nGET /admin",
+		},
+		{
+			name:   "overlong ID is replaced",
+			header: strings.Repeat("a", 129),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var contextID string
+			handler := middleware.RequestID(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				contextID = middleware.GetRequestID(r.Context())
+			}))
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers", nil)
+			require.NoError(t, err)
+			if tc.header != "" {
+				req.Header.Set(middleware.RequestIDHeader, tc.header)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			responseID := rr.Header().Get(middleware.RequestIDHeader)
+			assert.Equal(t, responseID, contextID, "the context and response should hold the same ID")
+			if tc.expectedID != "" {
+				assert.Equal(t, tc.expectedID, responseID)
+			} else {
+				_, err := uuid.Parse(responseID)
+				assert.NoError(t, err, "a UUID should be generated")
+			}
+		})
+	}
+}
+
+func TestLogf(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	middleware.Logf(context.Background(), "no request %d", 1)
+	assert.Contains(t, logs.String(), " no request 1\n")
+	assert.NotContains(t, logs.String(), "[")
+
+	logs.Reset()
+	handler := middleware.RequestID(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		middleware.Logf(r.Context(), "in request %d", 2)
+	}))
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers", nil)
+	require.NoError(t, err)
+	req.Header.Set(middleware.RequestIDHeader, "trace-7")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Contains(t, logs.String(), "[trace-7] in request 2\n")
+}
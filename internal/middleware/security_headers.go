@@ -0,0 +1,40 @@
+// Package middleware contains HTTP middleware shared by all API versions
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// productionEnvironment is the environment name in which HSTS is enabled
+const productionEnvironment = "production"
+
+// securityHeaders are the headers applied to every API response
+var securityHeaders = map[string]string{
+	"Content-Security-Policy": "default-src 'none'",
+	"X-Content-Type-Options":  "nosniff",
+	"X-Frame-Options":         "DENY",
+	"Referrer-Policy":         "no-referrer",
+	"Permissions-Policy":      "interest-cohort=()",
+}
+
+// SecurityHeaders returns a middleware that sets security headers on all responses.
+// Strict-Transport-Security is only sent in the production environment.
+func SecurityHeaders(cfg *config.Config) func(http.Handler) http.Handler {
+	hsts := cfg.Environment == productionEnvironment
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := w.Header()
+			for key, value := range securityHeaders {
+				header.Set(key, value)
+			}
+			if hsts {
+				header.Set("Strict-Transport-Security", "max-age=31536000")
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/api/router"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/events"
+	"github.com/modelcontextprotocol/registry/internal/middleware"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestHandler creates the full API handler wrapped in the security headers middleware
+func newTestHandler(cfg *config.Config) http.Handler {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	mux := router.New(cfg, registry, nil, events.NewHub(), nil)
+	return middleware.SecurityHeaders(cfg)(mux)
+}
+
+func TestSecurityHeaders(t *testing.T) {
+	expectedHeaders := map[string]string{
+		"Content-Security-Policy": "default-src 'none'",
+		"X-Content-Type-Options":  "nosniff",
+		"X-Frame-Options":         "DENY",
+		"Referrer-Policy":         "no-referrer",
+		"Permissions-Policy":      "interest-cohort=()",
+	}
+
+	testCases := []struct {
+		name        string
+		environment string
+		expectHSTS  bool
+	}{
+		{
+			name:        "production sends HSTS",
+			environment: "production",
+			expectHSTS:  true,
+		},
+		{
+			name:        "development does not send HSTS",
+			environment: "development",
+			expectHSTS:  false,
+		},
+	}
+
+	paths := []string{
+		"/v0/health",
+		"/v0/servers",
+		"/v0/servers/550e8400-e29b-41d4-a716-446655440000",
+		"/v0/does-not-exist",
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := newTestHandler(&config.Config{Environment: tc.environment})
+
+			for _, path := range paths {
+				req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, path, nil)
+				require.NoError(t, err)
+
+				rr := httptest.NewRecorder()
+				handler.ServeHTTP(rr, req)
+
+				for key, value := range expectedHeaders {
+					assert.Equal(t, value, rr.Header().Get(key), "header %s on %s", key, path)
+				}
+
+				if tc.expectHSTS {
+					assert.Equal(t, "max-age=31536000", rr.Header().Get("Strict-Transport-Security"), path)
+				} else {
+					assert.Empty(t, rr.Header().Get("Strict-Transport-Security"), path)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CompareSemVer compares two semantic versions following the precedence rules of https://semver.org:
+// it returns -1 if a < b, 0 if they have the same precedence and +1 if a > b. Build metadata is ignored.
+func CompareSemVer(a, b string) (int, error) {
+	partsA := semVerRegex.FindStringSubmatch(a)
+	if partsA == nil {
+		return 0, fmt.Errorf("invalid semantic version %q", a)
+	}
+	partsB := semVerRegex.FindStringSubmatch(b)
+	if partsB == nil {
+		return 0, fmt.Errorf("invalid semantic version %q", b)
+	}
+
+	// Major, minor and patch versions are compared numerically
+	for i := 1; i <= 3; i++ {
+		if c := compareNumeric(partsA[i], partsB[i]); c != 0 {
+			return c, nil
+		}
+	}
+
+	// A version without a pre-release has higher precedence than one with a pre-release
+	preA, preB := partsA[4], partsB[4]
+	switch {
+	case preA == preB:
+		return 0, nil
+	case preA == "":
+		return 1, nil
+	case preB == "":
+		return -1, nil
+	}
+
+	// Pre-release identifiers are compared one by one: numeric identifiers numerically and lower than
+	// alphanumeric ones, alphanumeric identifiers in ASCII order. A longer list wins if all others are equal.
+	idsA, idsB := strings.Split(preA, "."), strings.Split(preB, ".")
+	for i := 0; i < len(idsA) && i < len(idsB); i++ {
+		_, errA := strconv.ParseUint(idsA[i], 10, 64)
+		_, errB := strconv.ParseUint(idsB[i], 10, 64)
+		var c int
+		switch {
+		case errA == nil && errB == nil:
+			c = compareNumeric(idsA[i], idsB[i])
+		case errA == nil:
+			c = -1
+		case errB == nil:
+			c = 1
+		default:
+			c = strings.Compare(idsA[i], idsB[i])
+		}
+		if c != 0 {
+			return c, nil
+		}
+	}
+	switch {
+	case len(idsA) < len(idsB):
+		return -1, nil
+	case len(idsA) > len(idsB):
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// compareNumeric compares two decimal numbers without leading zeros, which may exceed the range of int
+func compareNumeric(a, b string) int {
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// CompatibleWith reports whether a client implementing clientVersion of the MCP protocol can use a server
+// requiring at least serverMinVersion. Servers without a minimum version are compatible with every client.
+func CompatibleWith(serverMinVersion, clientVersion string) (bool, error) {
+	if serverMinVersion == "" {
+		return true, nil
+	}
+	c, err := CompareSemVer(serverMinVersion, clientVersion)
+	if err != nil {
+		return false, err
+	}
+	return c <= 0, nil
+}
@@ -0,0 +1,61 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareSemVer(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"1.0.0", "2.0.0", -1},
+		{"1.10.0", "1.9.0", 1},
+		{"1.0.10", "1.0.9", 1},
+		{"1.0.0-alpha", "1.0.0", -1},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{"1.0.0-beta.2", "1.0.0-beta.11", -1},
+		{"1.0.0-rc.1", "1.0.0-beta", 1},
+		{"1.0.0+build.1", "1.0.0+build.2", 0},
+		{"18446744073709551616.0.0", "1.0.0", 1},
+	}
+	for _, tc := range testCases {
+		c, err := model.CompareSemVer(tc.a, tc.b)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, c, "%s vs %s", tc.a, tc.b)
+	}
+
+	_, err := model.CompareSemVer("1.0", "1.0.0")
+	assert.Error(t, err)
+}
+
+func TestCompatibleWith(t *testing.T) {
+	testCases := []struct {
+		name             string
+		serverMinVersion string
+		clientVersion    string
+		expected         bool
+	}{
+		{name: "no minimum version", serverMinVersion: "", clientVersion: "0.1.0", expected: true},
+		{name: "same version", serverMinVersion: "1.0.0", clientVersion: "1.0.0", expected: true},
+		{name: "newer client", serverMinVersion: "1.0.0", clientVersion: "1.2.0", expected: true},
+		{name: "older client", serverMinVersion: "2.0.0", clientVersion: "1.9.9", expected: false},
+		{name: "pre-release client", serverMinVersion: "2.0.0", clientVersion: "2.0.0-rc.1", expected: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			compatible, err := model.CompatibleWith(tc.serverMinVersion, tc.clientVersion)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, compatible)
+		})
+	}
+
+	_, err := model.CompatibleWith("1.0.0", "latest")
+	assert.Error(t, err)
+}
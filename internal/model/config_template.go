@@ -0,0 +1,135 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MCP clients GetConfigTemplate can generate configuration for
+const (
+	ConfigClientClaudeDesktop = "claude-desktop"
+	ConfigClientVSCode        = "vscode"
+)
+
+var (
+	// ErrUnsupportedConfigClient is returned for clients without a known configuration format
+	ErrUnsupportedConfigClient = errors.New("unsupported MCP client")
+	// ErrUnsupportedConfigRegistry is returned for packages of registries without a known runner
+	ErrUnsupportedConfigRegistry = errors.New("no runner is known for the package registry")
+)
+
+// clientServerConfig is the configuration of a server started by an MCP client over stdio
+type clientServerConfig struct {
+	// Type is only used by VS Code, which also supports remote servers
+	Type    string            `json:"type,omitempty"`
+	Command string            `json:"command"`
+	Args    []string          `json:"args"`
+	Env     map[string]string `json:"env,omitempty"`
+}
+
+// claudeDesktopConfig is the format of claude_desktop_config.json
+type claudeDesktopConfig struct {
+	MCPServers map[string]clientServerConfig `json:"mcpServers"`
+}
+
+// vsCodeInput is a value VS Code prompts for when it first starts the server
+type vsCodeInput struct {
+	Type        string `json:"type"`
+	ID          string `json:"id"`
+	Description string `json:"description,omitempty"`
+	Password    bool   `json:"password,omitempty"`
+}
+
+// vsCodeConfig is the format of the mcp.json file of VS Code
+type vsCodeConfig struct {
+	Inputs  []vsCodeInput                 `json:"inputs,omitempty"`
+	Servers map[string]clientServerConfig `json:"servers"`
+}
+
+// GetConfigTemplate returns a configuration snippet starting the package over stdio, in the format of the
+// configuration file of the MCP client. The server is keyed by the package name without its scope.
+// Environment variables without a value are left empty for Claude Desktop; VS Code prompts for them.
+func GetConfigTemplate(pkg Package, client string) (json.RawMessage, error) {
+	if client != ConfigClientClaudeDesktop && client != ConfigClientVSCode {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedConfigClient, client)
+	}
+
+	var command, ref string
+	var prefix []string
+	switch strings.ToLower(pkg.RegistryName) {
+	case "npm":
+		command, prefix, ref = "npx", []string{"-y"}, pkg.Name+"@"+pkg.Version
+	case "pypi":
+		command, ref = "uvx", pkg.Name+"=="+pkg.Version
+	case "docker":
+		command, prefix, ref = "docker", []string{"run", "-i", "--rm"}, pkg.Name+":"+pkg.Version
+		// Containers only see the variables passed to them
+		for _, variable := range pkg.EnvironmentVariables {
+			prefix = append(prefix, "-e", variable.Name)
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedConfigRegistry, pkg.RegistryName)
+	}
+
+	server := clientServerConfig{Command: command}
+	server.Args = append(server.Args, prefix...)
+	server.Args = append(server.Args, configArguments(pkg.RuntimeArguments)...)
+	server.Args = append(server.Args, ref)
+	server.Args = append(server.Args, configArguments(pkg.PackageArguments)...)
+
+	var inputs []vsCodeInput
+	if len(pkg.EnvironmentVariables) > 0 {
+		server.Env = make(map[string]string, len(pkg.EnvironmentVariables))
+	}
+	for _, variable := range pkg.EnvironmentVariables {
+		value := variable.Value
+		if value == "" {
+			value = variable.Default
+		}
+		if value == "" && client == ConfigClientVSCode {
+			inputs = append(inputs, vsCodeInput{
+				Type: "promptString", ID: variable.Name, Description: variable.Description, Password: variable.IsSecret,
+			})
+			value = "${input:" + variable.Name + "}"
+		}
+		server.Env[variable.Name] = value
+	}
+
+	name := configServerName(pkg.Name)
+	if client == ConfigClientVSCode {
+		server.Type = "stdio"
+		return json.Marshal(vsCodeConfig{Inputs: inputs, Servers: map[string]clientServerConfig{name: server}})
+	}
+	return json.Marshal(claudeDesktopConfig{MCPServers: map[string]clientServerConfig{name: server}})
+}
+
+// configArguments returns the command line words of the arguments, using their value or default.
+// Arguments without either are left for the user to add.
+func configArguments(args []Argument) []string {
+	words := []string{}
+	for _, arg := range args {
+		value := arg.Value
+		if value == "" {
+			value = arg.Default
+		}
+		switch arg.Type {
+		case ArgumentTypeNamed:
+			words = append(words, arg.Name)
+			if value != "" {
+				words = append(words, value)
+			}
+		case ArgumentTypePositional:
+			if value != "" {
+				words = append(words, value)
+			}
+		}
+	}
+	return words
+}
+
+// configServerName returns the last segment of a package name, e.g. weather for @example/weather
+func configServerName(packageName string) string {
+	return packageName[strings.LastIndex(packageName, "/")+1:]
+}
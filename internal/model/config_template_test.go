@@ -0,0 +1,83 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetConfigTemplateClaudeDesktop(t *testing.T) {
+	pkg := model.Package{
+		RegistryName: "npm",
+		Name:         "@example/weather",
+		Version:      "1.2.3",
+		PackageArguments: []model.Argument{
+			{Type: model.ArgumentTypeNamed, Name: "--units", InputWithVariables: model.InputWithVariables{
+				Input: model.Input{Default: "metric"},
+			}},
+			{Type: model.ArgumentTypePositional, ValueHint: "directory"},
+		},
+		EnvironmentVariables: []model.KeyValueInput{
+			{Name: "WEATHER_API_KEY", InputWithVariables: model.InputWithVariables{
+				Input: model.Input{IsRequired: true, IsSecret: true},
+			}},
+			{Name: "WEATHER_REGION", InputWithVariables: model.InputWithVariables{
+				Input: model.Input{Default: "eu"},
+			}},
+		},
+	}
+
+	config, err := model.GetConfigTemplate(pkg, model.ConfigClientClaudeDesktop)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"mcpServers": {
+			"weather": {
+				"command": "npx",
+				"args": ["-y", "@example/weather@1.2.3", "--units", "metric"],
+				"env": {"WEATHER_API_KEY": "", "WEATHER_REGION": "eu"}
+			}
+		}
+	}`, string(config))
+}
+
+func TestGetConfigTemplateVSCode(t *testing.T) {
+	pkg := model.Package{
+		RegistryName: "docker",
+		Name:         "example/weather",
+		Version:      "1.2.3",
+		RuntimeArguments: []model.Argument{
+			{Type: model.ArgumentTypeNamed, Name: "--network", InputWithVariables: model.InputWithVariables{
+				Input: model.Input{Value: "host"},
+			}},
+		},
+		EnvironmentVariables: []model.KeyValueInput{
+			{Name: "WEATHER_API_KEY", InputWithVariables: model.InputWithVariables{
+				Input: model.Input{Description: "Weather API key", IsRequired: true, IsSecret: true},
+			}},
+		},
+	}
+
+	config, err := model.GetConfigTemplate(pkg, model.ConfigClientVSCode)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"inputs": [{"type": "promptString", "id": "WEATHER_API_KEY", "description": "Weather API key", "password": true}],
+		"servers": {
+			"weather": {
+				"type": "stdio",
+				"command": "docker",
+				"args": ["run", "-i", "--rm", "-e", "WEATHER_API_KEY", "--network", "host", "example/weather:1.2.3"],
+				"env": {"WEATHER_API_KEY": "${input:WEATHER_API_KEY}"}
+			}
+		}
+	}`, string(config))
+}
+
+func TestGetConfigTemplateUnsupported(t *testing.T) {
+	_, err := model.GetConfigTemplate(model.Package{RegistryName: "npm", Name: "weather", Version: "1.0.0"}, "emacs")
+	assert.ErrorIs(t, err, model.ErrUnsupportedConfigClient)
+
+	_, err = model.GetConfigTemplate(model.Package{RegistryName: "nuget", Name: "Weather", Version: "1.0.0"}, "vscode")
+	assert.ErrorIs(t, err, model.ErrUnsupportedConfigRegistry)
+}
@@ -1,5 +1,13 @@
 package model
 
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
 // AuthMethod represents the authentication method used
 type AuthMethod string
 
@@ -28,7 +36,138 @@ type PublishOSSRequest struct {
 	RepositoryURL string    `json:"repository_url"`
 	Owner         string    `json:"owner,omitempty"`
 	Repo          string    `json:"repo,omitempty"`
+	Version       string    `json:"version,omitempty"`
+	Category      Category  `json:"category,omitempty"`
 	Packages      []Package `json:"packages"`
+	// Schema is the optional JSON schema the server exposes as an MCP tool
+	Schema *json.RawMessage `json:"schema,omitempty"`
+	// Tools are the optional MCP tools the server declares
+	Tools []MCPTool `json:"tools,omitempty"`
+	// MinMCPVersion is the optional oldest MCP protocol version clients must implement to use the server
+	MinMCPVersion string `json:"min_mcp_version,omitempty"`
+	// Tags are optional labels chosen by the publisher
+	Tags []string `json:"tags,omitempty"`
+	// License is the optional SPDX identifier of the server's license
+	License string `json:"license,omitempty"`
+	// Maintainers optionally describe the people maintaining the server; the publisher is added automatically
+	Maintainers []Maintainer `json:"maintainers,omitempty"`
+}
+
+// Maintainer identifies a person maintaining a server. Every field is optional.
+type Maintainer struct {
+	Name           string `json:"name,omitempty" bson:"name,omitempty"`
+	Email          string `json:"email,omitempty" bson:"email,omitempty"`
+	GitHubUsername string `json:"github_username,omitempty" bson:"github_username,omitempty"`
+}
+
+// Category classifies the purpose of a server
+type Category string
+
+const (
+	// CategoryLLMTools represents tools that extend language model capabilities
+	CategoryLLMTools Category = "llm-tools"
+	// CategoryFilesystem represents servers that access local or remote files
+	CategoryFilesystem Category = "filesystem"
+	// CategoryDatabase represents servers that query or manage databases
+	CategoryDatabase Category = "database"
+	// CategoryCommunication represents servers for messaging, email and chat
+	CategoryCommunication Category = "communication"
+	// CategoryDeveloperTools represents servers for software development workflows
+	CategoryDeveloperTools Category = "developer-tools"
+	// CategoryProductivity represents servers for notes, calendars and task management
+	CategoryProductivity Category = "productivity"
+	// CategoryOther represents servers that fit no other category
+	CategoryOther Category = "other"
+)
+
+// Categories lists all defined categories
+var Categories = []Category{
+	CategoryLLMTools,
+	CategoryFilesystem,
+	CategoryDatabase,
+	CategoryCommunication,
+	CategoryDeveloperTools,
+	CategoryProductivity,
+	CategoryOther,
+}
+
+// IsValid reports whether the category is one of the defined categories
+func (c Category) IsValid() bool {
+	for _, category := range Categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// Lifecycle statuses of a server
+const (
+	// StatusActive marks a server that is maintained; servers published before statuses existed count as active
+	StatusActive = "active"
+	// StatusDeprecated marks a server its publisher no longer recommends but that still works
+	StatusDeprecated = "deprecated"
+	// StatusArchived marks a server that is no longer maintained. Only the registry owner may reactivate it.
+	StatusArchived = "archived"
+)
+
+// IsValidStatus reports whether status is one of the lifecycle statuses
+func IsValidStatus(status string) bool {
+	return status == StatusActive || status == StatusDeprecated || status == StatusArchived
+}
+
+// CategoryCount represents the number of servers in a category
+type CategoryCount struct {
+	Category Category `json:"category"`
+	Count    int      `json:"count"`
+}
+
+// LanguageCount represents the number of servers written in a programming language
+type LanguageCount struct {
+	Language string `json:"language"`
+	Count    int    `json:"count"`
+}
+
+// RegistryStats represents aggregate metrics of the registry
+type RegistryStats struct {
+	// ServerCount is the number of servers, counting each server once whatever its number of versions
+	ServerCount int `json:"server_count"`
+	// ServersByRegistry is the number of servers whose latest version has a package in each package registry
+	ServersByRegistry map[string]int `json:"servers_by_registry"`
+	// Published is the number of server versions the registry received in recent periods
+	Published PublishedCounts `json:"published"`
+	// PublisherCount is the number of distinct GitHub users and organizations owning servers
+	PublisherCount int `json:"publisher_count"`
+	// VerifiedChecksumCount is the number of servers with at least one package whose checksum the registry verified
+	VerifiedChecksumCount int `json:"verified_checksum_count"`
+}
+
+// PublishedCounts is the number of server versions published in the periods ending now
+type PublishedCounts struct {
+	Last24Hours int `json:"last_24h" bson:"last_24h"`
+	Last7Days   int `json:"last_7d" bson:"last_7d"`
+	Last30Days  int `json:"last_30d" bson:"last_30d"`
+}
+
+// TopicCount represents the number of servers tagged with a repository topic
+type TopicCount struct {
+	Topic string `json:"topic"`
+	Count int    `json:"count"`
+}
+
+// TagCount represents the number of servers carrying a publisher-chosen tag
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+// Facets are the number of servers with each value of the fields searches filter on. Servers count towards each
+// package registry they publish to and each of their tags; servers without a status count as active.
+type Facets struct {
+	RegistryNames map[string]int `json:"registry_name"`
+	Tags          map[string]int `json:"tags"`
+	Licenses      map[string]int `json:"license"`
+	Statuses      map[string]int `json:"status"`
 }
 
 // Repository represents a source code repository as defined in the spec
@@ -36,6 +175,17 @@ type Repository struct {
 	URL    string `json:"url" bson:"url"`
 	Source string `json:"source" bson:"source"`
 	ID     string `json:"id" bson:"id"`
+	// DefaultBranch is the default branch of the repository, recorded when publishing from GitHub
+	DefaultBranch string `json:"default_branch,omitempty" bson:"default_branch,omitempty"`
+}
+
+// Branches returns the branches to try, in order, when fetching files from the repository: its default branch,
+// or main and then master for servers published before the default branch was recorded
+func (r Repository) Branches() []string {
+	if r.DefaultBranch != "" {
+		return []string{r.DefaultBranch}
+	}
+	return []string{"main", "master"}
 }
 
 // ServerList represents the response for listing servers as defined in the spec
@@ -101,6 +251,15 @@ type Package struct {
 	RuntimeArguments     []Argument      `json:"runtime_arguments,omitempty" bson:"runtime_arguments,omitempty"`
 	PackageArguments     []Argument      `json:"package_arguments,omitempty" bson:"package_arguments,omitempty"`
 	EnvironmentVariables []KeyValueInput `json:"environment_variables,omitempty" bson:"environment_variables,omitempty"`
+	// VerifiedChecksum is the checksum of the package version as published by its registry, fetched by the
+	// registry after publishing so clients can verify downloads
+	VerifiedChecksum string `json:"verified_checksum,omitempty" bson:"verified_checksum,omitempty"`
+	// DocURL links to the documentation of the package. It must use HTTPS; packages published without one
+	// link to their page on the npm, PyPI or Docker Hub registry.
+	DocURL string `json:"doc_url,omitempty" bson:"doc_url,omitempty"`
+	// Yanked is set when the publisher reports the package version was pulled from its registry
+	Yanked     bool   `json:"yanked,omitempty" bson:"yanked,omitempty"`
+	YankReason string `json:"yank_reason,omitempty" bson:"yank_reason,omitempty"`
 }
 
 // Remote represents a remote connection endpoint
@@ -115,15 +274,179 @@ type VersionDetail struct {
 	Version     string `json:"version" bson:"version"`
 	ReleaseDate string `json:"release_date" bson:"release_date"`
 	IsLatest    bool   `json:"is_latest" bson:"is_latest"`
+	// Yanked is set on versions a publisher pulled; they are kept but never marked as latest
+	Yanked bool `json:"yanked,omitempty" bson:"yanked,omitempty"`
+}
+
+// ServerVersion identifies a single published version of a server
+type ServerVersion struct {
+	ID            string `json:"id"`
+	VersionDetail `json:",inline"`
+}
+
+// semVerRegex matches semantic versions as defined at https://semver.org
+var semVerRegex = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// IsValidSemVer reports whether the version is a valid semantic version
+func IsValidSemVer(version string) bool {
+	return semVerRegex.MatchString(version)
+}
+
+// ChangelogEntry describes a release of a server as published on its source repository
+type ChangelogEntry struct {
+	// Version is the release tag without a leading "v"
+	Version     string    `json:"version"`
+	ReleaseDate time.Time `json:"release_date"`
+	Title       string    `json:"title"`
+	// Body holds the release notes, usually Markdown
+	Body    string `json:"body"`
+	HTMLURL string `json:"html_url"`
+}
+
+// RepositoryStats represents cached statistics about a server's source repository. They are fetched by the
+// registry, never taken from the publisher.
+type RepositoryStats struct {
+	Stars int `json:"stars" bson:"stars"`
+	// SyncedAt is when the registry last fetched the statistics
+	SyncedAt time.Time `json:"synced_at" bson:"synced_at"`
 }
 
 // Server represents a basic server information as defined in the spec
 type Server struct {
-	ID            string        `json:"id" bson:"id"`
-	Name          string        `json:"name" bson:"name"`
-	Description   string        `json:"description" bson:"description"`
-	Repository    Repository    `json:"repository" bson:"repository"`
-	VersionDetail VersionDetail `json:"version_detail" bson:"version_detail"`
+	ID              string           `json:"id" bson:"id"`
+	Name            string           `json:"name" bson:"name"`
+	Description     string           `json:"description" bson:"description"`
+	Repository      Repository       `json:"repository" bson:"repository"`
+	VersionDetail   VersionDetail    `json:"version_detail" bson:"version_detail"`
+	RepositoryStats *RepositoryStats `json:"repository_stats,omitempty" bson:"repository_stats,omitempty"`
+	Category        Category         `json:"category,omitempty" bson:"category,omitempty"`
+	// Language is the primary programming language of the server's repository, in lowercase
+	Language string `json:"language,omitempty" bson:"language,omitempty"`
+	// Topics are the topics of the server's repository, in lowercase
+	Topics []string `json:"topics,omitempty" bson:"topics,omitempty"`
+	// Tags are labels chosen by the publisher, in lowercase
+	Tags []string `json:"tags,omitempty" bson:"tags,omitempty"`
+	// License is the SPDX identifier of the server's license, e.g. "MIT"
+	License string `json:"license,omitempty" bson:"license,omitempty"`
+	// Status is the lifecycle status of the server, one of the Status constants, shared by all its versions
+	Status string `json:"status,omitempty" bson:"status,omitempty"`
+	// Maintainers describe the people maintaining the server, for display. Unlike MaintainedBy they grant no
+	// permissions.
+	Maintainers []Maintainer `json:"maintainers,omitempty" bson:"maintainers,omitempty"`
+	// MinMCPVersion is the oldest MCP protocol version, as a semantic version, that clients must implement
+	// to use the server. It is empty if the server works with every client.
+	MinMCPVersion string `json:"min_mcp_version,omitempty" bson:"min_mcp_version,omitempty"`
+	// PinnedVersion is set on the version a publisher pinned as latest; publishing a new version clears it
+	PinnedVersion bool `json:"pinned_version,omitempty" bson:"pinned_version,omitempty"`
+	// PublisherUsername is the GitHub user ownership was transferred to; it is empty until the server changes hands
+	PublisherUsername string `json:"publisher_username,omitempty" bson:"publisher_username,omitempty"`
+	// MaintainedBy lists the GitHub users who may modify the server alongside its publisher.
+	// The publisher is added on publish; the list is shared by all versions of the server.
+	MaintainedBy []string `json:"maintained_by,omitempty" bson:"maintained_by,omitempty"`
+	// ContactEmail is the address security researchers can report vulnerabilities to.
+	// Public endpoints replace it with MaskedContactEmail; only admin endpoints return it in full.
+	ContactEmail       string `json:"contact_email,omitempty" bson:"contact_email,omitempty"`
+	MaskedContactEmail string `json:"masked_contact_email,omitempty" bson:"-"`
+	// Deprecated marks a server its publisher no longer recommends; SuccessorID optionally names the server
+	// replacing it
+	Deprecated  bool   `json:"deprecated,omitempty" bson:"deprecated,omitempty"`
+	SuccessorID string `json:"successor_id,omitempty" bson:"successor_id,omitempty"`
+	// DeprecationCycle is set on reads when following the successors of the server leads back to it
+	DeprecationCycle bool `json:"deprecation_cycle,omitempty" bson:"-"`
+	// HealthCheckURL is an HTTPS endpoint of a hosted server that answers when the server is reachable
+	HealthCheckURL string `json:"health_check_url,omitempty" bson:"health_check_url,omitempty"`
+	// FeaturedOrder is the position of a featured server in the featured list, starting at 0.
+	// It is nil for servers that are not featured.
+	FeaturedOrder *int `json:"featured_order,omitempty" bson:"featured_order,omitempty"`
+	// PublishedAt is the time the registry received the version, set server-side on publish.
+	// Unlike VersionDetail.ReleaseDate it cannot be chosen by the publisher.
+	PublishedAt time.Time `json:"published_at" bson:"published_at,omitempty"`
+	// PublishedBy is the GitHub user who published the version, set server-side on publish.
+	// It is empty for versions published by the registry owner or before it was recorded.
+	PublishedBy string `json:"published_by,omitempty" bson:"published_by,omitempty"`
+	// UpdatedAt is the time the server was last modified, used for incremental sync
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at,omitempty"`
+	// CreatedSeq is a monotonically increasing sequence number assigned on publish, used for stable pagination
+	CreatedSeq int64 `json:"-" bson:"created_seq,omitempty"`
+}
+
+// namespaceOwnerRegex extracts the owner from an io.github.<owner>/<repo> server name
+var namespaceOwnerRegex = regexp.MustCompile(`^io\.github\.([^/]+)/`)
+
+// NamespaceOwner returns the GitHub user or organization owning the io.github namespace of a server name,
+// or an empty string if the name is not in an io.github namespace
+func NamespaceOwner(name string) string {
+	if matches := namespaceOwnerRegex.FindStringSubmatch(name); matches != nil {
+		return matches[1]
+	}
+	return ""
+}
+
+// Publisher returns the GitHub username of the server's owner: the user ownership was transferred to,
+// or otherwise the owner of the server's io.github namespace. It is empty if neither is known.
+func (s Server) Publisher() string {
+	if s.PublisherUsername != "" {
+		return s.PublisherUsername
+	}
+	return NamespaceOwner(s.Name)
+}
+
+// IsMaintainer reports whether the GitHub user is the publisher or one of the maintainers of the server
+func (s Server) IsMaintainer(username string) bool {
+	if username == "" {
+		return false
+	}
+	return strings.EqualFold(username, s.Publisher()) || s.maintainerIndex(username) >= 0
+}
+
+// AddMaintainer adds the GitHub user to the maintainers of the server unless they are already listed
+func (s *Server) AddMaintainer(username string) {
+	if username == "" || s.maintainerIndex(username) >= 0 {
+		return
+	}
+	s.MaintainedBy = append(s.MaintainedBy, username)
+}
+
+// RemoveMaintainer removes the GitHub user from the maintainers of the server and reports whether they were listed
+func (s *Server) RemoveMaintainer(username string) bool {
+	i := s.maintainerIndex(username)
+	if i < 0 {
+		return false
+	}
+	s.MaintainedBy = slices.Delete(slices.Clone(s.MaintainedBy), i, i+1)
+	return true
+}
+
+// maintainerIndex returns the position of the GitHub user in the maintainers list, ignoring case, or -1
+func (s Server) maintainerIndex(username string) int {
+	return slices.IndexFunc(s.MaintainedBy, func(maintainer string) bool {
+		return strings.EqualFold(maintainer, username)
+	})
+}
+
+// RedactContactEmail replaces the contact email with its masked form, for responses from public endpoints
+func (s *Server) RedactContactEmail() {
+	if s.ContactEmail == "" {
+		return
+	}
+	s.MaskedContactEmail = MaskEmail(s.ContactEmail)
+	s.ContactEmail = ""
+}
+
+// MaskEmail keeps only the first two characters of the local part of an email address, followed by the domain,
+// e.g. "security@example.com" becomes "se***@example.com"
+func MaskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return "***"
+	}
+	local, domain := email[:at], email[at+1:]
+	if len(local) > 2 {
+		local = local[:2]
+	}
+	return local + "***@" + domain
 }
 
 // ServerDetail represents detailed server information as defined in the spec
@@ -131,4 +454,211 @@ type ServerDetail struct {
 	Server   `json:",inline" bson:",inline"`
 	Packages []Package `json:"packages,omitempty" bson:"packages,omitempty"`
 	Remotes  []Remote  `json:"remotes,omitempty" bson:"remotes,omitempty"`
+	// InstallScore is a derived quality score between 0 and 100; it is nil for servers that have not been scored
+	InstallScore *int `json:"install_score,omitempty" bson:"-"`
+	// AverageRating and ReviewCount summarize the community reviews of the server.
+	// They are recomputed whenever a review is submitted.
+	AverageRating float64 `json:"average_rating" bson:"average_rating,omitempty"`
+	ReviewCount   int     `json:"review_count" bson:"review_count,omitempty"`
+	// Schema is the optional JSON schema the server exposes as an MCP tool. It is accepted on publish
+	// and stored separately from the server document; HasSchema reports whether one was published.
+	Schema    *json.RawMessage `json:"schema,omitempty" bson:"-"`
+	HasSchema bool             `json:"has_schema,omitempty" bson:"has_schema,omitempty"`
+	// Tools are the MCP tools the server declares, searchable by name
+	Tools []MCPTool `json:"tools,omitempty" bson:"tools,omitempty"`
+	// Dependencies are the IDs of the registry servers this server depends on
+	Dependencies []string `json:"dependencies,omitempty" bson:"dependencies,omitempty"`
+	// GitHubMetadata is the repository information GitHub returned when the server was published
+	// through publish-oss. It is only served to the registry owner for debugging.
+	GitHubMetadata json.RawMessage `json:"-" bson:"github_metadata,omitempty"`
+	// Highlights are the fragments of the name and description that match the query of a search, keyed by field.
+	// They are only set in search results.
+	Highlights map[string][]string `json:"highlights,omitempty" bson:"-"`
+}
+
+// MCPTool describes an MCP tool declared by a server
+type MCPTool struct {
+	Name        string          `json:"name" bson:"name"`
+	Description string          `json:"description,omitempty" bson:"description,omitempty"`
+	InputSchema json.RawMessage `json:"input_schema,omitempty" bson:"input_schema,omitempty"`
+}
+
+// ServerMinimal represents the minimal identifying information of a server
+type ServerMinimal struct {
+	ID   string `json:"id" bson:"id"`
+	Name string `json:"name" bson:"name"`
+}
+
+// NetworkRelation describes how two servers in a network are connected
+type NetworkRelation string
+
+const (
+	// NetworkRelationSamePublisher connects servers owned by the same GitHub user
+	NetworkRelationSamePublisher NetworkRelation = "same_publisher"
+	// NetworkRelationSameTag connects servers sharing at least one tag
+	NetworkRelationSameTag NetworkRelation = "same_tag"
+	// NetworkRelationDependsOn connects a server to a server it depends on
+	NetworkRelationDependsOn NetworkRelation = "depends_on"
+)
+
+// MaxNetworkNodes is the maximum number of servers in a network, including the server it was built for
+const MaxNetworkNodes = 50
+
+// NetworkEdge connects two servers in a network
+type NetworkEdge struct {
+	From     string          `json:"from"`
+	To       string          `json:"to"`
+	Relation NetworkRelation `json:"relation"`
+}
+
+// ServerNetwork is the ecosystem of a server: the servers directly connected to it through a shared publisher,
+// a shared tag or a dependency. The server itself is the first node.
+type ServerNetwork struct {
+	Nodes []ServerMinimal `json:"nodes"`
+	Edges []NetworkEdge   `json:"edges"`
+}
+
+// ReindexJobStatus represents the state of a text index rebuild job
+type ReindexJobStatus string
+
+const (
+	// ReindexJobRunning indicates the index is being rebuilt
+	ReindexJobRunning ReindexJobStatus = "running"
+	// ReindexJobCompleted indicates the index was rebuilt successfully
+	ReindexJobCompleted ReindexJobStatus = "completed"
+	// ReindexJobFailed indicates the index rebuild failed
+	ReindexJobFailed ReindexJobStatus = "failed"
+)
+
+// ReindexJob tracks the progress of a text index rebuild
+type ReindexJob struct {
+	ID                 string           `json:"job_id" bson:"id"`
+	Status             ReindexJobStatus `json:"status" bson:"status"`
+	DocumentsProcessed int64            `json:"documents_processed" bson:"documents_processed"`
+	Error              string           `json:"error,omitempty" bson:"error,omitempty"`
+	StartedAt          time.Time        `json:"started_at" bson:"started_at"`
+	CompletedAt        *time.Time       `json:"completed_at,omitempty" bson:"completed_at,omitempty"`
+}
+
+// VacuumJobStatus represents the state of a database vacuum job
+type VacuumJobStatus string
+
+const (
+	// VacuumJobPending indicates the vacuum job was accepted but has not started yet
+	VacuumJobPending VacuumJobStatus = "pending"
+	// VacuumJobRunning indicates the vacuum phases are running
+	VacuumJobRunning VacuumJobStatus = "running"
+	// VacuumJobCompleted indicates all vacuum phases completed successfully
+	VacuumJobCompleted VacuumJobStatus = "completed"
+	// VacuumJobFailed indicates at least one vacuum phase failed
+	VacuumJobFailed VacuumJobStatus = "failed"
+)
+
+// VacuumPhase reports the outcome of one phase of a vacuum job
+type VacuumPhase struct {
+	Name             string `json:"name" bson:"name"`
+	DocumentsRemoved int64  `json:"documents_removed" bson:"documents_removed"`
+	BytesReclaimed   int64  `json:"bytes_reclaimed" bson:"bytes_reclaimed"`
+	Error            string `json:"error,omitempty" bson:"error,omitempty"`
+}
+
+// VacuumJob tracks the progress of a database vacuum, which removes stale records and compacts storage
+type VacuumJob struct {
+	ID             string          `json:"job_id" bson:"id"`
+	Status         VacuumJobStatus `json:"status" bson:"status"`
+	Phases         []VacuumPhase   `json:"phases" bson:"phases"`
+	BytesReclaimed int64           `json:"bytes_reclaimed" bson:"bytes_reclaimed"`
+	Error          string          `json:"error,omitempty" bson:"error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at" bson:"created_at"`
+	CompletedAt    *time.Time      `json:"completed_at,omitempty" bson:"completed_at,omitempty"`
+}
+
+// Provenance is an in-toto attestation carrying the SLSA provenance of a server version
+type Provenance struct {
+	ServerID   string `json:"server_id" bson:"server_id"`
+	ServerName string `json:"name" bson:"server_name"`
+	Version    string `json:"version" bson:"version"`
+	// Attestation is the attestation JSON exactly as submitted by the publisher
+	Attestation json.RawMessage `json:"attestation" bson:"attestation"`
+	CreatedAt   time.Time       `json:"created_at" bson:"created_at"`
+}
+
+// TrendingEntry is a server along with the number of times it was viewed in a trending period
+type TrendingEntry struct {
+	ServerID string `json:"server_id"`
+	Name     string `json:"name,omitempty"`
+	Views    int64  `json:"views"`
+}
+
+// Bounds of a review rating
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+// MaxReviewCommentLength is the maximum length of a review comment in characters
+const MaxReviewCommentLength = 500
+
+// Review is a community rating of a server by a GitHub user
+type Review struct {
+	ServerID       string    `json:"server_id" bson:"server_id"`
+	GitHubUserID   string    `json:"github_user_id" bson:"github_user_id"`
+	GitHubUsername string    `json:"github_username" bson:"github_username"`
+	Rating         int       `json:"rating" bson:"rating"`
+	Comment        string    `json:"comment,omitempty" bson:"comment,omitempty"`
+	CreatedAt      time.Time `json:"created_at" bson:"created_at"`
+}
+
+// SubscriptionEvent identifies a change to a server that subscriptions can be notified about
+type SubscriptionEvent string
+
+const (
+	// SubscriptionEventVersionPublished is delivered when a new version of the server is published
+	SubscriptionEventVersionPublished SubscriptionEvent = "version.published"
+	// SubscriptionEventVersionUpdated is delivered when a published version of the server is modified
+	SubscriptionEventVersionUpdated SubscriptionEvent = "version.updated"
+)
+
+// IsValid reports whether the event is one subscriptions can be notified about
+func (e SubscriptionEvent) IsValid() bool {
+	return e == SubscriptionEventVersionPublished || e == SubscriptionEventVersionUpdated
+}
+
+// MaxSubscriptionsPerUser is the maximum number of webhook subscriptions a GitHub user may hold
+const MaxSubscriptionsPerUser = 50
+
+// Subscription is a webhook notified about changes to all versions of a server.
+// Each server has at most one subscription per webhook URL.
+type Subscription struct {
+	ID             string              `json:"id" bson:"id"`
+	ServerID       string              `json:"server_id" bson:"server_id"`
+	ServerName     string              `json:"name" bson:"server_name"`
+	WebhookURL     string              `json:"webhook_url" bson:"webhook_url"`
+	Events         []SubscriptionEvent `json:"events" bson:"events"`
+	GitHubUserID   string              `json:"github_user_id" bson:"github_user_id"`
+	GitHubUsername string              `json:"github_username" bson:"github_username"`
+	CreatedAt      time.Time           `json:"created_at" bson:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at" bson:"updated_at"`
+}
+
+// APIKey is a long-lived credential the registry owner issues to a GitHub user, for example for CI environments
+// that cannot complete the GitHub OAuth flow. Requests authenticated with it act as the owning user. Only a bcrypt
+// hash of the secret part of the key is stored.
+type APIKey struct {
+	ID                  string    `json:"id" bson:"id"`
+	HashedSecret        []byte    `json:"-" bson:"hashed_secret"`
+	OwnerGitHubUsername string    `json:"owner_github_username" bson:"owner_github_username"`
+	CreatedAt           time.Time `json:"created_at" bson:"created_at"`
+	// ExpiresAt is the time the key stops being accepted; a zero time never expires
+	ExpiresAt   time.Time `json:"expires_at,omitempty" bson:"expires_at,omitempty"`
+	Description string    `json:"description,omitempty" bson:"description,omitempty"`
+}
+
+// WebhookPayload is the body delivered to the webhook of a subscription
+type WebhookPayload struct {
+	SubscriptionID string            `json:"subscription_id"`
+	Event          SubscriptionEvent `json:"event"`
+	ServerID       string            `json:"server_id"`
+	Name           string            `json:"name"`
+	Timestamp      time.Time         `json:"timestamp"`
 }
@@ -0,0 +1,33 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepositoryBranches(t *testing.T) {
+	testCases := []struct {
+		name       string
+		repository model.Repository
+		expected   []string
+	}{
+		{
+			name:       "default branch",
+			repository: model.Repository{URL: "https://github.com/example/server", DefaultBranch: "develop"},
+			expected:   []string{"develop"},
+		},
+		{
+			name:       "legacy server without a default branch",
+			repository: model.Repository{URL: "https://github.com/example/server"},
+			expected:   []string{"main", "master"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.repository.Branches())
+		})
+	}
+}
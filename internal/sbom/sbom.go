@@ -0,0 +1,305 @@
+// Package sbom builds software bills of materials for registry entries in the CycloneDX and SPDX formats
+package sbom
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/model"
+)
+
+// Format is a software bill of materials format
+type Format string
+
+const (
+	// FormatCycloneDX is CycloneDX 1.4 JSON
+	FormatCycloneDX Format = "cyclonedx"
+	// FormatSPDX is SPDX 2.3 JSON
+	FormatSPDX Format = "spdx"
+)
+
+// IsValid reports whether the format is a supported format
+func (f Format) IsValid() bool {
+	return f == FormatCycloneDX || f == FormatSPDX
+}
+
+// ContentType returns the media type of documents in the format
+func (f Format) ContentType() string {
+	if f == FormatSPDX {
+		return "application/spdx+json"
+	}
+	return "application/vnd.cyclonedx+json; version=1.4"
+}
+
+// toolName identifies the registry as the creator of the documents
+const toolName = "mcp-registry"
+
+// Dependencies maps the purl of each package of a server to the dependencies its manifest declares,
+// by dependency name and version range
+type Dependencies map[string]map[string]string
+
+// CycloneDXDocument is a CycloneDX 1.4 bill of materials
+type CycloneDXDocument struct {
+	BOMFormat    string                `json:"bomFormat"`
+	SpecVersion  string                `json:"specVersion"`
+	SerialNumber string                `json:"serialNumber"`
+	Version      int                   `json:"version"`
+	Metadata     CycloneDXMetadata     `json:"metadata"`
+	Components   []CycloneDXComponent  `json:"components"`
+	Dependencies []CycloneDXDependency `json:"dependencies"`
+}
+
+// CycloneDXMetadata describes the bill of materials and the server it is about
+type CycloneDXMetadata struct {
+	Timestamp string             `json:"timestamp"`
+	Tools     []CycloneDXTool    `json:"tools"`
+	Component CycloneDXComponent `json:"component"`
+}
+
+// CycloneDXTool is a tool that created the bill of materials
+type CycloneDXTool struct {
+	Name string `json:"name"`
+}
+
+// CycloneDXComponent is the server, one of its packages or a dependency of a package
+type CycloneDXComponent struct {
+	Type    string          `json:"type"`
+	BOMRef  string          `json:"bom-ref"`
+	Name    string          `json:"name"`
+	Version string          `json:"version,omitempty"`
+	PURL    string          `json:"purl,omitempty"`
+	Hashes  []CycloneDXHash `json:"hashes,omitempty"`
+}
+
+// CycloneDXHash is a checksum of a component
+type CycloneDXHash struct {
+	Algorithm string `json:"alg"`
+	Content   string `json:"content"`
+}
+
+// CycloneDXDependency lists the components a component depends on
+type CycloneDXDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+// SPDXDocument is an SPDX 2.3 document
+type SPDXDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      SPDXCreationInfo   `json:"creationInfo"`
+	Packages          []SPDXPackage      `json:"packages"`
+	Relationships     []SPDXRelationship `json:"relationships"`
+}
+
+// SPDXCreationInfo records when and by whom the document was created
+type SPDXCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+// SPDXPackage is the server, one of its packages or a dependency of a package
+type SPDXPackage struct {
+	SPDXID           string            `json:"SPDXID"`
+	Name             string            `json:"name"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	Checksums        []SPDXChecksum    `json:"checksums,omitempty"`
+	ExternalRefs     []SPDXExternalRef `json:"externalRefs,omitempty"`
+}
+
+// SPDXChecksum is a checksum of a package
+type SPDXChecksum struct {
+	Algorithm string `json:"algorithm"`
+	Value     string `json:"checksumValue"`
+}
+
+// SPDXExternalRef identifies a package in a package manager
+type SPDXExternalRef struct {
+	Category string `json:"referenceCategory"`
+	Type     string `json:"referenceType"`
+	Locator  string `json:"referenceLocator"`
+}
+
+// SPDXRelationship relates two elements of the document
+type SPDXRelationship struct {
+	Element        string `json:"spdxElementId"`
+	Type           string `json:"relationshipType"`
+	RelatedElement string `json:"relatedSpdxElement"`
+}
+
+// purlTypes maps registry names to package URL types; other registries use the generic type
+var purlTypes = map[string]string{
+	"npm":    "npm",
+	"pypi":   "pypi",
+	"docker": "docker",
+	"nuget":  "nuget",
+	"cargo":  "cargo",
+	"gem":    "gem",
+}
+
+// PackageURL returns the package URL (purl) of a package version; the version is omitted if it is empty
+func PackageURL(registryName, name, version string) string {
+	purlType, ok := purlTypes[strings.ToLower(registryName)]
+	if !ok {
+		purlType = "generic"
+	}
+	if purlType == "pypi" {
+		name = strings.ToLower(name)
+	}
+
+	// Namespaces such as npm scopes are separated by a slash, and each segment is percent-encoded
+	segments := strings.Split(name, "/")
+	for i, segment := range segments {
+		segments[i] = strings.ReplaceAll(url.PathEscape(segment), "@", "%40")
+	}
+
+	purl := "pkg:" + purlType + "/" + strings.Join(segments, "/")
+	if version != "" {
+		purl += "@" + url.PathEscape(version)
+	}
+	return purl
+}
+
+// dependencyNames returns the names of the dependencies in a stable order
+func dependencyNames(dependencies map[string]string) []string {
+	names := make([]string, 0, len(dependencies))
+	for name := range dependencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// serverRef is the reference of the server itself in the documents
+func serverRef(serverDetail *model.ServerDetail) string {
+	return "server:" + serverDetail.Name + "@" + serverDetail.VersionDetail.Version
+}
+
+// NewCycloneDX builds a CycloneDX bill of materials listing the packages of a server and their dependencies.
+// Dependencies are listed with the version ranges their manifests declare.
+func NewCycloneDX(serverDetail *model.ServerDetail, dependencies Dependencies, now time.Time) *CycloneDXDocument {
+	root := serverRef(serverDetail)
+	doc := &CycloneDXDocument{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.4",
+		SerialNumber: "urn:uuid:" + uuid.NewString(),
+		Version:      1,
+		Metadata: CycloneDXMetadata{
+			Timestamp: now.UTC().Format(time.RFC3339),
+			Tools:     []CycloneDXTool{{Name: toolName}},
+			Component: CycloneDXComponent{
+				Type:    "application",
+				BOMRef:  root,
+				Name:    serverDetail.Name,
+				Version: serverDetail.VersionDetail.Version,
+			},
+		},
+		Components:   []CycloneDXComponent{},
+		Dependencies: []CycloneDXDependency{},
+	}
+
+	seen := make(map[string]bool)
+	rootDependency := CycloneDXDependency{Ref: root, DependsOn: []string{}}
+	for _, pkg := range serverDetail.Packages {
+		purl := PackageURL(pkg.RegistryName, pkg.Name, pkg.Version)
+		rootDependency.DependsOn = append(rootDependency.DependsOn, purl)
+		if seen[purl] {
+			continue
+		}
+		seen[purl] = true
+
+		component := CycloneDXComponent{Type: "library", BOMRef: purl, Name: pkg.Name, Version: pkg.Version, PURL: purl}
+		if pkg.VerifiedChecksum != "" {
+			// npm publishes SHA-1 shasums, the only checksums the registry verifies
+			component.Hashes = []CycloneDXHash{{Algorithm: "SHA-1", Content: pkg.VerifiedChecksum}}
+		}
+		doc.Components = append(doc.Components, component)
+
+		packageDependency := CycloneDXDependency{Ref: purl, DependsOn: []string{}}
+		for _, name := range dependencyNames(dependencies[purl]) {
+			ref := PackageURL(pkg.RegistryName, name, "")
+			packageDependency.DependsOn = append(packageDependency.DependsOn, ref)
+			if seen[ref] {
+				continue
+			}
+			seen[ref] = true
+			doc.Components = append(doc.Components, CycloneDXComponent{
+				Type: "library", BOMRef: ref, Name: name, Version: dependencies[purl][name], PURL: ref,
+			})
+		}
+		doc.Dependencies = append(doc.Dependencies, packageDependency)
+	}
+	doc.Dependencies = append([]CycloneDXDependency{rootDependency}, doc.Dependencies...)
+
+	return doc
+}
+
+// NewSPDX builds an SPDX document listing the packages of a server and their dependencies.
+// Dependencies are listed with the version ranges their manifests declare.
+func NewSPDX(serverDetail *model.ServerDetail, dependencies Dependencies, now time.Time) *SPDXDocument {
+	const rootID = "SPDXRef-Server"
+	doc := &SPDXDocument{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              serverDetail.Name + "@" + serverDetail.VersionDetail.Version,
+		DocumentNamespace: "https://registry.modelcontextprotocol.io/spdx/" + serverDetail.ID + "/" + uuid.NewString(),
+		CreationInfo: SPDXCreationInfo{
+			Created:  now.UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: " + toolName},
+		},
+		Packages: []SPDXPackage{{
+			SPDXID:           rootID,
+			Name:             serverDetail.Name,
+			VersionInfo:      serverDetail.VersionDetail.Version,
+			DownloadLocation: "NOASSERTION",
+		}},
+		Relationships: []SPDXRelationship{{Element: "SPDXRef-DOCUMENT", Type: "DESCRIBES", RelatedElement: rootID}},
+	}
+
+	ids := make(map[string]string)
+	addPackage := func(purl, name, version string) (string, bool) {
+		if id, ok := ids[purl]; ok {
+			return id, false
+		}
+		id := "SPDXRef-Package-" + strconv.Itoa(len(ids)+1)
+		ids[purl] = id
+		doc.Packages = append(doc.Packages, SPDXPackage{
+			SPDXID:           id,
+			Name:             name,
+			VersionInfo:      version,
+			DownloadLocation: "NOASSERTION",
+			ExternalRefs:     []SPDXExternalRef{{Category: "PACKAGE-MANAGER", Type: "purl", Locator: purl}},
+		})
+		return id, true
+	}
+
+	for _, pkg := range serverDetail.Packages {
+		purl := PackageURL(pkg.RegistryName, pkg.Name, pkg.Version)
+		id, added := addPackage(purl, pkg.Name, pkg.Version)
+		doc.Relationships = append(doc.Relationships, SPDXRelationship{Element: rootID, Type: "DEPENDS_ON", RelatedElement: id})
+		if !added {
+			continue
+		}
+		if pkg.VerifiedChecksum != "" {
+			doc.Packages[len(doc.Packages)-1].Checksums = []SPDXChecksum{{Algorithm: "SHA1", Value: pkg.VerifiedChecksum}}
+		}
+
+		for _, name := range dependencyNames(dependencies[purl]) {
+			dependencyID, _ := addPackage(PackageURL(pkg.RegistryName, name, ""), name, dependencies[purl][name])
+			doc.Relationships = append(doc.Relationships, SPDXRelationship{
+				Element: id, Type: "DEPENDS_ON", RelatedElement: dependencyID,
+			})
+		}
+	}
+
+	return doc
+}
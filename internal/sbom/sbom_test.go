@@ -0,0 +1,117 @@
+package sbom_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/sbom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageURL(t *testing.T) {
+	testCases := []struct {
+		registryName string
+		name         string
+		version      string
+		expected     string
+	}{
+		{"npm", "@modelcontextprotocol/server-filesystem", "1.0.2", "pkg:npm/%40modelcontextprotocol/server-filesystem@1.0.2"},
+		{"npm", "left-pad", "", "pkg:npm/left-pad"},
+		{"pypi", "MCP-Server-Git", "0.6.2", "pkg:pypi/mcp-server-git@0.6.2"},
+		{"docker", "mcp/postgres", "latest", "pkg:docker/mcp/postgres@latest"},
+		{"homebrew", "mcp-server", "2.0.0", "pkg:generic/mcp-server@2.0.0"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			assert.Equal(t, tc.expected, sbom.PackageURL(tc.registryName, tc.name, tc.version))
+		})
+	}
+}
+
+// newServerDetail returns a server with an npm package with a verified checksum and a docker image
+func newServerDetail() *model.ServerDetail {
+	return &model.ServerDetail{
+		Server: model.Server{
+			ID:            "123e4567-e89b-12d3-a456-426614174000",
+			Name:          "io.github.example/server",
+			VersionDetail: model.VersionDetail{Version: "1.0.2"},
+		},
+		Packages: []model.Package{
+			{RegistryName: "npm", Name: "@example/server", Version: "1.0.2", VerifiedChecksum: "abc123"},
+			{RegistryName: "docker", Name: "example/server", Version: "1.0.2"},
+		},
+	}
+}
+
+func TestNewCycloneDX(t *testing.T) {
+	npmPURL := "pkg:npm/%40example/server@1.0.2"
+	dependencies := sbom.Dependencies{npmPURL: {"zod": "^3.22.0", "express": "^4.18.0"}}
+	now := time.Date(2025, 5, 17, 17, 34, 22, 0, time.UTC)
+
+	doc := sbom.NewCycloneDX(newServerDetail(), dependencies, now)
+
+	// Round trip through JSON to check the field names of the format
+	encoded, err := json.Marshal(doc)
+	require.NoError(t, err)
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, "CycloneDX", decoded["bomFormat"])
+	assert.Equal(t, "1.4", decoded["specVersion"])
+	assert.Regexp(t, `^urn:uuid:[0-9a-f-]{36}$`, decoded["serialNumber"])
+	assert.InDelta(t, 1, decoded["version"], 0)
+
+	assert.Equal(t, "2025-05-17T17:34:22Z", doc.Metadata.Timestamp)
+	assert.Equal(t, "application", doc.Metadata.Component.Type)
+	assert.Equal(t, "io.github.example/server", doc.Metadata.Component.Name)
+
+	assert.Equal(t, []sbom.CycloneDXComponent{
+		{
+			Type: "library", BOMRef: npmPURL, Name: "@example/server", Version: "1.0.2", PURL: npmPURL,
+			Hashes: []sbom.CycloneDXHash{{Algorithm: "SHA-1", Content: "abc123"}},
+		},
+		{Type: "library", BOMRef: "pkg:npm/express", Name: "express", Version: "^4.18.0", PURL: "pkg:npm/express"},
+		{Type: "library", BOMRef: "pkg:npm/zod", Name: "zod", Version: "^3.22.0", PURL: "pkg:npm/zod"},
+		{
+			Type: "library", BOMRef: "pkg:docker/example/server@1.0.2", Name: "example/server", Version: "1.0.2",
+			PURL: "pkg:docker/example/server@1.0.2",
+		},
+	}, doc.Components)
+
+	assert.Equal(t, []sbom.CycloneDXDependency{
+		{Ref: doc.Metadata.Component.BOMRef, DependsOn: []string{npmPURL, "pkg:docker/example/server@1.0.2"}},
+		{Ref: npmPURL, DependsOn: []string{"pkg:npm/express", "pkg:npm/zod"}},
+		{Ref: "pkg:docker/example/server@1.0.2", DependsOn: []string{}},
+	}, doc.Dependencies)
+}
+
+func TestNewSPDX(t *testing.T) {
+	npmPURL := "pkg:npm/%40example/server@1.0.2"
+	dependencies := sbom.Dependencies{npmPURL: {"zod": "^3.22.0"}}
+	now := time.Date(2025, 5, 17, 17, 34, 22, 0, time.UTC)
+
+	doc := sbom.NewSPDX(newServerDetail(), dependencies, now)
+
+	assert.Equal(t, "SPDX-2.3", doc.SPDXVersion)
+	assert.Equal(t, "CC0-1.0", doc.DataLicense)
+	assert.Equal(t, "SPDXRef-DOCUMENT", doc.SPDXID)
+	assert.Equal(t, "io.github.example/server@1.0.2", doc.Name)
+	assert.Contains(t, doc.DocumentNamespace, "123e4567-e89b-12d3-a456-426614174000")
+	assert.Equal(t, "2025-05-17T17:34:22Z", doc.CreationInfo.Created)
+
+	require.Len(t, doc.Packages, 4)
+	assert.Equal(t, "SPDXRef-Server", doc.Packages[0].SPDXID)
+	assert.Equal(t, []sbom.SPDXChecksum{{Algorithm: "SHA1", Value: "abc123"}}, doc.Packages[1].Checksums)
+	assert.Equal(t, npmPURL, doc.Packages[1].ExternalRefs[0].Locator)
+	assert.Equal(t, "zod", doc.Packages[2].Name)
+
+	assert.Equal(t, []sbom.SPDXRelationship{
+		{Element: "SPDXRef-DOCUMENT", Type: "DESCRIBES", RelatedElement: "SPDXRef-Server"},
+		{Element: "SPDXRef-Server", Type: "DEPENDS_ON", RelatedElement: doc.Packages[1].SPDXID},
+		{Element: doc.Packages[1].SPDXID, Type: "DEPENDS_ON", RelatedElement: doc.Packages[2].SPDXID},
+		{Element: "SPDXRef-Server", Type: "DEPENDS_ON", RelatedElement: doc.Packages[3].SPDXID},
+	}, doc.Relationships)
+}
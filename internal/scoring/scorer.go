@@ -0,0 +1,89 @@
+// Package scoring computes derived quality metrics for registry entries
+package scoring
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/model"
+)
+
+// MinStars is the number of repository stars a server needs to earn the popularity points
+const MinStars = 10
+
+// Points awarded for each scoring criterion.
+// The registry does not record READMEs yet, so the 20 points for a README are not awarded.
+const (
+	DescriptionPoints       = 10
+	SemVerPoints            = 10
+	LicensePoints           = 10
+	TagsPoints              = 10
+	EnvVarDocsPoints        = 10
+	StarsPoints             = 20
+	OwnershipVerifiedPoints = 10
+)
+
+// MaxScore is the highest score a server can currently reach
+const MaxScore = DescriptionPoints + SemVerPoints + LicensePoints + TagsPoints + EnvVarDocsPoints + StarsPoints +
+	OwnershipVerifiedPoints
+
+// repositoryOwnerRegex extracts the owner from a GitHub repository URL
+var repositoryOwnerRegex = regexp.MustCompile(`github\.com/([^/]+)/`)
+
+// ComputeScore returns the install score of a server, between 0 and MaxScore
+func ComputeScore(detail *model.ServerDetail) int {
+	score := 0
+	if strings.TrimSpace(detail.Description) != "" {
+		score += DescriptionPoints
+	}
+	if model.IsValidSemVer(detail.VersionDetail.Version) {
+		score += SemVerPoints
+	}
+	if detail.License != "" {
+		score += LicensePoints
+	}
+	if len(detail.Tags) > 0 {
+		score += TagsPoints
+	}
+	if hasEnvVarDocs(detail) {
+		score += EnvVarDocsPoints
+	}
+	if hasSyncedStars(detail) {
+		score += StarsPoints
+	}
+	if isOwnershipVerified(detail) {
+		score += OwnershipVerifiedPoints
+	}
+	return score
+}
+
+// hasSyncedStars reports whether the server's repository has enough stars according to the stats the registry
+// synced from GitHub. Stats without a sync time did not come from the registry and earn nothing.
+func hasSyncedStars(detail *model.ServerDetail) bool {
+	stats := detail.RepositoryStats
+	return stats != nil && !stats.SyncedAt.IsZero() && stats.Stars >= MinStars
+}
+
+// hasEnvVarDocs reports whether every environment variable declared by the server's packages is described.
+// Servers that need no environment variables have nothing left to document.
+func hasEnvVarDocs(detail *model.ServerDetail) bool {
+	for _, pkg := range detail.Packages {
+		for _, envVar := range pkg.EnvironmentVariables {
+			if strings.TrimSpace(envVar.Description) == "" {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isOwnershipVerified reports whether the server's publisher is the owner of its repository.
+// Publishing into an io.github namespace requires a GitHub token of that owner.
+func isOwnershipVerified(detail *model.ServerDetail) bool {
+	publisher := detail.Publisher()
+	repoMatch := repositoryOwnerRegex.FindStringSubmatch(detail.Repository.URL)
+	if publisher == "" || repoMatch == nil {
+		return false
+	}
+	return strings.EqualFold(publisher, repoMatch[1])
+}
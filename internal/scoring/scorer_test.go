@@ -0,0 +1,173 @@
+package scoring_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/scoring"
+	"github.com/stretchr/testify/assert"
+)
+
+func envVar(name, description string) model.KeyValueInput {
+	return model.KeyValueInput{
+		Name: name,
+		InputWithVariables: model.InputWithVariables{
+			Input: model.Input{Description: description},
+		},
+	}
+}
+
+func TestComputeScore(t *testing.T) {
+	// undocumentedPackages keeps the env var criterion from scoring so each case isolates one criterion
+	undocumentedPackages := []model.Package{{
+		RegistryName:         "npm",
+		Name:                 "example",
+		EnvironmentVariables: []model.KeyValueInput{envVar("API_KEY", "")},
+	}}
+
+	syncedAt := time.Date(2025, 5, 17, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		detail   model.ServerDetail
+		expected int
+	}{
+		{
+			name:     "no criteria met",
+			detail:   model.ServerDetail{Packages: undocumentedPackages},
+			expected: 0,
+		},
+		{
+			name: "description",
+			detail: model.ServerDetail{
+				Server:   model.Server{Description: "A useful server"},
+				Packages: undocumentedPackages,
+			},
+			expected: scoring.DescriptionPoints,
+		},
+		{
+			name: "blank description",
+			detail: model.ServerDetail{
+				Server:   model.Server{Description: "   "},
+				Packages: undocumentedPackages,
+			},
+			expected: 0,
+		},
+		{
+			name: "semantic version",
+			detail: model.ServerDetail{
+				Server:   model.Server{VersionDetail: model.VersionDetail{Version: "1.2.3-beta.1"}},
+				Packages: undocumentedPackages,
+			},
+			expected: scoring.SemVerPoints,
+		},
+		{
+			name: "non semantic version",
+			detail: model.ServerDetail{
+				Server:   model.Server{VersionDetail: model.VersionDetail{Version: "latest"}},
+				Packages: undocumentedPackages,
+			},
+			expected: 0,
+		},
+		{
+			name: "documented environment variables",
+			detail: model.ServerDetail{
+				Packages: []model.Package{{
+					RegistryName:         "npm",
+					Name:                 "example",
+					EnvironmentVariables: []model.KeyValueInput{envVar("API_KEY", "Key for the example API")},
+				}},
+			},
+			expected: scoring.EnvVarDocsPoints,
+		},
+		{
+			name:     "no environment variables to document",
+			detail:   model.ServerDetail{},
+			expected: scoring.EnvVarDocsPoints,
+		},
+		{
+			name: "license",
+			detail: model.ServerDetail{
+				Server:   model.Server{License: "MIT"},
+				Packages: undocumentedPackages,
+			},
+			expected: scoring.LicensePoints,
+		},
+		{
+			name: "tags",
+			detail: model.ServerDetail{
+				Server:   model.Server{Tags: []string{"database"}},
+				Packages: undocumentedPackages,
+			},
+			expected: scoring.TagsPoints,
+		},
+		{
+			name: "enough stars",
+			detail: model.ServerDetail{
+				Server:   model.Server{RepositoryStats: &model.RepositoryStats{Stars: scoring.MinStars, SyncedAt: syncedAt}},
+				Packages: undocumentedPackages,
+			},
+			expected: scoring.StarsPoints,
+		},
+		{
+			name: "too few stars",
+			detail: model.ServerDetail{
+				Server:   model.Server{RepositoryStats: &model.RepositoryStats{Stars: scoring.MinStars - 1, SyncedAt: syncedAt}},
+				Packages: undocumentedPackages,
+			},
+			expected: 0,
+		},
+		{
+			name: "stars not synced by the registry",
+			detail: model.ServerDetail{
+				Server:   model.Server{RepositoryStats: &model.RepositoryStats{Stars: 1_000_000}},
+				Packages: undocumentedPackages,
+			},
+			expected: 0,
+		},
+		{
+			name: "ownership verified",
+			detail: model.ServerDetail{
+				Server: model.Server{
+					Name:       "io.github.Example/server",
+					Repository: model.Repository{URL: "https://github.com/example/server"},
+				},
+				Packages: undocumentedPackages,
+			},
+			expected: scoring.OwnershipVerifiedPoints,
+		},
+		{
+			name: "namespace owner differs from repository owner",
+			detail: model.ServerDetail{
+				Server: model.Server{
+					Name:       "io.github.someone/server",
+					Repository: model.Repository{URL: "https://github.com/example/server"},
+				},
+				Packages: undocumentedPackages,
+			},
+			expected: 0,
+		},
+		{
+			name: "all criteria met",
+			detail: model.ServerDetail{
+				Server: model.Server{
+					Name:            "io.github.example/server",
+					Description:     "A useful server",
+					Repository:      model.Repository{URL: "https://github.com/example/server"},
+					VersionDetail:   model.VersionDetail{Version: "1.0.0"},
+					License:         "MIT",
+					Tags:            []string{"database"},
+					RepositoryStats: &model.RepositoryStats{Stars: 42, SyncedAt: syncedAt},
+				},
+			},
+			expected: scoring.MaxScore,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, scoring.ComputeScore(&tc.detail))
+		})
+	}
+}
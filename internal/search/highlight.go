@@ -0,0 +1,80 @@
+package search
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// highlightContext is the number of characters kept around a match in a highlight, half before and half after it
+const highlightContext = 40
+
+// maxHighlights caps the number of highlights extracted from a single text
+const maxHighlights = 3
+
+// Terms returns the terms of a query that matched text is highlighted for: its double-quoted phrases followed by
+// the words outside of them
+func Terms(query string) []string {
+	return append(Phrases(query), words(query)...)
+}
+
+// Highlights returns the fragments of text around case-insensitive occurrences of the terms, in the order they
+// appear in the text. Each fragment holds the match and up to highlightContext characters around it; fragments
+// that would overlap are merged. At most maxHighlights fragments are returned.
+func Highlights(text string, terms []string) []string {
+	runes := []rune(text)
+	lower := toLowerRunes(runes)
+
+	// Mark the characters covered by a match of any term
+	matched := make([]bool, len(runes))
+	for _, term := range terms {
+		needle := toLowerRunes([]rune(term))
+		if len(needle) == 0 {
+			continue
+		}
+		for i := 0; i+len(needle) <= len(lower); i++ {
+			if slices.Equal(lower[i:i+len(needle)], needle) {
+				for j := i; j < i+len(needle); j++ {
+					matched[j] = true
+				}
+			}
+		}
+	}
+
+	var highlights []string
+	for i := 0; i < len(runes) && len(highlights) < maxHighlights; {
+		if !matched[i] {
+			i++
+			continue
+		}
+		start := max(i-highlightContext/2, 0)
+		end := i
+		// Extend the fragment over every match starting within the context after the previous one
+		for end < len(runes) {
+			next := end
+			for next < len(runes) && !matched[next] && next-end < highlightContext {
+				next++
+			}
+			if next == len(runes) || !matched[next] {
+				break
+			}
+			for next < len(runes) && matched[next] {
+				next++
+			}
+			end = next
+		}
+		stop := min(end+highlightContext/2, len(runes))
+		highlights = append(highlights, strings.TrimSpace(string(runes[start:stop])))
+		i = stop
+	}
+	return highlights
+}
+
+// toLowerRunes lowercases each character separately, so indexes into the result are indexes into the input
+func toLowerRunes(runes []rune) []rune {
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+	return lower
+}
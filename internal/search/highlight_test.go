@@ -0,0 +1,60 @@
+package search_test
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/search"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerms(t *testing.T) {
+	assert.Equal(t, []string{"mcp filesystem", "server", "tools"}, search.Terms(`server "mcp   filesystem" tools`))
+	assert.Empty(t, search.Terms(`  "" `))
+}
+
+func TestHighlights(t *testing.T) {
+	description := "A server that gives agents a persistent memory, backed by a knowledge graph stored on the local disk. " +
+		"Memory entries expire after a configurable time."
+
+	testCases := []struct {
+		name     string
+		text     string
+		terms    []string
+		expected []string
+	}{
+		{name: "no match", text: "filesystem access", terms: []string{"memory"}},
+		{name: "no terms", text: "filesystem access"},
+		{name: "short text", text: "io.github.example/memory", terms: []string{"memory"}, expected: []string{"io.github.example/memory"}},
+		{
+			name:     "matches ignore case and keep context",
+			text:     description,
+			terms:    []string{"MEMORY"},
+			expected: []string{"agents a persistent memory, backed by a knowle", "on the local disk. Memory entries expire afte"},
+		},
+		{
+			name:     "nearby matches are merged",
+			text:     description,
+			terms:    []string{"knowledge", "graph"},
+			expected: []string{"memory, backed by a knowledge graph stored on the local"},
+		},
+		{name: "phrase", text: description, terms: []string{"local disk"}, expected: []string{"graph stored on the local disk. Memory entries exp"}},
+		{
+			name:     "multibyte characters",
+			text:     "Zugriff auf Dateien über das Netzwerk",
+			terms:    []string{"ÜBER"},
+			expected: []string{"Zugriff auf Dateien über das Netzwerk"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, search.Highlights(tc.text, tc.terms))
+		})
+	}
+}
+
+func TestHighlightsLimit(t *testing.T) {
+	text := "memory" + " .............................................. memory" +
+		" .............................................. memory" + " .............................................. memory"
+	assert.Len(t, search.Highlights(text, []string{"memory"}), 3)
+}
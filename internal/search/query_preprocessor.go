@@ -0,0 +1,44 @@
+// Package search contains helpers for interpreting user supplied search queries
+package search
+
+import (
+	"regexp"
+	"strings"
+)
+
+// phraseRegex matches a double-quoted phrase
+var phraseRegex = regexp.MustCompile(`"([^"]*)"`)
+
+// PreprocessSearchQuery normalizes a raw search query for MongoDB text search.
+// Double-quoted segments are kept as phrases, which text search matches exactly; the remaining words are
+// searched individually. Unbalanced quotes and empty phrases are dropped and whitespace is collapsed.
+// hasPhrase reports whether the normalized query contains at least one phrase.
+func PreprocessSearchQuery(raw string) (textSearchQuery string, hasPhrase bool) {
+	var parts []string
+	for _, phrase := range Phrases(raw) {
+		parts = append(parts, `"`+phrase+`"`)
+	}
+	hasPhrase = len(parts) > 0
+
+	// Whatever is left outside of phrases is searched word by word
+	parts = append(parts, words(raw)...)
+
+	return strings.Join(parts, " "), hasPhrase
+}
+
+// words returns the words of a query outside of its double-quoted phrases; stray quotes are dropped
+func words(query string) []string {
+	return strings.Fields(strings.ReplaceAll(phraseRegex.ReplaceAllString(query, " "), `"`, " "))
+}
+
+// Phrases returns the non-empty double-quoted phrases of a query, without quotes and with their whitespace
+// collapsed, in the order they appear
+func Phrases(query string) []string {
+	var phrases []string
+	for _, match := range phraseRegex.FindAllStringSubmatch(query, -1) {
+		if phrase := strings.Join(strings.Fields(match[1]), " "); phrase != "" {
+			phrases = append(phrases, phrase)
+		}
+	}
+	return phrases
+}
@@ -0,0 +1,49 @@
+package search_test
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/search"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreprocessSearchQuery(t *testing.T) {
+	testCases := []struct {
+		name              string
+		raw               string
+		expectedQuery     string
+		expectedHasPhrase bool
+	}{
+		{name: "empty query", raw: "", expectedQuery: ""},
+		{name: "single word", raw: "filesystem", expectedQuery: "filesystem"},
+		{name: "separate words", raw: "mcp  filesystem ", expectedQuery: "mcp filesystem"},
+		{name: "phrase", raw: `"mcp filesystem"`, expectedQuery: `"mcp filesystem"`, expectedHasPhrase: true},
+		{
+			name:              "phrase with surrounding words",
+			raw:               `server "mcp   filesystem" tools`,
+			expectedQuery:     `"mcp filesystem" server tools`,
+			expectedHasPhrase: true,
+		},
+		{
+			name:              "multiple phrases",
+			raw:               `"mcp server" "local files"`,
+			expectedQuery:     `"mcp server" "local files"`,
+			expectedHasPhrase: true,
+		},
+		{name: "empty phrase is dropped", raw: `"" filesystem`, expectedQuery: "filesystem"},
+		{name: "unbalanced quote is dropped", raw: `mcp "filesystem`, expectedQuery: "mcp filesystem"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			query, hasPhrase := search.PreprocessSearchQuery(tc.raw)
+			assert.Equal(t, tc.expectedQuery, query)
+			assert.Equal(t, tc.expectedHasPhrase, hasPhrase)
+		})
+	}
+}
+
+func TestPhrases(t *testing.T) {
+	assert.Equal(t, []string{"mcp server", "local files"}, search.Phrases(`"mcp  server" tools "local files" ""`))
+	assert.Empty(t, search.Phrases("mcp server"))
+}
@@ -0,0 +1,519 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+)
+
+const (
+	serverCacheKeyPrefix = "server:"
+	listCacheKeyPrefix   = "list:"
+	// featuredCacheKey shares the list prefix, so changes to any server also invalidate the featured list
+	featuredCacheKey = listCacheKeyPrefix + "featured"
+)
+
+// cacheEntry holds a cached value together with its expiry time
+type cacheEntry struct {
+	value     any
+	expiresAt time.Time
+}
+
+// cachedList holds the cached result of a List call
+type cachedList struct {
+	servers []model.Server
+	cursors database.PageCursors
+}
+
+// CachedRegistryService wraps a RegistryService with an in-process cache for GetByID and List.
+// Write operations invalidate the affected cache entries.
+type CachedRegistryService struct {
+	next  RegistryService
+	ttl   time.Duration
+	cache sync.Map
+}
+
+// NewCachedRegistryService creates a caching decorator around the provided registry service
+func NewCachedRegistryService(next RegistryService, ttl time.Duration) *CachedRegistryService {
+	return &CachedRegistryService{
+		next: next,
+		ttl:  ttl,
+	}
+}
+
+// List returns registry entries with cursor-based pagination, served from cache when possible
+func (s *CachedRegistryService) List(
+	sortBy string, cursor string, direction database.CursorDirection, limit int,
+) ([]model.Server, database.PageCursors, error) {
+	key := listCacheKey(sortBy, cursor, direction, limit)
+	if value, ok := s.load(key); ok {
+		list := value.(cachedList)
+		return append([]model.Server(nil), list.servers...), list.cursors, nil
+	}
+
+	servers, cursors, err := s.next.List(sortBy, cursor, direction, limit)
+	if err != nil {
+		return nil, database.PageCursors{}, err
+	}
+
+	s.store(key, cachedList{
+		servers: append([]model.Server(nil), servers...),
+		cursors: cursors,
+	})
+
+	return servers, cursors, nil
+}
+
+// ListFeatured returns the featured servers in their display order, served from cache when possible
+func (s *CachedRegistryService) ListFeatured(ctx context.Context) ([]model.Server, error) {
+	if value, ok := s.load(featuredCacheKey); ok {
+		return append([]model.Server(nil), value.([]model.Server)...), nil
+	}
+
+	servers, err := s.next.ListFeatured(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.store(featuredCacheKey, append([]model.Server(nil), servers...))
+	return servers, nil
+}
+
+// ReorderFeatured sets the display order of the featured servers and invalidates the cache,
+// including the featured list, since every featured server is updated
+func (s *CachedRegistryService) ReorderFeatured(ctx context.Context, ids []string) error {
+	if err := s.next.ReorderFeatured(ctx, ids); err != nil {
+		return err
+	}
+
+	s.invalidateAll()
+	return nil
+}
+
+// ListUpdatedAfter returns the versions of all servers updated strictly after the given time.
+// It is not cached, since sync clients need to see updates immediately.
+func (s *CachedRegistryService) ListUpdatedAfter(
+	ctx context.Context, updatedAfter time.Time, afterSeq int64, limit int,
+) ([]model.Server, error) {
+	return s.next.ListUpdatedAfter(ctx, updatedAfter, afterSeq, limit)
+}
+
+// ListRecent returns the latest versions of the most recently published servers, newest first
+func (s *CachedRegistryService) ListRecent(ctx context.Context, limit int) ([]model.Server, error) {
+	return s.next.ListRecent(ctx, limit)
+}
+
+// GetByID retrieves a specific server detail by its ID, served from cache when possible
+func (s *CachedRegistryService) GetByID(id string) (*model.ServerDetail, error) {
+	key := serverCacheKeyPrefix + id
+	if value, ok := s.load(key); ok {
+		serverDetailCopy := *value.(*model.ServerDetail)
+		return &serverDetailCopy, nil
+	}
+
+	serverDetail, err := s.next.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverDetailCopy := *serverDetail
+	s.store(key, &serverDetailCopy)
+
+	return serverDetail, nil
+}
+
+// GetByIDs retrieves the servers with the given IDs in the order of the IDs, skipping unknown IDs
+func (s *CachedRegistryService) GetByIDs(ctx context.Context, ids []string) ([]model.ServerDetail, error) {
+	return s.next.GetByIDs(ctx, ids)
+}
+
+// GetByName retrieves the latest version of the server with the given name
+func (s *CachedRegistryService) GetByName(ctx context.Context, name string) (*model.ServerDetail, error) {
+	return s.next.GetByName(ctx, name)
+}
+
+// GetMetadata retrieves a server by its ID without its packages list
+func (s *CachedRegistryService) GetMetadata(ctx context.Context, id string) (*model.ServerDetail, error) {
+	return s.next.GetMetadata(ctx, id)
+}
+
+// Publish adds a new server detail to the registry and invalidates the cache,
+// since publishing a new version changes the latest flag of the server's other versions
+func (s *CachedRegistryService) Publish(serverDetail *model.ServerDetail) error {
+	if err := s.next.Publish(serverDetail); err != nil {
+		return err
+	}
+
+	s.invalidateAll()
+	return nil
+}
+
+// PublishVersion publishes a new version of a server and invalidates the cache, since the new version
+// becomes the latest
+func (s *CachedRegistryService) PublishVersion(
+	id string, versionDetail model.VersionDetail, packages []model.Package,
+) (*model.ServerDetail, error) {
+	serverDetail, err := s.next.PublishVersion(id, versionDetail, packages)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateAll()
+	return serverDetail, nil
+}
+
+// Update replaces the mutable fields of a server version and invalidates the cache,
+// since the version number appears in listings
+func (s *CachedRegistryService) Update(id string, detail *model.ServerDetail) error {
+	if err := s.next.Update(id, detail); err != nil {
+		return err
+	}
+
+	s.invalidateAll()
+	return nil
+}
+
+// Delete removes a server version from the registry and invalidates the cache,
+// since the latest flag may move to another version
+func (s *CachedRegistryService) Delete(id string) error {
+	if err := s.next.Delete(id); err != nil {
+		return err
+	}
+
+	s.invalidateAll()
+	return nil
+}
+
+// AppendPackages adds packages to an existing server and invalidates its cache entry
+func (s *CachedRegistryService) AppendPackages(ctx context.Context, id string, packages []model.Package) ([]model.Package, error) {
+	updated, err := s.next.AppendPackages(ctx, id, packages)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateServer(id)
+	return updated, nil
+}
+
+// RemovePackage removes a package from an existing server and invalidates its cache entry
+func (s *CachedRegistryService) RemovePackage(ctx context.Context, id, registryName, packageName string) error {
+	if err := s.next.RemovePackage(ctx, id, registryName, packageName); err != nil {
+		return err
+	}
+
+	s.invalidateServer(id)
+	return nil
+}
+
+// YankPackage marks packages of an existing server as yanked and invalidates its cache entry
+func (s *CachedRegistryService) YankPackage(ctx context.Context, id, registryName, version, reason string) ([]model.Package, error) {
+	updated, err := s.next.YankPackage(ctx, id, registryName, version, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateServer(id)
+	return updated, nil
+}
+
+// AddTags adds tags to an existing server and invalidates its cache entry
+func (s *CachedRegistryService) AddTags(ctx context.Context, id string, tags []string) ([]string, error) {
+	updated, err := s.next.AddTags(ctx, id, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateServer(id)
+	return updated, nil
+}
+
+// RemoveTag removes a tag from an existing server and invalidates its cache entry
+func (s *CachedRegistryService) RemoveTag(ctx context.Context, id, tag string) error {
+	if err := s.next.RemoveTag(ctx, id, tag); err != nil {
+		return err
+	}
+
+	s.invalidateServer(id)
+	return nil
+}
+
+// PinVersion marks the given version of a server as its latest version and invalidates the cache,
+// since all versions of the server are updated
+func (s *CachedRegistryService) PinVersion(ctx context.Context, id, version string) error {
+	if err := s.next.PinVersion(ctx, id, version); err != nil {
+		return err
+	}
+
+	s.invalidateAll()
+	return nil
+}
+
+// UpgradeVersion promotes the given version of a server to its latest version and invalidates the cache,
+// since all versions of the server are updated
+func (s *CachedRegistryService) UpgradeVersion(ctx context.Context, id, version string) error {
+	if err := s.next.UpgradeVersion(ctx, id, version); err != nil {
+		return err
+	}
+
+	s.invalidateAll()
+	return nil
+}
+
+// YankVersion marks the given version of a server as yanked and invalidates the cache,
+// since the latest flag may move to another version
+func (s *CachedRegistryService) YankVersion(ctx context.Context, id, version string) error {
+	if err := s.next.YankVersion(ctx, id, version); err != nil {
+		return err
+	}
+
+	s.invalidateAll()
+	return nil
+}
+
+// ListVersions returns the versions of the server with the given ID in publish order
+func (s *CachedRegistryService) ListVersions(
+	ctx context.Context, id string, includeYanked bool, cursor string, direction database.CursorDirection, limit int,
+) ([]model.ServerVersion, database.PageCursors, error) {
+	return s.next.ListVersions(ctx, id, includeYanked, cursor, direction, limit)
+}
+
+// TransferOwnership hands all versions of a server over to a new publisher and invalidates the cache,
+// since all versions of the server are updated
+func (s *CachedRegistryService) TransferOwnership(ctx context.Context, id, newOwner string) error {
+	if err := s.next.TransferOwnership(ctx, id, newOwner); err != nil {
+		return err
+	}
+
+	s.invalidateAll()
+	return nil
+}
+
+// UpdateStatus sets the lifecycle status of a server and invalidates the cache, since all versions of the
+// server are updated
+func (s *CachedRegistryService) UpdateStatus(ctx context.Context, id, status string, byRegistryOwner bool) error {
+	if err := s.next.UpdateStatus(ctx, id, status, byRegistryOwner); err != nil {
+		return err
+	}
+
+	s.invalidateAll()
+	return nil
+}
+
+// AddMaintainer adds a GitHub user to the maintainers of a server and invalidates the cache,
+// since all versions of the server are updated
+func (s *CachedRegistryService) AddMaintainer(ctx context.Context, id, username string) ([]string, error) {
+	maintainers, err := s.next.AddMaintainer(ctx, id, username)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateAll()
+	return maintainers, nil
+}
+
+// RemoveMaintainer removes a GitHub user from the maintainers of a server and invalidates the cache,
+// since all versions of the server are updated
+func (s *CachedRegistryService) RemoveMaintainer(ctx context.Context, id, username string) ([]string, error) {
+	maintainers, err := s.next.RemoveMaintainer(ctx, id, username)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateAll()
+	return maintainers, nil
+}
+
+// Search searches for servers matching the query and basic filters of the search options
+func (s *CachedRegistryService) Search(opts SearchOptions) ([]model.Server, database.PageCursors, error) {
+	return s.next.Search(opts)
+}
+
+// CountServers returns the number of servers in the registry
+func (s *CachedRegistryService) CountServers(ctx context.Context) (int64, error) {
+	return s.next.CountServers(ctx)
+}
+
+// CountSearchResults returns the number of servers a SearchDetails search with the same options finds
+func (s *CachedRegistryService) CountSearchResults(ctx context.Context, opts SearchOptions) (int64, error) {
+	return s.next.CountSearchResults(ctx, opts)
+}
+
+// SearchFacets returns the number of servers matching a search query with each package registry, tag, license and
+// status
+func (s *CachedRegistryService) SearchFacets(ctx context.Context, query string) (model.Facets, error) {
+	return s.next.SearchFacets(ctx, query)
+}
+
+// SearchDetails searches for servers matching the search options and returns full details
+func (s *CachedRegistryService) SearchDetails(opts SearchOptions) ([]model.ServerDetail, database.PageCursors, error) {
+	return s.next.SearchDetails(opts)
+}
+
+// Stats returns aggregate metrics of the registry
+func (s *CachedRegistryService) Stats() (model.RegistryStats, error) {
+	return s.next.Stats()
+}
+
+// ListTags returns the tags of servers with the number of servers carrying each, sorted by tag
+func (s *CachedRegistryService) ListTags(ctx context.Context) ([]model.TagCount, error) {
+	return s.next.ListTags(ctx)
+}
+
+// ListTopics returns the repository topics of servers with the number of servers tagged with each
+func (s *CachedRegistryService) ListTopics(ctx context.Context) ([]model.TopicCount, error) {
+	return s.next.ListTopics(ctx)
+}
+
+// ListLanguages returns the languages servers are written in with the number of servers in each
+func (s *CachedRegistryService) ListLanguages(ctx context.Context) ([]model.LanguageCount, error) {
+	return s.next.ListLanguages(ctx)
+}
+
+// ListCategories returns all categories with the number of servers in each
+func (s *CachedRegistryService) ListCategories(ctx context.Context) ([]model.CategoryCount, error) {
+	return s.next.ListCategories(ctx)
+}
+
+// SaveProvenance stores a SLSA provenance attestation for the server version with the given ID
+func (s *CachedRegistryService) SaveProvenance(ctx context.Context, id string, attestation []byte) (*model.Provenance, error) {
+	return s.next.SaveProvenance(ctx, id, attestation)
+}
+
+// GetProvenance retrieves the provenance attestation of a server version,
+// or the most recently attested version if version is empty
+func (s *CachedRegistryService) GetProvenance(ctx context.Context, id, version string) (*model.Provenance, error) {
+	return s.next.GetProvenance(ctx, id, version)
+}
+
+// GetSchema retrieves the JSON schema published with the server version with the given ID
+func (s *CachedRegistryService) GetSchema(ctx context.Context, id string) (json.RawMessage, error) {
+	return s.next.GetSchema(ctx, id)
+}
+
+// CountDependents returns the number of server versions that depend on the server with the given ID
+func (s *CachedRegistryService) CountDependents(ctx context.Context, id string) (int64, error) {
+	return s.next.CountDependents(ctx, id)
+}
+
+// Network returns the servers directly connected to the server with the given ID
+func (s *CachedRegistryService) Network(ctx context.Context, id string) (*model.ServerNetwork, error) {
+	return s.next.Network(ctx, id)
+}
+
+// GetGitHubMetadata retrieves the GitHub repository information stored when the server version with the given ID
+// was published
+func (s *CachedRegistryService) GetGitHubMetadata(ctx context.Context, id string) (json.RawMessage, error) {
+	return s.next.GetGitHubMetadata(ctx, id)
+}
+
+// SubmitReview stores a community review and invalidates the cached server, whose rating summary changes
+func (s *CachedRegistryService) SubmitReview(ctx context.Context, id string, review *model.Review) error {
+	if err := s.next.SubmitReview(ctx, id, review); err != nil {
+		return err
+	}
+
+	s.invalidateServer(id)
+	return nil
+}
+
+// ListReviews retrieves the reviews of the server with the given ID, newest first
+func (s *CachedRegistryService) ListReviews(
+	ctx context.Context, id string, cursor string, direction database.CursorDirection, limit int,
+) ([]model.Review, database.PageCursors, error) {
+	return s.next.ListReviews(ctx, id, cursor, direction, limit)
+}
+
+// StartReindex starts rebuilding the text search index in the background
+func (s *CachedRegistryService) StartReindex(ctx context.Context) (*model.ReindexJob, error) {
+	return s.next.StartReindex(ctx)
+}
+
+// GetReindexJob retrieves the status of a text index rebuild job
+func (s *CachedRegistryService) GetReindexJob(ctx context.Context, id string) (*model.ReindexJob, error) {
+	return s.next.GetReindexJob(ctx, id)
+}
+
+// StartVacuum starts removing stale records and compacting the database in the background
+func (s *CachedRegistryService) StartVacuum(ctx context.Context) (*model.VacuumJob, error) {
+	return s.next.StartVacuum(ctx)
+}
+
+// GetVacuumJob retrieves the status of a vacuum job
+func (s *CachedRegistryService) GetVacuumJob(ctx context.Context, id string) (*model.VacuumJob, error) {
+	return s.next.GetVacuumJob(ctx, id)
+}
+
+// SaveSubscription subscribes a webhook to a server, or updates the events of an existing subscription
+// of the server with the same webhook URL. It reports whether a new subscription was created.
+func (s *CachedRegistryService) SaveSubscription(ctx context.Context, subscription *model.Subscription) (bool, error) {
+	return s.next.SaveSubscription(ctx, subscription)
+}
+
+// GetSubscription retrieves a webhook subscription by its ID
+func (s *CachedRegistryService) GetSubscription(ctx context.Context, id string) (*model.Subscription, error) {
+	return s.next.GetSubscription(ctx, id)
+}
+
+// DeleteSubscription removes a webhook subscription
+func (s *CachedRegistryService) DeleteSubscription(ctx context.Context, id string) error {
+	return s.next.DeleteSubscription(ctx, id)
+}
+
+// load returns the cached value for a key if it exists and has not expired
+func (s *CachedRegistryService) load(key string) (any, bool) {
+	value, ok := s.cache.Load(key)
+	if !ok {
+		return nil, false
+	}
+
+	entry := value.(cacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		s.cache.Delete(key)
+		return nil, false
+	}
+
+	return entry.value, true
+}
+
+// store caches a value for the configured TTL
+func (s *CachedRegistryService) store(key string, value any) {
+	s.cache.Store(key, cacheEntry{
+		value:     value,
+		expiresAt: time.Now().Add(s.ttl),
+	})
+}
+
+// invalidateServer removes the cached server and all cached lists that may contain it
+func (s *CachedRegistryService) invalidateServer(id string) {
+	s.cache.Delete(serverCacheKeyPrefix + id)
+	s.invalidateLists()
+}
+
+// invalidateAll removes all cached servers and lists
+func (s *CachedRegistryService) invalidateAll() {
+	s.cache.Clear()
+}
+
+// invalidateLists removes all cached list results
+func (s *CachedRegistryService) invalidateLists() {
+	s.cache.Range(func(key, _ any) bool {
+		if strings.HasPrefix(key.(string), listCacheKeyPrefix) {
+			s.cache.Delete(key)
+		}
+		return true
+	})
+}
+
+// listCacheKey builds the cache key for a List call
+func listCacheKey(sortBy, cursor string, direction database.CursorDirection, limit int) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%s|%d", sortBy, cursor, direction, limit)))
+	return listCacheKeyPrefix + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,111 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServerDetail(name string) *model.ServerDetail {
+	return &model.ServerDetail{
+		Server: model.Server{
+			Name:        name,
+			Description: "Cache test server",
+			Repository: model.Repository{
+				URL:    "https://github.com/" + name,
+				Source: "github",
+			},
+			VersionDetail: model.VersionDetail{
+				Version: "1.0.0",
+			},
+		},
+		Packages: []model.Package{
+			{RegistryName: "npm", Name: name, Version: "1.0.0"},
+		},
+	}
+}
+
+func TestCachedRegistryServiceGetByID(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewMemoryDB(map[string]*model.Server{})
+	cached := service.NewCachedRegistryService(service.NewRegistryServiceWithDB(db), time.Minute)
+
+	serverDetail := newTestServerDetail("io.github.example/cached")
+	require.NoError(t, cached.Publish(serverDetail))
+
+	// Miss: the first read is loaded from the database
+	first, err := cached.GetByID(serverDetail.ID)
+	require.NoError(t, err)
+	assert.Len(t, first.Packages, 1)
+
+	// Hit: changes made directly in the database are not visible until the entry is invalidated
+	extra := []model.Package{{RegistryName: "docker", Name: "example/cached", Version: "1.0.0"}}
+	require.NoError(t, db.AppendPackages(ctx, serverDetail.ID, extra, 0))
+
+	second, err := cached.GetByID(serverDetail.ID)
+	require.NoError(t, err)
+	assert.Len(t, second.Packages, 1)
+
+	// Write through the cache invalidates the entry
+	require.NoError(t, cached.RemovePackage(ctx, serverDetail.ID, "docker", "example/cached"))
+	require.NoError(t, db.AppendPackages(ctx, serverDetail.ID, extra, 0))
+
+	third, err := cached.GetByID(serverDetail.ID)
+	require.NoError(t, err)
+	assert.Len(t, third.Packages, 2)
+}
+
+func TestCachedRegistryServiceList(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewMemoryDB(map[string]*model.Server{})
+	cached := service.NewCachedRegistryService(service.NewRegistryServiceWithDB(db), time.Minute)
+
+	require.NoError(t, cached.Publish(newTestServerDetail("io.github.example/first")))
+
+	servers, _, err := cached.List("", "", database.CursorNext, 10)
+	require.NoError(t, err)
+	assert.Len(t, servers, 1)
+
+	// Hit: a server published directly to the database is not visible
+	require.NoError(t, db.Publish(ctx, newTestServerDetail("io.github.example/second")))
+
+	servers, _, err = cached.List("", "", database.CursorNext, 10)
+	require.NoError(t, err)
+	assert.Len(t, servers, 1)
+
+	// Miss: different pagination parameters use a different cache key
+	servers, _, err = cached.List("", "", database.CursorNext, 5)
+	require.NoError(t, err)
+	assert.Len(t, servers, 2)
+
+	// Publishing through the cache invalidates all cached lists
+	require.NoError(t, cached.Publish(newTestServerDetail("io.github.example/third")))
+
+	servers, _, err = cached.List("", "", database.CursorNext, 10)
+	require.NoError(t, err)
+	assert.Len(t, servers, 3)
+}
+
+func TestCachedRegistryServiceExpiry(t *testing.T) {
+	db := database.NewMemoryDB(map[string]*model.Server{})
+	cached := service.NewCachedRegistryService(service.NewRegistryServiceWithDB(db), 20*time.Millisecond)
+
+	require.NoError(t, cached.Publish(newTestServerDetail("io.github.example/first")))
+
+	servers, _, err := cached.List("", "", database.CursorNext, 10)
+	require.NoError(t, err)
+	assert.Len(t, servers, 1)
+
+	require.NoError(t, db.Publish(context.Background(), newTestServerDetail("io.github.example/second")))
+	time.Sleep(40 * time.Millisecond)
+
+	servers, _, err = cached.List("", "", database.CursorNext, 10)
+	require.NoError(t, err)
+	assert.Len(t, servers, 2)
+}
@@ -0,0 +1,92 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newServerDetail returns a publishable server detail in the given category
+func newServerDetail(name string, category model.Category) *model.ServerDetail {
+	return &model.ServerDetail{
+		Server: model.Server{
+			Name:          name,
+			Repository:    model.Repository{URL: "https://github.com/" + name, Source: "github"},
+			VersionDetail: model.VersionDetail{Version: "1.0.0"},
+			Category:      category,
+		},
+	}
+}
+
+func TestPublishCategory(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+
+	t.Run("valid category", func(t *testing.T) {
+		serverDetail := newServerDetail("example/postgres", model.CategoryDatabase)
+		require.NoError(t, registry.Publish(serverDetail))
+
+		stored, err := registry.GetByID(serverDetail.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.CategoryDatabase, stored.Category)
+	})
+
+	t.Run("empty category defaults to other", func(t *testing.T) {
+		serverDetail := newServerDetail("example/misc", "")
+		require.NoError(t, registry.Publish(serverDetail))
+
+		stored, err := registry.GetByID(serverDetail.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.CategoryOther, stored.Category)
+	})
+
+	t.Run("invalid category is rejected", func(t *testing.T) {
+		err := registry.Publish(newServerDetail("example/games", "games"))
+		require.ErrorIs(t, err, database.ErrInvalidCategory)
+	})
+}
+
+func TestCategoryFilteredSearch(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	require.NoError(t, registry.Publish(newServerDetail("example/files", model.CategoryFilesystem)))
+	require.NoError(t, registry.Publish(newServerDetail("example/postgres", model.CategoryDatabase)))
+	require.NoError(t, registry.Publish(newServerDetail("example/sqlite", model.CategoryDatabase)))
+
+	servers, _, err := registry.Search(service.SearchOptions{
+		Category:  string(model.CategoryDatabase),
+		Direction: database.CursorNext,
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, servers, 2)
+	for _, server := range servers {
+		assert.Equal(t, model.CategoryDatabase, server.Category)
+	}
+
+	details, _, err := registry.SearchDetails(service.SearchOptions{
+		Category:  string(model.CategoryFilesystem),
+		Direction: database.CursorNext,
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, details, 1)
+	assert.Equal(t, "example/files", details[0].Name)
+
+	counts, err := registry.ListCategories(context.Background())
+	require.NoError(t, err)
+	require.Len(t, counts, len(model.Categories))
+	for _, count := range counts {
+		switch count.Category {
+		case model.CategoryDatabase:
+			assert.Equal(t, 2, count.Count)
+		case model.CategoryFilesystem:
+			assert.Equal(t, 1, count.Count)
+		default:
+			assert.Zero(t, count.Count)
+		}
+	}
+}
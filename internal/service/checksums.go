@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/vcs"
+)
+
+// checksumVerificationTimeout bounds how long fetching the checksums of a published server may take
+const checksumVerificationTimeout = 2 * time.Minute
+
+// clearVerifiedChecksums drops client-supplied checksums; only the registry records verified checksums
+func clearVerifiedChecksums(packages []model.Package) {
+	for i := range packages {
+		packages[i].VerifiedChecksum = ""
+	}
+}
+
+// verifyPackageChecksums fetches the checksums of the npm packages of a server from the npm registry and
+// records them on the server. It runs detached from the request that published the server.
+func verifyPackageChecksums(db database.Database, id string, packages []model.Package) {
+	ctx, cancel := context.WithTimeout(context.Background(), checksumVerificationTimeout)
+	defer cancel()
+
+	for _, pkg := range packages {
+		if pkg.RegistryName != "npm" || pkg.Version == "" {
+			continue
+		}
+
+		checksum, err := vcs.FetchNPMChecksum(ctx, pkg.Name, pkg.Version)
+		if err != nil {
+			log.Printf("Error fetching checksum of npm package %s@%s: %v", pkg.Name, pkg.Version, err)
+			continue
+		}
+
+		if err := db.SetPackageChecksum(ctx, id, pkg.RegistryName, pkg.Name, checksum); err != nil {
+			log.Printf("Error saving checksum of npm package %s@%s: %v", pkg.Name, pkg.Version, err)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package service_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestPublishVerifiesNPMChecksums(t *testing.T) {
+	original := http.DefaultTransport
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		checksums := map[string]string{"/example-server/1.0.0": "abc123", "/example-tools/2.0.0": "def456"}
+		checksum, ok := checksums[req.URL.Path]
+		if req.URL.Host != "registry.npmjs.org" || !ok {
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"dist": {"shasum": "` + checksum + `"}}`)),
+		}, nil
+	})
+	t.Cleanup(func() {
+		http.DefaultTransport = original
+	})
+
+	registry := service.NewRegistryServiceWithDB(
+		database.NewMemoryDB(map[string]*model.Server{}), service.WithChecksumVerification(),
+	)
+	serverDetail := &model.ServerDetail{
+		Server: model.Server{
+			Name:          "io.github.example/checksum-server",
+			Repository:    model.Repository{URL: "https://github.com/example/checksum-server", Source: "github"},
+			VersionDetail: model.VersionDetail{Version: "1.0.0"},
+		},
+		Packages: []model.Package{
+			{RegistryName: "npm", Name: "example-server", Version: "1.0.0", VerifiedChecksum: "client-supplied"},
+			{RegistryName: "docker", Name: "example/server", Version: "1.0.0"},
+		},
+	}
+	require.NoError(t, registry.Publish(serverDetail))
+
+	var stored *model.ServerDetail
+	require.Eventually(t, func() bool {
+		var err error
+		stored, err = registry.GetByID(serverDetail.ID)
+		require.NoError(t, err)
+		return stored.Packages[0].VerifiedChecksum != ""
+	}, 2*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, "abc123", stored.Packages[0].VerifiedChecksum)
+	assert.Empty(t, stored.Packages[1].VerifiedChecksum)
+
+	t.Run("appended packages are verified", func(t *testing.T) {
+		_, err := registry.AppendPackages(context.Background(), serverDetail.ID, []model.Package{
+			{RegistryName: "npm", Name: "example-tools", Version: "2.0.0", VerifiedChecksum: "client-supplied"},
+		})
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			stored, err := registry.GetByID(serverDetail.ID)
+			require.NoError(t, err)
+			require.Len(t, stored.Packages, 3)
+			return stored.Packages[2].VerifiedChecksum == "def456"
+		}, 2*time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestPublishWithoutChecksumVerification(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	serverDetail := &model.ServerDetail{
+		Server: model.Server{
+			Name:          "io.github.example/unverified",
+			Repository:    model.Repository{URL: "https://github.com/example/unverified", Source: "github"},
+			VersionDetail: model.VersionDetail{Version: "1.0.0"},
+		},
+		Packages: []model.Package{
+			{RegistryName: "npm", Name: "example-server", Version: "1.0.0", VerifiedChecksum: "client-supplied"},
+		},
+	}
+	require.NoError(t, registry.Publish(serverDetail))
+
+	stored, err := registry.GetByID(serverDetail.ID)
+	require.NoError(t, err)
+	assert.Empty(t, stored.Packages[0].VerifiedChecksum)
+}
+
+func TestVerifiedChecksumFilter(t *testing.T) {
+	db := database.NewMemoryDB(map[string]*model.Server{})
+	registry := service.NewRegistryServiceWithDB(db)
+	ids := make(map[string]string)
+	for _, name := range []string{"example/verified", "example/unverified"} {
+		serverDetail := newServerDetail(name, "")
+		serverDetail.Packages = []model.Package{{RegistryName: "npm", Name: name, Version: "1.0.0"}}
+		require.NoError(t, registry.Publish(serverDetail))
+		ids[name] = serverDetail.ID
+	}
+	require.NoError(t, db.SetPackageChecksum(context.Background(), ids["example/verified"], "npm", "example/verified", "abc123"))
+
+	for hasVerifiedChecksum, expectedNames := range map[bool][]string{
+		true:  {"example/verified"},
+		false: {"example/verified", "example/unverified"},
+	} {
+		servers, _, err := registry.SearchDetails(service.SearchOptions{
+			HasVerifiedChecksum: hasVerifiedChecksum,
+			Direction:           database.CursorNext,
+			Limit:               10,
+		})
+		require.NoError(t, err)
+		names := make([]string, len(servers))
+		for i, server := range servers {
+			names[i] = server.Name
+		}
+		assert.ElementsMatch(t, expectedNames, names, "has_verified_checksum=%t", hasVerifiedChecksum)
+	}
+
+	stats, err := registry.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.VerifiedChecksumCount)
+}
@@ -0,0 +1,117 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchDetailsMCPVersion(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	for _, server := range []struct{ name, minMCPVersion string }{
+		{"example/v1", "1.0.0"},
+		{"example/any-client", ""},
+		{"example/v1-5", "1.5.0"},
+		{"example/v2", "2.0.0"},
+		{"example/v3-beta", "3.0.0-beta.1"},
+	} {
+		serverDetail := newServerDetail(server.name, "")
+		serverDetail.MinMCPVersion = server.minMCPVersion
+		require.NoError(t, registry.Publish(serverDetail))
+	}
+
+	search := func(t *testing.T, minMCPVersion, maxMCPVersion, cursor string, limit int) ([]string, string) {
+		t.Helper()
+		servers, cursors, err := registry.SearchDetails(service.SearchOptions{
+			MinMCPVersion: minMCPVersion,
+			MaxMCPVersion: maxMCPVersion,
+			Cursor:        cursor,
+			Direction:     database.CursorNext,
+			Limit:         limit,
+		})
+		require.NoError(t, err)
+		names := make([]string, len(servers))
+		for i, server := range servers {
+			names[i] = server.Name
+		}
+		return names, cursors.Next
+	}
+
+	testCases := []struct {
+		name          string
+		minMCPVersion string
+		maxMCPVersion string
+		expectedNames []string
+	}{
+		{
+			name:          "compatible with a client",
+			maxMCPVersion: "1.5.0",
+			expectedNames: []string{"example/any-client", "example/v1", "example/v1-5"},
+		},
+		{
+			name:          "requiring at least a version",
+			minMCPVersion: "2.0.0",
+			expectedNames: []string{"example/v2", "example/v3-beta"},
+		},
+		{
+			name:          "version range",
+			minMCPVersion: "1.2.0",
+			maxMCPVersion: "3.0.0",
+			expectedNames: []string{"example/v1-5", "example/v2", "example/v3-beta"},
+		},
+		{
+			name:          "pre-release precedes the release",
+			minMCPVersion: "3.0.0",
+			expectedNames: []string{},
+		},
+		{
+			name: "no bounds",
+			expectedNames: []string{
+				"example/any-client", "example/v1", "example/v1-5", "example/v2", "example/v3-beta",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			names, _ := search(t, tc.minMCPVersion, tc.maxMCPVersion, "", 10)
+			assert.ElementsMatch(t, tc.expectedNames, names)
+		})
+	}
+
+	// The first page of two servers holds a single match; pages are not refilled
+	t.Run("paginates over filtered pages", func(t *testing.T) {
+		var names []string
+		cursor := ""
+		for pages := 0; ; pages++ {
+			require.Less(t, pages, 10, "pagination did not terminate")
+			page, nextCursor := search(t, "1.0.0", "", cursor, 2)
+			assert.NotEmpty(t, page)
+			assert.LessOrEqual(t, len(page), 2)
+			names = append(names, page...)
+			if nextCursor == "" {
+				break
+			}
+			cursor = nextCursor
+		}
+		assert.ElementsMatch(t, []string{"example/v1", "example/v1-5", "example/v2", "example/v3-beta"}, names)
+	})
+}
+
+func TestPublishMinMCPVersion(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+
+	serverDetail := newServerDetail("example/invalid-mcp-version", "")
+	serverDetail.MinMCPVersion = "2025-03-26"
+	assert.ErrorIs(t, registry.Publish(serverDetail), database.ErrInvalidMCPVersion)
+
+	serverDetail.MinMCPVersion = "1.2.3"
+	require.NoError(t, registry.Publish(serverDetail))
+	stored, err := registry.GetByID(serverDetail.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3", stored.MinMCPVersion)
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+)
+
+// DefaultDependentsCountTTL is how long the number of dependents of a server is cached
+const DefaultDependentsCountTTL = time.Minute
+
+// validateDependencies checks that the dependencies of a server being published are the IDs of existing servers.
+// Their format is checked by ValidateServerDetail beforehand. The error lists every unknown ID.
+func validateDependencies(ctx context.Context, db database.Database, serverDetail *model.ServerDetail) error {
+	if len(serverDetail.Dependencies) == 0 {
+		return nil
+	}
+
+	found, err := db.GetByIDs(ctx, serverDetail.Dependencies)
+	if err != nil {
+		return err
+	}
+	if len(found) == len(serverDetail.Dependencies) {
+		return nil
+	}
+
+	var unknown []string
+	for _, id := range serverDetail.Dependencies {
+		if !slices.ContainsFunc(found, func(dependency *model.ServerDetail) bool { return dependency.ID == id }) {
+			unknown = append(unknown, strconv.Quote(id))
+		}
+	}
+	return ValidationErrors{{
+		Field:   "dependencies",
+		Message: "unknown servers " + strings.Join(unknown, ", "),
+		err:     database.ErrInvalidDependency,
+	}}
+}
+
+// dependentsCounter counts the dependents of servers, caching each count for a fixed time.
+// Counting scans all servers, so popular servers would otherwise be expensive to look up repeatedly.
+type dependentsCounter struct {
+	db     database.Database
+	ttl    time.Duration
+	counts sync.Map
+}
+
+// newDependentsCounter creates a counter caching counts for ttl; counts are not cached if ttl is not positive
+func newDependentsCounter(db database.Database, ttl time.Duration) *dependentsCounter {
+	return &dependentsCounter{
+		db:  db,
+		ttl: ttl,
+	}
+}
+
+// count returns the number of server versions depending on the server with the given ID.
+// It returns database.ErrNotFound if the server does not exist.
+func (c *dependentsCounter) count(ctx context.Context, id string) (int64, error) {
+	if value, ok := c.counts.Load(id); ok {
+		entry := value.(cacheEntry)
+		if time.Now().Before(entry.expiresAt) {
+			return entry.value.(int64), nil
+		}
+		c.counts.Delete(id)
+	}
+
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if _, err := c.db.GetByID(ctx, id); err != nil {
+		return 0, err
+	}
+
+	count, err := c.db.CountDependents(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	if c.ttl > 0 {
+		c.counts.Store(id, cacheEntry{value: count, expiresAt: time.Now().Add(c.ttl)})
+	}
+	return count, nil
+}
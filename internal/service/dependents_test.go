@@ -0,0 +1,122 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// publishDependent publishes a server depending on the servers with the given IDs
+func publishDependent(t *testing.T, registry service.RegistryService, name string, dependencies ...string) {
+	t.Helper()
+	serverDetail := newServerDetail(name, "")
+	serverDetail.Dependencies = dependencies
+	require.NoError(t, registry.Publish(serverDetail))
+}
+
+func TestCountDependents(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(
+		database.NewMemoryDB(map[string]*model.Server{}),
+		service.WithDependentsCountTTL(100*time.Millisecond),
+	)
+	ctx := context.Background()
+
+	target := newServerDetail("example/target", "")
+	require.NoError(t, registry.Publish(target))
+	other := newServerDetail("example/other", "")
+	require.NoError(t, registry.Publish(other))
+
+	publishDependent(t, registry, "example/first", target.ID)
+	publishDependent(t, registry, "example/second", target.ID, other.ID)
+	publishDependent(t, registry, "example/third", other.ID, target.ID)
+
+	count, err := registry.CountDependents(ctx, target.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	count, err = registry.CountDependents(ctx, other.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	t.Run("no dependents", func(t *testing.T) {
+		count, err := registry.CountDependents(ctx, newServerID(t, registry))
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
+	t.Run("unknown server", func(t *testing.T) {
+		_, err := registry.CountDependents(ctx, uuid.New().String())
+		require.ErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("counts are cached", func(t *testing.T) {
+		publishDependent(t, registry, "example/fourth", target.ID)
+
+		count, err := registry.CountDependents(ctx, target.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count, "the cached count should be returned until it expires")
+
+		assert.Eventually(t, func() bool {
+			count, err := registry.CountDependents(ctx, target.ID)
+			return err == nil && count == 4
+		}, time.Second, 20*time.Millisecond)
+	})
+}
+
+// newServerID publishes a server without dependencies or dependents and returns its ID
+func newServerID(t *testing.T, registry service.RegistryService) string {
+	t.Helper()
+	serverDetail := newServerDetail("example/standalone", "")
+	require.NoError(t, registry.Publish(serverDetail))
+	return serverDetail.ID
+}
+
+func TestPublishDependencies(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+
+	target := newServerDetail("example/target", "")
+	require.NoError(t, registry.Publish(target))
+	unknown := uuid.New().String()
+
+	tests := []struct {
+		name         string
+		dependencies []string
+		wantErr      bool
+	}{
+		{name: "existing server", dependencies: []string{target.ID}},
+		{name: "not a server ID", dependencies: []string{"example/target"}, wantErr: true},
+		{name: "unknown server", dependencies: []string{target.ID, unknown}, wantErr: true},
+		{name: "duplicate dependency", dependencies: []string{target.ID, target.ID}, wantErr: true},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverDetail := newServerDetail("example/dependent", "")
+			serverDetail.VersionDetail.Version = fmt.Sprintf("1.0.%d", i)
+			serverDetail.Dependencies = tt.dependencies
+
+			err := registry.Publish(serverDetail)
+			if tt.wantErr {
+				require.ErrorIs(t, err, database.ErrInvalidDependency)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("error lists unknown servers", func(t *testing.T) {
+		serverDetail := newServerDetail("example/listed", "")
+		serverDetail.Dependencies = []string{unknown}
+		err := registry.Publish(serverDetail)
+		require.ErrorIs(t, err, database.ErrInvalidDependency)
+		assert.Contains(t, err.Error(), unknown)
+	})
+}
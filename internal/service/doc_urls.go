@@ -0,0 +1,24 @@
+package service
+
+import (
+	"github.com/modelcontextprotocol/registry/internal/model"
+)
+
+// defaultDocURLPrefixes maps package registries to the URL prefix of their package pages, which serve as
+// documentation links for packages published without one
+var defaultDocURLPrefixes = map[string]string{
+	"npm":    "https://npmjs.com/package/",
+	"pypi":   "https://pypi.org/project/",
+	"docker": "https://hub.docker.com/r/",
+}
+
+// populateDocURLs fills in the package page of the registry as the documentation URL of packages without one.
+// Provided URLs are kept as is; they are checked by validatePackages.
+func populateDocURLs(packages []model.Package) {
+	for i := range packages {
+		pkg := &packages[i]
+		if prefix, ok := defaultDocURLPrefixes[pkg.RegistryName]; ok && pkg.DocURL == "" && pkg.Name != "" {
+			pkg.DocURL = prefix + pkg.Name
+		}
+	}
+}
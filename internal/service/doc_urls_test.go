@@ -0,0 +1,90 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishDocURL(t *testing.T) {
+	testCases := []struct {
+		name     string
+		pkg      model.Package
+		expected string
+	}{
+		{
+			name:     "npm package links to npmjs",
+			pkg:      model.Package{RegistryName: "npm", Name: "@example/server", Version: "1.0.0"},
+			expected: "https://npmjs.com/package/@example/server",
+		},
+		{
+			name:     "PyPI package links to pypi.org",
+			pkg:      model.Package{RegistryName: "pypi", Name: "example-server", Version: "1.0.0"},
+			expected: "https://pypi.org/project/example-server",
+		},
+		{
+			name:     "Docker image links to Docker Hub",
+			pkg:      model.Package{RegistryName: "docker", Name: "example/server", Version: "1.0.0"},
+			expected: "https://hub.docker.com/r/example/server",
+		},
+		{
+			name:     "other registries are left without a link",
+			pkg:      model.Package{RegistryName: "cargo", Name: "example-server", Version: "1.0.0"},
+			expected: "",
+		},
+		{
+			name: "provided URL overrides the registry page",
+			pkg: model.Package{
+				RegistryName: "npm", Name: "@example/server", Version: "1.0.0", DocURL: "https://example.com/docs",
+			},
+			expected: "https://example.com/docs",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+			serverDetail := newServerDetail("example/doc-url", "")
+			serverDetail.Packages = []model.Package{tc.pkg}
+			require.NoError(t, registry.Publish(serverDetail))
+
+			stored, err := registry.GetByID(serverDetail.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, stored.Packages[0].DocURL)
+		})
+	}
+
+	t.Run("only HTTPS URLs are accepted", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		for _, docURL := range []string{"http://example.com/docs", "ftp://example.com/docs", "javascript:alert(1)", "https:///docs"} {
+			serverDetail := newServerDetail("example/insecure-doc-url", "")
+			serverDetail.Packages = []model.Package{{RegistryName: "npm", Name: "example", Version: "1.0.0", DocURL: docURL}}
+			err := registry.Publish(serverDetail)
+			assert.ErrorIs(t, err, database.ErrInvalidDocURL, docURL)
+		}
+	})
+
+	t.Run("appended packages are validated and populated", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		serverDetail := newServerDetail("example/append-doc-url", "")
+		serverDetail.Packages = []model.Package{{RegistryName: "npm", Name: "example", Version: "1.0.0"}}
+		require.NoError(t, registry.Publish(serverDetail))
+
+		_, err := registry.AppendPackages(context.Background(), serverDetail.ID, []model.Package{
+			{RegistryName: "pypi", Name: "example", Version: "1.0.0", DocURL: "http://example.com/docs"},
+		})
+		require.ErrorIs(t, err, database.ErrInvalidDocURL)
+
+		packages, err := registry.AppendPackages(context.Background(), serverDetail.ID, []model.Package{
+			{RegistryName: "pypi", Name: "example", Version: "1.0.0"},
+		})
+		require.NoError(t, err)
+		require.Len(t, packages, 2)
+		assert.Equal(t, "https://pypi.org/project/example", packages[1].DocURL)
+	})
+}
@@ -0,0 +1,419 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/events"
+	"github.com/modelcontextprotocol/registry/internal/model"
+)
+
+// EventingRegistryService wraps a RegistryService and broadcasts an event on the hub
+// after each successful write operation
+type EventingRegistryService struct {
+	next RegistryService
+	hub  *events.Hub
+}
+
+// NewEventingRegistryService creates a decorator that broadcasts registry changes on the provided hub
+func NewEventingRegistryService(next RegistryService, hub *events.Hub) *EventingRegistryService {
+	return &EventingRegistryService{
+		next: next,
+		hub:  hub,
+	}
+}
+
+// List returns registry entries with cursor-based pagination
+func (s *EventingRegistryService) List(
+	sortBy string, cursor string, direction database.CursorDirection, limit int,
+) ([]model.Server, database.PageCursors, error) {
+	return s.next.List(sortBy, cursor, direction, limit)
+}
+
+// ListUpdatedAfter returns the versions of all servers updated strictly after the given time
+func (s *EventingRegistryService) ListUpdatedAfter(
+	ctx context.Context, updatedAfter time.Time, afterSeq int64, limit int,
+) ([]model.Server, error) {
+	return s.next.ListUpdatedAfter(ctx, updatedAfter, afterSeq, limit)
+}
+
+// ListRecent returns the latest versions of the most recently published servers, newest first
+func (s *EventingRegistryService) ListRecent(ctx context.Context, limit int) ([]model.Server, error) {
+	return s.next.ListRecent(ctx, limit)
+}
+
+// GetByID retrieves a specific server detail by its ID
+func (s *EventingRegistryService) GetByID(id string) (*model.ServerDetail, error) {
+	return s.next.GetByID(id)
+}
+
+// GetByIDs retrieves the servers with the given IDs in the order of the IDs, skipping unknown IDs
+func (s *EventingRegistryService) GetByIDs(ctx context.Context, ids []string) ([]model.ServerDetail, error) {
+	return s.next.GetByIDs(ctx, ids)
+}
+
+// GetByName retrieves the latest version of the server with the given name
+func (s *EventingRegistryService) GetByName(ctx context.Context, name string) (*model.ServerDetail, error) {
+	return s.next.GetByName(ctx, name)
+}
+
+// GetMetadata retrieves a server by its ID without its packages list
+func (s *EventingRegistryService) GetMetadata(ctx context.Context, id string) (*model.ServerDetail, error) {
+	return s.next.GetMetadata(ctx, id)
+}
+
+// Publish adds a new server detail to the registry and broadcasts a published event
+func (s *EventingRegistryService) Publish(serverDetail *model.ServerDetail) error {
+	if err := s.next.Publish(serverDetail); err != nil {
+		return err
+	}
+
+	s.hub.Publish(events.Event{
+		Type:     events.EventPublished,
+		ServerID: serverDetail.ID,
+		Name:     serverDetail.Name,
+		// The hub stamps the current time on servers published without a publication time
+		Timestamp: serverDetail.PublishedAt,
+	})
+	return nil
+}
+
+// PublishVersion publishes a new version of a server and broadcasts a published event
+func (s *EventingRegistryService) PublishVersion(
+	id string, versionDetail model.VersionDetail, packages []model.Package,
+) (*model.ServerDetail, error) {
+	serverDetail, err := s.next.PublishVersion(id, versionDetail, packages)
+	if err != nil {
+		return nil, err
+	}
+
+	s.hub.Publish(events.Event{
+		Type:      events.EventPublished,
+		ServerID:  serverDetail.ID,
+		Name:      serverDetail.Name,
+		Timestamp: serverDetail.PublishedAt,
+	})
+	return serverDetail, nil
+}
+
+// Update replaces the mutable fields of a server version and broadcasts an updated event
+func (s *EventingRegistryService) Update(id string, detail *model.ServerDetail) error {
+	if err := s.next.Update(id, detail); err != nil {
+		return err
+	}
+
+	s.publishUpdated(context.Background(), id)
+	return nil
+}
+
+// Delete removes a server version from the registry and broadcasts a deleted event
+func (s *EventingRegistryService) Delete(id string) error {
+	// The name must be looked up before the server is gone
+	serverDetail, err := s.next.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.next.Delete(id); err != nil {
+		return err
+	}
+
+	s.hub.Publish(events.Event{
+		Type:     events.EventDeleted,
+		ServerID: id,
+		Name:     serverDetail.Name,
+	})
+	return nil
+}
+
+// AppendPackages adds packages to an existing server and broadcasts an updated event
+func (s *EventingRegistryService) AppendPackages(ctx context.Context, id string, packages []model.Package) ([]model.Package, error) {
+	updated, err := s.next.AppendPackages(ctx, id, packages)
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishUpdated(ctx, id)
+	return updated, nil
+}
+
+// RemovePackage removes a package from an existing server and broadcasts an updated event
+func (s *EventingRegistryService) RemovePackage(ctx context.Context, id, registryName, packageName string) error {
+	if err := s.next.RemovePackage(ctx, id, registryName, packageName); err != nil {
+		return err
+	}
+
+	s.publishUpdated(ctx, id)
+	return nil
+}
+
+// YankPackage marks packages of an existing server as yanked and broadcasts an updated event
+func (s *EventingRegistryService) YankPackage(ctx context.Context, id, registryName, version, reason string) ([]model.Package, error) {
+	updated, err := s.next.YankPackage(ctx, id, registryName, version, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishUpdated(ctx, id)
+	return updated, nil
+}
+
+// AddTags adds tags to an existing server and broadcasts an updated event
+func (s *EventingRegistryService) AddTags(ctx context.Context, id string, tags []string) ([]string, error) {
+	updated, err := s.next.AddTags(ctx, id, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishUpdated(ctx, id)
+	return updated, nil
+}
+
+// RemoveTag removes a tag from an existing server and broadcasts an updated event
+func (s *EventingRegistryService) RemoveTag(ctx context.Context, id, tag string) error {
+	if err := s.next.RemoveTag(ctx, id, tag); err != nil {
+		return err
+	}
+
+	s.publishUpdated(ctx, id)
+	return nil
+}
+
+// ListFeatured returns the featured servers in their display order
+func (s *EventingRegistryService) ListFeatured(ctx context.Context) ([]model.Server, error) {
+	return s.next.ListFeatured(ctx)
+}
+
+// ReorderFeatured sets the display order of the featured servers and broadcasts an updated event for each
+func (s *EventingRegistryService) ReorderFeatured(ctx context.Context, ids []string) error {
+	if err := s.next.ReorderFeatured(ctx, ids); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		s.publishUpdated(ctx, id)
+	}
+	return nil
+}
+
+// PinVersion marks the given version of a server as its latest version and broadcasts an updated event
+func (s *EventingRegistryService) PinVersion(ctx context.Context, id, version string) error {
+	if err := s.next.PinVersion(ctx, id, version); err != nil {
+		return err
+	}
+
+	s.publishUpdated(ctx, id)
+	return nil
+}
+
+// UpgradeVersion promotes the given version of a server to its latest version and broadcasts an updated event
+func (s *EventingRegistryService) UpgradeVersion(ctx context.Context, id, version string) error {
+	if err := s.next.UpgradeVersion(ctx, id, version); err != nil {
+		return err
+	}
+
+	s.publishUpdated(ctx, id)
+	return nil
+}
+
+// YankVersion marks the given version of a server as yanked and broadcasts an updated event
+func (s *EventingRegistryService) YankVersion(ctx context.Context, id, version string) error {
+	if err := s.next.YankVersion(ctx, id, version); err != nil {
+		return err
+	}
+
+	s.publishUpdated(ctx, id)
+	return nil
+}
+
+// ListVersions returns the versions of the server with the given ID in publish order
+func (s *EventingRegistryService) ListVersions(
+	ctx context.Context, id string, includeYanked bool, cursor string, direction database.CursorDirection, limit int,
+) ([]model.ServerVersion, database.PageCursors, error) {
+	return s.next.ListVersions(ctx, id, includeYanked, cursor, direction, limit)
+}
+
+// TransferOwnership hands all versions of a server over to a new publisher and broadcasts
+// an ownership transferred event naming both the previous and the new owner
+func (s *EventingRegistryService) TransferOwnership(ctx context.Context, id, newOwner string) error {
+	serverMeta, err := s.next.GetMetadata(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.next.TransferOwnership(ctx, id, newOwner); err != nil {
+		return err
+	}
+
+	s.hub.Publish(events.Event{
+		Type:          events.EventOwnershipTransferred,
+		ServerID:      id,
+		Name:          serverMeta.Name,
+		PreviousOwner: serverMeta.Publisher(),
+		NewOwner:      newOwner,
+	})
+	return nil
+}
+
+// UpdateStatus sets the lifecycle status of all versions of a server
+func (s *EventingRegistryService) UpdateStatus(ctx context.Context, id, status string, byRegistryOwner bool) error {
+	return s.next.UpdateStatus(ctx, id, status, byRegistryOwner)
+}
+
+// AddMaintainer adds a GitHub user to the maintainers of all versions of a server
+func (s *EventingRegistryService) AddMaintainer(ctx context.Context, id, username string) ([]string, error) {
+	return s.next.AddMaintainer(ctx, id, username)
+}
+
+// RemoveMaintainer removes a GitHub user from the maintainers of all versions of a server
+func (s *EventingRegistryService) RemoveMaintainer(ctx context.Context, id, username string) ([]string, error) {
+	return s.next.RemoveMaintainer(ctx, id, username)
+}
+
+// Search searches for servers matching the query and basic filters of the search options
+func (s *EventingRegistryService) Search(opts SearchOptions) ([]model.Server, database.PageCursors, error) {
+	return s.next.Search(opts)
+}
+
+// CountServers returns the number of servers in the registry
+func (s *EventingRegistryService) CountServers(ctx context.Context) (int64, error) {
+	return s.next.CountServers(ctx)
+}
+
+// CountSearchResults returns the number of servers a SearchDetails search with the same options finds
+func (s *EventingRegistryService) CountSearchResults(ctx context.Context, opts SearchOptions) (int64, error) {
+	return s.next.CountSearchResults(ctx, opts)
+}
+
+// SearchFacets returns the number of servers matching a search query with each package registry, tag, license and
+// status
+func (s *EventingRegistryService) SearchFacets(ctx context.Context, query string) (model.Facets, error) {
+	return s.next.SearchFacets(ctx, query)
+}
+
+// SearchDetails searches for servers matching the search options and returns full details
+func (s *EventingRegistryService) SearchDetails(opts SearchOptions) ([]model.ServerDetail, database.PageCursors, error) {
+	return s.next.SearchDetails(opts)
+}
+
+// Stats returns aggregate metrics of the registry
+func (s *EventingRegistryService) Stats() (model.RegistryStats, error) {
+	return s.next.Stats()
+}
+
+// ListTags returns the tags of servers with the number of servers carrying each, sorted by tag
+func (s *EventingRegistryService) ListTags(ctx context.Context) ([]model.TagCount, error) {
+	return s.next.ListTags(ctx)
+}
+
+// ListTopics returns the repository topics of servers with the number of servers tagged with each
+func (s *EventingRegistryService) ListTopics(ctx context.Context) ([]model.TopicCount, error) {
+	return s.next.ListTopics(ctx)
+}
+
+// ListLanguages returns the languages servers are written in with the number of servers in each
+func (s *EventingRegistryService) ListLanguages(ctx context.Context) ([]model.LanguageCount, error) {
+	return s.next.ListLanguages(ctx)
+}
+
+// ListCategories returns all categories with the number of servers in each
+func (s *EventingRegistryService) ListCategories(ctx context.Context) ([]model.CategoryCount, error) {
+	return s.next.ListCategories(ctx)
+}
+
+// SaveProvenance stores a SLSA provenance attestation for the server version with the given ID
+func (s *EventingRegistryService) SaveProvenance(ctx context.Context, id string, attestation []byte) (*model.Provenance, error) {
+	return s.next.SaveProvenance(ctx, id, attestation)
+}
+
+// GetProvenance retrieves the provenance attestation of a server version,
+// or the most recently attested version if version is empty
+func (s *EventingRegistryService) GetProvenance(ctx context.Context, id, version string) (*model.Provenance, error) {
+	return s.next.GetProvenance(ctx, id, version)
+}
+
+// GetSchema retrieves the JSON schema published with the server version with the given ID
+func (s *EventingRegistryService) GetSchema(ctx context.Context, id string) (json.RawMessage, error) {
+	return s.next.GetSchema(ctx, id)
+}
+
+// CountDependents returns the number of server versions that depend on the server with the given ID
+func (s *EventingRegistryService) CountDependents(ctx context.Context, id string) (int64, error) {
+	return s.next.CountDependents(ctx, id)
+}
+
+// Network returns the servers directly connected to the server with the given ID
+func (s *EventingRegistryService) Network(ctx context.Context, id string) (*model.ServerNetwork, error) {
+	return s.next.Network(ctx, id)
+}
+
+// GetGitHubMetadata retrieves the GitHub repository information stored when the server version with the given ID
+// was published
+func (s *EventingRegistryService) GetGitHubMetadata(ctx context.Context, id string) (json.RawMessage, error) {
+	return s.next.GetGitHubMetadata(ctx, id)
+}
+
+// SubmitReview stores a community review of the server with the given ID
+func (s *EventingRegistryService) SubmitReview(ctx context.Context, id string, review *model.Review) error {
+	return s.next.SubmitReview(ctx, id, review)
+}
+
+// ListReviews retrieves the reviews of the server with the given ID, newest first
+func (s *EventingRegistryService) ListReviews(
+	ctx context.Context, id string, cursor string, direction database.CursorDirection, limit int,
+) ([]model.Review, database.PageCursors, error) {
+	return s.next.ListReviews(ctx, id, cursor, direction, limit)
+}
+
+// StartReindex starts rebuilding the text search index in the background
+func (s *EventingRegistryService) StartReindex(ctx context.Context) (*model.ReindexJob, error) {
+	return s.next.StartReindex(ctx)
+}
+
+// GetReindexJob retrieves the status of a text index rebuild job
+func (s *EventingRegistryService) GetReindexJob(ctx context.Context, id string) (*model.ReindexJob, error) {
+	return s.next.GetReindexJob(ctx, id)
+}
+
+// StartVacuum starts removing stale records and compacting the database in the background
+func (s *EventingRegistryService) StartVacuum(ctx context.Context) (*model.VacuumJob, error) {
+	return s.next.StartVacuum(ctx)
+}
+
+// GetVacuumJob retrieves the status of a vacuum job
+func (s *EventingRegistryService) GetVacuumJob(ctx context.Context, id string) (*model.VacuumJob, error) {
+	return s.next.GetVacuumJob(ctx, id)
+}
+
+// SaveSubscription subscribes a webhook to a server, or updates the events of an existing subscription
+// of the server with the same webhook URL. It reports whether a new subscription was created.
+func (s *EventingRegistryService) SaveSubscription(ctx context.Context, subscription *model.Subscription) (bool, error) {
+	return s.next.SaveSubscription(ctx, subscription)
+}
+
+// GetSubscription retrieves a webhook subscription by its ID
+func (s *EventingRegistryService) GetSubscription(ctx context.Context, id string) (*model.Subscription, error) {
+	return s.next.GetSubscription(ctx, id)
+}
+
+// DeleteSubscription removes a webhook subscription
+func (s *EventingRegistryService) DeleteSubscription(ctx context.Context, id string) error {
+	return s.next.DeleteSubscription(ctx, id)
+}
+
+// publishUpdated broadcasts an updated event for the server with the given ID
+func (s *EventingRegistryService) publishUpdated(ctx context.Context, id string) {
+	event := events.Event{
+		Type:     events.EventUpdated,
+		ServerID: id,
+	}
+
+	// The name is informational only, so a failed lookup does not prevent the event
+	if serverMeta, err := s.next.GetMetadata(ctx, id); err == nil {
+		event.Name = serverMeta.Name
+	}
+
+	s.hub.Publish(event)
+}
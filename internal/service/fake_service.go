@@ -2,16 +2,22 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/database/mongodb"
 	"github.com/modelcontextprotocol/registry/internal/model"
 )
 
 // fakeRegistryService implements RegistryService interface with an in-memory database
 type fakeRegistryService struct {
-	db *database.MemoryDB
+	db         *database.MemoryDB
+	dependents *dependentsCounter
+	networks   *networkBuilder
 }
 
 // NewFakeRegistryService creates a new fake registry service with pre-populated data
@@ -74,28 +80,110 @@ func NewFakeRegistryService() RegistryService {
 	}
 	memDB := database.NewMemoryDB(registryMap)
 	return &fakeRegistryService{
-		db: memDB,
+		db:         memDB,
+		dependents: newDependentsCounter(memDB, DefaultDependentsCountTTL),
+		networks:   newNetworkBuilder(memDB, DefaultNetworkTTL),
 	}
 }
 
 // List retrieves MCPRegistry entries with optional filtering and pagination
-func (s *fakeRegistryService) List(cursor string, limit int) ([]model.Server, string, error) {
+func (s *fakeRegistryService) List(
+	sortBy string, cursor string, direction database.CursorDirection, limit int,
+) ([]model.Server, database.PageCursors, error) {
+	sortOrder, err := parseListSortOrder(sortBy)
+	if err != nil {
+		return nil, database.PageCursors{}, err
+	}
+
 	// Create a timeout context for the database operation
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	// Use the database's List method with no filters to get all entries
-	entries, nextCursor, err := s.db.List(ctx, nil, cursor, limit)
+	entries, cursors, err := s.db.List(ctx, nil, sortOrder, cursor, direction, limit)
+	if err != nil {
+		return nil, database.PageCursors{}, err
+	}
+	// Convert from []*model.Server to []model.Server
+	result := make([]model.Server, len(entries))
+	for i, entry := range entries {
+		result[i] = *entry
+	}
+
+	return result, cursors, nil
+}
+
+// ListUpdatedAfter returns the versions of all servers updated strictly after the given time, or at that time
+// with a creation sequence number greater than afterSeq, least recently updated first
+func (s *fakeRegistryService) ListUpdatedAfter(
+	ctx context.Context, updatedAfter time.Time, afterSeq int64, limit int,
+) ([]model.Server, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	entries, err := s.db.ListUpdatedAfter(ctx, updatedAfter, afterSeq, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert from []*model.Server to []model.Server
+	result := make([]model.Server, len(entries))
+	for i, entry := range entries {
+		result[i] = *entry
+	}
+
+	return result, nil
+}
+
+// ListRecent returns the latest versions of the most recently published servers, newest first
+func (s *fakeRegistryService) ListRecent(ctx context.Context, limit int) ([]model.Server, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	entries, err := s.db.ListRecent(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert from []*model.Server to []model.Server
+	result := make([]model.Server, len(entries))
+	for i, entry := range entries {
+		result[i] = *entry
+	}
+
+	return result, nil
+}
+
+// ListFeatured returns the featured servers in their display order
+func (s *fakeRegistryService) ListFeatured(ctx context.Context) ([]model.Server, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	entries, err := s.db.ListFeatured(ctx)
 	if err != nil {
-		return nil, "", err
+		return nil, err
 	}
+
 	// Convert from []*model.Server to []model.Server
 	result := make([]model.Server, len(entries))
 	for i, entry := range entries {
 		result[i] = *entry
 	}
 
-	return result, nextCursor, nil
+	return result, nil
+}
+
+// ReorderFeatured sets the display order of the featured servers to the order of the given IDs,
+// which must list every featured server exactly once
+func (s *fakeRegistryService) ReorderFeatured(ctx context.Context, ids []string) error {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.ReorderFeatured(ctx, ids)
 }
 
 // GetByID retrieves a specific server detail by its ID
@@ -113,47 +201,323 @@ func (s *fakeRegistryService) GetByID(id string) (*model.ServerDetail, error) {
 	return serverDetail, nil
 }
 
+// GetByName retrieves the latest version of the server with the given name
+func (s *fakeRegistryService) GetByName(ctx context.Context, name string) (*model.ServerDetail, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	id, err := latestVersionID(ctx, s.db, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.db.GetByID(ctx, id)
+}
+
+// GetByIDs retrieves the servers with the given IDs in the order of the IDs, skipping unknown IDs
+func (s *fakeRegistryService) GetByIDs(ctx context.Context, ids []string) ([]model.ServerDetail, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return serversByIDs(ctx, s.db, ids)
+}
+
+// GetMetadata retrieves a server by its ID without its packages list
+func (s *fakeRegistryService) GetMetadata(ctx context.Context, id string) (*model.ServerDetail, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	serverDetail, err := s.db.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return serverMetadata(serverDetail), nil
+}
+
 // Publish adds a new server detail to the in-memory database
 func (s *fakeRegistryService) Publish(serverDetail *model.ServerDetail) error {
 	// Create a timeout context for the database operation
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	if err := validationErr(ValidateServerDetail(serverDetail, DefaultValidationConfig())); err != nil {
+		return err
+	}
+
+	if err := validateDependencies(ctx, s.db, serverDetail); err != nil {
+		return err
+	}
+
+	normalizeCategory(serverDetail)
+	normalizeLanguage(serverDetail)
+	normalizeTopics(serverDetail)
+	serverDetail.Tags = normalizeLabels(serverDetail.Tags)
+	serverDetail.License = normalizeLicense(serverDetail.License)
+	normalizeStatus(serverDetail)
+	populateDocURLs(serverDetail.Packages)
+
+	// Ownership changes only through transfers, and maintainers are managed through the maintainers endpoints,
+	// never by the published document
+	serverDetail.PublisherUsername = ""
+	serverDetail.MaintainedBy = nil
+	// Repository stats are synced from GitHub by the registry, never reported by the publisher
+	serverDetail.RepositoryStats = nil
+	// Only the registry owner features servers
+	serverDetail.FeaturedOrder = nil
+	// Versions are pinned through the pin endpoint, and a new version releases any pin
+	serverDetail.PinnedVersion = false
+	// The rating summarizes the reviews, which only SaveReview records
+	serverDetail.AverageRating = 0
+	serverDetail.ReviewCount = 0
+
 	// Use the database's Publish method to add the server detail
 	return s.db.Publish(ctx, serverDetail)
 }
 
-// Search searches for servers by name with optional registry_name filter
-func (s *fakeRegistryService) Search(query string, registryName string, url string, cursor string, limit int) ([]model.Server, string, error) {
+// PublishVersion publishes a new version of the server with the given ID
+func (s *fakeRegistryService) PublishVersion(
+	id string, versionDetail model.VersionDetail, packages []model.Package,
+) (*model.ServerDetail, error) {
 	// Create a timeout context for the database operation
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	// If limit is not set or negative, use a default limit
-	if limit <= 0 {
-		limit = 30
+	if len(packages) == 0 {
+		return nil, database.ErrInvalidInput
+	}
+
+	serverDetail, err := newServerVersion(ctx, s.db, id, versionDetail, packages)
+	if err != nil {
+		return nil, err
+	}
+	if err := validationErr(ValidateServerDetail(serverDetail, DefaultValidationConfig())); err != nil {
+		return nil, err
 	}
+	populateDocURLs(serverDetail.Packages)
 
-	// Build the filter map
-	filter := make(map[string]interface{})
+	if err := s.db.Publish(ctx, serverDetail); err != nil {
+		return nil, err
+	}
+	return serverDetail, nil
+}
 
-	// Add regex search for name if query is provided
-	if query != "" {
-		filter["name"] = map[string]interface{}{
-			"$regex":   query,
-			"$options": "i", // Case-insensitive search
+// AppendPackages adds packages to an existing server and returns the updated packages list
+func (s *fakeRegistryService) AppendPackages(ctx context.Context, id string, packages []model.Package) ([]model.Package, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if len(packages) == 0 {
+		return nil, database.ErrInvalidInput
+	}
+
+	serverDetail, err := s.db.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := DefaultValidationConfig()
+	if err := validationErr(validateAppendedPackages(serverDetail, packages, cfg)); err != nil {
+		return nil, err
+	}
+
+	populateDocURLs(packages)
+
+	if err := s.db.AppendPackages(ctx, id, packages, cfg.MaxPackages); err != nil {
+		if errors.Is(err, database.ErrTooManyPackages) {
+			return nil, tooManyPackagesErr(cfg.MaxPackages)
 		}
+		return nil, err
+	}
+
+	updated, err := s.db.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated.Packages, nil
+}
+
+// RemovePackage removes a package from an existing server
+func (s *fakeRegistryService) RemovePackage(ctx context.Context, id, registryName, packageName string) error {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.RemovePackage(ctx, id, registryName, packageName)
+}
+
+// YankPackage marks the packages of an existing server with the given registry name and version as yanked
+// and returns the updated packages list
+func (s *fakeRegistryService) YankPackage(ctx context.Context, id, registryName, version, reason string) ([]model.Package, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := s.db.YankPackage(ctx, id, registryName, version, reason); err != nil {
+		return nil, err
 	}
 
-	// Add registry_name filter if provided
-	if registryName != "" {
-		filter["packages.registry_name"] = registryName
+	updated, err := s.db.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
 	}
 
+	return updated.Packages, nil
+}
+
+// AddTags adds tags to an existing server and returns the updated tags list. Tags are stored in lowercase.
+func (s *fakeRegistryService) AddTags(ctx context.Context, id string, tags []string) ([]string, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tags = normalizeLabels(tags)
+	if len(tags) == 0 {
+		return nil, database.ErrInvalidInput
+	}
+
+	serverDetail, err := s.db.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validationErr(validateAddedTags(serverDetail, tags)); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.AddTags(ctx, id, tags); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.db.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated.Tags, nil
+}
+
+// RemoveTag removes a tag from an existing server
+func (s *fakeRegistryService) RemoveTag(ctx context.Context, id, tag string) error {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.RemoveTag(ctx, id, strings.ToLower(tag))
+}
+
+// PinVersion marks the given version of a server as its latest version
+func (s *fakeRegistryService) PinVersion(ctx context.Context, id, version string) error {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.PinVersion(ctx, id, version)
+}
+
+// UpgradeVersion promotes the given version of a server to its latest version without pinning it
+func (s *fakeRegistryService) UpgradeVersion(ctx context.Context, id, version string) error {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.UpgradeVersion(ctx, id, version)
+}
+
+// Update replaces the description, packages, version number and release date of a server version
+func (s *fakeRegistryService) Update(id string, detail *model.ServerDetail) error {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := validationErr(ValidateServerDetail(detail, DefaultValidationConfig())); err != nil {
+		return err
+	}
+
+	populateDocURLs(detail.Packages)
+
+	return s.db.Update(ctx, id, detail)
+}
+
+// Delete removes a server version from the registry
+func (s *fakeRegistryService) Delete(id string) error {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return s.db.Delete(ctx, id)
+}
+
+// YankVersion marks the given version of a server as yanked
+func (s *fakeRegistryService) YankVersion(ctx context.Context, id, version string) error {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.YankVersion(ctx, id, version)
+}
+
+// ListVersions returns the versions of the server with the given ID in publish order
+func (s *fakeRegistryService) ListVersions(
+	ctx context.Context, id string, includeYanked bool, cursor string, direction database.CursorDirection, limit int,
+) ([]model.ServerVersion, database.PageCursors, error) {
+	return listVersions(ctx, s.db, id, includeYanked, cursor, direction, limit)
+}
+
+// TransferOwnership hands all versions of a server over to a new publisher
+func (s *fakeRegistryService) TransferOwnership(ctx context.Context, id, newOwner string) error {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.TransferOwnership(ctx, id, newOwner)
+}
+
+// AddMaintainer adds a GitHub user to the maintainers of all versions of a server
+func (s *fakeRegistryService) AddMaintainer(ctx context.Context, id, username string) ([]string, error) {
+	return addMaintainer(ctx, s.db, id, username)
+}
+
+// RemoveMaintainer removes a GitHub user from the maintainers of all versions of a server
+func (s *fakeRegistryService) RemoveMaintainer(ctx context.Context, id, username string) ([]string, error) {
+	return removeMaintainer(ctx, s.db, id, username)
+}
+
+// UpdateStatus sets the lifecycle status of all versions of a server
+func (s *fakeRegistryService) UpdateStatus(ctx context.Context, id, status string, byRegistryOwner bool) error {
+	return updateStatus(ctx, s.db, id, status, byRegistryOwner)
+}
+
+// Search searches for servers by name with optional registry_name filter
+func (s *fakeRegistryService) Search(opts SearchOptions) ([]model.Server, database.PageCursors, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// If limit is not set or negative, use a default limit
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 30
+	}
+
+	// Search by name with optional registry_name filter
+	filter := mongodb.NewQueryBuilder().
+		WithNameSearch(opts.Query).
+		WithRegistryName(opts.RegistryName).
+		WithCategory(opts.Category).
+		WithLanguage(opts.Language).
+		WithTopic(opts.Topic).
+		Build()
+
 	// Use the database's List method with search filters
-	entries, nextCursor, err := s.db.List(ctx, filter, cursor, limit)
+	entries, cursors, err := s.db.List(ctx, filter, database.SortByCreation, opts.Cursor, opts.Direction, limit)
 	if err != nil {
-		return nil, "", err
+		return nil, database.PageCursors{}, err
 	}
 
 	// Convert from []*model.Server to []model.Server
@@ -162,52 +526,227 @@ func (s *fakeRegistryService) Search(query string, registryName string, url stri
 		result[i] = *entry
 	}
 
-	return result, nextCursor, nil
+	return result, cursors, nil
 }
 
 // SearchDetails searches for servers by name with optional registry_name filter and returns full details
-func (s *fakeRegistryService) SearchDetails(query string, registryName string, url string, cursor string, limit int) ([]model.ServerDetail, string, error) {
+func (s *fakeRegistryService) SearchDetails(opts SearchOptions) ([]model.ServerDetail, database.PageCursors, error) {
+	sortOrder, err := parseSortOrder(opts.Sort)
+	if err != nil {
+		return nil, database.PageCursors{}, err
+	}
+
 	// Create a timeout context for the database operation
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	// If limit is not set or negative, use a default limit
+	limit := opts.Limit
 	if limit <= 0 {
 		limit = 30
 	}
 
-	// Build the filter map
-	filter := make(map[string]interface{})
-
-	// Add regex search for name if query is provided
-	if query != "" {
-		filter["name"] = map[string]interface{}{
-			"$regex":   query,
-			"$options": "i", // Case-insensitive search
-		}
-	}
-
-	// Add registry_name filter if provided
-	if registryName != "" {
-		filter["packages.registry_name"] = registryName
-	}
+	// Search by name with optional registry_name filter
+	builder := withSearchFilters(mongodb.NewQueryBuilder().WithNameSearch(opts.Query), opts)
 
 	// Use the database's ListDetails method with search filters
-	entries, nextCursor, err := s.db.ListDetails(ctx, filter, cursor, limit)
+	entries, cursors, err := listDetailsInMCPRange(
+		ctx, s.db, builder, sortOrder, opts.MinMCPVersion, opts.MaxMCPVersion, opts.Cursor, opts.Direction, limit,
+	)
 	if err != nil {
-		return nil, "", err
+		return nil, database.PageCursors{}, err
 	}
 
 	// Convert from []*model.ServerDetail to []model.ServerDetail
 	result := make([]model.ServerDetail, len(entries))
 	for i, entry := range entries {
 		result[i] = *entry
+		result[i].Highlights = highlights(entry, opts.Query)
 	}
 
-	return result, nextCursor, nil
+	return result, cursors, nil
 }
 
 // Close closes the in-memory database connection
 func (s *fakeRegistryService) Close() error {
 	return s.db.Close()
 }
+
+// ListCategories returns all categories with the number of servers in each
+func (s *fakeRegistryService) ListCategories(ctx context.Context) ([]model.CategoryCount, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return categoryCounts(ctx, s.db)
+}
+
+// CountServers returns the number of servers in the registry
+func (s *fakeRegistryService) CountServers(ctx context.Context) (int64, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.Count(ctx, nil)
+}
+
+// CountSearchResults returns the number of servers a SearchDetails search with the same options finds over all
+// pages
+func (s *fakeRegistryService) CountSearchResults(ctx context.Context, opts SearchOptions) (int64, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	builder := withSearchFilters(mongodb.NewQueryBuilder().WithNameSearch(opts.Query), opts)
+	return countDetailsInMCPRange(ctx, s.db, builder, opts.MinMCPVersion, opts.MaxMCPVersion)
+}
+
+// SearchFacets returns the number of servers whose name matches a search query with each package registry, tag,
+// license and status
+func (s *fakeRegistryService) SearchFacets(ctx context.Context, query string) (model.Facets, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.CountFacets(ctx, mongodb.NewQueryBuilder().WithNameSearch(query).Build())
+}
+
+// Stats returns aggregate metrics of the registry
+func (s *fakeRegistryService) Stats() (model.RegistryStats, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return registryStats(ctx, s.db)
+}
+
+// ListTags returns the tags of servers with the number of servers carrying each, sorted by tag
+func (s *fakeRegistryService) ListTags(ctx context.Context) ([]model.TagCount, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return tagCounts(ctx, s.db)
+}
+
+// ListTopics returns the repository topics of servers with the number of servers tagged with each
+func (s *fakeRegistryService) ListTopics(ctx context.Context) ([]model.TopicCount, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return topicCounts(ctx, s.db)
+}
+
+// ListLanguages returns the languages servers are written in with the number of servers in each
+func (s *fakeRegistryService) ListLanguages(ctx context.Context) ([]model.LanguageCount, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return languageCounts(ctx, s.db)
+}
+
+// SaveProvenance stores a SLSA provenance attestation for the server version with the given ID
+func (s *fakeRegistryService) SaveProvenance(ctx context.Context, id string, attestation []byte) (*model.Provenance, error) {
+	return saveProvenance(ctx, s.db, id, attestation)
+}
+
+// GetProvenance retrieves the provenance attestation of a server version,
+// or the most recently attested version if version is empty
+func (s *fakeRegistryService) GetProvenance(ctx context.Context, id, version string) (*model.Provenance, error) {
+	return getProvenance(ctx, s.db, id, version)
+}
+
+// GetSchema retrieves the JSON schema published with the server version with the given ID
+func (s *fakeRegistryService) GetSchema(ctx context.Context, id string) (json.RawMessage, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.GetSchema(ctx, id)
+}
+
+// GetGitHubMetadata retrieves the GitHub repository information stored when the server version with the given ID
+// was published
+func (s *fakeRegistryService) GetGitHubMetadata(ctx context.Context, id string) (json.RawMessage, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.GetGitHubMetadata(ctx, id)
+}
+
+// CountDependents returns the number of server versions that depend on the server with the given ID
+func (s *fakeRegistryService) CountDependents(ctx context.Context, id string) (int64, error) {
+	return s.dependents.count(ctx, id)
+}
+
+// Network returns the servers directly connected to the server with the given ID
+func (s *fakeRegistryService) Network(ctx context.Context, id string) (*model.ServerNetwork, error) {
+	return s.networks.build(ctx, id)
+}
+
+// SubmitReview stores a community review of the server with the given ID
+func (s *fakeRegistryService) SubmitReview(ctx context.Context, id string, review *model.Review) error {
+	return submitReview(ctx, s.db, id, review)
+}
+
+// ListReviews retrieves the reviews of the server with the given ID, newest first
+func (s *fakeRegistryService) ListReviews(
+	ctx context.Context, id string, cursor string, direction database.CursorDirection, limit int,
+) ([]model.Review, database.PageCursors, error) {
+	return listReviews(ctx, s.db, id, cursor, direction, limit)
+}
+
+// StartReindex starts rebuilding the text search index in the background
+func (s *fakeRegistryService) StartReindex(ctx context.Context) (*model.ReindexJob, error) {
+	return startReindex(ctx, s.db)
+}
+
+// GetReindexJob retrieves the status of a text index rebuild job
+func (s *fakeRegistryService) GetReindexJob(ctx context.Context, id string) (*model.ReindexJob, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.GetReindexJob(ctx, id)
+}
+
+// StartVacuum starts removing stale records and compacting the database in the background
+func (s *fakeRegistryService) StartVacuum(ctx context.Context) (*model.VacuumJob, error) {
+	return startVacuum(ctx, s.db, DefaultVacuumRetention)
+}
+
+// GetVacuumJob retrieves the status of a vacuum job
+func (s *fakeRegistryService) GetVacuumJob(ctx context.Context, id string) (*model.VacuumJob, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.GetVacuumJob(ctx, id)
+}
+
+// SaveSubscription subscribes a webhook to a server, or updates the events of an existing subscription
+// of the server with the same webhook URL. It reports whether a new subscription was created.
+func (s *fakeRegistryService) SaveSubscription(ctx context.Context, subscription *model.Subscription) (bool, error) {
+	return saveSubscription(ctx, s.db, lookupHost, subscription)
+}
+
+// GetSubscription retrieves a webhook subscription by its ID
+func (s *fakeRegistryService) GetSubscription(ctx context.Context, id string) (*model.Subscription, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.GetSubscription(ctx, id)
+}
+
+// DeleteSubscription removes a webhook subscription
+func (s *fakeRegistryService) DeleteSubscription(ctx context.Context, id string) error {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.DeleteSubscription(ctx, id)
+}
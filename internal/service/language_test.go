@@ -0,0 +1,61 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLanguages(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	for name, language := range map[string]string{
+		"example/ts-one": "TypeScript",
+		"example/ts-two": " typescript ",
+		"example/py":     "Python",
+		"example/go":     "Go",
+		"example/none":   "",
+	} {
+		serverDetail := newServerDetail(name, "")
+		serverDetail.Language = language
+		require.NoError(t, registry.Publish(serverDetail))
+	}
+
+	t.Run("stored in lowercase", func(t *testing.T) {
+		servers, _, err := registry.Search(service.SearchOptions{
+			Language:  "TYPESCRIPT",
+			Direction: database.CursorNext,
+			Limit:     10,
+		})
+		require.NoError(t, err)
+		require.Len(t, servers, 2)
+		for _, server := range servers {
+			assert.Equal(t, "typescript", server.Language)
+		}
+	})
+
+	t.Run("text search matches the language", func(t *testing.T) {
+		servers, _, err := registry.SearchDetails(service.SearchOptions{
+			Query:     "python",
+			Direction: database.CursorNext,
+			Limit:     10,
+		})
+		require.NoError(t, err)
+		require.Len(t, servers, 1)
+		assert.Equal(t, "example/py", servers[0].Name)
+	})
+
+	t.Run("most common first", func(t *testing.T) {
+		languages, err := registry.ListLanguages(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []model.LanguageCount{
+			{Language: "typescript", Count: 2},
+			{Language: "go", Count: 1},
+			{Language: "python", Count: 1},
+		}, languages)
+	})
+}
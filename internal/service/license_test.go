@@ -0,0 +1,80 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLicenses(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	for name, license := range map[string]string{
+		"example/mit":    "MIT",
+		"example/mit-lc": " mit ",
+		"example/apache": "Apache-2.0",
+		"example/none":   "",
+	} {
+		serverDetail := newServerDetail(name, "")
+		serverDetail.License = license
+		require.NoError(t, registry.Publish(serverDetail))
+	}
+
+	t.Run("unknown licenses are rejected", func(t *testing.T) {
+		serverDetail := newServerDetail("example/proprietary", "")
+		serverDetail.License = "Proprietary"
+		err := registry.Publish(serverDetail)
+		require.ErrorIs(t, err, database.ErrInvalidLicense)
+		require.ErrorIs(t, err, database.ErrValidation)
+	})
+
+	t.Run("stored as the canonical identifier", func(t *testing.T) {
+		servers, _, err := registry.SearchDetails(service.SearchOptions{
+			License:   "mit",
+			Direction: database.CursorNext,
+			Limit:     10,
+		})
+		require.NoError(t, err)
+		names := make([]string, len(servers))
+		for i, server := range servers {
+			names[i] = server.Name
+			assert.Equal(t, "MIT", server.License)
+		}
+		assert.ElementsMatch(t, []string{"example/mit", "example/mit-lc"}, names)
+	})
+
+	t.Run("filter matches exactly", func(t *testing.T) {
+		servers, _, err := registry.SearchDetails(service.SearchOptions{
+			License:   "Apache-2.0",
+			Direction: database.CursorNext,
+			Limit:     10,
+		})
+		require.NoError(t, err)
+		require.Len(t, servers, 1)
+		assert.Equal(t, "example/apache", servers[0].Name)
+
+		servers, _, err = registry.SearchDetails(service.SearchOptions{
+			License:   "Apache",
+			Direction: database.CursorNext,
+			Limit:     10,
+		})
+		require.NoError(t, err)
+		assert.Empty(t, servers)
+	})
+
+	t.Run("returned with the server", func(t *testing.T) {
+		servers, _, err := registry.SearchDetails(service.SearchOptions{
+			License:   "Apache-2.0",
+			Direction: database.CursorNext,
+			Limit:     10,
+		})
+		require.NoError(t, err)
+		require.Len(t, servers, 1)
+		serverDetail, err := registry.GetByID(servers[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Apache-2.0", serverDetail.License)
+	})
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	_ "embed"
+	"strings"
+)
+
+// spdxLicenseList is a curated subset of the SPDX license list (https://spdx.org/licenses/), covering the
+// licenses open source servers commonly use, with one identifier per line
+//
+//go:embed spdx_licenses.txt
+var spdxLicenseList string
+
+// spdxLicenses maps the lowercase form of each known SPDX identifier to its canonical form
+var spdxLicenses = func() map[string]string {
+	licenses := make(map[string]string)
+	for _, line := range strings.Split(spdxLicenseList, "\n") {
+		if id := strings.TrimSpace(line); id != "" {
+			licenses[strings.ToLower(id)] = id
+		}
+	}
+	return licenses
+}()
+
+// canonicalLicense returns the canonical form of an SPDX license identifier, ignoring case, and whether the
+// identifier is known
+func canonicalLicense(license string) (string, bool) {
+	id, ok := spdxLicenses[strings.ToLower(strings.TrimSpace(license))]
+	return id, ok
+}
+
+// normalizeLicense returns the canonical form of a known SPDX license identifier so filters match it
+// consistently. Unknown identifiers are returned trimmed.
+func normalizeLicense(license string) string {
+	if id, ok := canonicalLicense(license); ok {
+		return id
+	}
+	return strings.TrimSpace(license)
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// addMaintainer adds a GitHub user to the maintainers of all versions of the server with the given ID
+// and returns the updated maintainers
+func addMaintainer(ctx context.Context, db database.Database, id, username string) ([]string, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	serverDetail, err := db.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if serverDetail.IsMaintainer(username) {
+		return nil, fmt.Errorf("%w: %s is already a maintainer", database.ErrAlreadyExists, username)
+	}
+
+	serverDetail.AddMaintainer(username)
+	if err := db.SetMaintainers(ctx, id, serverDetail.MaintainedBy); err != nil {
+		return nil, err
+	}
+
+	return serverDetail.MaintainedBy, nil
+}
+
+// removeMaintainer removes a GitHub user from the maintainers of all versions of the server with the given ID
+// and returns the updated maintainers. The publisher cannot be removed; ownership has to be transferred instead.
+func removeMaintainer(ctx context.Context, db database.Database, id, username string) ([]string, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	serverDetail, err := db.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.EqualFold(username, serverDetail.Publisher()) {
+		return nil, fmt.Errorf("%w: the publisher cannot be removed as a maintainer", database.ErrInvalidInput)
+	}
+
+	if !serverDetail.RemoveMaintainer(username) {
+		return nil, fmt.Errorf("%w: %s is not a maintainer", database.ErrNotFound, username)
+	}
+	if err := db.SetMaintainers(ctx, id, serverDetail.MaintainedBy); err != nil {
+		return nil, err
+	}
+
+	return serverDetail.MaintainedBy, nil
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+)
+
+// DefaultNetworkTTL is how long the network of a server is cached
+const DefaultNetworkTTL = 10 * time.Minute
+
+// networkBuilder builds the networks of servers, caching each network for a fixed time.
+// Finding the related servers is expensive, and networks change rarely.
+type networkBuilder struct {
+	db       database.Database
+	ttl      time.Duration
+	networks sync.Map
+}
+
+// newNetworkBuilder creates a builder caching networks for ttl; networks are not cached if ttl is not positive
+func newNetworkBuilder(db database.Database, ttl time.Duration) *networkBuilder {
+	return &networkBuilder{
+		db:  db,
+		ttl: ttl,
+	}
+}
+
+// build returns the network of the server with the given ID.
+// It returns database.ErrNotFound if the server does not exist.
+func (b *networkBuilder) build(ctx context.Context, id string) (*model.ServerNetwork, error) {
+	if value, ok := b.networks.Load(id); ok {
+		entry := value.(cacheEntry)
+		if time.Now().Before(entry.expiresAt) {
+			return entry.value.(*model.ServerNetwork), nil
+		}
+		b.networks.Delete(id)
+	}
+
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	serverDetail, err := b.db.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	related, err := b.db.ListRelated(ctx, serverDetail, model.MaxNetworkNodes-1)
+	if err != nil {
+		return nil, err
+	}
+
+	network := newServerNetwork(serverDetail, related)
+	if b.ttl > 0 {
+		b.networks.Store(id, cacheEntry{value: network, expiresAt: time.Now().Add(b.ttl)})
+	}
+	return network, nil
+}
+
+// newServerNetwork connects a server to each related server by every relation they have
+func newServerNetwork(serverDetail *model.ServerDetail, related []*model.ServerDetail) *model.ServerNetwork {
+	network := &model.ServerNetwork{
+		Nodes: []model.ServerMinimal{{ID: serverDetail.ID, Name: serverDetail.Name}},
+		Edges: []model.NetworkEdge{},
+	}
+
+	publisher := serverDetail.Publisher()
+	for _, other := range related {
+		network.Nodes = append(network.Nodes, model.ServerMinimal{ID: other.ID, Name: other.Name})
+
+		if publisher != "" && strings.EqualFold(other.Publisher(), publisher) {
+			network.Edges = append(network.Edges, model.NetworkEdge{
+				From: serverDetail.ID, To: other.ID, Relation: model.NetworkRelationSamePublisher,
+			})
+		}
+		if slices.ContainsFunc(other.Tags, func(tag string) bool { return slices.Contains(serverDetail.Tags, tag) }) {
+			network.Edges = append(network.Edges, model.NetworkEdge{
+				From: serverDetail.ID, To: other.ID, Relation: model.NetworkRelationSameTag,
+			})
+		}
+		if slices.Contains(serverDetail.Dependencies, other.ID) {
+			network.Edges = append(network.Edges, model.NetworkEdge{
+				From: serverDetail.ID, To: other.ID, Relation: model.NetworkRelationDependsOn,
+			})
+		}
+		if slices.Contains(other.Dependencies, serverDetail.ID) {
+			network.Edges = append(network.Edges, model.NetworkEdge{
+				From: other.ID, To: serverDetail.ID, Relation: model.NetworkRelationDependsOn,
+			})
+		}
+	}
+
+	return network
+}
@@ -0,0 +1,95 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetwork(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(
+		database.NewMemoryDB(map[string]*model.Server{}),
+		service.WithNetworkTTL(100*time.Millisecond),
+	)
+	ctx := context.Background()
+
+	publish := func(name string, tags []string, dependencies ...string) *model.ServerDetail {
+		t.Helper()
+		serverDetail := newServerDetail(name, "")
+		serverDetail.Tags = tags
+		serverDetail.Dependencies = dependencies
+		require.NoError(t, registry.Publish(serverDetail))
+		return serverDetail
+	}
+
+	library := publish("io.github.lib-author/library", nil)
+	center := publish("io.github.acme/center", []string{"llm", "search"}, library.ID)
+	sibling := publish("io.github.acme/sibling", nil)
+	tagged := publish("io.github.other/tagged", []string{"search"})
+	dependent := publish("io.github.third/dependent", nil, center.ID)
+	publish("io.github.nobody/unrelated", []string{"memory"})
+
+	network, err := registry.Network(ctx, center.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, []model.ServerMinimal{
+		{ID: center.ID, Name: center.Name},
+		{ID: sibling.ID, Name: sibling.Name},
+		{ID: library.ID, Name: library.Name},
+		{ID: tagged.ID, Name: tagged.Name},
+		{ID: dependent.ID, Name: dependent.Name},
+	}, network.Nodes)
+	assert.ElementsMatch(t, []model.NetworkEdge{
+		{From: center.ID, To: sibling.ID, Relation: model.NetworkRelationSamePublisher},
+		{From: center.ID, To: library.ID, Relation: model.NetworkRelationDependsOn},
+		{From: center.ID, To: tagged.ID, Relation: model.NetworkRelationSameTag},
+		{From: dependent.ID, To: center.ID, Relation: model.NetworkRelationDependsOn},
+	}, network.Edges)
+
+	t.Run("connections are seen from both ends", func(t *testing.T) {
+		network, err := registry.Network(ctx, sibling.ID)
+		require.NoError(t, err)
+		assert.Len(t, network.Nodes, 2)
+		assert.Equal(t, []model.NetworkEdge{
+			{From: sibling.ID, To: center.ID, Relation: model.NetworkRelationSamePublisher},
+		}, network.Edges)
+	})
+
+	t.Run("unknown server", func(t *testing.T) {
+		_, err := registry.Network(ctx, uuid.New().String())
+		require.ErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("networks are cached", func(t *testing.T) {
+		publish("io.github.acme/newcomer", nil)
+
+		network, err := registry.Network(ctx, center.ID)
+		require.NoError(t, err)
+		assert.Len(t, network.Nodes, 5, "the cached network should be returned until it expires")
+
+		assert.Eventually(t, func() bool {
+			network, err := registry.Network(ctx, center.ID)
+			return err == nil && len(network.Nodes) == 6
+		}, time.Second, 20*time.Millisecond)
+	})
+
+	t.Run("networks are limited", func(t *testing.T) {
+		hub := publish("io.github.hub/hub", []string{"popular"})
+		for i := range model.MaxNetworkNodes {
+			publish(fmt.Sprintf("io.github.user%d/server", i), []string{"popular"})
+		}
+
+		network, err := registry.Network(ctx, hub.ID)
+		require.NoError(t, err)
+		assert.Len(t, network.Nodes, model.MaxNetworkNodes)
+		assert.Len(t, network.Edges, model.MaxNetworkNodes-1)
+	})
+}
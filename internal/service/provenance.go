@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+)
+
+// MaxAttestationSize is the maximum size in bytes of a provenance attestation
+const MaxAttestationSize = 50 * 1024
+
+const (
+	// inTotoStatementType is the statement type of in-toto attestations (in-toto Statement v1)
+	inTotoStatementType = "https://in-toto.io/Statement/v1"
+	// slsaProvenancePredicateType is the predicate type of SLSA provenance v1.0
+	slsaProvenancePredicateType = "https://slsa.dev/provenance/v1"
+)
+
+// inTotoStatement represents the parts of an in-toto statement carrying SLSA provenance that are validated
+type inTotoStatement struct {
+	Type    string `json:"_type"`
+	Subject []struct {
+		Name   string            `json:"name"`
+		Digest map[string]string `json:"digest"`
+	} `json:"subject"`
+	PredicateType string `json:"predicateType"`
+	Predicate     *struct {
+		BuildDefinition *struct {
+			BuildType          string          `json:"buildType"`
+			ExternalParameters json.RawMessage `json:"externalParameters"`
+		} `json:"buildDefinition"`
+		RunDetails *struct {
+			Builder *struct {
+				ID string `json:"id"`
+			} `json:"builder"`
+		} `json:"runDetails"`
+	} `json:"predicate"`
+}
+
+// validateAttestation checks that an attestation is an in-toto statement carrying SLSA provenance v1.0
+func validateAttestation(attestation []byte) error {
+	if len(attestation) > MaxAttestationSize {
+		return fmt.Errorf("%w: larger than %d bytes", database.ErrInvalidAttestation, MaxAttestationSize)
+	}
+
+	var statement inTotoStatement
+	if err := json.Unmarshal(attestation, &statement); err != nil {
+		return fmt.Errorf("%w: %w", database.ErrInvalidAttestation, err)
+	}
+
+	switch {
+	case statement.Type != inTotoStatementType:
+		return fmt.Errorf("%w: _type must be %q", database.ErrInvalidAttestation, inTotoStatementType)
+	case statement.PredicateType != slsaProvenancePredicateType:
+		return fmt.Errorf("%w: predicateType must be %q", database.ErrInvalidAttestation, slsaProvenancePredicateType)
+	case len(statement.Subject) == 0:
+		return fmt.Errorf("%w: at least one subject is required", database.ErrInvalidAttestation)
+	case statement.Predicate == nil:
+		return fmt.Errorf("%w: predicate is required", database.ErrInvalidAttestation)
+	case statement.Predicate.BuildDefinition == nil || statement.Predicate.BuildDefinition.BuildType == "":
+		return fmt.Errorf("%w: predicate.buildDefinition.buildType is required", database.ErrInvalidAttestation)
+	case len(statement.Predicate.BuildDefinition.ExternalParameters) == 0:
+		return fmt.Errorf("%w: predicate.buildDefinition.externalParameters is required", database.ErrInvalidAttestation)
+	case statement.Predicate.RunDetails == nil || statement.Predicate.RunDetails.Builder == nil ||
+		statement.Predicate.RunDetails.Builder.ID == "":
+		return fmt.Errorf("%w: predicate.runDetails.builder.id is required", database.ErrInvalidAttestation)
+	}
+
+	for _, subject := range statement.Subject {
+		if len(subject.Digest) == 0 {
+			return fmt.Errorf("%w: every subject requires a digest", database.ErrInvalidAttestation)
+		}
+	}
+
+	return nil
+}
+
+// saveProvenance validates an attestation and stores it as the provenance of the server version with the given ID
+func saveProvenance(ctx context.Context, db database.Database, id string, attestation []byte) (*model.Provenance, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := validateAttestation(attestation); err != nil {
+		return nil, err
+	}
+
+	serverDetail, err := db.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	provenance := &model.Provenance{
+		ServerID:    id,
+		ServerName:  serverDetail.Name,
+		Version:     serverDetail.VersionDetail.Version,
+		Attestation: slices.Clone(attestation),
+		CreatedAt:   time.Now().UTC(),
+	}
+	if err := db.SaveProvenance(ctx, provenance); err != nil {
+		return nil, err
+	}
+
+	return provenance, nil
+}
+
+// getProvenance retrieves the provenance of a version of the server with the given ID,
+// or the most recently attested version if version is empty
+func getProvenance(ctx context.Context, db database.Database, id, version string) (*model.Provenance, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	serverDetail, err := db.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.GetProvenance(ctx, serverDetail.Name, version)
+}
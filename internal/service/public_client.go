@@ -0,0 +1,85 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+const (
+	// publicDialTimeout bounds connecting to a host chosen by a publisher or subscriber
+	publicDialTimeout = 5 * time.Second
+	// healthCheckTimeout bounds a server health check
+	healthCheckTimeout = 5 * time.Second
+	// maxHealthCheckRedirects is the number of redirects a health check follows
+	maxHealthCheckRedirects = 5
+)
+
+var (
+	// ErrPrivateAddress is returned when a host chosen by a publisher or subscriber is or resolves to an address
+	// that is not publicly routable
+	ErrPrivateAddress = errors.New("address is not publicly routable")
+	// ErrInsecureRedirect is returned when a health check is redirected to a URL that is not HTTPS
+	ErrInsecureRedirect = errors.New("redirect to a URL that is not HTTPS")
+)
+
+// isPublicAddress reports whether an address may be called on behalf of publishers and subscribers. Loopback,
+// private and link-local addresses are rejected so that they cannot reach the registry host or its internal network.
+func isPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsValid() &&
+		!addr.IsLoopback() &&
+		!addr.IsPrivate() &&
+		!addr.IsLinkLocalUnicast() &&
+		!addr.IsLinkLocalMulticast() &&
+		!addr.IsInterfaceLocalMulticast() &&
+		!addr.IsUnspecified()
+}
+
+// newPublicTransport creates a transport that refuses to connect to addresses that are not public. The address is
+// checked after the host was resolved, so hosts that resolve to a private address are refused as well. Proxies are
+// not used, since the address checked would be the proxy's rather than the host's.
+func newPublicTransport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout: publicDialTimeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !isPublicAddress(addrPort.Addr()) {
+				return fmt.Errorf("%w: %s", ErrPrivateAddress, addrPort.Addr())
+			}
+			return nil
+		},
+	}
+	return &http.Transport{
+		DialContext:         dialer.DialContext,
+		ForceAttemptHTTP2:   true,
+		MaxIdleConns:        100,
+		IdleConnTimeout:     90 * time.Second,
+		TLSHandshakeTimeout: 10 * time.Second,
+	}
+}
+
+// NewHealthCheckClient creates the HTTP client for checking the health check URLs of servers. Like the webhook
+// client it only connects to public addresses, and it only follows redirects to HTTPS URLs.
+func NewHealthCheckClient() *http.Client {
+	return &http.Client{
+		Transport: newPublicTransport(),
+		Timeout:   healthCheckTimeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxHealthCheckRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxHealthCheckRedirects)
+			}
+			if req.URL.Scheme != "https" {
+				return fmt.Errorf("%w: %s", ErrInsecureRedirect, req.URL.Redacted())
+			}
+			return nil
+		},
+	}
+}
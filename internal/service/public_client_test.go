@@ -0,0 +1,57 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthCheckClient(t *testing.T) {
+	upstream := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/insecure":
+			http.Redirect(w, r, "http://example.com/health", http.StatusFound)
+		case "/secure":
+			http.Redirect(w, r, "/health", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer upstream.Close()
+
+	head := func(client *http.Client, path string) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodHead, upstream.URL+path, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		if resp != nil {
+			resp.Body.Close()
+		}
+		return resp, err
+	}
+
+	t.Run("private addresses are refused", func(t *testing.T) {
+		// The test server listens on a loopback address
+		_, err := head(service.NewHealthCheckClient(), "/health")
+		require.ErrorIs(t, err, service.ErrPrivateAddress)
+	})
+
+	// The redirect rules are checked with a transport that may reach the test server
+	client := service.NewHealthCheckClient()
+	client.Transport = upstream.Client().Transport
+
+	t.Run("redirects to plain HTTP are refused", func(t *testing.T) {
+		_, err := head(client, "/insecure")
+		require.ErrorIs(t, err, service.ErrInsecureRedirect)
+	})
+
+	t.Run("redirects to HTTPS are followed", func(t *testing.T) {
+		resp, err := head(client, "/secure")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+}
@@ -0,0 +1,91 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withPackages returns a server declaring one package in each of the given registries
+func withPackages(name string, registryNames ...string) *model.ServerDetail {
+	serverDetail := newServerDetail(name, model.CategoryOther)
+	for _, registryName := range registryNames {
+		serverDetail.Packages = append(serverDetail.Packages, model.Package{
+			RegistryName: registryName,
+			Name:         "package-" + registryName,
+			Version:      "1.0.0",
+		})
+	}
+	return serverDetail
+}
+
+func TestPublishRegistryNames(t *testing.T) {
+	testCases := []struct {
+		name          string
+		allowed       []string
+		registryNames []string
+		disallowed    []string
+	}{
+		{name: "allowed list accepts listed names", allowed: []string{"npm", "pypi"}, registryNames: []string{"npm", "pypi"}},
+		{
+			name:          "allowed list rejects other names",
+			allowed:       []string{"npm", "pypi"},
+			registryNames: []string{"npm", "docker"},
+			disallowed:    []string{`"docker"`},
+		},
+		{name: "allowed list ignores case", allowed: []string{"npm", " PyPI "}, registryNames: []string{"NPM", "pypi"}},
+		{name: "empty list accepts well-formed names", registryNames: []string{"npm", "my-registry-2"}},
+		{
+			name:          "empty list rejects malformed names",
+			registryNames: []string{"npm", "Docker", "my_registry"},
+			disallowed:    []string{`"Docker"`, `"my_registry"`},
+		},
+		{
+			name:          "error lists every offending name",
+			allowed:       []string{"npm"},
+			registryNames: []string{"docker", "cargo", "docker"},
+			disallowed:    []string{`"docker"`, `"cargo"`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			registry := service.NewRegistryServiceWithDB(
+				database.NewMemoryDB(map[string]*model.Server{}), service.WithAllowedRegistryNames(tc.allowed),
+			)
+
+			err := registry.Publish(withPackages("example/registry-names", tc.registryNames...))
+			if len(tc.disallowed) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, database.ErrInvalidRegistry)
+			for _, name := range tc.disallowed {
+				assert.Contains(t, err.Error(), name)
+			}
+		})
+	}
+}
+
+func TestAppendPackagesRegistryNames(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(
+		database.NewMemoryDB(map[string]*model.Server{}), service.WithAllowedRegistryNames([]string{"npm"}),
+	)
+	serverDetail := withPackages("example/append-registry-names", "npm")
+	require.NoError(t, registry.Publish(serverDetail))
+
+	_, err := registry.AppendPackages(context.Background(), serverDetail.ID, withPackages("", "docker").Packages)
+	require.ErrorIs(t, err, database.ErrInvalidRegistry)
+	assert.Contains(t, err.Error(), `"docker"`)
+
+	packages, err := registry.AppendPackages(context.Background(), serverDetail.ID, []model.Package{
+		{RegistryName: "NPM", Name: "another-package", Version: "1.0.0"},
+	})
+	require.NoError(t, err)
+	assert.Len(t, packages, 2)
+}
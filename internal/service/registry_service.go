@@ -2,25 +2,114 @@ package service
 
 import (
 	"context"
-	"regexp"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/database/mongodb"
 	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/search"
 )
 
 // registryServiceImpl implements the RegistryService interface using our Database
 type registryServiceImpl struct {
-	db database.Database
+	db                 database.Database
+	verifyChecksums    bool
+	vacuumRetention    time.Duration
+	validation         ValidationConfig
+	dependentsCountTTL time.Duration
+	dependents         *dependentsCounter
+	networkTTL         time.Duration
+	networks           *networkBuilder
+	resolveHost        HostResolver
+}
+
+// Option configures optional behavior of the registry service
+type Option func(*registryServiceImpl)
+
+// WithChecksumVerification makes the service fetch and record the checksums of published npm packages
+func WithChecksumVerification() Option {
+	return func(s *registryServiceImpl) {
+		s.verifyChecksums = true
+	}
+}
+
+// WithVacuumRetention sets how long finished reindex jobs are kept before a vacuum removes them
+func WithVacuumRetention(retention time.Duration) Option {
+	return func(s *registryServiceImpl) {
+		s.vacuumRetention = retention
+	}
+}
+
+// WithDependentsCountTTL sets how long the number of dependents of a server is cached
+func WithDependentsCountTTL(ttl time.Duration) Option {
+	return func(s *registryServiceImpl) {
+		s.dependentsCountTTL = ttl
+	}
+}
+
+// WithNetworkTTL sets how long the network of a server is cached
+func WithNetworkTTL(ttl time.Duration) Option {
+	return func(s *registryServiceImpl) {
+		s.networkTTL = ttl
+	}
+}
+
+// WithAllowedRegistryNames restricts the registry names packages may declare to the given names, ignoring case
+func WithAllowedRegistryNames(names []string) Option {
+	return func(s *registryServiceImpl) {
+		s.validation.AllowedRegistryNames = nil
+		for _, name := range names {
+			if name = strings.TrimSpace(name); name != "" {
+				s.validation.AllowedRegistryNames = append(s.validation.AllowedRegistryNames, name)
+			}
+		}
+	}
+}
+
+// WithMaxPackages sets the maximum number of packages a server may declare; the number is not limited if max is
+// not positive
+func WithMaxPackages(maxPackages int) Option {
+	return func(s *registryServiceImpl) {
+		s.validation.MaxPackages = maxPackages
+	}
+}
+
+// WithRequiredSemVer rejects published servers whose version is not a semantic version
+func WithRequiredSemVer() Option {
+	return func(s *registryServiceImpl) {
+		s.validation.RequireSemVer = true
+	}
+}
+
+// WithWebhookResolver sets how the hosts of subscribed webhooks are resolved when checking that they are public
+func WithWebhookResolver(resolve HostResolver) Option {
+	return func(s *registryServiceImpl) {
+		s.resolveHost = resolve
+	}
 }
 
 // NewRegistryServiceWithDB creates a new registry service with the provided database
 //
 //nolint:ireturn // Factory function intentionally returns interface for dependency injection
-func NewRegistryServiceWithDB(db database.Database) RegistryService {
-	return &registryServiceImpl{
-		db: db,
+func NewRegistryServiceWithDB(db database.Database, opts ...Option) RegistryService {
+	s := &registryServiceImpl{
+		db:                 db,
+		vacuumRetention:    DefaultVacuumRetention,
+		validation:         DefaultValidationConfig(),
+		dependentsCountTTL: DefaultDependentsCountTTL,
+		networkTTL:         DefaultNetworkTTL,
+		resolveHost:        lookupHost,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.dependents = newDependentsCounter(db, s.dependentsCountTTL)
+	s.networks = newNetworkBuilder(db, s.networkTTL)
+	return s
 }
 
 // GetAll returns all registry entries
@@ -30,7 +119,7 @@ func (s *registryServiceImpl) GetAll() ([]model.Server, error) {
 	defer cancel()
 
 	// Use the database's List method with no filters to get all entries
-	entries, _, err := s.db.List(ctx, nil, "", 30)
+	entries, _, err := s.db.List(ctx, nil, database.SortByCreation, "", database.CursorNext, 30)
 	if err != nil {
 		return nil, err
 	}
@@ -45,7 +134,14 @@ func (s *registryServiceImpl) GetAll() ([]model.Server, error) {
 }
 
 // List returns registry entries with cursor-based pagination
-func (s *registryServiceImpl) List(cursor string, limit int) ([]model.Server, string, error) {
+func (s *registryServiceImpl) List(
+	sortBy string, cursor string, direction database.CursorDirection, limit int,
+) ([]model.Server, database.PageCursors, error) {
+	sortOrder, err := parseListSortOrder(sortBy)
+	if err != nil {
+		return nil, database.PageCursors{}, err
+	}
+
 	// Create a timeout context for the database operation
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -56,9 +152,9 @@ func (s *registryServiceImpl) List(cursor string, limit int) ([]model.Server, st
 	}
 
 	// Use the database's List method with pagination
-	entries, nextCursor, err := s.db.List(ctx, nil, cursor, limit)
+	entries, cursors, err := s.db.List(ctx, nil, sortOrder, cursor, direction, limit)
 	if err != nil {
-		return nil, "", err
+		return nil, database.PageCursors{}, err
 	}
 
 	// Convert from []*model.Server to []model.Server
@@ -67,7 +163,80 @@ func (s *registryServiceImpl) List(cursor string, limit int) ([]model.Server, st
 		result[i] = *entry
 	}
 
-	return result, nextCursor, nil
+	return result, cursors, nil
+}
+
+// ListUpdatedAfter returns the versions of all servers updated strictly after the given time, or at that time
+// with a creation sequence number greater than afterSeq, least recently updated first
+func (s *registryServiceImpl) ListUpdatedAfter(
+	ctx context.Context, updatedAfter time.Time, afterSeq int64, limit int,
+) ([]model.Server, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	entries, err := s.db.ListUpdatedAfter(ctx, updatedAfter, afterSeq, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert from []*model.Server to []model.Server
+	result := make([]model.Server, len(entries))
+	for i, entry := range entries {
+		result[i] = *entry
+	}
+
+	return result, nil
+}
+
+// ListRecent returns the latest versions of the most recently published servers, newest first
+func (s *registryServiceImpl) ListRecent(ctx context.Context, limit int) ([]model.Server, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	entries, err := s.db.ListRecent(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert from []*model.Server to []model.Server
+	result := make([]model.Server, len(entries))
+	for i, entry := range entries {
+		result[i] = *entry
+	}
+
+	return result, nil
+}
+
+// ListFeatured returns the featured servers in their display order
+func (s *registryServiceImpl) ListFeatured(ctx context.Context) ([]model.Server, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	entries, err := s.db.ListFeatured(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert from []*model.Server to []model.Server
+	result := make([]model.Server, len(entries))
+	for i, entry := range entries {
+		result[i] = *entry
+	}
+
+	return result, nil
+}
+
+// ReorderFeatured sets the display order of the featured servers to the order of the given IDs,
+// which must list every featured server exactly once
+func (s *registryServiceImpl) ReorderFeatured(ctx context.Context, ids []string) error {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.ReorderFeatured(ctx, ids)
 }
 
 // GetByID retrieves a specific server detail by its ID
@@ -85,6 +254,43 @@ func (s *registryServiceImpl) GetByID(id string) (*model.ServerDetail, error) {
 	return serverDetail, nil
 }
 
+// GetByIDs retrieves the servers with the given IDs in the order of the IDs, skipping unknown IDs
+func (s *registryServiceImpl) GetByIDs(ctx context.Context, ids []string) ([]model.ServerDetail, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return serversByIDs(ctx, s.db, ids)
+}
+
+// GetByName retrieves the latest version of the server with the given name
+func (s *registryServiceImpl) GetByName(ctx context.Context, name string) (*model.ServerDetail, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	id, err := latestVersionID(ctx, s.db, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.db.GetByID(ctx, id)
+}
+
+// GetMetadata retrieves a server by its ID without its packages list
+func (s *registryServiceImpl) GetMetadata(ctx context.Context, id string) (*model.ServerDetail, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	serverDetail, err := s.db.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return serverMetadata(serverDetail), nil
+}
+
 // Publish adds a new server detail to the registry
 func (s *registryServiceImpl) Publish(serverDetail *model.ServerDetail) error {
 	// Create a timeout context for the database operation
@@ -95,126 +301,385 @@ func (s *registryServiceImpl) Publish(serverDetail *model.ServerDetail) error {
 		return database.ErrInvalidInput
 	}
 
-	err := s.db.Publish(ctx, serverDetail)
+	if err := validationErr(ValidateServerDetail(serverDetail, s.validation)); err != nil {
+		return err
+	}
+
+	normalizeCategory(serverDetail)
+	normalizeLanguage(serverDetail)
+	normalizeTopics(serverDetail)
+	serverDetail.Tags = normalizeLabels(serverDetail.Tags)
+	serverDetail.License = normalizeLicense(serverDetail.License)
+	normalizeStatus(serverDetail)
+	populateDocURLs(serverDetail.Packages)
+
+	clearVerifiedChecksums(serverDetail.Packages)
+	// Ownership changes only through transfers, and maintainers are managed through the maintainers endpoints,
+	// never by the published document
+	serverDetail.PublisherUsername = ""
+	serverDetail.MaintainedBy = nil
+	// Repository stats are synced from GitHub by the registry, never reported by the publisher
+	serverDetail.RepositoryStats = nil
+	// Only the registry owner features servers
+	serverDetail.FeaturedOrder = nil
+	// Versions are pinned through the pin endpoint, and a new version releases any pin
+	serverDetail.PinnedVersion = false
+	// The rating summarizes the reviews, which only SaveReview records
+	serverDetail.AverageRating = 0
+	serverDetail.ReviewCount = 0
+
+	// The dependencies must still exist when the server is stored, and a publish that fails part way must not
+	// leave the previous version demoted
+	err := s.db.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := validateDependencies(ctx, s.db, serverDetail); err != nil {
+			return err
+		}
+		return s.db.Publish(ctx, serverDetail)
+	})
 	if err != nil {
 		return err
 	}
 
+	if s.verifyChecksums {
+		go verifyPackageChecksums(s.db, serverDetail.ID, slices.Clone(serverDetail.Packages))
+	}
+
 	return nil
 }
 
-// Search searches for servers by name with optional registry_name filter
-func (s *registryServiceImpl) Search(query string, registryName string, url string, cursor string, limit int) ([]model.Server, string, error) {
+// PublishVersion publishes a new version of the server with the given ID, carrying over the server-wide
+// fields of that version. The version must be higher than every existing version and becomes the latest.
+func (s *registryServiceImpl) PublishVersion(
+	id string, versionDetail model.VersionDetail, packages []model.Package,
+) (*model.ServerDetail, error) {
 	// Create a timeout context for the database operation
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	// If limit is not set or negative, use a default limit
-	if limit <= 0 {
-		limit = 30
+	if len(packages) == 0 {
+		return nil, database.ErrInvalidInput
 	}
 
-	// Build the filter map
-	filter := make(map[string]interface{})
+	// The versions are checked in the same transaction as the publish so a concurrent publish cannot
+	// take the version
+	var serverDetail *model.ServerDetail
+	err := s.db.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		serverDetail, err = newServerVersion(ctx, s.db, id, versionDetail, packages)
+		if err != nil {
+			return err
+		}
+		if err := validationErr(ValidateServerDetail(serverDetail, s.validation)); err != nil {
+			return err
+		}
+		populateDocURLs(serverDetail.Packages)
+		clearVerifiedChecksums(serverDetail.Packages)
 
-	// Use MongoDB text search instead of regex to prevent ReDoS attacks
-	if query != "" {
-		filter["$text"] = map[string]interface{}{
-			"$search": query,
+		if err := validateDependencies(ctx, s.db, serverDetail); err != nil {
+			return err
 		}
+		return s.db.Publish(ctx, serverDetail)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Add registry_name filter if provided
-	if registryName != "" {
-		filter["packages.registry_name"] = registryName
+	if s.verifyChecksums {
+		go verifyPackageChecksums(s.db, serverDetail.ID, slices.Clone(serverDetail.Packages))
 	}
 
-	// Add URL filter if provided - exact match for security
-	if url != "" {
-		filter["repository.url"] = url
+	return serverDetail, nil
+}
+
+// AppendPackages adds packages to an existing server and returns the updated packages list
+func (s *registryServiceImpl) AppendPackages(ctx context.Context, id string, packages []model.Package) ([]model.Package, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if len(packages) == 0 {
+		return nil, database.ErrInvalidInput
 	}
 
-	// Use the database's List method with search filters
-	entries, nextCursor, err := s.db.List(ctx, filter, cursor, limit)
+	serverDetail, err := s.db.GetByID(ctx, id)
 	if err != nil {
-		return nil, "", err
+		return nil, err
 	}
 
-	// Convert from []*model.Server to []model.Server
-	result := make([]model.Server, len(entries))
-	for i, entry := range entries {
-		result[i] = *entry
+	if err := validationErr(validateAppendedPackages(serverDetail, packages, s.validation)); err != nil {
+		return nil, err
 	}
 
-	return result, nextCursor, nil
+	populateDocURLs(packages)
+
+	clearVerifiedChecksums(packages)
+
+	if err := s.db.AppendPackages(ctx, id, packages, s.validation.MaxPackages); err != nil {
+		if errors.Is(err, database.ErrTooManyPackages) {
+			return nil, tooManyPackagesErr(s.validation.MaxPackages)
+		}
+		return nil, err
+	}
+
+	if s.verifyChecksums {
+		go verifyPackageChecksums(s.db, id, slices.Clone(packages))
+	}
+
+	updated, err := s.db.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated.Packages, nil
 }
 
-// SearchDetails searches for servers by name with optional registry_name filter and returns full details
-func (s *registryServiceImpl) SearchDetails(query string, registryName string, url string, cursor string, limit int) ([]model.ServerDetail, string, error) {
+// RemovePackage removes a package from an existing server
+func (s *registryServiceImpl) RemovePackage(ctx context.Context, id, registryName, packageName string) error {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.RemovePackage(ctx, id, registryName, packageName)
+}
+
+// YankPackage marks the packages of an existing server with the given registry name and version as yanked
+// and returns the updated packages list
+func (s *registryServiceImpl) YankPackage(ctx context.Context, id, registryName, version, reason string) ([]model.Package, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := s.db.YankPackage(ctx, id, registryName, version, reason); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.db.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated.Packages, nil
+}
+
+// AddTags adds tags to an existing server and returns the updated tags list. Tags are stored in lowercase.
+func (s *registryServiceImpl) AddTags(ctx context.Context, id string, tags []string) ([]string, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tags = normalizeLabels(tags)
+	if len(tags) == 0 {
+		return nil, database.ErrInvalidInput
+	}
+
+	serverDetail, err := s.db.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validationErr(validateAddedTags(serverDetail, tags)); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.AddTags(ctx, id, tags); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.db.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated.Tags, nil
+}
+
+// RemoveTag removes a tag from an existing server
+func (s *registryServiceImpl) RemoveTag(ctx context.Context, id, tag string) error {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.RemoveTag(ctx, id, strings.ToLower(tag))
+}
+
+// PinVersion marks the given version of a server as its latest version
+func (s *registryServiceImpl) PinVersion(ctx context.Context, id, version string) error {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.PinVersion(ctx, id, version)
+}
+
+// UpgradeVersion promotes the given version of a server to its latest version without pinning it
+func (s *registryServiceImpl) UpgradeVersion(ctx context.Context, id, version string) error {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.UpgradeVersion(ctx, id, version)
+}
+
+// Update replaces the description, packages, version number and release date of a server version with those
+// of detail, which must be the complete server details after the change
+func (s *registryServiceImpl) Update(id string, detail *model.ServerDetail) error {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if detail == nil {
+		return database.ErrInvalidInput
+	}
+
+	if err := validationErr(ValidateServerDetail(detail, s.validation)); err != nil {
+		return err
+	}
+
+	populateDocURLs(detail.Packages)
+
+	clearVerifiedChecksums(detail.Packages)
+
+	if err := s.db.Update(ctx, id, detail); err != nil {
+		return err
+	}
+
+	if s.verifyChecksums {
+		go verifyPackageChecksums(s.db, id, slices.Clone(detail.Packages))
+	}
+
+	return nil
+}
+
+// Delete removes a server version from the registry
+func (s *registryServiceImpl) Delete(id string) error {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return s.db.Delete(ctx, id)
+}
+
+// YankVersion marks the given version of a server as yanked
+func (s *registryServiceImpl) YankVersion(ctx context.Context, id, version string) error {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.YankVersion(ctx, id, version)
+}
+
+// ListVersions returns the versions of the server with the given ID in publish order
+func (s *registryServiceImpl) ListVersions(
+	ctx context.Context, id string, includeYanked bool, cursor string, direction database.CursorDirection, limit int,
+) ([]model.ServerVersion, database.PageCursors, error) {
+	return listVersions(ctx, s.db, id, includeYanked, cursor, direction, limit)
+}
+
+// TransferOwnership hands all versions of a server over to a new publisher
+func (s *registryServiceImpl) TransferOwnership(ctx context.Context, id, newOwner string) error {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.TransferOwnership(ctx, id, newOwner)
+}
+
+// AddMaintainer adds a GitHub user to the maintainers of all versions of a server
+func (s *registryServiceImpl) AddMaintainer(ctx context.Context, id, username string) ([]string, error) {
+	return addMaintainer(ctx, s.db, id, username)
+}
+
+// RemoveMaintainer removes a GitHub user from the maintainers of all versions of a server
+func (s *registryServiceImpl) RemoveMaintainer(ctx context.Context, id, username string) ([]string, error) {
+	return removeMaintainer(ctx, s.db, id, username)
+}
+
+// UpdateStatus sets the lifecycle status of all versions of a server
+func (s *registryServiceImpl) UpdateStatus(ctx context.Context, id, status string, byRegistryOwner bool) error {
+	return updateStatus(ctx, s.db, id, status, byRegistryOwner)
+}
+
+// Search searches for servers by name with optional registry_name filter
+func (s *registryServiceImpl) Search(opts SearchOptions) ([]model.Server, database.PageCursors, error) {
 	// Create a timeout context for the database operation
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	// If limit is not set or negative, use a default limit
+	limit := opts.Limit
 	if limit <= 0 {
 		limit = 30
 	}
 
-	// Build the filter map
-	filter := make(map[string]interface{})
+	// Use MongoDB text search instead of regex to prevent ReDoS attacks.
+	// Quoted phrases in the query are matched exactly.
+	textQuery, _ := search.PreprocessSearchQuery(opts.Query)
+	filter := mongodb.NewQueryBuilder().
+		WithTextSearch(textQuery).
+		WithRegistryName(opts.RegistryName).
+		WithURL(opts.URL).
+		WithCategory(opts.Category).
+		WithLanguage(opts.Language).
+		WithTopic(opts.Topic).
+		Build()
 
-	// Use MongoDB text search for full-word matches
-	if query != "" {
-		filter["$text"] = map[string]interface{}{
-			"$search": query,
-		}
+	// Use the database's List method with search filters
+	entries, cursors, err := s.db.List(ctx, filter, database.SortByCreation, opts.Cursor, opts.Direction, limit)
+	if err != nil {
+		return nil, database.PageCursors{}, err
 	}
 
-	// Add registry_name filter if provided
-	if registryName != "" {
-		filter["packages.registry_name"] = registryName
+	// Convert from []*model.Server to []model.Server
+	result := make([]model.Server, len(entries))
+	for i, entry := range entries {
+		result[i] = *entry
 	}
 
-	// Add URL filter if provided - exact match for security
-	if url != "" {
-		filter["repository.url"] = url
+	return result, cursors, nil
+}
+
+// SearchDetails searches for servers by name with optional registry_name filter and returns full details
+func (s *registryServiceImpl) SearchDetails(opts SearchOptions) ([]model.ServerDetail, database.PageCursors, error) {
+	sortOrder, err := parseSortOrder(opts.Sort)
+	if err != nil {
+		return nil, database.PageCursors{}, err
+	}
+
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// If limit is not set or negative, use a default limit
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 30
 	}
 
+	// Use MongoDB text search for full-word matches; quoted phrases in the query are matched exactly
+	textQuery, hasPhrase := search.PreprocessSearchQuery(opts.Query)
+	builder := withSearchFilters(mongodb.NewQueryBuilder().WithTextSearch(textQuery), opts)
+
 	// Use the database's ListDetails method with search filters
-	entries, nextCursor, err := s.db.ListDetails(ctx, filter, cursor, limit)
+	entries, cursors, err := listDetailsInMCPRange(
+		ctx, s.db, builder, sortOrder, opts.MinMCPVersion, opts.MaxMCPVersion, opts.Cursor, opts.Direction, limit,
+	)
 	if err != nil {
-		return nil, "", err
+		return nil, database.PageCursors{}, err
 	}
 
 	// If text search returned no results and we have a query, try with a case-insensitive regex
 	// This helps with partial matches and compound words
-	if len(entries) == 0 && query != "" {
-		// Remove text search and add regex search
-		delete(filter, "$text")
-		
-		// Escape special regex characters to prevent regex injection
-		escapedQuery := escapeRegex(query)
-		
-		// Create a safe regex pattern with case-insensitive search on multiple fields
-		filter["$or"] = []map[string]interface{}{
-			{"name": map[string]interface{}{
-				"$regex": escapedQuery,
-				"$options": "i",
-			}},
-			{"description": map[string]interface{}{
-				"$regex": escapedQuery,
-				"$options": "i",
-			}},
-			{"packages.name": map[string]interface{}{
-				"$regex": escapedQuery,
-				"$options": "i",
-			}},
-		}
-		
+	if len(entries) == 0 && textQuery != "" {
+		// Replace text search with a case-insensitive regex search on multiple fields.
+		// The query is escaped to prevent regex injection. For phrase searches the exact phrase is matched.
+		builder = withSearchFilters(mongodb.NewQueryBuilder().WithPatternSearch(fallbackPattern(textQuery, hasPhrase)), opts)
+
 		// Retry with regex search
-		entries, nextCursor, err = s.db.ListDetails(ctx, filter, cursor, limit)
+		entries, cursors, err = listDetailsInMCPRange(
+			ctx, s.db, builder, sortOrder, opts.MinMCPVersion, opts.MaxMCPVersion, opts.Cursor, opts.Direction, limit,
+		)
 		if err != nil {
-			return nil, "", err
+			return nil, database.PageCursors{}, err
 		}
 	}
 
@@ -222,13 +687,206 @@ func (s *registryServiceImpl) SearchDetails(query string, registryName string, u
 	result := make([]model.ServerDetail, len(entries))
 	for i, entry := range entries {
 		result[i] = *entry
+		result[i].Highlights = highlights(entry, opts.Query)
 	}
 
-	return result, nextCursor, nil
+	return result, cursors, nil
 }
 
-// escapeRegex escapes special regex characters to prevent regex injection
-func escapeRegex(input string) string {
-	// Escape all special regex characters
-	return regexp.QuoteMeta(input)
-}
\ No newline at end of file
+// ListCategories returns all categories with the number of servers in each
+func (s *registryServiceImpl) ListCategories(ctx context.Context) ([]model.CategoryCount, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return categoryCounts(ctx, s.db)
+}
+
+// CountServers returns the number of servers in the registry, counting each server once whatever its number of
+// versions
+func (s *registryServiceImpl) CountServers(ctx context.Context) (int64, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.Count(ctx, nil)
+}
+
+// CountSearchResults returns the number of servers a SearchDetails search with the same options finds over all
+// pages. The sort order and page of the options are ignored.
+func (s *registryServiceImpl) CountSearchResults(ctx context.Context, opts SearchOptions) (int64, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	textQuery, hasPhrase := search.PreprocessSearchQuery(opts.Query)
+	builder := withSearchFilters(mongodb.NewQueryBuilder().WithTextSearch(textQuery), opts)
+	count, err := countDetailsInMCPRange(ctx, s.db, builder, opts.MinMCPVersion, opts.MaxMCPVersion)
+	if err != nil || count > 0 || textQuery == "" {
+		return count, err
+	}
+
+	// Like SearchDetails, fall back to a case-insensitive regex search if the text search finds nothing
+	builder = withSearchFilters(mongodb.NewQueryBuilder().WithPatternSearch(fallbackPattern(textQuery, hasPhrase)), opts)
+	return countDetailsInMCPRange(ctx, s.db, builder, opts.MinMCPVersion, opts.MaxMCPVersion)
+}
+
+// SearchFacets returns the number of servers matching a search query with each package registry, tag, license and
+// status. Without a query all servers are counted.
+func (s *registryServiceImpl) SearchFacets(ctx context.Context, query string) (model.Facets, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	textQuery, hasPhrase := search.PreprocessSearchQuery(query)
+	facets, err := s.db.CountFacets(ctx, mongodb.NewQueryBuilder().WithTextSearch(textQuery).Build())
+	// Every server has a status, so there are status counts whenever a server matches
+	if err != nil || len(facets.Statuses) > 0 || textQuery == "" {
+		return facets, err
+	}
+
+	// Like SearchDetails, fall back to a case-insensitive regex search if the text search finds nothing
+	return s.db.CountFacets(ctx, mongodb.NewQueryBuilder().WithPatternSearch(fallbackPattern(textQuery, hasPhrase)).Build())
+}
+
+// Stats returns aggregate metrics of the registry
+func (s *registryServiceImpl) Stats() (model.RegistryStats, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return registryStats(ctx, s.db)
+}
+
+// ListTags returns the tags of servers with the number of servers carrying each, sorted by tag
+func (s *registryServiceImpl) ListTags(ctx context.Context) ([]model.TagCount, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return tagCounts(ctx, s.db)
+}
+
+// ListTopics returns the repository topics of servers with the number of servers tagged with each
+func (s *registryServiceImpl) ListTopics(ctx context.Context) ([]model.TopicCount, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return topicCounts(ctx, s.db)
+}
+
+// ListLanguages returns the languages servers are written in with the number of servers in each
+func (s *registryServiceImpl) ListLanguages(ctx context.Context) ([]model.LanguageCount, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return languageCounts(ctx, s.db)
+}
+
+// SaveProvenance stores a SLSA provenance attestation for the server version with the given ID
+func (s *registryServiceImpl) SaveProvenance(ctx context.Context, id string, attestation []byte) (*model.Provenance, error) {
+	return saveProvenance(ctx, s.db, id, attestation)
+}
+
+// GetProvenance retrieves the provenance attestation of a server version,
+// or the most recently attested version if version is empty
+func (s *registryServiceImpl) GetProvenance(ctx context.Context, id, version string) (*model.Provenance, error) {
+	return getProvenance(ctx, s.db, id, version)
+}
+
+// GetSchema retrieves the JSON schema published with the server version with the given ID
+func (s *registryServiceImpl) GetSchema(ctx context.Context, id string) (json.RawMessage, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.GetSchema(ctx, id)
+}
+
+// GetGitHubMetadata retrieves the GitHub repository information stored when the server version with the given ID
+// was published
+func (s *registryServiceImpl) GetGitHubMetadata(ctx context.Context, id string) (json.RawMessage, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.GetGitHubMetadata(ctx, id)
+}
+
+// CountDependents returns the number of server versions that depend on the server with the given ID.
+// Counts are cached, so a new dependent may take up to the configured TTL to be counted.
+func (s *registryServiceImpl) CountDependents(ctx context.Context, id string) (int64, error) {
+	return s.dependents.count(ctx, id)
+}
+
+// Network returns the servers directly connected to the server with the given ID.
+// Networks are cached, so a new connection may take up to the configured TTL to appear.
+func (s *registryServiceImpl) Network(ctx context.Context, id string) (*model.ServerNetwork, error) {
+	return s.networks.build(ctx, id)
+}
+
+// SubmitReview stores a community review of the server with the given ID
+func (s *registryServiceImpl) SubmitReview(ctx context.Context, id string, review *model.Review) error {
+	return submitReview(ctx, s.db, id, review)
+}
+
+// ListReviews retrieves the reviews of the server with the given ID, newest first
+func (s *registryServiceImpl) ListReviews(
+	ctx context.Context, id string, cursor string, direction database.CursorDirection, limit int,
+) ([]model.Review, database.PageCursors, error) {
+	return listReviews(ctx, s.db, id, cursor, direction, limit)
+}
+
+// StartReindex starts rebuilding the text search index in the background
+func (s *registryServiceImpl) StartReindex(ctx context.Context) (*model.ReindexJob, error) {
+	return startReindex(ctx, s.db)
+}
+
+// GetReindexJob retrieves the status of a text index rebuild job
+func (s *registryServiceImpl) GetReindexJob(ctx context.Context, id string) (*model.ReindexJob, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.GetReindexJob(ctx, id)
+}
+
+// StartVacuum starts removing stale records and compacting the database in the background
+func (s *registryServiceImpl) StartVacuum(ctx context.Context) (*model.VacuumJob, error) {
+	return startVacuum(ctx, s.db, s.vacuumRetention)
+}
+
+// GetVacuumJob retrieves the status of a vacuum job
+func (s *registryServiceImpl) GetVacuumJob(ctx context.Context, id string) (*model.VacuumJob, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.GetVacuumJob(ctx, id)
+}
+
+// SaveSubscription subscribes a webhook to a server, or updates the events of an existing subscription
+// of the server with the same webhook URL. It reports whether a new subscription was created.
+func (s *registryServiceImpl) SaveSubscription(ctx context.Context, subscription *model.Subscription) (bool, error) {
+	return saveSubscription(ctx, s.db, s.resolveHost, subscription)
+}
+
+// GetSubscription retrieves a webhook subscription by its ID
+func (s *registryServiceImpl) GetSubscription(ctx context.Context, id string) (*model.Subscription, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.GetSubscription(ctx, id)
+}
+
+// DeleteSubscription removes a webhook subscription
+func (s *registryServiceImpl) DeleteSubscription(ctx context.Context, id string) error {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.DeleteSubscription(ctx, id)
+}
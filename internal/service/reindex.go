@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+)
+
+// reindexTimeout bounds how long a text index rebuild may run
+const reindexTimeout = 30 * time.Minute
+
+// startReindex records a new running reindex job and rebuilds the text index in the background
+func startReindex(ctx context.Context, db database.Database) (*model.ReindexJob, error) {
+	job := &model.ReindexJob{
+		ID:        uuid.New().String(),
+		Status:    model.ReindexJobRunning,
+		StartedAt: time.Now().UTC(),
+	}
+
+	saveCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := db.SaveReindexJob(saveCtx, job); err != nil {
+		return nil, err
+	}
+
+	jobCopy := *job
+	go runReindex(db, &jobCopy)
+
+	return job, nil
+}
+
+// runReindex rebuilds the text index and records the outcome on the job.
+// It runs detached from the request that started it.
+func runReindex(db database.Database, job *model.ReindexJob) {
+	ctx, cancel := context.WithTimeout(context.Background(), reindexTimeout)
+	defer cancel()
+
+	count, err := db.RebuildTextIndex(ctx)
+	completedAt := time.Now().UTC()
+	job.CompletedAt = &completedAt
+	job.DocumentsProcessed = count
+	if err != nil {
+		job.Status = model.ReindexJobFailed
+		job.Error = err.Error()
+		log.Printf("Reindex job %s failed: %v", job.ID, err)
+	} else {
+		job.Status = model.ReindexJobCompleted
+	}
+
+	if err := db.SaveReindexJob(ctx, job); err != nil {
+		log.Printf("Error saving reindex job %s: %v", job.ID, err)
+	}
+}
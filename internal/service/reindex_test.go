@@ -0,0 +1,64 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingIndexDB is an in-memory database whose text index rebuild always fails
+type failingIndexDB struct {
+	*database.MemoryDB
+}
+
+func (db *failingIndexDB) RebuildTextIndex(_ context.Context) (int64, error) {
+	return 0, errors.New("index build failed")
+}
+
+// waitForJob polls a reindex job until it is no longer running
+func waitForJob(t *testing.T, registry service.RegistryService, id string) *model.ReindexJob {
+	t.Helper()
+	var job *model.ReindexJob
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = registry.GetReindexJob(context.Background(), id)
+		require.NoError(t, err)
+		return job.Status != model.ReindexJobRunning
+	}, 2*time.Second, 10*time.Millisecond)
+	return job
+}
+
+func TestStartReindex(t *testing.T) {
+	t.Run("completed", func(t *testing.T) {
+		db := database.NewMemoryDB(map[string]*model.Server{"1": {ID: "1", Name: "example/one"}})
+		registry := service.NewRegistryServiceWithDB(db)
+
+		job, err := registry.StartReindex(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, model.ReindexJobRunning, job.Status)
+
+		job = waitForJob(t, registry, job.ID)
+		assert.Equal(t, model.ReindexJobCompleted, job.Status)
+		assert.Equal(t, int64(1), job.DocumentsProcessed)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		db := &failingIndexDB{database.NewMemoryDB(map[string]*model.Server{})}
+		registry := service.NewRegistryServiceWithDB(db)
+
+		job, err := registry.StartReindex(context.Background())
+		require.NoError(t, err)
+
+		job = waitForJob(t, registry, job.ID)
+		assert.Equal(t, model.ReindexJobFailed, job.Status)
+		assert.Equal(t, "index build failed", job.Error)
+		assert.NotNil(t, job.CompletedAt)
+	})
+}
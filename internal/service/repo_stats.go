@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/vcs"
+)
+
+const (
+	// repoStatsSyncTimeout bounds how long a single sync may run
+	repoStatsSyncTimeout = 30 * time.Minute
+	// repoStatsPageSize is the number of server versions read at a time while collecting the servers to sync
+	repoStatsPageSize = 100
+)
+
+// StarsFetcher returns the number of stars of a GitHub repository
+type StarsFetcher func(ctx context.Context, owner, repo string) (int, error)
+
+// RepoStatsSyncer copies the star counts of the servers' GitHub repositories into the registry, so that sorting
+// by stars relies on numbers the registry fetched itself rather than on what publishers report
+type RepoStatsSyncer struct {
+	db    database.Database
+	fetch StarsFetcher
+}
+
+// NewRepoStatsSyncer creates a syncer that stores the stats in db and fetches star counts with fetch,
+// usually vcs.FetchGitHubStars
+func NewRepoStatsSyncer(db database.Database, fetch StarsFetcher) *RepoStatsSyncer {
+	return &RepoStatsSyncer{db: db, fetch: fetch}
+}
+
+// Start syncs the stats in the background right away and then every interval, until ctx is done
+func (s *RepoStatsSyncer) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			syncCtx, cancel := context.WithTimeout(ctx, repoStatsSyncTimeout)
+			synced, err := s.Sync(syncCtx)
+			cancel()
+			if err != nil {
+				log.Printf("repo-stats: Synced %d servers before failing: %v", synced, err)
+			} else {
+				log.Printf("repo-stats: Synced %d servers", synced)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Sync fetches the stars of the GitHub repository of every server, least recently synced first, and stores them
+// on all versions of the server. It returns the number of synced servers. Repositories that cannot be fetched
+// are skipped, but an exhausted GitHub rate limit ends the sync; the servers left out go first next time.
+func (s *RepoStatsSyncer) Sync(ctx context.Context) (int, error) {
+	servers, err := s.latestGitHubServers(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// Servers that were never synced have zero sync times and go first
+	slices.SortStableFunc(servers, func(a, b *model.Server) int {
+		return lastSynced(a).Compare(lastSynced(b))
+	})
+
+	synced := 0
+	for _, server := range servers {
+		owner, repo, ok := vcs.ParseGitHubRepoURL(server.Repository.URL)
+		if !ok {
+			continue
+		}
+
+		stars, err := s.fetch(ctx, owner, repo)
+		if err != nil {
+			var rateLimitErr *vcs.RateLimitError
+			if errors.As(err, &rateLimitErr) || ctx.Err() != nil {
+				return synced, err
+			}
+			log.Printf("repo-stats: Failed to fetch stars of %s/%s for %s: %v", owner, repo, server.Name, err)
+			continue
+		}
+
+		stats := model.RepositoryStats{Stars: stars, SyncedAt: time.Now().UTC()}
+		if err := s.db.SetRepositoryStats(ctx, server.ID, stats); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				// The server was deleted since it was listed
+				continue
+			}
+			return synced, fmt.Errorf("error storing repository stats of %s: %w", server.Name, err)
+		}
+		synced++
+	}
+
+	return synced, nil
+}
+
+// latestGitHubServers returns the latest version of every server whose repository is hosted on GitHub
+func (s *RepoStatsSyncer) latestGitHubServers(ctx context.Context) ([]*model.Server, error) {
+	var servers []*model.Server
+	cursor := ""
+	for {
+		page, cursors, err := s.db.List(ctx, nil, database.SortByCreation, cursor, database.CursorNext, repoStatsPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("error listing servers: %w", err)
+		}
+		for _, server := range page {
+			if server.VersionDetail.IsLatest && server.Repository.Source == "github" {
+				servers = append(servers, server)
+			}
+		}
+		if cursors.Next == "" || len(page) < repoStatsPageSize {
+			return servers, nil
+		}
+		cursor = cursors.Next
+	}
+}
+
+// lastSynced returns when the repository stats of a server were last synced, or the zero time if never
+func lastSynced(server *model.Server) time.Time {
+	if server.RepositoryStats == nil {
+		return time.Time{}
+	}
+	return server.RepositoryStats.SyncedAt
+}
@@ -0,0 +1,125 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/vcs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// publishRepoServer publishes a version of a server whose repository is the GitHub repository of the same name
+func publishRepoServer(t *testing.T, registry service.RegistryService, name, version string) string {
+	t.Helper()
+	serverDetail := &model.ServerDetail{
+		Server: model.Server{
+			Name:          "io.github." + name,
+			Repository:    model.Repository{URL: "https://github.com/" + name, Source: "github"},
+			VersionDetail: model.VersionDetail{Version: version},
+			// Publishers cannot report their own stats
+			RepositoryStats: &model.RepositoryStats{Stars: 1_000_000},
+		},
+	}
+	require.NoError(t, registry.Publish(serverDetail))
+	return serverDetail.ID
+}
+
+// repoStats returns the repository stats of a server version
+func repoStats(t *testing.T, db database.Database, id string) *model.RepositoryStats {
+	t.Helper()
+	serverDetail, err := db.GetByID(context.Background(), id)
+	require.NoError(t, err)
+	return serverDetail.RepositoryStats
+}
+
+func TestRepoStatsSyncer(t *testing.T) {
+	t.Run("published stats are discarded", func(t *testing.T) {
+		db := database.NewMemoryDB(map[string]*model.Server{})
+		id := publishRepoServer(t, service.NewRegistryServiceWithDB(db), "example/server", "1.0.0")
+
+		assert.Nil(t, repoStats(t, db, id))
+	})
+
+	t.Run("stores the stars on all versions", func(t *testing.T) {
+		db := database.NewMemoryDB(map[string]*model.Server{})
+		registry := service.NewRegistryServiceWithDB(db)
+		oldID := publishRepoServer(t, registry, "example/server", "1.0.0")
+		latestID := publishRepoServer(t, registry, "example/server", "1.1.0")
+
+		var fetched []string
+		syncer := service.NewRepoStatsSyncer(db, func(_ context.Context, owner, repo string) (int, error) {
+			fetched = append(fetched, owner+"/"+repo)
+			return 42, nil
+		})
+		synced, err := syncer.Sync(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, synced)
+		assert.Equal(t, []string{"example/server"}, fetched)
+
+		for _, id := range []string{oldID, latestID} {
+			stats := repoStats(t, db, id)
+			require.NotNil(t, stats)
+			assert.Equal(t, 42, stats.Stars)
+			assert.False(t, stats.SyncedAt.IsZero())
+		}
+
+		// New versions keep the synced stats
+		newID := publishRepoServer(t, registry, "example/server", "1.2.0")
+		require.NotNil(t, repoStats(t, db, newID))
+		assert.Equal(t, 42, repoStats(t, db, newID).Stars)
+	})
+
+	t.Run("skips repositories that cannot be fetched", func(t *testing.T) {
+		db := database.NewMemoryDB(map[string]*model.Server{})
+		registry := service.NewRegistryServiceWithDB(db)
+		missingID := publishRepoServer(t, registry, "example/missing", "1.0.0")
+		id := publishRepoServer(t, registry, "example/server", "1.0.0")
+
+		syncer := service.NewRepoStatsSyncer(db, func(_ context.Context, _, repo string) (int, error) {
+			if repo == "missing" {
+				return 0, errors.New("repository not found")
+			}
+			return 7, nil
+		})
+		synced, err := syncer.Sync(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, synced)
+		assert.Nil(t, repoStats(t, db, missingID))
+		assert.Equal(t, 7, repoStats(t, db, id).Stars)
+	})
+
+	t.Run("rate limit ends the sync and least recently synced go first", func(t *testing.T) {
+		db := database.NewMemoryDB(map[string]*model.Server{})
+		registry := service.NewRegistryServiceWithDB(db)
+		firstID := publishRepoServer(t, registry, "example/first", "1.0.0")
+		secondID := publishRepoServer(t, registry, "example/second", "1.0.0")
+
+		remaining := 1
+		syncer := service.NewRepoStatsSyncer(db, func(context.Context, string, string) (int, error) {
+			if remaining == 0 {
+				return 0, &vcs.RateLimitError{}
+			}
+			remaining--
+			return 5, nil
+		})
+
+		synced, err := syncer.Sync(context.Background())
+		var rateLimitErr *vcs.RateLimitError
+		require.ErrorAs(t, err, &rateLimitErr)
+		assert.Equal(t, 1, synced)
+		assert.NotNil(t, repoStats(t, db, firstID))
+		assert.Nil(t, repoStats(t, db, secondID))
+
+		// The next run starts with the server that was left out
+		remaining = 1
+		synced, err = syncer.Sync(context.Background())
+		require.ErrorAs(t, err, &rateLimitErr)
+		assert.Equal(t, 1, synced)
+		assert.NotNil(t, repoStats(t, db, secondID))
+	})
+}
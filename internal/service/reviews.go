@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+)
+
+// validateReview checks the rating and comment of a submitted review
+func validateReview(review *model.Review) error {
+	if review.Rating < model.MinRating || review.Rating > model.MaxRating {
+		return fmt.Errorf("%w: rating must be between %d and %d", database.ErrInvalidReview, model.MinRating, model.MaxRating)
+	}
+	if utf8.RuneCountInString(review.Comment) > model.MaxReviewCommentLength {
+		return fmt.Errorf(
+			"%w: comment must be at most %d characters", database.ErrInvalidReview, model.MaxReviewCommentLength,
+		)
+	}
+	return nil
+}
+
+// submitReview validates a review and stores it for the server with the given ID
+func submitReview(ctx context.Context, db database.Database, id string, review *model.Review) error {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	review.Comment = strings.TrimSpace(review.Comment)
+	if err := validateReview(review); err != nil {
+		return err
+	}
+
+	review.ServerID = id
+	review.CreatedAt = time.Now().UTC()
+	return db.SaveReview(ctx, review)
+}
+
+// listReviews retrieves the reviews of the server with the given ID, newest first
+func listReviews(
+	ctx context.Context, db database.Database, id string, cursor string, direction database.CursorDirection, limit int,
+) ([]model.Review, database.PageCursors, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if _, err := db.GetByID(ctx, id); err != nil {
+		return nil, database.PageCursors{}, err
+	}
+
+	entries, cursors, err := db.ListReviews(ctx, id, cursor, direction, limit)
+	if err != nil {
+		return nil, database.PageCursors{}, err
+	}
+
+	result := make([]model.Review, len(entries))
+	for i, entry := range entries {
+		result[i] = *entry
+	}
+
+	return result, cursors, nil
+}
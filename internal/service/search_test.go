@@ -0,0 +1,87 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchPhrase(t *testing.T) {
+	newServer := func(id, name, description string) *model.Server {
+		return &model.Server{
+			ID:            id,
+			Name:          name,
+			Description:   description,
+			VersionDetail: model.VersionDetail{Version: "1.0.0", IsLatest: true},
+		}
+	}
+
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{
+		"1": newServer("1", "Acme MCP Filesystem Server", "Reads local files"),
+		"2": newServer("2", "Filesystem tools for MCP", "Exposes local files to clients"),
+		"3": newServer("3", "MCP database server", "Queries databases"),
+	}))
+
+	names := func(servers []model.ServerDetail) []string {
+		var result []string
+		for _, server := range servers {
+			result = append(result, server.Name)
+		}
+		return result
+	}
+
+	t.Run("words match independently", func(t *testing.T) {
+		servers, _, err := registry.SearchDetails(service.SearchOptions{
+			Query:     "mcp filesystem",
+			Direction: database.CursorNext,
+			Limit:     10,
+		})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{
+			"Acme MCP Filesystem Server", "Filesystem tools for MCP", "MCP database server",
+		}, names(servers))
+	})
+
+	t.Run("phrase matches exact name substring", func(t *testing.T) {
+		servers, _, err := registry.SearchDetails(service.SearchOptions{
+			Query:     `"mcp filesystem"`,
+			Direction: database.CursorNext,
+			Limit:     10,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Acme MCP Filesystem Server"}, names(servers))
+
+		summaries, _, err := registry.Search(service.SearchOptions{
+			Query:     `"mcp filesystem"`,
+			Direction: database.CursorNext,
+			Limit:     10,
+		})
+		require.NoError(t, err)
+		require.Len(t, summaries, 1)
+		assert.Equal(t, "Acme MCP Filesystem Server", summaries[0].Name)
+	})
+
+	t.Run("phrase falls back to exact substring match on other fields", func(t *testing.T) {
+		servers, _, err := registry.SearchDetails(service.SearchOptions{
+			Query:     `"local files to"`,
+			Direction: database.CursorNext,
+			Limit:     10,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Filesystem tools for MCP"}, names(servers))
+	})
+
+	t.Run("phrase without matches", func(t *testing.T) {
+		servers, _, err := registry.SearchDetails(service.SearchOptions{
+			Query:     `"filesystem mcp"`,
+			Direction: database.CursorNext,
+			Limit:     10,
+		})
+		require.NoError(t, err)
+		assert.Empty(t, servers)
+	})
+}
@@ -1,12 +1,548 @@
 package service
 
-import "github.com/modelcontextprotocol/registry/internal/model"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/database/mongodb"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/scoring"
+	"github.com/modelcontextprotocol/registry/internal/search"
+)
+
+// MaxPackagesPerServer is the maximum number of packages a single server may declare
+const MaxPackagesPerServer = 20
+
+// MaxTagsPerServer is the maximum number of tags a single server may have
+const MaxTagsPerServer = 10
+
+// SortByStars is the SearchDetails sort value ordering results by repository star count
+const SortByStars = string(database.SortByStars)
+
+// SortByReleaseDate is the SearchDetails sort value ordering results by publication time, most recent first
+const SortByReleaseDate = string(database.SortByReleaseDate)
+
+// SortByRelevance is the SearchDetails sort value ordering text search results by relevance to the query
+const SortByRelevance = string(database.SortByRelevance)
+
+// IsFieldSort reports whether a sort value orders results by name, published_at or updated_at, ascending or,
+// prefixed with "-", descending. List and SearchDetails both accept these values.
+func IsFieldSort(sortBy string) bool {
+	return database.IsFieldSort(database.SortOrder(sortBy))
+}
+
+// SearchOptions holds the query, filters and paging of a Search or SearchDetails search. Empty fields do not filter.
+type SearchOptions struct {
+	// Query is the free text searched for; double-quoted phrases in it are matched exactly
+	Query        string
+	RegistryName string
+	URL          string
+	Category     string
+	Language     string
+	Topic        string
+	// Tags are the tags a server must all have
+	Tags       []string
+	License    string
+	Maintainer string
+	Status     string
+	MinRating  float64
+	MaxRating  float64
+	// HasSchema and HasVerifiedChecksum only keep servers with a config schema or a verified package checksum
+	HasSchema           bool
+	HasVerifiedChecksum bool
+	// HasTool is the name of a tool a server must declare
+	HasTool string
+	// MinMCPVersion and MaxMCPVersion bound the minimum MCP version of a server, compared as semantic versions
+	MinMCPVersion string
+	MaxMCPVersion string
+	// PublishedAfter and PublishedBefore exclusively bound the publication time of the latest version of a server
+	PublishedAfter  time.Time
+	PublishedBefore time.Time
+	Sort            string
+	Cursor          string
+	Direction       database.CursorDirection
+	Limit           int
+}
 
 // RegistryService defines the interface for registry operations
 type RegistryService interface {
-	List(cursor string, limit int) ([]model.Server, string, error)
+	List(sortBy string, cursor string, direction database.CursorDirection, limit int) ([]model.Server, database.PageCursors, error)
+	ListUpdatedAfter(ctx context.Context, updatedAfter time.Time, afterSeq int64, limit int) ([]model.Server, error)
+	ListRecent(ctx context.Context, limit int) ([]model.Server, error)
+	ListFeatured(ctx context.Context) ([]model.Server, error)
+	ReorderFeatured(ctx context.Context, ids []string) error
 	GetByID(id string) (*model.ServerDetail, error)
+	GetByIDs(ctx context.Context, ids []string) ([]model.ServerDetail, error)
+	GetByName(ctx context.Context, name string) (*model.ServerDetail, error)
+	GetMetadata(ctx context.Context, id string) (*model.ServerDetail, error)
 	Publish(serverDetail *model.ServerDetail) error
-	Search(query string, registryName string, url string, cursor string, limit int) ([]model.Server, string, error)
-	SearchDetails(query string, registryName string, url string, cursor string, limit int) ([]model.ServerDetail, string, error)
+	Update(id string, detail *model.ServerDetail) error
+	Delete(id string) error
+	PublishVersion(id string, versionDetail model.VersionDetail, packages []model.Package) (*model.ServerDetail, error)
+	AppendPackages(ctx context.Context, id string, packages []model.Package) ([]model.Package, error)
+	RemovePackage(ctx context.Context, id, registryName, packageName string) error
+	YankPackage(ctx context.Context, id, registryName, version, reason string) ([]model.Package, error)
+	PinVersion(ctx context.Context, id, version string) error
+	UpgradeVersion(ctx context.Context, id, version string) error
+	YankVersion(ctx context.Context, id, version string) error
+	ListVersions(
+		ctx context.Context, id string, includeYanked bool, cursor string, direction database.CursorDirection, limit int,
+	) ([]model.ServerVersion, database.PageCursors, error)
+	TransferOwnership(ctx context.Context, id, newOwner string) error
+	AddMaintainer(ctx context.Context, id, username string) ([]string, error)
+	RemoveMaintainer(ctx context.Context, id, username string) ([]string, error)
+	// UpdateStatus sets the lifecycle status of all versions of a server. Only the registry owner may change the
+	// status of an archived server.
+	UpdateStatus(ctx context.Context, id, status string, byRegistryOwner bool) error
+	// Search only applies the query, registry name, URL, category, language, topic and paging of the options;
+	// SearchDetails applies all of them
+	Search(opts SearchOptions) ([]model.Server, database.PageCursors, error)
+	SearchDetails(opts SearchOptions) ([]model.ServerDetail, database.PageCursors, error)
+	CountServers(ctx context.Context) (int64, error)
+	SearchFacets(ctx context.Context, query string) (model.Facets, error)
+	CountSearchResults(ctx context.Context, opts SearchOptions) (int64, error)
+	ListCategories(ctx context.Context) ([]model.CategoryCount, error)
+	ListLanguages(ctx context.Context) ([]model.LanguageCount, error)
+	ListTopics(ctx context.Context) ([]model.TopicCount, error)
+	ListTags(ctx context.Context) ([]model.TagCount, error)
+	Stats() (model.RegistryStats, error)
+	AddTags(ctx context.Context, id string, tags []string) ([]string, error)
+	RemoveTag(ctx context.Context, id, tag string) error
+	SaveProvenance(ctx context.Context, id string, attestation []byte) (*model.Provenance, error)
+	GetProvenance(ctx context.Context, id, version string) (*model.Provenance, error)
+	GetSchema(ctx context.Context, id string) (json.RawMessage, error)
+	GetGitHubMetadata(ctx context.Context, id string) (json.RawMessage, error)
+	CountDependents(ctx context.Context, id string) (int64, error)
+	Network(ctx context.Context, id string) (*model.ServerNetwork, error)
+	SubmitReview(ctx context.Context, id string, review *model.Review) error
+	ListReviews(
+		ctx context.Context, id string, cursor string, direction database.CursorDirection, limit int,
+	) ([]model.Review, database.PageCursors, error)
+	StartReindex(ctx context.Context) (*model.ReindexJob, error)
+	GetReindexJob(ctx context.Context, id string) (*model.ReindexJob, error)
+	StartVacuum(ctx context.Context) (*model.VacuumJob, error)
+	GetVacuumJob(ctx context.Context, id string) (*model.VacuumJob, error)
+	SaveSubscription(ctx context.Context, subscription *model.Subscription) (bool, error)
+	GetSubscription(ctx context.Context, id string) (*model.Subscription, error)
+	DeleteSubscription(ctx context.Context, id string) error
+}
+
+// normalizeCategory defaults the category of a server being published to model.CategoryOther if it is empty
+func normalizeCategory(serverDetail *model.ServerDetail) {
+	if serverDetail.Category == "" {
+		serverDetail.Category = model.CategoryOther
+	}
+}
+
+// normalizeLanguage lowercases the language of a server being published so filters match it consistently
+func normalizeLanguage(serverDetail *model.ServerDetail) {
+	serverDetail.Language = strings.ToLower(strings.TrimSpace(serverDetail.Language))
+}
+
+// MaxTopicsPerServer is the most repository topics stored for a server
+const MaxTopicsPerServer = 20
+
+// normalizeTopics lowercases the topics of a server being published so filters match them consistently,
+// dropping empty and duplicate topics and keeping at most MaxTopicsPerServer
+func normalizeTopics(serverDetail *model.ServerDetail) {
+	topics := normalizeLabels(serverDetail.Topics)
+	serverDetail.Topics = topics[:min(len(topics), MaxTopicsPerServer)]
+}
+
+// normalizeLabels lowercases and trims labels such as topics and tags, dropping empty and duplicate labels
+func normalizeLabels(labels []string) []string {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	normalized := make([]string, 0, len(labels))
+	for _, label := range labels {
+		label = strings.ToLower(strings.TrimSpace(label))
+		if label == "" || slices.Contains(normalized, label) {
+			continue
+		}
+		normalized = append(normalized, label)
+	}
+	return normalized
+}
+
+// listDetailsInMCPRange lists the servers matching the filter of builder whose minimum MCP version lies
+// within the given bounds. MongoDB cannot compare semantic versions, so the bounds are applied to each page
+// after it is read: pages may hold fewer than limit entries, and pages without any match are skipped in the
+// direction of the cursor. Empty bounds are not applied.
+func listDetailsInMCPRange(
+	ctx context.Context, db database.Database, builder *mongodb.QueryBuilder, sortOrder database.SortOrder,
+	minMCPVersion, maxMCPVersion, cursor string, direction database.CursorDirection, limit int,
+) ([]*model.ServerDetail, database.PageCursors, error) {
+	for {
+		entries, cursors, err := db.ListDetails(ctx, builder.Build(), sortOrder, cursor, direction, limit)
+		if err != nil {
+			return nil, database.PageCursors{}, err
+		}
+		if minMCPVersion == "" && maxMCPVersion == "" {
+			return entries, cursors, nil
+		}
+
+		matching := make([]*model.ServerDetail, 0, len(entries))
+		for _, entry := range entries {
+			if inMCPRange(entry.MinMCPVersion, minMCPVersion, maxMCPVersion) {
+				matching = append(matching, entry)
+			}
+		}
+		// Skipping continues past the page in the direction it was read
+		skipCursor := cursors.Next
+		if direction == database.CursorPrev {
+			skipCursor = cursors.Prev
+		}
+		if len(matching) > 0 || skipCursor == "" {
+			return matching, cursors, nil
+		}
+		cursor = skipCursor
+	}
+}
+
+// countDetailsInMCPRange returns the number of servers whose latest version matches the filter of the builder
+// and whose minimum MCP version lies within the given bounds. Without bounds the database counts the matches;
+// otherwise they are read page by page, since the bounds are compared as semantic versions.
+func countDetailsInMCPRange(
+	ctx context.Context, db database.Database, builder *mongodb.QueryBuilder, minMCPVersion, maxMCPVersion string,
+) (int64, error) {
+	if minMCPVersion == "" && maxMCPVersion == "" {
+		return db.Count(ctx, builder.Build())
+	}
+
+	var count int64
+	cursor := ""
+	for {
+		entries, cursors, err := db.ListDetails(ctx, builder.Build(), database.SortByCreation, cursor, database.CursorNext, 500)
+		if err != nil {
+			return 0, err
+		}
+		for _, entry := range entries {
+			if inMCPRange(entry.MinMCPVersion, minMCPVersion, maxMCPVersion) {
+				count++
+			}
+		}
+		if cursors.Next == "" {
+			return count, nil
+		}
+		cursor = cursors.Next
+	}
+}
+
+// fallbackPattern returns the pattern of the regex search a text search falls back to when it finds nothing: the
+// first phrase of phrase searches, and otherwise the whole query
+func fallbackPattern(textQuery string, hasPhrase bool) string {
+	if hasPhrase {
+		return search.Phrases(textQuery)[0]
+	}
+	return textQuery
+}
+
+// withSearchFilters adds the filters of a SearchDetails search other than the query and the MCP version bounds
+// to the builder
+func withSearchFilters(builder *mongodb.QueryBuilder, opts SearchOptions) *mongodb.QueryBuilder {
+	return builder.
+		WithRegistryName(opts.RegistryName).
+		WithURL(opts.URL).
+		WithCategory(opts.Category).
+		WithLanguage(opts.Language).
+		WithTopic(opts.Topic).
+		WithTags(normalizeLabels(opts.Tags)).
+		WithLicense(normalizeLicense(opts.License)).
+		WithMaintainer(opts.Maintainer).
+		WithStatus(opts.Status).
+		WithRatingRange(opts.MinRating, opts.MaxRating).
+		WithSchema(opts.HasSchema).
+		WithVerifiedChecksum(opts.HasVerifiedChecksum).
+		WithTool(opts.HasTool).
+		WithPublishedRange(opts.PublishedAfter, opts.PublishedBefore)
+}
+
+// highlights returns the fragments of the name and description of a server matching the terms of a search query,
+// keyed by field, or nil if neither matches
+func highlights(serverDetail *model.ServerDetail, query string) map[string][]string {
+	terms := search.Terms(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	var result map[string][]string
+	for field, text := range map[string]string{"name": serverDetail.Name, "description": serverDetail.Description} {
+		if fragments := search.Highlights(text, terms); len(fragments) > 0 {
+			if result == nil {
+				result = make(map[string][]string)
+			}
+			result[field] = fragments
+		}
+	}
+	return result
+}
+
+// inMCPRange reports whether a server minimum MCP version lies within the given bounds.
+// Servers without a minimum version only satisfy an upper bound.
+func inMCPRange(serverMinVersion, minMCPVersion, maxMCPVersion string) bool {
+	if minMCPVersion != "" {
+		if serverMinVersion == "" {
+			return false
+		}
+		if c, err := model.CompareSemVer(serverMinVersion, minMCPVersion); err != nil || c < 0 {
+			return false
+		}
+	}
+	if maxMCPVersion != "" {
+		if compatible, err := model.CompatibleWith(serverMinVersion, maxMCPVersion); err != nil || !compatible {
+			return false
+		}
+	}
+	return true
+}
+
+// categoryCounts returns all defined categories with the number of servers in each
+func categoryCounts(ctx context.Context, db database.Database) ([]model.CategoryCount, error) {
+	counts, err := db.CountByCategory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.CategoryCount, len(model.Categories))
+	for i, category := range model.Categories {
+		result[i] = model.CategoryCount{Category: category, Count: counts[category]}
+	}
+
+	return result, nil
+}
+
+// languageCounts returns the languages servers are written in with the number of servers in each,
+// most common first
+func languageCounts(ctx context.Context, db database.Database) ([]model.LanguageCount, error) {
+	counts, err := db.CountByLanguage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.LanguageCount, 0, len(counts))
+	for language, count := range counts {
+		result = append(result, model.LanguageCount{Language: language, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Language < result[j].Language
+	})
+
+	return result, nil
+}
+
+// topicCounts returns the repository topics of servers with the number of servers tagged with each,
+// most common first
+func topicCounts(ctx context.Context, db database.Database) ([]model.TopicCount, error) {
+	counts, err := db.CountByTopic(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.TopicCount, 0, len(counts))
+	for topic, count := range counts {
+		result = append(result, model.TopicCount{Topic: topic, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Topic < result[j].Topic
+	})
+
+	return result, nil
+}
+
+// tagCounts returns the tags of servers with the number of servers carrying each, sorted by tag
+func tagCounts(ctx context.Context, db database.Database) ([]model.TagCount, error) {
+	counts, err := db.CountByTag(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.TagCount, 0, len(counts))
+	for tag, count := range counts {
+		result = append(result, model.TagCount{Tag: tag, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Tag < result[j].Tag })
+
+	return result, nil
+}
+
+// registryStats returns the aggregate metrics of the registry as of now
+func registryStats(ctx context.Context, db database.Database) (model.RegistryStats, error) {
+	stats, err := db.AggregateStats(ctx, time.Now())
+	if err != nil {
+		return model.RegistryStats{}, err
+	}
+
+	stats.VerifiedChecksumCount, err = db.CountVerifiedChecksums(ctx)
+	if err != nil {
+		return model.RegistryStats{}, err
+	}
+
+	return stats, nil
+}
+
+// serverMetadata strips the packages list from a server. The install score depends on the packages, so it is
+// computed first.
+func serverMetadata(serverDetail *model.ServerDetail) *model.ServerDetail {
+	installScore := scoring.ComputeScore(serverDetail)
+	serverDetail.InstallScore = &installScore
+	serverDetail.Packages = nil
+	return serverDetail
+}
+
+// latestVersionID returns the ID of the latest version of the server with the given name
+func latestVersionID(ctx context.Context, db database.Database, name string) (string, error) {
+	filter := mongodb.NewQueryBuilder().WithName(name).Build()
+	cursor := ""
+	for {
+		entries, cursors, err := db.ListDetails(ctx, filter, database.SortByCreation, cursor, database.CursorNext, 100)
+		if err != nil {
+			return "", err
+		}
+		for _, entry := range entries {
+			if entry.VersionDetail.IsLatest {
+				return entry.ID, nil
+			}
+		}
+		if cursors.Next == "" {
+			return "", database.ErrNotFound
+		}
+		cursor = cursors.Next
+	}
+}
+
+// listVersions returns a page of the versions of the server with the given ID in publish order.
+// Yanked versions are only included if includeYanked is set.
+func listVersions(
+	ctx context.Context, db database.Database, id string, includeYanked bool, cursor string, direction database.CursorDirection, limit int,
+) ([]model.ServerVersion, database.PageCursors, error) {
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	entries, cursors, err := db.ListVersions(ctx, id, includeYanked, cursor, direction, limit)
+	if err != nil {
+		return nil, database.PageCursors{}, err
+	}
+
+	versions := make([]model.ServerVersion, len(entries))
+	for i, entry := range entries {
+		versions[i] = model.ServerVersion{ID: entry.ID, VersionDetail: entry.VersionDetail}
+	}
+
+	return versions, cursors, nil
+}
+
+// newServerVersion returns the server detail of a new version of the server with the given ID, copying the
+// server-wide fields of that version. The version must be higher than every existing version of the server.
+func newServerVersion(
+	ctx context.Context, db database.Database, id string, versionDetail model.VersionDetail, packages []model.Package,
+) (*model.ServerDetail, error) {
+	current, err := db.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []*model.ServerDetail
+	cursor := ""
+	for {
+		entries, cursors, err := db.ListVersions(ctx, id, true, cursor, database.CursorNext, 100)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, entries...)
+		if cursors.Next == "" {
+			break
+		}
+		cursor = cursors.Next
+	}
+	if err := database.CheckNewVersion(versions, versionDetail.Version); err != nil {
+		return nil, err
+	}
+
+	serverDetail := &model.ServerDetail{
+		Server:       current.Server,
+		Remotes:      slices.Clone(current.Remotes),
+		Tools:        slices.Clone(current.Tools),
+		Dependencies: slices.Clone(current.Dependencies),
+		Packages:     slices.Clone(packages),
+	}
+	serverDetail.ID = ""
+	serverDetail.CreatedSeq = 0
+	// The release date and latest flag are set when the version is stored
+	serverDetail.VersionDetail = model.VersionDetail{Version: versionDetail.Version}
+	serverDetail.Topics = slices.Clone(current.Topics)
+	serverDetail.Tags = slices.Clone(current.Tags)
+	serverDetail.Maintainers = slices.Clone(current.Maintainers)
+	serverDetail.PinnedVersion = false
+	// Maintainers carry over when the version is stored, and only the registry owner features servers
+	serverDetail.MaintainedBy = nil
+	serverDetail.FeaturedOrder = nil
+	serverDetail.PublishedAt = time.Now().UTC()
+
+	return serverDetail, nil
+}
+
+// serversByIDs retrieves the servers with the given IDs in the order of the IDs.
+// Unknown IDs are skipped and repeated IDs return the server once.
+func serversByIDs(ctx context.Context, db database.Database, ids []string) ([]model.ServerDetail, error) {
+	entries, err := db.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*model.ServerDetail, len(entries))
+	for _, entry := range entries {
+		byID[entry.ID] = entry
+	}
+
+	result := make([]model.ServerDetail, 0, len(entries))
+	for _, id := range ids {
+		if entry, found := byID[id]; found {
+			result = append(result, *entry)
+			delete(byID, id)
+		}
+	}
+
+	return result, nil
+}
+
+// parseSortOrder converts a SearchDetails sort value to a database sort order.
+// An empty value keeps the default creation order, or relevance order for text searches of the memory database.
+func parseSortOrder(sortBy string) (database.SortOrder, error) {
+	switch sortBy {
+	case "":
+		return database.SortByCreation, nil
+	case SortByStars:
+		return database.SortByStars, nil
+	case SortByReleaseDate:
+		return database.SortByReleaseDate, nil
+	case SortByRelevance:
+		return database.SortByRelevance, nil
+	}
+	if IsFieldSort(sortBy) {
+		return database.SortOrder(sortBy), nil
+	}
+	return "", fmt.Errorf("%w: unsupported sort order %q", database.ErrInvalidInput, sortBy)
+}
+
+// parseListSortOrder converts a List sort value, empty or a field sort, to a database sort order
+func parseListSortOrder(sortBy string) (database.SortOrder, error) {
+	if sortBy != "" && !IsFieldSort(sortBy) {
+		return "", fmt.Errorf("%w: unsupported sort order %q", database.ErrInvalidInput, sortBy)
+	}
+	return database.SortOrder(sortBy), nil
 }
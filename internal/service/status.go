@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+)
+
+// normalizeStatus makes servers published without a lifecycle status active
+func normalizeStatus(serverDetail *model.ServerDetail) {
+	if serverDetail.Status == "" {
+		serverDetail.Status = model.StatusActive
+	}
+}
+
+// checkStatusTransition returns database.ErrStatusTransition if a server may not change from one lifecycle
+// status to another. Archived servers may only be brought back by the registry owner; every other change is
+// allowed, including setting the current status again.
+func checkStatusTransition(from, to string, byRegistryOwner bool) error {
+	if from == model.StatusArchived && to != model.StatusArchived && !byRegistryOwner {
+		return fmt.Errorf("%w: only the registry owner may change the status of an archived server", database.ErrStatusTransition)
+	}
+	return nil
+}
+
+// updateStatus sets the lifecycle status of all versions of the server with the given ID after checking that
+// the server may change to it. Servers without a status count as active.
+func updateStatus(ctx context.Context, db database.Database, id, status string, byRegistryOwner bool) error {
+	if !model.IsValidStatus(status) {
+		return fmt.Errorf("%w: unknown status %q", database.ErrInvalidStatus, status)
+	}
+
+	// Create a timeout context for the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// The status is checked in the same transaction as the update so a concurrent change cannot bypass the check
+	return db.WithTransaction(ctx, func(ctx context.Context) error {
+		serverDetail, err := db.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		current := serverDetail.Status
+		if current == "" {
+			current = model.StatusActive
+		}
+		if err := checkStatusTransition(current, status, byRegistryOwner); err != nil {
+			return err
+		}
+		return db.SetStatus(ctx, id, status)
+	})
+}
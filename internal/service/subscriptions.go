@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+)
+
+// validateSubscription checks the webhook URL and events of a subscription and removes repeated events.
+// The webhook host must resolve to public addresses only, so that webhooks cannot reach internal services.
+func validateSubscription(ctx context.Context, resolve HostResolver, subscription *model.Subscription) error {
+	webhookURL, err := url.Parse(subscription.WebhookURL)
+	if err != nil || webhookURL.Scheme != "https" || webhookURL.Hostname() == "" {
+		return fmt.Errorf("%w: webhook URL must be an absolute HTTPS URL", database.ErrInvalidWebhook)
+	}
+	if err := checkWebhookHost(ctx, resolve, webhookURL.Hostname()); err != nil {
+		return fmt.Errorf("%w: %w", database.ErrInvalidWebhook, err)
+	}
+
+	if len(subscription.Events) == 0 {
+		return fmt.Errorf("%w: at least one event is required", database.ErrInvalidWebhook)
+	}
+	events := make([]model.SubscriptionEvent, 0, len(subscription.Events))
+	for _, event := range subscription.Events {
+		if !event.IsValid() {
+			return fmt.Errorf("%w: unknown event %q", database.ErrInvalidWebhook, event)
+		}
+		if !slices.Contains(events, event) {
+			events = append(events, event)
+		}
+	}
+	subscription.Events = events
+	return nil
+}
+
+// saveSubscription subscribes a webhook to the server with the ID of the subscription. Subscribing a webhook URL
+// the server is already subscribed to updates the events of the existing subscription instead of adding another.
+// It reports whether a new subscription was created.
+func saveSubscription(
+	ctx context.Context, db database.Database, resolve HostResolver, subscription *model.Subscription,
+) (bool, error) {
+	// Create a timeout context for the host lookup and the database operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := validateSubscription(ctx, resolve, subscription); err != nil {
+		return false, err
+	}
+
+	serverDetail, err := db.GetByID(ctx, subscription.ServerID)
+	if err != nil {
+		return false, err
+	}
+	subscription.ServerName = serverDetail.Name
+	subscription.UpdatedAt = time.Now().UTC()
+
+	existing, err := db.FindSubscription(ctx, subscription.ServerName, subscription.WebhookURL)
+	switch {
+	case err == nil:
+		if existing.GitHubUserID != subscription.GitHubUserID {
+			return false, database.ErrAlreadyExists
+		}
+		subscription.ID = existing.ID
+		subscription.CreatedAt = existing.CreatedAt
+		return false, db.SaveSubscription(ctx, subscription)
+	case !errors.Is(err, database.ErrNotFound):
+		return false, err
+	}
+
+	count, err := db.CountSubscriptions(ctx, subscription.GitHubUserID)
+	if err != nil {
+		return false, err
+	}
+	if count >= model.MaxSubscriptionsPerUser {
+		return false, database.ErrTooManyWebhooks
+	}
+
+	subscription.ID = uuid.New().String()
+	subscription.CreatedAt = subscription.UpdatedAt
+	if err := db.SaveSubscription(ctx, subscription); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,78 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopics(t *testing.T) {
+	registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+	for name, topics := range map[string][]string{
+		"example/one":  {"MCP", " Database ", "mcp", ""},
+		"example/two":  {"mcp", "search"},
+		"example/none": nil,
+	} {
+		serverDetail := newServerDetail(name, "")
+		serverDetail.Topics = topics
+		require.NoError(t, registry.Publish(serverDetail))
+	}
+
+	t.Run("stored normalized", func(t *testing.T) {
+		servers, _, err := registry.Search(service.SearchOptions{
+			Topic:     "DATABASE",
+			Direction: database.CursorNext,
+			Limit:     10,
+		})
+		require.NoError(t, err)
+		require.Len(t, servers, 1)
+		assert.Equal(t, []string{"mcp", "database"}, servers[0].Topics)
+	})
+
+	t.Run("filter by topic", func(t *testing.T) {
+		servers, _, err := registry.Search(service.SearchOptions{
+			Topic:     "mcp",
+			Direction: database.CursorNext,
+			Limit:     10,
+		})
+		require.NoError(t, err)
+		assert.Len(t, servers, 2)
+	})
+
+	t.Run("text search matches topics", func(t *testing.T) {
+		servers, _, err := registry.SearchDetails(service.SearchOptions{
+			Query:     "search",
+			Direction: database.CursorNext,
+			Limit:     10,
+		})
+		require.NoError(t, err)
+		require.Len(t, servers, 1)
+		assert.Equal(t, "example/two", servers[0].Name)
+	})
+
+	t.Run("at most 20 topics", func(t *testing.T) {
+		serverDetail := newServerDetail("example/many", "")
+		for i := range 25 {
+			serverDetail.Topics = append(serverDetail.Topics, fmt.Sprintf("topic-%d", i))
+		}
+		require.NoError(t, registry.Publish(serverDetail))
+		assert.Len(t, serverDetail.Topics, service.MaxTopicsPerServer)
+	})
+
+	t.Run("most common first", func(t *testing.T) {
+		topics, err := registry.ListTopics(context.Background())
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, len(topics), 3)
+		assert.Equal(t, []model.TopicCount{
+			{Topic: "mcp", Count: 2},
+			{Topic: "database", Count: 1},
+			{Topic: "search", Count: 1},
+		}, topics[:3])
+	})
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+)
+
+const (
+	// vacuumTimeout bounds how long each vacuum phase may run
+	vacuumTimeout = 30 * time.Minute
+
+	// DefaultVacuumRetention is how long finished reindex jobs are kept before a vacuum removes them
+	DefaultVacuumRetention = 30 * 24 * time.Hour
+)
+
+// Names of the vacuum phases, in the order they run
+const (
+	VacuumPhaseOrphanedSchemas = "orphaned_schemas"
+	VacuumPhaseReindexJobs     = "reindex_jobs"
+	VacuumPhaseCompact         = "compact"
+)
+
+// vacuumPhase removes stale records and returns the number of removed documents and reclaimed bytes
+type vacuumPhase struct {
+	name string
+	run  func(ctx context.Context) (int64, int64, error)
+}
+
+// startVacuum records a new pending vacuum job and runs its phases in the background.
+// Finished reindex jobs older than retention are removed.
+func startVacuum(ctx context.Context, db database.Database, retention time.Duration) (*model.VacuumJob, error) {
+	job := &model.VacuumJob{
+		ID:        uuid.New().String(),
+		Status:    model.VacuumJobPending,
+		Phases:    []model.VacuumPhase{},
+		CreatedAt: time.Now().UTC(),
+	}
+
+	saveCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := db.SaveVacuumJob(saveCtx, job); err != nil {
+		return nil, err
+	}
+
+	phases := []vacuumPhase{
+		{name: VacuumPhaseOrphanedSchemas, run: db.RemoveOrphanedSchemas},
+		{name: VacuumPhaseReindexJobs, run: func(ctx context.Context) (int64, int64, error) {
+			return db.RemoveReindexJobs(ctx, job.CreatedAt.Add(-retention))
+		}},
+		{name: VacuumPhaseCompact, run: func(ctx context.Context) (int64, int64, error) {
+			bytesFreed, err := db.Compact(ctx)
+			return 0, bytesFreed, err
+		}},
+	}
+
+	jobCopy := *job
+	go runVacuum(db, &jobCopy, phases)
+
+	return job, nil
+}
+
+// runVacuum runs the vacuum phases and records their outcome on the job. A failing phase does not
+// stop the phases after it; the job fails if any phase failed. It runs detached from the request that started it.
+func runVacuum(db database.Database, job *model.VacuumJob, phases []vacuumPhase) {
+	job.Status = model.VacuumJobRunning
+	saveVacuumJob(db, job)
+
+	var phaseErrors []error
+	for _, phase := range phases {
+		ctx, cancel := context.WithTimeout(context.Background(), vacuumTimeout)
+		removed, bytesReclaimed, err := phase.run(ctx)
+		cancel()
+
+		result := model.VacuumPhase{Name: phase.name, DocumentsRemoved: removed, BytesReclaimed: bytesReclaimed}
+		if err != nil {
+			result.Error = err.Error()
+			phaseErrors = append(phaseErrors, err)
+			log.Printf("Vacuum job %s phase %s failed: %v", job.ID, phase.name, err)
+		}
+		job.Phases = append(job.Phases, result)
+		job.BytesReclaimed += bytesReclaimed
+	}
+
+	completedAt := time.Now().UTC()
+	job.CompletedAt = &completedAt
+	if err := errors.Join(phaseErrors...); err != nil {
+		job.Status = model.VacuumJobFailed
+		job.Error = err.Error()
+	} else {
+		job.Status = model.VacuumJobCompleted
+	}
+	saveVacuumJob(db, job)
+}
+
+// saveVacuumJob records the progress of a vacuum job, logging failures
+func saveVacuumJob(db database.Database, job *model.VacuumJob) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.SaveVacuumJob(ctx, job); err != nil {
+		log.Printf("Error saving vacuum job %s: %v", job.ID, err)
+	}
+}
@@ -0,0 +1,145 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// vacuumRecordingDB is an in-memory database that records the status of every saved vacuum job
+// and can fail individual vacuum phases
+type vacuumRecordingDB struct {
+	*database.MemoryDB
+	failSchemas bool
+	failCompact bool
+
+	mu       sync.Mutex
+	statuses []model.VacuumJobStatus
+}
+
+func (db *vacuumRecordingDB) SaveVacuumJob(ctx context.Context, job *model.VacuumJob) error {
+	db.mu.Lock()
+	db.statuses = append(db.statuses, job.Status)
+	db.mu.Unlock()
+	return db.MemoryDB.SaveVacuumJob(ctx, job)
+}
+
+func (db *vacuumRecordingDB) RemoveOrphanedSchemas(ctx context.Context) (int64, int64, error) {
+	if db.failSchemas {
+		return 0, 0, errors.New("schema cleanup failed")
+	}
+	return db.MemoryDB.RemoveOrphanedSchemas(ctx)
+}
+
+func (db *vacuumRecordingDB) Compact(ctx context.Context) (int64, error) {
+	if db.failCompact {
+		return 0, errors.New("compact failed")
+	}
+	return db.MemoryDB.Compact(ctx)
+}
+
+// saveFinishedReindexJob stores a reindex job that completed at the given time
+func saveFinishedReindexJob(t *testing.T, db database.Database, completedAt time.Time) {
+	t.Helper()
+	require.NoError(t, db.SaveReindexJob(context.Background(), &model.ReindexJob{
+		ID:          uuid.New().String(),
+		Status:      model.ReindexJobCompleted,
+		StartedAt:   completedAt.Add(-time.Minute),
+		CompletedAt: &completedAt,
+	}))
+}
+
+// waitForVacuum polls a vacuum job until it has finished
+func waitForVacuum(t *testing.T, registry service.RegistryService, id string) *model.VacuumJob {
+	t.Helper()
+	var job *model.VacuumJob
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = registry.GetVacuumJob(context.Background(), id)
+		require.NoError(t, err)
+		return job.Status == model.VacuumJobCompleted || job.Status == model.VacuumJobFailed
+	}, 2*time.Second, 10*time.Millisecond)
+	return job
+}
+
+// phaseByName returns the recorded result of the named vacuum phase
+func phaseByName(t *testing.T, job *model.VacuumJob, name string) model.VacuumPhase {
+	t.Helper()
+	for _, phase := range job.Phases {
+		if phase.Name == name {
+			return phase
+		}
+	}
+	require.Failf(t, "missing vacuum phase", "phase %s was not run", name)
+	return model.VacuumPhase{}
+}
+
+func TestStartVacuum(t *testing.T) {
+	t.Run("completes all phases and reclaims storage", func(t *testing.T) {
+		db := &vacuumRecordingDB{MemoryDB: database.NewMemoryDB(map[string]*model.Server{})}
+		registry := service.NewRegistryServiceWithDB(db, service.WithVacuumRetention(24*time.Hour))
+		saveFinishedReindexJob(t, db, time.Now().Add(-48*time.Hour))
+		saveFinishedReindexJob(t, db, time.Now().Add(-48*time.Hour))
+		saveFinishedReindexJob(t, db, time.Now().Add(-time.Hour))
+
+		job, err := registry.StartVacuum(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, model.VacuumJobPending, job.Status)
+
+		job = waitForVacuum(t, registry, job.ID)
+		assert.Equal(t, model.VacuumJobCompleted, job.Status)
+		assert.Empty(t, job.Error)
+		assert.NotNil(t, job.CompletedAt)
+		assert.Positive(t, job.BytesReclaimed)
+
+		reindexJobs := phaseByName(t, job, service.VacuumPhaseReindexJobs)
+		assert.Equal(t, int64(2), reindexJobs.DocumentsRemoved)
+		assert.Equal(t, job.BytesReclaimed, reindexJobs.BytesReclaimed)
+		assert.Zero(t, phaseByName(t, job, service.VacuumPhaseOrphanedSchemas).DocumentsRemoved)
+		assert.Empty(t, phaseByName(t, job, service.VacuumPhaseCompact).Error)
+
+		db.mu.Lock()
+		defer db.mu.Unlock()
+		assert.Equal(t,
+			[]model.VacuumJobStatus{model.VacuumJobPending, model.VacuumJobRunning, model.VacuumJobCompleted},
+			db.statuses,
+		)
+	})
+
+	t.Run("failing phases do not stop the others", func(t *testing.T) {
+		db := &vacuumRecordingDB{MemoryDB: database.NewMemoryDB(map[string]*model.Server{}), failSchemas: true, failCompact: true}
+		registry := service.NewRegistryServiceWithDB(db, service.WithVacuumRetention(24*time.Hour))
+		saveFinishedReindexJob(t, db, time.Now().Add(-48*time.Hour))
+
+		job, err := registry.StartVacuum(context.Background())
+		require.NoError(t, err)
+
+		job = waitForVacuum(t, registry, job.ID)
+		assert.Equal(t, model.VacuumJobFailed, job.Status)
+		assert.Contains(t, job.Error, "schema cleanup failed")
+		assert.Contains(t, job.Error, "compact failed")
+		require.Len(t, job.Phases, 3)
+
+		assert.Equal(t, "schema cleanup failed", phaseByName(t, job, service.VacuumPhaseOrphanedSchemas).Error)
+		assert.Equal(t, "compact failed", phaseByName(t, job, service.VacuumPhaseCompact).Error)
+		reindexJobs := phaseByName(t, job, service.VacuumPhaseReindexJobs)
+		assert.Empty(t, reindexJobs.Error)
+		assert.Equal(t, int64(1), reindexJobs.DocumentsRemoved)
+		assert.Positive(t, reindexJobs.BytesReclaimed)
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		_, err := registry.GetVacuumJob(context.Background(), uuid.New().String())
+		assert.ErrorIs(t, err, database.ErrNotFound)
+	})
+}
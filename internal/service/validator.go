@@ -0,0 +1,302 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+)
+
+// ValidationError describes a field of server details that failed validation
+type ValidationError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+	// err is the sentinel error of the failed rule, so callers can keep matching specific failures
+	err error
+}
+
+// ValidationErrors is returned when server details fail validation and lists every failure.
+// It matches database.ErrValidation as well as the sentinel error of each failed rule with errors.Is.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, validationErr := range e {
+		messages[i] = validationErr.Field + ": " + validationErr.Message
+	}
+	return fmt.Sprintf("%v: %s", database.ErrValidation, strings.Join(messages, "; "))
+}
+
+// Unwrap returns database.ErrValidation followed by the sentinel errors of the failed rules
+func (e ValidationErrors) Unwrap() []error {
+	errs := []error{database.ErrValidation}
+	for _, validationErr := range e {
+		if validationErr.err != nil {
+			errs = append(errs, validationErr.err)
+		}
+	}
+	return errs
+}
+
+// ValidationConfig holds the configurable limits applied when validating published server details
+type ValidationConfig struct {
+	// MaxPackages caps the number of packages of a server; the number is not limited if it is not positive
+	MaxPackages int
+	// AllowedRegistryNames restricts the registry names of packages, ignoring case.
+	// Any well-formed registry name is allowed if it is empty.
+	AllowedRegistryNames []string
+	// RequireSemVer requires server versions to be semantic versions
+	RequireSemVer bool
+}
+
+// DefaultValidationConfig returns the validation limits used unless service options change them
+func DefaultValidationConfig() ValidationConfig {
+	return ValidationConfig{MaxPackages: MaxPackagesPerServer}
+}
+
+// tagPattern is the format tags must have: lowercase letters, digits and hyphens, starting with a letter or digit
+var tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// maxTagLength is the maximum length of a tag
+const maxTagLength = 50
+
+// githubUsernamePattern is the format GitHub usernames of maintainers must have
+var githubUsernamePattern = regexp.MustCompile(`^[a-zA-Z0-9-]+$`)
+
+// registryNamePattern is the format registry names must have when no allowed registry names are configured
+var registryNamePattern = regexp.MustCompile(`^[a-z0-9\-]+$`)
+
+// validator collects the failures found while validating server details
+type validator struct {
+	errs []ValidationError
+}
+
+// fail records a failure of the given field for the rule identified by err
+func (v *validator) fail(field string, err error, format string, args ...any) {
+	v.errs = append(v.errs, ValidationError{Field: field, Message: fmt.Sprintf(format, args...), err: err})
+}
+
+// ValidateServerDetail checks server details being published against every validation rule and returns all
+// failures, or nil if the details are valid. It does not modify the details. Dependencies are only checked
+// for their format, since checking that they exist needs the database.
+func ValidateServerDetail(detail *model.ServerDetail, cfg ValidationConfig) []ValidationError {
+	var v validator
+	if detail == nil {
+		v.fail("server", database.ErrInvalidInput, "server details are required")
+		return v.errs
+	}
+
+	if strings.TrimSpace(detail.Name) == "" {
+		v.fail("name", database.ErrInvalidInput, "name is required")
+	}
+	switch {
+	case detail.VersionDetail.Version == "":
+		v.fail("version_detail.version", database.ErrInvalidInput, "version is required")
+	case cfg.RequireSemVer && !model.IsValidSemVer(detail.VersionDetail.Version):
+		v.fail("version_detail.version", database.ErrInvalidInput, "%q is not a semantic version", detail.VersionDetail.Version)
+	}
+
+	// An empty category is allowed; it defaults to other when published
+	if detail.Category != "" && !detail.Category.IsValid() {
+		v.fail("category", database.ErrInvalidCategory, "unknown category %q", detail.Category)
+	}
+	if detail.ContactEmail != "" {
+		address, err := mail.ParseAddress(detail.ContactEmail)
+		if err != nil || address.Address != detail.ContactEmail {
+			v.fail("contact_email", database.ErrInvalidEmail, "%q is not a plain email address", detail.ContactEmail)
+		}
+	}
+	if detail.License != "" {
+		if _, ok := canonicalLicense(detail.License); !ok {
+			v.fail("license", database.ErrInvalidLicense, "%q is not a recognized SPDX license identifier", detail.License)
+		}
+	}
+	if detail.Status != "" && !model.IsValidStatus(detail.Status) {
+		v.fail("status", database.ErrInvalidStatus, "unknown status %q", detail.Status)
+	}
+	if detail.MinMCPVersion != "" && !model.IsValidSemVer(detail.MinMCPVersion) {
+		v.fail("min_mcp_version", database.ErrInvalidMCPVersion, "%q is not a semantic version", detail.MinMCPVersion)
+	}
+	if detail.Schema != nil {
+		var schema any
+		if err := json.Unmarshal(*detail.Schema, &schema); err != nil {
+			v.fail("schema", database.ErrInvalidSchema, "schema is not valid JSON: %v", err)
+		}
+	}
+
+	if detail.SuccessorID != "" {
+		switch _, err := uuid.Parse(detail.SuccessorID); {
+		case err != nil:
+			v.fail("successor_id", database.ErrInvalidInput, "%q is not a server ID", detail.SuccessorID)
+		case !detail.Deprecated:
+			v.fail("successor_id", database.ErrInvalidInput, "only deprecated servers may name a successor")
+		case detail.SuccessorID == detail.ID:
+			v.fail("successor_id", database.ErrInvalidInput, "a server cannot be its own successor")
+		}
+	}
+
+	if detail.HealthCheckURL != "" && !isHTTPSURL(detail.HealthCheckURL) {
+		v.fail("health_check_url", database.ErrInvalidHealthCheck, "%q is not an HTTPS URL", detail.HealthCheckURL)
+	}
+
+	v.validateTools(detail.Tools)
+
+	if cfg.MaxPackages > 0 && len(detail.Packages) > cfg.MaxPackages {
+		v.fail("packages", database.ErrTooManyPackages, "at most %d packages are allowed, got %d", cfg.MaxPackages, len(detail.Packages))
+	}
+	v.validatePackages(detail.Packages, cfg.AllowedRegistryNames)
+
+	if len(detail.Tags) > MaxTagsPerServer {
+		v.fail("tags", database.ErrTooManyTags, "at most %d tags are allowed, got %d", MaxTagsPerServer, len(detail.Tags))
+	}
+	v.validateTags(detail.Tags)
+	v.validateMaintainers(detail.Maintainers)
+
+	for i, id := range detail.Dependencies {
+		field := fmt.Sprintf("dependencies[%d]", i)
+		if _, err := uuid.Parse(id); err != nil {
+			v.fail(field, database.ErrInvalidDependency, "%q is not a server ID", id)
+		} else if slices.Contains(detail.Dependencies[:i], id) {
+			v.fail(field, database.ErrInvalidDependency, "duplicate dependency %q", id)
+		}
+	}
+
+	return v.errs
+}
+
+// validateAppendedPackages checks packages appended to an existing server
+func validateAppendedPackages(serverDetail *model.ServerDetail, packages []model.Package, cfg ValidationConfig) []ValidationError {
+	var v validator
+	if total := len(serverDetail.Packages) + len(packages); cfg.MaxPackages > 0 && total > cfg.MaxPackages {
+		v.fail("packages", database.ErrTooManyPackages, "at most %d packages are allowed, got %d", cfg.MaxPackages, total)
+	}
+	v.validatePackages(packages, cfg.AllowedRegistryNames)
+	return v.errs
+}
+
+// tooManyPackagesErr is the validation error for packages that were checked against the limit, but no longer fit
+// by the time they were stored because packages were appended concurrently
+func tooManyPackagesErr(maxPackages int) error {
+	var v validator
+	v.fail("packages", database.ErrTooManyPackages, "at most %d packages are allowed", maxPackages)
+	return validationErr(v.errs)
+}
+
+// validateAddedTags checks the tags being added to a published server; tags the server already has are not counted
+func validateAddedTags(serverDetail *model.ServerDetail, tags []string) []ValidationError {
+	var v validator
+	total := len(serverDetail.Tags)
+	for _, tag := range tags {
+		if !slices.Contains(serverDetail.Tags, tag) {
+			total++
+		}
+	}
+	if total > MaxTagsPerServer {
+		v.fail("tags", database.ErrTooManyTags, "at most %d tags are allowed, got %d", MaxTagsPerServer, total)
+	}
+	v.validateTags(tags)
+	return v.errs
+}
+
+// validateTags checks that every tag consists of lowercase letters, digits and hyphens and is not too long
+func (v *validator) validateTags(tags []string) {
+	for i, tag := range tags {
+		field := fmt.Sprintf("tags[%d]", i)
+		switch {
+		case len(tag) > maxTagLength:
+			v.fail(field, database.ErrInvalidTag, "tags may be at most %d characters long", maxTagLength)
+		case !tagPattern.MatchString(tag):
+			v.fail(field, database.ErrInvalidTag, "%q must match %s", tag, tagPattern)
+		}
+	}
+}
+
+// validateMaintainers checks the email address and GitHub username of every maintainer that sets them
+func (v *validator) validateMaintainers(maintainers []model.Maintainer) {
+	for i, maintainer := range maintainers {
+		field := fmt.Sprintf("maintainers[%d]", i)
+		if maintainer.Email != "" {
+			address, err := mail.ParseAddress(maintainer.Email)
+			if err != nil || address.Address != maintainer.Email {
+				v.fail(field+".email", database.ErrInvalidMaintainer, "%q is not a plain email address", maintainer.Email)
+			}
+		}
+		if maintainer.GitHubUsername != "" && !githubUsernamePattern.MatchString(maintainer.GitHubUsername) {
+			v.fail(field+".github_username", database.ErrInvalidMaintainer, "%q must match %s",
+				maintainer.GitHubUsername, githubUsernamePattern)
+		}
+	}
+}
+
+// validatePackages checks that every package has a registry name and name, that its registry name
+// is allowed and that its documentation URL, if set, uses HTTPS. With allowed registry names configured,
+// each registry name must be one of them, ignoring case. Otherwise it must consist of lowercase letters,
+// digits and hyphens.
+func (v *validator) validatePackages(packages []model.Package, allowedRegistryNames []string) {
+	for i, pkg := range packages {
+		field := fmt.Sprintf("packages[%d]", i)
+
+		switch {
+		case pkg.RegistryName == "":
+			v.fail(field+".registry_name", database.ErrInvalidRegistry, "registry name is required")
+		case len(allowedRegistryNames) > 0:
+			if !slices.ContainsFunc(allowedRegistryNames, func(name string) bool { return strings.EqualFold(name, pkg.RegistryName) }) {
+				v.fail(field+".registry_name", database.ErrInvalidRegistry, "%q is not allowed (allowed: %s)",
+					pkg.RegistryName, strings.Join(allowedRegistryNames, ", "))
+			}
+		case !registryNamePattern.MatchString(pkg.RegistryName):
+			v.fail(field+".registry_name", database.ErrInvalidRegistry, "%q must match %s", pkg.RegistryName, registryNamePattern)
+		}
+
+		if pkg.Name == "" {
+			v.fail(field+".name", database.ErrInvalidInput, "name is required")
+		}
+
+		if pkg.DocURL != "" && !isHTTPSURL(pkg.DocURL) {
+			v.fail(field+".doc_url", database.ErrInvalidDocURL, "%q is not an HTTPS URL", pkg.DocURL)
+		}
+	}
+}
+
+// isHTTPSURL reports whether rawURL is an absolute HTTPS URL
+func isHTTPSURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	return err == nil && parsed.Scheme == "https" && parsed.Host != ""
+}
+
+// validateTools checks that every declared tool has a unique name and that input schemas are JSON objects
+func (v *validator) validateTools(tools []model.MCPTool) {
+	names := make(map[string]bool, len(tools))
+	for i, tool := range tools {
+		field := fmt.Sprintf("tools[%d]", i)
+		switch {
+		case strings.TrimSpace(tool.Name) == "":
+			v.fail(field+".name", database.ErrInvalidTool, "tool name is required")
+		case names[tool.Name]:
+			v.fail(field+".name", database.ErrInvalidTool, "duplicate tool name %q", tool.Name)
+		}
+		names[tool.Name] = true
+
+		if len(tool.InputSchema) > 0 {
+			var inputSchema map[string]any
+			if err := json.Unmarshal(tool.InputSchema, &inputSchema); err != nil {
+				v.fail(field+".input_schema", database.ErrInvalidTool, "input schema of tool %q must be a JSON object", tool.Name)
+			}
+		}
+	}
+}
+
+// validationErr returns the failures as an error, or nil if there are none
+func validationErr(errs []ValidationError) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return ValidationErrors(errs)
+}
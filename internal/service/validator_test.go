@@ -0,0 +1,385 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validServerDetail returns server details passing every validation rule
+func validServerDetail() *model.ServerDetail {
+	schema := json.RawMessage(`{"type": "object"}`)
+	serverDetail := newServerDetail("example/valid", model.CategoryDatabase)
+	serverDetail.ContactEmail = "security@example.com"
+	serverDetail.MinMCPVersion = "1.2.0"
+	serverDetail.License = "Apache-2.0"
+	serverDetail.Maintainers = []model.Maintainer{{Name: "Jane Doe", Email: "jane@example.com", GitHubUsername: "jane-doe"}}
+	serverDetail.Schema = &schema
+	serverDetail.Tools = []model.MCPTool{{Name: "query", InputSchema: json.RawMessage(`{"type": "object"}`)}}
+	serverDetail.Packages = []model.Package{
+		{RegistryName: "npm", Name: "@example/valid", Version: "1.0.0", DocURL: "https://example.com/docs"},
+	}
+	serverDetail.Dependencies = []string{uuid.New().String()}
+	return serverDetail
+}
+
+func TestValidateServerDetail(t *testing.T) {
+	dependency := uuid.New().String()
+	invalidSchema := json.RawMessage(`{"type": `)
+
+	testCases := []struct {
+		name   string
+		cfg    service.ValidationConfig
+		modify func(*model.ServerDetail)
+		// fields are the fields expected to fail, in order; none are expected if empty
+		fields []string
+		err    error
+	}{
+		{name: "valid details", cfg: service.DefaultValidationConfig(), modify: func(*model.ServerDetail) {}},
+		{
+			name:   "name is required",
+			modify: func(s *model.ServerDetail) { s.Name = " " },
+			fields: []string{"name"},
+			err:    database.ErrInvalidInput,
+		},
+		{
+			name:   "version is required",
+			modify: func(s *model.ServerDetail) { s.VersionDetail.Version = "" },
+			fields: []string{"version_detail.version"},
+			err:    database.ErrInvalidInput,
+		},
+		{
+			name:   "any version is accepted by default",
+			modify: func(s *model.ServerDetail) { s.VersionDetail.Version = "2025-06-01" },
+		},
+		{
+			name:   "semantic version required",
+			cfg:    service.ValidationConfig{RequireSemVer: true},
+			modify: func(s *model.ServerDetail) { s.VersionDetail.Version = "2025-06-01" },
+			fields: []string{"version_detail.version"},
+			err:    database.ErrInvalidInput,
+		},
+		{
+			name:   "empty category is allowed",
+			modify: func(s *model.ServerDetail) { s.Category = "" },
+		},
+		{
+			name:   "unknown category",
+			modify: func(s *model.ServerDetail) { s.Category = "blockchain" },
+			fields: []string{"category"},
+			err:    database.ErrInvalidCategory,
+		},
+		{
+			name:   "contact email with display name",
+			modify: func(s *model.ServerDetail) { s.ContactEmail = "Security <security@example.com>" },
+			fields: []string{"contact_email"},
+			err:    database.ErrInvalidEmail,
+		},
+		{
+			name:   "minimum MCP version is not a semantic version",
+			modify: func(s *model.ServerDetail) { s.MinMCPVersion = "latest" },
+			fields: []string{"min_mcp_version"},
+			err:    database.ErrInvalidMCPVersion,
+		},
+		{
+			name:   "license is not an SPDX identifier",
+			modify: func(s *model.ServerDetail) { s.License = "MIT or whatever" },
+			fields: []string{"license"},
+			err:    database.ErrInvalidLicense,
+		},
+		{
+			name: "maintainer with an invalid email and GitHub username",
+			modify: func(s *model.ServerDetail) {
+				s.Maintainers = append(s.Maintainers, model.Maintainer{Email: "Jane <jane@example.com>", GitHubUsername: "jane.doe"})
+			},
+			fields: []string{"maintainers[1].email", "maintainers[1].github_username"},
+			err:    database.ErrInvalidMaintainer,
+		},
+		{
+			name:   "unknown status",
+			modify: func(s *model.ServerDetail) { s.Status = "retired" },
+			fields: []string{"status"},
+			err:    database.ErrInvalidStatus,
+		},
+		{
+			name:   "malformed schema",
+			modify: func(s *model.ServerDetail) { s.Schema = &invalidSchema },
+			fields: []string{"schema"},
+			err:    database.ErrInvalidSchema,
+		},
+		{
+			name: "deprecated server with successor",
+			modify: func(s *model.ServerDetail) {
+				s.Deprecated = true
+				s.SuccessorID = dependency
+			},
+		},
+		{
+			name:   "successor is not a server ID",
+			modify: func(s *model.ServerDetail) { s.Deprecated, s.SuccessorID = true, "example/other" },
+			fields: []string{"successor_id"},
+			err:    database.ErrInvalidInput,
+		},
+		{
+			name:   "successor of a server that is not deprecated",
+			modify: func(s *model.ServerDetail) { s.SuccessorID = dependency },
+			fields: []string{"successor_id"},
+			err:    database.ErrInvalidInput,
+		},
+		{
+			name: "server succeeding itself",
+			modify: func(s *model.ServerDetail) {
+				s.ID = dependency
+				s.Deprecated, s.SuccessorID = true, dependency
+			},
+			fields: []string{"successor_id"},
+			err:    database.ErrInvalidInput,
+		},
+		{
+			name:   "health check URL must use HTTPS",
+			modify: func(s *model.ServerDetail) { s.HealthCheckURL = "http://example.com/health" },
+			fields: []string{"health_check_url"},
+			err:    database.ErrInvalidHealthCheck,
+		},
+		{
+			name:   "tags must be well-formed",
+			modify: func(s *model.ServerDetail) { s.Tags = []string{"llm", "not a tag"} },
+			fields: []string{"tags[1]"},
+			err:    database.ErrInvalidTag,
+		},
+		{
+			name: "too many tags",
+			modify: func(s *model.ServerDetail) {
+				for i := range service.MaxTagsPerServer + 1 {
+					s.Tags = append(s.Tags, fmt.Sprintf("tag-%d", i))
+				}
+			},
+			fields: []string{"tags"},
+			err:    database.ErrTooManyTags,
+		},
+		{
+			name:   "tool name is required",
+			modify: func(s *model.ServerDetail) { s.Tools = append(s.Tools, model.MCPTool{Name: ""}) },
+			fields: []string{"tools[1].name"},
+			err:    database.ErrInvalidTool,
+		},
+		{
+			name:   "duplicate tool name",
+			modify: func(s *model.ServerDetail) { s.Tools = append(s.Tools, model.MCPTool{Name: "query"}) },
+			fields: []string{"tools[1].name"},
+			err:    database.ErrInvalidTool,
+		},
+		{
+			name:   "tool input schema is not an object",
+			modify: func(s *model.ServerDetail) { s.Tools[0].InputSchema = json.RawMessage(`["query"]`) },
+			fields: []string{"tools[0].input_schema"},
+			err:    database.ErrInvalidTool,
+		},
+		{
+			name: "too many packages",
+			cfg:  service.ValidationConfig{MaxPackages: 1},
+			modify: func(s *model.ServerDetail) {
+				s.Packages = append(s.Packages, model.Package{RegistryName: "pypi", Name: "example", Version: "1.0.0"})
+			},
+			fields: []string{"packages"},
+			err:    database.ErrTooManyPackages,
+		},
+		{
+			name: "packages are not limited without a maximum",
+			modify: func(s *model.ServerDetail) {
+				for i := range 30 {
+					s.Packages = append(s.Packages, model.Package{RegistryName: "npm", Name: fmt.Sprintf("example-%d", i), Version: "1.0.0"})
+				}
+			},
+		},
+		{
+			name:   "registry name is required",
+			modify: func(s *model.ServerDetail) { s.Packages[0].RegistryName = "" },
+			fields: []string{"packages[0].registry_name"},
+			err:    database.ErrInvalidRegistry,
+		},
+		{
+			name:   "registry name not in allowed list",
+			cfg:    service.ValidationConfig{AllowedRegistryNames: []string{"pypi"}},
+			modify: func(*model.ServerDetail) {},
+			fields: []string{"packages[0].registry_name"},
+			err:    database.ErrInvalidRegistry,
+		},
+		{
+			name:   "allowed list ignores case",
+			cfg:    service.ValidationConfig{AllowedRegistryNames: []string{"NPM"}},
+			modify: func(*model.ServerDetail) {},
+		},
+		{
+			name:   "malformed registry name",
+			modify: func(s *model.ServerDetail) { s.Packages[0].RegistryName = "My_Registry" },
+			fields: []string{"packages[0].registry_name"},
+			err:    database.ErrInvalidRegistry,
+		},
+		{
+			name:   "package name is required",
+			modify: func(s *model.ServerDetail) { s.Packages[0].Name = "" },
+			fields: []string{"packages[0].name"},
+			err:    database.ErrInvalidInput,
+		},
+		{
+			name:   "documentation URL must use HTTPS",
+			modify: func(s *model.ServerDetail) { s.Packages[0].DocURL = "http://example.com/docs" },
+			fields: []string{"packages[0].doc_url"},
+			err:    database.ErrInvalidDocURL,
+		},
+		{
+			name:   "dependency is not a server ID",
+			modify: func(s *model.ServerDetail) { s.Dependencies = []string{"example/other"} },
+			fields: []string{"dependencies[0]"},
+			err:    database.ErrInvalidDependency,
+		},
+		{
+			name:   "duplicate dependency",
+			modify: func(s *model.ServerDetail) { s.Dependencies = []string{dependency, dependency} },
+			fields: []string{"dependencies[1]"},
+			err:    database.ErrInvalidDependency,
+		},
+		{
+			name: "every failure is reported",
+			modify: func(s *model.ServerDetail) {
+				s.Name = ""
+				s.Category = "blockchain"
+				s.Packages[0].DocURL = "ftp://example.com/docs"
+			},
+			fields: []string{"name", "category", "packages[0].doc_url"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			serverDetail := validServerDetail()
+			tc.modify(serverDetail)
+
+			errs := service.ValidateServerDetail(serverDetail, tc.cfg)
+			fields := make([]string, len(errs))
+			for i, validationErr := range errs {
+				fields[i] = validationErr.Field
+				assert.NotEmpty(t, validationErr.Message)
+			}
+			if len(tc.fields) == 0 {
+				assert.Empty(t, fields)
+				return
+			}
+			assert.Equal(t, tc.fields, fields)
+
+			err := service.ValidationErrors(errs)
+			assert.ErrorIs(t, err, database.ErrValidation)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+			}
+		})
+	}
+
+	t.Run("nil details", func(t *testing.T) {
+		errs := service.ValidateServerDetail(nil, service.DefaultValidationConfig())
+		require.Len(t, errs, 1)
+		assert.ErrorIs(t, service.ValidationErrors(errs), database.ErrInvalidInput)
+	})
+}
+
+func TestPublishValidation(t *testing.T) {
+	t.Run("invalid details are rejected with every failure", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		serverDetail := newServerDetail("example/invalid", "blockchain")
+		serverDetail.ContactEmail = "not-an-email"
+
+		err := registry.Publish(serverDetail)
+		var validationErrs service.ValidationErrors
+		require.ErrorAs(t, err, &validationErrs)
+		require.Len(t, validationErrs, 2)
+		assert.Equal(t, "category", validationErrs[0].Field)
+		assert.Equal(t, "contact_email", validationErrs[1].Field)
+		assert.ErrorIs(t, err, database.ErrInvalidCategory)
+		assert.ErrorIs(t, err, database.ErrInvalidEmail)
+	})
+
+	t.Run("semantic versions can be required", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(
+			database.NewMemoryDB(map[string]*model.Server{}), service.WithRequiredSemVer(),
+		)
+		serverDetail := newServerDetail("example/semver", "")
+		serverDetail.VersionDetail.Version = "2.0.0-rc.1"
+		require.NoError(t, registry.Publish(serverDetail))
+
+		serverDetail = newServerDetail("example/semver", "")
+		serverDetail.VersionDetail.Version = "v2"
+		require.ErrorIs(t, registry.Publish(serverDetail), database.ErrValidation)
+	})
+
+	t.Run("maximum packages is configurable", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(
+			database.NewMemoryDB(map[string]*model.Server{}), service.WithMaxPackages(1),
+		)
+		serverDetail := withPackages("example/max-packages", "npm")
+		require.NoError(t, registry.Publish(serverDetail))
+
+		_, err := registry.AppendPackages(context.Background(), serverDetail.ID, withPackages("", "pypi").Packages)
+		require.ErrorIs(t, err, database.ErrTooManyPackages)
+		require.ErrorIs(t, err, database.ErrValidation)
+
+		require.ErrorIs(t, registry.Publish(withPackages("example/two-packages", "npm", "pypi")), database.ErrTooManyPackages)
+	})
+
+	t.Run("concurrent appends stay within the maximum packages", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(
+			database.NewMemoryDB(map[string]*model.Server{}), service.WithMaxPackages(2),
+		)
+		serverDetail := withPackages("example/concurrent-packages", "npm")
+		require.NoError(t, registry.Publish(serverDetail))
+
+		// Each append passes validation on its own, but only one of them fits
+		registryNames := []string{"pypi", "docker", "nuget", "cargo", "maven"}
+		var wg sync.WaitGroup
+		errs := make([]error, len(registryNames))
+		for i, registryName := range registryNames {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, errs[i] = registry.AppendPackages(context.Background(), serverDetail.ID, withPackages("", registryName).Packages)
+			}()
+		}
+		wg.Wait()
+
+		appended := 0
+		for _, err := range errs {
+			if err == nil {
+				appended++
+				continue
+			}
+			require.ErrorIs(t, err, database.ErrTooManyPackages)
+			require.ErrorIs(t, err, database.ErrValidation)
+		}
+		assert.Equal(t, 1, appended)
+
+		stored, err := registry.GetByID(serverDetail.ID)
+		require.NoError(t, err)
+		assert.Len(t, stored.Packages, 2)
+	})
+
+	t.Run("unknown dependencies are validation failures", func(t *testing.T) {
+		registry := service.NewRegistryServiceWithDB(database.NewMemoryDB(map[string]*model.Server{}))
+		serverDetail := newServerDetail("example/unknown-dependency", "")
+		serverDetail.Dependencies = []string{uuid.New().String()}
+
+		err := registry.Publish(serverDetail)
+		var validationErrs service.ValidationErrors
+		require.ErrorAs(t, err, &validationErrs)
+		assert.Equal(t, "dependencies", validationErrs[0].Field)
+		assert.ErrorIs(t, err, database.ErrInvalidDependency)
+	})
+}
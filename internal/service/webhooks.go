@@ -0,0 +1,179 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/netip"
+	"slices"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/events"
+	"github.com/modelcontextprotocol/registry/internal/model"
+)
+
+const (
+	// webhookAttempts is the number of times delivery to a webhook is attempted
+	webhookAttempts = 3
+	// defaultWebhookRetryDelay is the delay before the first retry; it doubles with every further retry
+	defaultWebhookRetryDelay = time.Second
+	// webhookTimeout bounds a single delivery attempt
+	webhookTimeout = 10 * time.Second
+)
+
+// HostResolver returns the IP addresses of a host name
+type HostResolver func(ctx context.Context, host string) ([]netip.Addr, error)
+
+// lookupHost resolves host names with the system resolver
+func lookupHost(ctx context.Context, host string) ([]netip.Addr, error) {
+	return net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+}
+
+// checkWebhookHost checks that every address of a webhook host is public
+func checkWebhookHost(ctx context.Context, resolve HostResolver, host string) error {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if !isPublicAddress(addr) {
+			return ErrPrivateAddress
+		}
+		return nil
+	}
+
+	addrs, err := resolve(ctx, host)
+	if err != nil {
+		return fmt.Errorf("webhook host cannot be resolved: %w", err)
+	}
+	if len(addrs) == 0 {
+		return errors.New("webhook host cannot be resolved")
+	}
+	for _, addr := range addrs {
+		if !isPublicAddress(addr) {
+			return ErrPrivateAddress
+		}
+	}
+	return nil
+}
+
+// NewWebhookClient creates the HTTP client for delivering webhooks. It refuses to connect to addresses that are
+// not public, which also covers hosts that resolved to a public address when they were subscribed but no longer do.
+func NewWebhookClient() *http.Client {
+	return &http.Client{Transport: newPublicTransport()}
+}
+
+// subscriptionEvents maps the registry events to the subscription events they are delivered as
+var subscriptionEvents = map[events.EventType]model.SubscriptionEvent{
+	events.EventPublished: model.SubscriptionEventVersionPublished,
+	events.EventUpdated:   model.SubscriptionEventVersionUpdated,
+}
+
+// WebhookDispatcher delivers the events broadcast on a hub to the webhooks of the servers' subscriptions
+type WebhookDispatcher struct {
+	db         database.Database
+	client     *http.Client
+	retryDelay time.Duration
+}
+
+// NewWebhookDispatcher creates a dispatcher that looks up subscriptions in db and delivers with client,
+// usually NewWebhookClient
+func NewWebhookDispatcher(db database.Database, client *http.Client) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		db:         db,
+		client:     client,
+		retryDelay: defaultWebhookRetryDelay,
+	}
+}
+
+// Start subscribes to the hub and delivers its events in the background until the hub is closed.
+// Each delivery runs on its own goroutine so a slow webhook does not hold up the others.
+func (d *WebhookDispatcher) Start(hub *events.Hub) {
+	ch, unsubscribe := hub.Subscribe()
+	go func() {
+		defer unsubscribe()
+		for event := range ch {
+			go d.dispatch(event)
+		}
+	}()
+}
+
+// dispatch delivers an event to all subscriptions of the server that opted in to it
+func (d *WebhookDispatcher) dispatch(event events.Event) {
+	subscriptionEvent, ok := subscriptionEvents[event.Type]
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	subscriptions, err := d.db.ListSubscriptions(ctx, event.Name)
+	if err != nil {
+		log.Printf("webhooks: Failed to list subscriptions of %s: %v", event.Name, err)
+		return
+	}
+
+	for _, subscription := range subscriptions {
+		if !slices.Contains(subscription.Events, subscriptionEvent) {
+			continue
+		}
+		go d.deliver(subscription, model.WebhookPayload{
+			SubscriptionID: subscription.ID,
+			Event:          subscriptionEvent,
+			ServerID:       event.ServerID,
+			Name:           event.Name,
+			Timestamp:      event.Timestamp,
+		})
+	}
+}
+
+// deliver posts the payload to the webhook of the subscription, retrying failed attempts with backoff
+func (d *WebhookDispatcher) deliver(subscription *model.Subscription, payload model.WebhookPayload) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("webhooks: Failed to encode payload for subscription %s: %v", subscription.ID, err)
+		return
+	}
+
+	delay := d.retryDelay
+	for attempt := 1; ; attempt++ {
+		retry, err := d.post(subscription.WebhookURL, payload.Event, body)
+		if err == nil {
+			return
+		}
+		if !retry || attempt == webhookAttempts {
+			log.Printf("webhooks: Failed to deliver %s to subscription %s: %v", payload.Event, subscription.ID, err)
+			return
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// post sends a single delivery attempt and reports whether a failed attempt is worth retrying.
+// Server errors and network failures are retried; other error responses are not.
+func (d *WebhookDispatcher) post(webhookURL string, event model.SubscriptionEvent, body []byte) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Registry-Event", string(event))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode >= http.StatusInternalServerError, fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+	return false, nil
+}
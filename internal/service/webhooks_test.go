@@ -0,0 +1,82 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookHosts(t *testing.T) {
+	hosts := map[string][]netip.Addr{
+		"hooks.example.com":    {netip.MustParseAddr("93.184.215.14")},
+		"internal.example.com": {netip.MustParseAddr("10.1.2.3")},
+		"metadata.example.com": {netip.MustParseAddr("169.254.169.254")},
+		"mixed.example.com":    {netip.MustParseAddr("93.184.215.14"), netip.MustParseAddr("::1")},
+		"mapped.example.com":   {netip.MustParseAddr("::ffff:127.0.0.1")},
+	}
+	resolve := func(_ context.Context, host string) ([]netip.Addr, error) {
+		if addrs, ok := hosts[host]; ok {
+			return addrs, nil
+		}
+		return nil, errors.New("no such host")
+	}
+	registry := service.NewRegistryServiceWithDB(
+		database.NewMemoryDB(map[string]*model.Server{}), service.WithWebhookResolver(resolve),
+	)
+	id := publishRepoServer(t, registry, "example/server", "1.0.0")
+
+	subscribe := func(webhookURL string) error {
+		_, err := registry.SaveSubscription(context.Background(), &model.Subscription{
+			ServerID:     id,
+			GitHubUserID: "1",
+			WebhookURL:   webhookURL,
+			Events:       []model.SubscriptionEvent{model.SubscriptionEventVersionUpdated},
+		})
+		return err
+	}
+
+	require.NoError(t, subscribe("https://hooks.example.com/registry"))
+
+	for _, webhookURL := range []string{
+		"http://hooks.example.com/registry",
+		"https://internal.example.com/registry",
+		"https://metadata.example.com/latest",
+		"https://mixed.example.com/registry",
+		"https://mapped.example.com/registry",
+		"https://unknown.example.com/registry",
+		"https://127.0.0.1/registry",
+		"https://192.168.1.1/registry",
+		"https://[::1]:8443/registry",
+		"https://0.0.0.0/registry",
+	} {
+		assert.ErrorIs(t, subscribe(webhookURL), database.ErrInvalidWebhook, webhookURL)
+	}
+}
+
+func TestWebhookClient(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL, nil)
+	require.NoError(t, err)
+
+	// The test server listens on a loopback address, which webhooks must not reach
+	resp, err := service.NewWebhookClient().Do(req)
+	if resp != nil {
+		resp.Body.Close()
+	}
+	require.ErrorContains(t, err, "not publicly routable")
+	assert.False(t, called)
+}
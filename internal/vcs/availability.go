@@ -0,0 +1,35 @@
+package vcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// PackageAvailable reports whether the registry of a package still serves the package version.
+// npm, PyPI and Docker Hub are supported; other registries return ErrUnsupportedRegistry.
+func PackageAvailable(ctx context.Context, registryName, name, version string) (bool, error) {
+	switch strings.ToLower(registryName) {
+	case "npm":
+		_, err := fetchNPMVersion(ctx, name, version)
+		if errors.Is(err, ErrPackageNotFound) {
+			return false, nil
+		}
+		return err == nil, err
+	case "pypi":
+		versions, err := FetchPyPIVersions(ctx, name)
+		if errors.Is(err, ErrPackageNotFound) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return slices.Contains(versions, version), nil
+	case "docker":
+		return DockerHubTagExists(ctx, name, version)
+	default:
+		return false, fmt.Errorf("%w: %s", ErrUnsupportedRegistry, registryName)
+	}
+}
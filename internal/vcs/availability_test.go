@@ -0,0 +1,106 @@
+package vcs_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/vcs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageAvailable(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("npm version", func(t *testing.T) {
+		stubTransport(t, func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "registry.npmjs.org", req.URL.Host)
+			if req.URL.EscapedPath() == "/@example%2Fserver/1.0.0" {
+				return jsonResponse(http.StatusOK, `{"name": "@example/server"}`), nil
+			}
+			return jsonResponse(http.StatusNotFound, `"version not found"`), nil
+		})
+
+		available, err := vcs.PackageAvailable(ctx, "npm", "@example/server", "1.0.0")
+		require.NoError(t, err)
+		assert.True(t, available)
+
+		available, err = vcs.PackageAvailable(ctx, "npm", "@example/server", "9.9.9")
+		require.NoError(t, err)
+		assert.False(t, available)
+	})
+
+	t.Run("pypi project is looked up by its normalized name", func(t *testing.T) {
+		stubTransport(t, func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "pypi.org", req.URL.Host)
+			assert.Equal(t, "/simple/example-mcp-server/", req.URL.Path)
+			assert.Equal(t, "application/vnd.pypi.simple.v1+json", req.Header.Get("Accept"))
+			return jsonResponse(http.StatusOK, `{"name": "example-mcp-server", "versions": ["0.1.0", "0.2.0"]}`), nil
+		})
+
+		available, err := vcs.PackageAvailable(ctx, "pypi", "Example_MCP.Server", "0.2.0")
+		require.NoError(t, err)
+		assert.True(t, available)
+
+		available, err = vcs.PackageAvailable(ctx, "pypi", "Example_MCP.Server", "1.0.0")
+		require.NoError(t, err)
+		assert.False(t, available)
+	})
+
+	t.Run("unknown pypi project", func(t *testing.T) {
+		stubTransport(t, func(_ *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusNotFound, `{}`), nil
+		})
+
+		available, err := vcs.PackageAvailable(ctx, "pypi", "missing", "1.0.0")
+		require.NoError(t, err)
+		assert.False(t, available)
+	})
+
+	t.Run("docker hub tags", func(t *testing.T) {
+		var paths []string
+		stubTransport(t, func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "hub.docker.com", req.URL.Host)
+			paths = append(paths, req.URL.Path)
+			if req.URL.Path == "/v2/namespaces/library/repositories/postgres/tags/latest" {
+				return jsonResponse(http.StatusOK, `{"name": "latest"}`), nil
+			}
+			return jsonResponse(http.StatusNotFound, `{}`), nil
+		})
+
+		available, err := vcs.PackageAvailable(ctx, "docker", "postgres", "")
+		require.NoError(t, err)
+		assert.True(t, available)
+
+		available, err = vcs.PackageAvailable(ctx, "docker", "docker.io/example/server", "1.0.0")
+		require.NoError(t, err)
+		assert.False(t, available)
+		assert.Equal(t, []string{
+			"/v2/namespaces/library/repositories/postgres/tags/latest",
+			"/v2/namespaces/example/repositories/server/tags/1.0.0",
+		}, paths)
+	})
+
+	t.Run("unsupported registries are not queried", func(t *testing.T) {
+		stubTransport(t, func(req *http.Request) (*http.Response, error) {
+			t.Errorf("unexpected request to %s", req.URL)
+			return jsonResponse(http.StatusOK, `{}`), nil
+		})
+
+		_, err := vcs.PackageAvailable(ctx, "docker", "ghcr.io/example/server", "1.0.0")
+		assert.ErrorIs(t, err, vcs.ErrUnsupportedRegistry)
+
+		_, err = vcs.PackageAvailable(ctx, "cargo", "example", "1.0.0")
+		assert.ErrorIs(t, err, vcs.ErrUnsupportedRegistry)
+	})
+
+	t.Run("registry errors are returned", func(t *testing.T) {
+		stubTransport(t, func(_ *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusServiceUnavailable, `{}`), nil
+		})
+
+		_, err := vcs.PackageAvailable(ctx, "docker", "example/server", "1.0.0")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,69 @@
+package vcs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by CircuitBreaker.Do while the breaker rejects calls
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitBreaker stops calling an upstream API after repeated failures, so a struggling or rate-limiting
+// API is not hammered with requests that would fail anyway.
+//
+// After threshold consecutive failures the breaker opens and rejects calls for the cooldown. Once the cooldown
+// has passed, calls are let through again; the next failure reopens the breaker and a success closes it.
+// A RateLimitError opens the breaker until the rate limit resets.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+// NewCircuitBreaker creates a breaker that opens for cooldown after threshold consecutive failures
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold: max(threshold, 1),
+		cooldown:  cooldown,
+	}
+}
+
+// Do calls fn unless the breaker is open, in which case it returns ErrCircuitOpen without calling it.
+// Errors returned by fn count as failures and are returned unchanged.
+func (b *CircuitBreaker) Do(fn func() error) error {
+	b.mu.Lock()
+	if time.Now().Before(b.openUntil) {
+		b.mu.Unlock()
+		return ErrCircuitOpen
+	}
+	b.mu.Unlock()
+
+	err := fn()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		b.failures = 0
+		return nil
+	}
+	// A caller giving up says nothing about the upstream API
+	if errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	b.failures++
+	var rateLimitErr *RateLimitError
+	switch {
+	case errors.As(err, &rateLimitErr) && rateLimitErr.Reset.After(time.Now()):
+		b.openUntil = rateLimitErr.Reset
+	case errors.As(err, &rateLimitErr), b.failures >= b.threshold:
+		b.openUntil = time.Now().Add(b.cooldown)
+	}
+	return err
+}
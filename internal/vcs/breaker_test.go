@@ -0,0 +1,53 @@
+package vcs_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/vcs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	errUpstream := errors.New("upstream failed")
+	succeed := func() error { return nil }
+	fail := func() error { return errUpstream }
+
+	t.Run("opens after consecutive failures", func(t *testing.T) {
+		breaker := vcs.NewCircuitBreaker(2, time.Hour)
+
+		require.ErrorIs(t, breaker.Do(fail), errUpstream)
+		require.NoError(t, breaker.Do(succeed))
+		require.ErrorIs(t, breaker.Do(fail), errUpstream)
+		require.ErrorIs(t, breaker.Do(fail), errUpstream)
+
+		called := false
+		err := breaker.Do(func() error {
+			called = true
+			return nil
+		})
+		require.ErrorIs(t, err, vcs.ErrCircuitOpen)
+		assert.False(t, called)
+	})
+
+	t.Run("lets calls through after the cooldown", func(t *testing.T) {
+		breaker := vcs.NewCircuitBreaker(1, 10*time.Millisecond)
+		require.ErrorIs(t, breaker.Do(fail), errUpstream)
+		require.ErrorIs(t, breaker.Do(succeed), vcs.ErrCircuitOpen)
+
+		time.Sleep(20 * time.Millisecond)
+		require.NoError(t, breaker.Do(succeed))
+	})
+
+	t.Run("rate limits open the breaker until they reset", func(t *testing.T) {
+		breaker := vcs.NewCircuitBreaker(5, time.Millisecond)
+		rateLimited := func() error { return &vcs.RateLimitError{Reset: time.Now().Add(time.Hour)} }
+
+		var rateLimitErr *vcs.RateLimitError
+		require.ErrorAs(t, breaker.Do(rateLimited), &rateLimitErr)
+		time.Sleep(5 * time.Millisecond)
+		require.ErrorIs(t, breaker.Do(succeed), vcs.ErrCircuitOpen)
+	})
+}
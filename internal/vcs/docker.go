@@ -0,0 +1,66 @@
+package vcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"strings"
+)
+
+// ErrUnsupportedRegistry is returned for packages whose registry cannot be queried
+var ErrUnsupportedRegistry = errors.New("unsupported package registry")
+
+// dockerHubRepository splits an image name into its Docker Hub namespace and repository; official images
+// live in the library namespace. Images hosted on other registries, named after their host, are not supported.
+func dockerHubRepository(image string) (string, string, error) {
+	namespace, repository, found := strings.Cut(image, "/")
+	if !found {
+		return "library", image, nil
+	}
+	if namespace == "docker.io" {
+		return dockerHubRepository(repository)
+	}
+	if strings.ContainsAny(namespace, ".:") || namespace == "localhost" {
+		return "", "", fmt.Errorf("%w: image %s is not hosted on Docker Hub", ErrUnsupportedRegistry, image)
+	}
+	return namespace, repository, nil
+}
+
+// DockerHubTagExists reports whether Docker Hub has an image with the tag; an empty tag means latest
+func DockerHubTagExists(ctx context.Context, image, tag string) (bool, error) {
+	namespace, repository, err := dockerHubRepository(image)
+	if err != nil {
+		return false, err
+	}
+	if tag == "" {
+		tag = "latest"
+	}
+
+	url := "https://hub.docker.com/v2/namespaces/" + neturl.PathEscape(namespace) +
+		"/repositories/" + neturl.PathEscape(repository) + "/tags/" + neturl.PathEscape(tag)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to fetch Docker Hub tag %s:%s: status %d", image, tag, resp.StatusCode)
+	}
+}
@@ -0,0 +1,159 @@
+package vcs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GitHubAPIURL is the base URL of the GitHub REST API
+const GitHubAPIURL = "https://api.github.com"
+
+// MaxGitHubReleases is the most releases FetchGitHubReleases returns, the largest page GitHub serves
+const MaxGitHubReleases = 100
+
+// GitHubRelease represents the parts of a GitHub release we use
+type GitHubRelease struct {
+	TagName     string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	Body        string    `json:"body"`
+	HTMLURL     string    `json:"html_url"`
+	Draft       bool      `json:"draft"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// RateLimitError is returned when the GitHub API refuses a request because the rate limit is exhausted
+type RateLimitError struct {
+	// Reset is when the rate limit resets; it is zero if GitHub did not say
+	Reset time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	if e.Reset.IsZero() {
+		return "GitHub API rate limit exceeded"
+	}
+	return "GitHub API rate limit exceeded until " + e.Reset.Format(time.RFC3339)
+}
+
+// FetchGitHubReleases returns the published releases of a GitHub repository, newest first. At most limit
+// releases are returned, capped at MaxGitHubReleases. Repositories that do not exist have no releases.
+func FetchGitHubReleases(ctx context.Context, owner, repo string, limit int) ([]GitHubRelease, error) {
+	if owner == "" || repo == "" {
+		return nil, fmt.Errorf("repository owner and name are required")
+	}
+	if limit <= 0 || limit > MaxGitHubReleases {
+		limit = MaxGitHubReleases
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s/releases?per_page=%d",
+		GitHubAPIURL, neturl.PathEscape(owner), neturl.PathEscape(repo), limit)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return []GitHubRelease{}, nil
+	case resp.StatusCode == http.StatusTooManyRequests,
+		resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0":
+		return nil, &RateLimitError{Reset: rateLimitReset(resp.Header)}
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("failed to fetch releases of %s/%s: status %d", owner, repo, resp.StatusCode)
+	}
+
+	var releases []GitHubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, fmt.Errorf("failed to decode GitHub releases: %w", err)
+	}
+
+	published := make([]GitHubRelease, 0, len(releases))
+	for _, release := range releases {
+		if !release.Draft {
+			published = append(published, release)
+		}
+	}
+
+	return published, nil
+}
+
+// FetchGitHubStars returns the number of stargazers of a GitHub repository
+func FetchGitHubStars(ctx context.Context, owner, repo string) (int, error) {
+	if owner == "" || repo == "" {
+		return 0, fmt.Errorf("repository owner and name are required")
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s", GitHubAPIURL, neturl.PathEscape(owner), neturl.PathEscape(repo))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests,
+		resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0":
+		return 0, &RateLimitError{Reset: rateLimitReset(resp.Header)}
+	case resp.StatusCode != http.StatusOK:
+		return 0, fmt.Errorf("failed to fetch repository %s/%s: status %d", owner, repo, resp.StatusCode)
+	}
+
+	var repository struct {
+		Stars int `json:"stargazers_count"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&repository); err != nil {
+		return 0, fmt.Errorf("failed to decode GitHub repository: %w", err)
+	}
+
+	return repository.Stars, nil
+}
+
+// ParseGitHubRepoURL returns the owner and name of the repository at a GitHub URL such as
+// https://github.com/owner/repo or https://github.com/owner/repo.git, and false for other URLs
+func ParseGitHubRepoURL(repoURL string) (owner, repo string, ok bool) {
+	path, found := strings.CutPrefix(strings.TrimSpace(repoURL), "https://github.com/")
+	if !found {
+		return "", "", false
+	}
+	parts := strings.Split(strings.TrimSuffix(strings.TrimSuffix(path, "/"), ".git"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// rateLimitReset returns when the GitHub rate limit resets according to the response headers,
+// or the zero time if they do not say
+func rateLimitReset(header http.Header) time.Time {
+	if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil {
+		return time.Now().Add(time.Duration(seconds) * time.Second)
+	}
+	if epoch, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		return time.Unix(epoch, 0)
+	}
+	return time.Time{}
+}
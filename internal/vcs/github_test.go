@@ -0,0 +1,114 @@
+package vcs_test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/vcs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchGitHubReleases(t *testing.T) {
+	t.Run("returns published releases", func(t *testing.T) {
+		stubTransport(t, func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "api.github.com", req.URL.Host)
+			assert.Equal(t, "/repos/example/server/releases", req.URL.Path)
+			assert.Equal(t, "5", req.URL.Query().Get("per_page"))
+			return jsonResponse(http.StatusOK, `[
+				{"tag_name": "v1.1.0", "name": "Next", "draft": true},
+				{"tag_name": "v1.0.0", "name": "First", "body": "Notes", "html_url": "https://github.com/example/server/releases/v1.0.0",
+				 "published_at": "2025-05-17T17:34:22Z"}
+			]`), nil
+		})
+
+		releases, err := vcs.FetchGitHubReleases(context.Background(), "example", "server", 5)
+		require.NoError(t, err)
+		require.Len(t, releases, 1)
+		assert.Equal(t, "v1.0.0", releases[0].TagName)
+		assert.Equal(t, "First", releases[0].Name)
+		assert.Equal(t, "Notes", releases[0].Body)
+		assert.Equal(t, "https://github.com/example/server/releases/v1.0.0", releases[0].HTMLURL)
+		assert.Equal(t, time.Date(2025, 5, 17, 17, 34, 22, 0, time.UTC), releases[0].PublishedAt)
+	})
+
+	t.Run("missing repository has no releases", func(t *testing.T) {
+		stubTransport(t, func(*http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusNotFound, `{"message": "Not Found"}`), nil
+		})
+
+		releases, err := vcs.FetchGitHubReleases(context.Background(), "example", "missing", 10)
+		require.NoError(t, err)
+		assert.Empty(t, releases)
+	})
+
+	t.Run("exhausted rate limit", func(t *testing.T) {
+		reset := time.Now().Add(time.Hour).Truncate(time.Second)
+		stubTransport(t, func(*http.Request) (*http.Response, error) {
+			resp := jsonResponse(http.StatusForbidden, `{"message": "API rate limit exceeded"}`)
+			resp.Header.Set("X-RateLimit-Remaining", "0")
+			resp.Header.Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+			return resp, nil
+		})
+
+		_, err := vcs.FetchGitHubReleases(context.Background(), "example", "server", 10)
+		var rateLimitErr *vcs.RateLimitError
+		require.ErrorAs(t, err, &rateLimitErr)
+		assert.True(t, reset.Equal(rateLimitErr.Reset))
+	})
+}
+
+func TestFetchGitHubStars(t *testing.T) {
+	t.Run("returns the stargazer count", func(t *testing.T) {
+		stubTransport(t, func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "api.github.com", req.URL.Host)
+			assert.Equal(t, "/repos/example/server", req.URL.Path)
+			return jsonResponse(http.StatusOK, `{"full_name": "example/server", "stargazers_count": 42}`), nil
+		})
+
+		stars, err := vcs.FetchGitHubStars(context.Background(), "example", "server")
+		require.NoError(t, err)
+		assert.Equal(t, 42, stars)
+	})
+
+	t.Run("missing repository", func(t *testing.T) {
+		stubTransport(t, func(*http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusNotFound, `{"message": "Not Found"}`), nil
+		})
+
+		_, err := vcs.FetchGitHubStars(context.Background(), "example", "missing")
+		require.Error(t, err)
+	})
+
+	t.Run("exhausted rate limit", func(t *testing.T) {
+		stubTransport(t, func(*http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusTooManyRequests, `{"message": "API rate limit exceeded"}`), nil
+		})
+
+		_, err := vcs.FetchGitHubStars(context.Background(), "example", "server")
+		var rateLimitErr *vcs.RateLimitError
+		require.ErrorAs(t, err, &rateLimitErr)
+	})
+}
+
+func TestParseGitHubRepoURL(t *testing.T) {
+	for repoURL, expected := range map[string][]string{
+		"https://github.com/example/server":      {"example", "server"},
+		"https://github.com/example/server.git":  {"example", "server"},
+		"https://github.com/example/server/":     {"example", "server"},
+		"https://github.com/example":             nil,
+		"https://github.com/example/server/tree": nil,
+		"https://gitlab.com/example/server":      nil,
+	} {
+		owner, repo, ok := vcs.ParseGitHubRepoURL(repoURL)
+		if expected == nil {
+			assert.False(t, ok, repoURL)
+			continue
+		}
+		require.True(t, ok, repoURL)
+		assert.Equal(t, expected, []string{owner, repo}, repoURL)
+	}
+}
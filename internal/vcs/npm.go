@@ -0,0 +1,90 @@
+// Package vcs fetches package metadata from the official package registries and release information from GitHub
+package vcs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+)
+
+// ErrChecksumNotFound is returned when a registry does not publish a checksum for a package version
+var ErrChecksumNotFound = errors.New("checksum not found")
+
+// ErrPackageNotFound is returned when a registry does not know a package version
+var ErrPackageNotFound = errors.New("package not found")
+
+// npmVersionResponse represents the parts of the npm registry version document we use
+type npmVersionResponse struct {
+	Dist struct {
+		Shasum string `json:"shasum"`
+	} `json:"dist"`
+	// Dependencies maps the names of the runtime dependencies to their version ranges
+	Dependencies map[string]string `json:"dependencies"`
+}
+
+// fetchNPMVersion fetches the npm registry document of a package version
+func fetchNPMVersion(ctx context.Context, name, version string) (*npmVersionResponse, error) {
+	if name == "" || version == "" {
+		return nil, fmt.Errorf("package name and version are required")
+	}
+
+	url := "https://registry.npmjs.org/" + neturl.PathEscape(name) + "/" + neturl.PathEscape(version)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: npm package %s@%s", ErrPackageNotFound, name, version)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch npm package %s@%s: status %d", name, version, resp.StatusCode)
+	}
+
+	var body npmVersionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode npm response: %w", err)
+	}
+
+	return &body, nil
+}
+
+// FetchNPMChecksum returns the shasum the npm registry publishes for the tarball of a package version
+func FetchNPMChecksum(ctx context.Context, name, version string) (string, error) {
+	body, err := fetchNPMVersion(ctx, name, version)
+	if errors.Is(err, ErrPackageNotFound) {
+		return "", fmt.Errorf("%w: npm package %s@%s", ErrChecksumNotFound, name, version)
+	}
+	if err != nil {
+		return "", err
+	}
+	if body.Dist.Shasum == "" {
+		return "", fmt.Errorf("%w: npm package %s@%s", ErrChecksumNotFound, name, version)
+	}
+
+	return body.Dist.Shasum, nil
+}
+
+// FetchNPMDependencies returns the runtime dependencies of a package version from its npm manifest,
+// mapping each dependency name to its version range
+func FetchNPMDependencies(ctx context.Context, name, version string) (map[string]string, error) {
+	body, err := fetchNPMVersion(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return body.Dependencies, nil
+}
@@ -0,0 +1,103 @@
+package vcs_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/vcs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// stubTransport replaces the default transport so requests to the registries and GitHub are answered by fn
+func stubTransport(t *testing.T, fn roundTripFunc) {
+	t.Helper()
+	original := http.DefaultTransport
+	http.DefaultTransport = fn
+	t.Cleanup(func() {
+		http.DefaultTransport = original
+	})
+}
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestFetchNPMChecksum(t *testing.T) {
+	t.Run("returns the published shasum", func(t *testing.T) {
+		stubTransport(t, func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "registry.npmjs.org", req.URL.Host)
+			assert.Equal(t, "/@example%2Fserver/1.2.3", req.URL.EscapedPath())
+			return jsonResponse(http.StatusOK, `{"name": "@example/server", "dist": {"shasum": "abc123"}}`), nil
+		})
+
+		checksum, err := vcs.FetchNPMChecksum(context.Background(), "@example/server", "1.2.3")
+		require.NoError(t, err)
+		assert.Equal(t, "abc123", checksum)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		stubTransport(t, func(_ *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusNotFound, `"version not found: 9.9.9"`), nil
+		})
+
+		_, err := vcs.FetchNPMChecksum(context.Background(), "example-server", "9.9.9")
+		assert.ErrorIs(t, err, vcs.ErrChecksumNotFound)
+	})
+
+	t.Run("missing shasum", func(t *testing.T) {
+		stubTransport(t, func(_ *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusOK, `{"dist": {}}`), nil
+		})
+
+		_, err := vcs.FetchNPMChecksum(context.Background(), "example-server", "1.0.0")
+		assert.ErrorIs(t, err, vcs.ErrChecksumNotFound)
+	})
+
+	t.Run("registry error", func(t *testing.T) {
+		stubTransport(t, func(_ *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusInternalServerError, `{}`), nil
+		})
+
+		_, err := vcs.FetchNPMChecksum(context.Background(), "example-server", "1.0.0")
+		require.Error(t, err)
+		assert.False(t, errors.Is(err, vcs.ErrChecksumNotFound))
+	})
+}
+
+func TestFetchNPMDependencies(t *testing.T) {
+	t.Run("returns the runtime dependencies", func(t *testing.T) {
+		stubTransport(t, func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/@example%2Fserver/1.2.3", req.URL.EscapedPath())
+			return jsonResponse(http.StatusOK, `{"name": "@example/server", "dependencies": {"zod": "^3.22.0"},
+				"devDependencies": {"typescript": "^5.0.0"}}`), nil
+		})
+
+		dependencies, err := vcs.FetchNPMDependencies(context.Background(), "@example/server", "1.2.3")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"zod": "^3.22.0"}, dependencies)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		stubTransport(t, func(_ *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusNotFound, `"version not found: 9.9.9"`), nil
+		})
+
+		_, err := vcs.FetchNPMDependencies(context.Background(), "example-server", "9.9.9")
+		assert.ErrorIs(t, err, vcs.ErrPackageNotFound)
+	})
+}
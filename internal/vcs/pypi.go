@@ -0,0 +1,58 @@
+package vcs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"regexp"
+	"strings"
+)
+
+// pypiSeparatorRegex matches the runs of separators PEP 503 collapses when normalizing project names
+var pypiSeparatorRegex = regexp.MustCompile(`[-_.]+`)
+
+// pypiProjectResponse represents the parts of the PyPI simple API project page we use
+type pypiProjectResponse struct {
+	Versions []string `json:"versions"`
+}
+
+// FetchPyPIVersions returns the versions of a project listed by the JSON form of the PyPI simple API.
+// It returns ErrPackageNotFound if PyPI does not know the project.
+func FetchPyPIVersions(ctx context.Context, name string) ([]string, error) {
+	if name == "" {
+		return nil, fmt.Errorf("project name is required")
+	}
+
+	normalized := pypiSeparatorRegex.ReplaceAllString(strings.ToLower(name), "-")
+	url := "https://pypi.org/simple/" + neturl.PathEscape(normalized) + "/"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/vnd.pypi.simple.v1+json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: PyPI project %s", ErrPackageNotFound, name)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch PyPI project %s: status %d", name, resp.StatusCode)
+	}
+
+	var body pypiProjectResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode PyPI response: %w", err)
+	}
+
+	return body.Versions, nil
+}
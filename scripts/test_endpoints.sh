@@ -96,11 +96,11 @@ test_servers() {
   if [[ $status_code == 2* ]]; then
     # Parse and display JSON with jq
     echo "Response Summary:"
-    echo "$http_response" | jq '.servers | length' | xargs echo "Total registries:"
+    echo "$http_response" | jq '.data | length' | xargs echo "Total registries:"
     
     # Display a prettier formatted summary - fixed to use lowercase property name
     echo "servers Names:"
-    echo "$http_response" | jq -r '.servers[].name'
+    echo "$http_response" | jq -r '.data[].name'
     
     # Show the metadata with next cursor if available
     echo -e "\nPagination Metadata:"
@@ -78,13 +78,13 @@ test_search() {
     # Check if we have valid JSON response
     if echo "$http_response" | jq empty 2>/dev/null; then
       # Count results
-      result_count=$(echo "$http_response" | jq '.servers | length' 2>/dev/null || echo "0")
+      result_count=$(echo "$http_response" | jq '.data | length' 2>/dev/null || echo "0")
       echo "Total search results: $result_count"
       
       # Display server names if any results found
       if [[ $result_count -gt 0 ]]; then
         echo "Server Names:"
-        echo "$http_response" | jq -r '.servers[].name' 2>/dev/null || echo "Could not extract server names"
+        echo "$http_response" | jq -r '.data[].name' 2>/dev/null || echo "Could not extract server names"
         
         # Show pagination metadata if available
         echo -e "\nPagination Metadata:"